@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
@@ -11,9 +12,20 @@ import (
 )
 
 func main() {
+	check := flag.Bool("check", false, "valida configuración, base de datos, credenciales y puerto WS, y termina")
+	offline := flag.Bool("offline", false, "con -check, no valida los tokens contra las plataformas")
+	flag.Parse()
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if *check {
+		report := appruntime.Check(ctx, appruntime.CheckOptions{Offline: *offline})
+		report.Print(os.Stdout)
+		stop()
+		os.Exit(report.ExitCode())
+	}
+
 	run, err := appruntime.Start(ctx, appruntime.Options{})
 	if err != nil {
 		log.Fatalf("no se pudo iniciar el runtime: %v", err)
@@ -0,0 +1,63 @@
+// Command replay_events vuelve a pasar los eventos crudos guardados (con
+// RAW_EVENTS_LOG=1) por el parser de notificaciones e imprime el resultado
+// en JSON. Sirve para desarrollar parsers sin estar en vivo.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+	"zhatBot/internal/usecase/notifications"
+)
+
+func main() {
+	dbPath := flag.String("db", defaultDBPath(), "base de datos del bot")
+	platform := flag.String("platform", "", "solo eventos de esta plataforma (twitch, kick)")
+	eventType := flag.String("type", "", "solo eventos de este tipo (p. ej. sub, raid)")
+	since := flag.Duration("since", 0, "solo eventos de este período hacia atrás (p. ej. 24h)")
+	limit := flag.Int("limit", 0, "máximo de eventos a leer (0 = todos)")
+	flag.Parse()
+
+	store, err := sqlitestorage.NewCredentialStore(*dbPath)
+	if err != nil {
+		log.Fatalf("no pude abrir %s: %v", *dbPath, err)
+	}
+	defer store.Close()
+
+	filter := domain.RawEventFilter{
+		Platform:  domain.Platform(strings.ToLower(strings.TrimSpace(*platform))),
+		EventType: strings.TrimSpace(*eventType),
+		Limit:     *limit,
+	}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+
+	result, err := notifications.NewRawEventStore(store, 0).ReplayEvents(context.Background(), filter)
+	if err != nil {
+		log.Fatalf("no pude repetir los eventos: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		log.Fatalf("no pude escribir el resultado: %v", err)
+	}
+	log.Printf("%d eventos: %d notificaciones, %d sin alerta, %d con error",
+		result.Events, len(result.Notifications), result.Skipped, len(result.Failed))
+}
+
+func defaultDBPath() string {
+	if path := strings.TrimSpace(os.Getenv("DATABASE_PATH")); path != "" {
+		return path
+	}
+	return filepath.Join("data", "zhatbot.db")
+}
@@ -0,0 +1,361 @@
+// Command zhatctl administra el bot que está corriendo a través de su API
+// HTTP, para servidores sin la app de escritorio.
+//
+//	zhatctl [-addr :8080] [-json] commands list
+//	zhatctl commands add [-aliases a,b] [-platforms kick] [-permissions moderators] [-cooldown 30s] <nombre> <respuesta...>
+//	zhatctl commands remove <nombre>
+//	zhatctl tts voice [voz]
+//	zhatctl oauth status
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/interface/api/ws"
+	commandsusecase "zhatBot/internal/usecase/commands"
+)
+
+const usage = `uso: zhatctl [-addr dirección] [-json] <comando>
+
+comandos:
+  commands list                     lista los comandos
+  commands add [opciones] <nombre> <respuesta...>
+                                    crea o actualiza un comando personalizado
+  commands remove <nombre>          borra un comando personalizado
+  tts voice [voz]                   muestra las voces o cambia la actual
+  oauth status                      muestra el estado de las credenciales
+`
+
+var errUsage = errors.New("uso inválido")
+
+// invocation es una línea de comandos ya interpretada.
+type invocation struct {
+	addr   string
+	json   bool
+	action string
+
+	name     string
+	mutation commandsusecase.CommandMutationDTO
+	voice    string
+}
+
+func main() {
+	inv, err := parseArgs(os.Args[1:])
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "zhatctl: %v\n\n", err)
+		}
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if err := run(ctx, inv, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "zhatctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func parseArgs(args []string) (invocation, error) {
+	global := flag.NewFlagSet("zhatctl", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	addr := global.String("addr", defaultAddr(), "dirección de la API del bot")
+	asJSON := global.Bool("json", false, "imprime la respuesta en JSON")
+	if err := global.Parse(args); err != nil {
+		return invocation{}, err
+	}
+	inv := invocation{addr: *addr, json: *asJSON}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		return invocation{}, errUsage
+	}
+	inv.action = rest[0] + " " + rest[1]
+	rest = rest[2:]
+
+	switch inv.action {
+	case "commands list", "oauth status":
+		if len(rest) > 0 {
+			return invocation{}, fmt.Errorf("%s no recibe argumentos", inv.action)
+		}
+	case "commands add":
+		mutation, err := parseCommandAdd(rest)
+		if err != nil {
+			return invocation{}, err
+		}
+		inv.name = mutation.Name
+		inv.mutation = mutation
+	case "commands remove":
+		if len(rest) != 1 {
+			return invocation{}, fmt.Errorf("commands remove necesita el nombre del comando")
+		}
+		inv.name = strings.TrimPrefix(strings.TrimSpace(rest[0]), "!")
+		if inv.name == "" {
+			return invocation{}, fmt.Errorf("commands remove necesita el nombre del comando")
+		}
+	case "tts voice":
+		if len(rest) > 1 {
+			return invocation{}, fmt.Errorf("tts voice recibe una sola voz")
+		}
+		if len(rest) == 1 {
+			inv.voice = strings.TrimSpace(rest[0])
+		}
+	default:
+		return invocation{}, fmt.Errorf("comando desconocido %q", inv.action)
+	}
+	return inv, nil
+}
+
+func parseCommandAdd(args []string) (commandsusecase.CommandMutationDTO, error) {
+	fs := flag.NewFlagSet("commands add", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	aliases := fs.String("aliases", "", "alias separados por coma")
+	platforms := fs.String("platforms", "", "plataformas separadas por coma (vacío = todas)")
+	permissions := fs.String("permissions", "", "roles separados por coma (vacío = todos)")
+	cooldown := fs.Duration("cooldown", 0, "cooldown del comando (p. ej. 30s)")
+	userCooldown := fs.Duration("user-cooldown", 0, "cooldown por usuario (p. ej. 2m)")
+	if err := fs.Parse(args); err != nil {
+		return commandsusecase.CommandMutationDTO{}, err
+	}
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		return commandsusecase.CommandMutationDTO{}, fmt.Errorf("commands add necesita nombre y respuesta")
+	}
+	name := strings.TrimPrefix(strings.TrimSpace(rest[0]), "!")
+	response := strings.TrimSpace(strings.Join(rest[1:], " "))
+	if name == "" || response == "" {
+		return commandsusecase.CommandMutationDTO{}, fmt.Errorf("commands add necesita nombre y respuesta")
+	}
+	if *cooldown < 0 || *userCooldown < 0 {
+		return commandsusecase.CommandMutationDTO{}, fmt.Errorf("el cooldown no puede ser negativo")
+	}
+
+	mutation := commandsusecase.CommandMutationDTO{Name: name, Response: &response}
+	// Solo se mandan las opciones presentes: el resto del comando no cambia.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "aliases":
+			list := splitList(*aliases)
+			mutation.Aliases = &list
+		case "platforms":
+			list := splitList(*platforms)
+			mutation.Platforms = &list
+		case "permissions":
+			var roles []domain.CommandAccessRole
+			for _, item := range splitList(*permissions) {
+				roles = append(roles, domain.CommandAccessRole(strings.ToLower(item)))
+			}
+			mutation.Permissions = &roles
+		case "cooldown":
+			seconds := int(*cooldown / time.Second)
+			mutation.CooldownSeconds = &seconds
+		case "user-cooldown":
+			seconds := int(*userCooldown / time.Second)
+			mutation.UserCooldownSeconds = &seconds
+		}
+	})
+	return mutation, nil
+}
+
+func splitList(raw string) []string {
+	out := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func defaultAddr() string {
+	if addr := strings.TrimSpace(os.Getenv("CHAT_WS_ADDR")); addr != "" {
+		return addr
+	}
+	return ":8080"
+}
+
+func run(ctx context.Context, inv invocation, out io.Writer) error {
+	c, err := newClient(inv.addr)
+	if err != nil {
+		return err
+	}
+
+	switch inv.action {
+	case "commands list":
+		var items []commandsusecase.CommandDTO
+		if err := c.do(ctx, http.MethodGet, "/api/commands", nil, &items); err != nil {
+			return err
+		}
+		if inv.json {
+			return writeJSON(out, items)
+		}
+		printCommands(out, items)
+	case "commands add":
+		var saved commandsusecase.CommandDTO
+		if err := c.do(ctx, http.MethodPost, "/api/commands", inv.mutation, &saved); err != nil {
+			return err
+		}
+		if inv.json {
+			return writeJSON(out, saved)
+		}
+		fmt.Fprintf(out, "Comando !%s guardado.\n", saved.Name)
+		for _, warning := range saved.Warnings {
+			fmt.Fprintln(out, warning)
+		}
+	case "commands remove":
+		path := "/api/commands?name=" + url.QueryEscape(inv.name)
+		if err := c.do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+			return err
+		}
+		if !inv.json {
+			fmt.Fprintf(out, "Comando !%s eliminado.\n", inv.name)
+		}
+	case "tts voice":
+		var status ttsStatus
+		if inv.voice == "" {
+			err = c.do(ctx, http.MethodGet, "/api/tts/status", nil, &status)
+		} else {
+			err = c.do(ctx, http.MethodPost, "/api/tts/settings", map[string]string{"voice": inv.voice}, &status)
+		}
+		if err != nil {
+			return err
+		}
+		if inv.json {
+			return writeJSON(out, status)
+		}
+		printVoices(out, status)
+	case "oauth status":
+		var status ws.OAuthStatus
+		if err := c.do(ctx, http.MethodGet, "/api/oauth/status", nil, &status); err != nil {
+			return err
+		}
+		if inv.json {
+			return writeJSON(out, status)
+		}
+		printOAuthStatus(out, status)
+	}
+	return nil
+}
+
+// ttsStatus es la parte de /api/tts/status que usa zhatctl.
+type ttsStatus struct {
+	Enabled    bool   `json:"enabled"`
+	Voice      string `json:"voice"`
+	VoiceLabel string `json:"voice_label,omitempty"`
+	Voices     []struct {
+		Code   string `json:"code"`
+		Label  string `json:"label"`
+		Locale string `json:"locale,omitempty"`
+	} `json:"voices"`
+}
+
+func printCommands(out io.Writer, items []commandsusecase.CommandDTO) {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NOMBRE\tTIPO\tPLATAFORMAS\tESTADO\tRESPUESTA")
+	for _, item := range items {
+		platforms := strings.Join(item.Platforms, ",")
+		if platforms == "" {
+			platforms = "todas"
+		}
+		text := item.Response
+		if text == "" {
+			text = item.Description
+		}
+		fmt.Fprintf(tw, "!%s\t%s\t%s\t%s\t%s\n", item.Name, item.Source, platforms, item.Status, truncate(text, 60))
+	}
+	tw.Flush()
+}
+
+func printVoices(out io.Writer, status ttsStatus) {
+	state := "desactivado"
+	if status.Enabled {
+		state = "activado"
+	}
+	fmt.Fprintf(out, "TTS %s, voz actual: %s\n", state, firstNonEmpty(status.VoiceLabel, status.Voice))
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, voice := range status.Voices {
+		marker := " "
+		if voice.Code == status.Voice {
+			marker = "*"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", marker, voice.Code, voice.Label, voice.Locale)
+	}
+	tw.Flush()
+}
+
+func printOAuthStatus(out io.Writer, status ws.OAuthStatus) {
+	platforms := make([]string, 0, len(status.Credentials))
+	for platform := range status.Credentials {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PLATAFORMA\tROL\tTOKEN\tVENCE\tFALTA")
+	for _, platform := range platforms {
+		roles := make([]string, 0, len(status.Credentials[platform]))
+		for role := range status.Credentials[platform] {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		for _, role := range roles {
+			cred := status.Credentials[platform][role]
+			token := "no"
+			switch {
+			case cred.ReauthRequired != "":
+				token = "reautenticar: " + cred.ReauthRequired
+			case cred.HasAccessToken:
+				token = "sí"
+			}
+			expires := "-"
+			if !cred.ExpiresAt.IsZero() {
+				expires = cred.ExpiresAt.Local().Format("2006-01-02 15:04")
+			}
+			missing := strings.Join(cred.MissingScopes, ",")
+			if missing == "" {
+				missing = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", platform, role, token, expires, missing)
+		}
+	}
+	tw.Flush()
+}
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func truncate(text string, max int) string {
+	runes := []rune(strings.ReplaceAll(text, "\n", " "))
+	if len(runes) <= max {
+		return string(runes)
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
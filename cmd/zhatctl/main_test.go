@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+	"zhatBot/internal/interface/api/ws"
+	commandsusecase "zhatBot/internal/usecase/commands"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Setenv("CHAT_WS_ADDR", "")
+
+	inv, err := parseArgs([]string{"-json", "commands", "list"})
+	if err != nil || inv.action != "commands list" || !inv.json || inv.addr != ":8080" {
+		t.Fatalf("commands list = %+v, %v", inv, err)
+	}
+
+	inv, err = parseArgs([]string{"-addr", "bot:9000", "commands", "add", "-platforms", "kick, twitch", "-cooldown", "30s", "!redes", "Sígueme", "en", "@zero"})
+	if err != nil {
+		t.Fatalf("commands add: %v", err)
+	}
+	m := inv.mutation
+	if inv.addr != "bot:9000" || inv.name != "redes" || *m.Response != "Sígueme en @zero" {
+		t.Fatalf("commands add = %+v", inv)
+	}
+	if !slices.Equal(*m.Platforms, []string{"kick", "twitch"}) || *m.CooldownSeconds != 30 {
+		t.Fatalf("mutation = %+v", m)
+	}
+	// lo que no se pasó no se manda, así no pisa lo guardado
+	if m.Aliases != nil || m.Permissions != nil || m.UserCooldownSeconds != nil {
+		t.Fatalf("unset options were sent: %+v", m)
+	}
+
+	inv, err = parseArgs([]string{"commands", "remove", "!redes"})
+	if err != nil || inv.name != "redes" {
+		t.Fatalf("commands remove = %+v, %v", inv, err)
+	}
+	inv, err = parseArgs([]string{"tts", "voice", "es-MX"})
+	if err != nil || inv.voice != "es-MX" {
+		t.Fatalf("tts voice = %+v, %v", inv, err)
+	}
+}
+
+func TestParseArgsDefaultAddrFromEnv(t *testing.T) {
+	t.Setenv("CHAT_WS_ADDR", "127.0.0.1:7000")
+	inv, err := parseArgs([]string{"oauth", "status"})
+	if err != nil || inv.addr != "127.0.0.1:7000" {
+		t.Fatalf("oauth status = %+v, %v", inv, err)
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{"commands"},
+		{"commands", "list", "extra"},
+		{"commands", "add", "redes"},
+		{"commands", "add", "-cooldown", "-5s", "redes", "hola"},
+		{"commands", "add", "-cooldown", "mucho", "redes", "hola"},
+		{"commands", "remove"},
+		{"commands", "remove", "!"},
+		{"tts", "voice", "a", "b"},
+		{"quotes", "list"},
+		{"-nope", "commands", "list"},
+	}
+	for _, args := range tests {
+		if inv, err := parseArgs(args); err == nil {
+			t.Errorf("parseArgs(%q) = %+v, want an error", args, inv)
+		}
+	}
+	if _, err := parseArgs([]string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("-h = %v, want flag.ErrHelp", err)
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	tests := map[string]string{
+		":8080":                   "http://127.0.0.1:8080",
+		"bot:9000":                "http://bot:9000",
+		"https://bot.example/":    "https://bot.example",
+		" http://127.0.0.1:8080 ": "http://127.0.0.1:8080",
+	}
+	for in, want := range tests {
+		if got, err := apiBaseURL(in); err != nil || got != want {
+			t.Errorf("apiBaseURL(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := apiBaseURL(""); err == nil {
+		t.Fatal("empty address accepted")
+	}
+}
+
+// startBot levanta la API del bot sobre una base temporal, como lo hace el
+// runtime, y devuelve su dirección.
+func startBot(t *testing.T) (string, *sqlitestorage.CredentialStore) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := sqlitestorage.NewCredentialStore(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	mgr, err := commandsusecase.NewCustomCommandManager(ctx, store)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	server := ws.NewServer(ws.Config{
+		Addr:           addr,
+		CredentialRepo: store,
+		CommandManager: mgr,
+		CommandService: commandsusecase.NewService(mgr),
+	})
+	go server.Start(ctx)
+	select {
+	case <-server.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the API did not start")
+	}
+	return addr, store
+}
+
+func runCtl(t *testing.T, args ...string) string {
+	t.Helper()
+	inv, err := parseArgs(args)
+	if err != nil {
+		t.Fatalf("parseArgs(%q): %v", args, err)
+	}
+	var out bytes.Buffer
+	if err := run(context.Background(), inv, &out); err != nil {
+		t.Fatalf("run(%q): %v", args, err)
+	}
+	return out.String()
+}
+
+func TestCommandsEndToEnd(t *testing.T) {
+	ctx := context.Background()
+	addr, store := startBot(t)
+
+	got := runCtl(t, "-addr", addr, "commands", "add", "-platforms", "twitch", "-user-cooldown", "2m", "redes", "Sígueme", "en", "@zero")
+	if got != "Comando !redes guardado.\n" {
+		t.Fatalf("commands add printed %q", got)
+	}
+	saved, err := store.GetCustomCommand(ctx, "redes")
+	if err != nil || saved == nil {
+		t.Fatalf("GetCustomCommand = %+v, %v", saved, err)
+	}
+	if saved.Response != "Sígueme en @zero" || saved.UserCooldown != 2*time.Minute || len(saved.Platforms) != 1 || saved.Platforms[0] != domain.PlatformTwitch {
+		t.Fatalf("stored command = %+v", saved)
+	}
+
+	list := runCtl(t, "-addr", addr, "commands", "list")
+	if !strings.Contains(list, "!redes") || !strings.Contains(list, "Sígueme en @zero") || !strings.Contains(list, "!ping") {
+		t.Fatalf("commands list printed:\n%s", list)
+	}
+
+	if got := runCtl(t, "-addr", addr, "commands", "remove", "redes"); got != "Comando !redes eliminado.\n" {
+		t.Fatalf("commands remove printed %q", got)
+	}
+	if gone, err := store.GetCustomCommand(ctx, "redes"); err != nil || gone != nil {
+		t.Fatalf("command still stored: %+v, %v", gone, err)
+	}
+
+	// los errores de la API llegan con su código
+	inv, _ := parseArgs([]string{"-addr", addr, "commands", "remove", "redes"})
+	var apiErr *apiError
+	if err := run(ctx, inv, &bytes.Buffer{}); !errors.As(err, &apiErr) || apiErr.Status < 400 {
+		t.Fatalf("removing a missing command = %v", err)
+	}
+}
+
+func TestOAuthStatusEndToEnd(t *testing.T) {
+	addr, store := startBot(t)
+	if err := store.Save(context.Background(), &domain.Credential{
+		Platform:    domain.PlatformTwitch,
+		Role:        "bot",
+		AccessToken: "token",
+		Metadata:    map[string]string{"login": "zero"},
+	}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got := runCtl(t, "-addr", addr, "oauth", "status")
+	var row string
+	for _, line := range strings.Split(got, "\n") {
+		if strings.HasPrefix(line, "twitch") && strings.Contains(line, "bot") {
+			row = line
+		}
+	}
+	if !strings.Contains(row, "sí") {
+		t.Fatalf("oauth status printed:\n%s", got)
+	}
+}
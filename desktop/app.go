@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,7 +28,14 @@ import (
 	"zhatBot/internal/domain"
 	"zhatBot/internal/infrastructure/config"
 	commandsusecase "zhatBot/internal/usecase/commands"
+	configprofileusecase "zhatBot/internal/usecase/configprofile"
+	connectionsusecase "zhatBot/internal/usecase/connections"
+	countdownusecase "zhatBot/internal/usecase/countdown"
+	lurkersusecase "zhatBot/internal/usecase/lurkers"
+	notificationsusecase "zhatBot/internal/usecase/notifications"
+	overlaysusecase "zhatBot/internal/usecase/overlays"
 	statususecase "zhatBot/internal/usecase/status"
+	trackersusecase "zhatBot/internal/usecase/trackers"
 	ttsusecase "zhatBot/internal/usecase/tts"
 )
 
@@ -40,6 +48,12 @@ type App struct {
 	busWG           sync.WaitGroup
 	oauthMu         sync.Mutex
 	oauthFlows      map[string]*oauthLoopback
+	// dialogs es nil en producción (usa los diálogos de Wails).
+	dialogs fileDialogs
+	// eventPolicy limita el tamaño de lo que se reenvía del bus al frontend.
+	eventPolicy *eventPolicy
+	// emit es nil en producción (usa EventsEmit de Wails).
+	emit func(ctx context.Context, topic string, data ...any)
 }
 
 const (
@@ -49,7 +63,8 @@ const (
 
 func NewApp() *App {
 	return &App{
-		oauthFlows: make(map[string]*oauthLoopback),
+		oauthFlows:  make(map[string]*oauthLoopback),
+		eventPolicy: newEventPolicy(),
 	}
 }
 
@@ -106,11 +121,36 @@ func (a *App) OnStartup(ctx context.Context) {
 	a.runtime = run
 	a.runtimeCancel = rtCancel
 
-	a.subscribeToTopic(events.TopicChatMessage)
-	a.subscribeToTopic(events.TopicTTSStatus)
-	a.subscribeToTopic(events.TopicTTSSpoken)
-	a.subscribeToTopic(events.TopicTwitchBotConnected)
-	a.subscribeToTopic(events.TopicTwitchBotError)
+	a.subscribeAll(
+		events.TopicChatMessage,
+		events.TopicTTSStatus,
+		events.TopicTTSSpoken,
+		events.TopicTwitchBotConnected,
+		events.TopicTwitchBotError,
+		events.TopicTwitchAccount,
+		events.TopicOAuthReauth,
+		events.TopicChatDelete,
+		events.TopicKickChatStatus,
+		events.TopicCapabilities,
+		events.TopicChatSuppressed,
+		events.TopicNotification,
+		events.TopicReadOnly,
+		events.TopicBotPaused,
+		events.TopicLinkPreview,
+		events.TopicUserNotes,
+		events.TopicFollowBotAlert,
+		events.TopicUserEnriched,
+		events.TopicTwitchRateLimited,
+		events.TopicStreamUnhealthy,
+		events.TopicBotAway,
+		events.TopicCountdownTick,
+		events.TopicCounterUpdate,
+		events.TopicAutoShoutout,
+		events.TopicReturningViewer,
+		events.TopicBotConflict,
+		events.TopicCommandPrefix,
+	)
+	a.emitLegacyRedirects()
 }
 
 func (a *App) OnShutdown(ctx context.Context) {
@@ -138,7 +178,9 @@ func (a *App) OnShutdown(ctx context.Context) {
 	}
 }
 
-func (a *App) subscribeToTopic(topic string) {
+// subscribeAll reenvía al frontend, como eventos de Wails con el mismo nombre,
+// todo lo que llegue al bus por esos tópicos.
+func (a *App) subscribeAll(topics ...string) {
 	if a.runtime == nil {
 		return
 	}
@@ -147,28 +189,50 @@ func (a *App) subscribeToTopic(topic string) {
 		return
 	}
 
-	ch, unsubscribe := bus.Subscribe(topic)
+	ch, unsubscribe := bus.SubscribeMany(topics...)
 	a.busSubs = append(a.busSubs, unsubscribe)
 
 	a.busWG.Add(1)
 	go func() {
 		defer a.busWG.Done()
+		var seqs events.SeqTracker
 		for {
 			select {
 			case <-a.ctx.Done():
 				return
-			case payload, ok := <-ch:
+			case event, ok := <-ch:
 				if !ok {
 					return
 				}
-				if a.ctx != nil {
-					wailsruntime.EventsEmit(a.ctx, topic, payload)
+				// el frontend decide si vuelve a pedir el estado del tópico
+				if missed := seqs.Observe(event); missed > 0 {
+					wailsruntime.EventsEmit(a.ctx, "bus:gap", map[string]any{
+						"topic":  event.Topic,
+						"missed": missed,
+						"seq":    event.Seq,
+					})
 				}
+				a.emitBusEvent(event)
 			}
 		}
 	}()
 }
 
+// emitBusEvent pasa el payload por eventPolicy antes de emitirlo.
+func (a *App) emitBusEvent(event events.Event) {
+	policy := a.eventPolicy
+	payload, size, truncated := policy.apply(event.Topic, event.Payload)
+	if truncated {
+		log.Printf("desktop events: %s truncated: %d bytes > %d (total truncated: %d)",
+			event.Topic, size, policy.maxBytes, policy.Truncated())
+	}
+	if a.emit != nil {
+		a.emit(a.ctx, event.Topic, payload)
+		return
+	}
+	wailsruntime.EventsEmit(a.ctx, event.Topic, payload)
+}
+
 func (a *App) emitHeartbeat(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -230,6 +294,214 @@ func (a *App) DeleteCommand(name string) error {
 	return nil
 }
 
+// Commands_Count devuelve cuántos comandos hay (builtin, custom y total).
+func (a *App) Commands_Count() (commandsusecase.CommandCountDTO, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return commandsusecase.CommandCountDTO{}, fmt.Errorf("commands service unavailable")
+	}
+	return svc.Count(a.ctx)
+}
+
+// Commands_Reload vuelve a leer los comandos personalizados desde la base.
+func (a *App) Commands_Reload() (commandsusecase.CommandCountDTO, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return commandsusecase.CommandCountDTO{}, fmt.Errorf("commands service unavailable")
+	}
+	if err := svc.Reload(a.ctx); err != nil {
+		return commandsusecase.CommandCountDTO{}, err
+	}
+	a.emitCommandsChanged()
+	return svc.Count(a.ctx)
+}
+
+// Commands_GetCooldownFeedback devuelve el modo por defecto de aviso de cooldown.
+func (a *App) Commands_GetCooldownFeedback() (string, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	return svc.DefaultCooldownFeedback(), nil
+}
+
+// Commands_SetCooldownFeedback cambia el modo por defecto (silent, reply-once, whisper).
+func (a *App) Commands_SetCooldownFeedback(mode string) (string, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	result, err := svc.SetDefaultCooldownFeedback(a.ctx, mode)
+	if err != nil {
+		return "", err
+	}
+	a.emitCommandsChanged()
+	return result, nil
+}
+
+// Moderation_GetSpamSettings devuelve la configuración del detector de spam.
+func (a *App) Moderation_GetSpamSettings() (domain.SpamProtectionSettings, error) {
+	if a.runtime == nil || a.runtime.SpamRule() == nil {
+		return domain.SpamProtectionSettings{}, fmt.Errorf("moderation unavailable")
+	}
+	return a.runtime.SpamRule().Settings(), nil
+}
+
+// Moderation_SetSpamSettings guarda y aplica la configuración del detector de spam.
+func (a *App) Moderation_SetSpamSettings(settings domain.SpamProtectionSettings) (domain.SpamProtectionSettings, error) {
+	if a.runtime == nil || a.runtime.SpamRule() == nil {
+		return domain.SpamProtectionSettings{}, fmt.Errorf("moderation unavailable")
+	}
+	return a.runtime.SpamRule().Update(a.ctx, settings)
+}
+
+// Users_GetProfile devuelve el perfil (avatar, verificado) de un usuario del chat.
+func (a *App) Users_GetProfile(platform, userID string) (domain.UserProfile, error) {
+	if a.runtime == nil || a.runtime.Profiles() == nil {
+		return domain.UserProfile{}, fmt.Errorf("profiles unavailable")
+	}
+	return a.runtime.Profiles().Lookup(a.ctx, domain.Platform(strings.ToLower(strings.TrimSpace(platform))), userID)
+}
+
+// Lurkers_List devuelve los lurkers de la sesión de stream actual.
+func (a *App) Lurkers_List() (lurkersusecase.ListDTO, error) {
+	if a.runtime == nil || a.runtime.Lurkers() == nil {
+		return lurkersusecase.ListDTO{}, fmt.Errorf("lurkers unavailable")
+	}
+	return a.runtime.Lurkers().List(a.ctx), nil
+}
+
+func (a *App) Lurkers_GetSettings() (domain.LurkSettings, error) {
+	if a.runtime == nil || a.runtime.Lurkers() == nil {
+		return domain.LurkSettings{}, fmt.Errorf("lurkers unavailable")
+	}
+	return a.runtime.Lurkers().Settings(), nil
+}
+
+// Lurkers_SetSettings guarda las plantillas de !lurk/!unlurk y el auto-unlurk.
+func (a *App) Lurkers_SetSettings(settings domain.LurkSettings) (domain.LurkSettings, error) {
+	if a.runtime == nil || a.runtime.Lurkers() == nil {
+		return domain.LurkSettings{}, fmt.Errorf("lurkers unavailable")
+	}
+	return a.runtime.Lurkers().Update(a.ctx, settings)
+}
+
+// Automations_GetFollowSettings devuelve cómo se anuncian los follows en el chat.
+func (a *App) Automations_GetFollowSettings() (domain.FollowAnnounceSettings, error) {
+	if a.runtime == nil || a.runtime.Follows() == nil {
+		return domain.FollowAnnounceSettings{}, fmt.Errorf("follows unavailable")
+	}
+	return a.runtime.Follows().Settings(), nil
+}
+
+// Automations_SetFollowSettings guarda y aplica la política de anuncios de follows.
+func (a *App) Automations_SetFollowSettings(settings domain.FollowAnnounceSettings) (domain.FollowAnnounceSettings, error) {
+	if a.runtime == nil || a.runtime.Follows() == nil {
+		return domain.FollowAnnounceSettings{}, fmt.Errorf("follows unavailable")
+	}
+	return a.runtime.Follows().Update(a.ctx, settings)
+}
+
+// Commands_GetPermissionReply devuelve si se avisa cuando falta permiso (silent/reply).
+func (a *App) Commands_GetPermissionReply() (string, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	return svc.DefaultPermissionReply(), nil
+}
+
+// Commands_SetPermissionReply cambia el modo global de aviso de permisos.
+func (a *App) Commands_SetPermissionReply(mode string) (string, error) {
+	svc := a.commandService()
+	if svc == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	result, err := svc.SetDefaultPermissionReply(a.ctx, mode)
+	if err != nil {
+		return "", err
+	}
+	a.emitCommandsChanged()
+	return result, nil
+}
+
+// Overlays_GetConfig devuelve la configuración de un overlay (o sus valores por defecto).
+func (a *App) Overlays_GetConfig(name string) (overlaysusecase.ConfigDTO, error) {
+	if a.runtime == nil || a.runtime.OverlayService() == nil {
+		return overlaysusecase.ConfigDTO{}, fmt.Errorf("overlays unavailable")
+	}
+	return a.runtime.OverlayService().Get(a.ctx, name)
+}
+
+// Overlays_SetConfig valida y guarda la configuración; los overlays abiertos la aplican al instante.
+func (a *App) Overlays_SetConfig(name string, config map[string]any) (overlaysusecase.ConfigDTO, error) {
+	if a.runtime == nil || a.runtime.OverlayService() == nil {
+		return overlaysusecase.ConfigDTO{}, fmt.Errorf("overlays unavailable")
+	}
+	return a.runtime.OverlayService().Set(a.ctx, name, config)
+}
+
+// Trackers_List devuelve los contadores de palabras/emotes con su conteo de la sesión.
+// Leaderboard_Top devuelve el ranking de usuarios más activos. scope vacío
+// usa el configurado.
+func (a *App) Leaderboard_Top(scope, by string, limit int) (trackersusecase.LeaderboardDTO, error) {
+	if a.runtime == nil || a.runtime.Leaderboard() == nil {
+		return trackersusecase.LeaderboardDTO{}, fmt.Errorf("leaderboard unavailable")
+	}
+	var parsed domain.LeaderboardScope
+	if strings.TrimSpace(scope) != "" {
+		var err error
+		if parsed, err = domain.ParseLeaderboardScope(scope); err != nil {
+			return trackersusecase.LeaderboardDTO{}, err
+		}
+	}
+	return a.runtime.Leaderboard().Top(a.ctx, parsed, by, limit)
+}
+
+func (a *App) Leaderboard_GetSettings() (domain.LeaderboardSettings, error) {
+	if a.runtime == nil || a.runtime.Leaderboard() == nil {
+		return domain.LeaderboardSettings{}, fmt.Errorf("leaderboard unavailable")
+	}
+	return a.runtime.Leaderboard().Settings(), nil
+}
+
+func (a *App) Leaderboard_SetSettings(settings domain.LeaderboardSettings) (domain.LeaderboardSettings, error) {
+	if a.runtime == nil || a.runtime.Leaderboard() == nil {
+		return domain.LeaderboardSettings{}, fmt.Errorf("leaderboard unavailable")
+	}
+	return a.runtime.Leaderboard().Update(a.ctx, settings)
+}
+
+func (a *App) Trackers_List() ([]trackersusecase.TrackerDTO, error) {
+	if a.runtime == nil || a.runtime.TrackerService() == nil {
+		return nil, fmt.Errorf("trackers unavailable")
+	}
+	return a.runtime.TrackerService().List(), nil
+}
+
+// Trackers_Save crea o actualiza un contador.
+func (a *App) Trackers_Save(input trackersusecase.TrackerMutationDTO) (trackersusecase.TrackerDTO, error) {
+	if a.runtime == nil || a.runtime.TrackerService() == nil {
+		return trackersusecase.TrackerDTO{}, fmt.Errorf("trackers unavailable")
+	}
+	return a.runtime.TrackerService().Upsert(a.ctx, input)
+}
+
+func (a *App) Trackers_Delete(name string) error {
+	if a.runtime == nil || a.runtime.TrackerService() == nil {
+		return fmt.Errorf("trackers unavailable")
+	}
+	return a.runtime.TrackerService().Delete(a.ctx, name)
+}
+
+// Trackers_Reset pone a cero el contador en la sesión actual.
+func (a *App) Trackers_Reset(name string) (trackersusecase.TrackerDTO, error) {
+	if a.runtime == nil || a.runtime.TrackerService() == nil {
+		return trackersusecase.TrackerDTO{}, fmt.Errorf("trackers unavailable")
+	}
+	return a.runtime.TrackerService().Reset(a.ctx, name)
+}
+
 func (a *App) commandService() *commandsusecase.Service {
 	if a.runtime == nil {
 		return nil
@@ -262,8 +534,12 @@ func (a *App) emitCommandsChanged() {
 }
 
 type TTSSettingsUpdate struct {
-	Voice   string `json:"voice"`
-	Enabled *bool  `json:"enabled"`
+	Voice     string                       `json:"voice"`
+	Enabled   *bool                        `json:"enabled"`
+	AutoPause *domain.TTSAutoPauseSettings `json:"auto_pause"`
+	SkipVotes *int                         `json:"skip_votes"`
+	// MaxFetchSeconds es el tiempo máximo para descargar el audio de una lectura.
+	MaxFetchSeconds *int `json:"max_fetch_seconds"`
 }
 
 type NotificationDTO struct {
@@ -274,6 +550,8 @@ type NotificationDTO struct {
 	Amount    float64           `json:"amount,omitempty"`
 	Message   string            `json:"message,omitempty"`
 	Metadata  map[string]string `json:"metadata,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Test      bool              `json:"test,omitempty"`
 	CreatedAt string            `json:"created_at"`
 }
 
@@ -285,6 +563,8 @@ type StreamStatusDTO struct {
 	ViewerCount int    `json:"viewer_count,omitempty"`
 	URL         string `json:"url,omitempty"`
 	StartedAt   string `json:"started_at,omitempty"`
+	FetchedAt   string `json:"fetched_at,omitempty"`
+	Source      string `json:"source,omitempty"`
 }
 
 type NotificationCreateDTO struct {
@@ -306,6 +586,12 @@ type OAuthCredentialStatusDTO struct {
 	HasRefreshToken bool   `json:"has_refresh_token"`
 	UpdatedAt       string `json:"updated_at,omitempty"`
 	ExpiresAt       string `json:"expires_at,omitempty"`
+	// MissingScopes son los permisos que pide la app y el token no tiene.
+	ScopesKnown   bool     `json:"scopes_known"`
+	Scopes        []string `json:"scopes,omitempty"`
+	MissingScopes []string `json:"missing_scopes,omitempty"`
+	// ReauthRequired es el motivo si hay que volver a iniciar sesión.
+	ReauthRequired string `json:"reauth_required,omitempty"`
 }
 
 type OAuthStatusDTO struct {
@@ -349,6 +635,47 @@ func (a *App) TTS_Enqueue(text, voice, lang string, rate, volume float64) (strin
 	return service.Enqueue(a.ctx, req)
 }
 
+// TTS_Voices devuelve las voces agrupadas por idioma y motor.
+func (a *App) TTS_Voices() ([]ttsusecase.VoiceGroup, error) {
+	service := a.ttsService()
+	if service == nil {
+		return nil, fmt.Errorf("tts service unavailable")
+	}
+	return ttsusecase.GroupVoices(service.ListVoices()), nil
+}
+
+// TTS_PreviewVoice reproduce una frase de muestra con la voz sin guardarla.
+func (a *App) TTS_PreviewVoice(code string) (string, error) {
+	service := a.ttsService()
+	if service == nil {
+		return "", fmt.Errorf("tts service unavailable")
+	}
+	return service.PreviewVoice(a.ctx, code)
+}
+
+// TTS_GetAudio devuelve en base64 el mp3 de una lectura reciente. tts:spoken
+// llega sin el audio; trae audio_id para pedirlo acá.
+func (a *App) TTS_GetAudio(id string) (string, error) {
+	runner := a.ttsRunner()
+	if runner == nil {
+		return "", fmt.Errorf("tts runner unavailable")
+	}
+	audio, ok := runner.Audio(strings.TrimSpace(id))
+	if !ok {
+		return "", fmt.Errorf("tts audio %q not found", id)
+	}
+	return base64.StdEncoding.EncodeToString(audio), nil
+}
+
+// TTS_History devuelve las últimas lecturas TTS (las más nuevas primero),
+// opcionalmente solo las que pidió user.
+func (a *App) TTS_History(limit int, user string) ([]domain.TTSHistoryEntry, error) {
+	if a.runtime == nil || a.runtime.TTSHistory() == nil {
+		return nil, fmt.Errorf("tts history unavailable")
+	}
+	return a.runtime.TTSHistory().List(a.ctx, domain.TTSHistoryFilter{Limit: limit, User: user})
+}
+
 func (a *App) TTS_StopAll() error {
 	runner := a.ttsRunner()
 	if runner == nil {
@@ -380,6 +707,21 @@ func (a *App) TTS_UpdateSettings(update TTSSettingsUpdate) (ttsusecase.StatusSna
 			return ttsusecase.StatusSnapshot{}, err
 		}
 	}
+	if update.AutoPause != nil {
+		if _, err := service.SetAutoPauseSettings(a.ctx, *update.AutoPause); err != nil {
+			return ttsusecase.StatusSnapshot{}, err
+		}
+	}
+	if update.SkipVotes != nil {
+		if err := service.SetSkipVotesRequired(a.ctx, *update.SkipVotes); err != nil {
+			return ttsusecase.StatusSnapshot{}, err
+		}
+	}
+	if update.MaxFetchSeconds != nil {
+		if err := service.SetMaxFetchSeconds(a.ctx, *update.MaxFetchSeconds); err != nil {
+			return ttsusecase.StatusSnapshot{}, err
+		}
+	}
 	return service.Snapshot(a.ctx), nil
 }
 
@@ -412,50 +754,131 @@ func (a *App) Notifications_List(limit int) ([]NotificationDTO, error) {
 			Amount:    item.Amount,
 			Message:   item.Message,
 			Metadata:  item.Metadata,
+			Text:      a.notificationsService().FormatNotification(item),
+			Test:      item.IsTest(),
 			CreatedAt: created,
 		})
 	}
 	return out, nil
 }
 
+// NotificationCountFilterDTO son los filtros opcionales de Notifications_Count.
+// Since va en RFC3339.
+type NotificationCountFilterDTO struct {
+	Type        string `json:"type"`
+	Platform    string `json:"platform"`
+	Since       string `json:"since"`
+	ExcludeTest bool   `json:"exclude_test"`
+}
+
+// Notifications_Count cuenta las notificaciones guardadas que cumplen el filtro.
+func (a *App) Notifications_Count(filter NotificationCountFilterDTO) (int, error) {
+	counter, ok := a.notificationRepo().(domain.NotificationCounter)
+	if !ok {
+		return 0, fmt.Errorf("notification repository unavailable")
+	}
+	query := domain.NotificationFilter{
+		Platform:    domain.Platform(strings.ToLower(strings.TrimSpace(filter.Platform))),
+		ExcludeTest: filter.ExcludeTest,
+	}
+	if raw := strings.TrimSpace(filter.Type); raw != "" {
+		notificationType, err := domain.ParseNotificationType(raw)
+		if err != nil {
+			return 0, err
+		}
+		query.Type = notificationType
+	}
+	if raw := strings.TrimSpace(filter.Since); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return 0, fmt.Errorf("fecha inválida: %w", err)
+		}
+		query.Since = since
+	}
+	return counter.CountNotifications(a.ctx, query)
+}
+
 func (a *App) Notifications_Create(payload NotificationCreateDTO) (NotificationDTO, error) {
 	repo := a.notificationRepo()
 	if repo == nil {
 		return NotificationDTO{}, fmt.Errorf("notification repository unavailable")
 	}
 
-	notificationType := domain.NotificationType(strings.TrimSpace(payload.Type))
-	if notificationType == "" {
-		return NotificationDTO{}, fmt.Errorf("type is required")
+	record, err := domain.NewNotification(payload.Type, payload.Platform, payload.Username, payload.Amount, payload.Message, payload.Metadata)
+	if err != nil {
+		return NotificationDTO{}, err
+	}
+
+	var saved *domain.Notification
+	if notifier := a.runtime.Notifications(); notifier != nil {
+		saved, err = notifier.Emit(a.ctx, record)
+	} else {
+		saved, err = repo.SaveNotification(a.ctx, record)
+	}
+	if err != nil {
+		return NotificationDTO{}, err
+	}
+	if saved == nil {
+		return NotificationDTO{}, fmt.Errorf("notification not saved")
 	}
 
-	record := &domain.Notification{
-		Type:      notificationType,
-		Platform:  parsePlatform(payload.Platform),
-		Username:  strings.TrimSpace(payload.Username),
-		Amount:    payload.Amount,
-		Message:   strings.TrimSpace(payload.Message),
-		Metadata:  payload.Metadata,
-		CreatedAt: time.Now(),
+	return a.toNotificationDTO(saved), nil
+}
+
+func (a *App) NotificationTemplates_List() ([]notificationsusecase.TemplateDTO, error) {
+	if a.runtime == nil || a.runtime.Notifications() == nil {
+		return nil, fmt.Errorf("notifications unavailable")
 	}
+	return a.runtime.Notifications().Templates(), nil
+}
 
-	if record.Metadata == nil {
-		record.Metadata = make(map[string]string)
+// NotificationTemplates_Save guarda la plantilla de un tipo ({username}, {amount}, {message}).
+func (a *App) NotificationTemplates_Save(notificationType, template string) (notificationsusecase.TemplateDTO, error) {
+	if a.runtime == nil || a.runtime.Notifications() == nil {
+		return notificationsusecase.TemplateDTO{}, fmt.Errorf("notifications unavailable")
 	}
+	return a.runtime.Notifications().SetTemplate(a.ctx, domain.NotificationType(notificationType), template)
+}
 
-	saved, err := repo.SaveNotification(a.ctx, record)
+func (a *App) NotificationTemplates_Reset(notificationType string) (notificationsusecase.TemplateDTO, error) {
+	if a.runtime == nil || a.runtime.Notifications() == nil {
+		return notificationsusecase.TemplateDTO{}, fmt.Errorf("notifications unavailable")
+	}
+	return a.runtime.Notifications().ResetTemplate(a.ctx, domain.NotificationType(notificationType))
+}
+
+// Notifications_Test manda una alerta de prueba (marcada como test) por el
+// pipeline completo.
+func (a *App) Notifications_Test(notificationType string) (NotificationDTO, error) {
+	if a.runtime == nil || a.runtime.Notifications() == nil {
+		return NotificationDTO{}, fmt.Errorf("notifications unavailable")
+	}
+	parsed := domain.NotificationGeneric
+	if strings.TrimSpace(notificationType) != "" {
+		var err error
+		if parsed, err = domain.ParseNotificationType(notificationType); err != nil {
+			return NotificationDTO{}, err
+		}
+	}
+	saved, err := a.runtime.Notifications().Test(a.ctx, parsed, "", "desktop")
 	if err != nil {
 		return NotificationDTO{}, err
 	}
-	if saved == nil {
-		return NotificationDTO{}, fmt.Errorf("notification not saved")
+	return a.toNotificationDTO(saved), nil
+}
+
+func (a *App) notificationsService() *notificationsusecase.Service {
+	if a.runtime == nil {
+		return nil
 	}
+	return a.runtime.Notifications()
+}
 
+func (a *App) toNotificationDTO(saved *domain.Notification) NotificationDTO {
 	created := ""
-	if saved != nil && !saved.CreatedAt.IsZero() {
+	if !saved.CreatedAt.IsZero() {
 		created = saved.CreatedAt.UTC().Format(time.RFC3339)
 	}
-
 	return NotificationDTO{
 		ID:        saved.ID,
 		Type:      string(saved.Type),
@@ -464,8 +887,194 @@ func (a *App) Notifications_Create(payload NotificationCreateDTO) (NotificationD
 		Amount:    saved.Amount,
 		Message:   saved.Message,
 		Metadata:  saved.Metadata,
+		Text:      a.notificationsService().FormatNotification(saved),
+		Test:      saved.IsTest(),
 		CreatedAt: created,
-	}, nil
+	}
+}
+
+// Settings_Reload vuelve a leer la configuración guardada en todos los servicios.
+func (a *App) Settings_Reload() error {
+	if a.runtime == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	if err := a.runtime.ReloadSettings(a.ctx); err != nil {
+		return err
+	}
+	a.emitCommandsChanged()
+	return nil
+}
+
+// Platforms_Status devuelve cuándo se conectó/desconectó por última vez el chat
+// de cada plataforma y el tiempo conectado en esta sesión.
+func (a *App) Platforms_Status() []connectionsusecase.StatusDTO {
+	if a.runtime == nil {
+		return nil
+	}
+	return a.runtime.PlatformConnections()
+}
+
+// Kick_ChatStatus devuelve el estado de la conexión al chat de Kick (nil si
+// Kick no está habilitado).
+func (a *App) Kick_ChatStatus() *events.KickChatStatusDTO {
+	if a.runtime == nil {
+		return nil
+	}
+	status, ok := a.runtime.KickChatStatus()
+	if !ok {
+		return nil
+	}
+	return &status
+}
+
+// Platform_TestSend manda un mensaje de prueba al canal del bot para
+// confirmar que puede escribir en la plataforma.
+func (a *App) Platform_TestSend(platform string) (connectionsusecase.TestSendResult, error) {
+	if a.runtime == nil {
+		return connectionsusecase.TestSendResult{}, fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.TestSend(a.ctx, domain.Platform(strings.ToLower(strings.TrimSpace(platform))))
+}
+
+// Settings_ReadOnly indica si el bot está en modo solo lectura.
+func (a *App) Settings_ReadOnly() bool {
+	if a.runtime == nil {
+		return false
+	}
+	return a.runtime.ReadOnly()
+}
+
+// Settings_SetReadOnly activa o desactiva el modo solo lectura.
+func (a *App) Settings_SetReadOnly(enabled bool) error {
+	if a.runtime == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.SetReadOnly(a.ctx, enabled)
+}
+
+// Bot_Paused indica si los comandos están pausados.
+func (a *App) Bot_Paused() bool {
+	if a.runtime == nil {
+		return false
+	}
+	return a.runtime.BotPaused()
+}
+
+// Bot_SetPaused pausa o reanuda todos los comandos, igual que !bot pause|resume.
+func (a *App) Bot_SetPaused(paused bool) error {
+	if a.runtime == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.SetBotPaused(a.ctx, paused)
+}
+
+// Bot_Prefix devuelve el prefijo de los comandos.
+func (a *App) Bot_Prefix() string {
+	if a.runtime == nil {
+		return domain.DefaultCommandPrefix
+	}
+	return a.runtime.CommandPrefix()
+}
+
+// Bot_SetPrefix cambia el prefijo de los comandos, igual que !prefix.
+func (a *App) Bot_SetPrefix(prefix string) error {
+	if a.runtime == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.SetCommandPrefix(a.ctx, prefix)
+}
+
+// App_SupportBundle guarda el paquete de soporte (sin secretos) en un archivo
+// elegido por el usuario. Devuelve la ruta o "" si se canceló el diálogo.
+func (a *App) App_SupportBundle() (string, error) {
+	if a.runtime == nil {
+		return "", fmt.Errorf("runtime unavailable")
+	}
+	bundle, err := a.runtime.SupportBundle(a.ctx)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path, err := a.fileDialogs().SaveFile(a.ctx, wailsruntime.SaveDialogOptions{
+		Title:           "Guardar paquete de soporte",
+		DefaultFilename: "zhatbot-support-" + time.Now().Format("20060102-150405") + ".json",
+		Filters:         []wailsruntime.FileFilter{filterJSON},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Debug_RecorderSettings devuelve la configuración del registro de eventos.
+func (a *App) Debug_RecorderSettings() (domain.DebugRecorderSettings, error) {
+	if a.runtime == nil {
+		return domain.DebugRecorderSettings{}, fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.DebugRecorderSettings(), nil
+}
+
+func (a *App) Debug_SetRecorder(settings domain.DebugRecorderSettings) error {
+	if a.runtime == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.SetDebugRecorderSettings(a.ctx, settings)
+}
+
+// Debug_ExportBundle guarda un zip con los eventos recientes, el estado y la
+// configuración (sin secretos) para adjuntar a un reporte. Devuelve la ruta o
+// "" si se canceló el diálogo.
+func (a *App) Debug_ExportBundle() (string, error) {
+	if a.runtime == nil {
+		return "", fmt.Errorf("runtime unavailable")
+	}
+	data, err := a.runtime.DebugBundle(a.ctx)
+	if err != nil {
+		return "", err
+	}
+	path, err := a.fileDialogs().SaveFile(a.ctx, wailsruntime.SaveDialogOptions{
+		Title:           "Guardar paquete de depuración",
+		DefaultFilename: "zhatbot-debug-" + time.Now().Format("20060102-150405") + ".zip",
+		Filters:         []wailsruntime.FileFilter{filterZIP},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// App_BusStats devuelve la secuencia y las pérdidas de cada tópico del bus.
+func (a *App) App_BusStats() ([]events.TopicStats, error) {
+	if a.runtime == nil || a.runtime.Bus() == nil {
+		return nil, fmt.Errorf("bus unavailable")
+	}
+	return a.runtime.Bus().Stats(), nil
+}
+
+// Capabilities_List indica qué funciones están disponibles y qué falta para las demás.
+func (a *App) Capabilities_List() ([]events.CapabilityDTO, error) {
+	if a.runtime == nil {
+		return nil, fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.Capabilities(), nil
+}
+
+// Twitch_Account indica si el bot y el streamer son la misma cuenta y qué
+// credencial usa cada función.
+func (a *App) Twitch_Account() (events.TwitchAccountDTO, error) {
+	if a.runtime == nil {
+		return events.TwitchAccountDTO{}, fmt.Errorf("runtime unavailable")
+	}
+	return a.runtime.TwitchAccount(), nil
 }
 
 func (a *App) StreamStatus_List() ([]StreamStatusDTO, error) {
@@ -473,13 +1082,29 @@ func (a *App) StreamStatus_List() ([]StreamStatusDTO, error) {
 	if resolver == nil {
 		return nil, fmt.Errorf("stream status resolver unavailable")
 	}
-	snapshot := resolver.Snapshot(a.ctx)
+	return toStreamStatusDTOs(resolver.Snapshot(a.ctx)), nil
+}
+
+// StreamStatus_Refresh es como StreamStatus_List pero sin usar la caché.
+func (a *App) StreamStatus_Refresh() ([]StreamStatusDTO, error) {
+	resolver := a.streamStatusResolver()
+	if resolver == nil {
+		return nil, fmt.Errorf("stream status resolver unavailable")
+	}
+	return toStreamStatusDTOs(resolver.Refresh(a.ctx)), nil
+}
+
+func toStreamStatusDTOs(snapshot []domain.StreamStatus) []StreamStatusDTO {
 	out := make([]StreamStatusDTO, 0, len(snapshot))
 	for _, entry := range snapshot {
 		started := ""
 		if !entry.StartedAt.IsZero() {
 			started = entry.StartedAt.UTC().Format(time.RFC3339)
 		}
+		fetched := ""
+		if !entry.FetchedAt.IsZero() {
+			fetched = entry.FetchedAt.UTC().Format(time.RFC3339)
+		}
 		out = append(out, StreamStatusDTO{
 			Platform:    string(entry.Platform),
 			IsLive:      entry.IsLive,
@@ -488,9 +1113,11 @@ func (a *App) StreamStatus_List() ([]StreamStatusDTO, error) {
 			ViewerCount: entry.ViewerCount,
 			URL:         entry.URL,
 			StartedAt:   started,
+			FetchedAt:   fetched,
+			Source:      entry.Source,
 		})
 	}
-	return out, nil
+	return out
 }
 
 func (a *App) Category_Search(platform, query string) ([]CategoryOptionDTO, error) {
@@ -542,6 +1169,242 @@ func (a *App) Category_Update(platform, name string) error {
 	return service.Update(a.ctx, plat, name)
 }
 
+func (a *App) Category_GetSyncSettings() (domain.StreamSyncSettings, error) {
+	if a.runtime == nil || a.runtime.StreamSync() == nil {
+		return domain.StreamSyncSettings{}, fmt.Errorf("stream sync unavailable")
+	}
+	return a.runtime.StreamSync().Settings(), nil
+}
+
+// Category_SetSyncSettings activa la copia de título/categoría de Twitch a Kick.
+func (a *App) Category_SetSyncSettings(settings domain.StreamSyncSettings) (domain.StreamSyncSettings, error) {
+	if a.runtime == nil || a.runtime.StreamSync() == nil {
+		return domain.StreamSyncSettings{}, fmt.Errorf("stream sync unavailable")
+	}
+	return a.runtime.StreamSync().Update(a.ctx, settings)
+}
+
+// LinkPreview_GetSettings devuelve si las vistas previas de links están
+// activas y los dominios permitidos.
+func (a *App) LinkPreview_GetSettings() (domain.LinkPreviewSettings, error) {
+	if a.runtime == nil || a.runtime.LinkPreviews() == nil {
+		return domain.LinkPreviewSettings{}, fmt.Errorf("link previews unavailable")
+	}
+	return a.runtime.LinkPreviews().Settings(), nil
+}
+
+func (a *App) LinkPreview_SetSettings(settings domain.LinkPreviewSettings) (domain.LinkPreviewSettings, error) {
+	if a.runtime == nil || a.runtime.LinkPreviews() == nil {
+		return domain.LinkPreviewSettings{}, fmt.Errorf("link previews unavailable")
+	}
+	return a.runtime.LinkPreviews().Update(a.ctx, settings)
+}
+
+// Presence_GetSettings devuelve los mensajes que el bot manda al conectarse
+// a cada chat y al apagarse.
+func (a *App) Presence_GetSettings() (domain.PresenceSettings, error) {
+	if a.runtime == nil || a.runtime.Presence() == nil {
+		return nil, fmt.Errorf("presence messages unavailable")
+	}
+	return a.runtime.Presence().Settings(), nil
+}
+
+func (a *App) Presence_SetSettings(settings domain.PresenceSettings) (domain.PresenceSettings, error) {
+	if a.runtime == nil || a.runtime.Presence() == nil {
+		return nil, fmt.Errorf("presence messages unavailable")
+	}
+	return a.runtime.Presence().Update(a.ctx, settings)
+}
+
+// Users_ListNotes devuelve las notas de los mods sobre un usuario, de la más
+// nueva a la más vieja.
+func (a *App) Users_ListNotes(platform, userID string) ([]*domain.UserNote, error) {
+	if a.runtime == nil || a.runtime.UserNotes() == nil {
+		return nil, fmt.Errorf("user notes unavailable")
+	}
+	return a.runtime.UserNotes().List(a.ctx, domain.Platform(strings.ToLower(strings.TrimSpace(platform))), userID)
+}
+
+func (a *App) Users_AddNote(platform, userID, login, note string) (*domain.UserNote, error) {
+	if a.runtime == nil || a.runtime.UserNotes() == nil {
+		return nil, fmt.Errorf("user notes unavailable")
+	}
+	return a.runtime.UserNotes().Add(a.ctx, domain.UserNote{
+		Platform: domain.Platform(strings.ToLower(strings.TrimSpace(platform))),
+		UserID:   userID,
+		Login:    login,
+		Note:     note,
+		Author:   "dashboard",
+	})
+}
+
+func (a *App) Users_DeleteNote(platform, userID string, id int64) error {
+	if a.runtime == nil || a.runtime.UserNotes() == nil {
+		return fmt.Errorf("user notes unavailable")
+	}
+	return a.runtime.UserNotes().Delete(a.ctx, domain.Platform(strings.ToLower(strings.TrimSpace(platform))), userID, id)
+}
+
+// Countdown_Status devuelve las cuentas regresivas activas.
+func (a *App) Countdown_Status() ([]countdownusecase.Tick, error) {
+	if a.runtime == nil || a.runtime.Countdowns() == nil {
+		return nil, fmt.Errorf("countdown unavailable")
+	}
+	return a.runtime.Countdowns().Status(), nil
+}
+
+func (a *App) Counters_List() ([]domain.Counter, error) {
+	if a.runtime == nil || a.runtime.Counters() == nil {
+		return nil, fmt.Errorf("counters unavailable")
+	}
+	return a.runtime.Counters().List(), nil
+}
+
+// Counters_Add suma delta (negativo para restar) y crea el contador si no existe.
+func (a *App) Counters_Add(name string, delta int) (domain.Counter, error) {
+	if a.runtime == nil || a.runtime.Counters() == nil {
+		return domain.Counter{}, fmt.Errorf("counters unavailable")
+	}
+	return a.runtime.Counters().Add(a.ctx, name, delta)
+}
+
+func (a *App) Counters_Set(name string, value int) (domain.Counter, error) {
+	if a.runtime == nil || a.runtime.Counters() == nil {
+		return domain.Counter{}, fmt.Errorf("counters unavailable")
+	}
+	return a.runtime.Counters().Set(a.ctx, name, value)
+}
+
+func (a *App) Counters_Delete(name string) error {
+	if a.runtime == nil || a.runtime.Counters() == nil {
+		return fmt.Errorf("counters unavailable")
+	}
+	return a.runtime.Counters().Delete(a.ctx, name)
+}
+
+func (a *App) Games_GetSettings() (domain.ChatGamesSettings, error) {
+	if a.runtime == nil || a.runtime.Games() == nil {
+		return domain.ChatGamesSettings{}, fmt.Errorf("games unavailable")
+	}
+	return a.runtime.Games().Settings(), nil
+}
+
+// Games_SetSettings activa o desactiva !roll, !8ball y !coinflip, en general
+// o por canal.
+func (a *App) Games_SetSettings(settings domain.ChatGamesSettings) (domain.ChatGamesSettings, error) {
+	if a.runtime == nil || a.runtime.Games() == nil {
+		return domain.ChatGamesSettings{}, fmt.Errorf("games unavailable")
+	}
+	return a.runtime.Games().Update(a.ctx, settings)
+}
+
+func (a *App) Shoutouts_GetSettings() (domain.AutoShoutoutSettings, error) {
+	if a.runtime == nil || a.runtime.Shoutouts() == nil {
+		return domain.AutoShoutoutSettings{}, fmt.Errorf("shoutouts unavailable")
+	}
+	return a.runtime.Shoutouts().Settings(), nil
+}
+
+// Shoutouts_SetSettings configura el shoutout automático (lista de
+// streamers, VIPs y plantilla).
+func (a *App) Shoutouts_SetSettings(settings domain.AutoShoutoutSettings) (domain.AutoShoutoutSettings, error) {
+	if a.runtime == nil || a.runtime.Shoutouts() == nil {
+		return domain.AutoShoutoutSettings{}, fmt.Errorf("shoutouts unavailable")
+	}
+	return a.runtime.Shoutouts().Update(a.ctx, settings)
+}
+
+func (a *App) Greetings_GetSettings() (domain.GreetingSettings, error) {
+	if a.runtime == nil || a.runtime.Greetings() == nil {
+		return domain.GreetingSettings{}, fmt.Errorf("greetings unavailable")
+	}
+	return a.runtime.Greetings().Settings(), nil
+}
+
+// Greetings_SetSettings configura el saludo a quien vuelve (umbral en días,
+// plantilla y si se saluda en el chat).
+func (a *App) Greetings_SetSettings(settings domain.GreetingSettings) (domain.GreetingSettings, error) {
+	if a.runtime == nil || a.runtime.Greetings() == nil {
+		return domain.GreetingSettings{}, fmt.Errorf("greetings unavailable")
+	}
+	return a.runtime.Greetings().Update(a.ctx, settings)
+}
+
+func (a *App) BotConflicts_GetSettings() (domain.BotConflictSettings, error) {
+	if a.runtime == nil || a.runtime.BotConflicts() == nil {
+		return domain.BotConflictSettings{}, fmt.Errorf("bot conflicts unavailable")
+	}
+	return a.runtime.BotConflicts().Settings(), nil
+}
+
+// BotConflicts_SetSettings configura la detección de otros bots (lista,
+// ventana, modo) y los comandos suprimidos.
+func (a *App) BotConflicts_SetSettings(settings domain.BotConflictSettings) (domain.BotConflictSettings, error) {
+	if a.runtime == nil || a.runtime.BotConflicts() == nil {
+		return domain.BotConflictSettings{}, fmt.Errorf("bot conflicts unavailable")
+	}
+	return a.runtime.BotConflicts().Update(a.ctx, settings)
+}
+
+func (a *App) Quotes_List() ([]*domain.Quote, error) {
+	if a.runtime == nil || a.runtime.Quotes() == nil {
+		return nil, fmt.Errorf("quotes unavailable")
+	}
+	return a.runtime.Quotes().List(a.ctx)
+}
+
+// Quotes_Add guarda una cita desde el dashboard con el número siguiente.
+func (a *App) Quotes_Add(text, addedBy string) (*domain.Quote, error) {
+	if a.runtime == nil || a.runtime.Quotes() == nil {
+		return nil, fmt.Errorf("quotes unavailable")
+	}
+	if strings.TrimSpace(addedBy) == "" {
+		addedBy = "dashboard"
+	}
+	return a.runtime.Quotes().Add(a.ctx, domain.Quote{Text: text, AddedBy: addedBy})
+}
+
+func (a *App) Quotes_Update(number int64, text string) (*domain.Quote, error) {
+	if a.runtime == nil || a.runtime.Quotes() == nil {
+		return nil, fmt.Errorf("quotes unavailable")
+	}
+	return a.runtime.Quotes().Update(a.ctx, number, text)
+}
+
+func (a *App) Quotes_Delete(number int64) error {
+	if a.runtime == nil || a.runtime.Quotes() == nil {
+		return fmt.Errorf("quotes unavailable")
+	}
+	return a.runtime.Quotes().Delete(a.ctx, number)
+}
+
+// Profile_Export devuelve la configuración compartible (sin credenciales)
+// como JSON listo para guardar en un archivo.
+func (a *App) Profile_Export() (string, error) {
+	if a.runtime == nil {
+		return "", fmt.Errorf("profile unavailable")
+	}
+	doc, err := a.runtime.ProfileExport(a.ctx)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Profile_Import aplica las secciones elegidas (todas si sections está
+// vacío). Con overwrite en false se conservan los comandos, contadores y
+// overlays que ya existen.
+func (a *App) Profile_Import(profile string, sections []string, overwrite bool) (configprofileusecase.Report, error) {
+	if a.runtime == nil {
+		return configprofileusecase.Report{}, fmt.Errorf("profile unavailable")
+	}
+	return a.runtime.ProfileImport(a.ctx, []byte(profile), sections, overwrite)
+}
+
 func (a *App) ttsService() *ttsusecase.Service {
 	if a.runtime == nil {
 		return nil
@@ -597,6 +1460,10 @@ func (a *App) OAuth_Status() (OAuthStatusDTO, error) {
 				HasRefreshToken: entry.HasRefreshToken,
 				UpdatedAt:       updated,
 				ExpiresAt:       expires,
+				ScopesKnown:     entry.ScopesKnown,
+				Scopes:          entry.Scopes,
+				MissingScopes:   entry.MissingScopes,
+				ReauthRequired:  entry.ReauthRequired,
 			}
 		}
 	}
@@ -614,6 +1481,53 @@ func (a *App) OAuth_Logout(platform, role string) error {
 	return a.runtime.OAuthLogout(a.ctx, plat, role)
 }
 
+// OAuthRefreshResultDTO es el resultado de refrescar una credencial a mano.
+type OAuthRefreshResultDTO struct {
+	Platform  string `json:"platform"`
+	Role      string `json:"role"`
+	OK        bool   `json:"ok"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// OAuth_Refresh refresca ya el token de platform/role; con platform vacío
+// refresca todos y devuelve el resultado de cada uno. Si se pidió uno solo y
+// falla, devuelve el error del proveedor.
+func (a *App) OAuth_Refresh(platform, role string) ([]OAuthRefreshResultDTO, error) {
+	if a.runtime == nil {
+		return nil, fmt.Errorf("runtime unavailable")
+	}
+	var plat domain.Platform
+	if strings.TrimSpace(platform) != "" {
+		if plat = parsePlatform(platform); plat == "" {
+			return nil, fmt.Errorf("invalid platform")
+		}
+		role = normalizeRole(role)
+	}
+	results, err := a.runtime.OAuthRefresh(a.ctx, plat, role)
+	if err != nil {
+		return nil, err
+	}
+	if plat != "" && len(results) == 1 && results[0].Err != nil {
+		return nil, results[0].Err
+	}
+	out := make([]OAuthRefreshResultDTO, 0, len(results))
+	for _, result := range results {
+		dto := OAuthRefreshResultDTO{
+			Platform: string(result.Platform),
+			Role:     result.Role,
+			OK:       result.Err == nil,
+		}
+		if result.Err != nil {
+			dto.Error = result.Err.Error()
+		} else if !result.ExpiresAt.IsZero() {
+			dto.ExpiresAt = result.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
 func parsePlatform(value string) domain.Platform {
 	switch strings.ToLower(strings.TrimSpace(value)) {
 	case string(domain.PlatformTwitch):
@@ -1268,6 +2182,62 @@ func (a *App) Chat_SendCommand(payload ChatCommandDTO) error {
 	return a.runtime.DispatchMessage(a.ctx, msg)
 }
 
+// emitLegacyRedirects avisa al frontend de cada redirect URI que no apunta a
+// localhost; Config_FixRedirectURIs las corrige.
+func (a *App) emitLegacyRedirects() {
+	if a.runtime == nil || a.runtime.Config() == nil {
+		return
+	}
+	for _, legacy := range a.runtime.Config().LegacyRedirects() {
+		wailsruntime.EventsEmit(a.ctx, "config:legacy-redirect", legacy)
+	}
+}
+
+// Config_FixRedirectURIs reemplaza en config.json las redirect URIs heredadas
+// por las del loopback y devuelve qué URIs agregar en la consola de
+// desarrolladores de cada plataforma.
+func (a *App) Config_FixRedirectURIs() (string, error) {
+	if a.runtime == nil || a.runtime.Config() == nil {
+		return "", fmt.Errorf("config unavailable")
+	}
+	fixed, err := a.runtime.Config().FixRedirectURIs()
+	if err != nil {
+		return "", err
+	}
+	if len(fixed) == 0 {
+		return "Redirect URIs already point to localhost. Nothing to fix.", nil
+	}
+
+	var lines, keys []string
+	for _, entry := range fixed {
+		if entry.FromEnv {
+			envVar := strings.ToUpper(entry.Key)
+			lines = append(lines, fmt.Sprintf("%s comes from the %s environment variable (%s). Change it to %s.",
+				entry.Key, envVar, entry.Value, entry.Suggested))
+			continue
+		}
+		keys = append(keys, entry.Key)
+		lines = append(lines, fmt.Sprintf("%s: add %s as a redirect URL in the %s (and remove %s).",
+			entry.Key, entry.Suggested, developerConsole(entry.Provider), entry.Value))
+	}
+	if len(keys) > 0 && a.ctx != nil {
+		wailsruntime.EventsEmit(a.ctx, "config:updated", map[string]any{
+			"keys": keys,
+		})
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func developerConsole(provider string) string {
+	switch provider {
+	case "twitch":
+		return "Twitch developer console (https://dev.twitch.tv/console/apps)"
+	case "kick":
+		return "Kick developer settings (https://kick.com/settings/developer)"
+	}
+	return provider + " developer console"
+}
+
 func (a *App) Config_SetTwitchSecret(secret string) error {
 	secret = strings.TrimSpace(secret)
 	if secret == "" {
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"zhatBot/internal/infrastructure/config"
+)
+
+// Filtros de archivo compartidos por todos los diálogos.
+var (
+	filterMP3  = wailsruntime.FileFilter{DisplayName: "MP3 (*.mp3)", Pattern: "*.mp3"}
+	filterZIP  = wailsruntime.FileFilter{DisplayName: "ZIP (*.zip)", Pattern: "*.zip"}
+	filterJSON = wailsruntime.FileFilter{DisplayName: "JSON (*.json)", Pattern: "*.json"}
+)
+
+// fileKind describe un tipo de archivo que el frontend puede pedir con
+// Dialog_PickFile / Dialog_SaveFile.
+type fileKind struct {
+	title      string
+	filter     wailsruntime.FileFilter
+	extensions []string
+	maxSize    int64
+	// defaultDir devuelve la carpeta inicial del diálogo ("" = la del sistema).
+	defaultDir func() string
+}
+
+var fileKinds = map[string]fileKind{
+	"sound": {
+		title:      "Elegir sonido",
+		filter:     filterMP3,
+		extensions: []string{".mp3"},
+		maxSize:    10 << 20,
+		defaultDir: dataDir("sounds"),
+	},
+	"backup": {
+		title:      "Elegir respaldo",
+		filter:     filterZIP,
+		extensions: []string{".zip"},
+		maxSize:    200 << 20,
+		defaultDir: dataDir("backups"),
+	},
+	"commands": {
+		title:      "Elegir comandos",
+		filter:     filterJSON,
+		extensions: []string{".json"},
+		maxSize:    5 << 20,
+		defaultDir: configDir,
+	},
+}
+
+// fileDialogs separa los diálogos nativos de la validación.
+type fileDialogs interface {
+	OpenFile(ctx context.Context, opts wailsruntime.OpenDialogOptions) (string, error)
+	SaveFile(ctx context.Context, opts wailsruntime.SaveDialogOptions) (string, error)
+}
+
+type wailsDialogs struct{}
+
+func (wailsDialogs) OpenFile(ctx context.Context, opts wailsruntime.OpenDialogOptions) (string, error) {
+	return wailsruntime.OpenFileDialog(ctx, opts)
+}
+
+func (wailsDialogs) SaveFile(ctx context.Context, opts wailsruntime.SaveDialogOptions) (string, error) {
+	return wailsruntime.SaveFileDialog(ctx, opts)
+}
+
+// PickedFile es el archivo elegido ya validado.
+type PickedFile struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// Dialog_PickFile abre el diálogo nativo para elegir un archivo de kind
+// (sound, backup o commands) y lo valida antes de devolverlo. Si se cancela
+// devuelve un PickedFile vacío.
+func (a *App) Dialog_PickFile(kind string) (PickedFile, error) {
+	spec, err := lookupFileKind(kind)
+	if err != nil {
+		return PickedFile{}, err
+	}
+	path, err := a.fileDialogs().OpenFile(a.ctx, wailsruntime.OpenDialogOptions{
+		Title:            spec.title,
+		DefaultDirectory: spec.initialDir(),
+		Filters:          []wailsruntime.FileFilter{spec.filter},
+	})
+	if err != nil || path == "" {
+		return PickedFile{}, err
+	}
+	return validatePickedFile(spec, path)
+}
+
+// Dialog_SaveFile pide dónde guardar un archivo de kind. Agrega la extensión
+// si falta y devuelve "" si se cancela.
+func (a *App) Dialog_SaveFile(kind, defaultName string) (string, error) {
+	spec, err := lookupFileKind(kind)
+	if err != nil {
+		return "", err
+	}
+	path, err := a.fileDialogs().SaveFile(a.ctx, wailsruntime.SaveDialogOptions{
+		Title:            spec.title,
+		DefaultDirectory: spec.initialDir(),
+		DefaultFilename:  defaultName,
+		Filters:          []wailsruntime.FileFilter{spec.filter},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if !spec.allows(path) {
+		path += spec.extensions[0]
+	}
+	return path, nil
+}
+
+func (a *App) fileDialogs() fileDialogs {
+	if a.dialogs == nil {
+		return wailsDialogs{}
+	}
+	return a.dialogs
+}
+
+func lookupFileKind(kind string) (fileKind, error) {
+	spec, ok := fileKinds[strings.ToLower(strings.TrimSpace(kind))]
+	if !ok {
+		return fileKind{}, fmt.Errorf("tipo de archivo desconocido: %q", kind)
+	}
+	return spec, nil
+}
+
+// validatePickedFile comprueba que el archivo exista, tenga una extensión
+// permitida y no supere el tamaño máximo.
+func validatePickedFile(spec fileKind, path string) (PickedFile, error) {
+	if !spec.allows(path) {
+		return PickedFile{}, fmt.Errorf("el archivo debe ser %s", strings.Join(spec.extensions, ", "))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return PickedFile{}, fmt.Errorf("el archivo no existe: %s", path)
+		}
+		return PickedFile{}, err
+	}
+	if info.IsDir() {
+		return PickedFile{}, fmt.Errorf("%s es una carpeta", path)
+	}
+	if spec.maxSize > 0 && info.Size() > spec.maxSize {
+		return PickedFile{}, fmt.Errorf("el archivo pesa %d MB (máximo %d MB)", info.Size()>>20, spec.maxSize>>20)
+	}
+	return PickedFile{Path: path, Name: filepath.Base(path), Size: info.Size()}, nil
+}
+
+func (k fileKind) allows(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, allowed := range k.extensions {
+		if ext == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// initialDir devuelve defaultDir solo si existe; algunos sistemas fallan al
+// abrir el diálogo en una carpeta inexistente.
+func (k fileKind) initialDir() string {
+	if k.defaultDir == nil {
+		return ""
+	}
+	dir := k.defaultDir()
+	if dir == "" {
+		return ""
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+func dataDir(sub string) func() string {
+	return func() string {
+		dir, err := filepath.Abs(filepath.Join("data", sub))
+		if err != nil {
+			return ""
+		}
+		return dir
+	}
+}
+
+func configDir() string {
+	path := config.ConfigFilePath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Dir(path)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// fakeDialogs devuelve una ruta fija y guarda las opciones recibidas.
+type fakeDialogs struct {
+	path     string
+	err      error
+	openOpts wailsruntime.OpenDialogOptions
+	saveOpts wailsruntime.SaveDialogOptions
+}
+
+func (f *fakeDialogs) OpenFile(_ context.Context, opts wailsruntime.OpenDialogOptions) (string, error) {
+	f.openOpts = opts
+	return f.path, f.err
+}
+
+func (f *fakeDialogs) SaveFile(_ context.Context, opts wailsruntime.SaveDialogOptions) (string, error) {
+	f.saveOpts = opts
+	return f.path, f.err
+}
+
+func writeFile(t *testing.T, name string, size int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidatePickedFile(t *testing.T) {
+	sound := fileKinds["sound"]
+	dir := t.TempDir()
+
+	cases := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "valid", path: writeFile(t, "alerta.mp3", 1024)},
+		{name: "uppercase extension", path: writeFile(t, "ALERTA.MP3", 10)},
+		{name: "wrong extension", path: writeFile(t, "alerta.wav", 10), wantErr: "el archivo debe ser .mp3"},
+		{name: "missing", path: filepath.Join(dir, "no.mp3"), wantErr: "el archivo no existe"},
+		{name: "directory", path: mkdir(t, filepath.Join(dir, "carpeta.mp3")), wantErr: "es una carpeta"},
+		{name: "too large", path: writeFile(t, "grande.mp3", int(sound.maxSize)+1), wantErr: "máximo 10 MB"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			picked, err := validatePickedFile(sound, tc.path)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validatePickedFile: %v", err)
+			}
+			if picked.Path != tc.path || picked.Name != filepath.Base(tc.path) || picked.Size == 0 {
+				t.Fatalf("picked = %+v", picked)
+			}
+		})
+	}
+}
+
+func mkdir(t *testing.T, path string) string {
+	t.Helper()
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDialogPickFile(t *testing.T) {
+	path := writeFile(t, "respaldo.zip", 100)
+	dialogs := &fakeDialogs{path: path}
+	app := &App{ctx: context.Background(), dialogs: dialogs}
+
+	picked, err := app.Dialog_PickFile(" Backup ")
+	if err != nil {
+		t.Fatalf("Dialog_PickFile: %v", err)
+	}
+	if picked.Path != path || picked.Size != 100 {
+		t.Fatalf("picked = %+v", picked)
+	}
+	if len(dialogs.openOpts.Filters) != 1 || dialogs.openOpts.Filters[0] != filterZIP {
+		t.Fatalf("filters = %+v", dialogs.openOpts.Filters)
+	}
+
+	if _, err := app.Dialog_PickFile("video"); err == nil {
+		t.Fatal("unknown kind accepted")
+	}
+
+	// cancelar no es un error
+	dialogs.path = ""
+	if picked, err := app.Dialog_PickFile("sound"); err != nil || picked != (PickedFile{}) {
+		t.Fatalf("cancel = %+v, %v", picked, err)
+	}
+}
+
+func TestDialogSaveFileAddsExtension(t *testing.T) {
+	dialogs := &fakeDialogs{path: filepath.Join(t.TempDir(), "comandos")}
+	app := &App{ctx: context.Background(), dialogs: dialogs}
+
+	got, err := app.Dialog_SaveFile("commands", "comandos.json")
+	if err != nil {
+		t.Fatalf("Dialog_SaveFile: %v", err)
+	}
+	if !strings.HasSuffix(got, "comandos.json") {
+		t.Fatalf("path = %q", got)
+	}
+	if dialogs.saveOpts.DefaultFilename != "comandos.json" {
+		t.Fatalf("DefaultFilename = %q", dialogs.saveOpts.DefaultFilename)
+	}
+
+	dialogs.path = dialogs.path + ".JSON"
+	if got, _ := app.Dialog_SaveFile("commands", ""); got != dialogs.path {
+		t.Fatalf("path with extension changed to %q", got)
+	}
+}
+
+func TestInitialDirOnlyWhenItExists(t *testing.T) {
+	existing := t.TempDir()
+	kind := fileKind{defaultDir: func() string { return existing }}
+	if got := kind.initialDir(); got != existing {
+		t.Fatalf("initialDir = %q", got)
+	}
+	kind.defaultDir = func() string { return filepath.Join(existing, "no-existe") }
+	if got := kind.initialDir(); got != "" {
+		t.Fatalf("initialDir for a missing dir = %q", got)
+	}
+}
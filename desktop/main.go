@@ -19,8 +19,18 @@ import (
 //go:embed all:appassets
 var embedded embed.FS
 
-func init() {
-	f, err := os.OpenFile("zhatbot.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+// logPath es el archivo de log de la app; ZHATBOT_LOG lo cambia.
+func logPath() string {
+	if path := strings.TrimSpace(os.Getenv("ZHATBOT_LOG")); path != "" {
+		return path
+	}
+	return "zhatbot.log"
+}
+
+// setupLog manda el log a logPath. Se llama desde main y no desde init para
+// que los tests del paquete no escriban en el directorio.
+func setupLog() {
+	f, err := os.OpenFile(logPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err == nil {
 		log.SetOutput(f)
 	}
@@ -28,6 +38,7 @@ func init() {
 }
 
 func main() {
+	setupLog()
 	defer func() {
 		if r := recover(); r != nil {
 			log.Printf("PANIC: %v\n%s", r, debug.Stack())
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetupLogUsesConfiguredPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desktop.log")
+	t.Setenv("ZHATBOT_LOG", path)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	setupLog()
+	log.Println("hola")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if text := string(data); !strings.Contains(text, "=== zhatBot starting ===") || !strings.Contains(text, "hola") {
+		t.Fatalf("log = %q", text)
+	}
+}
+
+func TestLogPathDefault(t *testing.T) {
+	t.Setenv("ZHATBOT_LOG", " ")
+	if got := logPath(); got != "zhatbot.log" {
+		t.Fatalf("logPath() = %q, want zhatbot.log", got)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"zhatBot/internal/app/events"
+)
+
+// defaultEventMaxBytes es el tope por evento si DESKTOP_EVENT_MAX_BYTES no
+// está definido. El IPC de Wails serializa todo a JSON en el hilo de la UI, así
+// que un payload de varios MB congela la ventana.
+const defaultEventMaxBytes = 256 << 10
+
+// eventPolicy recorta lo que se reenvía al frontend: quita los campos que se
+// sabe que son grandes (se piden aparte) y reemplaza por un aviso cualquier
+// payload que siga pasando del tope.
+type eventPolicy struct {
+	maxBytes  int
+	truncated atomic.Int64
+}
+
+func newEventPolicy() *eventPolicy {
+	maxBytes := defaultEventMaxBytes
+	if v := strings.TrimSpace(os.Getenv("DESKTOP_EVENT_MAX_BYTES")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			maxBytes = n
+		}
+	}
+	return &eventPolicy{maxBytes: maxBytes}
+}
+
+// truncatedEvent es lo que recibe el frontend en lugar de un payload demasiado
+// grande; con el tópico puede volver a pedir el estado por su binding.
+type truncatedEvent struct {
+	Truncated bool   `json:"truncated"`
+	Topic     string `json:"topic"`
+	Size      int    `json:"size"`
+	MaxBytes  int    `json:"max_bytes"`
+}
+
+// apply devuelve el payload a emitir. truncated es true si se reemplazó por un
+// truncatedEvent y size es el tamaño en JSON del payload ya sin campos grandes.
+func (p *eventPolicy) apply(topic string, payload any) (out any, size int, truncated bool) {
+	payload = stripLargeFields(payload)
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		// Wails fallaría igual al serializarlo; se deja pasar para que lo registre
+		return payload, 0, false
+	}
+	if len(raw) <= p.maxBytes {
+		return payload, len(raw), false
+	}
+	p.truncated.Add(1)
+	return truncatedEvent{
+		Truncated: true,
+		Topic:     topic,
+		Size:      len(raw),
+		MaxBytes:  p.maxBytes,
+	}, len(raw), true
+}
+
+// Truncated cuenta los eventos reemplazados desde que arrancó la app.
+func (p *eventPolicy) Truncated() int64 {
+	return p.truncated.Load()
+}
+
+// stripLargeFields quita los campos grandes conocidos. El audio de TTS se pide
+// con TTS_GetAudio(audio_id).
+func stripLargeFields(payload any) any {
+	switch v := payload.(type) {
+	case events.TTSSpokenDTO:
+		if v.AudioBase64 != "" {
+			if v.AudioID == "" {
+				v.AudioID = v.ID
+			}
+			v.AudioBase64 = ""
+		}
+		return v
+	case *events.TTSSpokenDTO:
+		if v == nil {
+			return payload
+		}
+		return stripLargeFields(*v)
+	}
+	return payload
+}
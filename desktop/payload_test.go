@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/app/events"
+)
+
+type emitted struct {
+	topic string
+	data  any
+}
+
+// newEmitApp arma una App que guarda lo que se emitiría al frontend.
+func newEmitApp(maxBytes int) (*App, *[]emitted) {
+	var out []emitted
+	app := NewApp()
+	app.ctx = context.Background()
+	app.eventPolicy = &eventPolicy{maxBytes: maxBytes}
+	app.emit = func(_ context.Context, topic string, data ...any) {
+		out = append(out, emitted{topic: topic, data: data[0]})
+	}
+	return app, &out
+}
+
+func TestLargePayloadsNeverReachEmitUncut(t *testing.T) {
+	const maxBytes = 1024
+	app, out := newEmitApp(maxBytes)
+
+	app.emitBusEvent(events.Event{Topic: events.TopicChatMessage, Payload: map[string]string{"text": "hola"}})
+	app.emitBusEvent(events.Event{Topic: events.TopicChatMessage, Payload: map[string]string{"text": strings.Repeat("a", 4*maxBytes)}})
+	app.emitBusEvent(events.Event{Topic: "tts:spoken", Payload: events.TTSSpokenDTO{
+		ID:          "tts-1",
+		OK:          true,
+		Text:        "hola",
+		AudioBase64: strings.Repeat("A", 8*maxBytes),
+	}})
+
+	if len(*out) != 3 {
+		t.Fatalf("se emitieron %d eventos, esperaba 3", len(*out))
+	}
+	for _, event := range *out {
+		raw, err := json.Marshal(event.data)
+		if err != nil {
+			t.Fatalf("Marshal(%s): %v", event.topic, err)
+		}
+		if len(raw) > maxBytes {
+			t.Errorf("%s llegó con %d bytes (tope %d)", event.topic, len(raw), maxBytes)
+		}
+	}
+
+	if small, ok := (*out)[0].data.(map[string]string); !ok || small["text"] != "hola" {
+		t.Fatalf("un payload chico se modificó: %#v", (*out)[0].data)
+	}
+	cut, ok := (*out)[1].data.(truncatedEvent)
+	if !ok || !cut.Truncated || cut.Topic != events.TopicChatMessage || cut.MaxBytes != maxBytes || cut.Size <= maxBytes {
+		t.Fatalf("payload grande = %#v", (*out)[1].data)
+	}
+	spoken, ok := (*out)[2].data.(events.TTSSpokenDTO)
+	if !ok || spoken.AudioBase64 != "" || spoken.AudioID != "tts-1" || spoken.Text != "hola" {
+		t.Fatalf("tts:spoken = %#v", (*out)[2].data)
+	}
+	if got := app.eventPolicy.Truncated(); got != 1 {
+		t.Fatalf("Truncated = %d, esperaba 1", got)
+	}
+}
+
+func TestStripLargeFieldsKeepsAudioID(t *testing.T) {
+	policy := &eventPolicy{maxBytes: defaultEventMaxBytes}
+	payload, _, truncated := policy.apply("tts:spoken", &events.TTSSpokenDTO{
+		ID:          "tts-2",
+		AudioID:     "audio-9",
+		AudioBase64: "AAAA",
+	})
+	spoken, ok := payload.(events.TTSSpokenDTO)
+	if truncated || !ok || spoken.AudioBase64 != "" || spoken.AudioID != "audio-9" {
+		t.Fatalf("apply = %#v, %v", payload, truncated)
+	}
+}
+
+func TestEventPolicyMaxBytesFromEnv(t *testing.T) {
+	t.Setenv("DESKTOP_EVENT_MAX_BYTES", "2048")
+	if got := newEventPolicy().maxBytes; got != 2048 {
+		t.Fatalf("maxBytes = %d", got)
+	}
+	t.Setenv("DESKTOP_EVENT_MAX_BYTES", "no")
+	if got := newEventPolicy().maxBytes; got != defaultEventMaxBytes {
+		t.Fatalf("maxBytes con un valor inválido = %d", got)
+	}
+}
@@ -8,6 +8,7 @@ set -e
 cat >/usr/share/nginx/html/config.js <<EOF
 window.__CONFIG__ = {
   WS_URL: "${VITE_CHAT_WS_URL}",
+  WS_TOKEN: "${VITE_CHAT_WS_TOKEN}",
   API_BASE_URL: "${VITE_API_BASE_URL}"
 };
 EOF
@@ -2,6 +2,7 @@ package events
 
 import (
 	"log"
+	"strings"
 	"sync"
 )
 
@@ -14,23 +15,69 @@ const (
 	TopicTTSSpoken          = "tts:spoken"
 	TopicTwitchBotConnected = "twitch:bot:connected"
 	TopicTwitchBotError     = "twitch:bot:error"
+	TopicCapabilities       = "app:capabilities"
+	TopicChatSuppressed     = "chat:suppressed"
+	TopicReadOnly           = "app:readonly"
+	TopicBotPaused          = "app:paused"
+	TopicLinkPreview        = "chat:link-preview"
+	TopicUserNotes          = "user:notes"
+	TopicFollowBotAlert     = "alert:followbot"
+	TopicUserEnriched       = "user:enriched"
+	TopicTwitchRateLimited  = "twitch:ratelimited"
+	TopicStreamUnhealthy    = "stream:unhealthy"
+	TopicBotAway            = "app:away"
+	TopicCountdownTick      = "countdown:tick"
+	TopicCounterUpdate      = "counter:update"
+	TopicAutoShoutout       = "shoutout:auto"
+	TopicReturningViewer    = "chat:returning-viewer"
+	TopicBotConflict        = "bots:conflict"
+	TopicCommandPrefix      = "app:prefix"
+	TopicTwitchAccount      = "twitch:account"
+	TopicOAuthReauth        = "oauth:reauth-required"
+	TopicChatDelete         = "chat:delete"
+	TopicKickChatStatus     = "kick:chat:status"
 
 	defaultBufferSize = 128
+
+	// Wildcard al final de un tópico suscribe a todos los que empiezan con
+	// ese prefijo ("tts:*" recibe tts:status y tts:spoken; "*" recibe todo).
+	Wildcard = "*"
 )
 
+// Filter decide si un payload le llega a una suscripción. Se ejecuta en la
+// goroutine de Publish, así que tiene que ser barato y no bloquear.
+type Filter func(payload any) bool
+
+type subscription struct {
+	ch     chan any
+	filter Filter
+	// withTopic entrega Event (tópico real y secuencia) en lugar del payload;
+	// lo usa SubscribeMany.
+	withTopic bool
+}
+
 type Bus struct {
-	mu        sync.RWMutex
-	subs      map[string]map[int]chan any
+	mu   sync.RWMutex
+	subs map[string]map[int]subscription
+	// prefixes guarda las suscripciones con wildcard, por prefijo.
+	prefixes  map[string]map[int]subscription
 	nextSubID int
 	closed    bool
 
+	// seqMu ordena los Publish: cada tópico numera sus eventos y se entregan
+	// en ese orden.
+	seqMu sync.Mutex
+	seqs  map[string]uint64
+
 	dropMu     sync.Mutex
 	dropCounts map[string]uint64
 }
 
 func NewBus() *Bus {
 	return &Bus{
-		subs:       make(map[string]map[int]chan any),
+		subs:       make(map[string]map[int]subscription),
+		prefixes:   make(map[string]map[int]subscription),
+		seqs:       make(map[string]uint64),
 		dropCounts: make(map[string]uint64),
 	}
 }
@@ -39,54 +86,142 @@ func (b *Bus) Publish(topic string, payload any) {
 	if topic == "" {
 		return
 	}
+	// los envíos no bloquean, así que se hacen con el lock tomado: así
+	// unsubscribe/Close no pueden cerrar un canal en medio de un envío.
 	b.mu.RLock()
+	defer b.mu.RUnlock()
 	if b.closed {
-		b.mu.RUnlock()
 		return
 	}
-	channels := make([]chan any, 0, len(b.subs[topic]))
-	for _, ch := range b.subs[topic] {
-		channels = append(channels, ch)
+
+	b.seqMu.Lock()
+	defer b.seqMu.Unlock()
+	if b.seqs == nil {
+		b.seqs = make(map[string]uint64)
 	}
-	b.mu.RUnlock()
+	b.seqs[topic]++
+	event := Event{Topic: topic, Seq: b.seqs[topic], Payload: payload}
 
-	for _, ch := range channels {
-		select {
-		case ch <- payload:
-		default:
-			b.recordDrop(topic)
+	for _, sub := range b.subs[topic] {
+		b.deliver(sub, event)
+	}
+	for prefix, matched := range b.prefixes {
+		if !strings.HasPrefix(topic, prefix) {
+			continue
+		}
+		for _, sub := range matched {
+			b.deliver(sub, event)
 		}
 	}
 }
 
+func (b *Bus) deliver(sub subscription, event Event) {
+	if sub.filter != nil && !sub.filter(event.Payload) {
+		return
+	}
+	var value any = event.Payload
+	if sub.withTopic {
+		value = event
+	}
+	select {
+	case sub.ch <- value:
+	default:
+		b.recordDrop(event.Topic)
+	}
+}
+
+// Close cierra los canales de todas las suscripciones para que quienes los
+// leen terminen. Después de Close, Publish no hace nada y Subscribe devuelve
+// un canal ya cerrado. Se puede llamar más de una vez.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, index := range []map[string]map[int]subscription{b.subs, b.prefixes} {
+		for key, subs := range index {
+			for _, sub := range subs {
+				close(sub.ch)
+			}
+			delete(index, key)
+		}
+	}
+}
+
+// Subscribe recibe los payloads de topic. Si topic termina en Wildcard recibe
+// los de todos los tópicos con ese prefijo.
 func (b *Bus) Subscribe(topic string) (<-chan any, func()) {
-	ch := make(chan any, defaultBufferSize)
+	return b.SubscribeFiltered(topic, nil)
+}
+
+// SubscribeFiltered es como Subscribe pero solo entrega los payloads para los
+// que filter devuelve true (filter nil = todos). Lo descartado no cuenta como
+// pérdida.
+func (b *Bus) SubscribeFiltered(topic string, filter Filter) (<-chan any, func()) {
+	return b.subscribe(topic, subscription{filter: filter})
+}
+
+func (b *Bus) subscribe(topic string, sub subscription) (<-chan any, func()) {
+	sub.ch = make(chan any, BufferSize(topic))
+
+	prefix, isPattern := TopicPrefix(topic)
 
 	b.mu.Lock()
-	if b.subs == nil {
-		b.subs = make(map[string]map[int]chan any)
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	index := b.subs
+	key := topic
+	if isPattern {
+		if b.prefixes == nil {
+			b.prefixes = make(map[string]map[int]subscription)
+		}
+		index = b.prefixes
+		key = prefix
+	} else if b.subs == nil {
+		b.subs = make(map[string]map[int]subscription)
+		index = b.subs
 	}
-	if b.subs[topic] == nil {
-		b.subs[topic] = make(map[int]chan any)
+	if index[key] == nil {
+		index[key] = make(map[int]subscription)
 	}
 	id := b.nextSubID
 	b.nextSubID++
-	b.subs[topic][id] = ch
+	index[key][id] = sub
 	b.mu.Unlock()
 
 	unsubscribe := func() {
 		b.mu.Lock()
 		defer b.mu.Unlock()
-		if subs, ok := b.subs[topic]; ok {
-			delete(subs, id)
-			if len(subs) == 0 {
-				delete(b.subs, topic)
-			}
+		// si ya no está registrada (unsubscribe repetido o Close) el canal
+		// ya se cerró
+		subs, ok := index[key]
+		if !ok {
+			return
+		}
+		if _, ok := subs[id]; !ok {
+			return
 		}
-		close(ch)
+		delete(subs, id)
+		if len(subs) == 0 {
+			delete(index, key)
+		}
+		close(sub.ch)
 	}
 
-	return ch, unsubscribe
+	return sub.ch, unsubscribe
+}
+
+// TopicPrefix devuelve el prefijo de un patrón con Wildcard y si topic lo es.
+func TopicPrefix(topic string) (string, bool) {
+	if !strings.HasSuffix(topic, Wildcard) {
+		return "", false
+	}
+	return strings.TrimSuffix(topic, Wildcard), true
 }
 
 func (b *Bus) recordDrop(topic string) {
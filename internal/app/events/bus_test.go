@@ -0,0 +1,230 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func receiveAny(t *testing.T, ch <-chan any) any {
+	t.Helper()
+	select {
+	case payload, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return payload
+	case <-time.After(time.Second):
+		t.Fatal("no payload")
+	}
+	return nil
+}
+
+func assertEmpty(t *testing.T, ch <-chan any) {
+	t.Helper()
+	select {
+	case payload, ok := <-ch:
+		if ok {
+			t.Fatalf("unexpected payload %v", payload)
+		}
+		t.Fatal("channel closed")
+	default:
+	}
+}
+
+func TestSubscribeFiltered(t *testing.T) {
+	bus := NewBus()
+	onlyTwitch := func(payload any) bool {
+		msg, ok := payload.(ChatMessageDTO)
+		return ok && msg.Platform == "twitch"
+	}
+	filtered, cancelFiltered := bus.SubscribeFiltered(TopicChatMessage, onlyTwitch)
+	defer cancelFiltered()
+	all, cancelAll := bus.Subscribe(TopicChatMessage)
+	defer cancelAll()
+
+	bus.Publish(TopicChatMessage, ChatMessageDTO{Platform: "kick", Text: "a"})
+	bus.Publish(TopicChatMessage, ChatMessageDTO{Platform: "twitch", Text: "b"})
+
+	if got := receiveAny(t, filtered).(ChatMessageDTO); got.Text != "b" {
+		t.Fatalf("filtered got %+v", got)
+	}
+	assertEmpty(t, filtered)
+
+	// el filtro de una suscripción no afecta a las demás
+	for _, want := range []string{"a", "b"} {
+		if got := receiveAny(t, all).(ChatMessageDTO); got.Text != want {
+			t.Fatalf("unfiltered got %+v, want %q", got, want)
+		}
+	}
+
+	// lo descartado por el filtro no cuenta como pérdida
+	bus.dropMu.Lock()
+	drops := bus.dropCounts[TopicChatMessage]
+	bus.dropMu.Unlock()
+	if drops != 0 {
+		t.Fatalf("drops = %d", drops)
+	}
+}
+
+func TestSubscribeFilteredUnsubscribe(t *testing.T) {
+	bus := NewBus()
+	calls := 0
+	ch, cancel := bus.SubscribeFiltered(TopicNotification, func(any) bool {
+		calls++
+		return true
+	})
+
+	bus.Publish(TopicNotification, 1)
+	receiveAny(t, ch)
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("channel still open after unsubscribe")
+	}
+	bus.Publish(TopicNotification, 2)
+	if calls != 1 {
+		t.Fatalf("filter called %d times, want 1 (not after unsubscribe)", calls)
+	}
+
+	// desuscribirse dos veces o después de Close no entra en pánico
+	cancel()
+	bus.Close()
+	cancel()
+}
+
+func TestWildcardSubscriptions(t *testing.T) {
+	bus := NewBus()
+	tts, cancelTTS := bus.Subscribe("tts:*")
+	defer cancelTTS()
+	everything, cancelAll := bus.Subscribe(Wildcard)
+	defer cancelAll()
+	exact, cancelExact := bus.Subscribe(TopicTTSStatus)
+	defer cancelExact()
+
+	bus.Publish(TopicTTSStatus, "estado")
+	bus.Publish(TopicTTSSpoken, "hablado")
+	bus.Publish(TopicChatMessage, "chat")
+
+	for _, want := range []string{"estado", "hablado"} {
+		if got := receiveAny(t, tts); got != want {
+			t.Fatalf("tts:* got %v, want %v", got, want)
+		}
+	}
+	assertEmpty(t, tts)
+
+	for _, want := range []string{"estado", "hablado", "chat"} {
+		if got := receiveAny(t, everything); got != want {
+			t.Fatalf("* got %v, want %v", got, want)
+		}
+	}
+
+	// las suscripciones exactas siguen igual
+	if got := receiveAny(t, exact); got != "estado" {
+		t.Fatalf("exact got %v", got)
+	}
+	assertEmpty(t, exact)
+}
+
+func TestWildcardIsOnlyASuffix(t *testing.T) {
+	bus := NewBus()
+	ch, cancel := bus.Subscribe("tts")
+	defer cancel()
+	bus.Publish(TopicTTSStatus, "estado")
+	assertEmpty(t, ch)
+
+	if prefix, ok := TopicPrefix("tts:*"); !ok || prefix != "tts:" {
+		t.Fatalf("TopicPrefix(tts:*) = %q, %v", prefix, ok)
+	}
+	if _, ok := TopicPrefix(TopicTTSStatus); ok {
+		t.Fatal("TopicPrefix matched an exact topic")
+	}
+}
+
+func TestWildcardUnsubscribe(t *testing.T) {
+	bus := NewBus()
+	ch, cancel := bus.Subscribe("tts:*")
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("channel still open after unsubscribe")
+	}
+	bus.Publish(TopicTTSStatus, "estado")
+	if got := subscriberCount(bus); got != 0 {
+		t.Fatalf("subscribers = %d", got)
+	}
+}
+
+func TestSubscribeFilteredWildcard(t *testing.T) {
+	bus := NewBus()
+	ch, cancel := bus.SubscribeFiltered("tts:*", func(payload any) bool { return payload != "ignorar" })
+	defer cancel()
+
+	bus.Publish(TopicTTSStatus, "ignorar")
+	bus.Publish(TopicTTSSpoken, "hablado")
+	bus.Publish(TopicChatMessage, "otro tópico")
+
+	if got := receiveAny(t, ch); got != "hablado" {
+		t.Fatalf("got %v", got)
+	}
+	assertEmpty(t, ch)
+}
+
+func TestCloseClosesSubscribers(t *testing.T) {
+	bus := NewBus()
+	exact, _ := bus.Subscribe(TopicChatMessage)
+	pattern, _ := bus.Subscribe("tts:*")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range exact {
+		}
+	}()
+
+	bus.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reader still blocked after Close")
+	}
+	if _, ok := <-pattern; ok {
+		t.Fatal("wildcard channel still open after Close")
+	}
+}
+
+func TestCloseIsSafeToRepeat(t *testing.T) {
+	bus := NewBus()
+	_, unsubscribe := bus.Subscribe(TopicChatMessage)
+
+	bus.Close()
+	bus.Close()
+	// publicar, desuscribirse o suscribirse después de Close no entra en pánico
+	bus.Publish(TopicChatMessage, "tarde")
+	unsubscribe()
+
+	late, cancel := bus.Subscribe(TopicChatMessage)
+	if _, ok := <-late; ok {
+		t.Fatal("Subscribe after Close returned an open channel")
+	}
+	cancel()
+}
+
+func TestCloseWhilePublishing(t *testing.T) {
+	bus := NewBus()
+	for i := 0; i < 4; i++ {
+		ch, _ := bus.Subscribe(TopicChatMessage)
+		go func() {
+			for range ch {
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			bus.Publish(TopicChatMessage, i)
+		}
+	}()
+	bus.Close()
+	<-done
+}
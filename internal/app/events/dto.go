@@ -8,40 +8,156 @@ import (
 
 // ChatMessageDTO describe el payload que se envía al frontend a través del bus/eventos.
 type ChatMessageDTO struct {
+	ID              string `json:"id,omitempty"`
+	PlatformMsgID   string `json:"platform_message_id,omitempty"`
 	Platform        string `json:"platform"`
 	ChannelID       string `json:"channel_id"`
 	UserID          string `json:"user_id"`
 	Username        string `json:"username"`
+	Login           string `json:"login,omitempty"`
+	DisplayName     string `json:"display_name,omitempty"`
 	Text            string `json:"text"`
+	RawText         string `json:"raw_text,omitempty"`
+	Truncated       bool   `json:"truncated,omitempty"`
 	IsPrivate       bool   `json:"is_private"`
 	IsPlatformOwner bool   `json:"is_platform_owner"`
 	IsPlatformAdmin bool   `json:"is_platform_admin"`
 	IsPlatformMod   bool   `json:"is_platform_mod"`
 	IsPlatformVip   bool   `json:"is_platform_vip"`
 	IsSubscriber    bool   `json:"is_subscriber"`
+	IsVerified      bool   `json:"is_verified"`
+	AvatarURL       string `json:"avatar_url,omitempty"`
+	BotPaused       bool   `json:"bot_paused,omitempty"`
+	HasNotes        bool   `json:"has_notes,omitempty"`
+	Bits            int    `json:"bits,omitempty"`
 	Timestamp       string `json:"timestamp"`
 }
 
 // NewChatMessageDTO crea un DTO serializable a partir de domain.Message.
 func NewChatMessageDTO(msg domain.Message) ChatMessageDTO {
 	return ChatMessageDTO{
+		ID:              msg.ID,
+		PlatformMsgID:   msg.PlatformMessageID,
 		Platform:        string(msg.Platform),
 		ChannelID:       msg.ChannelID,
 		UserID:          msg.UserID,
 		Username:        msg.Username,
+		Login:           msg.Login,
+		DisplayName:     msg.DisplayName,
 		Text:            msg.Text,
+		RawText:         msg.RawText,
+		Truncated:       msg.Truncated,
 		IsPrivate:       msg.IsPrivate,
 		IsPlatformOwner: msg.IsPlatformOwner,
 		IsPlatformAdmin: msg.IsPlatformAdmin,
 		IsPlatformMod:   msg.IsPlatformMod,
 		IsPlatformVip:   msg.IsPlatformVip,
 		IsSubscriber:    msg.IsSubscriber,
+		IsVerified:      msg.IsVerified,
+		AvatarURL:       msg.AvatarURL,
+		BotPaused:       msg.BotPaused,
+		HasNotes:        msg.HasNotes,
+		Bits:            msg.Bits,
 		Timestamp:       time.Now().UTC().Format(time.RFC3339Nano),
 	}
 }
 
+// KickChatStatusDTO es el estado de la conexión al chat de Kick; state es
+// connecting, connected, reconnecting o stopped.
+type KickChatStatusDTO struct {
+	State     string `json:"state"`
+	Since     string `json:"since"`
+	Attempt   int    `json:"attempt,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	RetryAt   string `json:"retry_at,omitempty"`
+}
+
+// ChatDeleteDTO avisa que la moderación borró mensajes del chat. Los clientes
+// ubican el mensaje por platform_message_id o, si kind es "user", por user_id.
+type ChatDeleteDTO struct {
+	Platform        string `json:"platform"`
+	ChannelID       string `json:"channel_id"`
+	Kind            string `json:"kind"`
+	PlatformMsgID   string `json:"platform_message_id,omitempty"`
+	UserID          string `json:"user_id,omitempty"`
+	Login           string `json:"login,omitempty"`
+	Text            string `json:"text,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// NewChatDeleteDTO crea el payload de chat:delete a partir de domain.ChatDeletion.
+func NewChatDeleteDTO(del domain.ChatDeletion) ChatDeleteDTO {
+	at := del.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return ChatDeleteDTO{
+		Platform:        string(del.Platform),
+		ChannelID:       del.ChannelID,
+		Kind:            string(del.Kind),
+		PlatformMsgID:   del.MessageID,
+		UserID:          del.UserID,
+		Login:           del.Login,
+		Text:            del.Text,
+		DurationSeconds: int(del.Duration / time.Second),
+		Timestamp:       at.UTC().Format(time.RFC3339Nano),
+	}
+}
+
 type TwitchBotEventDTO struct {
 	Username string   `json:"username"`
 	Channels []string `json:"channels"`
 	Message  string   `json:"message,omitempty"`
 }
+
+// TwitchAccountDTO describe qué credencial de Twitch usa cada función. Con
+// SameAccount el bot y el streamer son la misma cuenta.
+type TwitchAccountDTO struct {
+	Login       string `json:"login,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	SameAccount bool   `json:"same_account"`
+	// ChatRole y ChannelRole son el rol cuyo token usan el chat (IRC) y
+	// título/categoría; vacío si ninguno alcanza.
+	ChatRole    string `json:"chat_role,omitempty"`
+	ChannelRole string `json:"channel_role,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+// OAuthReauthDTO avisa que una credencial dejó de refrescarse porque el
+// proveedor rechazó el refresh token: hay que volver a iniciar sesión.
+type OAuthReauthDTO struct {
+	Platform string `json:"platform"`
+	Role     string `json:"role"`
+	Login    string `json:"login,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// CapabilityDTO indica si una función está disponible y, si no, qué falta.
+type CapabilityDTO struct {
+	Platform  string `json:"platform"`
+	Feature   string `json:"feature"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SuppressedMessageDTO es un mensaje que el bot habría enviado si no estuviera
+// en modo solo lectura.
+type SuppressedMessageDTO struct {
+	Platform   string    `json:"platform"`
+	ChannelID  string    `json:"channel_id,omitempty"`
+	Username   string    `json:"username,omitempty"`
+	Text       string    `json:"text"`
+	Private    bool      `json:"private,omitempty"`
+	Suppressed bool      `json:"suppressed"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// ChatPlatformFilter deja pasar solo los ChatMessageDTO de la plataforma dada
+// (para SubscribeFiltered sobre TopicChatMessage).
+func ChatPlatformFilter(platform domain.Platform) Filter {
+	return func(payload any) bool {
+		msg, ok := payload.(ChatMessageDTO)
+		return ok && msg.Platform == string(platform)
+	}
+}
@@ -19,7 +19,11 @@ type TTSStatusDTO struct {
 	QueueLength int    `json:"queue_length"`
 	CurrentID   string `json:"current_id,omitempty"`
 	LastError   string `json:"last_error,omitempty"`
-	UpdatedAt   string `json:"updated_at"`
+	AutoPaused  bool   `json:"auto_paused"`
+	// SkipVotes cuenta los votos !ttsskip de la lectura actual.
+	SkipVotes         int    `json:"skip_votes"`
+	SkipVotesRequired int    `json:"skip_votes_required,omitempty"`
+	UpdatedAt         string `json:"updated_at"`
 }
 
 type TTSSpokenDTO struct {
@@ -32,6 +36,9 @@ type TTSSpokenDTO struct {
 	RequestedBy string `json:"requested_by,omitempty"`
 	FinishedAt  string `json:"finished_at"`
 	AudioBase64 string `json:"audio_base64,omitempty"`
+	// AudioID permite pedir el audio aparte cuando AudioBase64 no viaja con
+	// el evento (el puente de escritorio lo quita por tamaño).
+	AudioID string `json:"audio_id,omitempty"`
 }
 
 func NewTTSStatusDTO(state string, queueLength int, currentID, lastError string) TTSStatusDTO {
@@ -0,0 +1,91 @@
+package events
+
+import "sync"
+
+// topicBufferSizes ajusta el buffer de los tópicos con mucho o muy poco
+// tráfico; el resto usa defaultBufferSize.
+var topicBufferSizes = map[string]int{
+	TopicChatMessage:        512,
+	TopicChatSuppressed:     256,
+	TopicNotification:       256,
+	TopicTTSStatus:          32,
+	TopicCapabilities:       16,
+	TopicReadOnly:           16,
+	TopicBotPaused:          16,
+	TopicTwitchBotConnected: 16,
+}
+
+// BufferSize devuelve el tamaño de buffer de las suscripciones a topic.
+func BufferSize(topic string) int {
+	if size, ok := topicBufferSizes[topic]; ok && size > 0 {
+		return size
+	}
+	return defaultBufferSize
+}
+
+// Event es un payload del bus junto con el tópico por el que llegó y su
+// número de secuencia en ese tópico (empieza en 1). Un salto en Seq indica
+// eventos perdidos; ver SeqTracker.
+type Event struct {
+	Topic   string
+	Seq     uint64
+	Payload any
+}
+
+// SubscribeMany junta las suscripciones a varios tópicos en un solo canal.
+// Cada tópico mantiene su propio buffer (si se llena, el bus descarta como
+// en Subscribe). Acepta patrones con Wildcard; sus eventos llegan con el
+// tópico real. Si un tópico coincide con varios patrones (o con un patrón y
+// una suscripción exacta) llega una vez por cada uno. El canal se cierra al
+// llamar a la función devuelta o al cerrar el bus.
+func (b *Bus) SubscribeMany(topics ...string) (<-chan Event, func()) {
+	out := make(chan Event)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	unsubs := make([]func(), 0, len(topics))
+	seen := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		if topic == "" || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+
+		ch, unsubscribe := b.subscribe(topic, subscription{withTopic: true})
+		unsubs = append(unsubs, unsubscribe)
+
+		wg.Add(1)
+		go func(ch <-chan any) {
+			defer wg.Done()
+			for payload := range ch {
+				event, _ := payload.(Event)
+				select {
+				case out <- event:
+				case <-done:
+					return
+				}
+			}
+		}(ch)
+	}
+
+	// out se cierra cuando terminan todos los reenvíos: al cancelar o cuando
+	// el bus se cierra.
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(out)
+		close(finished)
+	}()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			for _, unsubscribe := range unsubs {
+				unsubscribe()
+			}
+		})
+		<-finished
+	}
+	return out, cancel
+}
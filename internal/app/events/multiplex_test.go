@@ -0,0 +1,128 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+	}
+	return Event{}
+}
+
+func waitClosed(t *testing.T, ch <-chan Event) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed")
+		}
+	}
+}
+
+func subscriberCount(b *Bus) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	n := 0
+	for _, subs := range b.subs {
+		n += len(subs)
+	}
+	for _, subs := range b.prefixes {
+		n += len(subs)
+	}
+	return n
+}
+
+func TestSubscribeManyDeliversWithTopic(t *testing.T) {
+	bus := NewBus()
+	events, cancel := bus.SubscribeMany(TopicChatMessage, TopicNotification, TopicChatMessage, "")
+	defer cancel()
+
+	// los tópicos repetidos o vacíos no suman suscripciones
+	if got := subscriberCount(bus); got != 2 {
+		t.Fatalf("subscribers = %d, want 2", got)
+	}
+
+	bus.Publish(TopicChatMessage, "hola")
+	if event := receive(t, events); event.Topic != TopicChatMessage || event.Payload != "hola" || event.Seq != 1 {
+		t.Fatalf("event = %+v", event)
+	}
+	bus.Publish(TopicNotification, 42)
+	if event := receive(t, events); event.Topic != TopicNotification || event.Payload != 42 {
+		t.Fatalf("event = %+v", event)
+	}
+	bus.Publish(TopicTTSStatus, "no suscripto")
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestSubscribeManyCancel(t *testing.T) {
+	bus := NewBus()
+	events, cancel := bus.SubscribeMany(TopicChatMessage, TopicNotification)
+
+	// un reenvío bloqueado porque nadie lee no impide cancelar
+	bus.Publish(TopicChatMessage, "sin leer")
+	bus.Publish(TopicNotification, "sin leer")
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	waitClosed(t, events)
+	if got := subscriberCount(bus); got != 0 {
+		t.Fatalf("subscribers after cancel = %d", got)
+	}
+
+	// cancelar de nuevo y publicar después no hace nada
+	cancel()
+	bus.Publish(TopicChatMessage, "tarde")
+}
+
+func TestSubscribeManyClosesWithBus(t *testing.T) {
+	bus := NewBus()
+	events, cancel := bus.SubscribeMany(TopicChatMessage, TopicNotification)
+
+	bus.Close()
+	waitClosed(t, events)
+	cancel()
+
+	// suscribirse a un bus cerrado devuelve un canal ya cerrado
+	late, cancelLate := bus.SubscribeMany(TopicChatMessage)
+	waitClosed(t, late)
+	cancelLate()
+}
+
+func TestBufferSize(t *testing.T) {
+	if got := BufferSize(TopicChatMessage); got != 512 {
+		t.Fatalf("BufferSize(chat) = %d", got)
+	}
+	if got := BufferSize("otro:topico"); got != defaultBufferSize {
+		t.Fatalf("BufferSize(unknown) = %d", got)
+	}
+}
+
+func TestSubscribeManyWildcardKeepsRealTopic(t *testing.T) {
+	bus := NewBus()
+	events, cancel := bus.SubscribeMany("tts:*")
+	defer cancel()
+
+	bus.Publish(TopicTTSSpoken, "hablado")
+	if event := receive(t, events); event.Topic != TopicTTSSpoken || event.Payload != "hablado" {
+		t.Fatalf("event = %+v", event)
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import "sort"
+
+// TopicStats resume un tópico: último número de secuencia publicado y
+// cuántos envíos se descartaron por buffers llenos.
+type TopicStats struct {
+	Topic string `json:"topic"`
+	Seq   uint64 `json:"seq"`
+	Drops uint64 `json:"drops"`
+}
+
+// Stats devuelve la secuencia y las pérdidas de cada tópico publicado,
+// ordenado por tópico.
+func (b *Bus) Stats() []TopicStats {
+	b.seqMu.Lock()
+	stats := make([]TopicStats, 0, len(b.seqs))
+	for topic, seq := range b.seqs {
+		stats = append(stats, TopicStats{Topic: topic, Seq: seq})
+	}
+	b.seqMu.Unlock()
+
+	b.dropMu.Lock()
+	for i := range stats {
+		stats[i].Drops = b.dropCounts[stats[i].Topic]
+	}
+	b.dropMu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Topic < stats[j].Topic })
+	return stats
+}
+
+// Seq devuelve el último número de secuencia publicado en topic (0 si nunca
+// se publicó). Sirve para resincronizar después de un salto.
+func (b *Bus) Seq(topic string) uint64 {
+	b.seqMu.Lock()
+	defer b.seqMu.Unlock()
+	return b.seqs[topic]
+}
+
+// SeqTracker detecta eventos perdidos comparando la secuencia de cada Event
+// con la anterior del mismo tópico. No es seguro para uso concurrente.
+// Las suscripciones con filtro ven saltos por lo que el filtro descarta.
+type SeqTracker struct {
+	last map[string]uint64
+}
+
+// Observe registra event y devuelve cuántos eventos del tópico se perdieron
+// desde el anterior. El primer evento de cada tópico nunca cuenta como salto.
+func (t *SeqTracker) Observe(event Event) uint64 {
+	if event.Seq == 0 {
+		return 0
+	}
+	if t.last == nil {
+		t.last = make(map[string]uint64)
+	}
+	prev, seen := t.last[event.Topic]
+	if event.Seq > prev {
+		t.last[event.Topic] = event.Seq
+	}
+	if !seen || event.Seq <= prev+1 {
+		return 0
+	}
+	return event.Seq - prev - 1
+}
@@ -0,0 +1,86 @@
+package events
+
+import "testing"
+
+func TestSequencePerTopic(t *testing.T) {
+	bus := NewBus()
+	events, cancel := bus.SubscribeMany(TopicChatMessage, TopicNotification)
+	defer cancel()
+
+	steps := []struct {
+		topic string
+		seq   uint64
+	}{
+		{TopicChatMessage, 1},
+		{TopicNotification, 1},
+		{TopicChatMessage, 2},
+	}
+	for _, step := range steps {
+		bus.Publish(step.topic, "payload")
+		if event := receive(t, events); event.Topic != step.topic || event.Seq != step.seq {
+			t.Fatalf("event = %+v, want %s #%d", event, step.topic, step.seq)
+		}
+	}
+	if seq := bus.Seq(TopicChatMessage); seq != 2 {
+		t.Fatalf("Seq(chat) = %d", seq)
+	}
+	if seq := bus.Seq(TopicTTSStatus); seq != 0 {
+		t.Fatalf("Seq(unpublished) = %d", seq)
+	}
+}
+
+func TestGapDetectionAfterForcedDrop(t *testing.T) {
+	bus := NewBus()
+	topic := TopicCapabilities
+	size := BufferSize(topic)
+	// suscripción sin reenvío: nadie lee, así que el buffer se llena
+	ch, cancel := bus.subscribe(topic, subscription{withTopic: true})
+	defer cancel()
+
+	for i := 0; i < size+3; i++ {
+		bus.Publish(topic, i)
+	}
+
+	var tracker SeqTracker
+	for i := 0; i < size; i++ {
+		event := (<-ch).(Event)
+		if gap := tracker.Observe(event); gap != 0 {
+			t.Fatalf("gap %d at seq %d", gap, event.Seq)
+		}
+	}
+
+	bus.Publish(topic, "después")
+	event := (<-ch).(Event)
+	if gap := tracker.Observe(event); gap != 3 {
+		t.Fatalf("gap = %d, want 3 dropped events", gap)
+	}
+
+	var stats TopicStats
+	for _, s := range bus.Stats() {
+		if s.Topic == topic {
+			stats = s
+		}
+	}
+	if stats.Seq != uint64(size+4) || stats.Drops != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestSeqTrackerIgnoresUnnumberedAndOldEvents(t *testing.T) {
+	var tracker SeqTracker
+	if gap := tracker.Observe(Event{Topic: "x", Seq: 5}); gap != 0 {
+		t.Fatalf("first event counted as gap %d", gap)
+	}
+	if gap := tracker.Observe(Event{Topic: "x"}); gap != 0 {
+		t.Fatalf("unnumbered event gap %d", gap)
+	}
+	if gap := tracker.Observe(Event{Topic: "x", Seq: 3}); gap != 0 {
+		t.Fatalf("old event gap %d", gap)
+	}
+	if gap := tracker.Observe(Event{Topic: "x", Seq: 7}); gap != 1 {
+		t.Fatalf("gap = %d, want 1", gap)
+	}
+	if gap := tracker.Observe(Event{Topic: "y", Seq: 9}); gap != 0 {
+		t.Fatalf("other topic gap %d", gap)
+	}
+}
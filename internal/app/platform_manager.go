@@ -13,6 +13,8 @@ import (
 	kickadapter "zhatBot/internal/interface/adapters/kick"
 	"zhatBot/internal/interface/outs"
 	categoryusecase "zhatBot/internal/usecase/category"
+	"zhatBot/internal/usecase/moderation"
+	readonlyusecase "zhatBot/internal/usecase/readonly"
 	statususecase "zhatBot/internal/usecase/status"
 	"zhatBot/internal/usecase/stream"
 )
@@ -25,13 +27,19 @@ type ManagerConfig struct {
 	Resolver *stream.Resolver
 	MultiOut *outs.MultiSender
 	Status   *statususecase.Resolver
-	Kick     KickConfig
+	ReadOnly *readonlyusecase.Mode
+	// Moderation recibe el servicio de sanciones de Kick al habilitarlo.
+	Moderation *moderation.Actions
+	Kick       KickConfig
 }
 
 type KickConfig struct {
 	BroadcasterUserID int
 	ChatroomID        int
 	EventHandler      kickadapter.EventHandler
+	ConnectionHandler kickadapter.ConnectionHandler
+	DeletionHandler   kickadapter.DeletionHandler
+	StatusHandler     kickadapter.StatusHandler
 }
 
 type PlatformManager struct {
@@ -40,6 +48,8 @@ type PlatformManager struct {
 	resolver *stream.Resolver
 	multiOut *outs.MultiSender
 	status   *statususecase.Resolver
+	readOnly *readonlyusecase.Mode
+	mod      *moderation.Actions
 
 	handlerMu sync.RWMutex
 	handler   MessageHandler
@@ -69,6 +79,8 @@ func NewPlatformManager(cfg ManagerConfig) *PlatformManager {
 		resolver: cfg.Resolver,
 		multiOut: cfg.MultiOut,
 		status:   cfg.Status,
+		readOnly: cfg.ReadOnly,
+		mod:      cfg.Moderation,
 		kickCfg:  cfg.Kick,
 	}
 }
@@ -98,6 +110,21 @@ func (m *PlatformManager) ChannelID(platform domain.Platform) string {
 	return ""
 }
 
+// ProfileFetcher devuelve quién consulta perfiles de usuario en la plataforma,
+// o nil si no está habilitada.
+func (m *PlatformManager) ProfileFetcher(platform domain.Platform) domain.UserProfileFetcher {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	switch platform {
+	case domain.PlatformKick:
+		if m.kick != nil && m.kick.rawSvc != nil {
+			return m.kick.rawSvc
+		}
+	default:
+	}
+	return nil
+}
+
 func (m *PlatformManager) HandleCredentialUpdate(ctx context.Context, cred *domain.Credential) {
 	if cred == nil {
 		return
@@ -156,21 +183,30 @@ func (m *PlatformManager) enableKick(token string) error {
 		BroadcasterUserID: m.kickCfg.BroadcasterUserID,
 		ChatroomID:        m.kickCfg.ChatroomID,
 		EventHandler:      m.kickCfg.EventHandler,
+		ConnectionHandler: m.kickCfg.ConnectionHandler,
+		DeletionHandler:   m.kickCfg.DeletionHandler,
+		StatusHandler:     m.kickCfg.StatusHandler,
+		Role:              "streamer",
 	})
 
 	multiOut := m.multiOut
 	if multiOut != nil {
 		multiOut.Register(domain.PlatformKick, adapter)
 	}
+	mutable := readonlyusecase.KickStream(streamSvcIface, m.readOnly)
 	if m.resolver != nil {
-		m.resolver.Set(domain.PlatformKick, streamSvcIface)
+		m.resolver.Set(domain.PlatformKick, mutable)
 	}
 	if m.category != nil {
-		m.category.SetKickService(streamSvcIface)
+		m.category.SetKickService(mutable)
 	}
 	if m.status != nil {
 		m.status.Set(domain.PlatformKick, kickinfra.NewKickStatusAdapter(streamSvcIface, m.kickCfg.BroadcasterUserID))
 	}
+	if m.mod != nil && rawSvc != nil {
+		m.mod.Set(domain.PlatformKick, readonlyusecase.Moderation(
+			kickinfra.NewKickModerationAdapter(rawSvc, m.kickCfg.BroadcasterUserID), m.readOnly))
+	}
 
 	handler := m.getHandler()
 	if handler != nil {
@@ -196,6 +232,17 @@ func (m *PlatformManager) enableKick(token string) error {
 	return nil
 }
 
+// KickStatus devuelve el estado de la conexión al chat de Kick; ok es false
+// si Kick no está habilitado.
+func (m *PlatformManager) KickStatus() (status kickadapter.Status, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.kick == nil {
+		return kickadapter.Status{}, false
+	}
+	return m.kick.adapter.Status(), true
+}
+
 func (m *PlatformManager) disableKick() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -215,6 +262,9 @@ func (m *PlatformManager) disableKick() {
 	if m.status != nil {
 		m.status.Set(domain.PlatformKick, nil)
 	}
+	if m.mod != nil {
+		m.mod.Set(domain.PlatformKick, nil)
+	}
 	m.kick = nil
 	log.Println("kick manager: Kick deshabilitado.")
 }
@@ -0,0 +1,30 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+)
+
+// chatDeletionHandler reenvía los borrados de la moderación como chat:delete
+// para que overlays y registros quiten los mensajes afectados.
+func (r *Runtime) chatDeletionHandler() func(domain.ChatDeletion) {
+	return func(del domain.ChatDeletion) {
+		if r == nil {
+			return
+		}
+		payload := events.NewChatDeleteDTO(del)
+		log.Printf("chat: borrado %s en %s/%s (mensaje=%q usuario=%q)", payload.Kind, payload.Platform, payload.ChannelID, payload.PlatformMsgID, payload.Login)
+		if r.wsServer != nil {
+			if err := r.wsServer.PublishEvent(r.ctx, "chat:delete", payload); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("ws publish error: %v", err)
+			}
+		}
+		if r.bus != nil {
+			r.bus.Publish(events.TopicChatDelete, payload)
+		}
+	}
+}
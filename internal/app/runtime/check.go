@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/infrastructure/config"
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+	kickinfra "zhatBot/internal/infrastructure/platform/kick"
+)
+
+// Códigos de salida de `bot -check`, uno por clase de falla. Si fallan varias
+// se usa la primera en este orden.
+const (
+	CheckExitOK          = 0
+	CheckExitConfig      = 2
+	CheckExitDatabase    = 3
+	CheckExitCredentials = 4
+	CheckExitPort        = 5
+)
+
+const defaultCheckTimeout = 5 * time.Second
+
+type CheckStatus string
+
+const (
+	CheckOK   CheckStatus = "ok"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+	CheckSkip CheckStatus = "skip"
+)
+
+type CheckOptions struct {
+	// Offline evita las llamadas a los endpoints de validación de tokens.
+	Offline bool
+	// Timeout por cada llamada de red; por defecto 5s.
+	Timeout time.Duration
+}
+
+type CheckItem struct {
+	Name     string
+	Status   CheckStatus
+	Detail   string
+	ExitCode int
+}
+
+// CheckReport es el resultado de Check, en el orden en que se ejecutó.
+type CheckReport struct {
+	Items []CheckItem
+}
+
+func (r *CheckReport) add(name string, status CheckStatus, exitCode int, format string, args ...any) {
+	r.Items = append(r.Items, CheckItem{
+		Name:     name,
+		Status:   status,
+		Detail:   fmt.Sprintf(format, args...),
+		ExitCode: exitCode,
+	})
+}
+
+// ExitCode devuelve el código de la primera clase que falló, o 0.
+func (r CheckReport) ExitCode() int {
+	code := CheckExitOK
+	for _, item := range r.Items {
+		if item.Status == CheckFail && (code == CheckExitOK || item.ExitCode < code) {
+			code = item.ExitCode
+		}
+	}
+	return code
+}
+
+// Print escribe el reporte en formato legible.
+func (r CheckReport) Print(w io.Writer) {
+	for _, item := range r.Items {
+		fmt.Fprintf(w, "[%-4s] %-18s %s\n", item.Status, item.Name, item.Detail)
+	}
+	if code := r.ExitCode(); code != CheckExitOK {
+		fmt.Fprintf(w, "check: FALLÓ (código %d)\n", code)
+		return
+	}
+	fmt.Fprintln(w, "check: OK")
+}
+
+// Check valida el entorno sin conectarse al chat: configuración, base de datos
+// (solo lectura), credenciales guardadas y que el puerto WS esté libre.
+func Check(ctx context.Context, opts CheckOptions) CheckReport {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultCheckTimeout
+	}
+	var report CheckReport
+
+	cfg, err := config.Load()
+	if err != nil {
+		report.add("config", CheckFail, CheckExitConfig, "no se pudo cargar: %v", err)
+		return report
+	}
+	if problems := cfg.Problems(); len(problems) > 0 {
+		report.add("config", CheckFail, CheckExitConfig, "%s", strings.Join(problems, "; "))
+	} else {
+		report.add("config", CheckOK, CheckExitConfig, "archivo %s", valueOrNone(config.ConfigFilePath()))
+	}
+	for _, legacy := range cfg.LegacyRedirects() {
+		report.add("redirect-uri", CheckWarn, CheckExitConfig, "%s heredada (%s), usar %s", legacy.Key, legacy.Value, legacy.Suggested)
+	}
+
+	dbPath := resolveDBPath(cfg)
+	store, err := sqlitestorage.OpenCredentialStoreReadOnly(dbPath)
+	if err != nil {
+		report.add("database", CheckFail, CheckExitDatabase, "%v", err)
+	} else {
+		defer store.Close()
+		creds, err := store.List(ctx)
+		if err != nil {
+			report.add("database", CheckFail, CheckExitDatabase, "%s: %v", dbPath, err)
+		} else {
+			report.add("database", CheckOK, CheckExitDatabase, "%s", dbPath)
+			checkCredentials(ctx, &report, cfg, creds, opts)
+		}
+	}
+
+	checkWSPort(&report, resolveWSAddr())
+	return report
+}
+
+func checkCredentials(ctx context.Context, report *CheckReport, cfg *config.Config, creds []*domain.Credential, opts CheckOptions) {
+	if len(creds) == 0 {
+		report.add("credentials", CheckWarn, CheckExitCredentials, "no hay credenciales guardadas")
+		return
+	}
+	for _, cred := range creds {
+		if cred == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s/%s", cred.Platform, cred.Role)
+		if strings.TrimSpace(cred.AccessToken) == "" {
+			report.add(name, CheckFail, CheckExitCredentials, "token vacío")
+			continue
+		}
+		if opts.Offline {
+			if !cred.ExpiresAt.IsZero() && time.Now().After(cred.ExpiresAt) && cred.RefreshToken == "" {
+				report.add(name, CheckFail, CheckExitCredentials, "token vencido y sin refresh token")
+				continue
+			}
+			report.add(name, CheckSkip, CheckExitCredentials, "sin validar (-offline)")
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		detail, err := validateCredential(callCtx, cfg, cred)
+		cancel()
+		if err != nil {
+			report.add(name, CheckFail, CheckExitCredentials, "%v", err)
+			continue
+		}
+		report.add(name, CheckOK, CheckExitCredentials, "%s", detail)
+	}
+}
+
+func validateCredential(ctx context.Context, cfg *config.Config, cred *domain.Credential) (string, error) {
+	switch cred.Platform {
+	case domain.PlatformTwitch:
+		_, login, err := fetchTwitchTokenOwner(ctx, cfg.TwitchClientId, cred.AccessToken, nil)
+		if err != nil {
+			return "", err
+		}
+		return "token válido para " + login, nil
+	case domain.PlatformKick:
+		if err := kickinfra.ValidateToken(ctx, cred.AccessToken); err != nil {
+			return "", err
+		}
+		return "token activo", nil
+	default:
+		return "", fmt.Errorf("plataforma desconocida")
+	}
+}
+
+func checkWSPort(report *CheckReport, addr string) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		report.add("ws", CheckFail, CheckExitPort, "no se puede escuchar en %s: %v", addr, err)
+		return
+	}
+	listener.Close()
+	report.add("ws", CheckOK, CheckExitPort, "%s disponible", addr)
+}
@@ -0,0 +1,175 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+)
+
+// checkEnv aísla Check del entorno real: config.json en un directorio
+// temporal, la base indicada y un puerto WS libre.
+func checkEnv(t *testing.T, configJSON, dbPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("ZHATBOT_MODE", "")
+	for _, key := range []string{
+		"TWITCH_CLIENT_ID", "TWITCH_CLIENT_SECRET", "TWITCH_REDIRECT_URI",
+		"KICK_CLIENT_ID", "KICK_CLIENT_SECRET", "KICK_REDIRECT_URI",
+	} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("DATABASE_PATH", dbPath)
+	t.Setenv("CHAT_WS_ADDR", "127.0.0.1:0")
+
+	if configJSON != "" {
+		if err := os.MkdirAll(filepath.Join(dir, "zhatbot"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "zhatbot", "config.json"), []byte(configJSON), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkDB(t *testing.T, creds ...*domain.Credential) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bot.db")
+	store, err := sqlitestorage.NewCredentialStore(path)
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+	defer store.Close()
+	for _, cred := range creds {
+		if err := store.Save(context.Background(), cred); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	return path
+}
+
+func runCheck(t *testing.T) CheckReport {
+	t.Helper()
+	report := Check(context.Background(), CheckOptions{Offline: true})
+	var out bytes.Buffer
+	report.Print(&out)
+	t.Logf("reporte:\n%s", out.String())
+	return report
+}
+
+func TestCheckBrokenConfigFile(t *testing.T) {
+	checkEnv(t, "{no es json", checkDB(t))
+
+	report := runCheck(t)
+	if code := report.ExitCode(); code != CheckExitConfig {
+		t.Fatalf("ExitCode = %d, want %d", code, CheckExitConfig)
+	}
+	// sin configuración no se sigue con el resto
+	if len(report.Items) != 1 {
+		t.Fatalf("items = %+v", report.Items)
+	}
+}
+
+func TestCheckIncompleteConfigWinsOverLaterFailures(t *testing.T) {
+	checkEnv(t, `{"kick_client_id": "abc"}`, filepath.Join(t.TempDir(), "falta.db"))
+
+	report := runCheck(t)
+	if code := report.ExitCode(); code != CheckExitConfig {
+		t.Fatalf("ExitCode = %d, want %d", code, CheckExitConfig)
+	}
+	if !hasFailure(report, "database") {
+		t.Fatalf("missing database failure still reported: %+v", report.Items)
+	}
+}
+
+func TestCheckMissingDatabase(t *testing.T) {
+	checkEnv(t, "", filepath.Join(t.TempDir(), "falta.db"))
+
+	if code := runCheck(t).ExitCode(); code != CheckExitDatabase {
+		t.Fatalf("ExitCode = %d, want %d", code, CheckExitDatabase)
+	}
+}
+
+func TestCheckCredentialsOffline(t *testing.T) {
+	db := checkDB(t,
+		&domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "tok", RefreshToken: "ref", ExpiresAt: time.Now().Add(-time.Hour)},
+		&domain.Credential{Platform: domain.PlatformKick, Role: "streamer", AccessToken: "tok", ExpiresAt: time.Now().Add(-time.Hour)},
+	)
+	checkEnv(t, "", db)
+
+	report := runCheck(t)
+	if code := report.ExitCode(); code != CheckExitCredentials {
+		t.Fatalf("ExitCode = %d, want %d", code, CheckExitCredentials)
+	}
+	// vencido pero con refresh token no es un error
+	if hasFailure(report, "twitch/bot") {
+		t.Fatal("twitch/bot should be skipped, not failed")
+	}
+	if !hasFailure(report, "kick/streamer") {
+		t.Fatal("expired kick/streamer without refresh token should fail")
+	}
+}
+
+func TestCheckPortInUse(t *testing.T) {
+	checkEnv(t, "", checkDB(t, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "tok"}))
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+	t.Setenv("CHAT_WS_ADDR", busy.Addr().String())
+
+	if code := runCheck(t).ExitCode(); code != CheckExitPort {
+		t.Fatalf("ExitCode = %d, want %d", code, CheckExitPort)
+	}
+}
+
+func TestCheckOK(t *testing.T) {
+	checkEnv(t, "", checkDB(t, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "tok"}))
+
+	report := runCheck(t)
+	if code := report.ExitCode(); code != CheckExitOK {
+		t.Fatalf("ExitCode = %d, want 0", code)
+	}
+	var out bytes.Buffer
+	report.Print(&out)
+	if !strings.HasSuffix(out.String(), "check: OK\n") {
+		t.Fatalf("report = %q", out.String())
+	}
+}
+
+func TestCheckDoesNotWriteTheDatabase(t *testing.T) {
+	db := checkDB(t)
+	before, err := os.Stat(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEnv(t, "", db)
+	runCheck(t)
+
+	after, err := os.Stat(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) || after.Size() != before.Size() {
+		t.Fatal("check modified the database")
+	}
+}
+
+func hasFailure(report CheckReport, name string) bool {
+	for _, item := range report.Items {
+		if item.Name == name && item.Status == CheckFail {
+			return true
+		}
+	}
+	return false
+}
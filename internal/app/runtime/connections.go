@@ -0,0 +1,114 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+	kickadapter "zhatBot/internal/interface/adapters/kick"
+	connectionsusecase "zhatBot/internal/usecase/connections"
+)
+
+// connectionHandler avisa al tracker cuando el chat de la plataforma se
+// conecta o se cae, y manda el saludo configurado al confirmar la conexión.
+func (r *Runtime) connectionHandler(platform domain.Platform) func(connected bool) {
+	return func(connected bool) {
+		if r == nil {
+			return
+		}
+		// también llega al apagar, con el contexto ya cancelado
+		ctx := context.WithoutCancel(r.ctx)
+		if r.connStatus != nil {
+			if connected {
+				r.connStatus.Connected(ctx, platform)
+			} else {
+				r.connStatus.Disconnected(ctx, platform)
+			}
+		}
+		if r.presence == nil {
+			return
+		}
+		if connected {
+			r.presence.Connected(r.ctx, platform)
+		} else {
+			r.presence.Disconnected(platform)
+		}
+	}
+}
+
+// kickStatusHandler publica cada cambio de la conexión al chat de Kick como
+// kick:chat:status, para que la UI muestre cuándo está reconectando.
+func (r *Runtime) kickStatusHandler() kickadapter.StatusHandler {
+	return func(status kickadapter.Status) {
+		if r == nil {
+			return
+		}
+		payload := kickChatStatusDTO(status)
+		if r.wsServer != nil {
+			if err := r.wsServer.PublishEvent(context.WithoutCancel(r.ctx), events.TopicKickChatStatus, payload); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("ws publish error: %v", err)
+			}
+		}
+		if r.bus != nil {
+			r.bus.Publish(events.TopicKickChatStatus, payload)
+		}
+	}
+}
+
+// KickChatStatus devuelve el estado de la conexión al chat de Kick; ok es
+// false si Kick no está habilitado.
+func (r *Runtime) KickChatStatus() (events.KickChatStatusDTO, bool) {
+	if r == nil || r.platform == nil {
+		return events.KickChatStatusDTO{}, false
+	}
+	status, ok := r.platform.KickStatus()
+	if !ok {
+		return events.KickChatStatusDTO{}, false
+	}
+	return kickChatStatusDTO(status), true
+}
+
+func kickChatStatusDTO(status kickadapter.Status) events.KickChatStatusDTO {
+	dto := events.KickChatStatusDTO{
+		State:     string(status.State),
+		Attempt:   status.Attempt,
+		LastError: status.LastError,
+	}
+	if !status.Since.IsZero() {
+		dto.Since = status.Since.UTC().Format(time.RFC3339)
+	}
+	if !status.RetryAt.IsZero() {
+		dto.RetryAt = status.RetryAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+// Presence expone los mensajes de conexión y despedida.
+func (r *Runtime) Presence() *connectionsusecase.Presence {
+	if r == nil {
+		return nil
+	}
+	return r.presence
+}
+
+// PlatformConnections devuelve la última conexión/desconexión de cada
+// plataforma y el tiempo conectado en esta sesión.
+func (r *Runtime) PlatformConnections() []connectionsusecase.StatusDTO {
+	if r == nil || r.connStatus == nil {
+		return nil
+	}
+	return r.connStatus.Statuses()
+}
+
+// TestSend manda un mensaje de prueba al canal del bot en la plataforma para
+// confirmar que puede escribir.
+func (r *Runtime) TestSend(ctx context.Context, platform domain.Platform) (connectionsusecase.TestSendResult, error) {
+	if r == nil || r.testSender == nil {
+		return connectionsusecase.TestSendResult{}, fmt.Errorf("runtime unavailable")
+	}
+	return r.testSender.Send(ctx, platform)
+}
@@ -0,0 +1,295 @@
+package runtime
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+	"zhatBot/internal/infrastructure/config"
+	"zhatBot/internal/infrastructure/eventlog"
+)
+
+// debugRecorder conecta el registro de eventos con su configuración guardada.
+type debugRecorder struct {
+	*eventlog.Recorder
+	repo domain.DebugRecorderRepository
+
+	mu       sync.RWMutex
+	settings domain.DebugRecorderSettings
+}
+
+func newDebugRecorder(dir string, repo domain.DebugRecorderRepository) *debugRecorder {
+	return &debugRecorder{Recorder: eventlog.NewRecorder(dir), repo: repo}
+}
+
+func (d *debugRecorder) Reload(ctx context.Context) error {
+	if d.repo == nil {
+		return nil
+	}
+	settings, err := d.repo.GetDebugRecorder(ctx)
+	if err != nil {
+		return err
+	}
+	d.apply(settings)
+	return nil
+}
+
+func (d *debugRecorder) Settings() domain.DebugRecorderSettings {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.settings
+}
+
+func (d *debugRecorder) SetSettings(ctx context.Context, settings domain.DebugRecorderSettings) error {
+	if d.repo != nil {
+		if err := d.repo.SetDebugRecorder(ctx, settings); err != nil {
+			return err
+		}
+	}
+	d.apply(settings)
+	return nil
+}
+
+func (d *debugRecorder) apply(settings domain.DebugRecorderSettings) {
+	d.mu.Lock()
+	d.settings = settings
+	d.mu.Unlock()
+	d.SetEnabled(settings.Enabled)
+}
+
+// recordMessage guarda los metadatos de un mensaje del chat y el resultado del
+// dispatch. El texto solo se incluye si el usuario lo activó.
+func (d *debugRecorder) recordMessage(msg domain.Message, isCommand bool, err error) {
+	if !d.Enabled() {
+		return
+	}
+	fields := map[string]any{
+		"platform":   string(msg.Platform),
+		"channel":    msg.ChannelID,
+		"user":       msg.LoginName(),
+		"text_len":   len(msg.Text),
+		"is_command": isCommand,
+		"ok":         err == nil,
+	}
+	if d.Settings().IncludeText {
+		fields["text"] = msg.Text
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	d.Record("message", fields)
+}
+
+func (d *debugRecorder) recordCredential(cred *domain.Credential) {
+	if cred == nil || !d.Enabled() {
+		return
+	}
+	fields := map[string]any{
+		"platform":    string(cred.Platform),
+		"role":        cred.Role,
+		"has_refresh": cred.RefreshToken != "",
+	}
+	if !cred.ExpiresAt.IsZero() {
+		fields["expires_at"] = cred.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	d.Record("credential", fields)
+}
+
+// debugTopics son los eventos del bus que se graban tal cual.
+var debugTopics = map[string]string{
+	events.TopicTwitchBotConnected: "adapter",
+	events.TopicTwitchBotError:     "adapter",
+	events.TopicKickChatStatus:     "adapter",
+	events.TopicCapabilities:       "capability",
+	events.TopicAppError:           "error",
+	events.TopicReadOnly:           "readonly",
+	events.TopicBotPaused:          "paused",
+}
+
+// runDebugRecorder graba los cambios de estado de los adaptadores y los errores
+// publicados en el bus.
+func (r *Runtime) runDebugRecorder(ctx context.Context) {
+	if r.bus == nil || r.recorder == nil {
+		return
+	}
+	type item struct {
+		topic   string
+		payload any
+	}
+	merged := make(chan item, 64)
+	var wg sync.WaitGroup
+	for topic := range debugTopics {
+		ch, unsubscribe := r.bus.Subscribe(topic)
+		defer unsubscribe()
+		wg.Add(1)
+		go func(topic string, ch <-chan any) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case payload, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case merged <- item{topic: topic, payload: payload}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(topic, ch)
+	}
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case it := <-merged:
+			r.recorder.Record(debugTopics[it.topic], map[string]any{
+				"topic":   it.topic,
+				"payload": toFields(it.payload),
+			})
+		}
+	}
+}
+
+// toFields pasa el payload por JSON para que la redacción vea los nombres de
+// campo reales.
+func toFields(payload any) any {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	var fields any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+type healthSnapshot struct {
+	Status      string `json:"status"`
+	ReadOnly    bool   `json:"read_only"`
+	Recorder    bool   `json:"recorder"`
+	Diagnostics any    `json:"diagnostics"`
+}
+
+// DebugRecorderSettings devuelve la configuración del registro de eventos.
+func (r *Runtime) DebugRecorderSettings() domain.DebugRecorderSettings {
+	if r == nil || r.recorder == nil {
+		return domain.DebugRecorderSettings{}
+	}
+	return r.recorder.Settings()
+}
+
+func (r *Runtime) SetDebugRecorderSettings(ctx context.Context, settings domain.DebugRecorderSettings) error {
+	if r == nil || r.recorder == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.recorder.SetSettings(ctx, settings)
+}
+
+// DebugBundle arma un zip con los eventos recientes, el estado del runtime y la
+// configuración sin secretos, para adjuntar a un reporte de errores.
+func (r *Runtime) DebugBundle(ctx context.Context) ([]byte, error) {
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	buf := bytes.NewBuffer(nil)
+	zw := zip.NewWriter(buf)
+
+	if r.recorder != nil {
+		for _, path := range r.recorder.Files() {
+			if err := addZipFile(zw, filepath.Join("events", filepath.Base(path)), path); err != nil {
+				zw.Close()
+				return nil, err
+			}
+		}
+	}
+
+	health := healthSnapshot{
+		Status:      "ok",
+		ReadOnly:    r.ReadOnly(),
+		Recorder:    r.recorder != nil && r.recorder.Enabled(),
+		Diagnostics: r.Diagnostics(ctx),
+	}
+	if err := addZipJSON(zw, "health.json", health); err != nil {
+		zw.Close()
+		return nil, err
+	}
+	if err := addZipJSON(zw, "config.json", redactedConfig(r.cfg)); err != nil {
+		zw.Close()
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// redactedConfig deja solo si cada secreto está configurado, nunca su valor.
+func redactedConfig(cfg *config.Config) map[string]any {
+	if cfg == nil {
+		return map[string]any{}
+	}
+	isSet := func(value string) bool { return strings.TrimSpace(value) != "" }
+	return map[string]any{
+		"twitch_username":              cfg.TwitchUsername,
+		"twitch_channels":              cfg.TwitchChannels,
+		"twitch_client_id":             cfg.TwitchClientId,
+		"twitch_redirect_uri":          cfg.TwitchRedirectURI,
+		"twitch_token_set":             isSet(cfg.TwitchToken),
+		"twitch_api_token_set":         isSet(cfg.TwitchApiToken),
+		"twitch_api_refresh_token_set": isSet(cfg.TwitchApiRefreshToken),
+		"twitch_client_secret_set":     isSet(cfg.TwitchClientSecret),
+		"kick_client_id":               cfg.KickClientID,
+		"kick_redirect_uri":            cfg.KickRedirectURI,
+		"kick_client_secret_set":       isSet(cfg.KickClientSecret),
+		"database_path":                cfg.DatabasePath,
+	}
+}
+
+func addZipJSON(zw *zip.Writer, name string, payload any) error {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func addZipFile(zw *zip.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, file)
+	return err
+}
@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/infrastructure/config"
+)
+
+func unzipBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("bundle is not a zip: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestDebugBundleNeverContainsSecrets(t *testing.T) {
+	cfg := &config.Config{
+		TwitchUsername:        "zerobot",
+		TwitchToken:           "oauth:chat-token-123",
+		TwitchApiToken:        "api-token-456",
+		TwitchApiRefreshToken: "refresh-789",
+		TwitchClientId:        "client-id",
+		TwitchClientSecret:    "twitch-secret-abc",
+		KickClientSecret:      "kick-secret-def",
+	}
+	r := newStreamerTestRuntime(t, cfg)
+	r.recorder = newDebugRecorder(t.TempDir(), nil)
+	if err := r.recorder.SetSettings(context.Background(), domain.DebugRecorderSettings{Enabled: true}); err != nil {
+		t.Fatalf("SetSettings: %v", err)
+	}
+	defer r.recorder.Close()
+
+	r.recorder.recordCredential(&domain.Credential{
+		Platform:     domain.PlatformTwitch,
+		Role:         "streamer",
+		AccessToken:  "api-token-456",
+		RefreshToken: "refresh-789",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	msg := domain.Message{Platform: domain.PlatformTwitch, ChannelID: "canal", Username: "zero", Text: "mi token es oauth:chat-token-123"}
+	r.recorder.recordMessage(msg, false, nil)
+	r.recorder.Record("error", map[string]any{"error": "refresh: Bearer api-token-456 rejected"})
+
+	data, err := r.DebugBundle(context.Background())
+	if err != nil {
+		t.Fatalf("DebugBundle: %v", err)
+	}
+	files := unzipBundle(t, data)
+	for _, name := range []string{"events/events.jsonl", "health.json", "config.json"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("bundle is missing %s (has %v)", name, files)
+		}
+	}
+	for name, content := range files {
+		for _, secret := range []string{"chat-token-123", "api-token-456", "refresh-789", "twitch-secret-abc", "kick-secret-def"} {
+			if strings.Contains(content, secret) {
+				t.Fatalf("%s leaks %q:\n%s", name, secret, content)
+			}
+		}
+	}
+
+	if strings.Contains(files["events/events.jsonl"], "mi token es") {
+		t.Fatal("message text recorded without include_text")
+	}
+	var redacted map[string]any
+	if err := json.Unmarshal([]byte(files["config.json"]), &redacted); err != nil {
+		t.Fatalf("config.json: %v", err)
+	}
+	if redacted["twitch_token_set"] != true || redacted["kick_client_secret_set"] != true || redacted["twitch_username"] != "zerobot" {
+		t.Fatalf("config.json = %v", redacted)
+	}
+}
+
+func TestDebugBundleIncludesTextOnlyWhenOptedIn(t *testing.T) {
+	r := newStreamerTestRuntime(t, &config.Config{})
+	r.recorder = newDebugRecorder(t.TempDir(), nil)
+	r.recorder.SetSettings(context.Background(), domain.DebugRecorderSettings{Enabled: true, IncludeText: true})
+	defer r.recorder.Close()
+
+	r.recorder.recordMessage(domain.Message{Platform: domain.PlatformKick, Text: "hola oauth:abc"}, true, nil)
+
+	data, err := r.DebugBundle(context.Background())
+	if err != nil {
+		t.Fatalf("DebugBundle: %v", err)
+	}
+	events := unzipBundle(t, data)["events/events.jsonl"]
+	if !strings.Contains(events, `"text":"hola oauth:[redacted]"`) {
+		t.Fatalf("events = %s", events)
+	}
+}
@@ -0,0 +1,41 @@
+package runtime
+
+import (
+	"context"
+	goruntime "runtime"
+	"time"
+
+	"zhatBot/internal/app/events"
+)
+
+// RuntimeDiagnostics resume el estado del proceso para el paquete de soporte.
+type RuntimeDiagnostics struct {
+	GoVersion      string                 `json:"go_version"`
+	OS             string                 `json:"os"`
+	Arch           string                 `json:"arch"`
+	StartedAt      string                 `json:"started_at"`
+	UptimeSeconds  int64                  `json:"uptime_seconds"`
+	TwitchBot      string                 `json:"twitch_bot,omitempty"`
+	TwitchChannels []string               `json:"twitch_channels,omitempty"`
+	Capabilities   []events.CapabilityDTO `json:"capabilities,omitempty"`
+}
+
+func (r *Runtime) Diagnostics(context.Context) any {
+	diag := RuntimeDiagnostics{
+		GoVersion: goruntime.Version(),
+		OS:        goruntime.GOOS,
+		Arch:      goruntime.GOARCH,
+	}
+	if r == nil {
+		return diag
+	}
+	diag.StartedAt = r.startedAt.UTC().Format(time.RFC3339)
+	diag.UptimeSeconds = int64(time.Since(r.startedAt) / time.Second)
+	diag.Capabilities = r.Capabilities()
+
+	r.twitchMu.RLock()
+	diag.TwitchBot = r.twitchBotLogin
+	diag.TwitchChannels = append([]string(nil), r.twitchChannels...)
+	r.twitchMu.RUnlock()
+	return diag
+}
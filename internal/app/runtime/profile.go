@@ -0,0 +1,371 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/usecase/commands"
+	configprofileusecase "zhatBot/internal/usecase/configprofile"
+	trackersusecase "zhatBot/internal/usecase/trackers"
+)
+
+// Secciones del perfil de configuración. No hay sección de credenciales ni
+// de la grabación de depuración (guarda rutas de esta máquina); tampoco de
+// datos de la audiencia (notas, conteos, ranking, lurkers actuales).
+const (
+	profileSectionCommands     = "commands"
+	profileSectionTemplates    = "notification_templates"
+	profileSectionTrackers     = "trackers"
+	profileSectionOverlays     = "overlays"
+	profileSectionTTS          = "tts"
+	profileSectionFollows      = "follows"
+	profileSectionLurkers      = "lurkers"
+	profileSectionModeration   = "moderation"
+	profileSectionStreamSync   = "stream_sync"
+	profileSectionLinkPreviews = "link_previews"
+	profileSectionLeaderboard  = "leaderboard"
+	profileSectionGames        = "games"
+	profileSectionShoutouts    = "auto_shoutout"
+	profileSectionGreetings    = "greetings"
+	profileSectionBotConflicts = "bot_conflicts"
+)
+
+type commandsProfile struct {
+	CooldownFeedback string                        `json:"cooldown_feedback,omitempty"`
+	PermissionReply  string                        `json:"permission_reply,omitempty"`
+	Commands         []commands.CommandMutationDTO `json:"commands"`
+}
+
+type trackerProfile struct {
+	Name           string `json:"name"`
+	Term           string `json:"term"`
+	Kind           string `json:"kind"`
+	MilestoneEvery int    `json:"milestone_every"`
+	Enabled        bool   `json:"enabled"`
+}
+
+type overlayProfile struct {
+	Name   string         `json:"name"`
+	Config map[string]any `json:"config"`
+}
+
+type ttsProfile struct {
+	Voice     string                       `json:"voice,omitempty"`
+	Enabled   *bool                        `json:"enabled,omitempty"`
+	SkipVotes int                          `json:"skip_votes,omitempty"`
+	AutoPause *domain.TTSAutoPauseSettings `json:"auto_pause,omitempty"`
+}
+
+// ProfileExport arma el perfil con la configuración compartible del bot.
+func (r *Runtime) ProfileExport(ctx context.Context) (configprofileusecase.Document, error) {
+	if r == nil || r.configProfile == nil {
+		return configprofileusecase.Document{}, fmt.Errorf("profile unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.configProfile.Export(ctx)
+}
+
+// ProfileImport aplica las secciones pedidas de un perfil exportado.
+func (r *Runtime) ProfileImport(ctx context.Context, raw []byte, sections []string, overwrite bool) (configprofileusecase.Report, error) {
+	if r == nil || r.configProfile == nil {
+		return configprofileusecase.Report{}, fmt.Errorf("profile unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.configProfile.Import(ctx, raw, sections, overwrite)
+}
+
+// newConfigProfile arma las secciones en el orden en que se importan. Los
+// servicios se leen al usarse porque algunos (TTS) se crean después.
+func (r *Runtime) newConfigProfile() *configprofileusecase.Service {
+	return configprofileusecase.NewService(
+		r.commandsProfileSection(),
+		r.templatesProfileSection(),
+		r.trackersProfileSection(),
+		r.overlaysProfileSection(),
+		r.ttsProfileSection(),
+		configprofileusecase.Settings(profileSectionFollows, r.follows.Settings, r.follows.Update),
+		configprofileusecase.Settings(profileSectionLurkers, r.lurkers.Settings, r.lurkers.Update),
+		configprofileusecase.Settings(profileSectionModeration, r.spamRule.Settings, r.spamRule.Update),
+		configprofileusecase.Settings(profileSectionStreamSync, r.streamSync.Settings, r.streamSync.Update),
+		configprofileusecase.Settings(profileSectionLinkPreviews, r.links.Settings, r.links.Update),
+		configprofileusecase.Settings(profileSectionLeaderboard, r.leaderboard.Settings, r.leaderboard.Update),
+		configprofileusecase.Settings(profileSectionGames, r.games.Settings, r.games.Update),
+		configprofileusecase.Settings(profileSectionShoutouts, r.shoutouts.Settings, r.shoutouts.Update),
+		configprofileusecase.Settings(profileSectionGreetings, r.greetings.Settings, r.greetings.Update),
+		configprofileusecase.Settings(profileSectionBotConflicts, r.conflicts.Settings, r.conflicts.Update),
+	)
+}
+
+func (r *Runtime) commandsProfileSection() configprofileusecase.Section {
+	return configprofileusecase.Section{
+		Name:    profileSectionCommands,
+		Version: 1,
+		Export: func(ctx context.Context) (any, error) {
+			list, err := r.commandSvc.List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out := commandsProfile{
+				CooldownFeedback: r.commandSvc.DefaultCooldownFeedback(),
+				PermissionReply:  r.commandSvc.DefaultPermissionReply(),
+				Commands:         []commands.CommandMutationDTO{},
+			}
+			for _, cmd := range list {
+				if cmd.Source == commands.CommandSourceCustom {
+					out.Commands = append(out.Commands, cmd.Mutation())
+				}
+			}
+			return out, nil
+		},
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (configprofileusecase.Result, error) {
+			var in commandsProfile
+			if err := json.Unmarshal(data, &in); err != nil {
+				return configprofileusecase.Result{}, fmt.Errorf("%w: %v", configprofileusecase.ErrInvalidProfile, err)
+			}
+			var result configprofileusecase.Result
+			if in.CooldownFeedback != "" {
+				if _, err := r.commandSvc.SetDefaultCooldownFeedback(ctx, in.CooldownFeedback); err != nil {
+					result.Fail("cooldown_feedback", err)
+				}
+			}
+			if in.PermissionReply != "" {
+				if _, err := r.commandSvc.SetDefaultPermissionReply(ctx, in.PermissionReply); err != nil {
+					result.Fail("permission_reply", err)
+				}
+			}
+
+			list, err := r.commandSvc.List(ctx)
+			if err != nil {
+				return result, err
+			}
+			existing := make(map[string]bool, len(list))
+			for _, cmd := range list {
+				if cmd.Source == commands.CommandSourceCustom {
+					existing[strings.ToLower(cmd.Name)] = true
+				}
+			}
+			for _, cmd := range in.Commands {
+				if existing[strings.ToLower(strings.TrimSpace(cmd.Name))] && !overwrite {
+					result.Skipped++
+					continue
+				}
+				if _, err := r.commandSvc.Upsert(ctx, cmd); err != nil {
+					result.Fail(cmd.Name, err)
+					continue
+				}
+				result.Applied++
+			}
+			return result, nil
+		},
+	}
+}
+
+func (r *Runtime) templatesProfileSection() configprofileusecase.Section {
+	return configprofileusecase.Section{
+		Name:    profileSectionTemplates,
+		Version: 1,
+		// solo las plantillas cambiadas: las de fábrica ya las tiene cualquiera
+		Export: func(ctx context.Context) (any, error) {
+			out := map[string]string{}
+			for _, item := range r.notifier.Templates() {
+				if !item.Default {
+					out[item.Type] = item.Template
+				}
+			}
+			return out, nil
+		},
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (configprofileusecase.Result, error) {
+			var in map[string]string
+			if err := json.Unmarshal(data, &in); err != nil {
+				return configprofileusecase.Result{}, fmt.Errorf("%w: %v", configprofileusecase.ErrInvalidProfile, err)
+			}
+			custom := map[string]bool{}
+			for _, item := range r.notifier.Templates() {
+				custom[item.Type] = !item.Default
+			}
+			kinds := make([]string, 0, len(in))
+			for kind := range in {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			var result configprofileusecase.Result
+			for _, kind := range kinds {
+				template := in[kind]
+				notificationType, err := domain.ParseNotificationType(kind)
+				if err != nil {
+					result.Fail(kind, err)
+					continue
+				}
+				if custom[string(notificationType)] && !overwrite {
+					result.Skipped++
+					continue
+				}
+				if _, err := r.notifier.SetTemplate(ctx, notificationType, template); err != nil {
+					result.Fail(kind, err)
+					continue
+				}
+				result.Applied++
+			}
+			return result, nil
+		},
+	}
+}
+
+func (r *Runtime) trackersProfileSection() configprofileusecase.Section {
+	return configprofileusecase.Section{
+		Name:    profileSectionTrackers,
+		Version: 1,
+		// los conteos son del canal, no de la configuración
+		Export: func(ctx context.Context) (any, error) {
+			out := []trackerProfile{}
+			for _, tracker := range r.trackers.List() {
+				out = append(out, trackerProfile{
+					Name:           tracker.Name,
+					Term:           tracker.Term,
+					Kind:           tracker.Kind,
+					MilestoneEvery: tracker.MilestoneEvery,
+					Enabled:        tracker.Enabled,
+				})
+			}
+			return out, nil
+		},
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (configprofileusecase.Result, error) {
+			var in []trackerProfile
+			if err := json.Unmarshal(data, &in); err != nil {
+				return configprofileusecase.Result{}, fmt.Errorf("%w: %v", configprofileusecase.ErrInvalidProfile, err)
+			}
+			existing := map[string]bool{}
+			for _, tracker := range r.trackers.List() {
+				existing[strings.ToLower(tracker.Name)] = true
+			}
+			var result configprofileusecase.Result
+			for _, tracker := range in {
+				if existing[strings.ToLower(strings.TrimSpace(tracker.Name))] && !overwrite {
+					result.Skipped++
+					continue
+				}
+				milestone, enabled := tracker.MilestoneEvery, tracker.Enabled
+				_, err := r.trackers.Upsert(ctx, trackersusecase.TrackerMutationDTO{
+					Name:           tracker.Name,
+					Term:           tracker.Term,
+					Kind:           tracker.Kind,
+					MilestoneEvery: &milestone,
+					Enabled:        &enabled,
+				})
+				if err != nil {
+					result.Fail(tracker.Name, err)
+					continue
+				}
+				result.Applied++
+			}
+			return result, nil
+		},
+	}
+}
+
+func (r *Runtime) overlaysProfileSection() configprofileusecase.Section {
+	return configprofileusecase.Section{
+		Name:    profileSectionOverlays,
+		Version: 1,
+		Export: func(ctx context.Context) (any, error) {
+			list, err := r.overlays.List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]overlayProfile, 0, len(list))
+			for _, item := range list {
+				out = append(out, overlayProfile{Name: item.Name, Config: item.Config})
+			}
+			return out, nil
+		},
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (configprofileusecase.Result, error) {
+			var in []overlayProfile
+			if err := json.Unmarshal(data, &in); err != nil {
+				return configprofileusecase.Result{}, fmt.Errorf("%w: %v", configprofileusecase.ErrInvalidProfile, err)
+			}
+			list, err := r.overlays.List(ctx)
+			if err != nil {
+				return configprofileusecase.Result{}, err
+			}
+			existing := make(map[string]bool, len(list))
+			for _, item := range list {
+				existing[item.Name] = true
+			}
+			var result configprofileusecase.Result
+			for _, item := range in {
+				if existing[strings.ToLower(strings.TrimSpace(item.Name))] && !overwrite {
+					result.Skipped++
+					continue
+				}
+				if _, err := r.overlays.Set(ctx, item.Name, item.Config); err != nil {
+					result.Fail(item.Name, err)
+					continue
+				}
+				result.Applied++
+			}
+			return result, nil
+		},
+	}
+}
+
+func (r *Runtime) ttsProfileSection() configprofileusecase.Section {
+	return configprofileusecase.Section{
+		Name:    profileSectionTTS,
+		Version: 1,
+		Export: func(ctx context.Context) (any, error) {
+			if r.ttsServ == nil {
+				return ttsProfile{}, nil
+			}
+			enabled := r.ttsServ.Enabled(ctx)
+			autoPause := r.ttsServ.AutoPauseSettings()
+			return ttsProfile{
+				Voice:     r.ttsServ.CurrentVoice(ctx).Code,
+				Enabled:   &enabled,
+				SkipVotes: r.ttsServ.SkipVotesRequired(ctx),
+				AutoPause: &autoPause,
+			}, nil
+		},
+		// la voz puede no existir en el motor de TTS de esta máquina; el resto
+		// se aplica igual
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (configprofileusecase.Result, error) {
+			if r.ttsServ == nil {
+				return configprofileusecase.Result{}, fmt.Errorf("tts service unavailable")
+			}
+			var in ttsProfile
+			if err := json.Unmarshal(data, &in); err != nil {
+				return configprofileusecase.Result{}, fmt.Errorf("%w: %v", configprofileusecase.ErrInvalidProfile, err)
+			}
+			var result configprofileusecase.Result
+			apply := func(field string, err error) {
+				if err != nil {
+					result.Fail(field, err)
+					return
+				}
+				result.Applied++
+			}
+			if in.Voice != "" {
+				_, err := r.ttsServ.SetVoice(ctx, in.Voice)
+				apply("voice", err)
+			}
+			if in.Enabled != nil {
+				apply("enabled", r.ttsServ.SetEnabled(ctx, *in.Enabled))
+			}
+			if in.SkipVotes > 0 {
+				apply("skip_votes", r.ttsServ.SetSkipVotesRequired(ctx, in.SkipVotes))
+			}
+			if in.AutoPause != nil {
+				_, err := r.ttsServ.SetAutoPauseSettings(ctx, *in.AutoPause)
+				apply("auto_pause", err)
+			}
+			return result, nil
+		},
+	}
+}
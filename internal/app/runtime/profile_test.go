@@ -0,0 +1,240 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/usecase/commands"
+	configprofileusecase "zhatBot/internal/usecase/configprofile"
+	followsusecase "zhatBot/internal/usecase/follows"
+	lurkersusecase "zhatBot/internal/usecase/lurkers"
+	"zhatBot/internal/usecase/notifications"
+	overlaysusecase "zhatBot/internal/usecase/overlays"
+	trackersusecase "zhatBot/internal/usecase/trackers"
+)
+
+// newProfileRuntime arma un runtime con una base propia y solo los servicios
+// que exporta el perfil (los de TTS, juegos, etc. necesitan más piezas).
+func newProfileRuntime(t *testing.T) *Runtime {
+	t.Helper()
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	manager, err := commands.NewCustomCommandManager(ctx, store)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	commandSvc := commands.NewService(manager)
+	commandSvc.SetSettingsRepository(store)
+	notifier := notifications.NewService(store)
+	notifier.SetTemplateRepository(store)
+	trackerSvc, err := trackersusecase.NewService(ctx, store, nil)
+	if err != nil {
+		t.Fatalf("trackers: %v", err)
+	}
+	follows := followsusecase.NewService(notifier, nil)
+	follows.SetSettingsRepository(store)
+
+	r := &Runtime{
+		credStore:   store,
+		commandSvc:  commandSvc,
+		customs:     manager,
+		notifier:    notifier,
+		trackers:    trackerSvc,
+		overlays:    overlaysusecase.NewService(store),
+		leaderboard: trackersusecase.NewLeaderboard(store),
+		lurkers:     lurkersusecase.NewService(store),
+		follows:     follows,
+	}
+	r.configProfile = configprofileusecase.NewService(
+		r.commandsProfileSection(),
+		r.templatesProfileSection(),
+		r.trackersProfileSection(),
+		r.overlaysProfileSection(),
+		configprofileusecase.Settings(profileSectionFollows, r.follows.Settings, r.follows.Update),
+		configprofileusecase.Settings(profileSectionLurkers, r.lurkers.Settings, r.lurkers.Update),
+		configprofileusecase.Settings(profileSectionLeaderboard, r.leaderboard.Settings, r.leaderboard.Update),
+	)
+	return r
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func seedProfile(t *testing.T, r *Runtime) {
+	t.Helper()
+	ctx := context.Background()
+	for _, cmd := range []commands.CommandMutationDTO{
+		{Name: "discord", Response: ptr("Únete: discord.gg/zero"), Aliases: ptr([]string{"dc"}), CooldownSeconds: ptr(30)},
+		{Name: "redes", Responses: ptr([]domain.WeightedResponse{{Text: "twitter", Weight: 1}, {Text: "ig", Weight: 3}}),
+			Permissions: ptr([]domain.CommandAccessRole{domain.CommandAccessModerators})},
+	} {
+		if _, err := r.commandSvc.Upsert(ctx, cmd); err != nil {
+			t.Fatalf("Upsert %s: %v", cmd.Name, err)
+		}
+	}
+	if _, err := r.commandSvc.SetDefaultCooldownFeedback(ctx, string(domain.CooldownFeedbackWhisper)); err != nil {
+		t.Fatalf("SetDefaultCooldownFeedback: %v", err)
+	}
+	if _, err := r.notifier.SetTemplate(ctx, domain.NotificationFollow, "{username} ya sigue"); err != nil {
+		t.Fatalf("SetTemplate: %v", err)
+	}
+	if _, err := r.trackers.Upsert(ctx, trackersusecase.TrackerMutationDTO{Name: "muertes", Term: "F", Kind: "word", MilestoneEvery: ptr(10), Enabled: ptr(true)}); err != nil {
+		t.Fatalf("tracker: %v", err)
+	}
+	if _, err := r.overlays.Set(ctx, "chat-main", map[string]any{"font_size": 24, "hide_commands": true}); err != nil {
+		t.Fatalf("overlay: %v", err)
+	}
+	settings := r.follows.Settings()
+	settings.BotThreshold = 42
+	if _, err := r.follows.Update(ctx, settings); err != nil {
+		t.Fatalf("follows: %v", err)
+	}
+	if _, err := r.lurkers.Update(ctx, domain.LurkSettings{LurkTemplate: "{user} se va", UnlurkTemplate: "{user} volvió", AutoUnlurk: true}); err != nil {
+		t.Fatalf("lurkers: %v", err)
+	}
+	if _, err := r.leaderboard.Update(ctx, domain.LeaderboardSettings{Scope: domain.LeaderboardAllTime, Size: 10}); err != nil {
+		t.Fatalf("leaderboard: %v", err)
+	}
+}
+
+// profileState resume lo que debería quedar igual después de importar.
+type profileState struct {
+	Commands    []commands.CommandMutationDTO
+	Cooldown    string
+	Templates   []notifications.TemplateDTO
+	Trackers    []trackersusecase.TrackerDTO
+	Overlays    []overlaysusecase.ConfigDTO
+	Follows     domain.FollowAnnounceSettings
+	Lurkers     domain.LurkSettings
+	Leaderboard domain.LeaderboardSettings
+}
+
+func snapshotProfile(t *testing.T, r *Runtime) profileState {
+	t.Helper()
+	ctx := context.Background()
+	list, err := r.commandSvc.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var custom []commands.CommandMutationDTO
+	for _, cmd := range list {
+		if cmd.Source == commands.CommandSourceCustom {
+			custom = append(custom, cmd.Mutation())
+		}
+	}
+	overlays, err := r.overlays.List(ctx)
+	if err != nil {
+		t.Fatalf("overlays: %v", err)
+	}
+	trackers := r.trackers.List()
+	for i := range trackers {
+		trackers[i].Count = 0
+	}
+	return profileState{
+		Commands:    custom,
+		Cooldown:    r.commandSvc.DefaultCooldownFeedback(),
+		Templates:   r.notifier.Templates(),
+		Trackers:    trackers,
+		Overlays:    overlays,
+		Follows:     r.follows.Settings(),
+		Lurkers:     r.lurkers.Settings(),
+		Leaderboard: r.leaderboard.Settings(),
+	}
+}
+
+func TestProfileRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newProfileRuntime(t)
+	seedProfile(t, source)
+
+	doc, err := source.ProfileExport(ctx)
+	if err != nil {
+		t.Fatalf("ProfileExport: %v", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	target := newProfileRuntime(t)
+	report, err := target.ProfileImport(ctx, raw, nil, false)
+	if err != nil {
+		t.Fatalf("ProfileImport: %v", err)
+	}
+	for _, section := range report.Sections {
+		if section.Status != configprofileusecase.StatusImported {
+			t.Errorf("sección %s = %+v", section.Section, section)
+		}
+	}
+
+	want, got := snapshotProfile(t, source), snapshotProfile(t, target)
+	if len(want.Commands) != 2 || len(want.Trackers) != 1 || len(want.Overlays) != 1 {
+		t.Fatalf("el perfil de origen quedó incompleto: %+v", want)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("el perfil importado no coincide:\nquería %+v\nquedó  %+v", want, got)
+	}
+}
+
+func TestProfileImportKeepsExistingWithoutOverwrite(t *testing.T) {
+	ctx := context.Background()
+	source := newProfileRuntime(t)
+	seedProfile(t, source)
+	doc, err := source.ProfileExport(ctx)
+	if err != nil {
+		t.Fatalf("ProfileExport: %v", err)
+	}
+	raw, _ := json.Marshal(doc)
+
+	target := newProfileRuntime(t)
+	if _, err := target.commandSvc.Upsert(ctx, commands.CommandMutationDTO{Name: "discord", Response: ptr("el mío")}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	report, err := target.ProfileImport(ctx, raw, []string{"commands", "timers"}, false)
+	if err != nil {
+		t.Fatalf("ProfileImport: %v", err)
+	}
+	if len(report.Sections) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if got := report.Sections[0]; got.Section != "commands" || got.Applied != 1 || got.Skipped != 1 || got.Status != configprofileusecase.StatusImported {
+		t.Fatalf("commands = %+v", got)
+	}
+	if got := report.Sections[1]; got.Section != "timers" || got.Status != configprofileusecase.StatusFailed {
+		t.Fatalf("una sección desconocida = %+v", got)
+	}
+	if cmd := target.customs.Find("discord"); cmd == nil || cmd.Response != "el mío" {
+		t.Fatalf("se pisó el comando existente: %+v", cmd)
+	}
+
+	// con overwrite se reemplaza
+	if _, err := target.ProfileImport(ctx, raw, []string{"commands"}, true); err != nil {
+		t.Fatalf("ProfileImport: %v", err)
+	}
+	if cmd := target.customs.Find("discord"); cmd == nil || cmd.Response != "Únete: discord.gg/zero" {
+		t.Fatalf("overwrite no reemplazó el comando: %+v", cmd)
+	}
+}
+
+func TestProfileExportHasNoCredentials(t *testing.T) {
+	ctx := context.Background()
+	r := newProfileRuntime(t)
+	seedProfile(t, r)
+	if err := r.credStore.Save(ctx, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "secreto-123"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	doc, err := r.ProfileExport(ctx)
+	if err != nil {
+		t.Fatalf("ProfileExport: %v", err)
+	}
+	raw, _ := json.Marshal(doc)
+	if strings.Contains(string(raw), "secreto-123") || strings.Contains(string(raw), "access_token") {
+		t.Fatalf("el perfil incluye credenciales: %s", raw)
+	}
+}
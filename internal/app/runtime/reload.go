@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"zhatBot/internal/domain"
+)
+
+type namedReloadable struct {
+	name string
+	svc  domain.Reloadable
+}
+
+// reloadFunc adapta una función de carga a domain.Reloadable.
+type reloadFunc func(ctx context.Context) error
+
+func (f reloadFunc) Reload(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ReloadSettings vuelve a leer de sqlite las cachés de los servicios (útil si
+// la configuración se editó a mano o desde otro cliente). Sigue con el resto
+// aunque alguno falle y devuelve todos los errores juntos.
+func (r *Runtime) ReloadSettings(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, item := range r.reloadables {
+		if item.svc == nil {
+			continue
+		}
+		if err := item.svc.Reload(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", item.name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		log.Printf("settings: recarga con errores: %v", err)
+		return err
+	}
+	log.Printf("settings: configuración recargada")
+	return nil
+}
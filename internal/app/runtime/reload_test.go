@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/usecase/commands"
+	"zhatBot/internal/usecase/trackers"
+	ttsusecase "zhatBot/internal/usecase/tts"
+)
+
+func TestReloadSettingsContinuesAndJoinsErrors(t *testing.T) {
+	var calls []string
+	reloader := func(name string, err error) namedReloadable {
+		return namedReloadable{name: name, svc: reloadFunc(func(context.Context) error {
+			calls = append(calls, name)
+			return err
+		})}
+	}
+	r := &Runtime{reloadables: []namedReloadable{
+		reloader("tts", nil),
+		reloader("commands", errors.New("db cerrada")),
+		{name: "sin-servicio"},
+		reloader("trackers", errors.New("tabla rota")),
+		reloader("pause", nil),
+	}}
+
+	err := r.ReloadSettings(context.Background())
+	if err == nil {
+		t.Fatal("expected the joined errors")
+	}
+	if got := strings.Join(calls, ","); got != "tts,commands,trackers,pause" {
+		t.Fatalf("reloaded %s, want every service despite failures", got)
+	}
+	for _, want := range []string{"commands: db cerrada", "trackers: tabla rota"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestReloadSettingsPropagatesExternalEdits(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	ttsSvc := ttsusecase.NewService(store, "")
+	trackerSvc, err := trackers.NewService(ctx, store, nil)
+	if err != nil {
+		t.Fatalf("trackers.NewService: %v", err)
+	}
+	router := commands.NewRouter(domain.DefaultCommandPrefix)
+	router.SetPauseStore(store)
+	router.SetPrefixStore(store)
+
+	r := &Runtime{reloadables: []namedReloadable{
+		{name: "tts", svc: ttsSvc},
+		{name: "trackers", svc: trackerSvc},
+		{name: "pause", svc: reloadFunc(router.LoadPause)},
+		{name: "prefix", svc: reloadFunc(router.LoadPrefix)},
+	}}
+
+	// otro cliente edita la configuración directamente en sqlite
+	autoPause := domain.TTSAutoPauseSettings{Enabled: true, PauseAbove: 50, ResumeBelow: 10, ResumeAfterMinutes: 5}
+	if err := store.SetTTSAutoPause(ctx, autoPause); err != nil {
+		t.Fatalf("SetTTSAutoPause: %v", err)
+	}
+	if err := store.UpsertTracker(ctx, &domain.WordTracker{Name: "gg", Term: "gg", Kind: domain.TrackerKindWord, Enabled: true}); err != nil {
+		t.Fatalf("UpsertTracker: %v", err)
+	}
+	if err := store.SetBotPaused(ctx, true); err != nil {
+		t.Fatalf("SetBotPaused: %v", err)
+	}
+	if err := store.SetCommandPrefix(ctx, "?"); err != nil {
+		t.Fatalf("SetCommandPrefix: %v", err)
+	}
+
+	if ttsSvc.AutoPauseSettings() == autoPause || len(trackerSvc.List()) != 0 || router.Paused() || router.Prefix() != "!" {
+		t.Fatal("services picked up the edits before reloading")
+	}
+
+	if err := r.ReloadSettings(ctx); err != nil {
+		t.Fatalf("ReloadSettings: %v", err)
+	}
+
+	if got := ttsSvc.AutoPauseSettings(); got != autoPause {
+		t.Fatalf("tts auto-pause = %+v, want %+v", got, autoPause)
+	}
+	if got := trackerSvc.List(); len(got) != 1 || got[0].Name != "gg" {
+		t.Fatalf("trackers = %+v, want gg", got)
+	}
+	if !router.Paused() {
+		t.Fatal("router pause was not reloaded")
+	}
+	if got := router.Prefix(); got != "?" {
+		t.Fatalf("router prefix = %q, want ?", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nicklaw5/helix/v2"
@@ -20,51 +21,129 @@ import (
 	ttsruntime "zhatBot/internal/app/tts/runner"
 	"zhatBot/internal/domain"
 	"zhatBot/internal/infrastructure/config"
+	linkpreviewinfra "zhatBot/internal/infrastructure/linkpreview"
 	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
 	twitchinfra "zhatBot/internal/infrastructure/platform/twitch"
 	twitchadapter "zhatBot/internal/interface/adapters/twitch"
 	ws "zhatBot/internal/interface/api/ws"
 	"zhatBot/internal/interface/outs"
+	awayusecase "zhatBot/internal/usecase/away"
+	botconflictsusecase "zhatBot/internal/usecase/botconflicts"
 	categoryusecase "zhatBot/internal/usecase/category"
 	"zhatBot/internal/usecase/commands"
+	configprofileusecase "zhatBot/internal/usecase/configprofile"
+	connectionsusecase "zhatBot/internal/usecase/connections"
+	countdownusecase "zhatBot/internal/usecase/countdown"
+	countersusecase "zhatBot/internal/usecase/counters"
 	credentialsusecase "zhatBot/internal/usecase/credentials"
+	followsusecase "zhatBot/internal/usecase/follows"
+	gamesusecase "zhatBot/internal/usecase/games"
+	greetingsusecase "zhatBot/internal/usecase/greetings"
 	"zhatBot/internal/usecase/handle_message"
+	linkpreviewusecase "zhatBot/internal/usecase/linkpreview"
+	lurkersusecase "zhatBot/internal/usecase/lurkers"
+	"zhatBot/internal/usecase/moderation"
 	"zhatBot/internal/usecase/notifications"
+	overlaysusecase "zhatBot/internal/usecase/overlays"
+	profilesusecase "zhatBot/internal/usecase/profiles"
+	quotesusecase "zhatBot/internal/usecase/quotes"
+	readonlyusecase "zhatBot/internal/usecase/readonly"
+	shoutoutsusecase "zhatBot/internal/usecase/shoutouts"
 	statususecase "zhatBot/internal/usecase/status"
 	"zhatBot/internal/usecase/stream"
+	trackersusecase "zhatBot/internal/usecase/trackers"
 	ttsusecase "zhatBot/internal/usecase/tts"
+	usernotesusecase "zhatBot/internal/usecase/usernotes"
 )
 
 type Options struct{}
 
 type Runtime struct {
-	ctx        context.Context
-	cancel     context.CancelFunc
-	cfg        *config.Config
-	credStore  *sqlitestorage.CredentialStore
-	refresher  *credentialsusecase.Refresher
-	platform   *app.PlatformManager
-	wsServer   *ws.Server
-	twitchAd   *twitchadapter.Adapter
-	multiOut   *outs.MultiSender
-	bus        *events.Bus
-	commandSvc *commands.Service
-	ttsServ    *ttsusecase.Service
-	ttsRunner  *ttsruntime.Runner
-	wg         sync.WaitGroup
-	started    bool
-	status     *statususecase.Resolver
-	category   *categoryusecase.Service
-	dispatcher func(context.Context, domain.Message) error
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cfg         *config.Config
+	credStore   *sqlitestorage.CredentialStore
+	refresher   *credentialsusecase.Refresher
+	credHooks   *credentialsusecase.HookDispatcher
+	platform    *app.PlatformManager
+	wsServer    *ws.Server
+	twitchAd    *twitchadapter.Adapter
+	multiOut    *outs.MultiSender
+	bus         *events.Bus
+	commandSvc  *commands.Service
+	ttsServ     *ttsusecase.Service
+	ttsRunner   *ttsruntime.Runner
+	ttsHistory  *ttsusecase.History
+	rawEvents   *notifications.RawEventStore
+	wg          sync.WaitGroup
+	started     bool
+	status      *statususecase.Resolver
+	category    *categoryusecase.Service
+	streamSync  *categoryusecase.Sync
+	moderation  *moderation.Service
+	spamRule    *moderation.SpamRule
+	overlays    *overlaysusecase.Service
+	trackers    *trackersusecase.Service
+	leaderboard *trackersusecase.Leaderboard
+	titles      *stream.Resolver
+	customs     *commands.CustomCommandManager
+	router      *commands.Router
+	modActions  *moderation.Actions
+	dispatcher  func(context.Context, domain.Message) error
+	gate        *startupGate
+	startedAt   time.Time
+	readOnly    *readonlyusecase.Mode
+	notifier    *notifications.Service
+	recorder    *debugRecorder
+	connStatus  *connectionsusecase.Tracker
+	testSender  *connectionsusecase.TestSender
+	presence    *connectionsusecase.Presence
+	follows     *followsusecase.Service
+	lurkers     *lurkersusecase.Service
+	away        *awayusecase.Service
+	profiles    *profilesusecase.Service
+	helixQuota  *twitchinfra.Quota
+	links       *linkpreviewusecase.Service
+	userNotes   *usernotesusecase.Service
+	countdowns  *countdownusecase.Service
+	quotes      *quotesusecase.Service
+	games       *gamesusecase.Service
+	counters    *countersusecase.Service
+	shoutouts   *shoutoutsusecase.Service
+	greetings   *greetingsusecase.Service
+	conflicts   *botconflictsusecase.Service
+	// configProfile exporta e importa la configuración compartible
+	configProfile *configprofileusecase.Service
+	msgSeq        atomic.Uint64
+
+	// servicios con el token del streamer; se crean en setupTwitchStreamer
+	streamerMu          sync.RWMutex
+	twitchAPI           domain.TwitchChannelService
+	twitchAPIToken      string
+	twitchAPILogin      string
+	twitchBroadcasterID string
+
+	capMu        sync.Mutex
+	capabilities map[string]events.CapabilityDTO
+
+	reloadables []namedReloadable
 
 	twitchMu            sync.RWMutex
 	twitchCancel        context.CancelFunc
 	twitchDone          chan struct{}
 	twitchBotLogin      string
 	twitchBotToken      string
+	twitchBotUserID     string
+	twitchBotSvc        domain.TwitchChannelService
+	twitchBotSvcToken   string
 	twitchChannels      []string
 	twitchStreamerLogin string
+	twitchAccount       events.TwitchAccountDTO
 	twitchNoticeHandler twitchadapter.UserNoticeHandler
+
+	// profileRetries marca los roles de Twitch con reintentos de perfil en curso.
+	profileMu      sync.Mutex
+	profileRetries map[string]bool
 }
 
 func Start(ctx context.Context, _ Options) (*Runtime, error) {
@@ -78,23 +157,72 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 		cancel()
 		return nil, fmt.Errorf("load config: %w", err)
 	}
-
-	dbPath := cfg.DatabasePath
-	if strings.TrimSpace(dbPath) == "" {
-		dbPath = filepath.Join("data", "zhatbot.db")
+	for _, problem := range cfg.Problems() {
+		log.Printf("config: %s", problem)
 	}
+	for _, legacy := range cfg.LegacyRedirects() {
+		log.Printf("config: %s apunta a %s, que ya no existe; se usa %s", legacy.Key, legacy.Value, legacy.Suggested)
+	}
+
+	dbPath := resolveDBPath(cfg)
 
 	credStore, err := sqlitestorage.NewCredentialStore(dbPath)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("sqlite: %w", err)
 	}
+	if merges, err := credStore.ReconcileCredentials(runtimeCtx); err != nil {
+		log.Printf("credentials: no pude normalizar las credenciales guardadas: %v", err)
+	} else {
+		for _, merge := range merges {
+			if len(merge.Removed) == 0 {
+				log.Printf("credentials: %s/%s normalizada (rol guardado como %q)", merge.Platform, merge.Role, merge.Kept)
+				continue
+			}
+			log.Printf("credentials: %s/%s tenía filas duplicadas; se conservó %q y se borraron %q",
+				merge.Platform, merge.Role, merge.Kept, merge.Removed)
+		}
+	}
 
 	categorySvc := categoryusecase.NewService(categoryusecase.Config{})
+	streamSync := categoryusecase.NewSync(categorySvc, credStore)
+	if err := streamSync.Load(runtimeCtx); err != nil {
+		log.Printf("stream-sync: no pude cargar la configuración: %v", err)
+	}
 	resolver := stream.NewResolver(nil, nil)
 	multiOut := outs.NewMultiSender()
 	eventLogger := notifications.NewEventLogger()
+	// RAW_EVENTS_LOG guarda además los eventos crudos en raw_events para
+	// depurar o reprocesar las alertas; RAW_EVENTS_RETENTION_DAYS es cuánto
+	// se conservan (3 días por defecto).
+	var rawEvents *notifications.RawEventStore
+	if envTrue("RAW_EVENTS_LOG") {
+		rawEvents = notifications.NewRawEventStore(credStore, time.Duration(envInt("RAW_EVENTS_RETENTION_DAYS"))*24*time.Hour)
+		eventLogger.SetStore(rawEvents)
+	}
+	notifier := notifications.NewService(credStore)
+	notifier.SetTemplateRepository(credStore)
+	if err := notifier.LoadTemplates(runtimeCtx); err != nil {
+		log.Printf("notifications: no pude cargar las plantillas: %v", err)
+	}
+	notifier.SetGiftAggregation(notifications.GiftAggregation{
+		Disabled:       envFalse("NOTIFICATIONS_GIFT_AGGREGATION"),
+		Window:         time.Duration(envInt("NOTIFICATIONS_GIFT_WINDOW_SECONDS")) * time.Second,
+		MaxDelay:       time.Duration(envInt("NOTIFICATIONS_GIFT_MAX_DELAY_SECONDS")) * time.Second,
+		KeepRecipients: envTrue("NOTIFICATIONS_GIFT_RECIPIENTS"),
+	})
 	statusResolver := statususecase.NewResolver()
+	if ttl := envInt("STREAM_STATUS_CACHE_SECONDS"); ttl != 0 {
+		statusResolver.SetTTL(time.Duration(ttl) * time.Second)
+	}
+	if timeout := envInt("STREAM_STATUS_TIMEOUT_SECONDS"); timeout > 0 {
+		statusResolver.SetFetchTimeout(time.Duration(timeout) * time.Second)
+	}
+	categorySvc.SetChangeHandler(func(ctx context.Context, change categoryusecase.Change) {
+		// que el dashboard no muestre el título viejo hasta que venza la caché
+		statusResolver.Invalidate(change.Platform)
+		streamSync.HandleChange(ctx, change)
+	})
 
 	customManager, err := commands.NewCustomCommandManager(runtimeCtx, credStore)
 	if err != nil {
@@ -102,36 +230,252 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 		credStore.Close()
 		return nil, fmt.Errorf("custom commands: %w", err)
 	}
+	customManager.SetPlatformAvailability(multiOut.Registered)
 
 	bus := events.NewBus()
 
+	helixQuota := twitchinfra.NewQuota(envInt("TWITCH_QUOTA_THRESHOLD"))
+	helixQuota.SetRateLimitedHandler(func(state domain.APIQuotaState) {
+		bus.Publish(events.TopicTwitchRateLimited, state)
+	})
+	statusResolver.SetUnhealthyHandler(func(platform domain.Platform, err error) {
+		bus.Publish(events.TopicStreamUnhealthy, map[string]string{
+			"platform": string(platform),
+			"error":    err.Error(),
+		})
+	})
+
+	recorder := newDebugRecorder(filepath.Join(filepath.Dir(dbPath), "events"), credStore)
+	if err := recorder.Reload(runtimeCtx); err != nil {
+		log.Printf("eventlog: no pude cargar la configuración: %v", err)
+	}
+	notifier.SetListener(func(dto notifications.NotificationDTO) {
+		bus.Publish(events.TopicNotification, dto)
+	})
+
+	readOnly := readonlyusecase.NewMode(credStore)
+	if err := readOnly.Load(runtimeCtx); err != nil {
+		log.Printf("readonly: no pude cargar el modo solo lectura: %v", err)
+	}
+	readOnly.SetChangeHandler(func(enabled bool) {
+		bus.Publish(events.TopicReadOnly, map[string]bool{"enabled": enabled})
+	})
+	multiOut.SetReadOnly(readOnly.Enabled, func(_ context.Context, msg outs.SuppressedMessage) {
+		log.Printf("readonly: mensaje suprimido (%s %s): %q", msg.Platform, msg.ChannelID, msg.Text)
+		bus.Publish(events.TopicChatSuppressed, events.SuppressedMessageDTO{
+			Platform:   string(msg.Platform),
+			ChannelID:  msg.ChannelID,
+			Username:   msg.Username,
+			Text:       msg.Text,
+			Private:    msg.Private,
+			Suppressed: true,
+			Timestamp:  time.Now(),
+		})
+	})
+
 	commandSvc := commands.NewService(customManager)
+	commandSvc.SetSettingsRepository(credStore)
+	if err := commandSvc.LoadSettings(runtimeCtx); err != nil {
+		log.Printf("commands: no pude cargar la configuración: %v", err)
+	}
+
+	// se completa más abajo; el handler de spam lo necesita para cambiar los modos del chat
+	var run *Runtime
+
+	spamRule := moderation.NewSpamRule(
+		moderation.NewSpamDetector(domain.DefaultSpamProtectionSettings()),
+		func(ctx context.Context, burst moderation.SpamBurst, settings domain.SpamProtectionSettings) error {
+			service, broadcasterID, moderatorID := run.twitchModerator()
+			if burst.Platform == domain.PlatformTwitch && service != nil {
+				chatModes := readonlyusecase.ChatSettings(service, readOnly)
+				var err error
+				switch settings.Action {
+				case domain.SpamActionEmoteOnly:
+					err = chatModes.SetEmoteOnly(ctx, broadcasterID, moderatorID, true)
+				case domain.SpamActionSlowMode:
+					err = chatModes.SetSlowMode(ctx, broadcasterID, moderatorID, 30)
+				}
+				if err != nil {
+					log.Printf("moderation: no pude cambiar el modo del chat: %v", err)
+				}
+			}
+			return multiOut.SendMessage(ctx, burst.Platform, burst.ChannelID,
+				fmt.Sprintf("⚠️ Detecté el mismo mensaje de %d usuarios. Por favor no hagan spam.", len(burst.Users)))
+		},
+	)
+	spamRule.SetSettingsRepository(credStore)
+	if err := spamRule.Load(runtimeCtx); err != nil {
+		log.Printf("moderation: no pude cargar la configuración de spam: %v", err)
+	}
+	moderationSvc := moderation.NewService(spamRule)
+	// bans, timeouts y borrados; cada plataforma se registra al tener token
+	modActions := moderation.NewActions()
+	overlaySvc := overlaysusecase.NewService(credStore)
+
+	trackerSvc, err := trackersusecase.NewService(runtimeCtx, credStore, multiOut)
+	if err != nil {
+		cancel()
+		credStore.Close()
+		return nil, fmt.Errorf("trackers: %w", err)
+	}
+	streamSession := trackersusecase.NewStatusSession(statusResolver, 2*time.Minute)
+	trackerSvc.SetSessionFunc(streamSession.ID)
 
-	run := &Runtime{
-		ctx:        runtimeCtx,
-		cancel:     cancel,
-		cfg:        cfg,
-		credStore:  credStore,
-		multiOut:   multiOut,
-		bus:        bus,
-		commandSvc: commandSvc,
-		status:     statusResolver,
-		category:   categorySvc,
+	leaderboard := trackersusecase.NewLeaderboard(credStore)
+	leaderboard.SetSessionFunc(streamSession.ID)
+	if err := leaderboard.Load(runtimeCtx); err != nil {
+		log.Printf("leaderboard: no pude cargar el ranking: %v", err)
 	}
 
+	lurkSvc := lurkersusecase.NewService(credStore)
+	lurkSvc.SetSessionFunc(streamSession.ID)
+	if err := lurkSvc.Load(runtimeCtx); err != nil {
+		log.Printf("lurkers: no pude cargar la configuración: %v", err)
+	}
+
+	connTracker := connectionsusecase.NewTracker(credStore)
+	if err := connTracker.Load(runtimeCtx, domain.PlatformTwitch, domain.PlatformKick); err != nil {
+		log.Printf("connections: no pude cargar las últimas conexiones: %v", err)
+	}
+
+	followSvc := followsusecase.NewService(notifier, func(ctx context.Context, platform domain.Platform, text string) error {
+		return multiOut.SendMessage(ctx, platform, run.defaultChannel(platform), text)
+	})
+	followSvc.SetSettingsRepository(credStore)
+	if err := followSvc.Load(runtimeCtx); err != nil {
+		log.Printf("follows: no pude cargar la configuración: %v", err)
+	}
+	followSvc.SetAlertHandler(func(alert followsusecase.BotAlert) {
+		bus.Publish(events.TopicFollowBotAlert, alert)
+	})
+
+	run = &Runtime{
+		ctx:         runtimeCtx,
+		cancel:      cancel,
+		cfg:         cfg,
+		credStore:   credStore,
+		rawEvents:   rawEvents,
+		multiOut:    multiOut,
+		bus:         bus,
+		commandSvc:  commandSvc,
+		status:      statusResolver,
+		category:    categorySvc,
+		streamSync:  streamSync,
+		helixQuota:  helixQuota,
+		moderation:  moderationSvc,
+		spamRule:    spamRule,
+		overlays:    overlaySvc,
+		trackers:    trackerSvc,
+		leaderboard: leaderboard,
+		titles:      resolver,
+		customs:     customManager,
+		readOnly:    readOnly,
+		notifier:    notifier,
+		recorder:    recorder,
+		connStatus:  connTracker,
+		follows:     followSvc,
+		lurkers:     lurkSvc,
+		gate:        newStartupGate(startupBufferSize),
+		startedAt:   time.Now(),
+	}
+	// los adaptadores pueden arrancar en cuanto llegan credenciales (RefreshAll,
+	// snapshot), así que el handler se fija antes y retiene los mensajes hasta
+	// que todo esté listo.
+	run.dispatcher = run.gate.Dispatch
+
 	platformMgr := app.NewPlatformManager(app.ManagerConfig{
-		Context:  runtimeCtx,
-		Category: categorySvc,
-		Resolver: resolver,
-		Status:   statusResolver,
-		MultiOut: multiOut,
+		Context:    runtimeCtx,
+		Category:   categorySvc,
+		Resolver:   resolver,
+		Status:     statusResolver,
+		MultiOut:   multiOut,
+		ReadOnly:   readOnly,
+		Moderation: modActions,
 		Kick: app.KickConfig{
 			BroadcasterUserID: envInt("KICK_BROADCASTER_USER_ID"),
 			ChatroomID:        envInt("KICK_CHATROOM_ID"),
 			EventHandler:      eventLogger.HandleKickMessage,
+			ConnectionHandler: run.connectionHandler(domain.PlatformKick),
+			DeletionHandler:   run.chatDeletionHandler(),
+			StatusHandler:     run.kickStatusHandler(),
 		},
 	})
 	run.platform = platformMgr
+	platformMgr.SetHandler(run.dispatcher)
+
+	run.testSender = connectionsusecase.NewTestSender(multiOut, run.defaultChannel)
+	run.testSender.SetReadOnly(readOnly.Enabled)
+	run.presence = connectionsusecase.NewPresence(multiOut, run.defaultChannel, credStore)
+	run.presence.SetReadOnly(readOnly.Enabled)
+	if err := run.presence.Load(runtimeCtx); err != nil {
+		log.Printf("presence: no pude cargar los mensajes: %v", err)
+	}
+
+	profileSvc := profilesusecase.NewService(platformMgr.ProfileFetcher, profilesusecase.DefaultCapacity)
+	run.profiles = profileSvc
+
+	linkSvc := linkpreviewusecase.NewService(linkpreviewinfra.NewFetcher(), credStore, linkpreviewusecase.DefaultCapacity)
+	if err := linkSvc.Load(runtimeCtx); err != nil {
+		log.Printf("linkpreview: no pude cargar la configuración: %v", err)
+	}
+	run.links = linkSvc
+
+	userNotes := usernotesusecase.NewService(credStore)
+	if err := userNotes.Load(runtimeCtx); err != nil {
+		log.Printf("usernotes: no pude cargar las notas: %v", err)
+	}
+	userNotes.SetChangeHandler(func(platform domain.Platform, userID string, notes int) {
+		bus.Publish(events.TopicUserNotes, map[string]any{
+			"platform":  string(platform),
+			"user_id":   userID,
+			"notes":     notes,
+			"has_notes": notes > 0,
+		})
+	})
+	run.userNotes = userNotes
+
+	countdownSvc := countdownusecase.NewService(credStore)
+	countdownSvc.SetSender(multiOut)
+	countdownSvc.SetChannelResolver(run.defaultChannel)
+	if err := countdownSvc.Load(runtimeCtx); err != nil {
+		log.Printf("countdown: no pude cargar las cuentas regresivas: %v", err)
+	}
+	run.countdowns = countdownSvc
+
+	quoteSvc := quotesusecase.NewService(credStore)
+	run.quotes = quoteSvc
+
+	gameSvc := gamesusecase.NewService(credStore)
+	if err := gameSvc.Load(runtimeCtx); err != nil {
+		log.Printf("games: no pude cargar la configuración: %v", err)
+	}
+	run.games = gameSvc
+
+	counterSvc := countersusecase.NewService(credStore)
+	if err := counterSvc.Load(runtimeCtx); err != nil {
+		log.Printf("counters: no pude cargar los contadores: %v", err)
+	}
+	run.counters = counterSvc
+
+	shoutoutSvc := shoutoutsusecase.NewService(credStore)
+	shoutoutSvc.SetSessionFunc(streamSession.ID)
+	if err := shoutoutSvc.Load(runtimeCtx); err != nil {
+		log.Printf("shoutouts: no pude cargar la configuración: %v", err)
+	}
+	run.shoutouts = shoutoutSvc
+
+	greetingSvc := greetingsusecase.NewService(credStore, credStore)
+	greetingSvc.SetSessionFunc(streamSession.ID)
+	if err := greetingSvc.Load(runtimeCtx); err != nil {
+		log.Printf("greetings: no pude cargar la configuración: %v", err)
+	}
+	run.greetings = greetingSvc
+
+	conflictSvc := botconflictsusecase.NewService(credStore)
+	if err := conflictSvc.Load(runtimeCtx); err != nil {
+		log.Printf("bot conflicts: no pude cargar la configuración: %v", err)
+	}
+	run.conflicts = conflictSvc
 
 	refresher := credentialsusecase.NewRefresher(
 		credStore,
@@ -145,13 +489,17 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 			RedirectURI:  cfg.KickRedirectURI,
 		},
 	)
-	refresher.RegisterHook(run.handleCredentialUpdate)
+	// hasta Start (después del snapshot inicial) los avisos quedan en cola
+	credHooks := credentialsusecase.NewHookDispatcher(run.handleCredentialUpdate, credentialsusecase.DefaultHookQueueSize)
+	run.credHooks = credHooks
+	refresher.RegisterHook(credHooks.Enqueue)
 	run.refresher = refresher
 
 	if err := refresher.RefreshAll(runtimeCtx); err != nil {
 		log.Printf("error refrescando tokens: %v", err)
 	}
 
+	// cada token se refresca cerca de su vencimiento; esto es la vuelta de respaldo
 	const refreshInterval = 1 * time.Hour
 	refresher.Start(runtimeCtx, refreshInterval)
 
@@ -164,30 +512,86 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 		UserNoticeHandler: eventLogger.HandleTwitchUserNotice,
 	}
 	run.initTwitchState(twitchCfg)
+	run.reconcileTwitchIdentities(runtimeCtx)
 
-	wsAddr := os.Getenv("CHAT_WS_ADDR")
-	if wsAddr == "" {
-		wsAddr = ":8080"
-	}
+	run.configProfile = run.newConfigProfile()
+
+	wsAddr := resolveWSAddr()
+
+	ttsHistory := ttsusecase.NewHistory(credStore, time.Duration(envInt("TTS_HISTORY_RETENTION_DAYS"))*24*time.Hour)
+	run.ttsHistory = ttsHistory
+
+	// el texto entrante se sanea antes de TTS, comandos y overlays
+	textPolicy := chatTextPolicy()
 
 	wsConfig := ws.Config{
 		Addr:             wsAddr,
+		TTSHistory:       ttsHistory,
 		CredentialRepo:   credStore,
 		NotificationRepo: credStore,
-		CredentialHook:   run.handleCredentialUpdate,
+		CredentialHook:   credHooks.Enqueue,
 		CategoryManager:  categorySvc,
 		StatusResolver:   statusResolver,
 		CommandManager:   customManager,
 		CommandService:   commandSvc,
+		OverlayService:   overlaySvc,
+		SettingsReloader: run,
+		SettingsLister:   credStore,
+		Diagnostics:      run,
+		ReadOnly:         readOnly,
+		Notifier:         notifier,
+		DebugRecorder:    run,
+		Templates:        notifier,
+		TrackerService:   trackerSvc,
+		Leaderboard:      leaderboard,
+		Connections:      run,
+		PlatformTester:   run,
+		FollowPolicy:     followSvc,
+		Lurkers:          lurkSvc,
+		Profiles:         profileSvc,
+		StreamSync:       streamSync,
+		APIQuota:         run,
+		BotPause:         run,
+		LinkPreviews:     linkSvc,
+		Presence:         run.presence,
+		UserNotes:        userNotes,
+		Countdowns:       countdownSvc,
+		ConfigProfile:    run.configProfile,
+		Quotes:           quoteSvc,
+		ChatGames:        gameSvc,
+		Counters:         counterSvc,
+		AutoShoutout:     shoutoutSvc,
+		Greetings:        greetingSvc,
+		Refresher:        refresher,
+		BotConflicts:     conflictSvc,
+		ConfigValidator:  run,
+		NotificationIntake: ws.NotificationIntakeConfig{
+			RatePerMinute: envInt("NOTIFICATIONS_RATE_PER_MINUTE"),
+			Burst:         envInt("NOTIFICATIONS_RATE_BURST"),
+			APIKey:        os.Getenv("NOTIFICATIONS_API_KEY"),
+			// separados por coma; loopback ya cuenta como proxy
+			TrustedProxies: strings.Split(os.Getenv("NOTIFICATIONS_TRUSTED_PROXIES"), ","),
+		},
+		ChatPage: ws.ChatPageConfig{
+			Disabled:  !envTrue("CHAT_PAGE"),
+			Token:     os.Getenv("CHAT_PAGE_TOKEN"),
+			Moderator: !envFalse("CHAT_PAGE_MODERATOR"),
+			Sender:    run,
+		},
+		TextPolicy: textPolicy,
 	}
 
 	if cfg.TwitchClientId != "" && cfg.TwitchClientSecret != "" && cfg.TwitchRedirectURI != "" {
 		wsConfig.Twitch = &ws.TwitchOAuthConfig{
-			ClientID:       cfg.TwitchClientId,
-			ClientSecret:   cfg.TwitchClientSecret,
-			RedirectURI:    cfg.TwitchRedirectURI,
-			BotScopes:      []string{"chat:read", "chat:edit"},
-			StreamerScopes: []string{"channel:manage:broadcast"},
+			ClientID:     cfg.TwitchClientId,
+			ClientSecret: cfg.TwitchClientSecret,
+			RedirectURI:  cfg.TwitchRedirectURI,
+			// user:manage:whispers, para los avisos privados (cooldowns, !notes)
+			// los moderator:manage:* son porque el bot modera con su propio token
+			BotScopes: []string{"chat:read", "chat:edit", "moderator:manage:announcements", "user:manage:whispers", "moderator:manage:chat_settings", "moderator:manage:banned_users", "moderator:manage:chat_messages"},
+			// los de lectura son para verificar seguidores, subs y mods en los permisos de comandos;
+			// banned_users y chat_messages, para !ban, !timeout y !delete
+			StreamerScopes: []string{"channel:manage:broadcast", "moderator:manage:chat_settings", "moderator:read:followers", "channel:read:subscriptions", "moderation:read", "moderator:manage:banned_users", "moderator:manage:chat_messages"},
 		}
 	}
 
@@ -196,43 +600,104 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 			ClientID:       cfg.KickClientID,
 			ClientSecret:   cfg.KickClientSecret,
 			RedirectURI:    cfg.KickRedirectURI,
-			StreamerScopes: []string{"user:read", "channel:read", "channel:write", "chat:write"},
+			StreamerScopes: []string{"user:read", "channel:read", "channel:write", "chat:write", "moderation:ban", "moderation:chat_message:manage"},
 		}
 	}
 
 	wsServer := ws.NewServer(wsConfig)
+	wsServer.SetHandler(run.dispatcher)
 	run.wsServer = wsServer
-
-	var twitchTitleSvc domain.StreamTitleService
-	var twitchAPIService domain.TwitchChannelService
-	var twitchBroadcasterID string
-	if cfg.TwitchClientId != "" && cfg.TwitchApiToken != "" {
-		service, err := twitchinfra.NewStreamService(cfg.TwitchClientId, cfg.TwitchApiToken)
-		if err != nil {
-			log.Printf("no se pudo iniciar el servicio de Twitch: %v", err)
-		} else {
-			broadcasterID, err := resolveTwitchBroadcasterID(runtimeCtx, cfg.TwitchClientId, cfg.TwitchApiToken, cfg.TwitchUsername)
-			if err != nil {
-				log.Printf("no pude resolver el ID de Twitch: %v", err)
-			} else {
-				twitchAPIService = service
-				twitchBroadcasterID = broadcasterID
-				categorySvc.SetTwitchService(twitchAPIService, broadcasterID)
-				twitchTitleSvc = twitchinfra.NewTwitchTitleAdapter(twitchAPIService, broadcasterID)
-				statusResolver.Set(domain.PlatformTwitch, twitchinfra.NewTwitchStatusAdapter(twitchAPIService, broadcasterID))
-			}
+	overlaySvc.SetPublisher(wsServer)
+	trackerSvc.SetPublisher(wsServer)
+	notifier.SetPublisher(wsServer)
+	profileSvc.SetEnrichedHandler(func(profile domain.UserProfile) {
+		if err := wsServer.PublishEvent(runtimeCtx, "user:enriched", profile); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
 		}
-	}
-
-	if twitchTitleSvc != nil {
-		resolver.Set(domain.PlatformTwitch, twitchTitleSvc)
-		if twitchAPIService != nil && twitchBroadcasterID != "" {
-			customManager.SetAudienceResolver(commands.NewTwitchAudienceResolver(twitchAPIService, twitchBroadcasterID))
+		bus.Publish(events.TopicUserEnriched, profile)
+	})
+	linkSvc.SetPreviewHandler(func(event linkpreviewusecase.Event) {
+		if err := wsServer.PublishEvent(runtimeCtx, "chat:link-preview", event); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
 		}
-	}
+		bus.Publish(events.TopicLinkPreview, event)
+	})
+	counterSvc.SetChangeHandler(func(counter domain.Counter) {
+		if err := wsServer.PublishEvent(runtimeCtx, "counter:update", counter); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicCounterUpdate, counter)
+	})
+	shoutoutSvc.SetShoutoutHandler(func(shoutout shoutoutsusecase.ShoutoutDTO) {
+		if err := wsServer.PublishEvent(runtimeCtx, "shoutout:auto", shoutout); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicAutoShoutout, shoutout)
+	})
+	greetingSvc.SetReturningHandler(func(viewer greetingsusecase.ReturningViewerDTO) {
+		if err := wsServer.PublishEvent(runtimeCtx, "chat:returning-viewer", viewer); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicReturningViewer, viewer)
+	})
+	conflictSvc.SetConflictHandler(func(conflict botconflictsusecase.ConflictDTO) {
+		if err := wsServer.PublishEvent(runtimeCtx, "bots:conflict", conflict); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicBotConflict, conflict)
+	})
+	countdownSvc.SetTickHandler(func(tick countdownusecase.Tick) {
+		if err := wsServer.PublishEvent(runtimeCtx, "countdown:tick", tick); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicCountdownTick, tick)
+	})
+	refresher.SetReauthHandler(func(ctx context.Context, cred *domain.Credential, reason string) {
+		payload := events.OAuthReauthDTO{
+			Platform: string(cred.Platform),
+			Role:     cred.Role,
+			Login:    cred.Metadata["login"],
+			Reason:   reason,
+		}
+		if err := wsServer.PublishEvent(ctx, "oauth:reauth-required", payload); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicOAuthReauth, payload)
+	})
 
-	router := commands.NewRouter("!")
+	router := commands.NewRouter(domain.DefaultCommandPrefix)
+	router.SetPrefixStore(credStore)
+	if err := router.LoadPrefix(runtimeCtx); err != nil {
+		log.Printf("router: no pude cargar el prefijo de comandos: %v", err)
+	}
+	router.SetPrefixHandler(func(prefix string) {
+		bus.Publish(events.TopicCommandPrefix, map[string]string{"prefix": prefix})
+	})
+	conflictSvc.SetTriggerFunc(router.Trigger)
 	router.SetCustomManager(customManager)
+	router.SetPauseStore(credStore)
+	router.SetScopeChecker(run.HasScope)
+	if err := router.LoadPause(runtimeCtx); err != nil {
+		log.Printf("router: no pude cargar la pausa de comandos: %v", err)
+	}
+	router.SetPauseHandler(func(paused bool) {
+		bus.Publish(events.TopicBotPaused, map[string]bool{"paused": paused})
+	})
+
+	awaySvc := awayusecase.NewService()
+	awaySvc.SetNamesFunc(run.awayNames)
+	if v := strings.TrimSpace(os.Getenv("AWAY_AUTOREPLY")); v == "0" || strings.EqualFold(v, "false") {
+		awaySvc.SetAutoReply(false)
+	}
+	awaySvc.SetChangeHandler(func(state awayusecase.State) {
+		if err := wsServer.PublishEvent(runtimeCtx, "app:away", state); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("ws publish error: %v", err)
+		}
+		bus.Publish(events.TopicBotAway, state)
+	})
+	run.away = awaySvc
+	run.router = router
+	run.modActions = modActions
 	router.Register(commands.NewPingCommand())
 	router.Register(commands.NewManageCustomCommand(customManager))
 
@@ -241,20 +706,104 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 		Service:   ttsService,
 		Publisher: wsServer,
 		Bus:       bus,
+		History:   ttsHistory,
+	})
+	ttsService.SetQueue(readonlyusecase.TTSQueue(ttsRunner, readOnly))
+	if err := ttsService.LoadAutoPause(runtimeCtx); err != nil {
+		log.Printf("tts: no pude cargar la pausa automática: %v", err)
+	}
+	ttsService.SetAutoPauseHandler(func(change ttsusecase.AutoPauseChange) {
+		ttsRunner.RefreshStatus()
+		if !change.Paused {
+			log.Printf("tts: pausa automática finalizada (%d msg/min)", change.Rate)
+			return
+		}
+		log.Printf("tts: pausa automática activada (%d msg/min)", change.Rate)
+		if change.Platform == "" || change.ChannelID == "" {
+			return
+		}
+		if err := multiOut.SendMessage(runtimeCtx, change.Platform, change.ChannelID,
+			"⏸️ TTS en pausa por mucha actividad en el chat. Se reanudará solo cuando se calme."); err != nil {
+			log.Printf("tts: no pude anunciar la pausa: %v", err)
+		}
 	})
-	ttsService.SetQueue(ttsRunner)
 	wsServer.SetTTSManager(ttsService)
 	wsServer.SetTTSStatusProvider(ttsRunner)
-	router.Register(commands.NewTTSCommand(ttsService))
+	router.Register(commands.NewTTSCommand(ttsService, ttsHistory))
+	router.Register(commands.NewTTSSkipCommand(ttsRunner))
 	run.ttsServ = ttsService
 	run.ttsRunner = ttsRunner
+	run.reloadables = []namedReloadable{
+		{name: "tts", svc: ttsService},
+		{name: "commands", svc: commandSvc},
+		{name: "moderation", svc: spamRule},
+		{name: "trackers", svc: trackerSvc},
+		{name: "leaderboard", svc: leaderboard},
+		{name: "readonly", svc: readOnly},
+		{name: "eventlog", svc: recorder},
+		{name: "notifications", svc: notifier},
+		{name: "follows", svc: followSvc},
+		{name: "lurkers", svc: lurkSvc},
+		{name: "stream-sync", svc: streamSync},
+		{name: "link-previews", svc: linkSvc},
+		{name: "user-notes", svc: userNotes},
+		{name: "games", svc: gameSvc},
+		{name: "counters", svc: counterSvc},
+		{name: "shoutouts", svc: shoutoutSvc},
+		{name: "greetings", svc: greetingSvc},
+		{name: "bot-conflicts", svc: conflictSvc},
+		{name: "pause", svc: reloadFunc(router.LoadPause)},
+		{name: "prefix", svc: reloadFunc(router.LoadPrefix)},
+	}
 
 	router.Register(commands.NewTitleCommand(resolver))
+	run.setupTwitchStreamer(runtimeCtx)
+
+	announcer := commands.NewAnnouncer(func() (domain.TwitchChannelService, string, string) {
+		if readOnly.Enabled() {
+			// sin servicio cae al multiOut, que suprime el mensaje
+			return nil, "", ""
+		}
+		_, broadcasterID := run.twitchStreamerAPI()
+		return run.twitchBotAPI(), broadcasterID, run.TwitchBotUserID()
+	}, multiOut)
+	router.Register(commands.NewAnnounceCommand(announcer))
+	router.Register(commands.NewCountCommand(trackerSvc, counterSvc))
+	router.Register(commands.NewTopCommand(leaderboard))
+	router.Register(commands.NewReadOnlyCommand(readOnly))
+	router.Register(commands.NewBotCommand(router))
+	router.Register(commands.NewPrefixCommand(router))
+	router.Register(commands.NewAwayCommand(awaySvc))
+	router.Register(commands.NewBackCommand(awaySvc))
+	router.Register(commands.NewCountdownCommand(countdownSvc))
+	router.Register(commands.NewQuoteCommand(quoteSvc))
+	router.Register(commands.NewRollCommand(gameSvc))
+	router.Register(commands.NewEightBallCommand(gameSvc))
+	router.Register(commands.NewCoinflipCommand(gameSvc))
+	router.Register(commands.NewNoteCommand(userNotes))
+	router.Register(commands.NewNotesCommand(userNotes))
+	router.Register(commands.NewTestAlertCommand(notifier))
+	router.Register(commands.NewLurkCommand(lurkSvc))
+	router.Register(commands.NewUnlurkCommand(lurkSvc))
+	router.Register(commands.NewLurkersCommand(lurkSvc))
+	router.Register(commands.NewBanCommand(modActions))
+	router.Register(commands.NewTimeoutCommand(modActions))
+	router.Register(commands.NewDeleteCommand(modActions))
 
 	uc := handle_message.NewInteractor(multiOut, router)
 
+	// los cheers que llegan como PRIVMSG se guardan también como notificación de bits
+	bitsAlerts := !envFalse("CHAT_BITS_NOTIFICATIONS")
+
 	dispatch := func(ctx context.Context, msg domain.Message) error {
-		msgNormalized := msg
+		msgNormalized := textPolicy.Apply(msg)
+		if msgNormalized.Text == "" && msg.Text != "" {
+			// solo traía caracteres invisibles o de control
+			return nil
+		}
+		if msgNormalized.ID == "" {
+			msgNormalized.ID = run.nextMessageID()
+		}
 
 		if msgNormalized.ChannelID == "" {
 			switch msgNormalized.Platform {
@@ -267,7 +816,14 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 
 		if msgNormalized.Username == "" {
 			msgNormalized.Username = "web-user"
+		} else {
+			ttsService.ObserveChatMessage(msgNormalized)
 		}
+		msgNormalized = profileSvc.Enrich(msgNormalized)
+		msgNormalized.BotPaused = router.Paused()
+		userNotes.Observe(msgNormalized)
+		modActions.Observe(msgNormalized)
+		msgNormalized.HasNotes = userNotes.HasNotes(msgNormalized.Platform, msgNormalized.UserID)
 
 		if err := wsServer.PublishMessage(ctx, msgNormalized); err != nil && !errors.Is(err, context.Canceled) {
 			log.Printf("ws publish error: %v", err)
@@ -277,44 +833,219 @@ func Start(ctx context.Context, _ Options) (*Runtime, error) {
 			bus.Publish(events.TopicChatMessage, events.NewChatMessageDTO(msgNormalized))
 		}
 
-		return uc.Handle(ctx, msgNormalized)
+		if bitsAlerts && msgNormalized.Bits > 0 {
+			if notification, err := notifications.BitsNotification(msgNormalized); err != nil {
+				log.Printf("notifications: %v", err)
+			} else if _, err := notifier.Emit(ctx, notification); err != nil {
+				log.Printf("notifications: no pude guardar los bits de %s: %v", msgNormalized.Name(), err)
+			}
+		}
+
+		linkSvc.Observe(msgNormalized)
+		moderationSvc.Evaluate(ctx, msgNormalized)
+		trackerSvc.Observe(ctx, msgNormalized)
+		leaderboard.Observe(ctx, msgNormalized)
+		if shoutout := shoutoutSvc.Observe(ctx, msgNormalized); shoutout != "" {
+			if err := multiOut.SendMessage(ctx, msgNormalized.Platform, msgNormalized.ChannelID, shoutout); err != nil {
+				log.Printf("shoutouts: no pude enviar el shoutout: %v", err)
+			}
+		}
+		if greeting := greetingSvc.Observe(ctx, msgNormalized); greeting != "" {
+			if err := multiOut.SendMessage(ctx, msgNormalized.Platform, msgNormalized.ChannelID, greeting); err != nil {
+				log.Printf("greetings: no pude enviar el saludo: %v", err)
+			}
+		}
+		if !router.IsCommand(msgNormalized.Text) {
+			if reply := awaySvc.Observe(msgNormalized); reply != "" {
+				if err := multiOut.SendMessage(ctx, msgNormalized.Platform, msgNormalized.ChannelID, reply); err != nil {
+					log.Printf("away: no pude responder la mención: %v", err)
+				}
+			}
+			if welcome := lurkSvc.Observe(ctx, msgNormalized); welcome != "" {
+				if err := multiOut.SendMessage(ctx, msgNormalized.Platform, msgNormalized.ChannelID, welcome); err != nil {
+					log.Printf("lurkers: no pude dar la bienvenida: %v", err)
+				}
+			}
+		}
+
+		var err error
+		if !conflictSvc.Observe(ctx, msgNormalized) {
+			err = uc.Handle(ctx, msgNormalized)
+		}
+		recorder.recordMessage(msgNormalized, router.IsCommand(msgNormalized.Text), err)
+		return err
 	}
-	run.dispatcher = dispatch
 
-	wsServer.SetHandler(dispatch)
-	platformMgr.SetHandler(dispatch)
-	run.syncTwitchAdapter()
+	run.twitchMu.RLock()
+	twitchRunning := run.twitchAd != nil
+	run.twitchMu.RUnlock()
+	if !twitchRunning {
+		run.syncTwitchAdapter()
+	}
 	run.wg.Add(1)
 	go func() {
 		defer run.wg.Done()
 		log.Printf("Iniciando servidor WS")
 		if err := wsServer.Start(runtimeCtx); err != nil && err != context.Canceled {
 			log.Printf("ws server error: %v", err)
+			// sin servidor WS el chat tiene que seguir funcionando
+			run.gate.Open(dispatch)
 		}
 	}()
 
 	run.handleCredentialSnapshot(runtimeCtx)
+	credHooks.Start(runtimeCtx)
+	rawEvents.Start(runtimeCtx)
 
 	if ttsRunner != nil {
+		ttsHistory.Start(runtimeCtx)
 		ttsRunner.Start(runtimeCtx)
 	}
+	go func() {
+		select {
+		case <-wsServer.Ready():
+			run.gate.Open(dispatch)
+		case <-runtimeCtx.Done():
+		}
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		run.runTTSAutoPause(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		trackerSvc.Run(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		leaderboard.Run(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		greetingSvc.Run(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		connTracker.Run(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		run.runDebugRecorder(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		run.runFollowBatches(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		run.runTwitchIdentityCheck(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		countdownSvc.Run(runtimeCtx)
+	}()
+	run.wg.Add(1)
+	go func() {
+		defer run.wg.Done()
+		streamSync.Run(runtimeCtx, statusResolver, categoryusecase.DefaultSyncInterval)
+	}()
+	for i := 0; i < profilesusecase.DefaultWorkers; i++ {
+		run.wg.Add(1)
+		go func() {
+			defer run.wg.Done()
+			profileSvc.Run(runtimeCtx)
+		}()
+	}
+	for i := 0; i < linkpreviewusecase.DefaultWorkers; i++ {
+		run.wg.Add(1)
+		go func() {
+			defer run.wg.Done()
+			linkSvc.Run(runtimeCtx)
+		}()
+	}
 
 	run.started = true
+	run.twitchMu.RLock()
+	channels := append([]string(nil), run.twitchChannels...)
+	run.twitchMu.RUnlock()
+	log.Println(buildStartupSummary(cfg, dbPath, wsAddr, ttsService.Backend(),
+		envInt("KICK_BROADCASTER_USER_ID"), channels, readOnly.Enabled()))
 	log.Println("Iniciando bot...")
 	return run, nil
 }
 
+// credentialHooksDrainTimeout es cuánto espera Stop a que terminen los avisos
+// de credenciales pendientes.
+const credentialHooksDrainTimeout = 5 * time.Second
+
+// presenceGoodbyeTimeout es cuánto espera Stop a que salga la despedida.
+const presenceGoodbyeTimeout = 3 * time.Second
+
+// ttsHistoryFlushTimeout es cuánto espera Stop a que se guarde el historial TTS.
+const ttsHistoryFlushTimeout = 5 * time.Second
+
 func (r *Runtime) Stop() error {
 	if r == nil || !r.started {
 		return nil
 	}
+	if r.credHooks != nil {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), credentialHooksDrainTimeout)
+		if err := r.credHooks.Close(flushCtx); err != nil {
+			log.Printf("credential hooks: quedaron avisos sin procesar: %v", err)
+		}
+		flushCancel()
+	}
+	if r.notifier != nil {
+		// los regalos agrupados todavía abiertos salen antes de cerrar el WS
+		r.notifier.FlushGifts(context.Background())
+	}
+	if r.presence != nil {
+		// la despedida sale mientras los adaptadores siguen conectados
+		byeCtx, byeCancel := context.WithTimeout(context.Background(), presenceGoodbyeTimeout)
+		r.presence.Goodbye(byeCtx)
+		byeCancel()
+	}
 	r.cancel()
 	r.stopTwitchAdapter()
 	r.platform.Shutdown()
 	if r.ttsRunner != nil {
 		_ = r.ttsRunner.Close()
 	}
+	if r.ttsHistory != nil {
+		// después del runner, para guardar también la lectura que se cortó
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), ttsHistoryFlushTimeout)
+		if err := r.ttsHistory.Close(flushCtx); err != nil {
+			log.Printf("tts history: no pude guardar las últimas lecturas: %v", err)
+		}
+		flushCancel()
+	}
+	if r.rawEvents != nil {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), ttsHistoryFlushTimeout)
+		if err := r.rawEvents.Close(flushCtx); err != nil {
+			log.Printf("raw events: no pude guardar los últimos eventos: %v", err)
+		}
+		flushCancel()
+	}
 	r.wg.Wait()
+	// la desconexión de los adaptadores llega después de que Run terminó
+	if r.connStatus != nil {
+		r.connStatus.Flush(context.Background())
+	}
+	if r.recorder != nil {
+		_ = r.recorder.Close()
+	}
+	if r.bus != nil {
+		r.bus.Close()
+	}
 	if r.credStore != nil {
 		if err := r.credStore.Close(); err != nil {
 			return err
@@ -338,6 +1069,77 @@ func (r *Runtime) CommandService() *commands.Service {
 	return r.commandSvc
 }
 
+func (r *Runtime) OverlayService() *overlaysusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.overlays
+}
+
+func (r *Runtime) TrackerService() *trackersusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.trackers
+}
+
+// Leaderboard devuelve el ranking de usuarios más activos (!top).
+func (r *Runtime) Leaderboard() *trackersusecase.Leaderboard {
+	if r == nil {
+		return nil
+	}
+	return r.leaderboard
+}
+
+// Follows recibe los eventos de follow y decide cómo anunciarlos.
+func (r *Runtime) Follows() *followsusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.follows
+}
+
+// Away devuelve el estado de ausencia (!away/!back).
+func (r *Runtime) Away() *awayusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.away
+}
+
+// awayNames son los nombres que cuentan como mención al streamer mientras
+// está ausente: las cuentas de Twitch conocidas y el canal principal.
+func (r *Runtime) awayNames() []string {
+	r.twitchMu.RLock()
+	defer r.twitchMu.RUnlock()
+	names := []string{r.twitchStreamerLogin, r.twitchBotLogin}
+	if len(r.twitchChannels) > 0 {
+		names = append(names, r.twitchChannels[0])
+	}
+	return names
+}
+
+func (r *Runtime) Lurkers() *lurkersusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.lurkers
+}
+
+func (r *Runtime) Profiles() *profilesusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.profiles
+}
+
+func (r *Runtime) SpamRule() *moderation.SpamRule {
+	if r == nil {
+		return nil
+	}
+	return r.spamRule
+}
+
 func (r *Runtime) TTSService() *ttsusecase.Service {
 	if r == nil {
 		return nil
@@ -345,6 +1147,14 @@ func (r *Runtime) TTSService() *ttsusecase.Service {
 	return r.ttsServ
 }
 
+// TTSHistory devuelve el historial de lecturas TTS.
+func (r *Runtime) TTSHistory() *ttsusecase.History {
+	if r == nil {
+		return nil
+	}
+	return r.ttsHistory
+}
+
 func (r *Runtime) TTSRunner() *ttsruntime.Runner {
 	if r == nil {
 		return nil
@@ -359,6 +1169,14 @@ func (r *Runtime) NotificationRepo() domain.NotificationRepository {
 	return r.credStore
 }
 
+// Notifications devuelve el pipeline de alertas.
+func (r *Runtime) Notifications() *notifications.Service {
+	if r == nil {
+		return nil
+	}
+	return r.notifier
+}
+
 func (r *Runtime) StreamStatusResolver() *statususecase.Resolver {
 	if r == nil {
 		return nil
@@ -373,6 +1191,22 @@ func (r *Runtime) CategoryService() *categoryusecase.Service {
 	return r.category
 }
 
+// APIQuota devuelve la cuota de Helix de cada token.
+func (r *Runtime) APIQuota() []domain.APIQuotaState {
+	if r == nil {
+		return nil
+	}
+	return r.helixQuota.State()
+}
+
+// StreamSync devuelve la copia de título/categoría de Twitch a Kick.
+func (r *Runtime) StreamSync() *categoryusecase.Sync {
+	if r == nil {
+		return nil
+	}
+	return r.streamSync
+}
+
 func (r *Runtime) DispatchMessage(ctx context.Context, msg domain.Message) error {
 	if r == nil || r.dispatcher == nil {
 		return fmt.Errorf("dispatcher unavailable")
@@ -383,6 +1217,27 @@ func (r *Runtime) DispatchMessage(ctx context.Context, msg domain.Message) error
 	return r.dispatcher(ctx, msg)
 }
 
+// SendChatPageMessage manda al chat lo escrito en la página /chat. El texto
+// que no es comando sale como mensaje del bot; después pasa por el dispatcher
+// como cualquier mensaje, para que aparezca en el feed y corran los comandos.
+func (r *Runtime) SendChatPageMessage(ctx context.Context, msg domain.Message) error {
+	if r == nil || r.dispatcher == nil || r.multiOut == nil {
+		return fmt.Errorf("dispatcher unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	if msg.ChannelID == "" {
+		msg.ChannelID = r.defaultChannel(msg.Platform)
+	}
+	if r.router == nil || !r.router.IsCommand(msg.Text) {
+		if err := r.multiOut.SendMessage(ctx, msg.Platform, msg.ChannelID, msg.Text); err != nil {
+			return err
+		}
+	}
+	return r.dispatcher(ctx, msg)
+}
+
 func (r *Runtime) Config() *config.Config {
 	if r == nil {
 		return nil
@@ -390,6 +1245,15 @@ func (r *Runtime) Config() *config.Config {
 	return r.cfg
 }
 
+// ConfigValidation revisa la configuración cargada (incluye las redirect URIs
+// heredadas).
+func (r *Runtime) ConfigValidation() config.Validation {
+	if r == nil || r.cfg == nil {
+		return config.Validation{Problems: []string{"configuración vacía"}, LegacyRedirects: []config.LegacyRedirect{}}
+	}
+	return r.cfg.Validate()
+}
+
 func (r *Runtime) CredentialRepo() domain.CredentialRepository {
 	if r == nil {
 		return nil
@@ -397,8 +1261,13 @@ func (r *Runtime) CredentialRepo() domain.CredentialRepository {
 	return r.credStore
 }
 
+// NotifyCredentialUpdate encola el aviso de una credencial nueva; vuelve sin
+// esperar a que se reconecten los adaptadores.
 func (r *Runtime) NotifyCredentialUpdate(ctx context.Context, cred *domain.Credential) {
-	r.handleCredentialUpdate(ctx, cred)
+	if r == nil || r.credHooks == nil {
+		return
+	}
+	r.credHooks.Enqueue(ctx, cred)
 }
 
 func (r *Runtime) OAuthStart(ctx context.Context, platform domain.Platform, role string) (string, error) {
@@ -411,6 +1280,151 @@ func (r *Runtime) OAuthStart(ctx context.Context, platform domain.Platform, role
 	return r.wsServer.OAuthStart(ctx, platform, role)
 }
 
+// ReadOnly indica si el bot está en modo solo lectura.
+func (r *Runtime) ReadOnly() bool {
+	if r == nil {
+		return false
+	}
+	return r.readOnly.Enabled()
+}
+
+func (r *Runtime) SetReadOnly(ctx context.Context, enabled bool) error {
+	if r == nil || r.readOnly == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.readOnly.Set(ctx, enabled)
+}
+
+// LinkPreviews devuelve el servicio de vistas previas de links del chat.
+func (r *Runtime) LinkPreviews() *linkpreviewusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.links
+}
+
+// Countdowns devuelve las cuentas regresivas (!countdown).
+func (r *Runtime) Countdowns() *countdownusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.countdowns
+}
+
+// Counters devuelve los contadores manuales (!count nombre +).
+func (r *Runtime) Counters() *countersusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.counters
+}
+
+// Shoutouts devuelve el shoutout automático a streamers y VIPs.
+func (r *Runtime) Shoutouts() *shoutoutsusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.shoutouts
+}
+
+// Greetings devuelve el saludo a quien vuelve al chat después de mucho tiempo.
+func (r *Runtime) Greetings() *greetingsusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.greetings
+}
+
+// BotConflicts devuelve la detección de otros bots que responden los mismos
+// comandos.
+func (r *Runtime) BotConflicts() *botconflictsusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.conflicts
+}
+
+// Games devuelve los juegos del chat (!roll, !8ball, !coinflip).
+func (r *Runtime) Games() *gamesusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.games
+}
+
+// Quotes devuelve las citas del canal (!quote).
+func (r *Runtime) Quotes() *quotesusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.quotes
+}
+
+// UserNotes devuelve las notas de los mods sobre usuarios.
+
+func (r *Runtime) UserNotes() *usernotesusecase.Service {
+	if r == nil {
+		return nil
+	}
+	return r.userNotes
+}
+
+// nextMessageID identifica cada mensaje recibido. El prefijo (hora de
+// arranque) evita repetir IDs de una sesión anterior en el historial.
+func (r *Runtime) nextMessageID() string {
+	return strconv.FormatInt(r.startedAt.UnixMilli(), 36) + "-" + strconv.FormatUint(r.msgSeq.Add(1), 36)
+}
+
+// BotPaused indica si los comandos están pausados (!bot pause).
+func (r *Runtime) BotPaused() bool {
+	if r == nil {
+		return false
+	}
+	return r.router.Paused()
+}
+
+func (r *Runtime) SetBotPaused(ctx context.Context, paused bool) error {
+	if r == nil || r.router == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.router.SetPaused(ctx, paused)
+}
+
+// CommandPrefix devuelve el prefijo de los comandos.
+func (r *Runtime) CommandPrefix() string {
+	if r == nil || r.router == nil {
+		return domain.DefaultCommandPrefix
+	}
+	return r.router.Prefix()
+}
+
+// SetCommandPrefix cambia el prefijo de los comandos, igual que !prefix.
+func (r *Runtime) SetCommandPrefix(ctx context.Context, prefix string) error {
+	if r == nil || r.router == nil {
+		return fmt.Errorf("runtime unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.router.SetPrefix(ctx, prefix)
+}
+
+func (r *Runtime) SupportBundle(ctx context.Context) (ws.SupportBundle, error) {
+	if r == nil || r.wsServer == nil {
+		return ws.SupportBundle{}, fmt.Errorf("api unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.wsServer.SupportBundle(ctx)
+}
+
 func (r *Runtime) OAuthStatus(ctx context.Context) (ws.OAuthStatus, error) {
 	if r == nil || r.wsServer == nil {
 		return ws.OAuthStatus{}, fmt.Errorf("oauth server unavailable")
@@ -431,6 +1445,18 @@ func (r *Runtime) OAuthLogout(ctx context.Context, platform domain.Platform, rol
 	return r.wsServer.OAuthLogout(ctx, platform, role)
 }
 
+// OAuthRefresh refresca a mano platform/role (o todas si platform está
+// vacío) sin esperar a que el token esté por vencer.
+func (r *Runtime) OAuthRefresh(ctx context.Context, platform domain.Platform, role string) ([]credentialsusecase.RefreshResult, error) {
+	if r == nil || r.refresher == nil {
+		return nil, fmt.Errorf("token refresher unavailable")
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+	return r.refresher.RefreshNow(ctx, platform, role)
+}
+
 func loadInitialTokens(ctx context.Context, store *sqlitestorage.CredentialStore, cfg *config.Config) {
 	if store == nil {
 		return
@@ -479,6 +1505,20 @@ func (r *Runtime) handleCredentialSnapshot(ctx context.Context) {
 	}
 }
 
+func resolveDBPath(cfg *config.Config) string {
+	if cfg == nil || strings.TrimSpace(cfg.DatabasePath) == "" {
+		return filepath.Join("data", "zhatbot.db")
+	}
+	return cfg.DatabasePath
+}
+
+func resolveWSAddr() string {
+	if addr := os.Getenv("CHAT_WS_ADDR"); addr != "" {
+		return addr
+	}
+	return ":8080"
+}
+
 func envInt(key string) int {
 	v := strings.TrimSpace(os.Getenv(key))
 	if v == "" {
@@ -492,6 +1532,27 @@ func envInt(key string) int {
 	return n
 }
 
+// chatTextPolicy lee CHAT_MAX_TEXT_RUNES (largo máximo de un mensaje, 2000 por
+// defecto) y CHAT_DEBUG_RAW_TEXT (conservar el texto original en RawText).
+func chatTextPolicy() domain.ChatTextPolicy {
+	return domain.ChatTextPolicy{
+		MaxRunes: envInt("CHAT_MAX_TEXT_RUNES"),
+		KeepRaw:  envTrue("CHAT_DEBUG_RAW_TEXT"),
+	}
+}
+
+// envFalse indica si key está apagada explícitamente ("0" o "false").
+func envFalse(key string) bool {
+	v := strings.TrimSpace(os.Getenv(key))
+	return v == "0" || strings.EqualFold(v, "false")
+}
+
+// envTrue indica si key está encendida explícitamente ("1" o "true").
+func envTrue(key string) bool {
+	v := strings.TrimSpace(os.Getenv(key))
+	return v == "1" || strings.EqualFold(v, "true")
+}
+
 func formatTwitchOAuthToken(token string) string {
 	if token == "" {
 		return ""
@@ -509,12 +1570,25 @@ func (r *Runtime) handleCredentialUpdate(ctx context.Context, cred *domain.Crede
 	if ctx == nil {
 		ctx = r.ctx
 	}
+	if r.recorder != nil {
+		r.recorder.recordCredential(cred)
+	}
 	if r.platform != nil {
 		r.platform.HandleCredentialUpdate(ctx, cred)
 	}
+	// un token nuevo puede arreglar el servicio de estado que se deshabilitó
+	r.status.ResetHealth(cred.Platform)
 	if cred.Platform == domain.PlatformTwitch {
 		r.applyTwitchCredential(cred)
+		if strings.EqualFold(strings.TrimSpace(cred.Role), "streamer") && r.router != nil {
+			r.setupTwitchStreamer(ctx)
+		}
+		r.reconcileTwitchAccounts(ctx)
+		// el ID del bot decide quién modera
+		r.wireTwitchModeration()
+		r.scheduleTwitchProfileRetry(cred)
 	}
+	r.reportScopeCapabilities(ctx, cred.Platform)
 }
 
 func (r *Runtime) initTwitchState(cfg twitchadapter.Config) {
@@ -541,6 +1615,19 @@ func (r *Runtime) defaultTwitchChannel() string {
 	return r.twitchChannels[0]
 }
 
+// defaultChannel es el canal donde habla el bot cuando no responde a un mensaje.
+func (r *Runtime) defaultChannel(platform domain.Platform) string {
+	switch platform {
+	case domain.PlatformTwitch:
+		return r.defaultTwitchChannel()
+	case domain.PlatformKick:
+		if r.platform != nil {
+			return r.platform.ChannelID(domain.PlatformKick)
+		}
+	}
+	return ""
+}
+
 func (r *Runtime) applyTwitchCredential(cred *domain.Credential) {
 	if cred == nil {
 		return
@@ -552,6 +1639,9 @@ func (r *Runtime) applyTwitchCredential(cred *domain.Credential) {
 	r.twitchMu.Lock()
 	switch role {
 	case "bot":
+		if userID := strings.TrimSpace(cred.Metadata["user_id"]); userID != "" {
+			r.twitchBotUserID = userID
+		}
 		token := formatTwitchOAuthToken(cred.AccessToken)
 		if token != "" && token != r.twitchBotToken {
 			r.twitchBotToken = token
@@ -610,7 +1700,11 @@ func (r *Runtime) syncTwitchAdapter() {
 		OAuthToken:        r.twitchBotToken,
 		Channels:          append([]string(nil), r.twitchChannels...),
 		UserNoticeHandler: r.twitchNoticeHandler,
+		DeletionHandler:   r.chatDeletionHandler(),
 	}
+	cfg.ConnectionHandler = r.twitchConnectionHandler(cfg)
+	cfg.ErrorHandler = func(err error) { r.publishTwitchError(err.Error()) }
+	cfg.Whisper = r.sendTwitchWhisper
 	running := r.twitchAd != nil
 	r.twitchMu.RUnlock()
 
@@ -651,7 +1745,6 @@ func (r *Runtime) startTwitchAdapter(cfg twitchadapter.Config) {
 	if r.multiOut != nil {
 		r.multiOut.Register(domain.PlatformTwitch, adapter)
 	}
-	r.publishTwitchConnected(cfg)
 
 	go func() {
 		defer close(done)
@@ -662,6 +1755,36 @@ func (r *Runtime) startTwitchAdapter(cfg twitchadapter.Config) {
 	}()
 }
 
+// runTTSAutoPause reevalúa la pausa automática para poder reanudar sin mensajes nuevos.
+func (r *Runtime) runTTSAutoPause(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.ttsServ != nil {
+				r.ttsServ.TickAutoPause()
+			}
+		}
+	}
+}
+
+// runFollowBatches anuncia los resúmenes de follows acumulados durante una oleada.
+func (r *Runtime) runFollowBatches(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.follows.Tick(ctx)
+		}
+	}
+}
+
 func (r *Runtime) stopTwitchAdapter() {
 	r.twitchMu.Lock()
 	cancel := r.twitchCancel
@@ -684,6 +1807,19 @@ func (r *Runtime) stopTwitchAdapter() {
 	}
 }
 
+// twitchConnectionHandler suma al aviso de conexión general el evento
+// twitch:bot:connected, que se publica recién cuando el IRC confirmó la
+// conexión (también después de cada reconexión).
+func (r *Runtime) twitchConnectionHandler(cfg twitchadapter.Config) twitchadapter.ConnectionHandler {
+	onConn := r.connectionHandler(domain.PlatformTwitch)
+	return func(connected bool) {
+		onConn(connected)
+		if connected {
+			r.publishTwitchConnected(cfg)
+		}
+	}
+}
+
 func (r *Runtime) publishTwitchConnected(cfg twitchadapter.Config) {
 	if r == nil || r.bus == nil {
 		return
@@ -740,7 +1876,7 @@ func ensureTwitchChannel(value string) string {
 	return strings.ToLower(value)
 }
 
-func resolveTwitchBroadcasterID(ctx context.Context, clientID, accessToken, username string) (string, error) {
+func resolveTwitchBroadcasterID(ctx context.Context, clientID, accessToken, username string, httpClient helix.HTTPClient) (string, error) {
 	if strings.TrimSpace(clientID) == "" {
 		return "", fmt.Errorf("twitch client id vacío")
 	}
@@ -754,6 +1890,7 @@ func resolveTwitchBroadcasterID(ctx context.Context, clientID, accessToken, user
 	client, err := helix.NewClient(&helix.Options{
 		ClientID:        clientID,
 		UserAccessToken: accessToken,
+		HTTPClient:      httpClient,
 	})
 	if err != nil {
 		return "", fmt.Errorf("helix: NewClient: %w", err)
@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// scopedFeature es una función que depende de los scopes otorgados a una
+// credencial; se reporta como capacidad cada vez que cambian las credenciales.
+type scopedFeature struct {
+	platform domain.Platform
+	feature  string
+	role     string
+	scopes   []string
+}
+
+var scopedFeatures = []scopedFeature{
+	{domain.PlatformTwitch, "chat_modes", "bot", []string{"moderator:manage:chat_settings"}},
+	{domain.PlatformTwitch, "moderation", "bot", []string{"moderator:manage:banned_users", "moderator:manage:chat_messages"}},
+	{domain.PlatformKick, "moderation", "streamer", []string{"moderation:ban", "moderation:chat_message:manage"}},
+}
+
+// HasScope indica si la credencial platform/role tiene scope. Sin credencial
+// responde false; las credenciales guardadas antes de registrar los scopes se
+// dan por buenas para no bloquear funciones que ya andaban.
+func (r *Runtime) HasScope(platform domain.Platform, role, scope string) bool {
+	cred := r.scopeCredential(r.ctx, platform, role)
+	if cred == nil {
+		return false
+	}
+	if len(cred.Scopes()) == 0 {
+		return true
+	}
+	return cred.HasScope(scope)
+}
+
+// scopeCredential lee la credencial que usa role. En Twitch el token del bot
+// reemplaza al del streamer cuando este falta (ver reconcileTwitchAccounts).
+func (r *Runtime) scopeCredential(ctx context.Context, platform domain.Platform, role string) *domain.Credential {
+	if r == nil || r.credStore == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cred, err := r.credStore.Get(ctx, platform, role)
+	if err != nil {
+		log.Printf("%s: no pude leer la credencial %s: %v", platform, role, err)
+		return nil
+	}
+	if cred == nil && platform == domain.PlatformTwitch && strings.EqualFold(role, "streamer") {
+		cred, err = r.credStore.Get(ctx, platform, "bot")
+		if err != nil {
+			log.Printf("%s: no pude leer la credencial bot: %v", platform, err)
+			return nil
+		}
+	}
+	return cred
+}
+
+// reportScopeCapabilities actualiza las capacidades que dependen de scopes.
+func (r *Runtime) reportScopeCapabilities(ctx context.Context, platform domain.Platform) {
+	for _, f := range scopedFeatures {
+		if f.platform != platform {
+			continue
+		}
+		cred := r.scopeCredential(ctx, f.platform, f.role)
+		if cred == nil {
+			r.reportCapability(f.platform, f.feature, false,
+				"Conecta tu cuenta de "+f.role+" para usar esta función")
+			continue
+		}
+		granted := cred.Scopes()
+		missing := domain.MissingScopes(f.scopes, granted)
+		if len(granted) == 0 || len(missing) == 0 {
+			r.reportCapability(f.platform, f.feature, true, "")
+			continue
+		}
+		r.reportCapability(f.platform, f.feature, false,
+			"Falta el permiso "+strings.Join(missing, ", ")+": vuelve a iniciar sesión")
+	}
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// startupBufferSize es el máximo de mensajes que se retienen mientras arranca el runtime.
+const startupBufferSize = 100
+
+type pendingMessage struct {
+	ctx context.Context
+	msg domain.Message
+}
+
+// startupGate se instala como handler de todos los adaptadores desde el inicio.
+// Mientras el runtime termina de arrancar guarda los mensajes (descartando los
+// más antiguos si se llena) y al abrirse los entrega en orden al dispatcher real.
+type startupGate struct {
+	// open serializa Open: un segundo llamado espera a que el primero termine
+	// de vaciar el buffer en vez de instalar su handler a mitad de camino
+	open    sync.Once
+	mu      sync.Mutex
+	handler func(context.Context, domain.Message) error
+	pending []pendingMessage
+	limit   int
+	dropped int
+}
+
+func newStartupGate(limit int) *startupGate {
+	if limit <= 0 {
+		limit = startupBufferSize
+	}
+	return &startupGate{limit: limit}
+}
+
+func (g *startupGate) Dispatch(ctx context.Context, msg domain.Message) error {
+	g.mu.Lock()
+	if handler := g.handler; handler != nil {
+		g.mu.Unlock()
+		return handler(ctx, msg)
+	}
+	if len(g.pending) >= g.limit {
+		g.pending = g.pending[1:]
+		g.dropped++
+	}
+	g.pending = append(g.pending, pendingMessage{ctx: ctx, msg: msg})
+	g.mu.Unlock()
+	return nil
+}
+
+// Open instala el dispatcher real y vacía el buffer. Es idempotente: solo
+// cuenta el primer handler no nulo, y los llamados concurrentes vuelven
+// cuando ya quedó instalado.
+func (g *startupGate) Open(handler func(context.Context, domain.Message) error) {
+	if handler == nil {
+		return
+	}
+	g.open.Do(func() { g.drain(handler) })
+}
+
+// drain entrega lo retenido y deja instalado handler cuando el buffer queda vacío.
+func (g *startupGate) drain(handler func(context.Context, domain.Message) error) {
+	delivered := 0
+	for {
+		g.mu.Lock()
+		if len(g.pending) == 0 {
+			g.handler = handler
+			dropped := g.dropped
+			g.dropped = 0
+			g.mu.Unlock()
+			if delivered > 0 || dropped > 0 {
+				log.Printf("runtime: %d mensajes recibidos durante el arranque entregados (%d descartados)", delivered, dropped)
+			}
+			return
+		}
+		batch := g.pending
+		g.pending = nil
+		g.mu.Unlock()
+
+		// los mensajes que lleguen mientras tanto se encolan y salen en la siguiente vuelta
+		for _, item := range batch {
+			if err := handler(item.ctx, item.msg); err != nil {
+				log.Printf("runtime: error entregando mensaje retenido: %v", err)
+			}
+			delivered++
+		}
+	}
+}
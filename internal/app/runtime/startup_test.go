@@ -0,0 +1,201 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	ws "zhatBot/internal/interface/api/ws"
+)
+
+type deliveries struct {
+	mu   sync.Mutex
+	msgs []domain.Message
+}
+
+func (d *deliveries) add(msg domain.Message) {
+	d.mu.Lock()
+	d.msgs = append(d.msgs, msg)
+	d.mu.Unlock()
+}
+
+func (d *deliveries) snapshot() []domain.Message {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]domain.Message(nil), d.msgs...)
+}
+
+func TestStartupGateDropsOldestWhenFull(t *testing.T) {
+	gate := newStartupGate(3)
+	for i := 1; i <= 5; i++ {
+		if err := gate.Dispatch(context.Background(), domain.Message{Text: fmt.Sprint(i)}); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+	}
+
+	got := &deliveries{}
+	gate.Open(func(_ context.Context, msg domain.Message) error {
+		got.add(msg)
+		return nil
+	})
+
+	var texts []string
+	for _, msg := range got.snapshot() {
+		texts = append(texts, msg.Text)
+	}
+	if fmt.Sprint(texts) != "[3 4 5]" {
+		t.Fatalf("delivered %v, want the 3 newest messages", texts)
+	}
+}
+
+func TestStartupGateOpenIsIdempotent(t *testing.T) {
+	gate := newStartupGate(10)
+	gate.Open(nil)
+	if err := gate.Dispatch(context.Background(), domain.Message{Text: "antes"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	first, second := &deliveries{}, &deliveries{}
+	gate.Open(func(_ context.Context, msg domain.Message) error { first.add(msg); return nil })
+	gate.Open(func(_ context.Context, msg domain.Message) error { second.add(msg); return nil })
+	if err := gate.Dispatch(context.Background(), domain.Message{Text: "después"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	if got := first.snapshot(); len(got) != 2 || got[0].Text != "antes" || got[1].Text != "después" {
+		t.Fatalf("first handler got %+v", got)
+	}
+	if got := second.snapshot(); len(got) != 0 {
+		t.Fatalf("second Open replaced the handler: %+v", got)
+	}
+}
+
+func TestStartupGateConcurrentOpenKeepsOrder(t *testing.T) {
+	gate := newStartupGate(10)
+	for _, text := range []string{"1", "2"} {
+		if err := gate.Dispatch(context.Background(), domain.Message{Text: text}); err != nil {
+			t.Fatalf("Dispatch: %v", err)
+		}
+	}
+
+	first, second := &deliveries{}, &deliveries{}
+	draining := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	opened := make(chan struct{})
+	go func() {
+		defer close(opened)
+		gate.Open(func(_ context.Context, msg domain.Message) error {
+			once.Do(func() {
+				close(draining)
+				<-release
+			})
+			first.add(msg)
+			return nil
+		})
+	}()
+	<-draining
+
+	// el segundo Open llega mientras el primero sigue vaciando el buffer
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		gate.Open(func(_ context.Context, msg domain.Message) error { second.add(msg); return nil })
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if err := gate.Dispatch(context.Background(), domain.Message{Text: "3"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	close(release)
+	<-opened
+	<-secondDone
+
+	var texts []string
+	for _, msg := range first.snapshot() {
+		texts = append(texts, msg.Text)
+	}
+	if fmt.Sprint(texts) != "[1 2 3]" {
+		t.Fatalf("first handler got %v, want [1 2 3]", texts)
+	}
+	if got := second.snapshot(); len(got) != 0 {
+		t.Fatalf("second Open took over mid-drain: %+v", got)
+	}
+}
+
+// TestStartupGateSlowStartup reproduce el arranque del runtime: los adaptadores
+// mandan mensajes desde el principio, el servidor WS tarda en escuchar y el
+// dispatcher real solo se instala cuando está listo.
+func TestStartupGateSlowStartup(t *testing.T) {
+	const (
+		producers   = 3
+		perProducer = 40
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := ws.NewServer(ws.Config{Addr: "127.0.0.1:0"})
+	gate := newStartupGate(startupBufferSize)
+	server.SetHandler(gate.Dispatch)
+
+	got := &deliveries{}
+	dispatch := func(ctx context.Context, msg domain.Message) error {
+		select {
+		case <-server.Ready():
+		default:
+			t.Errorf("message %q dispatched before the WS server was listening", msg.Text)
+		}
+		got.add(msg)
+		return server.PublishMessage(ctx, msg)
+	}
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				msg := domain.Message{Platform: domain.PlatformTwitch, UserID: fmt.Sprint(p), Text: fmt.Sprint(i)}
+				if err := gate.Dispatch(ctx, msg); err != nil {
+					t.Errorf("Dispatch: %v", err)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}(p)
+	}
+
+	// arranque lento: el servidor empieza a escuchar cuando ya llegaron mensajes
+	time.Sleep(20 * time.Millisecond)
+	serverDone := make(chan error, 1)
+	go func() { serverDone <- server.Start(ctx) }()
+	select {
+	case <-server.Ready():
+		gate.Open(dispatch)
+	case err := <-serverDone:
+		t.Fatalf("ws server stopped: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ws server never became ready")
+	}
+
+	wg.Wait()
+
+	msgs := got.snapshot()
+	if len(msgs) != producers*perProducer {
+		t.Fatalf("delivered %d messages, want %d", len(msgs), producers*perProducer)
+	}
+	next := make(map[string]int)
+	for _, msg := range msgs {
+		if want := fmt.Sprint(next[msg.UserID]); msg.Text != want {
+			t.Fatalf("producer %s: got message %s, want %s (out of order or lost)", msg.UserID, msg.Text, want)
+		}
+		next[msg.UserID]++
+	}
+
+	cancel()
+	if err := <-serverDone; err != nil {
+		t.Fatalf("ws server: %v", err)
+	}
+}
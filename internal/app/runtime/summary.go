@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"zhatBot/internal/infrastructure/config"
+)
+
+// startupSummary reúne en una línea lo que antes había que buscar en varios logs.
+type startupSummary struct {
+	Platforms   []string
+	DBPath      string
+	WSAddr      string
+	TwitchOAuth bool
+	KickOAuth   bool
+	TTSBackend  string
+	Channels    []string
+	ReadOnly    bool
+}
+
+func buildStartupSummary(cfg *config.Config, dbPath, wsAddr, ttsBackend string, kickBroadcasterID int, channels []string, readOnly bool) startupSummary {
+	summary := startupSummary{
+		DBPath:     dbPath,
+		WSAddr:     wsAddr,
+		TTSBackend: ttsBackend,
+		Channels:   append([]string(nil), channels...),
+		ReadOnly:   readOnly,
+	}
+	if cfg == nil {
+		return summary
+	}
+	summary.TwitchOAuth = cfg.TwitchClientId != "" && cfg.TwitchClientSecret != "" && cfg.TwitchRedirectURI != ""
+	summary.KickOAuth = cfg.KickClientID != "" && cfg.KickClientSecret != "" && cfg.KickRedirectURI != ""
+
+	if strings.TrimSpace(cfg.TwitchUsername) != "" || len(channels) > 0 || summary.TwitchOAuth {
+		summary.Platforms = append(summary.Platforms, "twitch")
+	}
+	if kickBroadcasterID > 0 || summary.KickOAuth {
+		summary.Platforms = append(summary.Platforms, "kick")
+	}
+	return summary
+}
+
+// String devuelve el resumen como pares clave=valor.
+func (s startupSummary) String() string {
+	return fmt.Sprintf("startup: platforms=%s db=%q ws=%s twitch_oauth=%s kick_oauth=%s tts=%s channels=%s read_only=%t",
+		listOrNone(s.Platforms), s.DBPath, s.WSAddr, onOff(s.TwitchOAuth), onOff(s.KickOAuth),
+		valueOrNone(s.TTSBackend), listOrNone(s.Channels), s.ReadOnly)
+}
+
+func listOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ",")
+}
+
+func valueOrNone(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "none"
+	}
+	return value
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
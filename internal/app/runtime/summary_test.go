@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"testing"
+
+	"zhatBot/internal/infrastructure/config"
+)
+
+func TestBuildStartupSummary(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       *config.Config
+		kickID    int
+		channels  []string
+		readOnly  bool
+		wantLine  string
+		wantPlats []string
+	}{
+		{
+			name: "both platforms with oauth",
+			cfg: &config.Config{
+				TwitchUsername:     "zerobot",
+				TwitchClientId:     "tid",
+				TwitchClientSecret: "tsecret",
+				TwitchRedirectURI:  "http://localhost/twitch",
+				KickClientID:       "kid",
+				KickClientSecret:   "ksecret",
+				KickRedirectURI:    "http://localhost/kick",
+			},
+			kickID:    77,
+			channels:  []string{"zeroproject", "amigo"},
+			readOnly:  true,
+			wantLine:  `startup: platforms=twitch,kick db="/data/bot.db" ws=:8080 twitch_oauth=on kick_oauth=on tts=google channels=zeroproject,amigo read_only=true`,
+			wantPlats: []string{"twitch", "kick"},
+		},
+		{
+			name:      "twitch without oauth secrets",
+			cfg:       &config.Config{TwitchUsername: "zerobot", TwitchClientId: "tid"},
+			channels:  []string{"zeroproject"},
+			wantLine:  `startup: platforms=twitch db="/data/bot.db" ws=:8080 twitch_oauth=off kick_oauth=off tts=google channels=zeroproject read_only=false`,
+			wantPlats: []string{"twitch"},
+		},
+		{
+			name:      "kick only by broadcaster id",
+			cfg:       &config.Config{},
+			kickID:    5,
+			wantLine:  `startup: platforms=kick db="/data/bot.db" ws=:8080 twitch_oauth=off kick_oauth=off tts=google channels=none read_only=false`,
+			wantPlats: []string{"kick"},
+		},
+		{
+			name:     "nothing configured",
+			cfg:      &config.Config{TwitchUsername: "   "},
+			wantLine: `startup: platforms=none db="/data/bot.db" ws=:8080 twitch_oauth=off kick_oauth=off tts=google channels=none read_only=false`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := buildStartupSummary(tc.cfg, "/data/bot.db", ":8080", "google", tc.kickID, tc.channels, tc.readOnly)
+			if got := summary.String(); got != tc.wantLine {
+				t.Fatalf("summary:\n got %s\nwant %s", got, tc.wantLine)
+			}
+			if len(summary.Platforms) != len(tc.wantPlats) {
+				t.Fatalf("platforms = %v, want %v", summary.Platforms, tc.wantPlats)
+			}
+		})
+	}
+}
+
+func TestBuildStartupSummaryWithoutConfig(t *testing.T) {
+	summary := buildStartupSummary(nil, "", ":8080", "", 0, nil, false)
+	want := `startup: platforms=none db="" ws=:8080 twitch_oauth=off kick_oauth=off tts=none channels=none read_only=false`
+	if got := summary.String(); got != want {
+		t.Fatalf("summary:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestBuildStartupSummaryCopiesChannels(t *testing.T) {
+	channels := []string{"zeroproject"}
+	summary := buildStartupSummary(&config.Config{}, "", "", "", 0, channels, false)
+	channels[0] = "cambiado"
+	if summary.Channels[0] != "zeroproject" {
+		t.Fatalf("summary shares the channels slice: %v", summary.Channels)
+	}
+}
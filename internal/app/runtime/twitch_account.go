@@ -0,0 +1,178 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+)
+
+var (
+	// twitchChatScopes son los que necesita el IRC para leer y escribir.
+	twitchChatScopes = []string{"chat:read", "chat:edit"}
+	// twitchChannelScopes son los que necesita cambiar título/categoría.
+	twitchChannelScopes = []string{"channel:manage:broadcast"}
+)
+
+// twitchAccountPlan dice qué credencial usa cada función de Twitch.
+type twitchAccountPlan struct {
+	same        bool
+	login       string
+	userID      string
+	chatRole    string
+	channelRole string
+}
+
+// sameTwitchAccount indica si las credenciales del bot y del streamer son de
+// la misma cuenta. Compara por user_id y, si falta en alguna, por login.
+func sameTwitchAccount(bot, streamer *domain.Credential) bool {
+	if !hasTwitchToken(bot) || !hasTwitchToken(streamer) {
+		return false
+	}
+	botID := strings.TrimSpace(bot.Metadata["user_id"])
+	streamerID := strings.TrimSpace(streamer.Metadata["user_id"])
+	if botID != "" && streamerID != "" {
+		return botID == streamerID
+	}
+	botLogin := strings.TrimSpace(bot.Metadata["login"])
+	streamerLogin := strings.TrimSpace(streamer.Metadata["login"])
+	return botLogin != "" && strings.EqualFold(botLogin, streamerLogin)
+}
+
+// twitchCredentialHasScopes indica si la credencial tiene todos los scopes.
+// Las credenciales guardadas antes de registrar los scopes no cuentan.
+func twitchCredentialHasScopes(cred *domain.Credential, scopes ...string) bool {
+	if !hasTwitchToken(cred) {
+		return false
+	}
+	granted := cred.Scopes()
+	return len(granted) > 0 && len(domain.MissingScopes(scopes, granted)) == 0
+}
+
+func hasTwitchToken(cred *domain.Credential) bool {
+	return cred != nil && strings.TrimSpace(cred.AccessToken) != ""
+}
+
+// planTwitchAccounts decide qué token usa el chat y cuál título/categoría.
+// Cada función usa su rol; si falta esa credencial y la del otro rol tiene
+// los scopes necesarios, usa esa, así una sola cuenta alcanza para las dos.
+func planTwitchAccounts(bot, streamer *domain.Credential) twitchAccountPlan {
+	plan := twitchAccountPlan{same: sameTwitchAccount(bot, streamer)}
+
+	switch {
+	case hasTwitchToken(bot):
+		plan.chatRole = "bot"
+	case twitchCredentialHasScopes(streamer, twitchChatScopes...):
+		plan.chatRole = "streamer"
+	}
+	switch {
+	case hasTwitchToken(streamer):
+		plan.channelRole = "streamer"
+	case twitchCredentialHasScopes(bot, twitchChannelScopes...):
+		plan.channelRole = "bot"
+	}
+
+	if plan.same || plan.chatRole != "bot" || plan.channelRole != "streamer" {
+		for _, cred := range []*domain.Credential{bot, streamer} {
+			if hasTwitchToken(cred) {
+				plan.login = strings.TrimSpace(cred.Metadata["login"])
+				plan.userID = strings.TrimSpace(cred.Metadata["user_id"])
+				break
+			}
+		}
+	}
+	return plan
+}
+
+// note explica el plan cuando no es el habitual de dos cuentas.
+func (p twitchAccountPlan) note() string {
+	switch {
+	case p.same:
+		return fmt.Sprintf("El bot y el streamer usan la misma cuenta de Twitch (%s): el chat usa el token del bot, título y categoría el del streamer, y comparten la cuota de Helix.", p.login)
+	case p.chatRole == "streamer":
+		return fmt.Sprintf("No hay cuenta de bot: el chat usa la cuenta del streamer (%s).", p.login)
+	case p.channelRole == "bot":
+		return fmt.Sprintf("No hay cuenta de streamer: título y categoría usan la cuenta del bot (%s).", p.login)
+	}
+	return ""
+}
+
+func (p twitchAccountPlan) dto() events.TwitchAccountDTO {
+	return events.TwitchAccountDTO{
+		Login:       p.login,
+		UserID:      p.userID,
+		SameAccount: p.same,
+		ChatRole:    p.chatRole,
+		ChannelRole: p.channelRole,
+		Note:        p.note(),
+	}
+}
+
+// TwitchAccount devuelve qué credencial de Twitch usa cada función.
+func (r *Runtime) TwitchAccount() events.TwitchAccountDTO {
+	if r == nil {
+		return events.TwitchAccountDTO{}
+	}
+	r.twitchMu.RLock()
+	defer r.twitchMu.RUnlock()
+	return r.twitchAccount
+}
+
+// reconcileTwitchAccounts revisa las credenciales del bot y del streamer
+// después de cada cambio. Si son la misma cuenta no hace falta una segunda
+// conexión: el IRC sigue con el token del bot, la API con el del streamer y
+// los dos tokens cuentan contra la misma cuota de Helix. Si falta una de las
+// dos, usa la otra cuando sus scopes alcanzan.
+func (r *Runtime) reconcileTwitchAccounts(ctx context.Context) {
+	if r == nil || r.credStore == nil {
+		return
+	}
+	bot, err := r.credStore.Get(ctx, domain.PlatformTwitch, "bot")
+	if err != nil {
+		log.Printf("twitch: no pude leer la credencial del bot: %v", err)
+		return
+	}
+	streamer, err := r.credStore.Get(ctx, domain.PlatformTwitch, "streamer")
+	if err != nil {
+		log.Printf("twitch: no pude leer la credencial del streamer: %v", err)
+		return
+	}
+	plan := planTwitchAccounts(bot, streamer)
+
+	if plan.same {
+		r.helixQuota.Alias("streamer", "bot")
+	} else {
+		r.helixQuota.Alias("streamer", "")
+	}
+	if plan.chatRole == "streamer" {
+		chat := *streamer
+		chat.Role = "bot"
+		r.applyTwitchCredential(&chat)
+	}
+	if plan.channelRole == "bot" && r.cfg != nil {
+		r.twitchMu.Lock()
+		r.cfg.TwitchApiToken = bot.AccessToken
+		r.twitchMu.Unlock()
+		if r.router != nil {
+			r.setupTwitchStreamer(ctx)
+		}
+	}
+
+	dto := plan.dto()
+	r.twitchMu.Lock()
+	changed := dto != r.twitchAccount
+	r.twitchAccount = dto
+	r.twitchMu.Unlock()
+	if !changed {
+		return
+	}
+	if dto.Note != "" {
+		log.Printf("twitch: %s", dto.Note)
+	}
+	if r.bus != nil {
+		r.bus.Publish(events.TopicTwitchAccount, dto)
+	}
+}
@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func twitchCred(role, userID, login, scopes string) *domain.Credential {
+	return &domain.Credential{
+		Platform:    domain.PlatformTwitch,
+		Role:        role,
+		AccessToken: "token-" + role,
+		Metadata: map[string]string{
+			"user_id":                  userID,
+			"login":                    login,
+			domain.CredentialScopesKey: scopes,
+		},
+	}
+}
+
+func TestSameTwitchAccount(t *testing.T) {
+	noToken := twitchCred("bot", "1", "zero", "")
+	noToken.AccessToken = " "
+
+	tests := []struct {
+		name     string
+		bot      *domain.Credential
+		streamer *domain.Credential
+		want     bool
+	}{
+		{"same user_id", twitchCred("bot", "1", "zero", ""), twitchCred("streamer", "1", "zero", ""), true},
+		{"user_id wins over login", twitchCred("bot", "1", "zero", ""), twitchCred("streamer", "2", "zero", ""), false},
+		{"login without user_id", twitchCred("bot", "", "Zero", ""), twitchCred("streamer", "1", "zero", ""), true},
+		{"different logins", twitchCred("bot", "", "zerobot", ""), twitchCred("streamer", "", "zero", ""), false},
+		{"no metadata at all", twitchCred("bot", "", "", ""), twitchCred("streamer", "", "", ""), false},
+		{"missing streamer", twitchCred("bot", "1", "zero", ""), nil, false},
+		{"empty token", noToken, twitchCred("streamer", "1", "zero", ""), false},
+	}
+	for _, tt := range tests {
+		if got := sameTwitchAccount(tt.bot, tt.streamer); got != tt.want {
+			t.Errorf("%s: sameTwitchAccount = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPlanTwitchAccounts(t *testing.T) {
+	const all = "chat:read chat:edit channel:manage:broadcast"
+
+	same := planTwitchAccounts(twitchCred("bot", "1", "zero", all), twitchCred("streamer", "1", "zero", all))
+	if !same.same || same.chatRole != "bot" || same.channelRole != "streamer" || same.login != "zero" || same.userID != "1" {
+		t.Fatalf("same account plan = %+v", same)
+	}
+	if note := same.note(); !strings.Contains(note, "misma cuenta") || !strings.Contains(note, "zero") {
+		t.Fatalf("note = %q", note)
+	}
+
+	two := planTwitchAccounts(twitchCred("bot", "2", "zerobot", all), twitchCred("streamer", "1", "zero", all))
+	if two.same || two.chatRole != "bot" || two.channelRole != "streamer" || two.note() != "" || two.login != "" {
+		t.Fatalf("two accounts plan = %+v", two)
+	}
+
+	// solo el streamer: el chat usa su token si tiene los scopes del IRC
+	onlyStreamer := planTwitchAccounts(nil, twitchCred("streamer", "1", "zero", all))
+	if onlyStreamer.chatRole != "streamer" || onlyStreamer.channelRole != "streamer" || onlyStreamer.login != "zero" {
+		t.Fatalf("streamer-only plan = %+v", onlyStreamer)
+	}
+	if plan := planTwitchAccounts(nil, twitchCred("streamer", "1", "zero", "channel:manage:broadcast")); plan.chatRole != "" {
+		t.Fatalf("chat without chat scopes: %+v", plan)
+	}
+	// scopes sin registrar (credencial vieja) no alcanzan
+	if plan := planTwitchAccounts(nil, twitchCred("streamer", "1", "zero", "")); plan.chatRole != "" {
+		t.Fatalf("chat with unknown scopes: %+v", plan)
+	}
+
+	onlyBot := planTwitchAccounts(twitchCred("bot", "2", "zerobot", all), nil)
+	if onlyBot.chatRole != "bot" || onlyBot.channelRole != "bot" || !strings.Contains(onlyBot.note(), "No hay cuenta de streamer") {
+		t.Fatalf("bot-only plan = %+v", onlyBot)
+	}
+	if plan := planTwitchAccounts(twitchCred("bot", "2", "zerobot", "chat:read chat:edit"), nil); plan.channelRole != "" {
+		t.Fatalf("channel without broadcast scope: %+v", plan)
+	}
+}
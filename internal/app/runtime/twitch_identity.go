@@ -0,0 +1,284 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+
+	"zhatBot/internal/domain"
+	twitchinfra "zhatBot/internal/infrastructure/platform/twitch"
+)
+
+// twitchIdentityCache guarda el ID del broadcaster junto al login con el que se resolvió.
+type twitchIdentityCache interface {
+	GetTwitchBroadcasterCache(ctx context.Context) (login, id string, err error)
+	SetTwitchBroadcasterCache(ctx context.Context, login, id string) error
+}
+
+// cachedTwitchBroadcasterID evita consultar Helix en cada arranque: solo vuelve a
+// resolver el ID cuando el login cambia o no hay nada guardado.
+func cachedTwitchBroadcasterID(
+	ctx context.Context,
+	cache twitchIdentityCache,
+	login string,
+	resolve func(ctx context.Context, login string) (string, error),
+) (string, error) {
+	login = strings.TrimSpace(login)
+	if cache != nil && login != "" {
+		cachedLogin, cachedID, err := cache.GetTwitchBroadcasterCache(ctx)
+		if err != nil {
+			log.Printf("twitch: no pude leer el broadcaster cacheado: %v", err)
+		} else if cachedID != "" && strings.EqualFold(cachedLogin, login) {
+			return cachedID, nil
+		}
+	}
+
+	id, err := resolve(ctx, login)
+	if err != nil {
+		return "", err
+	}
+
+	if cache != nil && id != "" {
+		if err := cache.SetTwitchBroadcasterCache(ctx, login, id); err != nil {
+			log.Printf("twitch: no pude guardar el broadcaster en caché: %v", err)
+		}
+	}
+	return id, nil
+}
+
+// TwitchBotUserID devuelve el ID numérico de la cuenta del bot (moderator_id en Helix).
+func (r *Runtime) TwitchBotUserID() string {
+	if r == nil {
+		return ""
+	}
+	r.twitchMu.RLock()
+	defer r.twitchMu.RUnlock()
+	return r.twitchBotUserID
+}
+
+// twitchIdentityInterval es cada cuánto se vuelve a comparar la metadata de
+// las credenciales con Twitch.
+const twitchIdentityInterval = 24 * time.Hour
+
+// twitchTokenOwner devuelve el ID y el login del dueño de un token.
+type twitchTokenOwner func(ctx context.Context, role, accessToken string) (id, login string, err error)
+
+// reconcileTwitchIdentities compara las credenciales guardadas del bot y del
+// streamer con /helix/users. Si la cuenta cambió de nombre en Twitch corrige
+// la metadata y los canales derivados del login; si el adaptador ya estaba
+// conectado lo reconecta al canal nuevo. También completa el user_id de
+// credenciales antiguas que no lo tenían.
+func (r *Runtime) reconcileTwitchIdentities(ctx context.Context) {
+	if r == nil || r.credStore == nil || r.cfg == nil {
+		return
+	}
+	changed := r.reconcileTwitchIdentitiesWith(ctx, r.twitchOwnerLookup())
+	// un login nuevo del streamer cambia el canal; el ID del bot, el moderator_id
+	if r.router != nil {
+		r.setupTwitchStreamer(ctx)
+	}
+	r.wireTwitchModeration()
+	if !changed {
+		return
+	}
+
+	r.twitchMu.RLock()
+	running := r.twitchAd != nil
+	r.twitchMu.RUnlock()
+	// al arrancar el adaptador todavía no existe y se conecta después con
+	// los datos ya corregidos
+	if running {
+		r.syncTwitchAdapter()
+	}
+}
+
+// reconcileTwitchIdentitiesWith revisa ambas credenciales con owner y
+// devuelve true si hay que reconectar el IRC.
+func (r *Runtime) reconcileTwitchIdentitiesWith(ctx context.Context, owner twitchTokenOwner) bool {
+	changed := false
+	for _, role := range []string{"bot", "streamer"} {
+		if r.reconcileTwitchIdentity(ctx, role, owner) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// reconcileTwitchIdentity revisa la credencial de role y devuelve true si
+// cambió algo que requiere reconectar el IRC (login del bot o canales).
+func (r *Runtime) reconcileTwitchIdentity(ctx context.Context, role string, owner twitchTokenOwner) bool {
+	cred, err := r.credStore.Get(ctx, domain.PlatformTwitch, role)
+	if err != nil || cred == nil || strings.TrimSpace(cred.AccessToken) == "" {
+		return false
+	}
+
+	id, login, err := owner(ctx, role, cred.AccessToken)
+	if err != nil {
+		log.Printf("twitch: no pude validar la cuenta del %s: %v", role, err)
+		return false
+	}
+	login = strings.ToLower(strings.TrimSpace(login))
+	storedID := strings.TrimSpace(cred.Metadata["user_id"])
+	storedLogin := strings.TrimSpace(cred.Metadata["login"])
+
+	if storedID != id || !strings.EqualFold(storedLogin, login) {
+		switch {
+		case storedID != "" && storedID != id:
+			log.Printf("twitch: el token del %s ahora es de otra cuenta (%s/%s → %s/%s)", role, storedLogin, storedID, login, id)
+		case storedLogin != "" && !strings.EqualFold(storedLogin, login):
+			// los datos guardados van por user_id, así que el historial se conserva
+			log.Printf("twitch: la cuenta del %s (%s) cambió de nombre: %s → %s", role, id, storedLogin, login)
+		}
+		if cred.Metadata == nil {
+			cred.Metadata = make(map[string]string)
+		}
+		cred.Metadata["user_id"] = id
+		if login != "" {
+			cred.Metadata["login"] = login
+		}
+		if err := r.credStore.Save(ctx, cred); err != nil {
+			log.Printf("twitch: no pude actualizar la credencial del %s: %v", role, err)
+		}
+	}
+
+	return r.applyTwitchIdentity(role, id, storedLogin, login)
+}
+
+// applyTwitchIdentity lleva el ID y el login a la configuración en memoria.
+// Los canales que salían del login viejo pasan al nuevo.
+func (r *Runtime) applyTwitchIdentity(role, id, oldLogin, login string) bool {
+	r.twitchMu.Lock()
+	defer r.twitchMu.Unlock()
+
+	changed := false
+	switch role {
+	case "bot":
+		r.twitchBotUserID = id
+		if login != "" && !strings.EqualFold(login, r.twitchBotLogin) {
+			r.twitchBotLogin = login
+			if r.cfg != nil {
+				r.cfg.TwitchUsername = login
+			}
+			changed = true
+		}
+	case "streamer":
+		if login != "" {
+			r.twitchStreamerLogin = login
+		}
+	}
+
+	if oldLogin == "" || login == "" || strings.EqualFold(oldLogin, login) {
+		return changed
+	}
+	oldChannel := ensureTwitchChannel(oldLogin)
+	for i, channel := range r.twitchChannels {
+		if channel == oldChannel {
+			r.twitchChannels[i] = ensureTwitchChannel(login)
+			changed = true
+		}
+	}
+	r.twitchChannels = sanitizeTwitchChannels(r.twitchChannels)
+	if r.cfg != nil {
+		r.cfg.TwitchChannels = append([]string(nil), r.twitchChannels...)
+	}
+	return changed
+}
+
+// runTwitchIdentityCheck repite la reconciliación una vez por día.
+func (r *Runtime) runTwitchIdentityCheck(ctx context.Context) {
+	ticker := time.NewTicker(twitchIdentityInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcileTwitchIdentities(ctx)
+		}
+	}
+}
+
+// fetchTwitchTokenOwner consulta /helix/users sin parámetros, que devuelve el
+// dueño del token.
+func fetchTwitchTokenOwner(ctx context.Context, clientID, accessToken string, httpClient helix.HTTPClient) (string, string, error) {
+	if strings.TrimSpace(clientID) == "" {
+		return "", "", fmt.Errorf("twitch client id vacío")
+	}
+	if strings.TrimSpace(accessToken) == "" {
+		return "", "", fmt.Errorf("twitch access token vacío")
+	}
+
+	client, err := helix.NewClientWithContext(ctx, &helix.Options{
+		ClientID:        clientID,
+		UserAccessToken: accessToken,
+		HTTPClient:      httpClient,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("helix: NewClient: %w", err)
+	}
+
+	resp, err := client.GetUsers(&helix.UsersParams{})
+	if err != nil {
+		return "", "", fmt.Errorf("helix: GetUsers: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("helix: GetUsers failed (%d: %s) %s",
+			resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	if len(resp.Data.Users) == 0 {
+		return "", "", fmt.Errorf("helix: token sin usuario asociado")
+	}
+
+	user := resp.Data.Users[0]
+	return user.ID, user.Login, nil
+}
+
+// twitchBotAPI devuelve un cliente Helix con el token del bot (para endpoints
+// que actúan como moderador, p. ej. anuncios). Se crea bajo demanda y se
+// mantiene sincronizado con el token actual.
+func (r *Runtime) twitchBotAPI() domain.TwitchChannelService {
+	if r == nil || r.cfg == nil {
+		return nil
+	}
+	r.twitchMu.Lock()
+	defer r.twitchMu.Unlock()
+
+	token := strings.TrimPrefix(r.twitchBotToken, "oauth:")
+	if token == "" || strings.TrimSpace(r.cfg.TwitchClientId) == "" {
+		return nil
+	}
+
+	if r.twitchBotSvc != nil {
+		if token != r.twitchBotSvcToken {
+			if updater, ok := r.twitchBotSvc.(interface{ UpdateAccessToken(string) }); ok {
+				updater.UpdateAccessToken(token)
+			}
+			r.twitchBotSvcToken = token
+		}
+		return r.twitchBotSvc
+	}
+
+	svc, err := twitchinfra.NewStreamServiceWithQuota(r.cfg.TwitchClientId, token, r.helixQuota, "bot")
+	if err != nil {
+		log.Printf("twitch: no pude crear el cliente Helix del bot: %v", err)
+		return nil
+	}
+	r.twitchBotSvc = svc
+	r.twitchBotSvcToken = token
+	return svc
+}
+
+// sendTwitchWhisper manda un whisper desde la cuenta del bot.
+func (r *Runtime) sendTwitchWhisper(ctx context.Context, toUserID, text string) error {
+	whisper, ok := r.twitchBotAPI().(domain.TwitchWhisperService)
+	botID := r.TwitchBotUserID()
+	if !ok || botID == "" {
+		return domain.ErrPrivateMessageUnsupported
+	}
+	return whisper.SendWhisper(ctx, botID, toUserID, text)
+}
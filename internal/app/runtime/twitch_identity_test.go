@@ -0,0 +1,216 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/infrastructure/config"
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+)
+
+func newTestStore(t *testing.T) *sqlitestorage.CredentialStore {
+	t.Helper()
+	store, err := sqlitestorage.NewCredentialStore(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestCachedTwitchBroadcasterIDInvalidatesOnLoginChange(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	var lookups []string
+	resolve := func(_ context.Context, login string) (string, error) {
+		lookups = append(lookups, login)
+		return "id-" + login, nil
+	}
+
+	id, err := cachedTwitchBroadcasterID(ctx, store, "Streamer", resolve)
+	if err != nil || id != "id-Streamer" {
+		t.Fatalf("first lookup = %q, %v", id, err)
+	}
+	// mismo login (sin importar mayúsculas): sale de la caché
+	id, err = cachedTwitchBroadcasterID(ctx, store, "streamer", resolve)
+	if err != nil || id != "id-Streamer" {
+		t.Fatalf("cached lookup = %q, %v", id, err)
+	}
+	if len(lookups) != 1 {
+		t.Fatalf("resolved %d times, want 1 (%v)", len(lookups), lookups)
+	}
+
+	// el streamer cambió de nombre: se vuelve a resolver y se pisa la caché
+	id, err = cachedTwitchBroadcasterID(ctx, store, "renamed", resolve)
+	if err != nil || id != "id-renamed" {
+		t.Fatalf("lookup after rename = %q, %v", id, err)
+	}
+	login, cachedID, err := store.GetTwitchBroadcasterCache(ctx)
+	if err != nil || login != "renamed" || cachedID != "id-renamed" {
+		t.Fatalf("cache = %q/%q, %v", login, cachedID, err)
+	}
+	if len(lookups) != 2 {
+		t.Fatalf("resolved %d times, want 2 (%v)", len(lookups), lookups)
+	}
+}
+
+func TestCachedTwitchBroadcasterIDKeepsCacheOnError(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetTwitchBroadcasterCache(ctx, "old", "id-old"); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := func(context.Context, string) (string, error) { return "", errors.New("helix caído") }
+	if _, err := cachedTwitchBroadcasterID(ctx, store, "new", failing); err == nil {
+		t.Fatalf("resolver error swallowed")
+	}
+	login, id, _ := store.GetTwitchBroadcasterCache(ctx)
+	if login != "old" || id != "id-old" {
+		t.Fatalf("failed lookup overwrote the cache: %q/%q", login, id)
+	}
+
+	// sin caché siempre se resuelve
+	id, err := cachedTwitchBroadcasterID(ctx, nil, "new", func(context.Context, string) (string, error) { return "42", nil })
+	if err != nil || id != "42" {
+		t.Fatalf("lookup without cache = %q, %v", id, err)
+	}
+}
+
+// helixUsersStub responde /helix/users con el dueño de cada token.
+func helixUsersStub(t *testing.T, owners map[string][2]string) helix.HTTPClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/helix/users" {
+			http.NotFound(w, r)
+			return
+		}
+		owner, ok := owners[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[{"id":%q,"login":%q,"display_name":%q}]}`, owner[0], owner[1], owner[1])
+	}))
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+	return &http.Client{Transport: rewriteHost{target: target}}
+}
+
+// rewriteHost manda a target los pedidos que iban a api.twitch.tv.
+type rewriteHost struct{ target *url.URL }
+
+func (rw rewriteHost) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rw.target.Scheme
+	req.URL.Host = rw.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func saveTwitchCredential(t *testing.T, store *sqlitestorage.CredentialStore, role, token, id, login string) {
+	t.Helper()
+	err := store.Save(context.Background(), &domain.Credential{
+		Platform:    domain.PlatformTwitch,
+		Role:        role,
+		AccessToken: token,
+		Metadata:    map[string]string{"user_id": id, "login": login},
+	})
+	if err != nil {
+		t.Fatalf("Save %s: %v", role, err)
+	}
+}
+
+func TestReconcileTwitchIdentitiesFollowsRename(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	saveTwitchCredential(t, store, "bot", "bot-token", "100", "zhatbot")
+	saveTwitchCredential(t, store, "streamer", "streamer-token", "200", "zero")
+
+	client := helixUsersStub(t, map[string][2]string{
+		"bot-token":      {"100", "zhatbot"},
+		"streamer-token": {"200", "zero_dev"}, // el streamer cambió de nombre
+	})
+	owner := func(ctx context.Context, _ string, token string) (string, string, error) {
+		return fetchTwitchTokenOwner(ctx, "client-id", token, client)
+	}
+
+	r := &Runtime{
+		cfg:            &config.Config{TwitchChannels: []string{"#zero", "#amigo"}},
+		credStore:      store,
+		twitchBotLogin: "zhatbot",
+		twitchChannels: []string{"#zero", "#amigo"},
+	}
+	if !r.reconcileTwitchIdentitiesWith(ctx, owner) {
+		t.Fatal("el cambio de nombre no pidió reconectar el IRC")
+	}
+
+	want := []string{"#zero_dev", "#amigo"}
+	if !slices.Equal(r.twitchChannels, want) || !slices.Equal(r.cfg.TwitchChannels, want) {
+		t.Fatalf("canales = %v (cfg %v), esperaba %v", r.twitchChannels, r.cfg.TwitchChannels, want)
+	}
+	if r.twitchStreamerLogin != "zero_dev" || r.TwitchBotUserID() != "100" {
+		t.Fatalf("streamer = %q, bot id = %q", r.twitchStreamerLogin, r.TwitchBotUserID())
+	}
+	cred, err := store.Get(ctx, domain.PlatformTwitch, "streamer")
+	if err != nil || cred.Metadata["login"] != "zero_dev" || cred.Metadata["user_id"] != "200" {
+		t.Fatalf("credencial del streamer = %+v, %v", cred, err)
+	}
+
+	// una segunda pasada sin cambios no vuelve a reconectar
+	if r.reconcileTwitchIdentitiesWith(ctx, owner) {
+		t.Fatal("se pidió reconectar sin cambios")
+	}
+}
+
+func TestReconcileTwitchIdentitiesBotRename(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	saveTwitchCredential(t, store, "bot", "bot-token", "100", "zhatbot")
+
+	client := helixUsersStub(t, map[string][2]string{"bot-token": {"100", "ZhatBot_v2"}})
+	owner := func(ctx context.Context, _ string, token string) (string, string, error) {
+		return fetchTwitchTokenOwner(ctx, "client-id", token, client)
+	}
+	r := &Runtime{cfg: &config.Config{TwitchUsername: "zhatbot"}, credStore: store, twitchBotLogin: "zhatbot"}
+
+	if !r.reconcileTwitchIdentitiesWith(ctx, owner) {
+		t.Fatal("el bot cambió de nombre y no se pidió reconectar")
+	}
+	if r.twitchBotLogin != "zhatbot_v2" || r.cfg.TwitchUsername != "zhatbot_v2" {
+		t.Fatalf("login del bot = %q (cfg %q)", r.twitchBotLogin, r.cfg.TwitchUsername)
+	}
+}
+
+func TestReconcileTwitchIdentitiesKeepsDataOnError(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	saveTwitchCredential(t, store, "streamer", "revocado", "200", "zero")
+
+	client := helixUsersStub(t, nil)
+	owner := func(ctx context.Context, _ string, token string) (string, string, error) {
+		return fetchTwitchTokenOwner(ctx, "client-id", token, client)
+	}
+	r := &Runtime{cfg: &config.Config{}, credStore: store, twitchChannels: []string{"#zero"}}
+
+	if r.reconcileTwitchIdentitiesWith(ctx, owner) {
+		t.Fatal("un token rechazado pidió reconectar")
+	}
+	cred, _ := store.Get(ctx, domain.PlatformTwitch, "streamer")
+	if cred.Metadata["login"] != "zero" || !slices.Equal(r.twitchChannels, []string{"#zero"}) {
+		t.Fatalf("se tocaron los datos: %+v, %v", cred.Metadata, r.twitchChannels)
+	}
+}
@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// twitchProfileRetryDelay es la primera espera antes de reintentar leer el
+	// perfil; se duplica en cada intento hasta twitchProfileRetryMaxDelay.
+	twitchProfileRetryDelay    = 5 * time.Second
+	twitchProfileRetryMaxDelay = 5 * time.Minute
+	twitchProfileRetryAttempts = 6
+)
+
+// twitchOwnerLookup consulta el dueño de un token con el cliente Helix del rol.
+func (r *Runtime) twitchOwnerLookup() twitchTokenOwner {
+	clientID := r.cfg.TwitchClientId
+	return func(ctx context.Context, role, accessToken string) (string, string, error) {
+		return fetchTwitchTokenOwner(ctx, clientID, accessToken, r.helixQuota.Client(role, nil))
+	}
+}
+
+// scheduleTwitchProfileRetry reintenta en segundo plano completar el login y
+// el user_id de una credencial de Twitch que se guardó sin ellos. Sin login
+// no se sabe a qué canal unirse y el IRC no arranca.
+func (r *Runtime) scheduleTwitchProfileRetry(cred *domain.Credential) {
+	if r == nil || r.credStore == nil || r.cfg == nil || !cred.MissingProfile() {
+		return
+	}
+	role := strings.ToLower(strings.TrimSpace(cred.Role))
+
+	r.profileMu.Lock()
+	if r.profileRetries == nil {
+		r.profileRetries = make(map[string]bool)
+	}
+	if r.profileRetries[role] {
+		r.profileMu.Unlock()
+		return
+	}
+	r.profileRetries[role] = true
+	r.profileMu.Unlock()
+
+	log.Printf("twitch: la credencial del %s no tiene perfil; reintentando en segundo plano", role)
+	go func() {
+		defer func() {
+			r.profileMu.Lock()
+			delete(r.profileRetries, role)
+			r.profileMu.Unlock()
+		}()
+		r.retryTwitchProfile(r.ctx, role, r.twitchOwnerLookup(), twitchProfileRetryDelay)
+	}()
+}
+
+// retryTwitchProfile espera delay antes del primer intento y lo duplica en
+// cada uno, hasta twitchProfileRetryMaxDelay.
+func (r *Runtime) retryTwitchProfile(ctx context.Context, role string, owner twitchTokenOwner, delay time.Duration) {
+	for attempt := 1; attempt <= twitchProfileRetryAttempts; attempt++ {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, twitchProfileRetryMaxDelay)
+
+		// se relee en cada intento: el token pudo refrescarse o la sesión cerrarse
+		cred, err := r.credStore.Get(ctx, domain.PlatformTwitch, role)
+		if err != nil {
+			log.Printf("twitch: no pude leer la credencial del %s: %v", role, err)
+			continue
+		}
+		if !cred.MissingProfile() {
+			return
+		}
+
+		id, login, err := owner(ctx, role, cred.AccessToken)
+		if err != nil || strings.TrimSpace(login) == "" {
+			log.Printf("twitch: intento %d/%d de leer el perfil del %s falló: %v", attempt, twitchProfileRetryAttempts, role, err)
+			continue
+		}
+
+		if cred.Metadata == nil {
+			cred.Metadata = make(map[string]string)
+		}
+		cred.Metadata["user_id"] = id
+		cred.Metadata["login"] = strings.ToLower(strings.TrimSpace(login))
+		if err := r.credStore.Save(ctx, cred); err != nil {
+			log.Printf("twitch: no pude guardar el perfil del %s: %v", role, err)
+			continue
+		}
+		log.Printf("twitch: perfil del %s recuperado (%s)", role, cred.Metadata["login"])
+		// el hook aplica el login y arranca el IRC
+		r.NotifyCredentialUpdate(ctx, cred)
+		return
+	}
+	log.Printf("twitch: no pude leer el perfil del %s tras %d intentos; hay que volver a iniciar sesión desde el panel", role, twitchProfileRetryAttempts)
+}
@@ -0,0 +1,146 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	credentialsusecase "zhatBot/internal/usecase/credentials"
+)
+
+// flakyOwner falla las primeras failures consultas y después responde el
+// dueño del token.
+type flakyOwner struct {
+	mu       sync.Mutex
+	failures int
+	tokens   []string
+}
+
+func (f *flakyOwner) lookup(_ context.Context, _ string, accessToken string) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = append(f.tokens, accessToken)
+	if len(f.tokens) <= f.failures {
+		return "", "", errors.New("helix: connection reset by peer")
+	}
+	return "100", "ZhatBot", nil
+}
+
+func (f *flakyOwner) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.tokens)
+}
+
+// newProfileTestRuntime arma un runtime cuyo hook de credenciales anota lo
+// que recibe en vez de arrancar el IRC.
+func newProfileTestRuntime(t *testing.T) (*Runtime, chan *domain.Credential) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	hooked := make(chan *domain.Credential, 4)
+	hooks := credentialsusecase.NewHookDispatcher(func(_ context.Context, cred *domain.Credential) {
+		hooked <- cred
+	}, 0)
+	hooks.Start(ctx)
+
+	return &Runtime{ctx: ctx, credStore: newTestStore(t), credHooks: hooks}, hooked
+}
+
+func TestRetryTwitchProfileAfterFailures(t *testing.T) {
+	r, hooked := newProfileTestRuntime(t)
+	ctx := context.Background()
+	// el callback de OAuth guardó la credencial sin perfil
+	if err := r.credStore.Save(ctx, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "token-1"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	owner := &flakyOwner{failures: 2}
+	r.retryTwitchProfile(ctx, "bot", owner.lookup, time.Millisecond)
+
+	if got := owner.calls(); got != 3 {
+		t.Fatalf("owner consulted %d times, want 3", got)
+	}
+	stored, err := r.credStore.Get(ctx, domain.PlatformTwitch, "bot")
+	if err != nil || stored.Metadata["login"] != "zhatbot" || stored.Metadata["user_id"] != "100" {
+		t.Fatalf("stored = %+v, %v", stored, err)
+	}
+
+	// el hook recibe la credencial con login, que es lo que arranca el IRC
+	select {
+	case cred := <-hooked:
+		if cred.Role != "bot" || cred.Metadata["login"] != "zhatbot" || cred.MissingProfile() {
+			t.Fatalf("hook got %+v", cred)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("credential hook not fired after the profile was found")
+	}
+}
+
+func TestRetryTwitchProfileRereadsTheCredential(t *testing.T) {
+	r, hooked := newProfileTestRuntime(t)
+	ctx := context.Background()
+	save := func(token string) {
+		t.Helper()
+		if err := r.credStore.Save(ctx, &domain.Credential{Platform: domain.PlatformTwitch, Role: "streamer", AccessToken: token}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	save("token-1")
+
+	// entre intentos el token se refrescó: se usa el guardado, no el viejo
+	owner := &flakyOwner{failures: 1}
+	refreshing := func(ctx context.Context, role, token string) (string, string, error) {
+		id, login, err := owner.lookup(ctx, role, token)
+		if err != nil {
+			save("token-2")
+		}
+		return id, login, err
+	}
+	r.retryTwitchProfile(ctx, "streamer", refreshing, time.Millisecond)
+	if owner.tokens[0] != "token-1" || owner.tokens[1] != "token-2" {
+		t.Fatalf("tokens used = %q", owner.tokens)
+	}
+	<-hooked
+
+	// si la sesión se cerró no se sigue intentando
+	if err := r.credStore.Delete(ctx, domain.PlatformTwitch, "streamer"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	gone := &flakyOwner{}
+	r.retryTwitchProfile(ctx, "streamer", gone.lookup, time.Millisecond)
+	if gone.calls() != 0 {
+		t.Fatalf("looked up a deleted credential %d times", gone.calls())
+	}
+}
+
+func TestRetryTwitchProfileGivesUp(t *testing.T) {
+	r, hooked := newProfileTestRuntime(t)
+	ctx := context.Background()
+	if err := r.credStore.Save(ctx, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "token-1"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	owner := &flakyOwner{failures: 100}
+	r.retryTwitchProfile(ctx, "bot", owner.lookup, time.Millisecond)
+	if got := owner.calls(); got != twitchProfileRetryAttempts {
+		t.Fatalf("owner consulted %d times, want %d", got, twitchProfileRetryAttempts)
+	}
+	select {
+	case cred := <-hooked:
+		t.Fatalf("hook fired without a profile: %+v", cred)
+	default:
+	}
+
+	// cancelar corta la espera
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	r.retryTwitchProfile(cancelled, "bot", owner.lookup, time.Hour)
+	if got := owner.calls(); got != twitchProfileRetryAttempts {
+		t.Fatalf("cancelled retry consulted the owner")
+	}
+}
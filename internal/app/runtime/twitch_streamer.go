@@ -0,0 +1,210 @@
+package runtime
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+	twitchinfra "zhatBot/internal/infrastructure/platform/twitch"
+	"zhatBot/internal/usecase/commands"
+	readonlyusecase "zhatBot/internal/usecase/readonly"
+)
+
+// featureChannelManagement agrupa título, categoría, estado del stream y modos
+// del chat: todo lo que necesita el token del streamer.
+const featureChannelManagement = "channel_management"
+
+// twitchStreamerAPI devuelve el cliente Helix del streamer y el ID del canal
+// (nil/"" mientras no haya token de streamer).
+func (r *Runtime) twitchStreamerAPI() (domain.TwitchChannelService, string) {
+	if r == nil {
+		return nil, ""
+	}
+	r.streamerMu.RLock()
+	defer r.streamerMu.RUnlock()
+	return r.twitchAPI, r.twitchBroadcasterID
+}
+
+// setupTwitchStreamer inicializa los servicios que dependen del token del
+// streamer. Se llama al arrancar y cada vez que cambia la credencial de
+// streamer, así las funciones aparecen en cuanto el streamer inicia sesión y
+// siguen a la cuenta nueva si cambia.
+func (r *Runtime) setupTwitchStreamer(ctx context.Context) {
+	if r == nil || r.cfg == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = r.ctx
+	}
+
+	r.twitchMu.RLock()
+	clientID := strings.TrimSpace(r.cfg.TwitchClientId)
+	token := strings.TrimSpace(r.cfg.TwitchApiToken)
+	login := r.twitchStreamerLogin
+	if login == "" {
+		login = strings.TrimSpace(r.cfg.TwitchUsername)
+	}
+	r.twitchMu.RUnlock()
+
+	switch {
+	case clientID == "":
+		r.reportCapability(domain.PlatformTwitch, featureChannelManagement, false,
+			"Configura TWITCH_CLIENT_ID para cambiar título/categoría")
+		return
+	case token == "":
+		r.reportCapability(domain.PlatformTwitch, featureChannelManagement, false,
+			"Conecta tu cuenta de streamer para cambiar título/categoría")
+		return
+	}
+
+	r.streamerMu.Lock()
+	// con el mismo login solo cambia el token; otra cuenta vuelve a resolver
+	// el canal y a armar todo lo que depende de él
+	if r.twitchAPI != nil && strings.EqualFold(login, r.twitchAPILogin) {
+		if token != r.twitchAPIToken {
+			if updater, ok := r.twitchAPI.(interface{ UpdateAccessToken(string) }); ok {
+				updater.UpdateAccessToken(token)
+			}
+			r.twitchAPIToken = token
+		}
+		r.streamerMu.Unlock()
+		return
+	}
+
+	service, err := twitchinfra.NewStreamServiceWithQuota(clientID, token, r.helixQuota, "streamer")
+	if err != nil {
+		r.streamerMu.Unlock()
+		log.Printf("no se pudo iniciar el servicio de Twitch: %v", err)
+		r.reportCapability(domain.PlatformTwitch, featureChannelManagement, false,
+			"No se pudo iniciar la API de Twitch")
+		return
+	}
+	broadcasterID, err := cachedTwitchBroadcasterID(ctx, r.credStore, login, func(ctx context.Context, login string) (string, error) {
+		return resolveTwitchBroadcasterID(ctx, clientID, token, login, r.helixQuota.Client("streamer", nil))
+	})
+	if err != nil {
+		r.streamerMu.Unlock()
+		log.Printf("no pude resolver el ID de Twitch: %v", err)
+		r.reportCapability(domain.PlatformTwitch, featureChannelManagement, false,
+			"No pude resolver el canal de Twitch del streamer")
+		return
+	}
+
+	if r.twitchBroadcasterID != "" && r.twitchBroadcasterID != broadcasterID {
+		log.Printf("twitch: cambió la cuenta del streamer (%s → %s/%s)", r.twitchAPILogin, login, broadcasterID)
+	}
+	r.twitchAPI = service
+	r.twitchAPIToken = token
+	r.twitchAPILogin = login
+	r.twitchBroadcasterID = broadcasterID
+	r.streamerMu.Unlock()
+
+	// título y categoría pasan por el modo solo lectura
+	mutable := readonlyusecase.TwitchChannel(service, r.readOnly)
+	r.category.SetTwitchService(mutable, broadcasterID)
+	r.titles.Set(domain.PlatformTwitch, twitchinfra.NewTwitchTitleAdapter(mutable, broadcasterID))
+	r.status.Set(domain.PlatformTwitch, twitchinfra.NewTwitchStatusAdapter(service, broadcasterID))
+	if r.customs != nil {
+		r.customs.SetAudienceResolver(commands.NewTwitchAudienceResolver(service, broadcasterID))
+	}
+	if r.router != nil {
+		r.router.Register(commands.NewAccountAgeCommand(service))
+	}
+	r.wireTwitchModeration()
+
+	r.reportCapability(domain.PlatformTwitch, featureChannelManagement, true, "")
+}
+
+// twitchModerator devuelve el cliente Helix, el canal y el moderator_id de
+// las llamadas de moderación. Helix exige que moderator_id sea el dueño del
+// token, así que modera el bot con su propio cliente (como los anuncios);
+// mientras no se conozca su ID lo hace el streamer en su canal.
+func (r *Runtime) twitchModerator() (domain.TwitchChannelService, string, string) {
+	streamer, broadcasterID := r.twitchStreamerAPI()
+	if streamer == nil || broadcasterID == "" {
+		return nil, "", ""
+	}
+	if botID := r.TwitchBotUserID(); botID != "" {
+		if bot := r.twitchBotAPI(); bot != nil {
+			return bot, broadcasterID, botID
+		}
+	}
+	return streamer, broadcasterID, broadcasterID
+}
+
+// wireTwitchModeration registra los modos del chat y las sanciones de Twitch
+// con el moderador vigente. Se vuelve a llamar cuando cambia la cuenta del
+// bot o la del streamer.
+func (r *Runtime) wireTwitchModeration() {
+	service, broadcasterID, moderatorID := r.twitchModerator()
+	if service == nil {
+		return
+	}
+	if raw, ok := service.(*twitchinfra.TwitchStreamService); ok && r.modActions != nil {
+		r.modActions.Set(domain.PlatformTwitch, readonlyusecase.Moderation(
+			twitchinfra.NewTwitchModerationAdapter(raw, broadcasterID, moderatorID), r.readOnly))
+	}
+	if r.router != nil {
+		chatModes := readonlyusecase.ChatSettings(service, r.readOnly)
+		r.router.Register(commands.NewSlowModeCommand(chatModes, broadcasterID, moderatorID))
+		r.router.Register(commands.NewEmoteOnlyCommand(chatModes, broadcasterID, moderatorID))
+		r.router.Register(commands.NewFollowersOnlyCommand(chatModes, broadcasterID, moderatorID))
+	}
+}
+
+// reportCapability registra el estado de una función y lo publica solo si cambió.
+func (r *Runtime) reportCapability(platform domain.Platform, feature string, available bool, reason string) {
+	if r == nil {
+		return
+	}
+	capability := events.CapabilityDTO{
+		Platform:  string(platform),
+		Feature:   feature,
+		Available: available,
+		Reason:    reason,
+	}
+	key := capability.Platform + ":" + feature
+
+	r.capMu.Lock()
+	if r.capabilities == nil {
+		r.capabilities = make(map[string]events.CapabilityDTO)
+	}
+	if prev, ok := r.capabilities[key]; ok && prev == capability {
+		r.capMu.Unlock()
+		return
+	}
+	r.capabilities[key] = capability
+	r.capMu.Unlock()
+
+	if available {
+		log.Printf("%s: %s disponible", platform, feature)
+	} else {
+		log.Printf("%s: %s no disponible: %s", platform, feature, reason)
+	}
+	if r.bus != nil {
+		r.bus.Publish(events.TopicCapabilities, capability)
+	}
+}
+
+// Capabilities devuelve el último estado conocido de cada función.
+func (r *Runtime) Capabilities() []events.CapabilityDTO {
+	if r == nil {
+		return nil
+	}
+	r.capMu.Lock()
+	defer r.capMu.Unlock()
+	out := make([]events.CapabilityDTO, 0, len(r.capabilities))
+	for _, capability := range r.capabilities {
+		out = append(out, capability)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Platform != out[j].Platform {
+			return out[i].Platform < out[j].Platform
+		}
+		return out[i].Feature < out[j].Feature
+	})
+	return out
+}
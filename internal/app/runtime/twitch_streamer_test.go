@@ -0,0 +1,255 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+	"zhatBot/internal/infrastructure/config"
+	categoryusecase "zhatBot/internal/usecase/category"
+	"zhatBot/internal/usecase/commands"
+	readonlyusecase "zhatBot/internal/usecase/readonly"
+	statususecase "zhatBot/internal/usecase/status"
+	"zhatBot/internal/usecase/stream"
+)
+
+type recordingOut struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (o *recordingOut) SendMessage(_ context.Context, _ domain.Platform, _ string, text string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.texts = append(o.texts, text)
+	return nil
+}
+
+func (o *recordingOut) sent() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.texts...)
+}
+
+// newStreamerTestRuntime arma lo mínimo que usa setupTwitchStreamer.
+func newStreamerTestRuntime(t *testing.T, cfg *config.Config) *Runtime {
+	t.Helper()
+	store := newTestStore(t)
+	return &Runtime{
+		ctx:       context.Background(),
+		cfg:       cfg,
+		credStore: store,
+		bus:       events.NewBus(),
+		status:    statususecase.NewResolver(),
+		category:  categoryusecase.NewService(categoryusecase.Config{}),
+		titles:    stream.NewResolver(nil, nil),
+		router:    commands.NewRouter(domain.DefaultCommandPrefix),
+		readOnly:  readonlyusecase.NewMode(store),
+	}
+}
+
+func nextCapability(t *testing.T, ch <-chan any) events.CapabilityDTO {
+	t.Helper()
+	select {
+	case payload := <-ch:
+		capability, ok := payload.(events.CapabilityDTO)
+		if !ok {
+			t.Fatalf("payload = %T, want CapabilityDTO", payload)
+		}
+		return capability
+	case <-time.After(time.Second):
+		t.Fatal("no capability published")
+	}
+	return events.CapabilityDTO{}
+}
+
+func TestSetupTwitchStreamerReportsMissingConfiguration(t *testing.T) {
+	cases := []struct {
+		name       string
+		cfg        config.Config
+		wantReason string
+	}{
+		{
+			name:       "no client id",
+			cfg:        config.Config{TwitchApiToken: "token"},
+			wantReason: "Configura TWITCH_CLIENT_ID para cambiar título/categoría",
+		},
+		{
+			name:       "bot token only",
+			cfg:        config.Config{TwitchClientId: "client", TwitchUsername: "streamer"},
+			wantReason: "Conecta tu cuenta de streamer para cambiar título/categoría",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			r := newStreamerTestRuntime(t, &cfg)
+			ch, unsubscribe := r.bus.Subscribe(events.TopicCapabilities)
+			defer unsubscribe()
+
+			r.setupTwitchStreamer(context.Background())
+
+			got := nextCapability(t, ch)
+			want := events.CapabilityDTO{Platform: "twitch", Feature: featureChannelManagement, Reason: tc.wantReason}
+			if got != want {
+				t.Fatalf("capability = %+v, want %+v", got, want)
+			}
+			if caps := r.Capabilities(); len(caps) != 1 || caps[0] != want {
+				t.Fatalf("Capabilities() = %+v", caps)
+			}
+			if api, _ := r.twitchStreamerAPI(); api != nil {
+				t.Fatal("streamer API must not start without a streamer token")
+			}
+		})
+	}
+}
+
+func TestSetupTwitchStreamerEnablesFeaturesOnLogin(t *testing.T) {
+	cfg := &config.Config{TwitchClientId: "client", TwitchUsername: "streamer"}
+	r := newStreamerTestRuntime(t, cfg)
+	ch, unsubscribe := r.bus.Subscribe(events.TopicCapabilities)
+	defer unsubscribe()
+
+	mod := domain.Message{Platform: domain.PlatformTwitch, ChannelID: "streamer", Username: "mod", IsPlatformMod: true, Text: "!slow"}
+	out := &recordingOut{}
+
+	r.setupTwitchStreamer(context.Background())
+	if got := nextCapability(t, ch); got.Available {
+		t.Fatalf("capability = %+v, want unavailable", got)
+	}
+	if err := r.router.Handle(context.Background(), mod, out); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if sent := out.sent(); len(sent) != 0 {
+		t.Fatalf("!slow answered before the streamer logged in: %v", sent)
+	}
+
+	// el streamer inicia sesión; el ID del canal sale de la caché, sin red
+	if err := r.credStore.SetTwitchBroadcasterCache(context.Background(), "streamer", "123"); err != nil {
+		t.Fatalf("SetTwitchBroadcasterCache: %v", err)
+	}
+	r.twitchMu.Lock()
+	cfg.TwitchApiToken = "streamer-token"
+	r.twitchMu.Unlock()
+	r.setupTwitchStreamer(context.Background())
+
+	got := nextCapability(t, ch)
+	if !got.Available || got.Reason != "" {
+		t.Fatalf("capability = %+v, want available", got)
+	}
+	if api, id := r.twitchStreamerAPI(); api == nil || id != "123" {
+		t.Fatalf("streamer API = %v/%q, want service for 123", api, id)
+	}
+	if err := r.router.Handle(context.Background(), mod, out); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if sent := out.sent(); len(sent) != 1 || sent[0] != "Uso: !slow <segundos 3-120> | !slow off" {
+		t.Fatalf("!slow replies = %v, want the usage text", sent)
+	}
+
+	// repetir el setup no vuelve a anunciar la capacidad
+	r.setupTwitchStreamer(context.Background())
+	select {
+	case payload := <-ch:
+		t.Fatalf("capability published again: %+v", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSetupTwitchStreamerFollowsAccountChange(t *testing.T) {
+	cfg := &config.Config{TwitchClientId: "client", TwitchUsername: "streamer", TwitchApiToken: "streamer-token"}
+	r := newStreamerTestRuntime(t, cfg)
+	ctx := context.Background()
+	if err := r.credStore.SetTwitchBroadcasterCache(ctx, "streamer", "123"); err != nil {
+		t.Fatalf("SetTwitchBroadcasterCache: %v", err)
+	}
+
+	r.setupTwitchStreamer(ctx)
+	first, id := r.twitchStreamerAPI()
+	if first == nil || id != "123" {
+		t.Fatalf("streamer API = %v/%q, want service for 123", first, id)
+	}
+
+	// un token nuevo de la misma cuenta no vuelve a resolver nada
+	r.twitchMu.Lock()
+	cfg.TwitchApiToken = "refreshed-token"
+	r.twitchMu.Unlock()
+	r.setupTwitchStreamer(ctx)
+	if api, id := r.twitchStreamerAPI(); api != first || id != "123" {
+		t.Fatalf("refresh rebuilt the API: %v/%q", api, id)
+	}
+
+	// el streamer inicia sesión con otra cuenta; la caché guarda un solo login
+	if err := r.credStore.SetTwitchBroadcasterCache(ctx, "otro", "789"); err != nil {
+		t.Fatalf("SetTwitchBroadcasterCache: %v", err)
+	}
+	r.twitchMu.Lock()
+	r.twitchStreamerLogin = "otro"
+	cfg.TwitchApiToken = "otro-token"
+	r.twitchMu.Unlock()
+	r.setupTwitchStreamer(ctx)
+
+	api, id := r.twitchStreamerAPI()
+	if api == nil || api == first || id != "789" {
+		t.Fatalf("streamer API = %v/%q, want a new service for 789", api, id)
+	}
+	if _, broadcasterID, moderatorID := r.twitchModerator(); broadcasterID != "789" || moderatorID != "789" {
+		t.Fatalf("moderator ids = %q/%q, want 789/789", broadcasterID, moderatorID)
+	}
+}
+
+func TestTwitchModeratorPrefersTheBot(t *testing.T) {
+	cfg := &config.Config{TwitchClientId: "client", TwitchUsername: "streamer", TwitchApiToken: "streamer-token"}
+	r := newStreamerTestRuntime(t, cfg)
+	if err := r.credStore.SetTwitchBroadcasterCache(context.Background(), "streamer", "123"); err != nil {
+		t.Fatalf("SetTwitchBroadcasterCache: %v", err)
+	}
+	if svc, _, _ := r.twitchModerator(); svc != nil {
+		t.Fatal("moderator available before the streamer API")
+	}
+	r.setupTwitchStreamer(context.Background())
+	streamer, _ := r.twitchStreamerAPI()
+
+	// sin el ID del bot modera el streamer en su canal
+	if svc, broadcasterID, moderatorID := r.twitchModerator(); svc != streamer || broadcasterID != "123" || moderatorID != "123" {
+		t.Fatalf("moderator = %v/%q/%q, want the streamer as 123/123", svc, broadcasterID, moderatorID)
+	}
+
+	r.twitchMu.Lock()
+	r.twitchBotToken = "oauth:bot-token"
+	r.twitchBotUserID = "456"
+	r.twitchMu.Unlock()
+	svc, broadcasterID, moderatorID := r.twitchModerator()
+	if svc == nil || svc == streamer || svc != r.twitchBotAPI() {
+		t.Fatalf("moderator service = %v, want the bot client", svc)
+	}
+	if broadcasterID != "123" || moderatorID != "456" {
+		t.Fatalf("moderator ids = %q/%q, want 123/456", broadcasterID, moderatorID)
+	}
+}
+
+func TestCapabilitiesAreSorted(t *testing.T) {
+	r := &Runtime{}
+	r.reportCapability(domain.PlatformTwitch, "b", true, "")
+	r.reportCapability(domain.PlatformKick, "z", false, "falta")
+	r.reportCapability(domain.PlatformTwitch, "a", true, "")
+
+	caps := r.Capabilities()
+	var keys []string
+	for _, c := range caps {
+		keys = append(keys, c.Platform+":"+c.Feature)
+	}
+	want := []string{"kick:z", "twitch:a", "twitch:b"}
+	if len(keys) != len(want) {
+		t.Fatalf("Capabilities() = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Capabilities() = %v, want %v", keys, want)
+		}
+	}
+}
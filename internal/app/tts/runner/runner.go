@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -18,11 +19,18 @@ import (
 	ttsusecase "zhatBot/internal/usecase/tts"
 )
 
+var errSkipped = errors.New("lectura saltada")
+
+// recentAudioSize es cuántos audios recientes se guardan para TTS_GetAudio.
+const recentAudioSize = 10
+
 type Config struct {
 	Service   *ttsusecase.Service
 	Publisher domain.TTSEventPublisher
 	Bus       *events.Bus
 	QueueSize int
+	// History guarda cada lectura terminada (opcional).
+	History *ttsusecase.History
 }
 
 type Runner struct {
@@ -35,15 +43,25 @@ type Runner struct {
 
 	current       *ttsusecase.Request
 	cancelCurrent context.CancelFunc
+	skippedID     string
+
+	votes        *ttsusecase.SkipVotes
+	skipRequired int
 
 	status events.TTSStatusDTO
 
 	audioMu sync.Mutex
+
+	recentMu    sync.Mutex
+	recentAudio map[string][]byte
+	recentOrder []string
 }
 
 func New(cfg Config) *Runner {
 	r := &Runner{
-		cfg: cfg,
+		cfg:         cfg,
+		votes:       ttsusecase.NewSkipVotes(),
+		recentAudio: make(map[string][]byte, recentAudioSize),
 	}
 	r.cond = sync.NewCond(&r.mu)
 	r.status = events.NewTTSStatusDTO("idle", 0, "", "")
@@ -112,6 +130,10 @@ func (r *Runner) handleRequest(ctx context.Context, req *ttsusecase.Request) {
 	defer r.clearCurrent()
 
 	audio, voice, err := r.cfg.Service.GenerateAudio(childCtx, req.Text, req.VoiceCode)
+	if r.takeSkipped(req.ID) {
+		r.finishSkipped(req)
+		return
+	}
 	if err != nil {
 		r.handleFailure(req, fmt.Errorf("tts synth: %w", err))
 		return
@@ -122,6 +144,10 @@ func (r *Runner) handleRequest(ctx context.Context, req *ttsusecase.Request) {
 	}
 
 	if err := r.playAudio(childCtx, audio); err != nil {
+		if r.takeSkipped(req.ID) {
+			r.finishSkipped(req)
+			return
+		}
 		if ctx.Err() != nil {
 			r.handleFailure(req, context.Canceled)
 			return
@@ -218,9 +244,61 @@ func (r *Runner) clearCurrent() {
 	defer r.mu.Unlock()
 	r.current = nil
 	r.cancelCurrent = nil
+	r.skippedID = ""
+	r.votes.Reset()
 	r.updateStatusLocked("idle", len(r.queue), "", "")
 }
 
+// CurrentID devuelve el ID de la lectura en curso ("" si no suena nada).
+func (r *Runner) CurrentID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return idOrEmpty(r.current)
+}
+
+// VoteSkip registra un voto !ttsskip para la lectura requestID. ok es false si
+// esa lectura ya terminó, en cuyo caso el voto se ignora.
+func (r *Runner) VoteSkip(ctx context.Context, requestID, voter string) (votes, required int, ok bool) {
+	required = domain.DefaultTTSSkipVotes
+	if r.cfg.Service != nil {
+		required = r.cfg.Service.SkipVotesRequired(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil || r.current.ID != requestID || r.skippedID == requestID {
+		return 0, required, false
+	}
+	votes = r.votes.Vote(requestID, voter)
+	r.skipRequired = required
+	r.setStatus(r.status.State, r.status.QueueLength, r.status.CurrentID, r.status.LastError)
+	return votes, required, true
+}
+
+// Skip corta la lectura requestID y sigue con la cola. Devuelve false si esa
+// lectura ya no es la actual.
+func (r *Runner) Skip(requestID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil || r.current.ID != requestID || r.cancelCurrent == nil || r.skippedID == requestID {
+		return false
+	}
+	r.skippedID = requestID
+	r.cancelCurrent()
+	return true
+}
+
+func (r *Runner) takeSkipped(requestID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return requestID != "" && r.skippedID == requestID
+}
+
+func (r *Runner) finishSkipped(req *ttsusecase.Request) {
+	log.Printf("tts runner: lectura %s saltada", req.ID)
+	r.emitSpoken(req, false, errSkipped, nil)
+}
+
 func (r *Runner) queueLength() int {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -294,12 +372,48 @@ func (r *Runner) emitSpoken(req *ttsusecase.Request, ok bool, err error, audio [
 	if err != nil {
 		payload.Error = err.Error()
 	}
+	r.cfg.History.Record(domain.TTSHistoryEntry{
+		ID:          req.ID,
+		Text:        req.Text,
+		RequestedBy: req.RequestedBy,
+		Platform:    req.Platform,
+		ChannelID:   req.ChannelID,
+		Voice:       req.VoiceCode,
+		Outcome:     outcome(ok, err),
+		Error:       payload.Error,
+	})
 	if len(audio) > 0 {
 		payload.AudioBase64 = base64.StdEncoding.EncodeToString(audio)
+		payload.AudioID = req.ID
+		r.rememberAudio(req.ID, audio)
 	}
 	r.publish(events.TopicTTSSpoken, payload)
 }
 
+// rememberAudio guarda el audio de una lectura; al pasar de recentAudioSize se
+// descarta el más viejo.
+func (r *Runner) rememberAudio(id string, audio []byte) {
+	r.recentMu.Lock()
+	defer r.recentMu.Unlock()
+	if _, ok := r.recentAudio[id]; !ok {
+		r.recentOrder = append(r.recentOrder, id)
+	}
+	r.recentAudio[id] = audio
+	for len(r.recentOrder) > recentAudioSize {
+		delete(r.recentAudio, r.recentOrder[0])
+		r.recentOrder = r.recentOrder[1:]
+	}
+}
+
+// Audio devuelve el mp3 de una lectura reciente. ok es false si ya se descartó
+// o si nunca tuvo audio.
+func (r *Runner) Audio(id string) ([]byte, bool) {
+	r.recentMu.Lock()
+	defer r.recentMu.Unlock()
+	audio, ok := r.recentAudio[id]
+	return audio, ok
+}
+
 func (r *Runner) updateStatus(state string, queueLength int, currentID, lastError string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -315,9 +429,23 @@ func (r *Runner) setStatus(state string, queueLength int, currentID, lastError s
 		state = "idle"
 	}
 	r.status = events.NewTTSStatusDTO(state, queueLength, currentID, lastError)
+	if r.cfg.Service != nil {
+		r.status.AutoPaused = r.cfg.Service.AutoPaused()
+	}
+	if votes := r.votes.Count(currentID); votes > 0 {
+		r.status.SkipVotes = votes
+		r.status.SkipVotesRequired = r.skipRequired
+	}
 	r.publish(events.TopicTTSStatus, r.status)
 }
 
+// RefreshStatus vuelve a publicar el estado actual (p.ej. al cambiar la pausa automática).
+func (r *Runner) RefreshStatus() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.setStatus(r.status.State, r.status.QueueLength, r.status.CurrentID, r.status.LastError)
+}
+
 func (r *Runner) publishStatus(status events.TTSStatusDTO) {
 	r.publish(events.TopicTTSStatus, status)
 }
@@ -335,6 +463,19 @@ func ensureID(id string) string {
 	return fmt.Sprintf("tts-%d", time.Now().UnixNano())
 }
 
+// outcome clasifica cómo terminó una lectura. Lo que cortó un mod (!ttsskip,
+// detener todo) o el cierre de la app cuenta como saltado, no como fallo.
+func outcome(ok bool, err error) string {
+	switch {
+	case ok:
+		return domain.TTSOutcomeOK
+	case errors.Is(err, errSkipped), errors.Is(err, context.Canceled):
+		return domain.TTSOutcomeSkipped
+	default:
+		return domain.TTSOutcomeFailed
+	}
+}
+
 func idOrEmpty(req *ttsusecase.Request) string {
 	if req == nil {
 		return ""
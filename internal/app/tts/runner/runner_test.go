@@ -0,0 +1,154 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+	ttsusecase "zhatBot/internal/usecase/tts"
+)
+
+// playing simula que req está sonando, como lo deja handleRequest.
+func playing(r *Runner, req *ttsusecase.Request) (cancelled func() bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	r.current = req
+	r.cancelCurrent = cancel
+	r.updateStatusLocked("speaking", 0, req.ID, "")
+	r.mu.Unlock()
+	return func() bool { return ctx.Err() != nil }
+}
+
+func TestVoteSkipConcurrentVotes(t *testing.T) {
+	r := New(Config{})
+	playing(r, &ttsusecase.Request{ID: "req-1"})
+
+	const voters = 20
+	var wg sync.WaitGroup
+	for i := 0; i < voters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, _, ok := r.VoteSkip(context.Background(), "req-1", fmt.Sprintf("user%d", i)); !ok {
+				t.Errorf("vote %d rejected", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	votes, required, ok := r.VoteSkip(context.Background(), "req-1", "user0")
+	if !ok || votes != voters {
+		t.Fatalf("VoteSkip = (%d, %v), want (%d, true)", votes, ok, voters)
+	}
+	if required != domain.DefaultTTSSkipVotes {
+		t.Fatalf("required = %d, want %d", required, domain.DefaultTTSSkipVotes)
+	}
+	if got := r.Status().SkipVotes; got != voters {
+		t.Fatalf("status skip votes = %d, want %d", got, voters)
+	}
+}
+
+func TestVoteSkipIgnoresFinishedItem(t *testing.T) {
+	r := New(Config{})
+	playing(r, &ttsusecase.Request{ID: "req-1"})
+	r.VoteSkip(context.Background(), "req-1", "ana")
+
+	r.clearCurrent()
+	if _, _, ok := r.VoteSkip(context.Background(), "req-1", "luis"); ok {
+		t.Fatal("vote for a finished item was accepted")
+	}
+	if r.Skip("req-1") {
+		t.Fatal("Skip of a finished item returned true")
+	}
+
+	playing(r, &ttsusecase.Request{ID: "req-2"})
+	if _, _, ok := r.VoteSkip(context.Background(), "req-1", "luis"); ok {
+		t.Fatal("late vote for the previous item was accepted")
+	}
+	votes, _, ok := r.VoteSkip(context.Background(), "req-2", "ana")
+	if !ok || votes != 1 {
+		t.Fatalf("votes after item change = (%d, %v), want (1, true)", votes, ok)
+	}
+}
+
+func TestSkipCancelsCurrentOnce(t *testing.T) {
+	r := New(Config{})
+	cancelled := playing(r, &ttsusecase.Request{ID: "req-1"})
+
+	if r.Skip("req-2") {
+		t.Fatal("Skip accepted a different item")
+	}
+	if !r.Skip("req-1") {
+		t.Fatal("Skip of the current item returned false")
+	}
+	if !cancelled() {
+		t.Fatal("current item was not cancelled")
+	}
+	if r.Skip("req-1") {
+		t.Fatal("second Skip returned true")
+	}
+	if _, _, ok := r.VoteSkip(context.Background(), "req-1", "ana"); ok {
+		t.Fatal("vote accepted after the item was skipped")
+	}
+}
+
+func TestRecentAudioKeepsTheLastItems(t *testing.T) {
+	r := New(Config{})
+	for i := range recentAudioSize + 2 {
+		r.rememberAudio(fmt.Sprintf("req-%d", i), []byte{byte(i)})
+	}
+	// re-guardar uno existente no lo duplica en el orden
+	r.rememberAudio("req-11", []byte{42})
+
+	for _, id := range []string{"req-0", "req-1"} {
+		if _, ok := r.Audio(id); ok {
+			t.Fatalf("%s should have been evicted", id)
+		}
+	}
+	if audio, ok := r.Audio("req-2"); !ok || audio[0] != 2 {
+		t.Fatalf("Audio(req-2) = %v, %v", audio, ok)
+	}
+	if audio, ok := r.Audio("req-11"); !ok || audio[0] != 42 {
+		t.Fatalf("Audio(req-11) = %v, %v", audio, ok)
+	}
+	if len(r.recentOrder) != recentAudioSize {
+		t.Fatalf("recentOrder = %v", r.recentOrder)
+	}
+}
+
+func TestOutcomeClassifiesSkipsAndErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+		err  error
+		want string
+	}{
+		{"sonó completa", true, nil, domain.TTSOutcomeOK},
+		{"!ttsskip", false, errSkipped, domain.TTSOutcomeSkipped},
+		{"detener todo o cierre", false, context.Canceled, domain.TTSOutcomeSkipped},
+		{"cancelación envuelta", false, fmt.Errorf("reproducir: %w", context.Canceled), domain.TTSOutcomeSkipped},
+		{"error del proveedor", false, errors.New("google tts: 500"), domain.TTSOutcomeFailed},
+		{"se pasó del tiempo", false, context.DeadlineExceeded, domain.TTSOutcomeFailed},
+		{"sin audio ni error", false, nil, domain.TTSOutcomeFailed},
+	}
+	for _, tt := range tests {
+		if got := outcome(tt.ok, tt.err); got != tt.want {
+			t.Errorf("%s: outcome = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEmitSpokenRecordsHistory(t *testing.T) {
+	history := ttsusecase.NewHistory(nil, 0)
+	r := New(Config{History: history})
+	r.emitSpoken(&ttsusecase.Request{ID: "r1", Text: "hola", RequestedBy: "ana", Platform: domain.PlatformKick}, true, nil, nil)
+	r.finishSkipped(&ttsusecase.Request{ID: "r2", RequestedBy: "beto"})
+
+	last, ok := history.Last(context.Background())
+	if !ok || last.ID != "r1" || last.RequestedBy != "ana" || last.Platform != domain.PlatformKick || last.Outcome != domain.TTSOutcomeOK {
+		t.Fatalf("Last = %+v, %v", last, ok)
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// APIQuotaState es el último estado conocido de la cuota de una API externa
+// (hoy Helix). Bucket distingue los tokens, que tienen cuotas separadas.
+type APIQuotaState struct {
+	Platform  Platform  `json:"platform"`
+	Bucket    string    `json:"bucket"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Delayed y Rejected cuentan las llamadas no críticas que se frenaron por
+	// cuota baja; RateLimited, las respuestas 429.
+	Delayed         int64     `json:"delayed"`
+	Rejected        int64     `json:"rejected"`
+	RateLimited     int64     `json:"rate_limited"`
+	LastRateLimited time.Time `json:"last_rate_limited"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AudienceService resuelve la relación del autor de un mensaje con el canal
+// preguntándole a la plataforma. Los flags del mensaje (IsSubscriber,
+// IsPlatformMod) los arma quien lo envía y por el WS se pueden falsificar;
+// los chequeos de permisos deberían pasar por acá.
+type AudienceService interface {
+	IsFollower(ctx context.Context, msg Message) (bool, error)
+	// FollowedAt devuelve desde cuándo sigue el canal; cero si no lo sigue
+	// o no se sabe.
+	FollowedAt(ctx context.Context, msg Message) (time.Time, error)
+	IsSubscriber(ctx context.Context, msg Message) (bool, error)
+	IsModerator(ctx context.Context, msg Message) (bool, error)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "context"
+
+// BotConflictMode indica qué hacer cuando otro bot responde el mismo comando.
+type BotConflictMode string
+
+const (
+	// BotConflictLog solo registra el conflicto.
+	BotConflictLog BotConflictMode = "log"
+	// BotConflictLearn además deja de responder ese comando: queda en
+	// Suppressed hasta que se lo quite de la lista.
+	BotConflictLearn BotConflictMode = "learn"
+)
+
+// BotConflictSettings configura la detección de otros bots que responden los
+// mismos comandos (respuestas dobles en el chat). Hay conflicto si uno de Bots
+// escribe dentro de WindowSeconds después de un comando que el bot conoce.
+type BotConflictSettings struct {
+	Enabled bool            `json:"enabled"`
+	Mode    BotConflictMode `json:"mode"`
+	// Bots son logins, en minúsculas; sirven para cualquier plataforma.
+	Bots          []string `json:"bots"`
+	WindowSeconds int      `json:"window_seconds"`
+	// Suppressed son los comandos (sin prefijo) que el bot dejó de responder.
+	Suppressed []string `json:"suppressed"`
+}
+
+func DefaultBotConflictSettings() BotConflictSettings {
+	return BotConflictSettings{
+		Mode:          BotConflictLog,
+		Bots:          []string{},
+		WindowSeconds: 5,
+		Suppressed:    []string{},
+	}
+}
+
+type BotConflictSettingsRepository interface {
+	GetBotConflictSettings(ctx context.Context) (*BotConflictSettings, error)
+	SetBotConflictSettings(ctx context.Context, settings BotConflictSettings) error
+}
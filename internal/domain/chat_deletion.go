@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// ChatDeletionKind indica el alcance de un borrado hecho por la moderación.
+type ChatDeletionKind string
+
+const (
+	// ChatDeletionMessage borra un único mensaje (MessageID).
+	ChatDeletionMessage ChatDeletionKind = "message"
+	// ChatDeletionUser borra los mensajes de un usuario (ban o timeout).
+	ChatDeletionUser ChatDeletionKind = "user"
+	// ChatDeletionChat limpia el chat completo.
+	ChatDeletionChat ChatDeletionKind = "chat"
+)
+
+// ChatDeletion describe un borrado de chat informado por la plataforma
+// (CLEARMSG/CLEARCHAT en Twitch, eventos de borrado y ban en Kick).
+type ChatDeletion struct {
+	Platform  Platform
+	ChannelID string
+	Kind      ChatDeletionKind
+	// MessageID es el ID del mensaje en la plataforma (ver
+	// Message.PlatformMessageID); solo en ChatDeletionMessage.
+	MessageID string
+	UserID    string
+	Login     string
+	// Text es el contenido borrado cuando la plataforma lo informa.
+	Text string
+	// Duration es la duración del timeout; 0 en un ban permanente.
+	Duration time.Duration
+	At       time.Time
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// ChatSettingsService cambia los modos del chat (slow, solo emotes, solo followers).
+// moderatorID debe coincidir con el dueño del token usado.
+type ChatSettingsService interface {
+	// seconds = 0 desactiva el modo lento.
+	SetSlowMode(ctx context.Context, broadcasterID, moderatorID string, seconds int) error
+	SetEmoteOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool) error
+	// minutes: antigüedad mínima de follow (0 = cualquier follower).
+	SetFollowersOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool, minutes int) error
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultMaxChatTextRunes es el largo máximo (en runas) de un mensaje
+// entrante; lo que sobra se recorta y el mensaje queda marcado.
+const DefaultMaxChatTextRunes = 2000
+
+const (
+	zeroWidthJoiner    = '\u200d'
+	zeroWidthNonJoiner = '\u200c'
+	variationSelector  = '\ufe0f'
+)
+
+// ChatTextPolicy decide cómo se sanea el texto de los mensajes entrantes.
+type ChatTextPolicy struct {
+	// MaxRunes es el largo máximo (DefaultMaxChatTextRunes si es 0).
+	MaxRunes int
+	// KeepRaw guarda el texto original en Message.RawText (depuración).
+	KeepRaw bool
+}
+
+// Apply sanea msg.Text y marca el mensaje si se recortó. Aplicarla dos veces
+// no cambia el resultado.
+func (p ChatTextPolicy) Apply(msg Message) Message {
+	clean, truncated := SanitizeChatText(msg.Text, p.MaxRunes)
+	if p.KeepRaw && msg.RawText == "" && clean != msg.Text {
+		msg.RawText = msg.Text
+	}
+	msg.Text = clean
+	msg.Truncated = msg.Truncated || truncated
+	return msg
+}
+
+// SanitizeChatText limpia el texto de un mensaje de chat: quita caracteres de
+// control (salvo el salto de línea), los de dirección bidi y los invisibles,
+// colapsa los espacios y saltos repetidos y recorta a maxRunes runas
+// (DefaultMaxChatTextRunes si es 0). El ZWJ se conserva dentro de un emoji
+// compuesto y el ZWNJ entre letras, donde cambian lo que se ve.
+func SanitizeChatText(text string, maxRunes int) (string, bool) {
+	if maxRunes <= 0 {
+		maxRunes = DefaultMaxChatTextRunes
+	}
+
+	runes := []rune(text)
+	var b strings.Builder
+	b.Grow(len(text))
+
+	var (
+		count          int
+		last           rune
+		pendingSpace   bool
+		pendingNewline bool
+	)
+	for i, r := range runes {
+		switch {
+		case r == '\n' || r == '\r':
+			pendingNewline = true
+			continue
+		case unicode.IsSpace(r):
+			pendingSpace = true
+			continue
+		case isBidiControl(r) || isInvisible(r) || unicode.IsControl(r):
+			continue
+		case r == zeroWidthJoiner:
+			if !isEmojiPart(last) || i+1 >= len(runes) || !isEmojiPart(runes[i+1]) {
+				continue
+			}
+		case r == zeroWidthNonJoiner:
+			if !unicode.IsLetter(last) || i+1 >= len(runes) || !unicode.IsLetter(runes[i+1]) {
+				continue
+			}
+		}
+
+		var sep rune
+		if count > 0 {
+			switch {
+			case pendingNewline:
+				sep = '\n'
+			case pendingSpace:
+				sep = ' '
+			}
+		}
+		pendingSpace, pendingNewline = false, false
+
+		need := 1
+		if sep != 0 {
+			need = 2
+		}
+		if count+need > maxRunes {
+			return b.String(), true
+		}
+		if sep != 0 {
+			b.WriteRune(sep)
+			count++
+		}
+		b.WriteRune(r)
+		last = r
+		count++
+	}
+	return b.String(), false
+}
+
+// isBidiControl reconoce las marcas y overrides de dirección (RLO, LRI, etc.).
+func isBidiControl(r rune) bool {
+	switch {
+	case r == '\u061c', r == '\u200e', r == '\u200f':
+		return true
+	case r >= '\u202a' && r <= '\u202e':
+		return true
+	case r >= '\u2066' && r <= '\u2069':
+		return true
+	}
+	return false
+}
+
+// isInvisible reconoce los caracteres de ancho cero que no aportan nada al
+// texto (el ZWJ y el ZWNJ se tratan aparte).
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u200b', '\u2060', '\ufeff', '\u00ad', '\u180e':
+		return true
+	}
+	return r >= '\u2061' && r <= '\u2064'
+}
+
+// isEmojiPart indica si r puede formar parte de una secuencia de emoji
+// (símbolo, modificador de tono o selector de variación).
+func isEmojiPart(r rune) bool {
+	return r == variationSelector || unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r)
+}
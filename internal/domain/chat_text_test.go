@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeChatText(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hola chat", "hola chat"},
+		{"collapse spaces and tabs", "hola  \t  chat", "hola chat"},
+		{"trim edges", "   hola   ", "hola"},
+		{"keep single newline", "hola\nchat", "hola\nchat"},
+		{"crlf and blank lines collapse", "hola\r\n\r\n\r\nchat", "hola\nchat"},
+		{"newline wins over spaces", "hola  \n  chat", "hola\nchat"},
+		{"c0 escapes", "hola\x1b[31m rojo\x07\x00", "hola[31m rojo"},
+		{"del dropped, next line is whitespace", "a\x7fb\u0085c", "ab c"},
+		{"right-to-left override", "user\u202egnp.exe", "usergnp.exe"},
+		{"isolates", "\u2067hola\u2069 \u2066chat\u2069", "hola chat"},
+		{"direction marks", "\u200fhola\u200e\u061c", "hola"},
+		{"zero width space and bom", "ho\u200bla\ufeff", "hola"},
+		{"soft hyphen", "in\u00advisible", "invisible"},
+		{"only invisible", "\u200b\u202e\u2066", ""},
+		{"family emoji keeps zwj", "👨\u200d👩\u200d👧", "👨\u200d👩\u200d👧"},
+		{"rainbow flag keeps variation selector", "🏳\ufe0f\u200d🌈", "🏳\ufe0f\u200d🌈"},
+		{"skin tone", "👋🏽 hola", "👋🏽 hola"},
+		{"regional indicator flag", "🇦🇷 vamos", "🇦🇷 vamos"},
+		{"stray zwj between letters", "ho\u200dla", "hola"},
+		{"trailing zwj", "🎉\u200d", "🎉"},
+		{"persian zwnj", "می\u200cخواهم", "می\u200cخواهم"},
+		{"stray zwnj next to emoji", "🎉\u200c🎉", "🎉🎉"},
+		{"kick emote markup", "[emote:37226:KEKW] jaja", "[emote:37226:KEKW] jaja"},
+		{"accents and ñ", "  ¡Qué   pasó,  ñandú?  ", "¡Qué pasó, ñandú?"},
+		{"japanese", "こんにちは\u3000世界", "こんにちは 世界"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, truncated := SanitizeChatText(tc.in, 0)
+			if got != tc.want || truncated {
+				t.Fatalf("SanitizeChatText(%q) = %q, %v; want %q", tc.in, got, truncated, tc.want)
+			}
+			// sanear dos veces no cambia nada
+			if again, _ := SanitizeChatText(got, 0); again != got {
+				t.Fatalf("not idempotent: %q -> %q", got, again)
+			}
+		})
+	}
+}
+
+func TestSanitizeChatTextTruncates(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		max       int
+		want      string
+		truncated bool
+	}{
+		{"exact fit", "hola", 4, "hola", false},
+		{"one over", "hola!", 4, "hola", true},
+		{"counts runes not bytes", "ñañaña", 4, "ñaña", true},
+		{"separator does not dangle", "hola chat", 5, "hola", true},
+		{"collapsed spaces do not count", "a     b", 3, "a b", false},
+		{"emoji is a rune each", "🎉🎉🎉", 2, "🎉🎉", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, truncated := SanitizeChatText(tc.in, tc.max)
+			if got != tc.want || truncated != tc.truncated {
+				t.Fatalf("SanitizeChatText(%q, %d) = %q, %v; want %q, %v", tc.in, tc.max, got, truncated, tc.want, tc.truncated)
+			}
+		})
+	}
+
+	long := strings.Repeat("x", DefaultMaxChatTextRunes+10)
+	got, truncated := SanitizeChatText(long, 0)
+	if !truncated || len([]rune(got)) != DefaultMaxChatTextRunes {
+		t.Fatalf("default cap: %d runes, truncated=%v", len([]rune(got)), truncated)
+	}
+}
+
+func TestChatTextPolicyApply(t *testing.T) {
+	msg := Message{Text: "hola\u202e  chat"}
+
+	clean := ChatTextPolicy{}.Apply(msg)
+	if clean.Text != "hola chat" || clean.RawText != "" || clean.Truncated {
+		t.Fatalf("Apply = %+v", clean)
+	}
+
+	debug := ChatTextPolicy{KeepRaw: true}.Apply(msg)
+	if debug.RawText != msg.Text {
+		t.Fatalf("RawText = %q, want the original", debug.RawText)
+	}
+	// el texto que ya estaba limpio no guarda copia
+	if same := (ChatTextPolicy{KeepRaw: true}).Apply(Message{Text: "hola"}); same.RawText != "" {
+		t.Fatalf("RawText = %q for unchanged text", same.RawText)
+	}
+
+	// aplicarla dos veces conserva la marca y el original
+	short := ChatTextPolicy{MaxRunes: 4, KeepRaw: true}
+	once := short.Apply(Message{Text: "hola chat"})
+	twice := short.Apply(once)
+	if twice.Text != "hola" || !twice.Truncated || twice.RawText != "hola chat" {
+		t.Fatalf("twice = %+v", twice)
+	}
+}
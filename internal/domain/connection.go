@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PlatformConnection guarda cuándo se conectó y desconectó por última vez el
+// chat de una plataforma.
+type PlatformConnection struct {
+	Platform           Platform  `json:"platform"`
+	LastConnectedAt    time.Time `json:"last_connected_at"`
+	LastDisconnectedAt time.Time `json:"last_disconnected_at"`
+}
+
+type PlatformConnectionRepository interface {
+	GetPlatformConnection(ctx context.Context, platform Platform) (PlatformConnection, error)
+	SetPlatformConnection(ctx context.Context, connection PlatformConnection) error
+}
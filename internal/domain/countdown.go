@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Countdown es una cuenta regresiva activa en un canal (p.ej. «empezamos
+// pronto»). Se guarda EndsAt para poder retomarla si el bot se reinicia.
+type Countdown struct {
+	Platform  Platform  `json:"platform"`
+	ChannelID string    `json:"channel_id"`
+	Label     string    `json:"label"`
+	StartedAt time.Time `json:"started_at"`
+	EndsAt    time.Time `json:"ends_at"`
+}
+
+type CountdownRepository interface {
+	GetCountdowns(ctx context.Context) ([]Countdown, error)
+	SetCountdowns(ctx context.Context, countdowns []Countdown) error
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Counter es un contador manual con nombre (p. ej. muertes) que los mods
+// suben y bajan con !count. A diferencia de WordTracker no mira el chat.
+type Counter struct {
+	Name      string    `json:"name"`
+	Value     int       `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CounterRepository interface {
+	ListCounters(ctx context.Context) ([]*Counter, error)
+	// SaveCounter inserta o reemplaza el contador.
+	SaveCounter(ctx context.Context, counter *Counter) error
+	DeleteCounter(ctx context.Context, name string) (bool, error)
+}
@@ -2,16 +2,70 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
 type CustomCommand struct {
-	Name      string
-	Response  string
-	Aliases   []string
-	Platforms []Platform
+	Name     string
+	Response string
+	// Responses es la alternativa a Response: se elige una al azar según su
+	// peso. Si tiene entradas, Response queda vacío.
+	Responses   []WeightedResponse
+	Aliases     []string
+	Platforms   []Platform
 	Permissions []CommandAccessRole
-	UpdatedAt time.Time
+	// Cooldown global del comando (0 = sin cooldown).
+	Cooldown time.Duration
+	// UserCooldown es la espera de cada usuario entre usos (0 = sin cooldown).
+	UserCooldown time.Duration
+	// CooldownFeedback vacío usa el modo por defecto configurado.
+	CooldownFeedback CooldownFeedbackMode
+	// PermissionReply vacío usa el modo global.
+	PermissionReply PermissionReplyMode
+	UpdatedAt       time.Time
+}
+
+// WeightedResponse es una de las respuestas posibles de un comando. Weight es
+// relativo a las demás y tiene que ser positivo.
+type WeightedResponse struct {
+	Text   string `json:"text"`
+	Weight int    `json:"weight"`
+}
+
+// ResponsePool devuelve las respuestas posibles del comando; un Response
+// simple cuenta como un pool de uno.
+func (c CustomCommand) ResponsePool() []WeightedResponse {
+	if len(c.Responses) > 0 {
+		return c.Responses
+	}
+	if strings.TrimSpace(c.Response) == "" {
+		return nil
+	}
+	return []WeightedResponse{{Text: c.Response, Weight: 1}}
+}
+
+// CooldownFeedbackMode define qué ve el usuario cuando un comando está en cooldown.
+type CooldownFeedbackMode string
+
+const (
+	CooldownFeedbackSilent    CooldownFeedbackMode = "silent"
+	CooldownFeedbackReplyOnce CooldownFeedbackMode = "reply-once"
+	CooldownFeedbackWhisper   CooldownFeedbackMode = "whisper"
+)
+
+// ParseCooldownFeedbackMode normaliza el modo; ok=false si no es válido.
+func ParseCooldownFeedbackMode(raw string) (CooldownFeedbackMode, bool) {
+	switch CooldownFeedbackMode(strings.ToLower(strings.TrimSpace(raw))) {
+	case CooldownFeedbackSilent:
+		return CooldownFeedbackSilent, true
+	case CooldownFeedbackReplyOnce, "reply_once", "replyonce":
+		return CooldownFeedbackReplyOnce, true
+	case CooldownFeedbackWhisper:
+		return CooldownFeedbackWhisper, true
+	default:
+		return "", false
+	}
 }
 
 type CommandAccessRole string
@@ -31,3 +85,36 @@ type CustomCommandRepository interface {
 	ListCustomCommands(ctx context.Context) ([]*CustomCommand, error)
 	DeleteCustomCommand(ctx context.Context, name string) error
 }
+
+// CustomCommandCounter cuenta los comandos personalizados guardados.
+type CustomCommandCounter interface {
+	CountCustomCommands(ctx context.Context) (int, error)
+}
+
+// PermissionReplyMode define si se avisa al usuario que no tiene permiso.
+type PermissionReplyMode string
+
+const (
+	PermissionReplySilent PermissionReplyMode = "silent"
+	PermissionReplyReply  PermissionReplyMode = "reply"
+)
+
+// ParsePermissionReplyMode normaliza el modo; ok=false si no es válido.
+func ParsePermissionReplyMode(raw string) (PermissionReplyMode, bool) {
+	switch PermissionReplyMode(strings.ToLower(strings.TrimSpace(raw))) {
+	case PermissionReplySilent, "off":
+		return PermissionReplySilent, true
+	case PermissionReplyReply, "on":
+		return PermissionReplyReply, true
+	default:
+		return "", false
+	}
+}
+
+// CommandSettingsRepository guarda opciones globales de los comandos.
+type CommandSettingsRepository interface {
+	GetCooldownFeedbackDefault(ctx context.Context) (CooldownFeedbackMode, error)
+	SetCooldownFeedbackDefault(ctx context.Context, mode CooldownFeedbackMode) error
+	GetPermissionReplyDefault(ctx context.Context) (PermissionReplyMode, error)
+	SetPermissionReplyDefault(ctx context.Context, mode PermissionReplyMode) error
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "context"
+
+// FollowAnnounceSettings controla cómo se anuncian los follows en el chat. Los
+// follows siempre se guardan como notificación; esto solo limita el chat.
+type FollowAnnounceSettings struct {
+	Enabled bool `json:"enabled"`
+	// AnnounceBelow: follows/minuto por debajo de los cuales se agradece cada
+	// follow por separado. A partir de ahí se agrupan.
+	AnnounceBelow int `json:"announce_below"`
+	// BatchIntervalSeconds: cada cuánto se anuncia el resumen ("+12 nuevos followers").
+	BatchIntervalSeconds int `json:"batch_interval_seconds"`
+	// BotThreshold: follows/minuto que se consideran un ataque de bots.
+	BotThreshold int `json:"bot_threshold"`
+	// AutoSilence deja de anunciar follows durante SilenceMinutes tras un ataque.
+	AutoSilence    bool `json:"auto_silence"`
+	SilenceMinutes int  `json:"silence_minutes"`
+}
+
+func DefaultFollowAnnounceSettings() FollowAnnounceSettings {
+	return FollowAnnounceSettings{
+		Enabled:              true,
+		AnnounceBelow:        10,
+		BatchIntervalSeconds: 30,
+		BotThreshold:         200,
+		AutoSilence:          true,
+		SilenceMinutes:       60,
+	}
+}
+
+type FollowSettingsRepository interface {
+	GetFollowAnnounceSettings(ctx context.Context) (*FollowAnnounceSettings, error)
+	SetFollowAnnounceSettings(ctx context.Context, settings FollowAnnounceSettings) error
+}
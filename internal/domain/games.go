@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// Juegos del chat que se pueden activar o desactivar.
+const (
+	GameRoll      = "roll"
+	GameEightBall = "8ball"
+	GameCoinflip  = "coinflip"
+)
+
+// ChatGames devuelve los juegos conocidos.
+func ChatGames() []string {
+	return []string{GameRoll, GameEightBall, GameCoinflip}
+}
+
+// ChatGamesSettings activa cada juego del chat. Un juego que no aparece en
+// Enabled está activo. Channels pisa a Enabled en un canal concreto; la
+// clave es ChatGamesChannelKey(plataforma, canal).
+type ChatGamesSettings struct {
+	Enabled  map[string]bool            `json:"enabled"`
+	Channels map[string]map[string]bool `json:"channels,omitempty"`
+}
+
+func DefaultChatGamesSettings() ChatGamesSettings {
+	enabled := make(map[string]bool, len(ChatGames()))
+	for _, game := range ChatGames() {
+		enabled[game] = true
+	}
+	return ChatGamesSettings{Enabled: enabled}
+}
+
+// ChatGamesChannelKey arma la clave de Channels: «twitch:canal» o
+// «kick:<id del chatroom>».
+func ChatGamesChannelKey(platform Platform, channelID string) string {
+	channelID = strings.TrimPrefix(strings.TrimSpace(channelID), "#")
+	return strings.ToLower(string(platform) + ":" + channelID)
+}
+
+// Allows indica si el juego está activo en ese canal.
+func (s ChatGamesSettings) Allows(game string, platform Platform, channelID string) bool {
+	if channel, ok := s.Channels[ChatGamesChannelKey(platform, channelID)]; ok {
+		if enabled, ok := channel[game]; ok {
+			return enabled
+		}
+	}
+	if enabled, ok := s.Enabled[game]; ok {
+		return enabled
+	}
+	return true
+}
+
+type ChatGamesSettingsRepository interface {
+	GetChatGamesSettings(ctx context.Context) (*ChatGamesSettings, error)
+	SetChatGamesSettings(ctx context.Context, settings ChatGamesSettings) error
+}
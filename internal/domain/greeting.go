@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultGreetingThresholdDays es la ausencia mínima para considerar que un
+// usuario "volvió".
+const DefaultGreetingThresholdDays = 30
+
+// GreetingSettings configura el saludo a quien vuelve al chat después de una
+// ausencia larga. Con Enabled el bot saluda en el chat; el aviso al panel se
+// publica siempre. La plantilla acepta {user} y {days}.
+type GreetingSettings struct {
+	Enabled       bool   `json:"enabled"`
+	ThresholdDays int    `json:"threshold_days"`
+	Template      string `json:"template"`
+}
+
+func DefaultGreetingSettings() GreetingSettings {
+	return GreetingSettings{
+		ThresholdDays: DefaultGreetingThresholdDays,
+		Template:      "👋 ¡{user} volvió después de {days} días! Bienvenido de vuelta.",
+	}
+}
+
+type GreetingSettingsRepository interface {
+	GetGreetingSettings(ctx context.Context) (*GreetingSettings, error)
+	SetGreetingSettings(ctx context.Context, settings GreetingSettings) error
+}
+
+// ChatUser es la última vez que un usuario escribió en el chat.
+type ChatUser struct {
+	Platform Platform
+	UserID   string
+	Name     string
+	LastSeen time.Time
+}
+
+type ChatUserRepository interface {
+	// GetChatUserLastSeen devuelve el cero si el usuario nunca escribió.
+	GetChatUserLastSeen(ctx context.Context, platform Platform, userID string) (time.Time, error)
+	// SaveChatUsers inserta o actualiza la última vez que escribieron.
+	SaveChatUsers(ctx context.Context, users []ChatUser) error
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type KickStreamService interface {
 	SetTitle(ctx context.Context, newTitle string) error
@@ -8,3 +11,26 @@ type KickStreamService interface {
 	SearchCategories(ctx context.Context, query string) ([]CategoryOption, error)
 	GetStreamStatus(ctx context.Context, broadcasterUserID int) (StreamStatus, error)
 }
+
+// ErrKickRejected indica que la API de Kick recibió el mensaje pero no lo
+// aceptó. Se usa con errors.As para traducirlo a un texto para el usuario.
+type ErrKickRejected struct {
+	Status      int
+	KickError   string
+	Description string
+	PosterType  string
+}
+
+func (e *ErrKickRejected) Error() string {
+	msg := fmt.Sprintf("kick: mensaje rechazado por la API (status %d", e.Status)
+	if e.PosterType != "" {
+		msg += ", type=" + e.PosterType
+	}
+	if e.KickError != "" {
+		msg += ", error=" + e.KickError
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg + ")"
+}
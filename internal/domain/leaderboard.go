@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LeaderboardScope indica qué conteo se consulta: el de la sesión de stream
+// actual o el histórico.
+type LeaderboardScope string
+
+const (
+	LeaderboardSession LeaderboardScope = "session"
+	LeaderboardAllTime LeaderboardScope = "all_time"
+)
+
+func ParseLeaderboardScope(value string) (LeaderboardScope, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case string(LeaderboardSession):
+		return LeaderboardSession, nil
+	case string(LeaderboardAllTime), "alltime", "all":
+		return LeaderboardAllTime, nil
+	default:
+		return "", fmt.Errorf("scope de ranking inválido: %q", value)
+	}
+}
+
+// ChatterStats es cuánto escribió un usuario en un scope. SessionID solo se
+// usa en el scope de sesión.
+type ChatterStats struct {
+	Platform  Platform
+	UserID    string
+	Name      string
+	SessionID string
+	Messages  int
+	Emotes    int
+	LastSeen  time.Time
+}
+
+// LeaderboardSettings elige qué ranking muestra !top por defecto. Los dos
+// conteos se llevan siempre.
+type LeaderboardSettings struct {
+	Scope LeaderboardScope `json:"scope"`
+	// Size es cuántos usuarios muestra !top.
+	Size int `json:"size"`
+}
+
+func DefaultLeaderboardSettings() LeaderboardSettings {
+	return LeaderboardSettings{Scope: LeaderboardSession, Size: 5}
+}
+
+type LeaderboardRepository interface {
+	GetLeaderboardSettings(ctx context.Context) (*LeaderboardSettings, error)
+	SetLeaderboardSettings(ctx context.Context, settings LeaderboardSettings) error
+	ListChatterStats(ctx context.Context, scope LeaderboardScope) ([]ChatterStats, error)
+	// SaveChatterStats inserta o reemplaza los conteos de esos usuarios.
+	SaveChatterStats(ctx context.Context, scope LeaderboardScope, stats []ChatterStats) error
+	ClearChatterStats(ctx context.Context, scope LeaderboardScope) error
+}
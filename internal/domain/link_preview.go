@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LinkPreview son los metadatos (OpenGraph/oEmbed) de un link pegado en el
+// chat. URL es la dirección ya limpia de parámetros de tracking.
+type LinkPreview struct {
+	URL         string    `json:"url"`
+	Domain      string    `json:"domain"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	SiteName    string    `json:"site_name,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// LinkPreviewSettings controla las vistas previas de links en el chat. Solo
+// se consultan los dominios de Domains (y sus subdominios).
+type LinkPreviewSettings struct {
+	Enabled bool     `json:"enabled"`
+	Domains []string `json:"domains"`
+}
+
+func DefaultLinkPreviewSettings() LinkPreviewSettings {
+	return LinkPreviewSettings{
+		Enabled: true,
+		Domains: []string{"youtube.com", "youtu.be", "twitch.tv", "kick.com"},
+	}
+}
+
+type LinkPreviewSettingsRepository interface {
+	GetLinkPreviewSettings(ctx context.Context) (*LinkPreviewSettings, error)
+	SetLinkPreviewSettings(ctx context.Context, settings LinkPreviewSettings) error
+}
+
+// LinkPreviewFetcher descarga los metadatos de un link. allowed decide qué
+// hosts se pueden visitar, incluidos los de las redirecciones.
+type LinkPreviewFetcher interface {
+	FetchLinkPreview(ctx context.Context, rawURL string, allowed func(host string) bool) (*LinkPreview, error)
+}
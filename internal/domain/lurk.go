@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+// LurkSettings configura los mensajes de !lurk/!unlurk. Las plantillas
+// aceptan {user} y, al volver, {duration}.
+type LurkSettings struct {
+	LurkTemplate   string `json:"lurk_template"`
+	UnlurkTemplate string `json:"unlurk_template"`
+	// AutoUnlurk da la bienvenida con el siguiente mensaje del usuario, sin
+	// esperar a que escriba !unlurk.
+	AutoUnlurk bool `json:"auto_unlurk"`
+}
+
+func DefaultLurkSettings() LurkSettings {
+	return LurkSettings{
+		LurkTemplate:   "👀 {user} se fue a lurkear. ¡Gracias por quedarte!",
+		UnlurkTemplate: "👋 ¡Bienvenido de vuelta, {user}! Estuviste lurkeando {duration}.",
+		AutoUnlurk:     true,
+	}
+}
+
+type LurkSettingsRepository interface {
+	GetLurkSettings(ctx context.Context) (*LurkSettings, error)
+	SetLurkSettings(ctx context.Context, settings LurkSettings) error
+}
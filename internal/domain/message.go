@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 type Platform string
 
 const (
@@ -9,12 +11,29 @@ const (
 )
 
 type Message struct {
-	Platform  Platform
-	ChannelID string
-	UserID    string
-	Username  string
-	Text      string
-	IsPrivate bool
+	// ID lo asigna el runtime a cada mensaje recibido; los eventos que llegan
+	// después (p.ej. vistas previas de links) lo usan como referencia.
+	ID string
+	// PlatformMessageID es el ID que asigna la plataforma; los borrados de la
+	// moderación (ChatDeletion) lo usan como referencia.
+	PlatformMessageID string
+	Platform          Platform
+	ChannelID         string
+	UserID            string
+	// Username es el nombre a mostrar (se mantiene por compatibilidad).
+	Username string
+	// Login es el nombre de cuenta en minúsculas (Twitch login / Kick slug).
+	Login string
+	// DisplayName respeta mayúsculas y caracteres especiales de la plataforma.
+	DisplayName string
+	Text        string
+	IsPrivate   bool
+
+	// Truncated indica que Text se recortó al sanearlo (ChatTextPolicy).
+	Truncated bool
+	// RawText es el texto tal como llegó, solo si ChatTextPolicy.KeepRaw está
+	// activo y el saneado lo cambió.
+	RawText string
 
 	// Flags que vienen de la plataforma (los rellenamos en el adapter)
 	IsPlatformOwner bool
@@ -22,4 +41,39 @@ type Message struct {
 	IsPlatformMod   bool
 	IsPlatformVip   bool
 	IsSubscriber    bool
+	IsVerified      bool
+
+	// AvatarURL lo completa el enriquecimiento de perfiles cuando el usuario
+	// ya está en caché (vacío en el primer mensaje).
+	AvatarURL string
+
+	// BotPaused lo completa el runtime: los comandos estaban pausados cuando
+	// llegó el mensaje.
+	BotPaused bool
+	// HasNotes lo completa el runtime: el usuario tiene notas de los mods.
+	HasNotes bool
+
+	// Emotes es cuántos emotes trae el mensaje, según lo que informa la
+	// plataforma (lo rellena el adapter).
+	Emotes int
+
+	// Bits es la cantidad de bits del cheer (Twitch, tag bits de IRC); 0 si
+	// el mensaje no es un cheer.
+	Bits int
+}
+
+// LoginName devuelve el login y, si el adapter no lo llenó, el username en minúsculas.
+func (m Message) LoginName() string {
+	if m.Login != "" {
+		return m.Login
+	}
+	return strings.ToLower(strings.TrimSpace(m.Username))
+}
+
+// Name devuelve el nombre para mostrar/mencionar al usuario.
+func (m Message) Name() string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Username
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// DefaultMaxMessageLength es el límite de caracteres por mensaje de chat que
+// usan Twitch y Kick.
+const DefaultMaxMessageLength = 500
+
+var maxMessageLength = map[Platform]int{
+	PlatformTwitch: 500,
+	PlatformKick:   500,
+}
+
+// kickEmotePattern reconoce el código de emote de Kick: [emote:12345:nombre].
+var kickEmotePattern = regexp.MustCompile(`\[emote:\d+:([^\]\s]*)\]`)
+
+// CountKickEmotes cuenta los emotes de un mensaje de Kick.
+func CountKickEmotes(text string) int {
+	return len(kickEmotePattern.FindAllStringIndex(text, -1))
+}
+
+// MaxMessageLength devuelve el límite de caracteres de la plataforma.
+func MaxMessageLength(p Platform) int {
+	if limit, ok := maxMessageLength[p]; ok {
+		return limit
+	}
+	return DefaultMaxMessageLength
+}
+
+// MessageLength mide un mensaje de dos formas: Raw es lo que la plataforma
+// cuenta contra su límite y Display lo que se ve en el chat.
+type MessageLength struct {
+	Raw     int
+	Display int
+}
+
+// Emotes indica si el código de los emotes ocupa más de lo que se ve.
+func (l MessageLength) Emotes() bool {
+	return l.Raw > l.Display
+}
+
+// MeasureMessage calcula el largo efectivo de text en la plataforma. En Kick
+// cada emote se ve como su nombre pero el código completo cuenta para el límite.
+func MeasureMessage(p Platform, text string) MessageLength {
+	raw := utf8.RuneCountInString(text)
+	if p != PlatformKick {
+		return MessageLength{Raw: raw, Display: raw}
+	}
+	display := raw
+	for _, match := range kickEmotePattern.FindAllStringSubmatchIndex(text, -1) {
+		markup := utf8.RuneCountInString(text[match[0]:match[1]])
+		name := utf8.RuneCountInString(text[match[2]:match[3]])
+		display -= markup - name
+	}
+	return MessageLength{Raw: raw, Display: display}
+}
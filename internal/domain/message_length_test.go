@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeasureMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform Platform
+		text     string
+		want     MessageLength
+	}{
+		{"plain twitch", PlatformTwitch, "hola chat", MessageLength{Raw: 9, Display: 9}},
+		{"plain kick", PlatformKick, "hola chat", MessageLength{Raw: 9, Display: 9}},
+		{"kick emote", PlatformKick, "[emote:12345:wave]", MessageLength{Raw: 18, Display: 4}},
+		{"kick mixed", PlatformKick, "hola [emote:1:a] y [emote:37226:KEKW]!", MessageLength{Raw: 38, Display: 14}},
+		{"kick emote without name", PlatformKick, "[emote:1:]", MessageLength{Raw: 10, Display: 0}},
+		{"kick markup is plain text on twitch", PlatformTwitch, "[emote:12345:wave]", MessageLength{Raw: 18, Display: 18}},
+		{"not an emote", PlatformKick, "[emote:abc:wave] [emote:1:two words]", MessageLength{Raw: 36, Display: 36}},
+		{"runes not bytes", PlatformKick, "ñandú 🎉 [emote:7:ñu]", MessageLength{Raw: 20, Display: 10}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MeasureMessage(tc.platform, tc.text)
+			if got != tc.want {
+				t.Fatalf("MeasureMessage = %+v, want %+v", got, tc.want)
+			}
+			if got.Emotes() != (tc.want.Raw > tc.want.Display) {
+				t.Fatalf("Emotes() = %v", got.Emotes())
+			}
+		})
+	}
+}
+
+func TestMeasureMessageNearLimit(t *testing.T) {
+	emote := "[emote:12345:wave]"
+	text := strings.Repeat(emote, 27) + strings.Repeat("x", 14)
+	got := MeasureMessage(PlatformKick, text)
+	if got.Raw != 500 || got.Display != 27*4+14 {
+		t.Fatalf("MeasureMessage = %+v", got)
+	}
+	if got.Raw > MaxMessageLength(PlatformKick) {
+		t.Fatal("exactly at the limit should not exceed it")
+	}
+	if CountKickEmotes(text) != 27 {
+		t.Fatalf("CountKickEmotes = %d, want 27", CountKickEmotes(text))
+	}
+}
+
+func TestMaxMessageLength(t *testing.T) {
+	if MaxMessageLength(PlatformTwitch) != 500 || MaxMessageLength(PlatformKick) != 500 {
+		t.Fatal("unexpected platform limits")
+	}
+	if MaxMessageLength("youtube") != DefaultMaxMessageLength {
+		t.Fatal("unknown platform should use the default limit")
+	}
+}
+
+func TestCountKickEmotes(t *testing.T) {
+	tests := map[string]int{
+		"hola":                                 0,
+		"[emote:37226:KEKW]":                   1,
+		"gg [emote:1:a] [emote:2:b][emote:3:]": 3,
+		"[emote:x:malo] [emote:1:con espacio]": 0,
+	}
+	for text, want := range tests {
+		if got := CountKickEmotes(text); got != want {
+			t.Errorf("CountKickEmotes(%q) = %d, esperaba %d", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestMessageLoginName(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"uses login when set", Message{Login: "zeroproject", Username: "ZeroProject"}, "zeroproject"},
+		{"falls back to lowercased username", Message{Username: " ZeroProject "}, "zeroproject"},
+		{"empty", Message{}, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.msg.LoginName(); got != tc.want {
+				t.Fatalf("LoginName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMessageName(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"prefers display name", Message{DisplayName: "ZeroProject", Username: "zeroproject"}, "ZeroProject"},
+		{"keeps non-ascii display name", Message{DisplayName: "ゼロ", Login: "zero"}, "ゼロ"},
+		{"falls back to username", Message{Username: "zeroproject"}, "zeroproject"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.msg.Name(); got != tc.want {
+				t.Fatalf("Name() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
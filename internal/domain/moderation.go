@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// SpamAction define qué hace el bot cuando detecta una ráfaga de spam.
+type SpamAction string
+
+const (
+	SpamActionNone     SpamAction = "none"
+	SpamActionAnnounce SpamAction = "announce"
+	// Solo Twitch; en otras plataformas se usa el aviso en chat.
+	SpamActionEmoteOnly SpamAction = "emote_only"
+	SpamActionSlowMode  SpamAction = "slow_mode"
+)
+
+// SpamProtectionSettings configura el detector de mensajes repetidos entre usuarios.
+type SpamProtectionSettings struct {
+	Enabled bool `json:"enabled"`
+	// WindowSeconds: ventana deslizante en la que se cuentan los mensajes.
+	WindowSeconds int `json:"window_seconds"`
+	// MinUsers: usuarios distintos con el mismo mensaje para considerarlo ráfaga.
+	MinUsers int `json:"min_users"`
+	// Similarity entre 0 y 1 (1 = texto idéntico tras normalizar).
+	Similarity float64 `json:"similarity"`
+	// CooldownSeconds evita disparar la acción varias veces seguidas.
+	CooldownSeconds int        `json:"cooldown_seconds"`
+	Action          SpamAction `json:"action"`
+}
+
+// DefaultSpamProtectionSettings devuelve valores razonables para un canal mediano.
+func DefaultSpamProtectionSettings() SpamProtectionSettings {
+	return SpamProtectionSettings{
+		Enabled:         false,
+		WindowSeconds:   15,
+		MinUsers:        5,
+		Similarity:      0.8,
+		CooldownSeconds: 60,
+		Action:          SpamActionAnnounce,
+	}
+}
+
+type ModerationSettingsRepository interface {
+	GetSpamProtectionSettings(ctx context.Context) (*SpamProtectionSettings, error)
+	SetSpamProtectionSettings(ctx context.Context, settings SpamProtectionSettings) error
+}
+
+// ModerationService aplica sanciones en el canal de una plataforma. userID y
+// messageID son los IDs que asigna la plataforma.
+type ModerationService interface {
+	// BanUser banea al usuario de forma permanente.
+	BanUser(ctx context.Context, userID, reason string) error
+	// TimeoutUser lo silencia durante duration; cada plataforma la ajusta a
+	// sus límites (ver ClampTimeout).
+	TimeoutUser(ctx context.Context, userID string, duration time.Duration, reason string) error
+	// DeleteMessage borra un mensaje del chat.
+	DeleteMessage(ctx context.Context, messageID string) error
+}
+
+// ModerationUserResolver lo implementan los ModerationService que pueden
+// buscar el ID de un usuario que todavía no escribió en el chat.
+type ModerationUserResolver interface {
+	ResolveUserID(ctx context.Context, login string) (string, error)
+}
+
+// ErrModerationUserNotFound indica que el login no existe en la plataforma.
+var ErrModerationUserNotFound = errors.New("usuario no encontrado")
+
+// Límites de los timeouts: Twitch acepta de 1 s a 2 semanas y Kick trabaja en
+// minutos, de 1 a 7 días.
+const (
+	MaxTwitchTimeout = 14 * 24 * time.Hour
+	MaxKickTimeout   = 7 * 24 * time.Hour
+)
+
+// ClampTimeout ajusta duration a lo que acepta la plataforma. En Kick se
+// redondea hacia arriba al minuto.
+func ClampTimeout(p Platform, duration time.Duration) time.Duration {
+	switch p {
+	case PlatformKick:
+		minutes := (duration + time.Minute - 1) / time.Minute
+		duration = max(minutes, 1) * time.Minute
+		return min(duration, MaxKickTimeout)
+	default:
+		duration = max(duration.Truncate(time.Second), time.Second)
+		return min(duration, MaxTwitchTimeout)
+	}
+}
@@ -1,6 +1,14 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type NotificationType string
 
@@ -9,9 +17,47 @@ const (
 	NotificationDonation       NotificationType = "donation"
 	NotificationBits           NotificationType = "bits"
 	NotificationGiveawayWinner NotificationType = "giveaway_winner"
+	NotificationFollow         NotificationType = "follow"
 	NotificationGeneric        NotificationType = "generic"
 )
 
+// NotificationTypes devuelve los tipos conocidos.
+func NotificationTypes() []NotificationType {
+	return []NotificationType{
+		NotificationSubscription,
+		NotificationDonation,
+		NotificationBits,
+		NotificationGiveawayWinner,
+		NotificationFollow,
+		NotificationGeneric,
+	}
+}
+
+// ParseNotificationType acepta el nombre de un tipo de notificación conocido.
+// Es la versión estricta de NormalizeNotificationType.
+func ParseNotificationType(value string) (NotificationType, error) {
+	return NormalizeNotificationType(value, true)
+}
+
+// NormalizeNotificationType recorta y pasa a minúsculas el tipo. Un tipo vacío
+// siempre es un error; uno desconocido lo es solo en modo estricto, si no se
+// convierte en NotificationGeneric.
+func NormalizeNotificationType(value string, strict bool) (NotificationType, error) {
+	candidate := NotificationType(strings.ToLower(strings.TrimSpace(value)))
+	if candidate == "" {
+		return "", fmt.Errorf("el tipo de notificación es obligatorio")
+	}
+	for _, known := range NotificationTypes() {
+		if candidate == known {
+			return candidate, nil
+		}
+	}
+	if strict {
+		return "", fmt.Errorf("tipo de notificación inválido: %s", value)
+	}
+	return NotificationGeneric, nil
+}
+
 type Notification struct {
 	ID        int64
 	Type      NotificationType
@@ -22,3 +68,95 @@ type Notification struct {
 	Metadata  map[string]string
 	CreatedAt time.Time
 }
+
+// Límites de los datos de una notificación (en caracteres).
+const (
+	MaxNotificationUsernameLen      = 64
+	MaxNotificationMessageLen       = 500
+	MaxNotificationAmount           = 1_000_000
+	MaxNotificationMetadataEntries  = 20
+	MaxNotificationMetadataKeyLen   = 64
+	MaxNotificationMetadataValueLen = 512
+)
+
+// ErrNotificationTooLarge indica que algún campo supera los límites.
+var ErrNotificationTooLarge = errors.New("notificación demasiado grande")
+
+// NewNotification arma una notificación normalizada a partir de datos de
+// entrada (API HTTP, desktop). El tipo es obligatorio; si no se reconoce se
+// guarda como genérico. Recorta los textos, pasa la plataforma a minúsculas,
+// descarta claves de metadata vacías y rechaza montos fuera de rango. Los
+// textos y la metadata que superan los límites devuelven
+// ErrNotificationTooLarge.
+func NewNotification(notificationType, platform, username string, amount float64, message string, metadata map[string]string) (*Notification, error) {
+	parsedType, err := NormalizeNotificationType(notificationType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 || amount > MaxNotificationAmount {
+		return nil, fmt.Errorf("monto inválido: %v", amount)
+	}
+
+	username = strings.TrimSpace(username)
+	message = strings.TrimSpace(message)
+	if utf8.RuneCountInString(username) > MaxNotificationUsernameLen {
+		return nil, fmt.Errorf("%w: username supera %d caracteres", ErrNotificationTooLarge, MaxNotificationUsernameLen)
+	}
+	if utf8.RuneCountInString(message) > MaxNotificationMessageLen {
+		return nil, fmt.Errorf("%w: message supera %d caracteres", ErrNotificationTooLarge, MaxNotificationMessageLen)
+	}
+
+	cleaned := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		if key = strings.TrimSpace(key); key != "" {
+			cleaned[key] = strings.TrimSpace(value)
+		}
+	}
+	if len(cleaned) > MaxNotificationMetadataEntries {
+		return nil, fmt.Errorf("%w: metadata supera %d entradas", ErrNotificationTooLarge, MaxNotificationMetadataEntries)
+	}
+	for key, value := range cleaned {
+		if utf8.RuneCountInString(key) > MaxNotificationMetadataKeyLen || utf8.RuneCountInString(value) > MaxNotificationMetadataValueLen {
+			return nil, fmt.Errorf("%w: metadata %q supera el largo permitido", ErrNotificationTooLarge, key)
+		}
+	}
+
+	return &Notification{
+		Type:      parsedType,
+		Platform:  Platform(strings.ToLower(strings.TrimSpace(platform))),
+		Username:  username,
+		Amount:    amount,
+		Message:   message,
+		Metadata:  cleaned,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NotificationTestKey marca en Metadata las notificaciones de prueba para que
+// se puedan filtrar.
+const NotificationTestKey = "test"
+
+func (n Notification) IsTest() bool {
+	return n.Metadata[NotificationTestKey] == "true"
+}
+
+// NotificationTemplateRepository guarda las plantillas por tipo de notificación.
+// Solo se guardan las que el usuario cambió; el resto usa la plantilla por defecto.
+type NotificationTemplateRepository interface {
+	GetNotificationTemplates(ctx context.Context) (map[NotificationType]string, error)
+	SetNotificationTemplates(ctx context.Context, templates map[NotificationType]string) error
+}
+
+// DefaultNotificationTemplates devuelve las plantillas de fábrica. Aceptan
+// {username}, {amount} y {message}.
+func DefaultNotificationTemplates() map[NotificationType]string {
+	return map[NotificationType]string{
+		NotificationSubscription:   "🎉 ¡{username} se suscribió! {message}",
+		NotificationDonation:       "💸 {username} donó {amount}: {message}",
+		NotificationBits:           "💎 {username} envió {amount} bits: {message}",
+		NotificationGiveawayWinner: "🏆 ¡{username} ganó el sorteo!",
+		NotificationFollow:         "💜 ¡{username} empezó a seguir el canal!",
+		NotificationGeneric:        "🔔 {username}: {message}",
+	}
+}
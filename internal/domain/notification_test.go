@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewNotificationNormalizes(t *testing.T) {
+	n, err := NewNotification("  Donation ", " Twitch ", "  zero  ", 5.5, "  gracias  ", map[string]string{
+		" currency ": " USD ",
+		"   ":        "se descarta",
+	})
+	if err != nil {
+		t.Fatalf("NewNotification: %v", err)
+	}
+	if n.Type != NotificationDonation {
+		t.Errorf("Type = %q", n.Type)
+	}
+	if n.Platform != PlatformTwitch {
+		t.Errorf("Platform = %q", n.Platform)
+	}
+	if n.Username != "zero" || n.Message != "gracias" {
+		t.Errorf("Username/Message = %q/%q", n.Username, n.Message)
+	}
+	if n.Amount != 5.5 {
+		t.Errorf("Amount = %v", n.Amount)
+	}
+	if len(n.Metadata) != 1 || n.Metadata["currency"] != "USD" {
+		t.Errorf("Metadata = %v", n.Metadata)
+	}
+	if n.CreatedAt.IsZero() {
+		t.Error("CreatedAt not set")
+	}
+}
+
+func TestNewNotificationDefaults(t *testing.T) {
+	n, err := NewNotification("raid", "", "", 0, "", nil)
+	if err != nil {
+		t.Fatalf("NewNotification: %v", err)
+	}
+	if n.Type != NotificationGeneric {
+		t.Errorf("unknown type = %q, want generic", n.Type)
+	}
+	if n.Metadata == nil {
+		t.Error("Metadata is nil, want empty map")
+	}
+}
+
+func TestNewNotificationRejects(t *testing.T) {
+	manyKeys := make(map[string]string, MaxNotificationMetadataEntries+1)
+	for i := 0; i <= MaxNotificationMetadataEntries; i++ {
+		manyKeys[strings.Repeat("k", i+1)] = "v"
+	}
+
+	cases := []struct {
+		name     string
+		typ      string
+		username string
+		amount   float64
+		message  string
+		metadata map[string]string
+		tooLarge bool
+	}{
+		{name: "empty type", typ: "  "},
+		{name: "negative amount", typ: "bits", amount: -1},
+		{name: "NaN amount", typ: "bits", amount: math.NaN()},
+		{name: "infinite amount", typ: "bits", amount: math.Inf(1)},
+		{name: "amount over limit", typ: "bits", amount: MaxNotificationAmount + 1},
+		{name: "long username", typ: "bits", username: strings.Repeat("ñ", MaxNotificationUsernameLen+1), tooLarge: true},
+		{name: "long message", typ: "bits", message: strings.Repeat("a", MaxNotificationMessageLen+1), tooLarge: true},
+		{name: "too many metadata entries", typ: "bits", metadata: manyKeys, tooLarge: true},
+		{name: "long metadata value", typ: "bits", metadata: map[string]string{"k": strings.Repeat("v", MaxNotificationMetadataValueLen+1)}, tooLarge: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewNotification(tc.typ, "twitch", tc.username, tc.amount, tc.message, tc.metadata)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := errors.Is(err, ErrNotificationTooLarge); got != tc.tooLarge {
+				t.Fatalf("errors.Is(ErrNotificationTooLarge) = %v for %v", got, err)
+			}
+		})
+	}
+}
+
+func TestNewNotificationLimitsCountRunes(t *testing.T) {
+	// los límites son en caracteres, no en bytes
+	username := strings.Repeat("ñ", MaxNotificationUsernameLen)
+	if _, err := NewNotification("bits", "twitch", username, 0, "", nil); err != nil {
+		t.Fatalf("username at the limit rejected: %v", err)
+	}
+}
+
+func TestNormalizeNotificationType(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		strict  bool
+		want    NotificationType
+		wantErr bool
+	}{
+		{name: "known", input: "bits", want: NotificationBits},
+		{name: "known with spaces and case", input: "  Giveaway_Winner ", want: NotificationGiveawayWinner},
+		{name: "follow", input: "FOLLOW", strict: true, want: NotificationFollow},
+		{name: "unknown becomes generic", input: "raid", want: NotificationGeneric},
+		{name: "unknown in strict mode", input: "raid", strict: true, wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "blank in strict mode", input: "   ", strict: true, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NormalizeNotificationType(tc.input, tc.strict)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got %q, want an error", got)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseNotificationTypeIsStrict(t *testing.T) {
+	for _, known := range NotificationTypes() {
+		if got, err := ParseNotificationType(string(known)); err != nil || got != known {
+			t.Errorf("ParseNotificationType(%q) = %q, %v", known, got, err)
+		}
+	}
+	if _, err := ParseNotificationType("raid"); err == nil {
+		t.Error("ParseNotificationType accepted an unknown type")
+	}
+}
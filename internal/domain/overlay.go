@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// OverlayConfigRepository guarda la configuración (JSON) de cada overlay por nombre.
+type OverlayConfigRepository interface {
+	// GetOverlayConfig devuelve nil si el overlay no tiene configuración guardada.
+	GetOverlayConfig(ctx context.Context, name string) ([]byte, error)
+	SaveOverlayConfig(ctx context.Context, name string, data []byte) error
+	// ListOverlayConfigNames devuelve los overlays con configuración guardada.
+	ListOverlayConfigNames(ctx context.Context) ([]string, error)
+}
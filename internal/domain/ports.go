@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -9,6 +12,21 @@ type OutgoingMessagePort interface {
 	SendMessage(ctx context.Context, platform Platform, channelID, text string) error
 }
 
+// PrivateMessagePort es opcional: lo implementan los senders que pueden mandar
+// mensajes privados (whispers) a un usuario.
+type PrivateMessagePort interface {
+	SendPrivateMessage(ctx context.Context, platform Platform, userID, username, text string) error
+}
+
+// ErrPrivateMessageUnsupported indica que la plataforma no permite mensajes privados.
+var ErrPrivateMessageUnsupported = errors.New("private messages not supported")
+
+// Reloadable lo implementan los servicios que cachean en memoria valores
+// guardados en settings; Reload vuelve a leerlos del repositorio.
+type Reloadable interface {
+	Reload(ctx context.Context) error
+}
+
 type MessagePublisher interface {
 	PublishMessage(ctx context.Context, msg Message) error
 }
@@ -34,6 +52,123 @@ type Credential struct {
 	Metadata     map[string]string
 }
 
+// CredentialScopesKey es la metadata donde el callback OAuth guarda los scopes
+// otorgados, separados por espacios.
+const CredentialScopesKey = "scopes"
+
+// CredentialReauthKey es la metadata que marca una credencial cuyo refresh
+// token fue rechazado para siempre; el valor es el motivo. Un login OAuth nuevo
+// guarda la credencial sin esta marca.
+const CredentialReauthKey = "reauth_required"
+
+// ReauthReason devuelve el motivo por el que hay que volver a iniciar sesión,
+// o "" si la credencial sigue siendo válida.
+func (c *Credential) ReauthReason() string {
+	if c == nil {
+		return ""
+	}
+	return c.Metadata[CredentialReauthKey]
+}
+
+// MissingProfile indica que una credencial de Twitch tiene token pero no
+// login: pasa cuando falló la lectura del perfil al iniciar sesión.
+func (c *Credential) MissingProfile() bool {
+	return c != nil &&
+		c.Platform == PlatformTwitch &&
+		strings.TrimSpace(c.AccessToken) != "" &&
+		strings.TrimSpace(c.Metadata["login"]) == ""
+}
+
+// Scopes devuelve los scopes otorgados. Es nil si la credencial se guardó
+// antes de que se registraran.
+func (c *Credential) Scopes() []string {
+	if c == nil {
+		return nil
+	}
+	return strings.Fields(c.Metadata[CredentialScopesKey])
+}
+
+// HasScope indica si la credencial tiene scope. Con los scopes sin registrar
+// (credenciales viejas) no se puede saber y se responde false.
+func (c *Credential) HasScope(scope string) bool {
+	granted := c.Scopes()
+	return len(granted) > 0 && len(MissingScopes([]string{scope}, granted)) == 0
+}
+
+// SetScopes guarda los scopes otorgados en forma canónica (sin repetidos y
+// ordenados). Una lista vacía no borra los que ya había: los refresh de Kick
+// pueden no informarlos.
+func (c *Credential) SetScopes(scopes []string) {
+	canonical := CanonicalScopes(scopes)
+	if c == nil || canonical == "" {
+		return
+	}
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]string)
+	}
+	c.Metadata[CredentialScopesKey] = canonical
+}
+
+// CanonicalScopes normaliza una lista de scopes (Twitch los manda como
+// arreglo, Kick como texto separado por espacios) a texto ordenado y sin
+// repetidos.
+func CanonicalScopes(scopes []string) string {
+	seen := make(map[string]struct{}, len(scopes))
+	var out []string
+	for _, raw := range scopes {
+		for _, scope := range strings.Fields(raw) {
+			if _, ok := seen[scope]; ok {
+				continue
+			}
+			seen[scope] = struct{}{}
+			out = append(out, scope)
+		}
+	}
+	sort.Strings(out)
+	return strings.Join(out, " ")
+}
+
+// MissingScopes devuelve los scopes de expected que no están en granted, en el
+// orden de expected. Twitch y Kick los devuelven tal cual se pidieron, pero la
+// comparación ignora mayúsculas por las dudas.
+func MissingScopes(expected, granted []string) []string {
+	have := make(map[string]struct{}, len(granted))
+	for _, scope := range granted {
+		have[strings.ToLower(strings.TrimSpace(scope))] = struct{}{}
+	}
+	var missing []string
+	seen := make(map[string]struct{}, len(expected))
+	for _, scope := range expected {
+		key := strings.ToLower(strings.TrimSpace(scope))
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if _, ok := have[key]; !ok {
+			missing = append(missing, strings.TrimSpace(scope))
+		}
+	}
+	return missing
+}
+
+// NormalizeCredentialPlatform deja la plataforma como se guarda (minúsculas).
+func NormalizeCredentialPlatform(platform Platform) Platform {
+	return Platform(strings.ToLower(strings.TrimSpace(string(platform))))
+}
+
+// NormalizeCredentialRole deja el rol como se guarda: en minúsculas y sin
+// espacios. Kick solo tiene credencial de streamer, así que cualquier otro rol
+// se guarda como "streamer".
+func NormalizeCredentialRole(platform Platform, role string) string {
+	if NormalizeCredentialPlatform(platform) == PlatformKick {
+		return "streamer"
+	}
+	return strings.ToLower(strings.TrimSpace(role))
+}
+
 type CredentialRepository interface {
 	Get(ctx context.Context, platform Platform, role string) (*Credential, error)
 	Save(ctx context.Context, cred *Credential) error
@@ -45,3 +180,22 @@ type NotificationRepository interface {
 	SaveNotification(ctx context.Context, notification *Notification) (*Notification, error)
 	ListNotifications(ctx context.Context, limit int) ([]*Notification, error)
 }
+
+// NotificationFilter limita qué notificaciones se cuentan. Los campos vacíos
+// no filtran.
+type NotificationFilter struct {
+	Type        NotificationType
+	Platform    Platform
+	Since       time.Time
+	ExcludeTest bool
+}
+
+// NotificationUpdater reescribe una notificación ya guardada (por ID).
+type NotificationUpdater interface {
+	UpdateNotification(ctx context.Context, notification *Notification) error
+}
+
+// NotificationCounter cuenta notificaciones sin cargarlas en memoria.
+type NotificationCounter interface {
+	CountNotifications(ctx context.Context, filter NotificationFilter) (int, error)
+}
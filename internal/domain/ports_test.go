@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMissingScopes(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []string
+		granted  []string
+		want     []string
+	}{
+		{"all granted", []string{"chat:read", "chat:edit"}, []string{"chat:edit", "chat:read", "whispers:read"}, nil},
+		{"keeps expected order", []string{"chat:read", "moderator:manage:banned_users", "chat:edit"}, []string{"chat:read"}, []string{"moderator:manage:banned_users", "chat:edit"}},
+		{"ignores case and spaces", []string{" Chat:Read "}, []string{"chat:read"}, nil},
+		{"duplicates reported once", []string{"channel:write", "channel:write"}, nil, []string{"channel:write"}},
+		{"blank expected ignored", []string{"", "  "}, nil, nil},
+		{"nothing expected", nil, []string{"chat:read"}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MissingScopes(tc.expected, tc.granted); !slices.Equal(got, tc.want) {
+				t.Fatalf("MissingScopes(%q, %q) = %q, want %q", tc.expected, tc.granted, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCredentialScopes(t *testing.T) {
+	cred := &Credential{Metadata: map[string]string{CredentialScopesKey: "chat:read  chat:edit"}}
+	if got := cred.Scopes(); !slices.Equal(got, []string{"chat:read", "chat:edit"}) {
+		t.Fatalf("Scopes() = %q", got)
+	}
+	if !cred.HasScope("CHAT:EDIT") || cred.HasScope("channel:manage:broadcast") {
+		t.Fatal("HasScope mismatch")
+	}
+	if (&Credential{}).HasScope("chat:read") {
+		t.Fatal("unknown scopes must not count as granted")
+	}
+}
+
+func TestCanonicalScopes(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []string
+		want string
+	}{
+		{"twitch array", []string{"chat:read", "chat:edit"}, "chat:edit chat:read"},
+		{"kick space separated", []string{"user:read  chat:write events:subscribe"}, "chat:write events:subscribe user:read"},
+		{"duplicates", []string{"chat:read", "chat:read chat:edit"}, "chat:edit chat:read"},
+		{"blank", []string{"", "  "}, ""},
+		{"nil", nil, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CanonicalScopes(tc.in); got != tc.want {
+				t.Fatalf("CanonicalScopes(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCredentialSetScopes(t *testing.T) {
+	cred := &Credential{}
+	cred.SetScopes([]string{"chat:read", "chat:edit", "chat:read"})
+	if got := cred.Metadata[CredentialScopesKey]; got != "chat:edit chat:read" {
+		t.Fatalf("stored scopes = %q", got)
+	}
+
+	// una respuesta sin scopes no borra los que ya se conocían
+	cred.SetScopes(nil)
+	cred.SetScopes([]string{""})
+	if !cred.HasScope("chat:edit") {
+		t.Fatalf("empty refresh dropped the scopes: %q", cred.Metadata[CredentialScopesKey])
+	}
+
+	var missing *Credential
+	missing.SetScopes([]string{"chat:read"})
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PresenceDedupeWindow es lo mínimo entre dos saludos en la misma
+// plataforma: una conexión que se cae y vuelve no repite el mensaje.
+const PresenceDedupeWindow = 10 * time.Minute
+
+// PresenceMessages son los mensajes que el bot manda al conectarse al chat y
+// al apagarse. Vacío no manda nada.
+type PresenceMessages struct {
+	ConnectMessage    string `json:"connect_message"`
+	DisconnectMessage string `json:"disconnect_message"`
+}
+
+// PresenceSettings guarda los mensajes de cada plataforma.
+type PresenceSettings map[Platform]PresenceMessages
+
+type PresenceSettingsRepository interface {
+	GetPresenceSettings(ctx context.Context) (PresenceSettings, error)
+	SetPresenceSettings(ctx context.Context, settings PresenceSettings) error
+}
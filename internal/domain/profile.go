@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserProfile son los datos públicos de un usuario que el chat no trae en
+// cada mensaje (avatar, verificado).
+type UserProfile struct {
+	Platform    Platform  `json:"platform"`
+	UserID      string    `json:"user_id"`
+	Login       string    `json:"login,omitempty"`
+	DisplayName string    `json:"display_name,omitempty"`
+	AvatarURL   string    `json:"avatar_url,omitempty"`
+	Verified    bool      `json:"verified"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// UserProfileFetcher consulta el perfil en la API de la plataforma.
+type UserProfileFetcher interface {
+	FetchUserProfile(ctx context.Context, userID string) (*UserProfile, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Quote es una frase guardada con !quote add. Number es correlativo y no se
+// reutiliza aunque se borren citas.
+type Quote struct {
+	Number    int64     `json:"number"`
+	Text      string    `json:"text"`
+	AddedBy   string    `json:"added_by"`
+	Platform  Platform  `json:"platform,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type QuoteRepository interface {
+	// AddQuote asigna el número siguiente y lo devuelve en la cita.
+	AddQuote(ctx context.Context, quote *Quote) (*Quote, error)
+	// GetQuote devuelve nil si no existe.
+	GetQuote(ctx context.Context, number int64) (*Quote, error)
+	// RandomQuote devuelve nil si no hay citas.
+	RandomQuote(ctx context.Context) (*Quote, error)
+	ListQuotes(ctx context.Context) ([]*Quote, error)
+	// UpdateQuote y DeleteQuote devuelven false si la cita no existe.
+	UpdateQuote(ctx context.Context, number int64, text string) (bool, error)
+	DeleteQuote(ctx context.Context, number int64) (bool, error)
+}
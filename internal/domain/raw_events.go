@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultRawEventRetention es cuánto se guardan los eventos crudos.
+const DefaultRawEventRetention = 3 * 24 * time.Hour
+
+// RawPlatformEvent es un evento de plataforma tal cual llegó (USERNOTICE de
+// Twitch, frames del websocket de Kick). Se guarda para depurar y para
+// reprocesar las notificaciones más adelante.
+type RawPlatformEvent struct {
+	Platform   Platform  `json:"platform"`
+	EventType  string    `json:"event_type"`
+	Payload    string    `json:"payload"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// RawEventFilter elige qué eventos crudos leer. Los campos vacíos no
+// filtran; Limit <= 0 no limita.
+type RawEventFilter struct {
+	Platform  Platform
+	EventType string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+}
+
+type RawEventRepository interface {
+	SaveRawEvents(ctx context.Context, events []RawPlatformEvent) error
+	// ListRawEvents devuelve los eventos en el orden en que llegaron.
+	ListRawEvents(ctx context.Context, filter RawEventFilter) ([]RawPlatformEvent, error)
+	// PruneRawEvents borra lo recibido antes de before y devuelve cuántos
+	// eventos borró.
+	PruneRawEvents(ctx context.Context, before time.Time) (int64, error)
+}
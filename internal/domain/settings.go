@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Setting es una fila cruda de la tabla de settings.
+type Setting struct {
+	Key       string
+	Value     string
+	UpdatedAt time.Time
+}
+
+type SettingsLister interface {
+	ListSettings(ctx context.Context) ([]Setting, error)
+}
+
+// ReadOnlyRepository guarda el modo solo lectura (el bot procesa todo pero no
+// envía nada).
+type ReadOnlyRepository interface {
+	GetReadOnly(ctx context.Context) (bool, error)
+	SetReadOnly(ctx context.Context, enabled bool) error
+}
+
+// BotPauseRepository guarda si los comandos están pausados, así un reinicio
+// no los reactiva sin que nadie lo note.
+type BotPauseRepository interface {
+	GetBotPaused(ctx context.Context) (bool, error)
+	SetBotPaused(ctx context.Context, paused bool) error
+}
+
+// DefaultCommandPrefix es el prefijo de los comandos si no se configuró otro.
+const DefaultCommandPrefix = "!"
+
+// CommandPrefixRepository guarda el prefijo de los comandos; "" es el de
+// fábrica.
+type CommandPrefixRepository interface {
+	GetCommandPrefix(ctx context.Context) (string, error)
+	SetCommandPrefix(ctx context.Context, prefix string) error
+}
+
+// DebugRecorderSettings controla el registro de eventos para depurar reportes.
+// IncludeText agrega el texto completo de los mensajes del chat.
+type DebugRecorderSettings struct {
+	Enabled     bool `json:"enabled"`
+	IncludeText bool `json:"include_text"`
+}
+
+type DebugRecorderRepository interface {
+	GetDebugRecorder(ctx context.Context) (DebugRecorderSettings, error)
+	SetDebugRecorder(ctx context.Context, settings DebugRecorderSettings) error
+}
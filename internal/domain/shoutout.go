@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+// AutoShoutoutSettings configura el shoutout automático: cuando un streamer
+// de la lista (o un VIP, si IncludeVIPs) escribe por primera vez en la sesión
+// de stream, el bot lo recomienda una vez. La plantilla acepta {user},
+// {login} y {url} (el canal en su plataforma).
+type AutoShoutoutSettings struct {
+	Enabled  bool   `json:"enabled"`
+	Template string `json:"template"`
+	// Streamers son logins, en minúsculas; sirven para cualquier plataforma.
+	Streamers   []string `json:"streamers"`
+	IncludeVIPs bool     `json:"include_vips"`
+}
+
+func DefaultAutoShoutoutSettings() AutoShoutoutSettings {
+	return AutoShoutoutSettings{
+		Template:  "📣 ¡{user} anda por el chat! Pasen a seguirlo en {url}",
+		Streamers: []string{},
+	}
+}
+
+type AutoShoutoutSettingsRepository interface {
+	GetAutoShoutoutSettings(ctx context.Context) (*AutoShoutoutSettings, error)
+	SetAutoShoutoutSettings(ctx context.Context, settings AutoShoutoutSettings) error
+}
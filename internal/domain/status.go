@@ -13,6 +13,10 @@ type StreamStatus struct {
 	ViewerCount int
 	StartedAt   time.Time
 	URL         string
+	// FetchedAt es cuándo se consultó el estado y Source qué servicio lo
+	// devolvió ("helix", "kick-api"); sirven para detectar datos viejos.
+	FetchedAt time.Time
+	Source    string
 }
 
 type StreamStatusService interface {
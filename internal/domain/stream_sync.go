@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+// StreamSyncSettings controla si los cambios de título/categoría hechos en
+// Twitch se copian a Kick. Solo va en ese sentido.
+type StreamSyncSettings struct {
+	Enabled  bool `json:"enabled"`
+	Title    bool `json:"title"`
+	Category bool `json:"category"`
+}
+
+func DefaultStreamSyncSettings() StreamSyncSettings {
+	return StreamSyncSettings{Enabled: false, Title: true, Category: true}
+}
+
+type StreamSyncSettingsRepository interface {
+	GetStreamSyncSettings(ctx context.Context) (*StreamSyncSettings, error)
+	SetStreamSyncSettings(ctx context.Context, settings StreamSyncSettings) error
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrackerKind indica cómo se busca el término en el chat.
+type TrackerKind string
+
+const (
+	// TrackerKindWord busca la palabra sin distinguir mayúsculas y respetando los límites de palabra.
+	TrackerKindWord TrackerKind = "word"
+	// TrackerKindEmote busca el código exacto del emote como token separado por espacios.
+	TrackerKindEmote TrackerKind = "emote"
+)
+
+func ParseTrackerKind(value string) (TrackerKind, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", string(TrackerKindWord):
+		return TrackerKindWord, nil
+	case string(TrackerKindEmote):
+		return TrackerKindEmote, nil
+	default:
+		return "", fmt.Errorf("tipo de contador inválido: %s", value)
+	}
+}
+
+// WordTracker cuenta cuántas veces aparece una palabra/emote en el chat durante
+// una sesión de stream.
+type WordTracker struct {
+	Name           string
+	Term           string
+	Kind           TrackerKind
+	MilestoneEvery int
+	Enabled        bool
+	SessionID      string
+	Count          int
+	UpdatedAt      time.Time
+}
+
+type TrackerRepository interface {
+	ListTrackers(ctx context.Context) ([]*WordTracker, error)
+	UpsertTracker(ctx context.Context, tracker *WordTracker) error
+	DeleteTracker(ctx context.Context, name string) error
+	// UpdateTrackerCount guarda el conteo de la sesión sin tocar la configuración.
+	UpdateTrackerCount(ctx context.Context, name, sessionID string, count int) error
+}
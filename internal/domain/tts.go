@@ -26,3 +26,91 @@ type TTSSettingsRepository interface {
 	SetTTSEnabled(ctx context.Context, enabled bool) error
 	GetTTSEnabled(ctx context.Context) (bool, error)
 }
+
+// TTSAutoPauseSettings pausa el TTS del chat cuando hay demasiados mensajes por minuto.
+type TTSAutoPauseSettings struct {
+	Enabled bool `json:"enabled"`
+	// PauseAbove: mensajes/minuto a partir de los cuales se pausa.
+	PauseAbove int `json:"pause_above"`
+	// ResumeBelow: mensajes/minuto por debajo de los cuales empieza la cuenta para reanudar.
+	ResumeBelow int `json:"resume_below"`
+	// ResumeAfterMinutes: minutos seguidos por debajo de ResumeBelow para reanudar.
+	ResumeAfterMinutes int `json:"resume_after_minutes"`
+}
+
+// DefaultTTSAutoPauseSettings viene apagado: el streamer lo activa desde la
+// configuración del TTS.
+func DefaultTTSAutoPauseSettings() TTSAutoPauseSettings {
+	return TTSAutoPauseSettings{
+		Enabled:            false,
+		PauseAbove:         120,
+		ResumeBelow:        40,
+		ResumeAfterMinutes: 2,
+	}
+}
+
+type TTSAutoPauseRepository interface {
+	GetTTSAutoPause(ctx context.Context) (*TTSAutoPauseSettings, error)
+	SetTTSAutoPause(ctx context.Context, settings TTSAutoPauseSettings) error
+}
+
+// DefaultTTSSkipVotes es la cantidad de usuarios distintos que deben votar
+// !ttsskip para saltar la lectura actual.
+const DefaultTTSSkipVotes = 5
+
+type TTSSkipVotesRepository interface {
+	GetTTSSkipVotes(ctx context.Context) (int, error)
+	SetTTSSkipVotes(ctx context.Context, votes int) error
+}
+
+// DefaultTTSMaxFetchSeconds es el tiempo máximo para descargar el audio de
+// una lectura; MaxTTSMaxFetchSeconds el mayor valor que se acepta.
+const (
+	DefaultTTSMaxFetchSeconds = 30
+	MaxTTSMaxFetchSeconds     = 300
+)
+
+type TTSMaxFetchRepository interface {
+	GetTTSMaxFetchSeconds(ctx context.Context) (int, error)
+	SetTTSMaxFetchSeconds(ctx context.Context, seconds int) error
+}
+
+// Resultados de una lectura TTS en el historial.
+const (
+	TTSOutcomeOK      = "ok"
+	TTSOutcomeFailed  = "failed"
+	TTSOutcomeSkipped = "skipped"
+)
+
+// DefaultTTSHistoryRetention es cuánto se guarda el historial de lecturas.
+const DefaultTTSHistoryRetention = 7 * 24 * time.Hour
+
+// TTSHistoryEntry es una lectura TTS ya procesada; sirve para que los mods
+// encuentren quién pidió algo que sonó en el stream.
+type TTSHistoryEntry struct {
+	ID          string    `json:"id"`
+	Text        string    `json:"text"`
+	RequestedBy string    `json:"requested_by"`
+	Platform    Platform  `json:"platform"`
+	ChannelID   string    `json:"channel_id,omitempty"`
+	Voice       string    `json:"voice"`
+	Outcome     string    `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+	FinishedAt  time.Time `json:"finished_at"`
+}
+
+// TTSHistoryFilter filtra el historial; User compara sin mayúsculas y vacío
+// no filtra, igual que Outcome.
+type TTSHistoryFilter struct {
+	Limit   int
+	User    string
+	Outcome string
+}
+
+type TTSHistoryRepository interface {
+	SaveTTSHistory(ctx context.Context, entries []TTSHistoryEntry) error
+	ListTTSHistory(ctx context.Context, filter TTSHistoryFilter) ([]TTSHistoryEntry, error)
+	// PruneTTSHistory borra lo terminado antes de before y devuelve cuántas
+	// lecturas borró.
+	PruneTTSHistory(ctx context.Context, before time.Time) (int64, error)
+}
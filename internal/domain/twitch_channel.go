@@ -1,6 +1,21 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTwitchMissingScope indica que el token no tiene el scope que pide el endpoint.
+var ErrTwitchMissingScope = errors.New("twitch: token sin el scope requerido")
+
+// TwitchUser describe los datos públicos de una cuenta de Twitch.
+type TwitchUser struct {
+	ID          string
+	Login       string
+	DisplayName string
+	CreatedAt   time.Time
+}
 
 // Puerto para hacer acciones sobre el canal de Twitch vía Helix.
 type TwitchChannelService interface {
@@ -16,4 +31,22 @@ type TwitchChannelService interface {
 
 	GetStreamStatus(ctx context.Context, broadcasterID string) (StreamStatus, error)
 	IsFollower(ctx context.Context, broadcasterID, userID string) (bool, error)
+	// FollowedAt devuelve cero si userID no sigue el canal.
+	FollowedAt(ctx context.Context, broadcasterID, userID string) (time.Time, error)
+	IsSubscriber(ctx context.Context, broadcasterID, userID string) (bool, error)
+	IsModerator(ctx context.Context, broadcasterID, userID string) (bool, error)
+
+	// GetUserByLogin devuelve nil (sin error) cuando el usuario no existe.
+	GetUserByLogin(ctx context.Context, login string) (*TwitchUser, error)
+
+	// SendAnnouncement publica un anuncio destacado; color vacío usa el color del canal.
+	SendAnnouncement(ctx context.Context, broadcasterID, moderatorID, text, color string) error
+
+	ChatSettingsService
+}
+
+// TwitchWhisperService manda whispers con el token del bot; necesita el scope
+// user:manage:whispers y que la cuenta tenga el teléfono verificado.
+type TwitchWhisperService interface {
+	SendWhisper(ctx context.Context, fromUserID, toUserID, text string) error
 }
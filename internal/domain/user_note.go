@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserNote es una nota de un mod sobre un usuario ("avisado por spoilers").
+// Login se guarda para mostrarla y para encontrar al usuario por nombre.
+type UserNote struct {
+	ID        int64     `json:"id"`
+	Platform  Platform  `json:"platform"`
+	UserID    string    `json:"user_id"`
+	Login     string    `json:"login,omitempty"`
+	Note      string    `json:"note"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NotedUser identifica a un usuario que tiene al menos una nota.
+type NotedUser struct {
+	Platform Platform
+	UserID   string
+	Login    string
+	Notes    int
+}
+
+type UserNoteRepository interface {
+	ListUserNotes(ctx context.Context, platform Platform, userID string) ([]*UserNote, error)
+	// ListNotedUsers devuelve los usuarios con notas (para el caché del runtime).
+	ListNotedUsers(ctx context.Context) ([]NotedUser, error)
+	AddUserNote(ctx context.Context, note *UserNote) (*UserNote, error)
+	// DeleteUserNote devuelve false si la nota no existe o es de otro usuario.
+	DeleteUserNote(ctx context.Context, platform Platform, userID string, id int64) (bool, error)
+}
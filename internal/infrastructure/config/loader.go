@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -42,7 +44,7 @@ type fileConfig struct {
 }
 
 var (
-	configFilePath    string
+	configFilePath   string
 	cachedFileConfig *fileConfig
 )
 
@@ -78,6 +80,62 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Problems lista configuraciones incompletas (p. ej. OAuth de Kick con client
+// id pero sin secret). Lo que falta del todo no es un problema: esa
+// plataforma simplemente queda desactivada.
+func (c *Config) Problems() []string {
+	if c == nil {
+		return []string{"configuración vacía"}
+	}
+	var problems []string
+
+	twitch := map[string]string{
+		"TWITCH_CLIENT_ID":     c.TwitchClientId,
+		"TWITCH_CLIENT_SECRET": c.TwitchClientSecret,
+		"TWITCH_REDIRECT_URI":  c.TwitchRedirectURI,
+	}
+	if c.TwitchClientId == embeddedTwitchClientID {
+		twitch["TWITCH_CLIENT_ID"] = ""
+	}
+	problems = append(problems, incomplete("OAuth de Twitch", twitch)...)
+
+	problems = append(problems, incomplete("OAuth de Kick", map[string]string{
+		"KICK_CLIENT_ID":     c.KickClientID,
+		"KICK_CLIENT_SECRET": c.KickClientSecret,
+		"KICK_REDIRECT_URI":  c.KickRedirectURI,
+	})...)
+
+	for _, uri := range []string{c.TwitchRedirectURI, c.KickRedirectURI} {
+		if uri = strings.TrimSpace(uri); uri == "" {
+			continue
+		}
+		if parsed, err := url.Parse(uri); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			problems = append(problems, fmt.Sprintf("redirect URI inválida: %s", uri))
+		}
+	}
+	return problems
+}
+
+// incomplete devuelve un problema si hay valores cargados y otros vacíos. La
+// redirect URI viene con valor por defecto en la plantilla, así que sola no
+// cuenta como configurada.
+func incomplete(name string, values map[string]string) []string {
+	var missing []string
+	set := 0
+	for key, value := range values {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, key)
+		} else if !strings.HasSuffix(key, "_REDIRECT_URI") {
+			set++
+		}
+	}
+	if set == 0 || len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return []string{fmt.Sprintf("%s incompleto, falta %s", name, strings.Join(missing, ", "))}
+}
+
 func ConfigFilePath() string {
 	return configFilePath
 }
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProblems(t *testing.T) {
+	const twitchRedirect = "http://localhost:17833/oauth/callback/twitch"
+	const kickRedirect = "http://localhost:17833/oauth/callback/kick"
+
+	cases := []struct {
+		name string
+		cfg  Config
+		want []string
+	}{
+		{
+			name: "fresh template only has redirect URIs",
+			cfg:  Config{TwitchClientId: embeddedTwitchClientID, TwitchRedirectURI: twitchRedirect, KickRedirectURI: kickRedirect},
+		},
+		{
+			name: "complete",
+			cfg:  Config{TwitchClientId: "id", TwitchClientSecret: "secret", TwitchRedirectURI: twitchRedirect},
+		},
+		{
+			name: "kick secret missing",
+			cfg:  Config{KickClientID: "id", KickRedirectURI: kickRedirect},
+			want: []string{"OAuth de Kick incompleto, falta KICK_CLIENT_SECRET"},
+		},
+		{
+			name: "redirect missing once credentials are set",
+			cfg:  Config{KickClientID: "id", KickClientSecret: "secret"},
+			want: []string{"OAuth de Kick incompleto, falta KICK_REDIRECT_URI"},
+		},
+		{
+			name: "invalid redirect",
+			cfg:  Config{TwitchRedirectURI: "localhost/callback"},
+			want: []string{"redirect URI inválida: localhost/callback"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.cfg.Problems()
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+				t.Fatalf("Problems() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoopbackPort es el puerto por defecto del callback OAuth de escritorio.
+const LoopbackPort = 17833
+
+// LoopbackRedirectURI devuelve la redirect URI por defecto del proveedor
+// ("twitch" o "kick").
+func LoopbackRedirectURI(provider string) string {
+	return fmt.Sprintf("http://localhost:%d/oauth/callback/%s", LoopbackPort, provider)
+}
+
+// LegacyRedirect es una redirect URI que no apunta al loopback local (p. ej.
+// https://dev.zdev.app/api/oauth/... de las herramientas de cmd). La app de
+// escritorio la ignora y usa el puerto por defecto.
+type LegacyRedirect struct {
+	Provider  string `json:"provider"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Suggested string `json:"suggested"`
+	// FromEnv indica que el valor viene de una variable de entorno: reescribir
+	// config.json no lo cambia.
+	FromEnv bool `json:"from_env"`
+}
+
+// Validation resume lo que falta o sobra en la configuración cargada.
+type Validation struct {
+	Path            string           `json:"path,omitempty"`
+	Problems        []string         `json:"problems"`
+	LegacyRedirects []LegacyRedirect `json:"legacy_redirects"`
+}
+
+type redirectField struct {
+	provider string
+	key      string
+	envVar   string
+}
+
+var redirectFields = []redirectField{
+	{provider: "twitch", key: "twitch_redirect_uri", envVar: "TWITCH_REDIRECT_URI"},
+	{provider: "kick", key: "kick_redirect_uri", envVar: "KICK_REDIRECT_URI"},
+}
+
+// Validate junta Problems y las redirect URIs heredadas.
+func (c *Config) Validate() Validation {
+	out := Validation{
+		Path:            ConfigFilePath(),
+		Problems:        c.Problems(),
+		LegacyRedirects: c.LegacyRedirects(),
+	}
+	if out.Problems == nil {
+		out.Problems = []string{}
+	}
+	return out
+}
+
+// LegacyRedirects devuelve las redirect URIs válidas que no apuntan a
+// localhost. Las vacías o mal formadas no cuentan (eso lo informa Problems).
+func (c *Config) LegacyRedirects() []LegacyRedirect {
+	out := []LegacyRedirect{}
+	if c == nil {
+		return out
+	}
+	for _, field := range redirectFields {
+		value := c.redirectURI(field.provider)
+		if !isLegacyRedirect(value) {
+			continue
+		}
+		out = append(out, LegacyRedirect{
+			Provider:  field.provider,
+			Key:       field.key,
+			Value:     value,
+			Suggested: LoopbackRedirectURI(field.provider),
+			FromEnv:   strings.TrimSpace(os.Getenv(field.envVar)) != "",
+		})
+	}
+	return out
+}
+
+// FixRedirectURIs reemplaza en config.json las redirect URIs heredadas por
+// las del loopback y actualiza c. Devuelve lo que cambió; las que vienen de
+// variables de entorno se devuelven sin tocar (FromEnv).
+func (c *Config) FixRedirectURIs() ([]LegacyRedirect, error) {
+	legacy := c.LegacyRedirects()
+	if len(legacy) == 0 {
+		return legacy, nil
+	}
+	path := ConfigFilePath()
+	if path == "" {
+		dir := configDir()
+		if dir == "" {
+			return nil, fmt.Errorf("config directory unavailable")
+		}
+		path = filepath.Join(dir, "config.json")
+		configFilePath = path
+	}
+
+	cfgCopy := fileConfig{}
+	if cachedFileConfig != nil {
+		cfgCopy = *cachedFileConfig
+	}
+	fixable := 0
+	for _, entry := range legacy {
+		if entry.FromEnv {
+			continue
+		}
+		setRedirect(&cfgCopy.TwitchRedirectURI, &cfgCopy.KickRedirectURI, entry)
+		fixable++
+	}
+	if fixable == 0 {
+		return legacy, nil
+	}
+
+	data, err := json.MarshalIndent(cfgCopy, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return nil, err
+	}
+	cachedFileConfig = &cfgCopy
+	for _, entry := range legacy {
+		if !entry.FromEnv {
+			setRedirect(&c.TwitchRedirectURI, &c.KickRedirectURI, entry)
+		}
+	}
+	return legacy, nil
+}
+
+func setRedirect(twitch, kick *string, entry LegacyRedirect) {
+	switch entry.Provider {
+	case "twitch":
+		*twitch = entry.Suggested
+	case "kick":
+		*kick = entry.Suggested
+	}
+}
+
+func (c *Config) redirectURI(provider string) string {
+	switch provider {
+	case "twitch":
+		return strings.TrimSpace(c.TwitchRedirectURI)
+	case "kick":
+		return strings.TrimSpace(c.KickRedirectURI)
+	}
+	return ""
+}
+
+func isLegacyRedirect(raw string) bool {
+	if raw == "" {
+		return false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return false
+	}
+	switch strings.ToLower(parsed.Hostname()) {
+	case "localhost", "127.0.0.1", "::1":
+		return false
+	}
+	return true
+}
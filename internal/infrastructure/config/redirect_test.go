@@ -0,0 +1,129 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const legacyTwitchRedirect = "https://dev.zdev.app/api/oauth/twitch/callback"
+
+// useConfigFile apunta el loader a un config.json temporal con ese contenido.
+func useConfigFile(t *testing.T, cfg fileConfig) string {
+	t.Helper()
+	prevPath, prevCached := configFilePath, cachedFileConfig
+	t.Cleanup(func() { configFilePath, cachedFileConfig = prevPath, prevCached })
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configFilePath = path
+	cachedFileConfig = &cfg
+	return path
+}
+
+func TestLegacyRedirects(t *testing.T) {
+	t.Setenv("TWITCH_REDIRECT_URI", "")
+	t.Setenv("KICK_REDIRECT_URI", "")
+
+	cases := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"dev domain", legacyTwitchRedirect, true},
+		{"any remote host", "http://example.com/callback", true},
+		{"loopback", LoopbackRedirectURI("twitch"), false},
+		{"loopback by ip", "http://127.0.0.1:9000/callback", false},
+		{"ipv6 loopback", "http://[::1]:17833/oauth/callback/twitch", false},
+		{"LOCALHOST uppercase", "http://LOCALHOST:17833/x", false},
+		{"empty", "", false},
+		{"malformed is a problem, not legacy", "dev.zdev.app/callback", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := (&Config{TwitchRedirectURI: tc.value}).LegacyRedirects()
+			if (len(got) == 1) != tc.want {
+				t.Fatalf("LegacyRedirects(%q) = %+v, want legacy=%v", tc.value, got, tc.want)
+			}
+			if tc.want && (got[0].Key != "twitch_redirect_uri" || got[0].Suggested != "http://localhost:17833/oauth/callback/twitch" || got[0].FromEnv) {
+				t.Fatalf("entry = %+v", got[0])
+			}
+		})
+	}
+}
+
+func TestFixRedirectURIsRewritesConfigFile(t *testing.T) {
+	t.Setenv("TWITCH_REDIRECT_URI", "")
+	t.Setenv("KICK_REDIRECT_URI", "")
+	path := useConfigFile(t, fileConfig{
+		TwitchClientID:    "client",
+		TwitchRedirectURI: legacyTwitchRedirect,
+		KickRedirectURI:   "https://dev.zdev.app/api/oauth/kick/callback",
+		DatabasePath:      "/data/bot.db",
+	})
+	cfg := &Config{
+		TwitchRedirectURI: legacyTwitchRedirect,
+		KickRedirectURI:   "https://dev.zdev.app/api/oauth/kick/callback",
+	}
+
+	fixed, err := cfg.FixRedirectURIs()
+	if err != nil {
+		t.Fatalf("FixRedirectURIs: %v", err)
+	}
+	if len(fixed) != 2 {
+		t.Fatalf("fixed = %+v", fixed)
+	}
+	if cfg.TwitchRedirectURI != LoopbackRedirectURI("twitch") || cfg.KickRedirectURI != LoopbackRedirectURI("kick") {
+		t.Fatalf("cfg not updated: %q, %q", cfg.TwitchRedirectURI, cfg.KickRedirectURI)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written fileConfig
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("config.json: %v", err)
+	}
+	want := fileConfig{
+		TwitchClientID:    "client",
+		TwitchRedirectURI: LoopbackRedirectURI("twitch"),
+		KickRedirectURI:   LoopbackRedirectURI("kick"),
+		DatabasePath:      "/data/bot.db",
+	}
+	if written != want {
+		t.Fatalf("config.json = %+v, want %+v", written, want)
+	}
+	if len(cfg.LegacyRedirects()) != 0 {
+		t.Fatal("still legacy after the fix")
+	}
+}
+
+func TestFixRedirectURIsLeavesEnvValues(t *testing.T) {
+	t.Setenv("TWITCH_REDIRECT_URI", legacyTwitchRedirect)
+	t.Setenv("KICK_REDIRECT_URI", "")
+	path := useConfigFile(t, fileConfig{TwitchClientID: "client"})
+	before, _ := os.ReadFile(path)
+	cfg := &Config{TwitchRedirectURI: legacyTwitchRedirect}
+
+	fixed, err := cfg.FixRedirectURIs()
+	if err != nil {
+		t.Fatalf("FixRedirectURIs: %v", err)
+	}
+	if len(fixed) != 1 || !fixed[0].FromEnv {
+		t.Fatalf("fixed = %+v", fixed)
+	}
+	if cfg.TwitchRedirectURI != legacyTwitchRedirect {
+		t.Fatalf("an env value was rewritten: %q", cfg.TwitchRedirectURI)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Fatalf("config.json changed: %s", after)
+	}
+}
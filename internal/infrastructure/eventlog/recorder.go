@@ -0,0 +1,270 @@
+// Package eventlog graba eventos clave en un archivo JSON-lines rotativo para
+// poder reconstruir qué pasó cuando un usuario reporta un problema.
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FileName es el archivo activo; los rotados llevan sufijo .1, .2, ...
+	FileName = "events.jsonl"
+
+	defaultMaxBytes   = 5 << 20
+	defaultMaxBackups = 2
+
+	redacted = "[redacted]"
+)
+
+// secretFieldMarkers: los campos cuyo nombre contiene alguno de estos
+// fragmentos nunca se escriben.
+var secretFieldMarkers = []string{"token", "secret", "password", "authorization", "cookie", "api_key", "apikey"}
+
+// tokenPattern detecta tokens sueltos dentro de textos (oauth:..., Bearer ...).
+var tokenPattern = regexp.MustCompile(`(?i)(oauth:|bearer\s+)[a-z0-9._\-]+`)
+
+// Recorder escribe un evento por línea. Mientras está desactivado Record no
+// hace nada.
+type Recorder struct {
+	dir        string
+	maxBytes   int64
+	maxBackups int
+
+	mu      sync.Mutex
+	enabled bool
+	file    *os.File
+	size    int64
+	now     func() time.Time
+}
+
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{
+		dir:        dir,
+		maxBytes:   defaultMaxBytes,
+		maxBackups: defaultMaxBackups,
+		now:        time.Now,
+	}
+}
+
+// SetLimits cambia el tamaño máximo por archivo y cuántos archivos rotados se guardan.
+func (r *Recorder) SetLimits(maxBytes int64, maxBackups int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if maxBytes > 0 {
+		r.maxBytes = maxBytes
+	}
+	if maxBackups >= 0 {
+		r.maxBackups = maxBackups
+	}
+}
+
+func (r *Recorder) SetEnabled(enabled bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+	if !enabled {
+		r.closeLocked()
+	}
+}
+
+func (r *Recorder) Enabled() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// Record agrega un evento. Los campos con nombre sensible se omiten y los
+// textos pasan por Redact.
+func (r *Recorder) Record(kind string, fields map[string]any) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+
+	entry := make(map[string]any, len(fields)+2)
+	for key, value := range fields {
+		entry[key] = redactValue(key, value)
+	}
+	entry["ts"] = r.now().UTC().Format(time.RFC3339Nano)
+	entry["kind"] = kind
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("eventlog: %v", err)
+		return
+	}
+	line = append(line, '\n')
+	if err := r.writeLocked(line); err != nil {
+		log.Printf("eventlog: %v", err)
+	}
+}
+
+// Files devuelve los archivos de eventos existentes, del más viejo al más nuevo.
+func (r *Recorder) Files() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		_ = r.file.Sync()
+	}
+	var files []string
+	for i := r.maxBackups; i >= 1; i-- {
+		path := r.backupPath(i)
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	if _, err := os.Stat(r.path()); err == nil {
+		files = append(files, r.path())
+	}
+	return files
+}
+
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeLocked()
+}
+
+func (r *Recorder) path() string {
+	return filepath.Join(r.dir, FileName)
+}
+
+func (r *Recorder) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path(), n)
+}
+
+func (r *Recorder) writeLocked(line []byte) error {
+	if r.file == nil {
+		if err := r.openLocked(); err != nil {
+			return err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(line)) > r.maxBytes {
+		if err := r.rotateLocked(); err != nil {
+			return err
+		}
+	}
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	return err
+}
+
+func (r *Recorder) openLocked() error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.path(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *Recorder) rotateLocked() error {
+	if err := r.closeLocked(); err != nil {
+		return err
+	}
+	if r.maxBackups == 0 {
+		if err := os.Remove(r.path()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return r.openLocked()
+	}
+	_ = os.Remove(r.backupPath(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backupPath(i), r.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path(), r.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return r.openLocked()
+}
+
+func (r *Recorder) closeLocked() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	r.size = 0
+	return err
+}
+
+// IsSecretField indica si un campo nunca debe grabarse.
+func IsSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact oculta tokens que aparezcan dentro de un texto.
+func Redact(text string) string {
+	return tokenPattern.ReplaceAllString(text, "${1}"+redacted)
+}
+
+func redactValue(key string, value any) any {
+	if IsSecretField(key) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, inner := range v {
+			out[k] = redactValue(k, inner)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(v))
+		for k, inner := range v {
+			if IsSecretField(k) {
+				out[k] = redacted
+			} else {
+				out[k] = Redact(inner)
+			}
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,156 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer file.Close()
+	var out []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(dir)
+	rec.SetEnabled(true)
+	defer rec.Close()
+
+	rec.Record("credential", map[string]any{
+		"access_token":  "abc123",
+		"Client_Secret": "s3cr3t",
+		"note":          "PASS oauth:abc123 y Bearer xyz789",
+		"error":         errors.New("refresh failed: bearer xyz789"),
+		"payload": map[string]any{
+			"refresh_token": "r3fr3sh",
+			"login":         "zero",
+		},
+		"headers": map[string]string{"Authorization": "Bearer xyz789", "Accept": "json"},
+	})
+
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	for _, secret := range []string{"abc123", "s3cr3t", "xyz789", "r3fr3sh"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("secret %q leaked: %s", secret, data)
+		}
+	}
+
+	entries := readLines(t, filepath.Join(dir, FileName))
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry["kind"] != "credential" || entry["ts"] == "" {
+		t.Fatalf("entry = %v", entry)
+	}
+	if entry["note"] != "PASS oauth:[redacted] y Bearer [redacted]" {
+		t.Fatalf("note = %q", entry["note"])
+	}
+	if payload := entry["payload"].(map[string]any); payload["login"] != "zero" {
+		t.Fatalf("non-secret nested field lost: %v", payload)
+	}
+}
+
+func TestRecordDisabledWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(dir)
+
+	rec.Record("message", map[string]any{"user": "zero"})
+	if files := rec.Files(); len(files) != 0 {
+		t.Fatalf("disabled recorder wrote %v", files)
+	}
+
+	var nilRec *Recorder
+	nilRec.Record("message", nil)
+	if nilRec.Enabled() || nilRec.Files() != nil || nilRec.Close() != nil {
+		t.Fatal("nil recorder should be a no-op")
+	}
+}
+
+func TestRecordRotatesAtSizeCap(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(dir)
+	rec.SetLimits(200, 2)
+	rec.SetEnabled(true)
+	defer rec.Close()
+
+	for i := 0; i < 40; i++ {
+		rec.Record("message", map[string]any{"n": i, "user": "zero"})
+	}
+
+	files := rec.Files()
+	want := []string{
+		filepath.Join(dir, FileName+".2"),
+		filepath.Join(dir, FileName+".1"),
+		filepath.Join(dir, FileName),
+	}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("files = %v, want %v", files, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FileName+".3")); !os.IsNotExist(err) {
+		t.Fatal("more backups than configured were kept")
+	}
+
+	last := -1
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 200 {
+			t.Fatalf("%s is %d bytes, cap is 200", path, info.Size())
+		}
+		// los archivos van del más viejo al más nuevo
+		for _, entry := range readLines(t, path) {
+			n := int(entry["n"].(float64))
+			if n <= last {
+				t.Fatalf("entry %d after %d", n, last)
+			}
+			last = n
+		}
+	}
+	if last != 39 {
+		t.Fatalf("newest entry = %d, want 39", last)
+	}
+}
+
+func TestRecordRotationWithoutBackups(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(dir)
+	rec.SetLimits(120, 0)
+	rec.SetEnabled(true)
+	defer rec.Close()
+
+	for i := 0; i < 10; i++ {
+		rec.Record("message", map[string]any{"n": i})
+	}
+	if files := rec.Files(); len(files) != 1 {
+		t.Fatalf("files = %v, want only the active file", files)
+	}
+	entries := readLines(t, filepath.Join(dir, FileName))
+	if n := int(entries[len(entries)-1]["n"].(float64)); n != 9 {
+		t.Fatalf("last entry = %d, want 9", n)
+	}
+}
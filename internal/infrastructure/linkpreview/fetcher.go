@@ -0,0 +1,266 @@
+// Package linkpreview descarga los metadatos OpenGraph/oEmbed de los links
+// del chat. Solo visita los hosts permitidos (también en las redirecciones),
+// corta a los 5 segundos y no lee más de MaxBodySize bytes.
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// MaxBodySize es lo máximo que se lee de una página; los meta tags van en
+	// el <head>, así que alcanza de sobra.
+	MaxBodySize  = 512 << 10
+	fetchTimeout = 5 * time.Second
+	maxRedirects = 5
+	maxFieldLen  = 300
+	userAgent    = "zhatBot-linkpreview/1.0"
+)
+
+var (
+	ErrHostNotAllowed = errors.New("linkpreview: host fuera de la lista permitida")
+	ErrNoMetadata     = errors.New("linkpreview: la página no tiene metadatos")
+)
+
+// Fetcher implementa domain.LinkPreviewFetcher.
+type Fetcher struct {
+	client *http.Client
+	// oembed devuelve el endpoint oEmbed para el host, o "" si no tiene.
+	oembed func(host string) string
+}
+
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		client: &http.Client{Timeout: fetchTimeout},
+		oembed: youtubeOEmbed,
+	}
+}
+
+// FetchLinkPreview usa oEmbed si el sitio lo tiene y si no lee los meta tags
+// OpenGraph de la página.
+func (f *Fetcher) FetchLinkPreview(ctx context.Context, rawURL string, allowed func(host string) bool) (*domain.LinkPreview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("linkpreview: url inválida: %w", err)
+	}
+	if err := checkURL(target, allowed); err != nil {
+		return nil, err
+	}
+
+	client := f.clientFor(allowed)
+	if endpoint := f.oembedEndpoint(target.Hostname()); endpoint != "" {
+		return f.fetchOEmbed(ctx, client, endpoint, target, allowed)
+	}
+	return f.fetchPage(ctx, client, target)
+}
+
+// clientFor copia el cliente con un CheckRedirect que no sale de allowed.
+func (f *Fetcher) clientFor(allowed func(host string) bool) *http.Client {
+	client := *f.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("linkpreview: demasiadas redirecciones")
+		}
+		return checkURL(req.URL, allowed)
+	}
+	return &client
+}
+
+func (f *Fetcher) oembedEndpoint(host string) string {
+	if f.oembed == nil {
+		return ""
+	}
+	return f.oembed(host)
+}
+
+func (f *Fetcher) fetchPage(ctx context.Context, client *http.Client, target *url.URL) (*domain.LinkPreview, error) {
+	body, err := get(ctx, client, target.String(), "text/html")
+	if err != nil {
+		return nil, err
+	}
+	preview := parseMeta(body)
+	if preview.Title == "" {
+		return nil, ErrNoMetadata
+	}
+	preview.ImageURL = absoluteURL(target, preview.ImageURL)
+	return preview, nil
+}
+
+type oembedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func (f *Fetcher) fetchOEmbed(ctx context.Context, client *http.Client, endpoint string, target *url.URL, allowed func(host string) bool) (*domain.LinkPreview, error) {
+	oembedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkURL(oembedURL, allowed); err != nil {
+		return nil, err
+	}
+	query := oembedURL.Query()
+	query.Set("url", target.String())
+	query.Set("format", "json")
+	oembedURL.RawQuery = query.Encode()
+
+	body, err := get(ctx, client, oembedURL.String(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var payload oembedResponse
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("linkpreview: oembed inválido: %w", err)
+	}
+	if strings.TrimSpace(payload.Title) == "" {
+		return nil, ErrNoMetadata
+	}
+	return &domain.LinkPreview{
+		Title:       clip(payload.Title),
+		Description: clip(payload.AuthorName),
+		ImageURL:    payload.ThumbnailURL,
+		SiteName:    clip(payload.ProviderName),
+	}, nil
+}
+
+// get descarga rawURL leyendo como máximo MaxBodySize bytes.
+func get(ctx context.Context, client *http.Client, rawURL, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("linkpreview: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("linkpreview: status %d", resp.StatusCode)
+	}
+	if accept == "text/html" {
+		mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+			return nil, fmt.Errorf("linkpreview: tipo de contenido %q", mediaType)
+		}
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+}
+
+func checkURL(u *url.URL, allowed func(host string) bool) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("linkpreview: esquema %q no permitido", u.Scheme)
+	}
+	if allowed == nil || !allowed(u.Hostname()) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Hostname())
+	}
+	return nil
+}
+
+var (
+	metaTagRe   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributeRe = regexp.MustCompile(`(?is)([a-z_:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	titleTagRe  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// parseMeta lee los meta tags og:* (y twitter:* como respaldo). No es un
+// parser de HTML completo, pero alcanza para el <head> de estos sitios.
+func parseMeta(body []byte) *domain.LinkPreview {
+	values := make(map[string]string)
+	for _, tag := range metaTagRe.FindAll(body, -1) {
+		var key, content string
+		for _, attr := range attributeRe.FindAllSubmatch(tag, -1) {
+			name := strings.ToLower(string(attr[1]))
+			value := string(attr[2]) + string(attr[3]) + string(attr[4])
+			switch name {
+			case "property", "name":
+				key = strings.ToLower(strings.TrimSpace(value))
+			case "content":
+				content = value
+			}
+		}
+		if key == "" || content == "" {
+			continue
+		}
+		if _, seen := values[key]; !seen {
+			values[key] = html.UnescapeString(strings.TrimSpace(content))
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := values[key]; value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	preview := &domain.LinkPreview{
+		Title:       first("og:title", "twitter:title"),
+		Description: first("og:description", "twitter:description", "description"),
+		ImageURL:    first("og:image", "og:image:url", "twitter:image"),
+		SiteName:    first("og:site_name"),
+	}
+	if preview.Title == "" {
+		if match := titleTagRe.FindSubmatch(body); match != nil {
+			preview.Title = html.UnescapeString(strings.TrimSpace(string(match[1])))
+		}
+	}
+	preview.Title = clip(preview.Title)
+	preview.Description = clip(preview.Description)
+	preview.SiteName = clip(preview.SiteName)
+	return preview
+}
+
+// absoluteURL resuelve imágenes relativas y descarta las que no son http(s).
+func absoluteURL(base *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
+
+func clip(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= maxFieldLen {
+		return value
+	}
+	return string(runes[:maxFieldLen-1]) + "…"
+}
+
+func youtubeOEmbed(host string) string {
+	host = strings.ToLower(host)
+	if host == "youtu.be" || host == "youtube.com" || strings.HasSuffix(host, ".youtube.com") {
+		return "https://www.youtube.com/oembed"
+	}
+	return ""
+}
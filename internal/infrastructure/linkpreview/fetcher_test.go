@@ -0,0 +1,186 @@
+package linkpreview
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const ogPage = `<!doctype html><html><head>
+<title>Título de respaldo</title>
+<meta property="og:title" content="Clip &amp; jugada">
+<meta property="og:description" content="  El mejor   momento  ">
+<meta property="og:image" content="/thumbs/1.jpg">
+<meta property="og:site_name" content="Twitch">
+</head><body></body></html>`
+
+// allowOnly permite solo el host del servidor de prueba.
+func allowOnly(srv *httptest.Server) func(host string) bool {
+	u, _ := url.Parse(srv.URL)
+	return func(host string) bool { return host == u.Hostname() }
+}
+
+func newTestFetcher() *Fetcher {
+	f := NewFetcher()
+	f.oembed = nil
+	return f
+}
+
+func TestFetchOpenGraph(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, ogPage)
+	}))
+	defer srv.Close()
+
+	preview, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL+"/clip/1", allowOnly(srv))
+	if err != nil {
+		t.Fatalf("FetchLinkPreview: %v", err)
+	}
+	if preview.Title != "Clip & jugada" {
+		t.Errorf("Title = %q", preview.Title)
+	}
+	if preview.Description != "El mejor momento" {
+		t.Errorf("Description = %q", preview.Description)
+	}
+	if preview.SiteName != "Twitch" {
+		t.Errorf("SiteName = %q", preview.SiteName)
+	}
+	if want := srv.URL + "/thumbs/1.jpg"; preview.ImageURL != want {
+		t.Errorf("ImageURL = %q, esperaba %q", preview.ImageURL, want)
+	}
+}
+
+func TestFetchFallsBackToTitleTag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title> Solo título </title><meta property="og:image" content="javascript:alert(1)"></head></html>`)
+	}))
+	defer srv.Close()
+
+	preview, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL, allowOnly(srv))
+	if err != nil {
+		t.Fatalf("FetchLinkPreview: %v", err)
+	}
+	if preview.Title != "Solo título" {
+		t.Errorf("Title = %q", preview.Title)
+	}
+	if preview.ImageURL != "" {
+		t.Errorf("ImageURL = %q, una imagen javascript: debería descartarse", preview.ImageURL)
+	}
+}
+
+func TestFetchCapsOversizedResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		// los meta tags quedan después del límite: no deberían leerse
+		fmt.Fprint(w, "<html><head>")
+		fmt.Fprint(w, strings.Repeat("<!-- relleno -->", MaxBodySize/16+1))
+		fmt.Fprint(w, ogPage)
+		// y el resto nunca termina de leerse
+		fmt.Fprint(w, strings.Repeat("x", 4*MaxBodySize))
+	}))
+	defer srv.Close()
+
+	_, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL, allowOnly(srv))
+	if !errors.Is(err, ErrNoMetadata) {
+		t.Fatalf("err = %v, esperaba ErrNoMetadata", err)
+	}
+}
+
+func TestFetchClipsHugeFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<meta property="og:title" content="%s">`, strings.Repeat("á", 10000))
+	}))
+	defer srv.Close()
+
+	preview, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL, allowOnly(srv))
+	if err != nil {
+		t.Fatalf("FetchLinkPreview: %v", err)
+	}
+	if n := len([]rune(preview.Title)); n != maxFieldLen {
+		t.Fatalf("el título quedó con %d runas, esperaba %d", n, maxFieldLen)
+	}
+	if !strings.HasSuffix(preview.Title, "…") {
+		t.Fatalf("el título recortado no termina en …")
+	}
+}
+
+func TestFetchRejectsNonHTML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, ogPage)
+	}))
+	defer srv.Close()
+
+	if _, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL, allowOnly(srv)); err == nil {
+		t.Fatal("se aceptó una respuesta que no es HTML")
+	}
+}
+
+func TestFetchRefusesRedirectOffAllowlist(t *testing.T) {
+	var visited atomic.Bool
+	outside := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		visited.Store(true)
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, ogPage)
+	}))
+	defer outside.Close()
+	// mismo puerto pero otro host: 127.0.0.1 está permitido, localhost no
+	outsideURL := strings.Replace(outside.URL, "127.0.0.1", "localhost", 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, outsideURL, http.StatusFound)
+	}))
+	defer srv.Close()
+
+	_, err := newTestFetcher().FetchLinkPreview(t.Context(), srv.URL, allowOnly(srv))
+	if !errors.Is(err, ErrHostNotAllowed) {
+		t.Fatalf("err = %v, esperaba ErrHostNotAllowed", err)
+	}
+	if visited.Load() {
+		t.Fatal("se siguió la redirección fuera de la lista")
+	}
+}
+
+func TestFetchRejectsDisallowedURLs(t *testing.T) {
+	allowed := func(host string) bool { return host == "youtube.com" }
+	for _, raw := range []string{"https://evil.example/x", "ftp://youtube.com/x", "file:///etc/passwd"} {
+		if _, err := newTestFetcher().FetchLinkPreview(t.Context(), raw, allowed); err == nil {
+			t.Errorf("%s: se aceptó", raw)
+		}
+	}
+}
+
+func TestFetchOEmbed(t *testing.T) {
+	var gotURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oembed" {
+			t.Errorf("se pidió %s en vez del endpoint oEmbed", r.URL.Path)
+		}
+		gotURL = r.URL.Query().Get("url")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"title":"Video","author_name":"Zero","provider_name":"YouTube","thumbnail_url":"https://i.ytimg.com/1.jpg"}`)
+	}))
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.oembed = func(string) string { return srv.URL + "/oembed" }
+	link := srv.URL + "/watch?v=abc"
+	preview, err := f.FetchLinkPreview(t.Context(), link, allowOnly(srv))
+	if err != nil {
+		t.Fatalf("FetchLinkPreview: %v", err)
+	}
+	if gotURL != link {
+		t.Errorf("url = %q, esperaba %q", gotURL, link)
+	}
+	if preview.Title != "Video" || preview.Description != "Zero" || preview.SiteName != "YouTube" {
+		t.Errorf("preview = %+v", preview)
+	}
+}
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestCountNotifications(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if n, err := store.CountNotifications(ctx, domain.NotificationFilter{}); err != nil || n != 0 {
+		t.Fatalf("empty count = %d, %v", n, err)
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	seed := []*domain.Notification{
+		{Type: domain.NotificationSubscription, Platform: domain.PlatformTwitch, Username: "ana", CreatedAt: old},
+		{Type: domain.NotificationSubscription, Platform: domain.PlatformKick, Username: "luis"},
+		{Type: domain.NotificationBits, Platform: domain.PlatformTwitch, Username: "eva", Amount: 100},
+		{Type: domain.NotificationBits, Platform: domain.PlatformTwitch, Username: "zhatbot_test",
+			Metadata: map[string]string{domain.NotificationTestKey: "true"}},
+		{Type: domain.NotificationDonation, Platform: domain.PlatformKick, Username: "max",
+			Metadata: map[string]string{"note": "test"}},
+	}
+	for _, n := range seed {
+		if _, err := store.SaveNotification(ctx, n); err != nil {
+			t.Fatalf("SaveNotification: %v", err)
+		}
+	}
+
+	cases := []struct {
+		name   string
+		filter domain.NotificationFilter
+		want   int
+	}{
+		{"no filter", domain.NotificationFilter{}, 5},
+		{"by type", domain.NotificationFilter{Type: domain.NotificationBits}, 2},
+		{"by platform", domain.NotificationFilter{Platform: domain.PlatformKick}, 2},
+		{"type and platform", domain.NotificationFilter{Type: domain.NotificationSubscription, Platform: domain.PlatformTwitch}, 1},
+		{"since", domain.NotificationFilter{Since: time.Now().Add(-time.Hour)}, 4},
+		{"exclude test", domain.NotificationFilter{ExcludeTest: true}, 4},
+		{"all filters", domain.NotificationFilter{Type: domain.NotificationBits, Platform: domain.PlatformTwitch, Since: time.Now().Add(-time.Hour), ExcludeTest: true}, 1},
+		{"no match", domain.NotificationFilter{Type: domain.NotificationFollow}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := store.CountNotifications(ctx, tc.filter)
+			if err != nil {
+				t.Fatalf("CountNotifications: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("count = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountCustomCommands(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if n, err := store.CountCustomCommands(ctx); err != nil || n != 0 {
+		t.Fatalf("empty count = %d, %v", n, err)
+	}
+	for _, name := range []string{"discord", "redes", "horario"} {
+		if err := store.UpsertCustomCommand(ctx, &domain.CustomCommand{Name: name, Response: "hola"}); err != nil {
+			t.Fatalf("UpsertCustomCommand: %v", err)
+		}
+	}
+	// actualizar no suma
+	if err := store.UpsertCustomCommand(ctx, &domain.CustomCommand{Name: "discord", Response: "otro"}); err != nil {
+		t.Fatalf("UpsertCustomCommand: %v", err)
+	}
+	if err := store.DeleteCustomCommand(ctx, "redes"); err != nil {
+		t.Fatalf("DeleteCustomCommand: %v", err)
+	}
+	if n, err := store.CountCustomCommands(ctx); err != nil || n != 2 {
+		t.Fatalf("count = %d, %v; want 2", n, err)
+	}
+}
@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestCounterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	at := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	if err := store.SaveCounter(ctx, &domain.Counter{Name: "muertes", Value: 3, UpdatedAt: at}); err != nil {
+		t.Fatalf("SaveCounter: %v", err)
+	}
+	// guardar de nuevo reemplaza el valor
+	if err := store.SaveCounter(ctx, &domain.Counter{Name: "muertes", Value: -2, UpdatedAt: at}); err != nil {
+		t.Fatalf("SaveCounter: %v", err)
+	}
+	list, err := store.ListCounters(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListCounters = %+v, %v", list, err)
+	}
+	if list[0].Name != "muertes" || list[0].Value != -2 || !list[0].UpdatedAt.Equal(at) {
+		t.Fatalf("counter = %+v", list[0])
+	}
+
+	if ok, err := store.DeleteCounter(ctx, "muertes"); err != nil || !ok {
+		t.Fatalf("DeleteCounter = %v, %v", ok, err)
+	}
+	if ok, err := store.DeleteCounter(ctx, "muertes"); err != nil || ok {
+		t.Fatalf("DeleteCounter(missing) = %v, %v", ok, err)
+	}
+}
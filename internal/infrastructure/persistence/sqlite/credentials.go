@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +44,29 @@ func NewCredentialStore(dbPath string) (*CredentialStore, error) {
 	return &CredentialStore{db: db}, nil
 }
 
+// OpenCredentialStoreReadOnly abre una base existente sin migrarla ni
+// escribir en ella (lo usa `bot -check`).
+func OpenCredentialStoreReadOnly(dbPath string) (*CredentialStore, error) {
+	if dbPath == "" {
+		return nil, fmt.Errorf("sqlite: empty db path")
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+filepath.ToSlash(dbPath)+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: open: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite: ping: %w", err)
+	}
+	return &CredentialStore{db: db}, nil
+}
+
 func migrate(db *sql.DB) error {
 	const schema = `
 CREATE TABLE IF NOT EXISTS credentials (
@@ -84,6 +108,31 @@ CREATE TABLE IF NOT EXISTS custom_commands (
 			return fmt.Errorf("sqlite: add permissions column: %w", err)
 		}
 	}
+	if _, err := db.Exec(`ALTER TABLE custom_commands ADD COLUMN cooldown_seconds INTEGER NOT NULL DEFAULT 0;`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("sqlite: add cooldown_seconds column: %w", err)
+		}
+	}
+	if _, err := db.Exec(`ALTER TABLE custom_commands ADD COLUMN user_cooldown_seconds INTEGER NOT NULL DEFAULT 0;`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("sqlite: add user_cooldown_seconds column: %w", err)
+		}
+	}
+	if _, err := db.Exec(`ALTER TABLE custom_commands ADD COLUMN cooldown_feedback TEXT;`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("sqlite: add cooldown_feedback column: %w", err)
+		}
+	}
+	if _, err := db.Exec(`ALTER TABLE custom_commands ADD COLUMN permission_reply TEXT;`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("sqlite: add permission_reply column: %w", err)
+		}
+	}
+	if _, err := db.Exec(`ALTER TABLE custom_commands ADD COLUMN responses TEXT;`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+			return fmt.Errorf("sqlite: add responses column: %w", err)
+		}
+	}
 
 	const settingsTable = `
 CREATE TABLE IF NOT EXISTS settings (
@@ -113,6 +162,130 @@ CREATE INDEX IF NOT EXISTS idx_notifications_created_at ON notifications(created
 		return fmt.Errorf("sqlite: migrate notifications: %w", err)
 	}
 
+	const trackersTable = `
+CREATE TABLE IF NOT EXISTS word_trackers (
+	name TEXT PRIMARY KEY,
+	term TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	milestone_every INTEGER NOT NULL DEFAULT 100,
+	enabled INTEGER NOT NULL DEFAULT 1,
+	session_id TEXT,
+	count INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP NOT NULL
+);`
+
+	if _, err := db.Exec(trackersTable); err != nil {
+		return fmt.Errorf("sqlite: migrate word_trackers: %w", err)
+	}
+
+	const userNotesTable = `
+CREATE TABLE IF NOT EXISTS user_notes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	platform TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	login TEXT,
+	note TEXT NOT NULL,
+	author TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_user_notes_user ON user_notes(platform, user_id);`
+
+	if _, err := db.Exec(userNotesTable); err != nil {
+		return fmt.Errorf("sqlite: migrate user_notes: %w", err)
+	}
+
+	const chatterStatsTable = `
+CREATE TABLE IF NOT EXISTS chatter_stats (
+	scope TEXT NOT NULL,
+	platform TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	name TEXT,
+	session_id TEXT,
+	messages INTEGER NOT NULL DEFAULT 0,
+	emotes INTEGER NOT NULL DEFAULT 0,
+	last_seen TIMESTAMP,
+	PRIMARY KEY (scope, platform, user_id)
+);`
+
+	if _, err := db.Exec(chatterStatsTable); err != nil {
+		return fmt.Errorf("sqlite: migrate chatter_stats: %w", err)
+	}
+
+	const countersTable = `
+CREATE TABLE IF NOT EXISTS counters (
+	name TEXT PRIMARY KEY,
+	value INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+
+	if _, err := db.Exec(countersTable); err != nil {
+		return fmt.Errorf("sqlite: migrate counters: %w", err)
+	}
+
+	// AUTOINCREMENT para que los números de las citas borradas no se reusen.
+	const quotesTable = `
+CREATE TABLE IF NOT EXISTS quotes (
+	number INTEGER PRIMARY KEY AUTOINCREMENT,
+	text TEXT NOT NULL,
+	added_by TEXT,
+	platform TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+
+	if _, err := db.Exec(quotesTable); err != nil {
+		return fmt.Errorf("sqlite: migrate quotes: %w", err)
+	}
+
+	const ttsHistoryTable = `
+CREATE TABLE IF NOT EXISTS tts_history (
+	id TEXT NOT NULL,
+	text TEXT NOT NULL,
+	requested_by TEXT,
+	platform TEXT,
+	channel_id TEXT,
+	voice TEXT,
+	outcome TEXT NOT NULL,
+	error TEXT,
+	finished_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tts_history_finished_at ON tts_history(finished_at DESC);`
+
+	if _, err := db.Exec(ttsHistoryTable); err != nil {
+		return fmt.Errorf("sqlite: migrate tts_history: %w", err)
+	}
+
+	const rawEventsTable = `
+CREATE TABLE IF NOT EXISTS raw_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	platform TEXT NOT NULL,
+	event_type TEXT,
+	payload TEXT NOT NULL,
+	received_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_raw_events_received_at ON raw_events(received_at);`
+
+	if _, err := db.Exec(rawEventsTable); err != nil {
+		return fmt.Errorf("sqlite: migrate raw_events: %w", err)
+	}
+
+	// el ranking histórico ya sabe cuándo escribió cada uno por última vez;
+	// se copia para no empezar de cero
+	const chatUsersTable = `
+CREATE TABLE IF NOT EXISTS chat_users (
+	platform TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	name TEXT,
+	last_seen TIMESTAMP NOT NULL,
+	PRIMARY KEY (platform, user_id)
+);
+INSERT OR IGNORE INTO chat_users (platform, user_id, name, last_seen)
+SELECT platform, user_id, name, last_seen FROM chatter_stats
+WHERE scope = 'all_time' AND last_seen IS NOT NULL;`
+
+	if _, err := db.Exec(chatUsersTable); err != nil {
+		return fmt.Errorf("sqlite: migrate chat_users: %w", err)
+	}
+
 	return nil
 }
 
@@ -131,6 +304,8 @@ WHERE platform = ? AND role = ?
 LIMIT 1;
 `
 
+	platform = domain.NormalizeCredentialPlatform(platform)
+	role = domain.NormalizeCredentialRole(platform, role)
 	row := s.db.QueryRowContext(ctx, query, string(platform), role)
 
 	var accessToken, refreshToken, metadata sql.NullString
@@ -158,6 +333,8 @@ func (s *CredentialStore) Save(ctx context.Context, cred *domain.Credential) err
 	if cred == nil {
 		return fmt.Errorf("sqlite: credential nil")
 	}
+	cred.Platform = domain.NormalizeCredentialPlatform(cred.Platform)
+	cred.Role = domain.NormalizeCredentialRole(cred.Platform, cred.Role)
 
 	now := time.Now().UTC()
 	if cred.UpdatedAt.IsZero() {
@@ -237,6 +414,8 @@ func (s *CredentialStore) Delete(ctx context.Context, platform domain.Platform,
 	if s.db == nil {
 		return fmt.Errorf("sqlite: db no inicializada")
 	}
+	platform = domain.NormalizeCredentialPlatform(platform)
+	role = domain.NormalizeCredentialRole(platform, role)
 	_, err := s.db.ExecContext(ctx, `DELETE FROM credentials WHERE platform = ? AND role = ?`, string(platform), role)
 	if err != nil {
 		return fmt.Errorf("sqlite: delete credential: %w", err)
@@ -244,6 +423,103 @@ func (s *CredentialStore) Delete(ctx context.Context, platform domain.Platform,
 	return nil
 }
 
+// CredentialMerge describe filas de credenciales que ReconcileCredentials
+// unificó bajo (Platform, Role).
+type CredentialMerge struct {
+	Platform domain.Platform
+	Role     string
+	// Kept es el rol original de la fila que se conservó (la más reciente).
+	Kept string
+	// Removed son los roles originales de las filas borradas.
+	Removed []string
+}
+
+type credentialRow struct {
+	rowID     int64
+	platform  string
+	role      string
+	updatedAt time.Time
+}
+
+// ReconcileCredentials normaliza la plataforma y el rol de las filas guardadas
+// con versiones viejas (mayúsculas, espacios, "bot" en Kick). Si quedan varias
+// filas para el mismo (plataforma, rol) conserva la más reciente y borra el
+// resto. Devuelve lo que cambió.
+func (s *CredentialStore) ReconcileCredentials(ctx context.Context) ([]CredentialMerge, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: reconcile credentials: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT rowid, platform, role, updated_at FROM credentials;`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: reconcile credentials: %w", err)
+	}
+	type groupKey struct {
+		platform domain.Platform
+		role     string
+	}
+	groups := make(map[groupKey][]credentialRow)
+	var order []groupKey
+	for rows.Next() {
+		var row credentialRow
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&row.rowID, &row.platform, &row.role, &updatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("sqlite: scan credential: %w", err)
+		}
+		row.updatedAt = updatedAt.Time
+		platform := domain.NormalizeCredentialPlatform(domain.Platform(row.platform))
+		key := groupKey{platform: platform, role: domain.NormalizeCredentialRole(platform, row.role)}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("sqlite: reconcile rows error: %w", err)
+	}
+	rows.Close()
+
+	var merges []CredentialMerge
+	for _, key := range order {
+		group := groups[key]
+		kept := group[0]
+		for _, row := range group[1:] {
+			if row.updatedAt.After(kept.updatedAt) {
+				kept = row
+			}
+		}
+		if len(group) == 1 && kept.platform == string(key.platform) && kept.role == key.role {
+			continue
+		}
+
+		merge := CredentialMerge{Platform: key.platform, Role: key.role, Kept: kept.role}
+		// primero se borran las demás para que el rename no choque con la clave primaria
+		for _, row := range group {
+			if row.rowID == kept.rowID {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM credentials WHERE rowid = ?`, row.rowID); err != nil {
+				return nil, fmt.Errorf("sqlite: delete duplicate credential: %w", err)
+			}
+			merge.Removed = append(merge.Removed, row.role)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE credentials SET platform = ?, role = ? WHERE rowid = ?`,
+			string(key.platform), key.role, kept.rowID); err != nil {
+			return nil, fmt.Errorf("sqlite: normalize credential: %w", err)
+		}
+		merges = append(merges, merge)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("sqlite: reconcile credentials: %w", err)
+	}
+	return merges, nil
+}
+
 func nullTime(t time.Time) interface{} {
 	if t.IsZero() {
 		return nil
@@ -289,13 +565,18 @@ func (s *CredentialStore) UpsertCustomCommand(ctx context.Context, cmd *domain.C
 	}
 
 	const stmt = `
-INSERT INTO custom_commands (name, response, aliases, platforms, permissions, updated_at)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO custom_commands (name, response, responses, aliases, platforms, permissions, cooldown_seconds, user_cooldown_seconds, cooldown_feedback, permission_reply, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(name) DO UPDATE SET
 	response=excluded.response,
+	responses=excluded.responses,
 	aliases=excluded.aliases,
 	platforms=excluded.platforms,
 	permissions=excluded.permissions,
+	cooldown_seconds=excluded.cooldown_seconds,
+	user_cooldown_seconds=excluded.user_cooldown_seconds,
+	cooldown_feedback=excluded.cooldown_feedback,
+	permission_reply=excluded.permission_reply,
 	updated_at=excluded.updated_at;
 `
 
@@ -304,9 +585,14 @@ ON CONFLICT(name) DO UPDATE SET
 		stmt,
 		cmd.Name,
 		cmd.Response,
+		encodeResponses(cmd.Responses),
 		encodeStringSlice(cmd.Aliases),
 		encodePlatforms(cmd.Platforms),
 		encodePermissions(cmd.Permissions),
+		int64(cmd.Cooldown/time.Second),
+		int64(cmd.UserCooldown/time.Second),
+		string(cmd.CooldownFeedback),
+		string(cmd.PermissionReply),
 		cmd.UpdatedAt,
 	)
 	if err != nil {
@@ -318,7 +604,7 @@ ON CONFLICT(name) DO UPDATE SET
 
 func (s *CredentialStore) GetCustomCommand(ctx context.Context, name string) (*domain.CustomCommand, error) {
 	const query = `
-SELECT name, response, aliases, platforms, permissions, updated_at
+SELECT name, response, responses, aliases, platforms, permissions, cooldown_seconds, user_cooldown_seconds, cooldown_feedback, permission_reply, updated_at
 FROM custom_commands
 WHERE LOWER(name) = LOWER(?)
 LIMIT 1;
@@ -327,19 +613,25 @@ LIMIT 1;
 	row := s.db.QueryRowContext(ctx, query, name)
 
 	var record domain.CustomCommand
-	var aliasesRaw, platformsRaw, permissionsRaw sql.NullString
+	var responsesRaw, aliasesRaw, platformsRaw, permissionsRaw, feedbackRaw, permissionReplyRaw sql.NullString
+	var cooldownSeconds, userCooldownSeconds sql.NullInt64
 	var updatedAt sql.NullTime
 
-	if err := row.Scan(&record.Name, &record.Response, &aliasesRaw, &platformsRaw, &permissionsRaw, &updatedAt); err != nil {
+	if err := row.Scan(&record.Name, &record.Response, &responsesRaw, &aliasesRaw, &platformsRaw, &permissionsRaw, &cooldownSeconds, &userCooldownSeconds, &feedbackRaw, &permissionReplyRaw, &updatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("sqlite: get custom command: %w", err)
 	}
 
+	record.Responses = decodeResponses(responsesRaw.String)
 	record.Aliases = decodeStringSlice(aliasesRaw.String)
 	record.Platforms = decodePlatforms(platformsRaw.String)
 	record.Permissions = decodePermissions(permissionsRaw.String)
+	record.Cooldown = time.Duration(cooldownSeconds.Int64) * time.Second
+	record.UserCooldown = time.Duration(userCooldownSeconds.Int64) * time.Second
+	record.CooldownFeedback, _ = domain.ParseCooldownFeedbackMode(feedbackRaw.String)
+	record.PermissionReply, _ = domain.ParsePermissionReplyMode(permissionReplyRaw.String)
 	record.UpdatedAt = updatedAt.Time
 
 	return &record, nil
@@ -347,7 +639,7 @@ LIMIT 1;
 
 func (s *CredentialStore) ListCustomCommands(ctx context.Context) ([]*domain.CustomCommand, error) {
 	const query = `
-SELECT name, response, aliases, platforms, permissions, updated_at
+SELECT name, response, responses, aliases, platforms, permissions, cooldown_seconds, user_cooldown_seconds, cooldown_feedback, permission_reply, updated_at
 FROM custom_commands;
 `
 
@@ -360,16 +652,22 @@ FROM custom_commands;
 	var cmds []*domain.CustomCommand
 	for rows.Next() {
 		var record domain.CustomCommand
-		var aliasesRaw, platformsRaw, permissionsRaw sql.NullString
+		var responsesRaw, aliasesRaw, platformsRaw, permissionsRaw, feedbackRaw, permissionReplyRaw sql.NullString
+		var cooldownSeconds, userCooldownSeconds sql.NullInt64
 		var updatedAt sql.NullTime
 
-		if err := rows.Scan(&record.Name, &record.Response, &aliasesRaw, &platformsRaw, &permissionsRaw, &updatedAt); err != nil {
+		if err := rows.Scan(&record.Name, &record.Response, &responsesRaw, &aliasesRaw, &platformsRaw, &permissionsRaw, &cooldownSeconds, &userCooldownSeconds, &feedbackRaw, &permissionReplyRaw, &updatedAt); err != nil {
 			return nil, fmt.Errorf("sqlite: scan custom command: %w", err)
 		}
 
+		record.Responses = decodeResponses(responsesRaw.String)
 		record.Aliases = decodeStringSlice(aliasesRaw.String)
 		record.Platforms = decodePlatforms(platformsRaw.String)
 		record.Permissions = decodePermissions(permissionsRaw.String)
+		record.Cooldown = time.Duration(cooldownSeconds.Int64) * time.Second
+		record.UserCooldown = time.Duration(userCooldownSeconds.Int64) * time.Second
+		record.CooldownFeedback, _ = domain.ParseCooldownFeedbackMode(feedbackRaw.String)
+		record.PermissionReply, _ = domain.ParsePermissionReplyMode(permissionReplyRaw.String)
 		record.UpdatedAt = updatedAt.Time
 
 		cmds = append(cmds, &record)
@@ -382,6 +680,14 @@ FROM custom_commands;
 	return cmds, nil
 }
 
+func (s *CredentialStore) CountCustomCommands(ctx context.Context) (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM custom_commands;`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("sqlite: count custom commands: %w", err)
+	}
+	return count, nil
+}
+
 // ----- Notifications -----
 
 func (s *CredentialStore) SaveNotification(ctx context.Context, notification *domain.Notification) (*domain.Notification, error) {
@@ -421,6 +727,33 @@ VALUES (?, ?, ?, ?, ?, ?, ?);
 	return notification, nil
 }
 
+func (s *CredentialStore) UpdateNotification(ctx context.Context, notification *domain.Notification) error {
+	if notification == nil || notification.ID == 0 {
+		return fmt.Errorf("sqlite: update notification: missing id")
+	}
+	const stmt = `
+UPDATE notifications
+SET type = ?, platform = ?, username = ?, amount = ?, message = ?, metadata = ?
+WHERE id = ?;
+`
+	if _, err := s.db.ExecContext(
+		ctx,
+		stmt,
+		string(notification.Type),
+		string(notification.Platform),
+		notification.Username,
+		notification.Amount,
+		notification.Message,
+		encodeMetadata(notification.Metadata),
+		notification.ID,
+	); err != nil {
+		return fmt.Errorf("sqlite: update notification: %w", err)
+	}
+	return nil
+}
+
+var _ domain.NotificationUpdater = (*CredentialStore)(nil)
+
 func (s *CredentialStore) ListNotifications(ctx context.Context, limit int) ([]*domain.Notification, error) {
 	if limit <= 0 {
 		limit = 50
@@ -480,6 +813,42 @@ LIMIT ?;
 	return out, nil
 }
 
+func (s *CredentialStore) CountNotifications(ctx context.Context, filter domain.NotificationFilter) (int, error) {
+	var (
+		where []string
+		args  []any
+	)
+	if filter.Type != "" {
+		where = append(where, "type = ?")
+		args = append(args, string(filter.Type))
+	}
+	if filter.Platform != "" {
+		where = append(where, "platform = ?")
+		args = append(args, string(filter.Platform))
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if filter.ExcludeTest {
+		// encodeMetadata serializa con json.Marshal, así que la marca siempre
+		// aparece como "test":"true".
+		where = append(where, "(metadata IS NULL OR metadata NOT LIKE ?)")
+		args = append(args, `%"`+domain.NotificationTestKey+`":"true"%`)
+	}
+
+	query := "SELECT COUNT(*) FROM notifications"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("sqlite: count notifications: %w", err)
+	}
+	return count, nil
+}
+
 func encodeStringSlice(values []string) interface{} {
 	clean := make([]string, 0, len(values))
 	for _, v := range values {
@@ -509,6 +878,28 @@ func decodeStringSlice(raw string) []string {
 	return values
 }
 
+func encodeResponses(values []domain.WeightedResponse) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	return string(b)
+}
+
+func decodeResponses(raw string) []domain.WeightedResponse {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var values []domain.WeightedResponse
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
 func encodePlatforms(values []domain.Platform) interface{} {
 	if len(values) == 0 {
 		return nil
@@ -592,6 +983,8 @@ func decodePermissions(raw string) []domain.CommandAccessRole {
 
 var _ domain.CustomCommandRepository = (*CredentialStore)(nil)
 var _ domain.NotificationRepository = (*CredentialStore)(nil)
+var _ domain.NotificationCounter = (*CredentialStore)(nil)
+var _ domain.CustomCommandCounter = (*CredentialStore)(nil)
 
 func (s *CredentialStore) DeleteCustomCommand(ctx context.Context, name string) error {
 	const stmt = `DELETE FROM custom_commands WHERE LOWER(name) = LOWER(?);`
@@ -601,73 +994,1338 @@ func (s *CredentialStore) DeleteCustomCommand(ctx context.Context, name string)
 	return nil
 }
 
-// ----- TTS Settings -----
+// ----- Word Trackers -----
 
-const ttsVoiceKey = "tts_voice"
-const ttsEnabledKey = "tts_enabled"
+func (s *CredentialStore) ListTrackers(ctx context.Context) ([]*domain.WordTracker, error) {
+	const query = `
+SELECT name, term, kind, milestone_every, enabled, session_id, count, updated_at
+FROM word_trackers
+ORDER BY name;
+`
 
-func (s *CredentialStore) SetTTSVoice(ctx context.Context, voice string) error {
-	return s.setSetting(ctx, ttsVoiceKey, voice)
-}
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list trackers: %w", err)
+	}
+	defer rows.Close()
 
-func (s *CredentialStore) GetTTSVoice(ctx context.Context) (string, error) {
-	return s.getSetting(ctx, ttsVoiceKey)
-}
+	var trackers []*domain.WordTracker
+	for rows.Next() {
+		var record domain.WordTracker
+		var kindRaw, sessionRaw sql.NullString
+		var updatedAt sql.NullTime
 
-func (s *CredentialStore) SetTTSEnabled(ctx context.Context, enabled bool) error {
-	value := "false"
-	if enabled {
-		value = "true"
+		if err := rows.Scan(&record.Name, &record.Term, &kindRaw, &record.MilestoneEvery, &record.Enabled, &sessionRaw, &record.Count, &updatedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan tracker: %w", err)
+		}
+		record.Kind, _ = domain.ParseTrackerKind(kindRaw.String)
+		record.SessionID = sessionRaw.String
+		record.UpdatedAt = updatedAt.Time
+
+		trackers = append(trackers, &record)
 	}
-	return s.setSetting(ctx, ttsEnabledKey, value)
-}
 
-func (s *CredentialStore) GetTTSEnabled(ctx context.Context) (bool, error) {
-	val, err := s.getSetting(ctx, ttsEnabledKey)
-	if err != nil {
-		return false, err
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list tracker rows: %w", err)
 	}
-	return strings.ToLower(strings.TrimSpace(val)) != "false", nil
+
+	return trackers, nil
 }
 
-func (s *CredentialStore) setSetting(ctx context.Context, key, value string) error {
-	if strings.TrimSpace(key) == "" {
-		return fmt.Errorf("sqlite: empty setting key")
+func (s *CredentialStore) UpsertTracker(ctx context.Context, tracker *domain.WordTracker) error {
+	if tracker == nil {
+		return fmt.Errorf("sqlite: tracker nil")
+	}
+	if tracker.UpdatedAt.IsZero() {
+		tracker.UpdatedAt = time.Now().UTC()
 	}
 
-	now := time.Now().UTC()
 	const stmt = `
-INSERT INTO settings (key, value, updated_at)
-VALUES (?, ?, ?)
-ON CONFLICT(key) DO UPDATE SET
-	value=excluded.value,
+INSERT INTO word_trackers (name, term, kind, milestone_every, enabled, session_id, count, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+	term=excluded.term,
+	kind=excluded.kind,
+	milestone_every=excluded.milestone_every,
+	enabled=excluded.enabled,
+	session_id=excluded.session_id,
+	count=excluded.count,
 	updated_at=excluded.updated_at;
 `
 
-	if _, err := s.db.ExecContext(ctx, stmt, key, value, now); err != nil {
-		return fmt.Errorf("sqlite: set setting: %w", err)
+	_, err := s.db.ExecContext(
+		ctx,
+		stmt,
+		tracker.Name,
+		tracker.Term,
+		string(tracker.Kind),
+		tracker.MilestoneEvery,
+		tracker.Enabled,
+		tracker.SessionID,
+		tracker.Count,
+		tracker.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: upsert tracker: %w", err)
 	}
+	return nil
+}
 
+func (s *CredentialStore) UpdateTrackerCount(ctx context.Context, name, sessionID string, count int) error {
+	const stmt = `UPDATE word_trackers SET session_id = ?, count = ?, updated_at = ? WHERE name = ?;`
+	if _, err := s.db.ExecContext(ctx, stmt, sessionID, count, time.Now().UTC(), name); err != nil {
+		return fmt.Errorf("sqlite: update tracker count: %w", err)
+	}
 	return nil
 }
 
-func (s *CredentialStore) getSetting(ctx context.Context, key string) (string, error) {
-	if strings.TrimSpace(key) == "" {
-		return "", fmt.Errorf("sqlite: empty setting key")
+func (s *CredentialStore) DeleteTracker(ctx context.Context, name string) error {
+	const stmt = `DELETE FROM word_trackers WHERE LOWER(name) = LOWER(?);`
+	if _, err := s.db.ExecContext(ctx, stmt, name); err != nil {
+		return fmt.Errorf("sqlite: delete tracker: %w", err)
 	}
+	return nil
+}
 
-	const query = `SELECT value FROM settings WHERE key = ? LIMIT 1;`
-	row := s.db.QueryRowContext(ctx, query, key)
+var _ domain.TrackerRepository = (*CredentialStore)(nil)
 
-	var value sql.NullString
-	if err := row.Scan(&value); err != nil {
-		if err == sql.ErrNoRows {
-			return "", nil
+// ----- User Notes -----
+
+func (s *CredentialStore) ListUserNotes(ctx context.Context, platform domain.Platform, userID string) ([]*domain.UserNote, error) {
+	const query = `
+SELECT id, platform, user_id, login, note, author, created_at
+FROM user_notes
+WHERE platform = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC;
+`
+
+	rows, err := s.db.QueryContext(ctx, query, string(platform), userID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list user notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*domain.UserNote
+	for rows.Next() {
+		var record domain.UserNote
+		var plat string
+		var login, author sql.NullString
+		var createdAt sql.NullTime
+		if err := rows.Scan(&record.ID, &plat, &record.UserID, &login, &record.Note, &author, &createdAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan user note: %w", err)
 		}
-		return "", fmt.Errorf("sqlite: get setting: %w", err)
+		record.Platform = domain.Platform(plat)
+		record.Login = login.String
+		record.Author = author.String
+		record.CreatedAt = createdAt.Time
+		notes = append(notes, &record)
 	}
 
-	return value.String, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list user note rows: %w", err)
+	}
+	return notes, nil
+}
+
+func (s *CredentialStore) ListNotedUsers(ctx context.Context) ([]domain.NotedUser, error) {
+	const query = `
+SELECT platform, user_id, MAX(login), COUNT(*)
+FROM user_notes
+GROUP BY platform, user_id;
+`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list noted users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.NotedUser
+	for rows.Next() {
+		var user domain.NotedUser
+		var plat string
+		var login sql.NullString
+		if err := rows.Scan(&plat, &user.UserID, &login, &user.Notes); err != nil {
+			return nil, fmt.Errorf("sqlite: scan noted user: %w", err)
+		}
+		user.Platform = domain.Platform(plat)
+		user.Login = login.String
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list noted user rows: %w", err)
+	}
+	return users, nil
+}
+
+func (s *CredentialStore) AddUserNote(ctx context.Context, note *domain.UserNote) (*domain.UserNote, error) {
+	if note == nil {
+		return nil, fmt.Errorf("sqlite: user note nil")
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now().UTC()
+	}
+
+	const stmt = `
+INSERT INTO user_notes (platform, user_id, login, note, author, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+`
+
+	res, err := s.db.ExecContext(ctx, stmt, string(note.Platform), note.UserID, note.Login, note.Note, note.Author, note.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: add user note: %w", err)
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		note.ID = id
+	}
+	return note, nil
+}
+
+func (s *CredentialStore) DeleteUserNote(ctx context.Context, platform domain.Platform, userID string, id int64) (bool, error) {
+	const stmt = `DELETE FROM user_notes WHERE id = ? AND platform = ? AND user_id = ?;`
+	res, err := s.db.ExecContext(ctx, stmt, id, string(platform), userID)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete user note: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete user note: %w", err)
+	}
+	return affected > 0, nil
+}
+
+var _ domain.UserNoteRepository = (*CredentialStore)(nil)
+
+// ----- TTS history -----
+
+func (s *CredentialStore) SaveTTSHistory(ctx context.Context, entries []domain.TTSHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	const stmt = `
+INSERT INTO tts_history (id, text, requested_by, platform, channel_id, voice, outcome, error, finished_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: save tts history: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range entries {
+		finishedAt := entry.FinishedAt
+		if finishedAt.IsZero() {
+			finishedAt = time.Now()
+		}
+		if _, err := tx.ExecContext(ctx, stmt,
+			entry.ID,
+			entry.Text,
+			entry.RequestedBy,
+			string(entry.Platform),
+			entry.ChannelID,
+			entry.Voice,
+			entry.Outcome,
+			entry.Error,
+			finishedAt.UTC(),
+		); err != nil {
+			return fmt.Errorf("sqlite: save tts history: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: save tts history: %w", err)
+	}
+	return nil
+}
+
+func (s *CredentialStore) ListTTSHistory(ctx context.Context, filter domain.TTSHistoryFilter) ([]domain.TTSHistoryEntry, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	query := `
+SELECT id, text, requested_by, platform, channel_id, voice, outcome, error, finished_at
+FROM tts_history
+WHERE 1 = 1`
+	var args []any
+	if user := strings.TrimSpace(filter.User); user != "" {
+		query += ` AND LOWER(requested_by) = LOWER(?)`
+		args = append(args, user)
+	}
+	if outcome := strings.TrimSpace(filter.Outcome); outcome != "" {
+		query += ` AND outcome = ?`
+		args = append(args, outcome)
+	}
+	query += `
+ORDER BY finished_at DESC, rowid DESC
+LIMIT ?;`
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list tts history: %w", err)
+	}
+	defer rows.Close()
+
+	out := []domain.TTSHistoryEntry{}
+	for rows.Next() {
+		var (
+			record                                  domain.TTSHistoryEntry
+			requestedBy, platform, channelID, voice sql.NullString
+			errText                                 sql.NullString
+			finishedAt                              sql.NullTime
+		)
+		if err := rows.Scan(
+			&record.ID,
+			&record.Text,
+			&requestedBy,
+			&platform,
+			&channelID,
+			&voice,
+			&record.Outcome,
+			&errText,
+			&finishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("sqlite: scan tts history: %w", err)
+		}
+		record.RequestedBy = requestedBy.String
+		record.Platform = domain.Platform(platform.String)
+		record.ChannelID = channelID.String
+		record.Voice = voice.String
+		record.Error = errText.String
+		record.FinishedAt = finishedAt.Time
+		out = append(out, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list tts history rows: %w", err)
+	}
+	return out, nil
+}
+
+func (s *CredentialStore) PruneTTSHistory(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tts_history WHERE finished_at < ?;`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: prune tts history: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+var _ domain.TTSHistoryRepository = (*CredentialStore)(nil)
+
+// ----- Raw events -----
+
+func (s *CredentialStore) SaveRawEvents(ctx context.Context, events []domain.RawPlatformEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	const stmt = `
+INSERT INTO raw_events (platform, event_type, payload, received_at)
+VALUES (?, ?, ?, ?);
+`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: save raw events: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		receivedAt := event.ReceivedAt
+		if receivedAt.IsZero() {
+			receivedAt = time.Now()
+		}
+		if _, err := tx.ExecContext(ctx, stmt,
+			string(event.Platform),
+			event.EventType,
+			event.Payload,
+			receivedAt.UTC(),
+		); err != nil {
+			return fmt.Errorf("sqlite: save raw events: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: save raw events: %w", err)
+	}
+	return nil
+}
+
+func (s *CredentialStore) ListRawEvents(ctx context.Context, filter domain.RawEventFilter) ([]domain.RawPlatformEvent, error) {
+	query := `SELECT platform, event_type, payload, received_at FROM raw_events WHERE 1=1`
+	var args []any
+	if filter.Platform != "" {
+		query += ` AND platform = ?`
+		args = append(args, string(filter.Platform))
+	}
+	if filter.EventType != "" {
+		query += ` AND LOWER(event_type) = LOWER(?)`
+		args = append(args, filter.EventType)
+	}
+	if !filter.Since.IsZero() {
+		query += ` AND received_at >= ?`
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		query += ` AND received_at < ?`
+		args = append(args, filter.Until.UTC())
+	}
+	query += ` ORDER BY received_at, id`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list raw events: %w", err)
+	}
+	defer rows.Close()
+
+	out := []domain.RawPlatformEvent{}
+	for rows.Next() {
+		var (
+			event      domain.RawPlatformEvent
+			platform   string
+			eventType  sql.NullString
+			receivedAt sql.NullTime
+		)
+		if err := rows.Scan(&platform, &eventType, &event.Payload, &receivedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan raw events: %w", err)
+		}
+		event.Platform = domain.Platform(platform)
+		event.EventType = eventType.String
+		event.ReceivedAt = receivedAt.Time
+		out = append(out, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list raw events rows: %w", err)
+	}
+	return out, nil
+}
+
+func (s *CredentialStore) PruneRawEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM raw_events WHERE received_at < ?;`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: prune raw events: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+var _ domain.RawEventRepository = (*CredentialStore)(nil)
+
+// ----- Counters -----
+
+func (s *CredentialStore) ListCounters(ctx context.Context) ([]*domain.Counter, error) {
+	const query = `SELECT name, value, updated_at FROM counters ORDER BY name;`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list counters: %w", err)
+	}
+	defer rows.Close()
+
+	var counters []*domain.Counter
+	for rows.Next() {
+		var counter domain.Counter
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&counter.Name, &counter.Value, &updatedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan counter: %w", err)
+		}
+		counter.UpdatedAt = updatedAt.Time
+		counters = append(counters, &counter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list counter rows: %w", err)
+	}
+	return counters, nil
+}
+
+func (s *CredentialStore) SaveCounter(ctx context.Context, counter *domain.Counter) error {
+	if counter == nil {
+		return fmt.Errorf("sqlite: counter nil")
+	}
+	if counter.UpdatedAt.IsZero() {
+		counter.UpdatedAt = time.Now().UTC()
+	}
+
+	const stmt = `
+INSERT INTO counters (name, value, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at;
+`
+	if _, err := s.db.ExecContext(ctx, stmt, counter.Name, counter.Value, counter.UpdatedAt); err != nil {
+		return fmt.Errorf("sqlite: save counter: %w", err)
+	}
+	return nil
+}
+
+func (s *CredentialStore) DeleteCounter(ctx context.Context, name string) (bool, error) {
+	const stmt = `DELETE FROM counters WHERE name = ?;`
+	res, err := s.db.ExecContext(ctx, stmt, name)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete counter: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete counter: %w", err)
+	}
+	return affected > 0, nil
+}
+
+var _ domain.CounterRepository = (*CredentialStore)(nil)
+
+// ----- Quotes -----
+
+const quoteColumns = `number, text, added_by, platform, created_at`
+
+func scanQuote(scan func(dest ...any) error) (*domain.Quote, error) {
+	var quote domain.Quote
+	var addedBy, platform sql.NullString
+	var createdAt sql.NullTime
+	if err := scan(&quote.Number, &quote.Text, &addedBy, &platform, &createdAt); err != nil {
+		return nil, err
+	}
+	quote.AddedBy = addedBy.String
+	quote.Platform = domain.Platform(platform.String)
+	quote.CreatedAt = createdAt.Time
+	return &quote, nil
+}
+
+func (s *CredentialStore) AddQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error) {
+	if quote == nil {
+		return nil, fmt.Errorf("sqlite: quote nil")
+	}
+	if quote.CreatedAt.IsZero() {
+		quote.CreatedAt = time.Now().UTC()
+	}
+
+	const stmt = `INSERT INTO quotes (text, added_by, platform, created_at) VALUES (?, ?, ?, ?);`
+	res, err := s.db.ExecContext(ctx, stmt, quote.Text, quote.AddedBy, string(quote.Platform), quote.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: add quote: %w", err)
+	}
+	number, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: add quote: %w", err)
+	}
+	quote.Number = number
+	return quote, nil
+}
+
+func (s *CredentialStore) GetQuote(ctx context.Context, number int64) (*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE number = ?;`
+	quote, err := scanQuote(s.db.QueryRowContext(ctx, query, number).Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("sqlite: get quote: %w", err)
+	}
+	return quote, nil
+}
+
+func (s *CredentialStore) RandomQuote(ctx context.Context) (*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes ORDER BY RANDOM() LIMIT 1;`
+	quote, err := scanQuote(s.db.QueryRowContext(ctx, query).Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("sqlite: random quote: %w", err)
+	}
+	return quote, nil
+}
+
+func (s *CredentialStore) ListQuotes(ctx context.Context) ([]*domain.Quote, error) {
+	query := `SELECT ` + quoteColumns + ` FROM quotes ORDER BY number;`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list quotes: %w", err)
+	}
+	defer rows.Close()
+
+	var quotes []*domain.Quote
+	for rows.Next() {
+		quote, err := scanQuote(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scan quote: %w", err)
+		}
+		quotes = append(quotes, quote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list quote rows: %w", err)
+	}
+	return quotes, nil
+}
+
+func (s *CredentialStore) UpdateQuote(ctx context.Context, number int64, text string) (bool, error) {
+	const stmt = `UPDATE quotes SET text = ? WHERE number = ?;`
+	res, err := s.db.ExecContext(ctx, stmt, text, number)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: update quote: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite: update quote: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (s *CredentialStore) DeleteQuote(ctx context.Context, number int64) (bool, error) {
+	const stmt = `DELETE FROM quotes WHERE number = ?;`
+	res, err := s.db.ExecContext(ctx, stmt, number)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete quote: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite: delete quote: %w", err)
+	}
+	return affected > 0, nil
+}
+
+var _ domain.QuoteRepository = (*CredentialStore)(nil)
+
+// ----- Chat games -----
+
+const chatGamesKey = "chat_games"
+
+func (s *CredentialStore) GetChatGamesSettings(ctx context.Context) (*domain.ChatGamesSettings, error) {
+	var settings domain.ChatGamesSettings
+	found, err := s.GetJSON(ctx, chatGamesKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetChatGamesSettings(ctx context.Context, settings domain.ChatGamesSettings) error {
+	return s.SetJSON(ctx, chatGamesKey, settings)
+}
+
+var _ domain.ChatGamesSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Auto shoutout -----
+
+const autoShoutoutKey = "auto_shoutout"
+
+func (s *CredentialStore) GetAutoShoutoutSettings(ctx context.Context) (*domain.AutoShoutoutSettings, error) {
+	var settings domain.AutoShoutoutSettings
+	found, err := s.GetJSON(ctx, autoShoutoutKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetAutoShoutoutSettings(ctx context.Context, settings domain.AutoShoutoutSettings) error {
+	return s.SetJSON(ctx, autoShoutoutKey, settings)
+}
+
+var _ domain.AutoShoutoutSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Bot conflicts -----
+
+const botConflictsKey = "bot_conflicts"
+
+func (s *CredentialStore) GetBotConflictSettings(ctx context.Context) (*domain.BotConflictSettings, error) {
+	var settings domain.BotConflictSettings
+	found, err := s.GetJSON(ctx, botConflictsKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetBotConflictSettings(ctx context.Context, settings domain.BotConflictSettings) error {
+	return s.SetJSON(ctx, botConflictsKey, settings)
+}
+
+var _ domain.BotConflictSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Leaderboard -----
+
+const leaderboardKey = "leaderboard"
+
+func (s *CredentialStore) GetLeaderboardSettings(ctx context.Context) (*domain.LeaderboardSettings, error) {
+	var settings domain.LeaderboardSettings
+	found, err := s.GetJSON(ctx, leaderboardKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetLeaderboardSettings(ctx context.Context, settings domain.LeaderboardSettings) error {
+	return s.SetJSON(ctx, leaderboardKey, settings)
+}
+
+func (s *CredentialStore) ListChatterStats(ctx context.Context, scope domain.LeaderboardScope) ([]domain.ChatterStats, error) {
+	const query = `
+SELECT platform, user_id, name, session_id, messages, emotes, last_seen
+FROM chatter_stats
+WHERE scope = ?;
+`
+
+	rows, err := s.db.QueryContext(ctx, query, string(scope))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list chatter stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []domain.ChatterStats
+	for rows.Next() {
+		var record domain.ChatterStats
+		var platform string
+		var name, sessionID sql.NullString
+		var lastSeen sql.NullTime
+		if err := rows.Scan(&platform, &record.UserID, &name, &sessionID, &record.Messages, &record.Emotes, &lastSeen); err != nil {
+			return nil, fmt.Errorf("sqlite: scan chatter stats: %w", err)
+		}
+		record.Platform = domain.Platform(platform)
+		record.Name = name.String
+		record.SessionID = sessionID.String
+		record.LastSeen = lastSeen.Time
+		stats = append(stats, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list chatter stats rows: %w", err)
+	}
+	return stats, nil
+}
+
+func (s *CredentialStore) SaveChatterStats(ctx context.Context, scope domain.LeaderboardScope, stats []domain.ChatterStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: save chatter stats: %w", err)
+	}
+	defer tx.Rollback()
+
+	const stmt = `
+INSERT INTO chatter_stats (scope, platform, user_id, name, session_id, messages, emotes, last_seen)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(scope, platform, user_id) DO UPDATE SET
+	name=excluded.name,
+	session_id=excluded.session_id,
+	messages=excluded.messages,
+	emotes=excluded.emotes,
+	last_seen=excluded.last_seen;
+`
+	for _, record := range stats {
+		if _, err := tx.ExecContext(ctx, stmt, string(scope), string(record.Platform), record.UserID, record.Name,
+			record.SessionID, record.Messages, record.Emotes, record.LastSeen); err != nil {
+			return fmt.Errorf("sqlite: save chatter stats: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: save chatter stats: %w", err)
+	}
+	return nil
+}
+
+func (s *CredentialStore) ClearChatterStats(ctx context.Context, scope domain.LeaderboardScope) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM chatter_stats WHERE scope = ?;`, string(scope)); err != nil {
+		return fmt.Errorf("sqlite: clear chatter stats: %w", err)
+	}
+	return nil
+}
+
+var _ domain.LeaderboardRepository = (*CredentialStore)(nil)
+
+// ----- Chat users -----
+
+func (s *CredentialStore) GetChatUserLastSeen(ctx context.Context, platform domain.Platform, userID string) (time.Time, error) {
+	var lastSeen sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		`SELECT last_seen FROM chat_users WHERE platform = ? AND user_id = ?;`,
+		string(platform), userID).Scan(&lastSeen)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("sqlite: get chat user: %w", err)
+	}
+	return lastSeen.Time, nil
+}
+
+func (s *CredentialStore) SaveChatUsers(ctx context.Context, users []domain.ChatUser) error {
+	if len(users) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: save chat users: %w", err)
+	}
+	defer tx.Rollback()
+
+	const stmt = `
+INSERT INTO chat_users (platform, user_id, name, last_seen)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(platform, user_id) DO UPDATE SET
+	name=excluded.name,
+	last_seen=excluded.last_seen;
+`
+	for _, user := range users {
+		if _, err := tx.ExecContext(ctx, stmt, string(user.Platform), user.UserID, user.Name, user.LastSeen); err != nil {
+			return fmt.Errorf("sqlite: save chat users: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlite: save chat users: %w", err)
+	}
+	return nil
+}
+
+var _ domain.ChatUserRepository = (*CredentialStore)(nil)
+
+// ----- Greetings -----
+
+const greetingKey = "greetings"
+
+func (s *CredentialStore) GetGreetingSettings(ctx context.Context) (*domain.GreetingSettings, error) {
+	var settings domain.GreetingSettings
+	found, err := s.GetJSON(ctx, greetingKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetGreetingSettings(ctx context.Context, settings domain.GreetingSettings) error {
+	return s.SetJSON(ctx, greetingKey, settings)
+}
+
+var _ domain.GreetingSettingsRepository = (*CredentialStore)(nil)
+
+// ----- TTS Settings -----
+
+const ttsVoiceKey = "tts_voice"
+const ttsEnabledKey = "tts_enabled"
+const ttsAutoPauseKey = "tts_auto_pause"
+const ttsSkipVotesKey = "tts_skip_votes"
+const ttsMaxFetchKey = "tts_max_fetch_seconds"
+
+func (s *CredentialStore) SetTTSVoice(ctx context.Context, voice string) error {
+	return s.setSetting(ctx, ttsVoiceKey, voice)
+}
+
+func (s *CredentialStore) GetTTSVoice(ctx context.Context) (string, error) {
+	return s.getSetting(ctx, ttsVoiceKey)
+}
+
+// defaultTTSEnabled: si nunca se configuró, el TTS arranca activado.
+const defaultTTSEnabled = true
+
+func (s *CredentialStore) SetTTSEnabled(ctx context.Context, enabled bool) error {
+	return s.SetBool(ctx, ttsEnabledKey, enabled)
+}
+
+func (s *CredentialStore) GetTTSEnabled(ctx context.Context) (bool, error) {
+	return s.GetBool(ctx, ttsEnabledKey, defaultTTSEnabled)
+}
+
+func (s *CredentialStore) GetTTSSkipVotes(ctx context.Context) (int, error) {
+	return s.GetInt(ctx, ttsSkipVotesKey, domain.DefaultTTSSkipVotes)
+}
+
+func (s *CredentialStore) SetTTSSkipVotes(ctx context.Context, votes int) error {
+	return s.SetInt(ctx, ttsSkipVotesKey, votes)
+}
+
+func (s *CredentialStore) GetTTSMaxFetchSeconds(ctx context.Context) (int, error) {
+	return s.GetInt(ctx, ttsMaxFetchKey, domain.DefaultTTSMaxFetchSeconds)
+}
+
+func (s *CredentialStore) SetTTSMaxFetchSeconds(ctx context.Context, seconds int) error {
+	return s.SetInt(ctx, ttsMaxFetchKey, seconds)
+}
+
+func (s *CredentialStore) GetTTSAutoPause(ctx context.Context) (*domain.TTSAutoPauseSettings, error) {
+	var settings domain.TTSAutoPauseSettings
+	found, err := s.GetJSON(ctx, ttsAutoPauseKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetTTSAutoPause(ctx context.Context, settings domain.TTSAutoPauseSettings) error {
+	return s.SetJSON(ctx, ttsAutoPauseKey, settings)
+}
+
+var _ domain.TTSAutoPauseRepository = (*CredentialStore)(nil)
+var _ domain.TTSSkipVotesRepository = (*CredentialStore)(nil)
+
+// ----- Command Settings -----
+
+const commandsCooldownFeedbackKey = "commands_cooldown_feedback"
+
+func (s *CredentialStore) GetCooldownFeedbackDefault(ctx context.Context) (domain.CooldownFeedbackMode, error) {
+	val, err := s.getSetting(ctx, commandsCooldownFeedbackKey)
+	if err != nil {
+		return "", err
+	}
+	mode, _ := domain.ParseCooldownFeedbackMode(val)
+	return mode, nil
+}
+
+func (s *CredentialStore) SetCooldownFeedbackDefault(ctx context.Context, mode domain.CooldownFeedbackMode) error {
+	return s.setSetting(ctx, commandsCooldownFeedbackKey, string(mode))
+}
+
+const commandsPermissionReplyKey = "commands_permission_reply"
+
+func (s *CredentialStore) GetPermissionReplyDefault(ctx context.Context) (domain.PermissionReplyMode, error) {
+	val, err := s.getSetting(ctx, commandsPermissionReplyKey)
+	if err != nil {
+		return "", err
+	}
+	mode, _ := domain.ParsePermissionReplyMode(val)
+	return mode, nil
+}
+
+func (s *CredentialStore) SetPermissionReplyDefault(ctx context.Context, mode domain.PermissionReplyMode) error {
+	return s.setSetting(ctx, commandsPermissionReplyKey, string(mode))
+}
+
+var _ domain.CommandSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Twitch identity cache -----
+
+const twitchBroadcasterLoginKey = "twitch_broadcaster_login"
+const twitchBroadcasterIDKey = "twitch_broadcaster_id"
+
+func (s *CredentialStore) GetTwitchBroadcasterCache(ctx context.Context) (string, string, error) {
+	login, err := s.getSetting(ctx, twitchBroadcasterLoginKey)
+	if err != nil {
+		return "", "", err
+	}
+	id, err := s.getSetting(ctx, twitchBroadcasterIDKey)
+	if err != nil {
+		return "", "", err
+	}
+	return login, id, nil
+}
+
+func (s *CredentialStore) SetTwitchBroadcasterCache(ctx context.Context, login, id string) error {
+	if err := s.setSetting(ctx, twitchBroadcasterLoginKey, strings.ToLower(strings.TrimSpace(login))); err != nil {
+		return err
+	}
+	return s.setSetting(ctx, twitchBroadcasterIDKey, strings.TrimSpace(id))
+}
+
+// ----- Overlay configs -----
+
+const overlayConfigPrefix = "overlay_config:"
+
+func (s *CredentialStore) GetOverlayConfig(ctx context.Context, name string) ([]byte, error) {
+	val, err := s.getSetting(ctx, overlayConfigPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+	return []byte(val), nil
+}
+
+func (s *CredentialStore) SaveOverlayConfig(ctx context.Context, name string, data []byte) error {
+	return s.setSetting(ctx, overlayConfigPrefix+name, string(data))
+}
+
+func (s *CredentialStore) ListOverlayConfigNames(ctx context.Context) ([]string, error) {
+	const query = `SELECT key FROM settings WHERE key LIKE ? AND value <> '' ORDER BY key;`
+	rows, err := s.db.QueryContext(ctx, query, overlayConfigPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list overlay configs: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("sqlite: scan overlay config: %w", err)
+		}
+		names = append(names, strings.TrimPrefix(key, overlayConfigPrefix))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list overlay config rows: %w", err)
+	}
+	return names, nil
+}
+
+var _ domain.OverlayConfigRepository = (*CredentialStore)(nil)
+
+// ----- Moderation Settings -----
+
+const moderationSpamKey = "moderation_spam"
+
+func (s *CredentialStore) GetSpamProtectionSettings(ctx context.Context) (*domain.SpamProtectionSettings, error) {
+	var settings domain.SpamProtectionSettings
+	found, err := s.GetJSON(ctx, moderationSpamKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetSpamProtectionSettings(ctx context.Context, settings domain.SpamProtectionSettings) error {
+	return s.SetJSON(ctx, moderationSpamKey, settings)
+}
+
+var _ domain.ModerationSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Follow Announcements -----
+
+const automationsFollowsKey = "automations_follows"
+
+func (s *CredentialStore) GetFollowAnnounceSettings(ctx context.Context) (*domain.FollowAnnounceSettings, error) {
+	var settings domain.FollowAnnounceSettings
+	found, err := s.GetJSON(ctx, automationsFollowsKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetFollowAnnounceSettings(ctx context.Context, settings domain.FollowAnnounceSettings) error {
+	return s.SetJSON(ctx, automationsFollowsKey, settings)
+}
+
+var _ domain.FollowSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Lurk Settings -----
+
+const lurkSettingsKey = "lurk_settings"
+
+func (s *CredentialStore) GetLurkSettings(ctx context.Context) (*domain.LurkSettings, error) {
+	var settings domain.LurkSettings
+	found, err := s.GetJSON(ctx, lurkSettingsKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetLurkSettings(ctx context.Context, settings domain.LurkSettings) error {
+	return s.SetJSON(ctx, lurkSettingsKey, settings)
+}
+
+var _ domain.LurkSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Stream Sync -----
+
+const streamSyncKey = "stream_sync"
+
+func (s *CredentialStore) GetStreamSyncSettings(ctx context.Context) (*domain.StreamSyncSettings, error) {
+	var settings domain.StreamSyncSettings
+	found, err := s.GetJSON(ctx, streamSyncKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetStreamSyncSettings(ctx context.Context, settings domain.StreamSyncSettings) error {
+	return s.SetJSON(ctx, streamSyncKey, settings)
+}
+
+var _ domain.StreamSyncSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Link Previews -----
+
+const linkPreviewsKey = "link_previews"
+
+func (s *CredentialStore) GetLinkPreviewSettings(ctx context.Context) (*domain.LinkPreviewSettings, error) {
+	var settings domain.LinkPreviewSettings
+	found, err := s.GetJSON(ctx, linkPreviewsKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *CredentialStore) SetLinkPreviewSettings(ctx context.Context, settings domain.LinkPreviewSettings) error {
+	return s.SetJSON(ctx, linkPreviewsKey, settings)
+}
+
+var _ domain.LinkPreviewSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Presence Messages -----
+
+const presenceKey = "presence_messages"
+
+func (s *CredentialStore) GetPresenceSettings(ctx context.Context) (domain.PresenceSettings, error) {
+	var settings domain.PresenceSettings
+	found, err := s.GetJSON(ctx, presenceKey, &settings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (s *CredentialStore) SetPresenceSettings(ctx context.Context, settings domain.PresenceSettings) error {
+	return s.SetJSON(ctx, presenceKey, settings)
+}
+
+var _ domain.PresenceSettingsRepository = (*CredentialStore)(nil)
+
+// ----- Read-only Mode -----
+
+const readOnlyKey = "read_only"
+
+func (s *CredentialStore) GetReadOnly(ctx context.Context) (bool, error) {
+	return s.GetBool(ctx, readOnlyKey, false)
+}
+
+func (s *CredentialStore) SetReadOnly(ctx context.Context, enabled bool) error {
+	return s.SetBool(ctx, readOnlyKey, enabled)
+}
+
+var _ domain.ReadOnlyRepository = (*CredentialStore)(nil)
+
+// ----- Bot Pause -----
+
+const botPausedKey = "bot_paused"
+
+func (s *CredentialStore) GetBotPaused(ctx context.Context) (bool, error) {
+	return s.GetBool(ctx, botPausedKey, false)
+}
+
+func (s *CredentialStore) SetBotPaused(ctx context.Context, paused bool) error {
+	return s.SetBool(ctx, botPausedKey, paused)
+}
+
+var _ domain.BotPauseRepository = (*CredentialStore)(nil)
+
+// ----- Command prefix -----
+
+const commandPrefixKey = "command_prefix"
+
+func (s *CredentialStore) GetCommandPrefix(ctx context.Context) (string, error) {
+	val, err := s.getSetting(ctx, commandPrefixKey)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(val), nil
+}
+
+func (s *CredentialStore) SetCommandPrefix(ctx context.Context, prefix string) error {
+	return s.setSetting(ctx, commandPrefixKey, prefix)
+}
+
+var _ domain.CommandPrefixRepository = (*CredentialStore)(nil)
+
+// ----- Countdowns -----
+
+const countdownsKey = "countdowns"
+
+func (s *CredentialStore) GetCountdowns(ctx context.Context) ([]domain.Countdown, error) {
+	var countdowns []domain.Countdown
+	if _, err := s.GetJSON(ctx, countdownsKey, &countdowns); err != nil {
+		return nil, err
+	}
+	return countdowns, nil
+}
+
+func (s *CredentialStore) SetCountdowns(ctx context.Context, countdowns []domain.Countdown) error {
+	return s.SetJSON(ctx, countdownsKey, countdowns)
+}
+
+var _ domain.CountdownRepository = (*CredentialStore)(nil)
+
+// ----- Notification Templates -----
+
+const notificationTemplatesKey = "notification_templates"
+
+func (s *CredentialStore) GetNotificationTemplates(ctx context.Context) (map[domain.NotificationType]string, error) {
+	templates := make(map[domain.NotificationType]string)
+	if _, err := s.GetJSON(ctx, notificationTemplatesKey, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (s *CredentialStore) SetNotificationTemplates(ctx context.Context, templates map[domain.NotificationType]string) error {
+	return s.SetJSON(ctx, notificationTemplatesKey, templates)
+}
+
+var _ domain.NotificationTemplateRepository = (*CredentialStore)(nil)
+
+// ----- Debug Recorder -----
+
+const debugRecorderKey = "debug_recorder"
+
+func (s *CredentialStore) GetDebugRecorder(ctx context.Context) (domain.DebugRecorderSettings, error) {
+	var settings domain.DebugRecorderSettings
+	_, err := s.GetJSON(ctx, debugRecorderKey, &settings)
+	return settings, err
+}
+
+func (s *CredentialStore) SetDebugRecorder(ctx context.Context, settings domain.DebugRecorderSettings) error {
+	return s.SetJSON(ctx, debugRecorderKey, settings)
+}
+
+var _ domain.DebugRecorderRepository = (*CredentialStore)(nil)
+
+// ----- Platform connections -----
+
+const platformConnectionPrefix = "platform_connection:"
+
+func (s *CredentialStore) GetPlatformConnection(ctx context.Context, platform domain.Platform) (domain.PlatformConnection, error) {
+	connection := domain.PlatformConnection{Platform: platform}
+	_, err := s.GetJSON(ctx, platformConnectionPrefix+string(platform), &connection)
+	connection.Platform = platform
+	return connection, err
+}
+
+func (s *CredentialStore) SetPlatformConnection(ctx context.Context, connection domain.PlatformConnection) error {
+	if connection.Platform == "" {
+		return fmt.Errorf("sqlite: platform connection without platform")
+	}
+	return s.SetJSON(ctx, platformConnectionPrefix+string(connection.Platform), connection)
+}
+
+var _ domain.PlatformConnectionRepository = (*CredentialStore)(nil)
+
+func (s *CredentialStore) setSetting(ctx context.Context, key, value string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("sqlite: empty setting key")
+	}
+
+	now := time.Now().UTC()
+	const stmt = `
+INSERT INTO settings (key, value, updated_at)
+VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET
+	value=excluded.value,
+	updated_at=excluded.updated_at;
+`
+
+	if _, err := s.db.ExecContext(ctx, stmt, key, value, now); err != nil {
+		return fmt.Errorf("sqlite: set setting: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CredentialStore) getSetting(ctx context.Context, key string) (string, error) {
+	if strings.TrimSpace(key) == "" {
+		return "", fmt.Errorf("sqlite: empty setting key")
+	}
+
+	const query = `SELECT value FROM settings WHERE key = ? LIMIT 1;`
+	row := s.db.QueryRowContext(ctx, query, key)
+
+	var value sql.NullString
+	if err := row.Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("sqlite: get setting: %w", err)
+	}
+
+	return value.String, nil
+}
+
+// ListSettings devuelve todas las filas de settings ordenadas por clave.
+func (s *CredentialStore) ListSettings(ctx context.Context) ([]domain.Setting, error) {
+	const query = `SELECT key, value, updated_at FROM settings ORDER BY key;`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list settings: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []domain.Setting
+	for rows.Next() {
+		var setting domain.Setting
+		var value sql.NullString
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&setting.Key, &value, &updatedAt); err != nil {
+			return nil, fmt.Errorf("sqlite: scan setting: %w", err)
+		}
+		setting.Value = value.String
+		setting.UpdatedAt = updatedAt.Time
+		settings = append(settings, setting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: list setting rows: %w", err)
+	}
+	return settings, nil
+}
+
+var _ domain.SettingsLister = (*CredentialStore)(nil)
+
+// ----- Typed settings -----
+//
+// Los getters devuelven def cuando la clave no existe o está vacía. Un valor que
+// no se puede interpretar también devuelve def, junto con el error.
+
+func (s *CredentialStore) GetBool(ctx context.Context, key string, def bool) (bool, error) {
+	val, err := s.getSetting(ctx, key)
+	if err != nil {
+		return def, err
+	}
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return def, nil
+	}
+	parsed, err := strconv.ParseBool(val)
+	if err != nil {
+		return def, fmt.Errorf("sqlite: setting %s no es booleano: %q", key, val)
+	}
+	return parsed, nil
+}
+
+func (s *CredentialStore) SetBool(ctx context.Context, key string, value bool) error {
+	return s.setSetting(ctx, key, strconv.FormatBool(value))
+}
+
+func (s *CredentialStore) GetInt(ctx context.Context, key string, def int) (int, error) {
+	val, err := s.getSetting(ctx, key)
+	if err != nil {
+		return def, err
+	}
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return def, nil
+	}
+	parsed, err := strconv.Atoi(val)
+	if err != nil {
+		return def, fmt.Errorf("sqlite: setting %s no es entero: %q", key, val)
+	}
+	return parsed, nil
+}
+
+func (s *CredentialStore) SetInt(ctx context.Context, key string, value int) error {
+	return s.setSetting(ctx, key, strconv.Itoa(value))
+}
+
+// GetJSON decodifica la clave en dst. Devuelve false (sin tocar dst) si no existe.
+func (s *CredentialStore) GetJSON(ctx context.Context, key string, dst any) (bool, error) {
+	val, err := s.getSetting(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(val) == "" {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(val), dst); err != nil {
+		return false, fmt.Errorf("sqlite: decode setting %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *CredentialStore) SetJSON(ctx context.Context, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("sqlite: encode setting %s: %w", key, err)
+	}
+	return s.setSetting(ctx, key, string(data))
 }
 
 var _ domain.TTSSettingsRepository = (*CredentialStore)(nil)
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// seedLegacyCredential inserta una fila tal como la dejaban versiones viejas,
+// sin pasar por la normalización de Save.
+func seedLegacyCredential(t *testing.T, store *CredentialStore, platform, role, token string, updatedAt time.Time) {
+	t.Helper()
+	_, err := store.db.Exec(`INSERT INTO credentials (platform, role, access_token, updated_at) VALUES (?, ?, ?, ?)`,
+		platform, role, token, updatedAt)
+	if err != nil {
+		t.Fatalf("seed %s/%s: %v", platform, role, err)
+	}
+}
+
+func TestReconcileCredentialsMergesLegacyRows(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	seedLegacyCredential(t, store, "twitch", "Bot", "viejo", base)
+	seedLegacyCredential(t, store, "twitch", " bot ", "nuevo", base.Add(time.Hour))
+	seedLegacyCredential(t, store, "twitch", "bot", "medio", base.Add(time.Minute))
+	seedLegacyCredential(t, store, "Kick", "bot", "kick-bot", base.Add(2*time.Hour))
+	seedLegacyCredential(t, store, "kick", "streamer", "kick-streamer", base)
+	seedLegacyCredential(t, store, "twitch", "streamer", "ok", base)
+
+	merges, err := store.ReconcileCredentials(ctx)
+	if err != nil {
+		t.Fatalf("ReconcileCredentials: %v", err)
+	}
+	if len(merges) != 2 {
+		t.Fatalf("merges = %+v, want twitch/bot and kick/streamer", merges)
+	}
+	if m := merges[0]; m.Platform != domain.PlatformTwitch || m.Role != "bot" || m.Kept != " bot " || len(m.Removed) != 2 {
+		t.Fatalf("twitch merge = %+v", m)
+	}
+	if m := merges[1]; m.Platform != domain.PlatformKick || m.Role != "streamer" || m.Kept != "bot" || len(m.Removed) != 1 {
+		t.Fatalf("kick merge = %+v", m)
+	}
+
+	creds, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	got := make(map[string]string, len(creds))
+	for _, cred := range creds {
+		got[string(cred.Platform)+"/"+cred.Role] = cred.AccessToken
+	}
+	want := map[string]string{
+		"twitch/bot":      "nuevo",
+		"twitch/streamer": "ok",
+		"kick/streamer":   "kick-bot",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	for key, token := range want {
+		if got[key] != token {
+			t.Fatalf("%s = %q, want %q (all rows: %v)", key, got[key], token, got)
+		}
+	}
+
+	// una segunda pasada no encuentra nada
+	if again, err := store.ReconcileCredentials(ctx); err != nil || len(again) != 0 {
+		t.Fatalf("second reconcile = %+v, %v", again, err)
+	}
+}
+
+func TestReconcileCredentialsRenamesSingleRow(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	seedLegacyCredential(t, store, " Twitch", "Streamer ", "tok", time.Now())
+
+	merges, err := store.ReconcileCredentials(ctx)
+	if err != nil {
+		t.Fatalf("ReconcileCredentials: %v", err)
+	}
+	if len(merges) != 1 || len(merges[0].Removed) != 0 {
+		t.Fatalf("merges = %+v", merges)
+	}
+	cred, err := store.Get(ctx, domain.PlatformTwitch, "streamer")
+	if err != nil || cred == nil || cred.AccessToken != "tok" {
+		t.Fatalf("Get = %+v, %v", cred, err)
+	}
+}
+
+func TestCredentialKeysAreNormalized(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if err := store.Save(ctx, &domain.Credential{Platform: "Twitch", Role: " BOT ", AccessToken: "a"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save(ctx, &domain.Credential{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "b"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	creds, err := store.List(ctx)
+	if err != nil || len(creds) != 1 {
+		t.Fatalf("List = %d rows, %v; want one", len(creds), err)
+	}
+
+	cred, err := store.Get(ctx, "TWITCH", "Bot")
+	if err != nil || cred == nil || cred.AccessToken != "b" {
+		t.Fatalf("Get = %+v, %v", cred, err)
+	}
+
+	// en Kick cualquier rol es el del streamer
+	if err := store.Save(ctx, &domain.Credential{Platform: domain.PlatformKick, Role: "bot", AccessToken: "k"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if cred, err := store.Get(ctx, domain.PlatformKick, "streamer"); err != nil || cred == nil || cred.AccessToken != "k" {
+		t.Fatalf("Get kick = %+v, %v", cred, err)
+	}
+
+	if err := store.Delete(ctx, "twitch", " Bot"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if cred, err := store.Get(ctx, domain.PlatformTwitch, "bot"); err != nil || cred != nil {
+		t.Fatalf("Get after Delete = %+v, %v", cred, err)
+	}
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestCustomCommandResponsePoolRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	pool := []domain.WeightedResponse{{Text: "hola", Weight: 1}, {Text: "¡buenas!", Weight: 4}}
+	if err := store.UpsertCustomCommand(ctx, &domain.CustomCommand{Name: "saludo", Responses: pool}); err != nil {
+		t.Fatalf("UpsertCustomCommand: %v", err)
+	}
+	got, err := store.GetCustomCommand(ctx, "saludo")
+	if err != nil || got == nil {
+		t.Fatalf("GetCustomCommand = %+v, %v", got, err)
+	}
+	if len(got.Responses) != 2 || got.Responses[0] != pool[0] || got.Responses[1] != pool[1] {
+		t.Fatalf("Responses = %+v", got.Responses)
+	}
+
+	// volver a una respuesta simple borra el pool guardado
+	if err := store.UpsertCustomCommand(ctx, &domain.CustomCommand{Name: "saludo", Response: "hola"}); err != nil {
+		t.Fatalf("UpsertCustomCommand: %v", err)
+	}
+	list, err := store.ListCustomCommands(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListCustomCommands = %+v, %v", list, err)
+	}
+	if list[0].Response != "hola" || len(list[0].Responses) != 0 {
+		t.Fatalf("command = %+v", list[0])
+	}
+}
+
+func TestCustomCommandUserCooldownColumn(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bot.db")
+	store, err := NewCredentialStore(path)
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+
+	cmd := &domain.CustomCommand{Name: "redes", Response: "hola", Cooldown: 15 * time.Second, UserCooldown: time.Minute}
+	if err := store.UpsertCustomCommand(ctx, cmd); err != nil {
+		t.Fatalf("UpsertCustomCommand: %v", err)
+	}
+	got, err := store.GetCustomCommand(ctx, "redes")
+	if err != nil || got.Cooldown != 15*time.Second || got.UserCooldown != time.Minute {
+		t.Fatalf("GetCustomCommand = %+v, %v", got, err)
+	}
+
+	// una base de antes de la columna conserva sus filas al migrar
+	if _, err := store.db.Exec(`ALTER TABLE custom_commands DROP COLUMN user_cooldown_seconds;`); err != nil {
+		t.Fatalf("drop column: %v", err)
+	}
+	store.Close()
+	store, err = NewCredentialStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	list, err := store.ListCustomCommands(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListCustomCommands = %+v, %v", list, err)
+	}
+	if list[0].Response != "hola" || list[0].Cooldown != 15*time.Second || list[0].UserCooldown != 0 {
+		t.Fatalf("migrated command = %+v", list[0])
+	}
+}
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestQuoteNumbersStayStableAfterDeletes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, text := range []string{"uno", "dos", "tres"} {
+		if _, err := store.AddQuote(ctx, &domain.Quote{Text: text, AddedBy: "mod", Platform: domain.PlatformTwitch}); err != nil {
+			t.Fatalf("AddQuote: %v", err)
+		}
+	}
+
+	// borrar la del medio y la última: ningún número se vuelve a usar
+	for _, number := range []int64{2, 3} {
+		if ok, err := store.DeleteQuote(ctx, number); err != nil || !ok {
+			t.Fatalf("DeleteQuote(%d) = %v, %v", number, ok, err)
+		}
+	}
+	added, err := store.AddQuote(ctx, &domain.Quote{Text: "cuatro"})
+	if err != nil {
+		t.Fatalf("AddQuote: %v", err)
+	}
+	if added.Number != 4 {
+		t.Fatalf("number after deletes = %d, want 4", added.Number)
+	}
+
+	list, err := store.ListQuotes(ctx)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListQuotes = %+v, %v", list, err)
+	}
+	if list[0].Number != 1 || list[0].Text != "uno" || list[1].Number != 4 || list[1].Text != "cuatro" {
+		t.Fatalf("list = %+v, %+v", list[0], list[1])
+	}
+	if list[0].AddedBy != "mod" || list[0].Platform != domain.PlatformTwitch || list[0].CreatedAt.IsZero() {
+		t.Fatalf("metadata = %+v", list[0])
+	}
+
+	if got, err := store.GetQuote(ctx, 2); err != nil || got != nil {
+		t.Fatalf("GetQuote(deleted) = %+v, %v", got, err)
+	}
+	if ok, err := store.DeleteQuote(ctx, 2); err != nil || ok {
+		t.Fatalf("DeleteQuote(deleted) = %v, %v", ok, err)
+	}
+	if ok, err := store.UpdateQuote(ctx, 4, "cuatro bis"); err != nil || !ok {
+		t.Fatalf("UpdateQuote = %v, %v", ok, err)
+	}
+	if got, _ := store.GetQuote(ctx, 4); got == nil || got.Text != "cuatro bis" {
+		t.Fatalf("GetQuote after update = %+v", got)
+	}
+}
+
+func TestRandomQuote(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if got, err := store.RandomQuote(ctx); err != nil || got != nil {
+		t.Fatalf("RandomQuote(empty) = %+v, %v", got, err)
+	}
+	if _, err := store.AddQuote(ctx, &domain.Quote{Text: "única"}); err != nil {
+		t.Fatalf("AddQuote: %v", err)
+	}
+	if got, err := store.RandomQuote(ctx); err != nil || got == nil || got.Number != 1 {
+		t.Fatalf("RandomQuote = %+v, %v", got, err)
+	}
+}
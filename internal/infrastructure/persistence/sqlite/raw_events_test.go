@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestRawEventsSaveListAndPrune(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+
+	events := []domain.RawPlatformEvent{
+		{Platform: domain.PlatformTwitch, EventType: "sub", Payload: `{"event_type":"sub"}`, ReceivedAt: now.Add(-4 * 24 * time.Hour)},
+		{Platform: domain.PlatformKick, EventType: `App\Events\GiftedSubscriptionsEvent`, Payload: `{"gifted_usernames":["ana"]}`, ReceivedAt: now.Add(-time.Hour)},
+		{Platform: domain.PlatformTwitch, EventType: "raid", Payload: `{"event_type":"raid"}`, ReceivedAt: now.Add(-30 * time.Minute)},
+		{Platform: domain.PlatformTwitch, EventType: "SUB", Payload: `{"event_type":"SUB"}`, ReceivedAt: now.Add(-30 * time.Minute)},
+	}
+	if err := store.SaveRawEvents(ctx, events); err != nil {
+		t.Fatalf("SaveRawEvents: %v", err)
+	}
+	if err := store.SaveRawEvents(ctx, nil); err != nil {
+		t.Fatalf("SaveRawEvents(nil): %v", err)
+	}
+
+	all, err := store.ListRawEvents(ctx, domain.RawEventFilter{})
+	if err != nil {
+		t.Fatalf("ListRawEvents: %v", err)
+	}
+	if len(all) != 4 || all[0].EventType != "sub" || all[2].EventType != "raid" || all[3].EventType != "SUB" {
+		t.Fatalf("all = %+v, want arrival order", all)
+	}
+	if all[1].Payload != events[1].Payload || all[1].Platform != domain.PlatformKick || !all[1].ReceivedAt.Equal(events[1].ReceivedAt) {
+		t.Fatalf("fields lost: %+v", all[1])
+	}
+
+	subs, err := store.ListRawEvents(ctx, domain.RawEventFilter{Platform: domain.PlatformTwitch, EventType: "sub", Since: now.Add(-24 * time.Hour)})
+	if err != nil || len(subs) != 1 || subs[0].Payload != `{"event_type":"SUB"}` {
+		t.Fatalf("recent twitch subs = %+v, %v", subs, err)
+	}
+	first, err := store.ListRawEvents(ctx, domain.RawEventFilter{Until: now.Add(-45 * time.Minute), Limit: 1})
+	if err != nil || len(first) != 1 || first[0].EventType != "sub" {
+		t.Fatalf("until + limit = %+v, %v", first, err)
+	}
+
+	pruned, err := store.PruneRawEvents(ctx, now.Add(-domain.DefaultRawEventRetention))
+	if err != nil || pruned != 1 {
+		t.Fatalf("PruneRawEvents = %d, %v; want 1", pruned, err)
+	}
+	rest, err := store.ListRawEvents(ctx, domain.RawEventFilter{})
+	if err != nil || len(rest) != 3 || rest[0].Platform != domain.PlatformKick {
+		t.Fatalf("after prune = %+v, %v", rest, err)
+	}
+}
+
+func TestSaveRawEventsStampsMissingTime(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	before := time.Now().Add(-time.Second)
+
+	if err := store.SaveRawEvents(ctx, []domain.RawPlatformEvent{{Platform: domain.PlatformTwitch, EventType: "sub", Payload: "{}"}}); err != nil {
+		t.Fatalf("SaveRawEvents: %v", err)
+	}
+	got, err := store.ListRawEvents(ctx, domain.RawEventFilter{})
+	if err != nil || len(got) != 1 || got[0].ReceivedAt.Before(before) {
+		t.Fatalf("got = %+v, %v", got, err)
+	}
+}
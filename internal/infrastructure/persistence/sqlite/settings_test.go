@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func newTestStore(t *testing.T) *CredentialStore {
+	t.Helper()
+	store, err := NewCredentialStore(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestGetBool(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, def := range []bool{true, false} {
+		got, err := store.GetBool(ctx, "missing", def)
+		if err != nil || got != def {
+			t.Fatalf("GetBool(missing, %v) = %v, %v; want the default", def, got, err)
+		}
+	}
+
+	if err := store.SetBool(ctx, "flag", false); err != nil {
+		t.Fatalf("SetBool: %v", err)
+	}
+	if got, err := store.GetBool(ctx, "flag", true); err != nil || got {
+		t.Fatalf("GetBool(flag) = %v, %v; want false", got, err)
+	}
+
+	// valores escritos a mano o por versiones viejas
+	for raw, want := range map[string]bool{"true": true, "1": true, " FALSE ": false, "0": false} {
+		if err := store.setSetting(ctx, "legacy", raw); err != nil {
+			t.Fatalf("setSetting: %v", err)
+		}
+		if got, err := store.GetBool(ctx, "legacy", !want); err != nil || got != want {
+			t.Fatalf("GetBool(%q) = %v, %v; want %v", raw, got, err, want)
+		}
+	}
+
+	if err := store.setSetting(ctx, "broken", "quizás"); err != nil {
+		t.Fatalf("setSetting: %v", err)
+	}
+	if got, err := store.GetBool(ctx, "broken", true); err == nil || !got {
+		t.Fatalf("GetBool(broken) = %v, %v; want the default and an error", got, err)
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if got, err := store.GetInt(ctx, "missing", 7); err != nil || got != 7 {
+		t.Fatalf("GetInt(missing) = %d, %v; want 7", got, err)
+	}
+
+	for _, value := range []int{0, -3, 42} {
+		if err := store.SetInt(ctx, "n", value); err != nil {
+			t.Fatalf("SetInt: %v", err)
+		}
+		if got, err := store.GetInt(ctx, "n", 99); err != nil || got != value {
+			t.Fatalf("GetInt(n) = %d, %v; want %d", got, err, value)
+		}
+	}
+
+	if err := store.setSetting(ctx, "broken", "3.5"); err != nil {
+		t.Fatalf("setSetting: %v", err)
+	}
+	if got, err := store.GetInt(ctx, "broken", 5); err == nil || got != 5 {
+		t.Fatalf("GetInt(broken) = %d, %v; want the default and an error", got, err)
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	dst := payload{Name: "sin tocar"}
+	found, err := store.GetJSON(ctx, "missing", &dst)
+	if err != nil || found || dst.Name != "sin tocar" {
+		t.Fatalf("GetJSON(missing) = %v, %v, dst %+v; want not found and dst untouched", found, err, dst)
+	}
+
+	if err := store.SetJSON(ctx, "obj", payload{Name: "gg", Count: 3}); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	var got payload
+	if found, err := store.GetJSON(ctx, "obj", &got); err != nil || !found || got != (payload{Name: "gg", Count: 3}) {
+		t.Fatalf("GetJSON(obj) = %v, %v, %+v", found, err, got)
+	}
+
+	if err := store.setSetting(ctx, "broken", "{no es json"); err != nil {
+		t.Fatalf("setSetting: %v", err)
+	}
+	if found, err := store.GetJSON(ctx, "broken", &got); err == nil || found {
+		t.Fatalf("GetJSON(broken) = %v, %v; want an error", found, err)
+	}
+}
+
+func TestTTSSettingsDefaults(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if enabled, err := store.GetTTSEnabled(ctx); err != nil || !enabled {
+		t.Fatalf("GetTTSEnabled() = %v, %v; want enabled when never configured", enabled, err)
+	}
+	if votes, err := store.GetTTSSkipVotes(ctx); err != nil || votes != domain.DefaultTTSSkipVotes {
+		t.Fatalf("GetTTSSkipVotes() = %d, %v; want %d", votes, err, domain.DefaultTTSSkipVotes)
+	}
+	if seconds, err := store.GetTTSMaxFetchSeconds(ctx); err != nil || seconds != domain.DefaultTTSMaxFetchSeconds {
+		t.Fatalf("GetTTSMaxFetchSeconds() = %d, %v; want %d", seconds, err, domain.DefaultTTSMaxFetchSeconds)
+	}
+	if settings, err := store.GetTTSAutoPause(ctx); err != nil || settings != nil {
+		t.Fatalf("GetTTSAutoPause() = %+v, %v; want nil when never configured", settings, err)
+	}
+
+	if err := store.SetTTSEnabled(ctx, false); err != nil {
+		t.Fatalf("SetTTSEnabled: %v", err)
+	}
+	if enabled, err := store.GetTTSEnabled(ctx); err != nil || enabled {
+		t.Fatalf("GetTTSEnabled() = %v, %v; want disabled", enabled, err)
+	}
+
+	autoPause := domain.TTSAutoPauseSettings{Enabled: true, PauseAbove: 90, ResumeBelow: 20, ResumeAfterMinutes: 3}
+	if err := store.SetTTSAutoPause(ctx, autoPause); err != nil {
+		t.Fatalf("SetTTSAutoPause: %v", err)
+	}
+	if settings, err := store.GetTTSAutoPause(ctx); err != nil || settings == nil || *settings != autoPause {
+		t.Fatalf("GetTTSAutoPause() = %+v, %v; want %+v", settings, err, autoPause)
+	}
+}
+
+func TestListSettingsSortedByKey(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	if settings, err := store.ListSettings(ctx); err != nil || len(settings) != 0 {
+		t.Fatalf("ListSettings() on an empty store = %+v, %v", settings, err)
+	}
+
+	for _, kv := range [][2]string{{"tts_voice", "es"}, {"bot_paused", "false"}, {"twitch_api_token", "abc"}} {
+		if err := store.setSetting(ctx, kv[0], kv[1]); err != nil {
+			t.Fatalf("setSetting(%s): %v", kv[0], err)
+		}
+	}
+
+	settings, err := store.ListSettings(ctx)
+	if err != nil {
+		t.Fatalf("ListSettings: %v", err)
+	}
+	var keys []string
+	for _, setting := range settings {
+		keys = append(keys, setting.Key+"="+setting.Value)
+		if setting.UpdatedAt.IsZero() {
+			t.Fatalf("%s has no updated_at", setting.Key)
+		}
+	}
+	// el store no enmascara: eso lo hace la API
+	if got := strings.Join(keys, ","); got != "bot_paused=false,tts_voice=es,twitch_api_token=abc" {
+		t.Fatalf("ListSettings() = %s", got)
+	}
+}
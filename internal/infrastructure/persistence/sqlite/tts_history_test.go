@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestTTSHistoryListAndPrune(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+
+	entries := []domain.TTSHistoryEntry{
+		{ID: "old", Text: "viejo", RequestedBy: "Ana", Platform: domain.PlatformTwitch, Outcome: domain.TTSOutcomeOK, FinishedAt: now.Add(-8 * 24 * time.Hour)},
+		{ID: "skip", Text: "cortado", RequestedBy: "beto", Platform: domain.PlatformKick, Outcome: domain.TTSOutcomeSkipped, FinishedAt: now.Add(-2 * time.Hour)},
+		{ID: "new", Text: "nuevo", RequestedBy: "ana", Platform: domain.PlatformTwitch, Voice: "es-MX", Outcome: domain.TTSOutcomeOK, FinishedAt: now.Add(-time.Hour)},
+		{ID: "fail", Text: "roto", RequestedBy: "ana", Outcome: domain.TTSOutcomeFailed, Error: "timeout", FinishedAt: now.Add(-30 * time.Minute)},
+	}
+	if err := store.SaveTTSHistory(ctx, entries); err != nil {
+		t.Fatalf("SaveTTSHistory: %v", err)
+	}
+
+	got, err := store.ListTTSHistory(ctx, domain.TTSHistoryFilter{User: "ANA"})
+	if err != nil {
+		t.Fatalf("ListTTSHistory: %v", err)
+	}
+	if len(got) != 3 || got[0].ID != "fail" || got[1].ID != "new" || got[2].ID != "old" {
+		t.Fatalf("list by user = %+v, want newest first", got)
+	}
+	if got[0].Error != "timeout" || got[1].Voice != "es-MX" || !got[1].FinishedAt.Equal(entries[2].FinishedAt) {
+		t.Fatalf("fields lost: %+v", got[:2])
+	}
+
+	last, err := store.ListTTSHistory(ctx, domain.TTSHistoryFilter{Limit: 1, Outcome: domain.TTSOutcomeOK})
+	if err != nil || len(last) != 1 || last[0].ID != "new" {
+		t.Fatalf("last ok = %+v, %v", last, err)
+	}
+
+	pruned, err := store.PruneTTSHistory(ctx, now.Add(-domain.DefaultTTSHistoryRetention))
+	if err != nil || pruned != 1 {
+		t.Fatalf("PruneTTSHistory = %d, %v; want 1", pruned, err)
+	}
+	rest, err := store.ListTTSHistory(ctx, domain.TTSHistoryFilter{})
+	if err != nil {
+		t.Fatalf("ListTTSHistory: %v", err)
+	}
+	if len(rest) != 3 || rest[len(rest)-1].ID != "skip" {
+		t.Fatalf("after prune = %+v", rest)
+	}
+}
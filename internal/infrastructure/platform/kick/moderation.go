@@ -0,0 +1,144 @@
+package kickinfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	kicksdk "github.com/glichtv/kick-sdk"
+
+	"zhatBot/internal/domain"
+)
+
+// kickBanInput es el cuerpo de POST /public/v1/moderation/bans; sin duration
+// el ban es permanente.
+type kickBanInput struct {
+	BroadcasterUserID int    `json:"broadcaster_user_id"`
+	UserID            int    `json:"user_id"`
+	Duration          int    `json:"duration,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// BanUser banea (duration = 0) o silencia a userID; Kick cuenta la duración en
+// minutos. Requiere el scope moderation:ban.
+func (s *KickStreamService) BanUser(ctx context.Context, broadcasterUserID int, userID string, duration time.Duration, reason string) error {
+	id, err := strconv.Atoi(strings.TrimSpace(userID))
+	if err != nil || id <= 0 {
+		return fmt.Errorf("kick: user id inválido %q", userID)
+	}
+	input := kickBanInput{
+		BroadcasterUserID: broadcasterUserID,
+		UserID:            id,
+		Reason:            reason,
+	}
+	if duration > 0 {
+		input.Duration = int(domain.ClampTimeout(domain.PlatformKick, duration) / time.Minute)
+	}
+
+	client := s.getClient()
+	resp, err := kicksdk.NewRequest[kicksdk.EmptyResponse](ctx, client, kicksdk.RequestOptions{
+		Resource: client.NewResource(kicksdk.ResourceTypeAPI, "public/v1/moderation/bans"),
+		Method:   http.MethodPost,
+		AuthType: kicksdk.AuthTypeUserToken,
+		Body:     input,
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("kick: banear usuario: %w", err)
+	}
+	return kickModerationError("banear usuario", resp.ResponseMetadata)
+}
+
+// DeleteChatMessage borra un mensaje del chat. Requiere el scope
+// moderation:chat_message:manage.
+func (s *KickStreamService) DeleteChatMessage(ctx context.Context, messageID string) error {
+	messageID = strings.TrimSpace(messageID)
+	if messageID == "" {
+		return fmt.Errorf("kick: message id vacío")
+	}
+
+	client := s.getClient()
+	resp, err := kicksdk.NewRequest[kicksdk.EmptyResponse](ctx, client, kicksdk.RequestOptions{
+		Resource: client.NewResource(kicksdk.ResourceTypeAPI, "public/v1/chat/"+messageID),
+		Method:   http.MethodDelete,
+		AuthType: kicksdk.AuthTypeUserToken,
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("kick: borrar mensaje: %w", err)
+	}
+	return kickModerationError("borrar mensaje", resp.ResponseMetadata)
+}
+
+// LookupUserID busca el ID de un usuario por su slug. El SDK no expone el
+// filtro por slug de /public/v1/channels, así que se arma la consulta a mano.
+func (s *KickStreamService) LookupUserID(ctx context.Context, slug string) (string, error) {
+	slug = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(slug), "@"))
+	if slug == "" {
+		return "", fmt.Errorf("kick: slug vacío")
+	}
+
+	client := s.getClient()
+	endpoint := client.BaseURLs().APIBaseURL + "/public/v1/channels?slug=" + url.QueryEscape(slug)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+client.AccessTokens().UserAccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("kick: buscar canal: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("kick: buscar canal: status %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data []kicksdk.Channel `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("kick: buscar canal: %w", err)
+	}
+	if len(payload.Data) == 0 || payload.Data[0].BroadcasterUserID == 0 {
+		return "", domain.ErrModerationUserNotFound
+	}
+	return strconv.Itoa(payload.Data[0].BroadcasterUserID), nil
+}
+
+func kickModerationError(action string, meta kicksdk.ResponseMetadata) error {
+	if meta.StatusCode >= http.StatusOK && meta.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	return fmt.Errorf("kick: %s: status %d %s", action, meta.StatusCode, meta.KickMessage)
+}
+
+// KickModerationAdapter aplica las sanciones en el canal del streamer.
+type KickModerationAdapter struct {
+	svc               *KickStreamService
+	broadcasterUserID int
+}
+
+func NewKickModerationAdapter(svc *KickStreamService, broadcasterUserID int) domain.ModerationService {
+	return &KickModerationAdapter{svc: svc, broadcasterUserID: broadcasterUserID}
+}
+
+func (a *KickModerationAdapter) BanUser(ctx context.Context, userID, reason string) error {
+	return a.svc.BanUser(ctx, a.broadcasterUserID, userID, 0, reason)
+}
+
+func (a *KickModerationAdapter) TimeoutUser(ctx context.Context, userID string, duration time.Duration, reason string) error {
+	return a.svc.BanUser(ctx, a.broadcasterUserID, userID, max(duration, time.Minute), reason)
+}
+
+func (a *KickModerationAdapter) DeleteMessage(ctx context.Context, messageID string) error {
+	return a.svc.DeleteChatMessage(ctx, messageID)
+}
+
+func (a *KickModerationAdapter) ResolveUserID(ctx context.Context, login string) (string, error) {
+	return a.svc.LookupUserID(ctx, login)
+}
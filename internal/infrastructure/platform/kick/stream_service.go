@@ -153,6 +153,7 @@ func (s *KickStreamService) getClient() *kicksdk.Client {
 func (s *KickStreamService) GetStreamStatus(ctx context.Context, broadcasterUserID int) (domain.StreamStatus, error) {
 	status := domain.StreamStatus{
 		Platform: domain.PlatformKick,
+		Source:   "kick-api",
 	}
 
 	client := s.getClient()
@@ -182,3 +183,33 @@ func (s *KickStreamService) GetStreamStatus(ctx context.Context, broadcasterUser
 
 	return status, nil
 }
+
+// FetchUserProfile trae el perfil público de un usuario de Kick. La API no
+// expone si está verificado; eso sale de los badges del chat.
+func (s *KickStreamService) FetchUserProfile(ctx context.Context, userID string) (*domain.UserProfile, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(userID))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("kick: user id inválido %q", userID)
+	}
+
+	client := s.getClient()
+	resp, err := client.Users().GetByIDs(ctx, kicksdk.GetUsersByIDsInput{
+		UsersIDs: []int{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kick: obtener usuario: %w", err)
+	}
+	if len(resp.Payload) == 0 {
+		return nil, fmt.Errorf("kick: usuario %d no encontrado", id)
+	}
+
+	user := resp.Payload[0]
+	return &domain.UserProfile{
+		Platform:    domain.PlatformKick,
+		UserID:      strconv.Itoa(user.ID),
+		Login:       strings.ToLower(user.Name),
+		DisplayName: user.Name,
+		AvatarURL:   user.ProfilePicture,
+		FetchedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package kickinfra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kicksdk "github.com/glichtv/kick-sdk"
+)
+
+// ValidateToken consulta el endpoint de introspección de Kick y falla si el
+// token no está activo.
+func ValidateToken(ctx context.Context, accessToken string) error {
+	if strings.TrimSpace(accessToken) == "" {
+		return fmt.Errorf("kick access token vacío")
+	}
+
+	client := kicksdk.NewClient(
+		kicksdk.WithAccessTokens(kicksdk.AccessTokens{
+			UserAccessToken: accessToken,
+		}),
+	)
+	resp, err := client.Users().IntrospectToken(ctx)
+	if err != nil {
+		return fmt.Errorf("kick: introspect: %w", err)
+	}
+	if !resp.Payload.Active {
+		return fmt.Errorf("kick: token inactivo")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package twitchinfra
+
+import (
+	"context"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// twitchModerationAPI es la parte de TwitchStreamService que usan las sanciones.
+type twitchModerationAPI interface {
+	BanUser(ctx context.Context, broadcasterID, moderatorID, userID string, duration time.Duration, reason string) error
+	DeleteChatMessage(ctx context.Context, broadcasterID, moderatorID, messageID string) error
+	GetUserByLogin(ctx context.Context, login string) (*domain.TwitchUser, error)
+}
+
+type TwitchModerationAdapter struct {
+	svc           twitchModerationAPI
+	broadcasterID string
+	moderatorID   string
+}
+
+// NewTwitchModerationAdapter fija el canal y el moderador (el dueño del token)
+// de las sanciones.
+func NewTwitchModerationAdapter(
+	svc *TwitchStreamService,
+	broadcasterID, moderatorID string,
+) domain.ModerationService {
+	return &TwitchModerationAdapter{
+		svc:           svc,
+		broadcasterID: broadcasterID,
+		moderatorID:   moderatorID,
+	}
+}
+
+func (a *TwitchModerationAdapter) BanUser(ctx context.Context, userID, reason string) error {
+	return a.svc.BanUser(ctx, a.broadcasterID, a.moderatorID, userID, 0, reason)
+}
+
+func (a *TwitchModerationAdapter) TimeoutUser(ctx context.Context, userID string, duration time.Duration, reason string) error {
+	duration = domain.ClampTimeout(domain.PlatformTwitch, duration)
+	return a.svc.BanUser(ctx, a.broadcasterID, a.moderatorID, userID, duration, reason)
+}
+
+func (a *TwitchModerationAdapter) DeleteMessage(ctx context.Context, messageID string) error {
+	return a.svc.DeleteChatMessage(ctx, a.broadcasterID, a.moderatorID, messageID)
+}
+
+func (a *TwitchModerationAdapter) ResolveUserID(ctx context.Context, login string) (string, error) {
+	user, err := a.svc.GetUserByLogin(ctx, login)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", domain.ErrModerationUserNotFound
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,240 @@
+package twitchinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+
+	"zhatBot/internal/domain"
+)
+
+// CallPriority indica si una llamada a Helix se puede frenar cuando queda
+// poca cuota.
+type CallPriority int
+
+const (
+	// CallNormal se demora o se rechaza con cuota baja (búsquedas, follows).
+	CallNormal CallPriority = iota
+	// CallCritical pasa siempre (cambiar título, modos del chat).
+	CallCritical
+)
+
+const (
+	// DefaultQuotaThreshold es a partir de cuántos puntos restantes se frenan
+	// las llamadas no críticas (Helix da 800 por minuto).
+	DefaultQuotaThreshold = 20
+	// maxQuotaWait es lo máximo que se espera al reset antes de rechazar.
+	maxQuotaWait = 3 * time.Second
+)
+
+// ErrQuotaLow indica que se rechazó una llamada no crítica para guardar la
+// cuota que queda.
+var ErrQuotaLow = errors.New("twitch: cuota de Helix casi agotada")
+
+// Quota lleva la cuota de Helix a partir de los headers Ratelimit-* de cada
+// respuesta. Es compartida por todos los clientes; cada token usa su bucket.
+// Un *Quota nil no limita nada.
+type Quota struct {
+	threshold int
+	maxWait   time.Duration
+	now       func() time.Time
+
+	mu            sync.Mutex
+	buckets       map[string]*domain.APIQuotaState
+	aliases       map[string]string
+	onRateLimited func(domain.APIQuotaState)
+}
+
+func NewQuota(threshold int) *Quota {
+	if threshold <= 0 {
+		threshold = DefaultQuotaThreshold
+	}
+	return &Quota{
+		threshold: threshold,
+		maxWait:   maxQuotaWait,
+		now:       time.Now,
+		buckets:   make(map[string]*domain.APIQuotaState),
+		aliases:   make(map[string]string),
+	}
+}
+
+// Alias hace que bucket cuente contra target: Helix limita por cuenta, así
+// que dos tokens de la misma cuenta comparten la cuota. Con target vacío el
+// bucket vuelve a ser independiente.
+func (q *Quota) Alias(bucket, target string) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if target == "" || target == bucket {
+		delete(q.aliases, bucket)
+		return
+	}
+	q.aliases[bucket] = target
+	// lo observado con el token propio ya no refleja la cuota real
+	delete(q.buckets, bucket)
+}
+
+// resolveLocked devuelve el bucket que se usa en lugar de bucket.
+func (q *Quota) resolveLocked(bucket string) string {
+	if target, ok := q.aliases[bucket]; ok {
+		return target
+	}
+	return bucket
+}
+
+// SetRateLimitedHandler recibe el estado del bucket cada vez que Helix
+// responde 429.
+func (q *Quota) SetRateLimitedHandler(fn func(domain.APIQuotaState)) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onRateLimited = fn
+}
+
+// Client envuelve base (http.DefaultClient si es nil) para leer los headers de
+// cada respuesta. Se pasa en helix.Options.HTTPClient.
+func (q *Quota) Client(bucket string, base helix.HTTPClient) helix.HTTPClient {
+	if q == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultClient
+	}
+	return &quotaClient{quota: q, bucket: bucket, base: base}
+}
+
+// Wait deja pasar la llamada, la demora hasta el reset o la rechaza con
+// ErrQuotaLow. Las críticas pasan siempre.
+func (q *Quota) Wait(ctx context.Context, bucket string, priority CallPriority) error {
+	if q == nil || priority == CallCritical {
+		return nil
+	}
+
+	q.mu.Lock()
+	state, ok := q.buckets[q.resolveLocked(bucket)]
+	if !ok || state.Remaining >= q.threshold {
+		q.mu.Unlock()
+		return nil
+	}
+	wait := state.ResetAt.Sub(q.now())
+	if wait <= 0 {
+		// ya se repuso; el próximo header lo confirma
+		q.mu.Unlock()
+		return nil
+	}
+	if wait > q.maxWait {
+		state.Rejected++
+		q.mu.Unlock()
+		return fmt.Errorf("%w (se repone en %ds)", ErrQuotaLow, int(wait.Seconds())+1)
+	}
+	state.Delayed++
+	q.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// State devuelve el estado de cada bucket, ordenado por nombre.
+func (q *Quota) State() []domain.APIQuotaState {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]domain.APIQuotaState, 0, len(q.buckets))
+	for _, state := range q.buckets {
+		out = append(out, *state)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Bucket < out[j].Bucket })
+	return out
+}
+
+func (q *Quota) observe(bucket string, req *http.Request, resp *http.Response) {
+	limit, okLimit := headerInt(resp.Header, "Ratelimit-Limit")
+	remaining, okRemaining := headerInt(resp.Header, "Ratelimit-Remaining")
+	reset, okReset := headerInt(resp.Header, "Ratelimit-Reset")
+	limited := resp.StatusCode == http.StatusTooManyRequests
+	if !okRemaining && !limited {
+		return
+	}
+
+	q.mu.Lock()
+	bucket = q.resolveLocked(bucket)
+	state, ok := q.buckets[bucket]
+	if !ok {
+		state = &domain.APIQuotaState{Platform: domain.PlatformTwitch, Bucket: bucket}
+		q.buckets[bucket] = state
+	}
+	now := q.now()
+	state.UpdatedAt = now
+	if okLimit {
+		state.Limit = limit
+	}
+	if okRemaining {
+		state.Remaining = remaining
+	}
+	if okReset {
+		state.ResetAt = time.Unix(int64(reset), 0)
+	}
+	var handler func(domain.APIQuotaState)
+	if limited {
+		state.Remaining = 0
+		state.RateLimited++
+		state.LastRateLimited = now
+		handler = q.onRateLimited
+	}
+	snapshot := *state
+	q.mu.Unlock()
+
+	if limited {
+		log.Printf("warning: twitch: Helix respondió 429 (%s %s, bucket %s, se repone %s)",
+			req.Method, req.URL.Path, bucket, snapshot.ResetAt.Format(time.TimeOnly))
+		if handler != nil {
+			handler(snapshot)
+		}
+	}
+}
+
+func headerInt(header http.Header, key string) (int, bool) {
+	raw := header.Get(key)
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+type quotaClient struct {
+	quota  *Quota
+	bucket string
+	base   helix.HTTPClient
+}
+
+func (c *quotaClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.base.Do(req)
+	if err == nil && resp != nil {
+		c.quota.observe(c.bucket, req, resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,194 @@
+package twitchinfra
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+
+	"zhatBot/internal/domain"
+)
+
+// cyclingHeaders devuelve en cada respuesta el siguiente valor de remaining
+// (el último se repite) y el status indicado para ese paso.
+type cyclingHeaders struct {
+	mu        sync.Mutex
+	remaining []int
+	statuses  []int
+	reset     int64
+	step      int
+}
+
+func (c *cyclingHeaders) respond(*http.Request) (int, http.Header, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := min(c.step, len(c.remaining)-1)
+	c.step++
+	status := http.StatusOK
+	if i < len(c.statuses) && c.statuses[i] != 0 {
+		status = c.statuses[i]
+	}
+	header := http.Header{}
+	header.Set("Ratelimit-Limit", "800")
+	header.Set("Ratelimit-Remaining", strconv.Itoa(c.remaining[i]))
+	header.Set("Ratelimit-Reset", strconv.FormatInt(c.reset, 10))
+	return status, header, `{"data":[]}`
+}
+
+func newQuotaService(t *testing.T, quota *Quota, stub *stubHelix) *TwitchStreamService {
+	t.Helper()
+	client, err := helix.NewClient(&helix.Options{
+		ClientID:        "client",
+		UserAccessToken: "token",
+		HTTPClient:      quota.Client("streamer", stub),
+	})
+	if err != nil {
+		t.Fatalf("helix.NewClient: %v", err)
+	}
+	return &TwitchStreamService{client: client, quota: quota, bucket: "streamer"}
+}
+
+func (s *stubHelix) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func TestQuotaRejectsNonCriticalCallsWhenLow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_800_000_000, 0)
+	headers := &cyclingHeaders{remaining: []int{500, 100, 10}, reset: now.Add(time.Minute).Unix()}
+	stub := &stubHelix{respond: headers.respond}
+	quota := NewQuota(20)
+	quota.now = func() time.Time { return now }
+	svc := newQuotaService(t, quota, stub)
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.SearchCategories(ctx, "minecraft"); err != nil {
+			t.Fatalf("search %d: %v", i, err)
+		}
+	}
+	state := quota.State()
+	if len(state) != 1 || state[0].Bucket != "streamer" || state[0].Remaining != 10 || state[0].Limit != 800 {
+		t.Fatalf("state = %+v", state)
+	}
+
+	// con 10 restantes y el reset a un minuto, la búsqueda no sale
+	if _, err := svc.SearchCategories(ctx, "minecraft"); !errors.Is(err, ErrQuotaLow) {
+		t.Fatalf("err = %v, want ErrQuotaLow", err)
+	}
+	if stub.count() != 3 {
+		t.Fatalf("requests = %d, the rejected call reached Helix", stub.count())
+	}
+
+	// las críticas pasan igual
+	if err := svc.SetTitle(ctx, "123", "Nuevo título"); err != nil {
+		t.Fatalf("SetTitle: %v", err)
+	}
+	if stub.count() != 4 {
+		t.Fatalf("critical call did not reach Helix")
+	}
+	if got := quota.State()[0].Rejected; got != 1 {
+		t.Fatalf("Rejected = %d", got)
+	}
+
+	// pasado el reset se vuelve a llamar
+	now = now.Add(2 * time.Minute)
+	if _, err := svc.SearchCategories(ctx, "minecraft"); err != nil {
+		t.Fatalf("after reset: %v", err)
+	}
+}
+
+func TestQuotaDelaysWhenResetIsClose(t *testing.T) {
+	reset := time.Unix(1_800_000_000, 0)
+	quota := NewQuota(20)
+	quota.now = func() time.Time { return reset.Add(-50 * time.Millisecond) }
+	headers := &cyclingHeaders{remaining: []int{5}, reset: reset.Unix()}
+	svc := newQuotaService(t, quota, &stubHelix{respond: headers.respond})
+
+	svc.SearchCategories(context.Background(), "a")
+	start := time.Now()
+	if _, err := svc.SearchCategories(context.Background(), "b"); err != nil {
+		t.Fatalf("delayed call failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("call was not delayed (%v)", elapsed)
+	}
+	if got := quota.State()[0].Delayed; got != 1 {
+		t.Fatalf("Delayed = %d", got)
+	}
+
+	// cancelar mientras espera devuelve el error del contexto
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := quota.Wait(ctx, "streamer", CallNormal); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait with cancelled ctx = %v", err)
+	}
+}
+
+func TestQuotaRateLimitedResponse(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	headers := &cyclingHeaders{
+		remaining: []int{300, 0},
+		statuses:  []int{0, http.StatusTooManyRequests},
+		reset:     now.Add(30 * time.Second).Unix(),
+	}
+	quota := NewQuota(20)
+	quota.now = func() time.Time { return now }
+	var events []domain.APIQuotaState
+	quota.SetRateLimitedHandler(func(state domain.APIQuotaState) { events = append(events, state) })
+	svc := newQuotaService(t, quota, &stubHelix{respond: headers.respond})
+
+	svc.SearchCategories(context.Background(), "a")
+	if len(events) != 0 {
+		t.Fatalf("handler called on a 200: %+v", events)
+	}
+	svc.SearchCategories(context.Background(), "a")
+	if len(events) != 1 {
+		t.Fatalf("429 events = %d, want 1", len(events))
+	}
+	if e := events[0]; e.RateLimited != 1 || e.Remaining != 0 || !e.LastRateLimited.Equal(now) || e.Platform != domain.PlatformTwitch {
+		t.Fatalf("event = %+v", e)
+	}
+}
+
+func TestQuotaAliasSharesBucket(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	quota := NewQuota(20)
+	quota.now = func() time.Time { return now }
+	headers := &cyclingHeaders{remaining: []int{3}, reset: now.Add(time.Minute).Unix()}
+
+	quota.Alias("bot", "streamer")
+	bot := &TwitchStreamService{quota: quota, bucket: "bot"}
+	client, err := helix.NewClient(&helix.Options{ClientID: "c", UserAccessToken: "t", HTTPClient: quota.Client("bot", &stubHelix{respond: headers.respond})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.client = client
+	bot.SearchCategories(context.Background(), "a")
+
+	// lo que gastó el bot cuenta para el streamer
+	if err := quota.Wait(context.Background(), "streamer", CallNormal); !errors.Is(err, ErrQuotaLow) {
+		t.Fatalf("streamer Wait = %v, want ErrQuotaLow", err)
+	}
+
+	quota.Alias("bot", "")
+	if err := quota.Wait(context.Background(), "bot", CallNormal); err != nil {
+		t.Fatalf("unaliased bot Wait = %v", err)
+	}
+}
+
+func TestNilQuotaDoesNotLimit(t *testing.T) {
+	var quota *Quota
+	if err := quota.Wait(context.Background(), "streamer", CallNormal); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if quota.State() != nil {
+		t.Fatal("nil quota has state")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/nicklaw5/helix/v2"
 
@@ -15,12 +16,21 @@ import (
 type TwitchStreamService struct {
 	client *helix.Client
 	mu     sync.RWMutex
+	quota  *Quota
+	bucket string
 }
 
 func NewStreamService(clientID, userAccessToken string) (domain.TwitchChannelService, error) {
+	return NewStreamServiceWithQuota(clientID, userAccessToken, nil, "")
+}
+
+// NewStreamServiceWithQuota crea el servicio con la cuota compartida; bucket
+// identifica el token ("streamer", "bot").
+func NewStreamServiceWithQuota(clientID, userAccessToken string, quota *Quota, bucket string) (domain.TwitchChannelService, error) {
 	client, err := helix.NewClient(&helix.Options{
 		ClientID:        clientID,
 		UserAccessToken: userAccessToken,
+		HTTPClient:      quota.Client(bucket, nil),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("helix: NewClient: %w", err)
@@ -28,10 +38,15 @@ func NewStreamService(clientID, userAccessToken string) (domain.TwitchChannelSer
 
 	return &TwitchStreamService{
 		client: client,
+		quota:  quota,
+		bucket: bucket,
 	}, nil
 }
 
 func (s *TwitchStreamService) SetTitle(ctx context.Context, broadcasterID, newTitle string) error {
+	if err := s.wait(ctx, CallCritical); err != nil {
+		return err
+	}
 	client := s.getClient()
 	resp, err := client.EditChannelInformation(&helix.EditChannelInformationParams{
 		BroadcasterID: broadcasterID,
@@ -56,6 +71,9 @@ func (s *TwitchStreamService) UpdateCategory(ctx context.Context, broadcasterID,
 		return fmt.Errorf("empty game name")
 	}
 
+	if err := s.wait(ctx, CallCritical); err != nil {
+		return err
+	}
 	client := s.getClient()
 	gamesResp, err := client.GetGames(&helix.GamesParams{
 		Names: []string{gameName},
@@ -99,6 +117,9 @@ func (s *TwitchStreamService) SearchCategories(ctx context.Context, query string
 		return nil, fmt.Errorf("empty query")
 	}
 
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return nil, err
+	}
 	client := s.getClient()
 	resp, err := client.SearchCategories(&helix.SearchCategoriesParams{
 		Query: query,
@@ -137,6 +158,11 @@ func (s *TwitchStreamService) UpdateAccessToken(token string) {
 	s.client.SetUserAccessToken(token)
 }
 
+// wait aplica la cuota compartida antes de cada llamada.
+func (s *TwitchStreamService) wait(ctx context.Context, priority CallPriority) error {
+	return s.quota.Wait(ctx, s.bucket, priority)
+}
+
 func (s *TwitchStreamService) getClient() *helix.Client {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -146,8 +172,12 @@ func (s *TwitchStreamService) getClient() *helix.Client {
 func (s *TwitchStreamService) GetStreamStatus(ctx context.Context, broadcasterID string) (domain.StreamStatus, error) {
 	status := domain.StreamStatus{
 		Platform: domain.PlatformTwitch,
+		Source:   "helix",
 	}
 
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return status, err
+	}
 	client := s.getClient()
 	resp, err := client.GetStreams(&helix.StreamsParams{
 		UserIDs: []string{broadcasterID},
@@ -177,23 +207,283 @@ func (s *TwitchStreamService) GetStreamStatus(ctx context.Context, broadcasterID
 }
 
 func (s *TwitchStreamService) IsFollower(ctx context.Context, broadcasterID, userID string) (bool, error) {
+	followedAt, err := s.FollowedAt(ctx, broadcasterID, userID)
+	return !followedAt.IsZero(), err
+}
+
+// FollowedAt usa Get Channel Followers (el reemplazo de Get Users Follows);
+// necesita moderator:read:followers en el token del streamer.
+func (s *TwitchStreamService) FollowedAt(ctx context.Context, broadcasterID, userID string) (time.Time, error) {
+	broadcasterID = strings.TrimSpace(broadcasterID)
+	userID = strings.TrimSpace(userID)
+	if broadcasterID == "" || userID == "" {
+		return time.Time{}, nil
+	}
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return time.Time{}, err
+	}
 	client := s.getClient()
+
+	resp, err := client.GetChannelFollows(&helix.GetChannelFollowsParams{
+		BroadcasterID: broadcasterID,
+		UserID:        userID,
+		First:         1,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("helix: GetChannelFollows: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("helix: GetChannelFollows failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	for _, follow := range resp.Data.Channels {
+		if follow.UserID == userID {
+			return follow.Followed.Time, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+// IsSubscriber necesita channel:read:subscriptions en el token del streamer.
+func (s *TwitchStreamService) IsSubscriber(ctx context.Context, broadcasterID, userID string) (bool, error) {
 	broadcasterID = strings.TrimSpace(broadcasterID)
 	userID = strings.TrimSpace(userID)
 	if broadcasterID == "" || userID == "" {
 		return false, nil
 	}
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return false, err
+	}
+	client := s.getClient()
 
-	resp, err := client.GetUsersFollows(&helix.UsersFollowsParams{
-		ToID:   broadcasterID,
-		FromID: userID,
-		First:  1,
+	resp, err := client.GetSubscriptions(&helix.SubscriptionsParams{
+		BroadcasterID: broadcasterID,
+		UserID:        []string{userID},
 	})
 	if err != nil {
-		return false, fmt.Errorf("helix: GetUsersFollows: %w", err)
+		return false, fmt.Errorf("helix: GetSubscriptions: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("helix: GetUsersFollows failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+		return false, fmt.Errorf("helix: GetSubscriptions failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	return len(resp.Data.Subscriptions) > 0, nil
+}
+
+// IsModerator necesita moderation:read en el token del streamer. El dueño
+// del canal no figura como moderador.
+func (s *TwitchStreamService) IsModerator(ctx context.Context, broadcasterID, userID string) (bool, error) {
+	broadcasterID = strings.TrimSpace(broadcasterID)
+	userID = strings.TrimSpace(userID)
+	if broadcasterID == "" || userID == "" {
+		return false, nil
+	}
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return false, err
+	}
+	client := s.getClient()
+
+	resp, err := client.GetModerators(&helix.GetModeratorsParams{
+		BroadcasterID: broadcasterID,
+		UserIDs:       []string{userID},
+	})
+	if err != nil {
+		return false, fmt.Errorf("helix: GetModerators: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("helix: GetModerators failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	return len(resp.Data.Moderators) > 0, nil
+}
+
+func (s *TwitchStreamService) GetUserByLogin(ctx context.Context, login string) (*domain.TwitchUser, error) {
+	login = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+	if login == "" {
+		return nil, fmt.Errorf("empty login")
+	}
+
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return nil, err
+	}
+	client := s.getClient()
+	resp, err := client.GetUsers(&helix.UsersParams{
+		Logins: []string{login},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("helix: GetUsers: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("helix: GetUsers failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	if len(resp.Data.Users) == 0 {
+		return nil, nil
+	}
+
+	user := resp.Data.Users[0]
+	return &domain.TwitchUser{
+		ID:          user.ID,
+		Login:       user.Login,
+		DisplayName: user.DisplayName,
+		CreatedAt:   user.CreatedAt.Time,
+	}, nil
+}
+
+// BanUser banea (duration = 0) o silencia a userID en el canal. moderatorID
+// debe ser el dueño del token; requiere moderator:manage:banned_users.
+func (s *TwitchStreamService) BanUser(ctx context.Context, broadcasterID, moderatorID, userID string, duration time.Duration, reason string) error {
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return err
+	}
+	client := s.getClient()
+
+	resp, err := client.BanUser(&helix.BanUserParams{
+		BroadcasterID: broadcasterID,
+		ModeratorId:   moderatorID,
+		Body: helix.BanUserRequestBody{
+			Duration: int(duration / time.Second),
+			Reason:   reason,
+			UserId:   userID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("helix: BanUser: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("helix: BanUser failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	return nil
+}
+
+// DeleteChatMessage borra un mensaje del chat; requiere
+// moderator:manage:chat_messages.
+func (s *TwitchStreamService) DeleteChatMessage(ctx context.Context, broadcasterID, moderatorID, messageID string) error {
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return err
+	}
+	client := s.getClient()
+
+	resp, err := client.DeleteChatMessage(&helix.DeleteChatMessageParams{
+		BroadcasterID: broadcasterID,
+		ModeratorID:   moderatorID,
+		MessageID:     messageID,
+	})
+	if err != nil {
+		return fmt.Errorf("helix: DeleteChatMessage: %w", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("helix: DeleteChatMessage failed (%d: %s) %s", resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	return nil
+}
+
+func (s *TwitchStreamService) SetSlowMode(ctx context.Context, broadcasterID, moderatorID string, seconds int) error {
+	enabled := seconds > 0
+	params := &helix.UpdateChatSettingsParams{
+		SlowMode: &enabled,
+	}
+	if enabled {
+		params.SlowModeWaitTime = &seconds
+	}
+	return s.updateChatSettings(ctx, broadcasterID, moderatorID, params)
+}
+
+func (s *TwitchStreamService) SetEmoteOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool) error {
+	return s.updateChatSettings(ctx, broadcasterID, moderatorID, &helix.UpdateChatSettingsParams{
+		EmoteMode: &enabled,
+	})
+}
+
+func (s *TwitchStreamService) SetFollowersOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool, minutes int) error {
+	params := &helix.UpdateChatSettingsParams{
+		FollowerMode: &enabled,
+	}
+	if enabled {
+		params.FollowerModeDuration = &minutes
+	}
+	return s.updateChatSettings(ctx, broadcasterID, moderatorID, params)
+}
+
+func (s *TwitchStreamService) updateChatSettings(ctx context.Context, broadcasterID, moderatorID string, params *helix.UpdateChatSettingsParams) error {
+	if moderatorID == "" {
+		moderatorID = broadcasterID
+	}
+	params.BroadcasterID = broadcasterID
+	params.ModeratorID = moderatorID
+	if err := s.wait(ctx, CallCritical); err != nil {
+		return err
+	}
+
+	client := s.getClient()
+	resp, err := client.UpdateChatSettings(params)
+	if err != nil {
+		return fmt.Errorf("helix: UpdateChatSettings: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("helix: UpdateChatSettings failed (%d: %s) %s",
+			resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+	return nil
+}
+
+func (s *TwitchStreamService) SendAnnouncement(ctx context.Context, broadcasterID, moderatorID, text, color string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("empty announcement")
+	}
+	if moderatorID == "" {
+		moderatorID = broadcasterID
+	}
+	if err := s.wait(ctx, CallCritical); err != nil {
+		return err
+	}
+
+	client := s.getClient()
+	resp, err := client.SendChatAnnouncement(&helix.SendChatAnnouncementParams{
+		BroadcasterID: broadcasterID,
+		ModeratorID:   moderatorID,
+		Message:       text,
+		Color:         strings.ToLower(strings.TrimSpace(color)),
+	})
+	if err != nil {
+		return fmt.Errorf("helix: SendChatAnnouncement: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("helix: SendChatAnnouncement (%d) %s: %w", resp.StatusCode, resp.ErrorMessage, domain.ErrTwitchMissingScope)
+	default:
+		return fmt.Errorf("helix: SendChatAnnouncement failed (%d: %s) %s",
+			resp.StatusCode, resp.Error, resp.ErrorMessage)
+	}
+}
+
+func (s *TwitchStreamService) SendWhisper(ctx context.Context, fromUserID, toUserID, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("empty whisper")
+	}
+	if fromUserID == "" || toUserID == "" {
+		return fmt.Errorf("whisper sin remitente o destinatario")
+	}
+	if err := s.wait(ctx, CallNormal); err != nil {
+		return err
+	}
+
+	client := s.getClient()
+	resp, err := client.SendUserWhisper(&helix.SendUserWhisperParams{
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Message:    text,
+	})
+	if err != nil {
+		return fmt.Errorf("helix: SendUserWhisper: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("helix: SendUserWhisper (%d) %s: %w", resp.StatusCode, resp.ErrorMessage, domain.ErrTwitchMissingScope)
+	default:
+		return fmt.Errorf("helix: SendUserWhisper failed (%d: %s) %s",
+			resp.StatusCode, resp.Error, resp.ErrorMessage)
 	}
-	return resp.Data.Total > 0, nil
 }
@@ -0,0 +1,130 @@
+package twitchinfra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2"
+
+	"zhatBot/internal/domain"
+)
+
+// stubHelix responde las llamadas a Helix sin salir a la red.
+type stubHelix struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	respond  func(req *http.Request) (int, http.Header, string)
+}
+
+func (s *stubHelix) Do(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		raw, _ := io.ReadAll(req.Body)
+		body = string(raw)
+	}
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.bodies = append(s.bodies, body)
+	respond := s.respond
+	s.mu.Unlock()
+
+	status, header, payload := http.StatusOK, http.Header{}, `{"data":[]}`
+	if respond != nil {
+		status, header, payload = respond(req)
+		if header == nil {
+			header = http.Header{}
+		}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(payload)),
+		Request:    req,
+	}, nil
+}
+
+func (s *stubHelix) lastRequest() (*http.Request, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return nil, ""
+	}
+	return s.requests[len(s.requests)-1], s.bodies[len(s.bodies)-1]
+}
+
+func newStubService(t *testing.T, stub *stubHelix) *TwitchStreamService {
+	t.Helper()
+	client, err := helix.NewClient(&helix.Options{
+		ClientID:        "client",
+		UserAccessToken: "token",
+		HTTPClient:      stub,
+	})
+	if err != nil {
+		t.Fatalf("helix.NewClient: %v", err)
+	}
+	return &TwitchStreamService{client: client}
+}
+
+func TestSendAnnouncement(t *testing.T) {
+	stub := &stubHelix{respond: func(*http.Request) (int, http.Header, string) {
+		return http.StatusNoContent, nil, ""
+	}}
+	svc := newStubService(t, stub)
+
+	if err := svc.SendAnnouncement(context.Background(), "123", "", "  Hola chat  ", " Blue "); err != nil {
+		t.Fatalf("SendAnnouncement: %v", err)
+	}
+	req, body := stub.lastRequest()
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/chat/announcements") {
+		t.Fatalf("request = %s %s", req.Method, req.URL.Path)
+	}
+	q := req.URL.Query()
+	// sin moderador se usa el broadcaster
+	if q.Get("broadcaster_id") != "123" || q.Get("moderator_id") != "123" {
+		t.Fatalf("query = %v", q)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("body %q: %v", body, err)
+	}
+	if payload["message"] != "Hola chat" || payload["color"] != "blue" {
+		t.Fatalf("payload = %v", payload)
+	}
+
+	if err := svc.SendAnnouncement(context.Background(), "123", "456", "   ", ""); err == nil {
+		t.Fatalf("empty announcement accepted")
+	}
+}
+
+func TestSendAnnouncementErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		missingScope bool
+	}{
+		{"unauthorized", http.StatusUnauthorized, true},
+		{"forbidden", http.StatusForbidden, true},
+		{"server error", http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubHelix{respond: func(*http.Request) (int, http.Header, string) {
+				return tt.status, nil, `{"error":"x","status":1,"message":"nope"}`
+			}}
+			err := newStubService(t, stub).SendAnnouncement(context.Background(), "123", "456", "hola", "")
+			if err == nil {
+				t.Fatalf("status %d accepted", tt.status)
+			}
+			if got := errors.Is(err, domain.ErrTwitchMissingScope); got != tt.missingScope {
+				t.Fatalf("missing scope = %v, want %v (%v)", got, tt.missingScope, err)
+			}
+		})
+	}
+}
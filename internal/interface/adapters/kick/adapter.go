@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	kicksdk "github.com/glichtv/kick-sdk"
 	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
@@ -29,18 +31,48 @@ type Config struct {
 
 	// EventHandler permite interceptar cualquier mensaje crudo del chatroom (subs, tips, etc.)
 	EventHandler EventHandler
+
+	// Role es el rol de la credencial que respalda el adaptador ("streamer" o
+	// "bot"); decide con qué PosterType se publica en el chat.
+	Role string
+
+	// ConnectionHandler se llama cuando el WS del chat se conecta o se cae.
+	ConnectionHandler ConnectionHandler
+
+	// StatusHandler recibe cada cambio de estado de la conexión (conectando,
+	// conectado, reconectando, detenido).
+	StatusHandler StatusHandler
+
+	// SendTimeout limita cada envío al chat (DefaultSendTimeout si es 0).
+	SendTimeout time.Duration
+
+	// DeletionHandler recibe los borrados de la moderación (mensaje borrado,
+	// ban/timeout o chat limpiado).
+	DeletionHandler DeletionHandler
+}
+
+// DefaultSendTimeout evita que una llamada colgada a la API de Kick bloquee al
+// comando que responde.
+const DefaultSendTimeout = 10 * time.Second
+
+// chatPoster es la parte del SDK que se usa para publicar (permite reemplazarla).
+type chatPoster interface {
+	PostMessage(ctx context.Context, input kicksdk.PostChatMessageInput) (kicksdk.Response[kicksdk.PostChatMessageOutput], error)
 }
 
 type MessageHandler func(ctx context.Context, msg domain.Message) error
 type EventHandler func(msg kickchatwrapper.ChatMessage)
+type ConnectionHandler func(connected bool)
+type DeletionHandler func(domain.ChatDeletion)
 
 type Adapter struct {
 	cfg     Config
 	handler MessageHandler
 
-	mu  sync.RWMutex
-	sdk *kicksdk.Client
-	ws  *kickchatwrapper.Client
+	mu     sync.RWMutex
+	sdk    *kicksdk.Client
+	poster chatPoster
+	status Status
 }
 
 func NewAdapter(cfg Config) *Adapter {
@@ -53,10 +85,9 @@ func (a *Adapter) SetHandler(h MessageHandler) {
 	a.handler = h
 }
 
+// Start se conecta al chatroom y queda escuchando hasta que ctx se cancela;
+// si el WS se cae, vuelve a conectar solo (ver runChat).
 func (a *Adapter) Start(ctx context.Context) error {
-	if a.cfg.AccessToken == "" {
-		return errors.New("kick: AccessToken vacío")
-	}
 	if a.cfg.ChatroomID == 0 {
 		return errors.New("kick: ChatroomID no configurado")
 	}
@@ -64,75 +95,27 @@ func (a *Adapter) Start(ctx context.Context) error {
 		return errors.New("kick: BroadcasterUserID no configurado")
 	}
 
-	// Cliente para enviar mensajes (REST / SDK oficial)
-	sdkClient := kicksdk.NewClient(
+	// El cliente para enviar (REST / SDK oficial) se arma con el token leído
+	// en la misma sección que lo guarda, así un UpdateAccessToken concurrente
+	// no queda pisado. Las reconexiones del chat no lo tocan.
+	a.mu.Lock()
+	if a.cfg.AccessToken == "" {
+		a.mu.Unlock()
+		return errors.New("kick: AccessToken vacío")
+	}
+	a.sdk = kicksdk.NewClient(
 		kicksdk.WithAccessTokens(kicksdk.AccessTokens{
 			UserAccessToken: a.cfg.AccessToken,
 		}),
 	)
-
-	// Cliente WebSocket para escuchar el chat
-	wsClient, err := kickchatwrapper.NewClient()
-	if err != nil {
-		return fmt.Errorf("kick: error creando ws client: %w", err)
-	}
-
-	if err := wsClient.JoinChannelByID(a.cfg.ChatroomID); err != nil {
-		return fmt.Errorf("kick: JoinChannelByID: %w", err)
-	}
-
-	msgChan := wsClient.ListenForMessages()
-
-	a.mu.Lock()
-	a.sdk = sdkClient
-	a.ws = wsClient
+	a.poster = a.sdk.Chat()
 	a.mu.Unlock()
 
-	log.Printf("kick: conectado al chatroom %d (broadcasterUserID=%d)", a.cfg.ChatroomID, a.cfg.BroadcasterUserID)
-
-	// Goroutine para leer mensajes del websocket y mandarlos a tu usecase
-	go func() {
-		for {
-			select {
-			case m, ok := <-msgChan:
-				if !ok {
-					log.Println("kick: canal de mensajes cerrado")
-					return
-				}
-
-				if h := a.cfg.EventHandler; h != nil {
-					go h(m)
-				}
-
-				a.mu.RLock()
-				handler := a.handler
-				a.mu.RUnlock()
-				if handler == nil {
-					continue
-				}
-
-				dmsg := mapChatMessageToDomain(m, a.cfg.BroadcasterUserID)
-
-				if err := handler(ctx, dmsg); err != nil {
-					log.Printf("kick: error en handler: %v", err)
-				}
-
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-
-	// Esperar a que cierren el contexto (igual que en Twitch)
-	<-ctx.Done()
-
-	a.mu.Lock()
-	if a.ws != nil {
-		a.ws.Close()
+	if onDelete := a.cfg.DeletionHandler; onDelete != nil {
+		go a.listenDeletions(ctx, onDelete)
 	}
-	a.mu.Unlock()
 
-	return ctx.Err()
+	return a.runChat(ctx)
 }
 
 func (a *Adapter) SendMessage(ctx context.Context, platform domain.Platform, channelID, text string) error {
@@ -141,10 +124,10 @@ func (a *Adapter) SendMessage(ctx context.Context, platform domain.Platform, cha
 	}
 
 	a.mu.RLock()
-	client := a.sdk
+	poster := a.poster
 	a.mu.RUnlock()
 
-	if client == nil {
+	if poster == nil {
 		return errors.New("kick: cliente SDK no inicializado (Start no llamado o falló)")
 	}
 	if text == "" {
@@ -154,34 +137,110 @@ func (a *Adapter) SendMessage(ctx context.Context, platform domain.Platform, cha
 		return errors.New("kick: BroadcasterUserID no configurado")
 	}
 
-	log.Printf("Kick -> Chat.PostMessage(broadcasterUserID=%d): %s", a.cfg.BroadcasterUserID, text)
+	// el envío respeta el contexto de quien responde y además tiene su propio
+	// límite, compartido con el reintento
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := a.cfg.SendTimeout
+	if timeout <= 0 {
+		timeout = DefaultSendTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	primary := posterTypeForRole(a.cfg.Role)
+	err := a.postMessage(ctx, poster, primary, text)
+
+	var rejected *domain.ErrKickRejected
+	if err == nil || !errors.As(err, &rejected) || !shouldRetryPoster(rejected) {
+		return err
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+
+	alternate := alternatePosterType(primary)
+	log.Printf("kick: reintentando como %s tras rechazo con %s", alternate, primary)
+	if retryErr := a.postMessage(ctx, poster, alternate, text); retryErr != nil {
+		log.Printf("kick: el reintento como %s también falló: %v", alternate, retryErr)
+		return err
+	}
+	return nil
+}
+
+func (a *Adapter) postMessage(ctx context.Context, poster chatPoster, posterType kicksdk.MessagePosterType, text string) error {
+	log.Printf("Kick -> Chat.PostMessage(broadcasterUserID=%d, type=%s): %s", a.cfg.BroadcasterUserID, posterType, text)
 
-	resp, err := client.Chat().PostMessage(ctx, kicksdk.PostChatMessageInput{
+	resp, err := poster.PostMessage(ctx, kicksdk.PostChatMessageInput{
 		BroadcasterUserID: a.cfg.BroadcasterUserID,
 		Content:           text,
-		PosterType:        kicksdk.MessagePosterUser,
+		PosterType:        posterType,
 	})
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("kick: envío cancelado: %w", ctxErr)
+		}
 		return fmt.Errorf("kick: error enviando mensaje de chat: %w", err)
 	}
 
 	if !resp.Payload.IsSent {
 		meta := resp.ResponseMetadata
 		log.Printf(
-			"kick: PostMessage rechazado (status=%d, message_id=%s, kick_message=%q, kick_error=%q, description=%q)",
+			"kick: PostMessage rechazado (status=%d, type=%s, message_id=%s, kick_message=%q, kick_error=%q, description=%q)",
 			meta.StatusCode,
+			posterType,
 			resp.Payload.MessageID,
 			meta.KickMessage,
 			meta.KickError,
 			meta.KickErrorDescription,
 		)
-		return fmt.Errorf("kick: mensaje no fue aceptado por la API (status %d)", meta.StatusCode)
+		description := meta.KickErrorDescription
+		if description == "" {
+			description = meta.KickMessage
+		}
+		return &domain.ErrKickRejected{
+			Status:      meta.StatusCode,
+			KickError:   meta.KickError,
+			Description: description,
+			PosterType:  string(posterType),
+		}
 	}
 
 	log.Printf("kick: mensaje entregado (message_id=%s)", resp.Payload.MessageID)
 	return nil
 }
 
+// posterTypeForRole: con el token del streamer se publica como usuario; con el
+// de un bot, como bot.
+func posterTypeForRole(role string) kicksdk.MessagePosterType {
+	if strings.EqualFold(strings.TrimSpace(role), "bot") {
+		return kicksdk.MessagePosterBot
+	}
+	return kicksdk.MessagePosterUser
+}
+
+func alternatePosterType(t kicksdk.MessagePosterType) kicksdk.MessagePosterType {
+	if t == kicksdk.MessagePosterBot {
+		return kicksdk.MessagePosterUser
+	}
+	return kicksdk.MessagePosterBot
+}
+
+// shouldRetryPoster: Kick responde 403 cuando el token no puede publicar con ese
+// tipo, y 400/422 cuando el campo "type" no es válido para la cuenta.
+func shouldRetryPoster(err *domain.ErrKickRejected) bool {
+	switch err.Status {
+	case http.StatusForbidden:
+		return true
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		detail := strings.ToLower(err.KickError + " " + err.Description)
+		return strings.Contains(detail, "type") || strings.Contains(detail, "poster")
+	default:
+		return false
+	}
+}
+
 func (a *Adapter) UpdateAccessToken(token string) {
 	token = strings.TrimSpace(token)
 	if token == "" {
@@ -191,6 +250,7 @@ func (a *Adapter) UpdateAccessToken(token string) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	// si Start todavía no armó el cliente, lo arma con este token
 	a.cfg.AccessToken = token
 	if a.sdk != nil {
 		a.sdk = kicksdk.NewClient(
@@ -198,16 +258,23 @@ func (a *Adapter) UpdateAccessToken(token string) {
 				UserAccessToken: token,
 			}),
 		)
+		a.poster = a.sdk.Chat()
 	}
 }
 
+// isBlankChatMessage detecta los eventos que no son mensajes (borrados, bans,
+// etc.): el wrapper los decodifica igual y llegan sin remitente ni texto.
+func isBlankChatMessage(m kickchatwrapper.ChatMessage) bool {
+	return m.Sender.ID == 0 && strings.TrimSpace(m.Content) == ""
+}
+
 func mapChatMessageToDomain(m kickchatwrapper.ChatMessage, broadcasterUserID int) domain.Message {
 	// TODO: log.Println(m)
 	sender := m.Sender
 
 	isOwner := sender.ID == broadcasterUserID
 
-	var isMod, isVip, isSubscriber bool
+	var isMod, isVip, isSubscriber, isVerified bool
 	for _, b := range sender.Identity.Badges {
 		switch strings.ToLower(b.Type) {
 		case "moderator":
@@ -217,17 +284,24 @@ func mapChatMessageToDomain(m kickchatwrapper.ChatMessage, broadcasterUserID int
 		case "broadcaster":
 			// a veces Kick marca esto en badges también
 			isMod = true
-		case "subscriber":
+		case "subscriber", "founder":
+			// founder es el badge de los primeros suscriptores
 			isSubscriber = true
+		case "verified":
+			isVerified = true
 		}
 	}
 
 	return domain.Message{
-		Platform:  domain.PlatformKick,
-		ChannelID: strconv.Itoa(m.ChatroomID), // o puedes guardar el slug en Config si quieres
-		UserID:    strconv.Itoa(sender.ID),
-		Username:  sender.Username,
-		Text:      m.Content,
+		PlatformMessageID: m.ID,
+		Platform:          domain.PlatformKick,
+		ChannelID:         strconv.Itoa(m.ChatroomID), // o puedes guardar el slug en Config si quieres
+		UserID:            strconv.Itoa(sender.ID),
+		Username:          sender.Username,
+		Text:              m.Content,
+
+		Login:       strings.ToLower(sender.Slug),
+		DisplayName: sender.Username,
 
 		IsPrivate: false,
 
@@ -236,5 +310,8 @@ func mapChatMessageToDomain(m kickchatwrapper.ChatMessage, broadcasterUserID int
 		IsPlatformMod:   isMod,
 		IsPlatformVip:   isVip,
 		IsSubscriber:    isSubscriber,
+		IsVerified:      isVerified,
+
+		Emotes: domain.CountKickEmotes(m.Content),
 	}
 }
@@ -0,0 +1,77 @@
+package kickadapter
+
+import (
+	"testing"
+
+	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+
+	"zhatBot/internal/domain"
+)
+
+func TestMapChatMessageSeparatesLoginAndDisplayName(t *testing.T) {
+	msg := mapChatMessageToDomain(kickchatwrapper.ChatMessage{
+		ID:         "m1",
+		ChatroomID: 99,
+		Content:    "hola",
+		Sender: kickchatwrapper.Sender{
+			ID:       5,
+			Username: "Zero_Project",
+			Slug:     "Zero-Project",
+		},
+	}, 1)
+
+	if msg.Platform != domain.PlatformKick {
+		t.Fatalf("Platform = %q, want kick", msg.Platform)
+	}
+	if msg.Login != "zero-project" {
+		t.Fatalf("Login = %q, want zero-project", msg.Login)
+	}
+	if msg.DisplayName != "Zero_Project" || msg.Username != "Zero_Project" {
+		t.Fatalf("DisplayName/Username = %q/%q, want Zero_Project", msg.DisplayName, msg.Username)
+	}
+	if msg.ChannelID != "99" || msg.UserID != "5" {
+		t.Fatalf("ChannelID/UserID = %q/%q", msg.ChannelID, msg.UserID)
+	}
+}
+
+func TestMapChatMessageOwnerFlags(t *testing.T) {
+	msg := mapChatMessageToDomain(kickchatwrapper.ChatMessage{
+		Sender: kickchatwrapper.Sender{ID: 1, Username: "Streamer", Slug: "streamer"},
+	}, 1)
+
+	if !msg.IsPlatformOwner || !msg.IsPlatformAdmin {
+		t.Fatalf("owner flags = %v/%v, want true/true", msg.IsPlatformOwner, msg.IsPlatformAdmin)
+	}
+}
+
+func TestMapChatMessageSubscriber(t *testing.T) {
+	cases := []struct {
+		name   string
+		badges []string
+		want   bool
+	}{
+		{"subscriber", []string{"subscriber"}, true},
+		{"founder", []string{"founder", "og"}, true},
+		{"vip only", []string{"vip"}, false},
+		{"no badges", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var badges []kickchatwrapper.Badge
+			for _, badge := range tc.badges {
+				badges = append(badges, kickchatwrapper.Badge{Type: badge, Text: badge})
+			}
+			msg := mapChatMessageToDomain(kickchatwrapper.ChatMessage{
+				Sender: kickchatwrapper.Sender{
+					ID:       5,
+					Username: "Ana",
+					Slug:     "ana",
+					Identity: kickchatwrapper.Identity{Badges: badges},
+				},
+			}, 1)
+			if msg.IsSubscriber != tc.want {
+				t.Fatalf("IsSubscriber = %v, want %v", msg.IsSubscriber, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package kickadapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+)
+
+// ConnectionState es el estado del WS del chat.
+type ConnectionState string
+
+const (
+	StateConnecting   ConnectionState = "connecting"
+	StateConnected    ConnectionState = "connected"
+	StateReconnecting ConnectionState = "reconnecting"
+	StateStopped      ConnectionState = "stopped"
+)
+
+// Status describe la conexión al chatroom. Attempt cuenta los reintentos
+// seguidos; RetryAt es cuándo se vuelve a intentar si está reconectando.
+type Status struct {
+	State     ConnectionState `json:"state"`
+	Since     time.Time       `json:"since"`
+	Attempt   int             `json:"attempt,omitempty"`
+	LastError string          `json:"last_error,omitempty"`
+	RetryAt   time.Time       `json:"retry_at,omitempty"`
+}
+
+type StatusHandler func(Status)
+
+const (
+	// reconnectBaseDelay es la primera espera antes de reconectar; se duplica
+	// en cada intento fallido hasta reconnectMaxDelay.
+	reconnectBaseDelay = 2 * time.Second
+	reconnectMaxDelay  = 2 * time.Minute
+	// reconnectJitter es la fracción de la espera que se sortea para que
+	// varias instancias no reconecten a la vez.
+	reconnectJitter = 0.2
+	// stableConnection es cuánto tiene que durar una conexión para volver a
+	// empezar el backoff desde cero.
+	stableConnection = time.Minute
+	// chatReadTimeout: pusher manda pusher:ping a los 120 s sin tráfico; si en
+	// este tiempo no llega nada la conexión se da por muerta.
+	chatReadTimeout = 3 * time.Minute
+)
+
+const (
+	pusherPingEvent       = "pusher:ping"
+	pusherSubscribedEvent = "pusher_internal:subscription_succeeded"
+	pusherProtocolPrefix  = "pusher:"
+	pusherInternalPrefix  = "pusher_internal:"
+)
+
+// Status devuelve el estado actual de la conexión al chat.
+func (a *Adapter) Status() Status {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	status := a.status
+	if status.State == "" {
+		status.State = StateStopped
+	}
+	return status
+}
+
+func (a *Adapter) setStatus(status Status) {
+	if status.Since.IsZero() {
+		status.Since = time.Now()
+	}
+	a.mu.Lock()
+	a.status = status
+	a.mu.Unlock()
+	if onStatus := a.cfg.StatusHandler; onStatus != nil {
+		onStatus(status)
+	}
+}
+
+// runChat mantiene la conexión al chatroom: si se cae, vuelve a conectar y a
+// unirse con backoff exponencial hasta que ctx se cancela.
+func (a *Adapter) runChat(ctx context.Context) error {
+	connected := false
+	setConnected := func(up bool) {
+		if up == connected {
+			return
+		}
+		connected = up
+		if onConn := a.cfg.ConnectionHandler; onConn != nil {
+			onConn(up)
+		}
+	}
+	defer setConnected(false)
+	defer a.setStatus(Status{State: StateStopped})
+
+	a.setStatus(Status{State: StateConnecting})
+	attempt := 0
+	for {
+		var since time.Time
+		err := a.readChat(ctx, func() {
+			since = time.Now()
+			log.Printf("kick: conectado al chatroom %d (broadcasterUserID=%d)", a.cfg.ChatroomID, a.cfg.BroadcasterUserID)
+			a.setStatus(Status{State: StateConnected, Since: since})
+			setConnected(true)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		setConnected(false)
+		if !since.IsZero() && time.Since(since) >= stableConnection {
+			attempt = 0
+		}
+
+		delay := withJitter(reconnectDelay(attempt))
+		attempt++
+		log.Printf("kick: chat desconectado (%v); reintentando en %s", err, delay.Round(time.Millisecond))
+		a.setStatus(Status{
+			State:     StateReconnecting,
+			Attempt:   attempt,
+			LastError: err.Error(),
+			RetryAt:   time.Now().Add(delay),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// readChat abre una conexión, se une al chatroom y despacha los mensajes
+// hasta que se cae (devuelve el error) o se cancela ctx. onConnected se llama
+// cuando pusher confirma la suscripción.
+func (a *Adapter) readChat(ctx context.Context, onConnected func()) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, kickchatwrapper.APIURL, nil)
+	if err != nil {
+		return fmt.Errorf("kick: no pude conectar al chat: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	defer conn.Close()
+
+	subscribe := map[string]any{
+		"event": "pusher:subscribe",
+		"data": map[string]string{
+			"channel": "chatrooms." + strconv.Itoa(a.cfg.ChatroomID) + ".v2",
+			"auth":    "",
+		},
+	}
+	if err := conn.WriteJSON(subscribe); err != nil {
+		return fmt.Errorf("kick: no pude unirme al chatroom %d: %w", a.cfg.ChatroomID, err)
+	}
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(chatReadTimeout)); err != nil {
+			return err
+		}
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var frame pusherFrame
+		if err := json.Unmarshal(raw, &frame); err != nil {
+			continue
+		}
+		switch {
+		case frame.Event == pusherPingEvent:
+			if err := conn.WriteJSON(map[string]any{"event": "pusher:pong", "data": map[string]any{}}); err != nil {
+				return err
+			}
+			continue
+		case frame.Event == pusherSubscribedEvent:
+			onConnected()
+			continue
+		case strings.HasPrefix(frame.Event, pusherProtocolPrefix), strings.HasPrefix(frame.Event, pusherInternalPrefix):
+			continue
+		}
+
+		// igual que el wrapper: data viene como string JSON con el mensaje
+		var encoded string
+		if err := json.Unmarshal(frame.Data, &encoded); err != nil {
+			continue
+		}
+		var m kickchatwrapper.ChatMessage
+		if err := json.Unmarshal([]byte(encoded), &m); err != nil {
+			continue
+		}
+		a.dispatch(ctx, m)
+	}
+}
+
+func (a *Adapter) dispatch(ctx context.Context, m kickchatwrapper.ChatMessage) {
+	if h := a.cfg.EventHandler; h != nil {
+		go h(m)
+	}
+
+	a.mu.RLock()
+	handler := a.handler
+	a.mu.RUnlock()
+	if handler == nil || isBlankChatMessage(m) {
+		return
+	}
+
+	if err := handler(ctx, mapChatMessageToDomain(m, a.cfg.BroadcasterUserID)); err != nil {
+		log.Printf("kick: error en handler: %v", err)
+	}
+}
+
+// reconnectDelay devuelve la espera antes del intento número attempt.
+func reconnectDelay(attempt int) time.Duration {
+	delay := reconnectBaseDelay
+	for i := 0; i < attempt && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > reconnectMaxDelay {
+		delay = reconnectMaxDelay
+	}
+	return delay
+}
+
+// withJitter corre la espera al azar hasta reconnectJitter hacia arriba o
+// hacia abajo.
+func withJitter(delay time.Duration) time.Duration {
+	spread := int64(float64(delay) * reconnectJitter)
+	if spread <= 0 {
+		return delay
+	}
+	return delay - time.Duration(spread) + time.Duration(rand.Int64N(2*spread+1))
+}
@@ -0,0 +1,221 @@
+package kickadapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+
+	"zhatBot/internal/domain"
+)
+
+const chatFrame = `{"event":"App\\Events\\ChatMessageEvent","data":"{\"id\":\"m1\",\"chatroom_id\":99,\"content\":\"hola\",\"type\":\"message\",\"sender\":{\"id\":5,\"username\":\"Ana\",\"slug\":\"ana\"}}","channel":"chatrooms.99.v2"}`
+
+// fakePusher hace de servidor de pusher: cada conexión la atiende session,
+// que recibe el número de conexión (desde 1).
+type fakePusher struct {
+	mu          sync.Mutex
+	connections int
+	subscribed  []string
+	pongs       int
+}
+
+func startPusher(t *testing.T, session func(n int, conn *websocket.Conn, p *fakePusher)) *fakePusher {
+	t.Helper()
+	p := &fakePusher{}
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var sub struct {
+			Event string `json:"event"`
+			Data  struct {
+				Channel string `json:"channel"`
+			} `json:"data"`
+		}
+		if err := conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		p.mu.Lock()
+		p.connections++
+		n := p.connections
+		p.subscribed = append(p.subscribed, sub.Event+" "+sub.Data.Channel)
+		p.mu.Unlock()
+		session(n, conn, p)
+	}))
+	t.Cleanup(srv.Close)
+
+	previous := kickchatwrapper.APIURL
+	kickchatwrapper.APIURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	t.Cleanup(func() { kickchatwrapper.APIURL = previous })
+	return p
+}
+
+func (p *fakePusher) snapshot() (int, []string, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.connections, append([]string(nil), p.subscribed...), p.pongs
+}
+
+func sendFrame(conn *websocket.Conn, frame string) error {
+	return conn.WriteMessage(websocket.TextMessage, []byte(frame))
+}
+
+func TestReadChatDispatchesUntilTheSocketDrops(t *testing.T) {
+	pusher := startPusher(t, func(_ int, conn *websocket.Conn, p *fakePusher) {
+		sendFrame(conn, `{"event":"pusher:ping","data":{}}`)
+		var pong struct {
+			Event string `json:"event"`
+		}
+		if err := conn.ReadJSON(&pong); err == nil && pong.Event == "pusher:pong" {
+			p.mu.Lock()
+			p.pongs++
+			p.mu.Unlock()
+		}
+		sendFrame(conn, `{"event":"pusher_internal:subscription_succeeded","data":"{}","channel":"chatrooms.99.v2"}`)
+		sendFrame(conn, `no es json`)
+		sendFrame(conn, chatFrame)
+	})
+
+	got := make(chan domain.Message, 1)
+	a := NewAdapter(Config{ChatroomID: 99, BroadcasterUserID: 1})
+	a.SetHandler(func(_ context.Context, msg domain.Message) error {
+		got <- msg
+		return nil
+	})
+
+	connected := false
+	err := a.readChat(context.Background(), func() { connected = true })
+	if err == nil {
+		t.Fatal("readChat returned nil after the server closed")
+	}
+	if !connected {
+		t.Fatal("onConnected was not called on subscription_succeeded")
+	}
+	select {
+	case msg := <-got:
+		if msg.Platform != domain.PlatformKick || msg.Text != "hola" || msg.Login != "ana" {
+			t.Fatalf("message = %+v", msg)
+		}
+	default:
+		t.Fatal("the chat message was not dispatched")
+	}
+	if _, subscribed, pongs := pusher.snapshot(); len(subscribed) != 1 || subscribed[0] != "pusher:subscribe chatrooms.99.v2" || pongs != 1 {
+		t.Fatalf("subscribed = %q, pongs = %d", subscribed, pongs)
+	}
+}
+
+func TestRunChatReconnectsAndRejoins(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for the first reconnect delay")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pusher := startPusher(t, func(n int, conn *websocket.Conn, _ *fakePusher) {
+		sendFrame(conn, `{"event":"pusher_internal:subscription_succeeded","data":"{}"}`)
+		if n == 1 {
+			// la primera conexión se cae enseguida
+			return
+		}
+		sendFrame(conn, chatFrame)
+		// la segunda queda abierta hasta que termina el test
+		conn.ReadMessage()
+	})
+
+	var (
+		mu       sync.Mutex
+		states   []ConnectionState
+		connects []bool
+	)
+	delivered := make(chan struct{}, 1)
+	a := NewAdapter(Config{
+		ChatroomID: 99,
+		ConnectionHandler: func(up bool) {
+			mu.Lock()
+			connects = append(connects, up)
+			mu.Unlock()
+		},
+		StatusHandler: func(s Status) {
+			mu.Lock()
+			states = append(states, s.State)
+			mu.Unlock()
+		},
+	})
+	a.SetHandler(func(context.Context, domain.Message) error {
+		delivered <- struct{}{}
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- a.runChat(ctx) }()
+
+	select {
+	case <-delivered:
+	case <-time.After(2*reconnectBaseDelay + 2*time.Second):
+		t.Fatal("no message after reconnecting")
+	}
+	if status := a.Status(); status.State != StateConnected {
+		t.Fatalf("Status() = %+v", status)
+	}
+	if n, subscribed, _ := pusher.snapshot(); n != 2 || subscribed[1] != "pusher:subscribe chatrooms.99.v2" {
+		t.Fatalf("connections = %d, subscribed = %q", n, subscribed)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("runChat did not stop on cancel")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	wantStates := []ConnectionState{StateConnecting, StateConnected, StateReconnecting, StateConnected, StateStopped}
+	if !slices.Equal(states, wantStates) {
+		t.Fatalf("states = %q, want %q", states, wantStates)
+	}
+	if want := []bool{true, false, true, false}; !slices.Equal(connects, want) {
+		t.Fatalf("connection callbacks = %v, want %v", connects, want)
+	}
+	if a.Status().State != StateStopped {
+		t.Fatalf("Status() after stop = %+v", a.Status())
+	}
+}
+
+func TestReconnectDelayAndJitter(t *testing.T) {
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second, 64 * time.Second, 2 * time.Minute, 2 * time.Minute}
+	for attempt, w := range want {
+		if got := reconnectDelay(attempt); got != w {
+			t.Errorf("reconnectDelay(%d) = %s, want %s", attempt, got, w)
+		}
+	}
+
+	for range 200 {
+		base := 10 * time.Second
+		got := withJitter(base)
+		if got < 8*time.Second || got > 12*time.Second {
+			t.Fatalf("withJitter(%s) = %s, outside ±20%%", base, got)
+		}
+	}
+	if got := withJitter(0); got != 0 {
+		t.Fatalf("withJitter(0) = %s", got)
+	}
+}
+
+func TestStatusBeforeStart(t *testing.T) {
+	if got := NewAdapter(Config{}).Status(); got.State != StateStopped {
+		t.Fatalf("Status() = %+v, want stopped", got)
+	}
+}
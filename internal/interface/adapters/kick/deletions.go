@@ -0,0 +1,152 @@
+package kickadapter
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+
+	"zhatBot/internal/domain"
+)
+
+// Eventos de pusher con los que Kick informa la moderación del chatroom.
+const (
+	kickMessageDeletedEvent = `App\Events\MessageDeletedEvent`
+	kickUserBannedEvent     = `App\Events\UserBannedEvent`
+	kickChatroomClearEvent  = `App\Events\ChatroomClearEvent`
+)
+
+// deletionsReconnectDelay separa los intentos de reconexión del listener.
+const deletionsReconnectDelay = 5 * time.Second
+
+type pusherFrame struct {
+	Event   string          `json:"event"`
+	Data    json.RawMessage `json:"data"`
+	Channel string          `json:"channel"`
+}
+
+type kickMessageDeleted struct {
+	Message struct {
+		ID string `json:"id"`
+	} `json:"message"`
+}
+
+type kickUserBanned struct {
+	User struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+		Slug     string `json:"slug"`
+	} `json:"user"`
+	Permanent bool `json:"permanent"`
+	// Duration viene en minutos en los timeouts.
+	Duration int `json:"duration"`
+}
+
+// parseDeletionFrame interpreta un frame de pusher del chatroom y devuelve el
+// borrado que representa. ok es false para cualquier otro evento.
+func parseDeletionFrame(raw []byte, chatroomID int) (domain.ChatDeletion, bool) {
+	var frame pusherFrame
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		return domain.ChatDeletion{}, false
+	}
+
+	// pusher manda data como string JSON; se acepta también un objeto
+	data := []byte(frame.Data)
+	var encoded string
+	if err := json.Unmarshal(frame.Data, &encoded); err == nil {
+		data = []byte(encoded)
+	}
+
+	del := domain.ChatDeletion{
+		Platform:  domain.PlatformKick,
+		ChannelID: strconv.Itoa(chatroomID),
+		At:        time.Now(),
+	}
+
+	switch frame.Event {
+	case kickMessageDeletedEvent:
+		var payload kickMessageDeleted
+		if err := json.Unmarshal(data, &payload); err != nil || payload.Message.ID == "" {
+			return domain.ChatDeletion{}, false
+		}
+		del.Kind = domain.ChatDeletionMessage
+		del.MessageID = payload.Message.ID
+	case kickUserBannedEvent:
+		var payload kickUserBanned
+		if err := json.Unmarshal(data, &payload); err != nil || payload.User.ID == 0 {
+			return domain.ChatDeletion{}, false
+		}
+		del.Kind = domain.ChatDeletionUser
+		del.UserID = strconv.Itoa(payload.User.ID)
+		del.Login = payload.User.Slug
+		if del.Login == "" {
+			del.Login = payload.User.Username
+		}
+		if !payload.Permanent && payload.Duration > 0 {
+			del.Duration = time.Duration(payload.Duration) * time.Minute
+		}
+	case kickChatroomClearEvent:
+		del.Kind = domain.ChatDeletionChat
+	default:
+		return domain.ChatDeletion{}, false
+	}
+	return del, true
+}
+
+// listenDeletions mantiene una conexión propia a pusher para los borrados: el
+// wrapper del chat descarta el nombre del evento y los entrega como mensajes
+// vacíos. Se reconecta hasta que ctx se cancela.
+func (a *Adapter) listenDeletions(ctx context.Context, onDelete DeletionHandler) {
+	for {
+		if err := a.readDeletions(ctx, onDelete); err != nil && ctx.Err() == nil {
+			log.Printf("kick: listener de borrados: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(deletionsReconnectDelay):
+		}
+	}
+}
+
+func (a *Adapter) readDeletions(ctx context.Context, onDelete DeletionHandler) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, kickchatwrapper.APIURL, nil)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	defer conn.Close()
+
+	subscribe := map[string]any{
+		"event": "pusher:subscribe",
+		"data": map[string]string{
+			"channel": "chatrooms." + strconv.Itoa(a.cfg.ChatroomID) + ".v2",
+			"auth":    "",
+		},
+	}
+	if err := conn.WriteJSON(subscribe); err != nil {
+		return err
+	}
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if del, ok := parseDeletionFrame(raw, a.cfg.ChatroomID); ok {
+			onDelete(del)
+			continue
+		}
+		var frame pusherFrame
+		if json.Unmarshal(raw, &frame) == nil && frame.Event == "pusher:ping" {
+			if err := conn.WriteJSON(map[string]any{"event": "pusher:pong", "data": map[string]any{}}); err != nil {
+				return err
+			}
+		}
+	}
+}
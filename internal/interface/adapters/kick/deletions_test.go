@@ -0,0 +1,89 @@
+package kickadapter
+
+import (
+	"testing"
+	"time"
+
+	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+
+	"zhatBot/internal/domain"
+)
+
+func TestParseDeletionFrame(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want domain.ChatDeletion
+	}{
+		{
+			name: "message deleted",
+			raw:  `{"event":"App\\Events\\MessageDeletedEvent","data":"{\"id\":\"d1\",\"message\":{\"id\":\"msg-9\"}}","channel":"chatrooms.99.v2"}`,
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionMessage, MessageID: "msg-9"},
+		},
+		{
+			name: "data as an object",
+			raw:  `{"event":"App\\Events\\MessageDeletedEvent","data":{"message":{"id":"msg-10"}}}`,
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionMessage, MessageID: "msg-10"},
+		},
+		{
+			name: "timeout in minutes",
+			raw:  `{"event":"App\\Events\\UserBannedEvent","data":"{\"user\":{\"id\":5,\"username\":\"Troll\",\"slug\":\"troll\"},\"permanent\":false,\"duration\":10}"}`,
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionUser, UserID: "5", Login: "troll", Duration: 10 * time.Minute},
+		},
+		{
+			name: "permanent ban falls back to username",
+			raw:  `{"event":"App\\Events\\UserBannedEvent","data":"{\"user\":{\"id\":5,\"username\":\"Troll\"},\"permanent\":true,\"duration\":10}"}`,
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionUser, UserID: "5", Login: "Troll"},
+		},
+		{
+			name: "chatroom cleared",
+			raw:  `{"event":"App\\Events\\ChatroomClearEvent","data":"{\"id\":\"c1\"}"}`,
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionChat},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			del, ok := parseDeletionFrame([]byte(tc.raw), 99)
+			if !ok {
+				t.Fatal("frame not recognized")
+			}
+			if del.At.IsZero() {
+				t.Fatal("At not set")
+			}
+			del.At = time.Time{}
+			tc.want.Platform = domain.PlatformKick
+			tc.want.ChannelID = "99"
+			if del != tc.want {
+				t.Fatalf("deletion = %+v, want %+v", del, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDeletionFrameIgnoresOtherEvents(t *testing.T) {
+	frames := []string{
+		`{"event":"App\\Events\\ChatMessageEvent","data":"{\"id\":\"m1\",\"content\":\"hola\"}"}`,
+		`{"event":"pusher:ping","data":"{}"}`,
+		`{"event":"App\\Events\\MessageDeletedEvent","data":"{\"message\":{}}"}`,
+		`{"event":"App\\Events\\UserBannedEvent","data":"{\"user\":{\"username\":\"x\"}}"}`,
+		`{"event":"App\\Events\\MessageDeletedEvent","data":"no es json"}`,
+		`no es json`,
+	}
+	for _, raw := range frames {
+		if del, ok := parseDeletionFrame([]byte(raw), 99); ok {
+			t.Errorf("%s parsed as %+v", raw, del)
+		}
+	}
+}
+
+func TestBlankChatMessagesAreNotChat(t *testing.T) {
+	if !isBlankChatMessage(kickchatwrapper.ChatMessage{ID: "d1", Content: "  "}) {
+		t.Fatal("a deletion event decoded as chat was dispatched")
+	}
+	if isBlankChatMessage(kickchatwrapper.ChatMessage{Content: "hola"}) {
+		t.Fatal("a message without sender id was dropped")
+	}
+	if isBlankChatMessage(kickchatwrapper.ChatMessage{Sender: kickchatwrapper.Sender{ID: 5}}) {
+		t.Fatal("an empty message from a real sender was dropped")
+	}
+}
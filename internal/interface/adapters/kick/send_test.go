@@ -0,0 +1,289 @@
+package kickadapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	kicksdk "github.com/glichtv/kick-sdk"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/interface/outs"
+)
+
+type postReply struct {
+	status      int
+	kickError   string
+	description string
+	err         error
+}
+
+// stubPoster reemplaza al SDK: responde según el PosterType pedido.
+type stubPoster struct {
+	mu      sync.Mutex
+	replies map[kicksdk.MessagePosterType]postReply
+	calls   []kicksdk.PostChatMessageInput
+	block   bool
+}
+
+func (p *stubPoster) PostMessage(ctx context.Context, input kicksdk.PostChatMessageInput) (kicksdk.Response[kicksdk.PostChatMessageOutput], error) {
+	p.mu.Lock()
+	p.calls = append(p.calls, input)
+	reply, ok := p.replies[input.PosterType]
+	block := p.block
+	p.mu.Unlock()
+
+	var resp kicksdk.Response[kicksdk.PostChatMessageOutput]
+	if block {
+		<-ctx.Done()
+		return resp, ctx.Err()
+	}
+	if !ok || reply.status == 0 {
+		resp.ResponseMetadata.StatusCode = http.StatusOK
+		resp.Payload = kicksdk.PostChatMessageOutput{MessageID: "m1", IsSent: true}
+		return resp, reply.err
+	}
+	resp.ResponseMetadata = kicksdk.ResponseMetadata{
+		StatusCode:           reply.status,
+		KickError:            reply.kickError,
+		KickErrorDescription: reply.description,
+	}
+	return resp, reply.err
+}
+
+func (p *stubPoster) posterTypes() []kicksdk.MessagePosterType {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]kicksdk.MessagePosterType, 0, len(p.calls))
+	for _, call := range p.calls {
+		out = append(out, call.PosterType)
+	}
+	return out
+}
+
+func newStubAdapter(role string, poster *stubPoster) *Adapter {
+	a := NewAdapter(Config{BroadcasterUserID: 10, ChatroomID: 20, Role: role})
+	a.poster = poster
+	return a
+}
+
+func TestSendMessageUsesPosterTypeForRole(t *testing.T) {
+	cases := []struct {
+		role string
+		want kicksdk.MessagePosterType
+	}{
+		{"streamer", kicksdk.MessagePosterUser},
+		{"", kicksdk.MessagePosterUser},
+		{"bot", kicksdk.MessagePosterBot},
+		{" BOT ", kicksdk.MessagePosterBot},
+	}
+	for _, tc := range cases {
+		poster := &stubPoster{}
+		a := newStubAdapter(tc.role, poster)
+
+		if err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola"); err != nil {
+			t.Fatalf("role %q: SendMessage: %v", tc.role, err)
+		}
+		got := poster.posterTypes()
+		if len(got) != 1 || got[0] != tc.want {
+			t.Fatalf("role %q: posted as %v, want [%s]", tc.role, got, tc.want)
+		}
+		if poster.calls[0].BroadcasterUserID != 10 || poster.calls[0].Content != "hola" {
+			t.Fatalf("role %q: input = %+v", tc.role, poster.calls[0])
+		}
+	}
+}
+
+func TestSendMessageRetriesWithAlternatePosterType(t *testing.T) {
+	poster := &stubPoster{replies: map[kicksdk.MessagePosterType]postReply{
+		kicksdk.MessagePosterBot: {status: http.StatusForbidden, kickError: "forbidden"},
+	}}
+	a := newStubAdapter("bot", poster)
+
+	if err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola"); err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	got := poster.posterTypes()
+	if len(got) != 2 || got[0] != kicksdk.MessagePosterBot || got[1] != kicksdk.MessagePosterUser {
+		t.Fatalf("posted as %v, want bot then user", got)
+	}
+}
+
+func TestSendMessageRetriesOnInvalidTypeOnly(t *testing.T) {
+	cases := []struct {
+		name      string
+		reply     postReply
+		wantCalls int
+	}{
+		{"invalid type", postReply{status: http.StatusUnprocessableEntity, description: "The type field is invalid"}, 2},
+		{"bad request about poster", postReply{status: http.StatusBadRequest, kickError: "invalid poster"}, 2},
+		{"bad request about content", postReply{status: http.StatusBadRequest, description: "content too long"}, 1},
+		{"rate limited", postReply{status: http.StatusTooManyRequests}, 1},
+		{"unauthorized", postReply{status: http.StatusUnauthorized}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			poster := &stubPoster{replies: map[kicksdk.MessagePosterType]postReply{kicksdk.MessagePosterUser: tc.reply}}
+			a := newStubAdapter("streamer", poster)
+
+			err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola")
+			if got := len(poster.posterTypes()); got != tc.wantCalls {
+				t.Fatalf("made %d calls, want %d", got, tc.wantCalls)
+			}
+			if tc.wantCalls == 1 && err == nil {
+				t.Fatal("expected the rejection to be returned")
+			}
+		})
+	}
+}
+
+func TestSendMessageReturnsTypedRejectionThroughMultiSender(t *testing.T) {
+	poster := &stubPoster{replies: map[kicksdk.MessagePosterType]postReply{
+		kicksdk.MessagePosterUser: {status: http.StatusForbidden, kickError: "forbidden", description: "user cannot post"},
+		kicksdk.MessagePosterBot:  {status: http.StatusForbidden, kickError: "forbidden", description: "bot cannot post"},
+	}}
+	multi := outs.NewMultiSender()
+	multi.Register(domain.PlatformKick, newStubAdapter("streamer", poster))
+
+	err := multi.SendMessage(context.Background(), domain.PlatformKick, "20", "hola")
+
+	var rejected *domain.ErrKickRejected
+	if !errors.As(err, &rejected) {
+		t.Fatalf("error = %v (%T), want *domain.ErrKickRejected", err, err)
+	}
+	// se devuelve el rechazo original, no el del reintento
+	if rejected.Status != http.StatusForbidden || rejected.PosterType != string(kicksdk.MessagePosterUser) || rejected.Description != "user cannot post" {
+		t.Fatalf("rejection = %+v", rejected)
+	}
+	if got := len(poster.posterTypes()); got != 2 {
+		t.Fatalf("made %d calls, want the original and one retry", got)
+	}
+}
+
+func TestSendMessageTransportErrorIsNotRetried(t *testing.T) {
+	poster := &stubPoster{replies: map[kicksdk.MessagePosterType]postReply{
+		kicksdk.MessagePosterUser: {err: errors.New("connection reset")},
+	}}
+	a := newStubAdapter("streamer", poster)
+
+	err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola")
+	var rejected *domain.ErrKickRejected
+	if err == nil || errors.As(err, &rejected) {
+		t.Fatalf("error = %v, want a plain transport error", err)
+	}
+	if got := len(poster.posterTypes()); got != 1 {
+		t.Fatalf("made %d calls, want 1", got)
+	}
+}
+
+func TestSendMessageTimesOut(t *testing.T) {
+	poster := &stubPoster{block: true}
+	a := newStubAdapter("streamer", poster)
+	a.cfg.SendTimeout = 20 * time.Millisecond
+
+	start := time.Now()
+	err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("SendMessage took %s", elapsed)
+	}
+}
+
+func TestSendMessageCallerCancel(t *testing.T) {
+	poster := &stubPoster{block: true}
+	a := newStubAdapter("streamer", poster)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.SendMessage(ctx, domain.PlatformKick, "20", "hola") }()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SendMessage ignored the caller's cancel")
+	}
+	if got := poster.posterTypes(); len(got) != 1 {
+		t.Fatalf("poster calls = %v, want only the first attempt", got)
+	}
+}
+
+func TestSendMessageCallerDeadlineWins(t *testing.T) {
+	poster := &stubPoster{block: true}
+	a := newStubAdapter("streamer", poster)
+	a.cfg.SendTimeout = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := a.SendMessage(ctx, domain.PlatformKick, "20", "hola"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("caller deadline ignored, took %s", elapsed)
+	}
+}
+
+func TestSendMessageAlreadyCancelled(t *testing.T) {
+	poster := &stubPoster{replies: map[kicksdk.MessagePosterType]postReply{
+		posterTypeForRole("streamer"): {status: http.StatusForbidden, kickError: "forbidden"},
+	}}
+	a := newStubAdapter("streamer", poster)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := a.SendMessage(ctx, domain.PlatformKick, "20", "hola")
+	if err == nil {
+		t.Fatal("expected an error for a cancelled context")
+	}
+	// con el contexto cancelado no se reintenta con el otro tipo
+	if got := poster.posterTypes(); len(got) > 1 {
+		t.Fatalf("retried after cancel: %v", got)
+	}
+}
+
+func TestSendMessageTimeoutCoversRetry(t *testing.T) {
+	poster := &retryThenBlockPoster{}
+	a := newStubAdapter("streamer", nil)
+	a.poster = poster
+	a.cfg.SendTimeout = 30 * time.Millisecond
+
+	start := time.Now()
+	// si el reintento falla se devuelve el rechazo original
+	err := a.SendMessage(context.Background(), domain.PlatformKick, "20", "hola")
+	var rejected *domain.ErrKickRejected
+	if !errors.As(err, &rejected) || rejected.Status != http.StatusForbidden {
+		t.Fatalf("error = %v, want the original rejection", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("retry got a fresh timeout, took %s", elapsed)
+	}
+	if poster.calls != 2 {
+		t.Fatalf("calls = %d, want the attempt and one retry", poster.calls)
+	}
+}
+
+// retryThenBlockPoster rechaza el primer intento con 403 y deja colgado el reintento.
+type retryThenBlockPoster struct {
+	calls int
+}
+
+func (p *retryThenBlockPoster) PostMessage(ctx context.Context, _ kicksdk.PostChatMessageInput) (kicksdk.Response[kicksdk.PostChatMessageOutput], error) {
+	p.calls++
+	var resp kicksdk.Response[kicksdk.PostChatMessageOutput]
+	if p.calls == 1 {
+		resp.ResponseMetadata = kicksdk.ResponseMetadata{StatusCode: http.StatusForbidden, KickError: "forbidden"}
+		return resp, nil
+	}
+	<-ctx.Done()
+	return resp, ctx.Err()
+}
@@ -6,8 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/adeithe/go-twitch/irc"
 
@@ -19,27 +23,72 @@ type Config struct {
 	OAuthToken        string
 	Channels          []string
 	UserNoticeHandler UserNoticeHandler
+	// ConnectionHandler se llama cada vez que el IRC se conecta (con los
+	// canales ya confirmados por el servidor) o se cae.
+	ConnectionHandler ConnectionHandler
+	// ErrorHandler recibe cada conexión fallida o perdida; el adaptador
+	// vuelve a intentar solo.
+	ErrorHandler ErrorHandler
+	// DeletionHandler recibe los borrados de la moderación (CLEARMSG y CLEARCHAT).
+	DeletionHandler DeletionHandler
+	// Whisper manda un mensaje privado por Helix (el IRC ya no permite
+	// whispers). Sin Whisper el adaptador no soporta mensajes privados.
+	Whisper WhisperFunc
 }
 
 type MessageHandler func(ctx context.Context, msg domain.Message) error
 type UserNoticeHandler func(irc.UserNotice)
+type ConnectionHandler func(connected bool)
+type ErrorHandler func(err error)
+type DeletionHandler func(domain.ChatDeletion)
+type WhisperFunc func(ctx context.Context, toUserID, text string) error
+
+const (
+	// reconnectBaseDelay es la primera espera antes de reconectar; se duplica
+	// en cada intento fallido hasta reconnectMaxDelay.
+	reconnectBaseDelay = 2 * time.Second
+	reconnectMaxDelay  = 2 * time.Minute
+	// stableConnection es cuánto tiene que durar una conexión para volver a
+	// la espera mínima; así un login rechazado no reconecta en loop.
+	stableConnection = time.Minute
+	// pingInterval es cada cuánto se verifica que el IRC siga respondiendo:
+	// una red caída puede no cerrar el socket.
+	pingInterval = time.Minute
+	// joinTimeout es cuánto se espera a que el IRC confirme los canales
+	// (eco del JOIN o ROOMSTATE) antes de dar el intento por fallido.
+	joinTimeout = 30 * time.Second
+)
+
+// ErrDisconnected lo devuelve SendMessage mientras se reintenta la conexión.
+var ErrDisconnected = errors.New("twitch: sin conexión con el IRC, reintentando")
 
 type Adapter struct {
 	cfg     Config
 	handler MessageHandler
 
 	mu   sync.RWMutex
-	conn *irc.Conn
+	conn irc.IConn
+
+	// dial crea la conexión al IRC; los tests la cambian por una falsa.
+	dial        func() irc.IConn
+	joinTimeout time.Duration
 }
 
 func NewAdapter(cfg Config) *Adapter {
-	return &Adapter{cfg: cfg}
+	return &Adapter{
+		cfg:         cfg,
+		dial:        func() irc.IConn { return &irc.Conn{} },
+		joinTimeout: joinTimeout,
+	}
 }
 
 func (a *Adapter) SetHandler(h MessageHandler) {
 	a.handler = h
 }
 
+// Start conecta al IRC y se queda escuchando hasta que se cancele ctx. Si la
+// conexión falla o se cae, reintenta con espera exponencial y vuelve a unirse
+// a los canales.
 func (a *Adapter) Start(ctx context.Context) error {
 	if len(a.cfg.Channels) == 0 {
 		return errors.New("twitch: no hay canales configurados")
@@ -49,7 +98,7 @@ func (a *Adapter) Start(ctx context.Context) error {
 	}
 
 	// 🔹 Usamos UNA sola conexión simple, sin sharding
-	conn := &irc.Conn{}
+	conn := a.dial()
 
 	if err := conn.SetLogin(a.cfg.Username, a.cfg.OAuthToken); err != nil {
 		return fmt.Errorf("twitch: SetLogin: %w", err)
@@ -76,32 +125,224 @@ func (a *Adapter) Start(ctx context.Context) error {
 		})
 	}
 
-	if err := conn.Connect(); err != nil {
-		return fmt.Errorf("twitch: Connect: %w", err)
+	if onDelete := a.cfg.DeletionHandler; onDelete != nil {
+		conn.OnChannelMessageDelete(func(del irc.ChatMessageDelete) {
+			onDelete(mapMessageDelete(del))
+		})
+		conn.OnChannelBan(func(ban irc.ChatBan) {
+			onDelete(mapChatClear(ban))
+		})
 	}
 
-	if err := conn.Join(a.cfg.Channels...); err != nil {
-		return fmt.Errorf("twitch: Join: %w", err)
-	}
-	for _, ch := range a.cfg.Channels {
-		log.Printf("twitch: joined channel %s", ch)
-	}
+	// el JOIN solo se escribe en el socket: el canal está unido cuando el
+	// servidor devuelve el eco del JOIN propio o el ROOMSTATE del canal
+	var waiter atomic.Pointer[joinWaiter]
+	conn.OnChannelJoin(func(channel, username string) {
+		if w := waiter.Load(); w != nil && strings.EqualFold(username, a.cfg.Username) {
+			w.confirm(channel)
+		}
+	})
+	conn.OnChannelUpdate(func(state irc.RoomState) {
+		if w := waiter.Load(); w != nil {
+			w.confirm(state.Name)
+		}
+	})
+
+	// los eventos llegan en goroutines sueltas; el loop solo necesita saber
+	// que hubo una caída
+	disconnected := make(chan struct{}, 1)
+	conn.OnDisconnect(func() {
+		select {
+		case disconnected <- struct{}{}:
+		default:
+		}
+	})
 
 	a.mu.Lock()
 	a.conn = conn
 	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.conn = nil
+		a.mu.Unlock()
+	}()
 
-	log.Printf("twitch: conectado como %s a canales %v", a.cfg.Username, a.cfg.Channels)
+	connected := false
+	setConnected := func(up bool) {
+		if up == connected {
+			return
+		}
+		connected = up
+		if onConn := a.cfg.ConnectionHandler; onConn != nil {
+			onConn(up)
+		}
+	}
+	defer setConnected(false)
 
-	<-ctx.Done()
+	attempt := 0
+	for {
+		select {
+		case <-disconnected:
+		default:
+		}
+		w := newJoinWaiter(a.cfg.Channels)
+		waiter.Store(w)
+		if err := a.connect(conn); err != nil {
+			a.reportError(fmt.Errorf("twitch: no pude conectar: %w", err))
+		} else if err := a.waitJoined(ctx, conn, w, disconnected); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			a.reportError(fmt.Errorf("twitch: %w", err))
+		} else {
+			since := time.Now()
+			log.Printf("twitch: conectado como %s a canales %v", a.cfg.Username, a.cfg.Channels)
+			setConnected(true)
+			if err := waitDisconnect(ctx, conn, disconnected); err != nil {
+				return err
+			}
+			setConnected(false)
+			if time.Since(since) >= stableConnection {
+				attempt = 0
+			}
+			a.reportError(errors.New("twitch: se perdió la conexión con el IRC"))
+		}
 
-	a.mu.Lock()
-	if a.conn != nil {
-		a.conn.Close()
+		delay := reconnectDelay(attempt)
+		attempt++
+		log.Printf("twitch: reintentando la conexión en %s", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
 	}
-	a.mu.Unlock()
+}
+
+// connect abre la conexión (si go-twitch no la reabrió solo después de un
+// RECONNECT del servidor) y pide unirse a los canales.
+func (a *Adapter) connect(conn irc.IConn) error {
+	if !conn.IsConnected() {
+		if err := conn.Connect(); err != nil && !errors.Is(err, irc.ErrAlreadyConnected) {
+			return fmt.Errorf("Connect: %w", err)
+		}
+	}
+	if err := conn.Join(a.cfg.Channels...); err != nil {
+		return fmt.Errorf("Join: %w", err)
+	}
+	return nil
+}
+
+// waitJoined espera a que el IRC confirme todos los canales. Si la conexión
+// se cae o la confirmación no llega en joinTimeout, devuelve error; en el
+// segundo caso cierra la conexión para reintentar desde cero.
+func (a *Adapter) waitJoined(ctx context.Context, conn irc.IConn, w *joinWaiter, disconnected <-chan struct{}) error {
+	timer := time.NewTimer(a.joinTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		for _, ch := range a.cfg.Channels {
+			log.Printf("twitch: joined channel %s", ch)
+		}
+		return nil
+	case <-ctx.Done():
+		conn.Close()
+		return ctx.Err()
+	case <-disconnected:
+		return errors.New("se perdió la conexión antes de unirse a los canales")
+	case <-timer.C:
+		conn.Close()
+		return fmt.Errorf("el IRC no confirmó el JOIN a %v", w.missing())
+	}
+}
+
+// joinWaiter junta las confirmaciones de los canales de un intento de
+// conexión; done se cierra cuando están todos.
+type joinWaiter struct {
+	mu      sync.Mutex
+	pending map[string]struct{}
+	done    chan struct{}
+}
+
+func newJoinWaiter(channels []string) *joinWaiter {
+	w := &joinWaiter{pending: make(map[string]struct{}, len(channels)), done: make(chan struct{})}
+	for _, ch := range channels {
+		w.pending[normalizeChannel(ch)] = struct{}{}
+	}
+	if len(w.pending) == 0 {
+		close(w.done)
+	}
+	return w
+}
+
+func (w *joinWaiter) confirm(channel string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) == 0 {
+		return
+	}
+	delete(w.pending, normalizeChannel(channel))
+	if len(w.pending) == 0 {
+		close(w.done)
+	}
+}
+
+func (w *joinWaiter) missing() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]string, 0, len(w.pending))
+	for ch := range w.pending {
+		out = append(out, ch)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func normalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
+}
+
+// waitDisconnect espera a que la conexión se caiga (nil) o a que se cancele
+// ctx (cierra la conexión y devuelve ctx.Err()). Un PING sin respuesta
+// cierra la conexión para forzar la reconexión.
+func waitDisconnect(ctx context.Context, conn irc.IConn, disconnected <-chan struct{}) error {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return ctx.Err()
+		case <-disconnected:
+			return nil
+		case <-ticker.C:
+			if _, err := conn.Ping(); err != nil {
+				log.Printf("twitch: el IRC no responde al PING: %v", err)
+				conn.Close()
+			}
+		}
+	}
+}
 
-	return ctx.Err()
+func (a *Adapter) reportError(err error) {
+	log.Printf("%v", err)
+	if onErr := a.cfg.ErrorHandler; onErr != nil {
+		onErr(err)
+	}
+}
+
+// reconnectDelay devuelve la espera antes del intento número attempt.
+func reconnectDelay(attempt int) time.Duration {
+	delay := reconnectBaseDelay
+	for i := 0; i < attempt && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > reconnectMaxDelay {
+		delay = reconnectMaxDelay
+	}
+	return delay
 }
 
 func (a *Adapter) SendMessage(ctx context.Context, platform domain.Platform, channelID, text string) error {
@@ -113,31 +354,126 @@ func (a *Adapter) SendMessage(ctx context.Context, platform domain.Platform, cha
 	conn := a.conn
 	a.mu.RUnlock()
 
-	if conn == nil || !conn.IsConnected() {
+	if conn == nil {
 		return errors.New("twitch: conexión no inicializada o cerrada")
 	}
+	if !conn.IsConnected() {
+		return ErrDisconnected
+	}
 
 	log.Printf("Twitch -> Say(%s): %s", channelID, text)
 	return conn.Say(channelID, text)
 }
 
+// SendPrivateMessage manda un whisper a userID; username solo se usa en el log.
+func (a *Adapter) SendPrivateMessage(ctx context.Context, platform domain.Platform, userID, username, text string) error {
+	if platform != domain.PlatformTwitch {
+		return fmt.Errorf("twitch adapter no soporta plataforma %s", platform)
+	}
+	if a.cfg.Whisper == nil {
+		return domain.ErrPrivateMessageUnsupported
+	}
+	if strings.TrimSpace(userID) == "" {
+		return fmt.Errorf("twitch: whisper a %s sin user id", username)
+	}
+
+	log.Printf("Twitch -> Whisper(%s): %s", username, text)
+	return a.cfg.Whisper(ctx, userID, text)
+}
+
 func mapChatMessageToDomain(cm irc.ChatMessage) domain.Message {
 	sender := cm.Sender
 
 	return domain.Message{
-		Platform: domain.PlatformTwitch,
+		PlatformMessageID: cm.ID,
+		Platform:          domain.PlatformTwitch,
 		// ChannelID: strconv.FormatInt(cm.ChannelID, 10),
 		ChannelID: cm.Channel,
 		UserID:    strconv.FormatInt(sender.ID, 10),
 		Username:  sender.DisplayName,
 		Text:      cm.Text,
 
+		Login:       strings.ToLower(sender.Username),
+		DisplayName: sender.DisplayName,
+
 		IsPrivate: false,
 
 		IsPlatformOwner: sender.IsBroadcaster,
 		IsPlatformAdmin: sender.IsBroadcaster || sender.IsModerator,
 		IsPlatformMod:   sender.IsModerator,
 		IsPlatformVip:   sender.IsVIP,
-		IsSubscriber:    sender.IsSubscriber,
+		IsSubscriber:    isTwitchSubscriber(cm),
+
+		Emotes: countTwitchEmotes(cm.IRCMessage.Tags["emotes"]),
+		Bits:   parseTwitchBits(cm.IRCMessage.Tags["bits"]),
+	}
+}
+
+// mapMessageDelete traduce un CLEARMSG (un mensaje borrado por un mod).
+func mapMessageDelete(del irc.ChatMessageDelete) domain.ChatDeletion {
+	return domain.ChatDeletion{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: del.ChannelName,
+		Kind:      domain.ChatDeletionMessage,
+		MessageID: del.TargetID,
+		Login:     strings.ToLower(del.TargetSenderName),
+		Text:      del.Text,
+		At:        del.CreatedAt,
+	}
+}
+
+// mapChatClear traduce un CLEARCHAT: con usuario es un ban o timeout (se
+// borran sus mensajes); sin usuario es un /clear del chat completo.
+func mapChatClear(ban irc.ChatBan) domain.ChatDeletion {
+	del := domain.ChatDeletion{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: ban.ChannelName,
+		Kind:      domain.ChatDeletionChat,
+		At:        ban.CreatedAt,
+	}
+	if ban.TargetName == "" {
+		return del
+	}
+	del.Kind = domain.ChatDeletionUser
+	del.Login = strings.ToLower(ban.TargetName)
+	if ban.TargetID > 0 {
+		del.UserID = strconv.FormatInt(ban.TargetID, 10)
+	}
+	del.Duration = ban.Duration()
+	return del
+}
+
+// parseTwitchBits lee el tag bits de un PRIVMSG con cheer. Un valor ausente
+// o inválido cuenta como 0.
+func parseTwitchBits(tag string) int {
+	bits, err := strconv.Atoi(strings.TrimSpace(tag))
+	if err != nil || bits < 0 {
+		return 0
+	}
+	return bits
+}
+
+// isTwitchSubscriber usa el tag subscriber de IRC y, si no viene, los badges.
+// La librería solo mira el badge subscriber, pero los primeros suscriptores
+// del canal lo llevan reemplazado por founder.
+func isTwitchSubscriber(cm irc.ChatMessage) bool {
+	if tag, ok := cm.IRCMessage.Tags["subscriber"]; ok && tag != "" {
+		return tag == "1"
+	}
+	_, founder := cm.Sender.Badges["founder"]
+	return cm.Sender.IsSubscriber || founder
+}
+
+// countTwitchEmotes cuenta los usos del tag emotes de IRC, con formato
+// «id:inicio-fin,inicio-fin/id:inicio-fin».
+func countTwitchEmotes(tag string) int {
+	count := 0
+	for _, emote := range strings.Split(tag, "/") {
+		_, positions, ok := strings.Cut(emote, ":")
+		if !ok || positions == "" {
+			continue
+		}
+		count += strings.Count(positions, ",") + 1
 	}
+	return count
 }
@@ -0,0 +1,244 @@
+package twitchadapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adeithe/go-twitch/irc"
+
+	"zhatBot/internal/domain"
+)
+
+func TestMapChatMessageSeparatesLoginAndDisplayName(t *testing.T) {
+	cases := []struct {
+		name            string
+		sender          irc.ChatSender
+		wantLogin       string
+		wantDisplayName string
+	}{
+		{
+			name:            "capitalized display name",
+			sender:          irc.ChatSender{ID: 42, Username: "zeroproject", DisplayName: "ZeroProject"},
+			wantLogin:       "zeroproject",
+			wantDisplayName: "ZeroProject",
+		},
+		{
+			name:            "localized display name",
+			sender:          irc.ChatSender{ID: 7, Username: "tanaka_jp", DisplayName: "田中"},
+			wantLogin:       "tanaka_jp",
+			wantDisplayName: "田中",
+		},
+		{
+			name:            "login is always lowercase",
+			sender:          irc.ChatSender{ID: 9, Username: "MixedCase", DisplayName: "MixedCase"},
+			wantLogin:       "mixedcase",
+			wantDisplayName: "MixedCase",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := mapChatMessageToDomain(irc.ChatMessage{
+				ID:      "m1",
+				Channel: "canal",
+				Text:    "hola",
+				Sender:  tc.sender,
+			})
+
+			if msg.Platform != domain.PlatformTwitch {
+				t.Fatalf("Platform = %q, want twitch", msg.Platform)
+			}
+			if msg.Login != tc.wantLogin {
+				t.Fatalf("Login = %q, want %q", msg.Login, tc.wantLogin)
+			}
+			if msg.DisplayName != tc.wantDisplayName {
+				t.Fatalf("DisplayName = %q, want %q", msg.DisplayName, tc.wantDisplayName)
+			}
+			// Username sigue siendo el nombre a mostrar por compatibilidad
+			if msg.Username != tc.wantDisplayName {
+				t.Fatalf("Username = %q, want %q", msg.Username, tc.wantDisplayName)
+			}
+			if msg.LoginName() != tc.wantLogin || msg.Name() != tc.wantDisplayName {
+				t.Fatalf("LoginName/Name = %q/%q", msg.LoginName(), msg.Name())
+			}
+		})
+	}
+}
+
+func TestCountTwitchEmotes(t *testing.T) {
+	tests := map[string]int{
+		"":                           0,
+		"25:0-4":                     1,
+		"25:0-4,12-16":               2,
+		"25:0-4,12-16/1902:6-10":     3,
+		"emotesv2_abc:0-5/bad/1902:": 1,
+	}
+	for tag, want := range tests {
+		if got := countTwitchEmotes(tag); got != want {
+			t.Errorf("countTwitchEmotes(%q) = %d, want %d", tag, got, want)
+		}
+	}
+}
+
+// parseFixture arma el ChatMessage como lo hace el cliente IRC con una línea cruda.
+func parseFixture(t *testing.T, raw string) irc.ChatMessage {
+	t.Helper()
+	parsed, err := irc.NewParsedMessage(raw)
+	if err != nil {
+		t.Fatalf("NewParsedMessage: %v", err)
+	}
+	return irc.NewChatMessage(parsed)
+}
+
+func TestMapChatMessageBits(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want int
+	}{
+		{
+			name: "cheer",
+			raw:  "@badge-info=;badges=bits/100;bits=250;color=#FF0000;display-name=Ana;emotes=;id=c1;mod=0;subscriber=0;user-id=42;user-type= :ana!ana@ana.tmi.twitch.tv PRIVMSG #canal :Cheer100 Cheer150 ¡vamos!",
+			want: 250,
+		},
+		{
+			name: "plain message",
+			raw:  "@badge-info=;badges=;display-name=Beto;emotes=;id=c2;mod=0;subscriber=0;user-id=7;user-type= :beto!beto@beto.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: 0,
+		},
+		{
+			name: "invalid tag",
+			raw:  "@badges=;bits=mucho;display-name=Eva;id=c3;user-id=9 :eva!eva@eva.tmi.twitch.tv PRIVMSG #canal :Cheer1",
+			want: 0,
+		},
+		{
+			name: "negative tag",
+			raw:  "@badges=;bits=-5;display-name=Eva;id=c4;user-id=9 :eva!eva@eva.tmi.twitch.tv PRIVMSG #canal :Cheer1",
+			want: 0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := mapChatMessageToDomain(parseFixture(t, tc.raw))
+			if msg.Bits != tc.want {
+				t.Fatalf("Bits = %d, want %d", msg.Bits, tc.want)
+			}
+		})
+	}
+}
+
+func TestMapChatMessageSubscriber(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want bool
+	}{
+		{
+			name: "subscriber badge and tag",
+			raw:  "@badge-info=subscriber/8;badges=subscriber/6;display-name=Ana;id=s1;subscriber=1;user-id=42 :ana!ana@ana.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: true,
+		},
+		{
+			name: "founder badge",
+			raw:  "@badge-info=founder/14;badges=founder/0;display-name=Beto;id=s2;subscriber=1;user-id=7 :beto!beto@beto.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: true,
+		},
+		{
+			name: "founder badge without tag",
+			raw:  "@badges=founder/0;display-name=Beto;id=s3;user-id=7 :beto!beto@beto.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: true,
+		},
+		{
+			name: "tag wins over a stale badge",
+			raw:  "@badges=subscriber/0;display-name=Eva;id=s4;subscriber=0;user-id=9 :eva!eva@eva.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: false,
+		},
+		{
+			name: "not a subscriber",
+			raw:  "@badges=vip/1;display-name=Dani;id=s5;subscriber=0;user-id=3 :dani!dani@dani.tmi.twitch.tv PRIVMSG #canal :hola",
+			want: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := mapChatMessageToDomain(parseFixture(t, tc.raw))
+			if msg.IsSubscriber != tc.want {
+				t.Fatalf("IsSubscriber = %v, want %v", msg.IsSubscriber, tc.want)
+			}
+		})
+	}
+}
+
+func parseIRC(t *testing.T, raw string) irc.Message {
+	t.Helper()
+	parsed, err := irc.NewParsedMessage(raw)
+	if err != nil {
+		t.Fatalf("NewParsedMessage: %v", err)
+	}
+	return parsed
+}
+
+func TestMapMessageDelete(t *testing.T) {
+	raw := "@login=ana;room-id=;target-msg-id=abc-123;tmi-sent-ts=1714593600000 :tmi.twitch.tv CLEARMSG #canal :mensaje borrado"
+	del := mapMessageDelete(irc.NewChatMessageDelete(parseIRC(t, raw)))
+
+	want := domain.ChatDeletion{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: "canal",
+		Kind:      domain.ChatDeletionMessage,
+		MessageID: "abc-123",
+		Login:     "ana",
+		Text:      "mensaje borrado",
+		At:        time.UnixMilli(1714593600000),
+	}
+	if !del.At.Equal(want.At) {
+		t.Fatalf("At = %v, want %v", del.At, want.At)
+	}
+	del.At = want.At
+	if del != want {
+		t.Fatalf("deletion = %+v, want %+v", del, want)
+	}
+}
+
+func TestMapChatClear(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want domain.ChatDeletion
+	}{
+		{
+			name: "timeout",
+			raw:  "@ban-duration=600;room-id=1;target-user-id=42;tmi-sent-ts=1714593600000 :tmi.twitch.tv CLEARCHAT #canal :Troll",
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionUser, UserID: "42", Login: "troll", Duration: 10 * time.Minute},
+		},
+		{
+			name: "permanent ban",
+			raw:  "@room-id=1;target-user-id=42;tmi-sent-ts=1714593600000 :tmi.twitch.tv CLEARCHAT #canal :troll",
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionUser, UserID: "42", Login: "troll"},
+		},
+		{
+			name: "ban without user id",
+			raw:  "@room-id=1;tmi-sent-ts=1714593600000 :tmi.twitch.tv CLEARCHAT #canal :troll",
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionUser, Login: "troll"},
+		},
+		{
+			name: "clear the whole chat",
+			raw:  "@room-id=1;tmi-sent-ts=1714593600000 :tmi.twitch.tv CLEARCHAT #canal",
+			want: domain.ChatDeletion{Kind: domain.ChatDeletionChat},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			del := mapChatClear(irc.NewChatBan(parseIRC(t, tc.raw)))
+			tc.want.Platform = domain.PlatformTwitch
+			tc.want.ChannelID = "canal"
+			if !del.At.Equal(time.UnixMilli(1714593600000)) {
+				t.Fatalf("At = %v", del.At)
+			}
+			del.At = time.Time{}
+			if del != tc.want {
+				t.Fatalf("deletion = %+v, want %+v", del, tc.want)
+			}
+		})
+	}
+}
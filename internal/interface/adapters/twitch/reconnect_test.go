@@ -0,0 +1,199 @@
+package twitchadapter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adeithe/go-twitch/irc"
+
+	"zhatBot/internal/domain"
+)
+
+func TestReconnectDelay(t *testing.T) {
+	want := []time.Duration{
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		32 * time.Second,
+		64 * time.Second,
+		2 * time.Minute,
+		2 * time.Minute,
+	}
+	for attempt, w := range want {
+		if got := reconnectDelay(attempt); got != w {
+			t.Errorf("reconnectDelay(%d) = %s, want %s", attempt, got, w)
+		}
+	}
+	if got := reconnectDelay(1000); got != reconnectMaxDelay {
+		t.Fatalf("reconnectDelay(1000) = %s, want the cap", got)
+	}
+}
+
+func TestSendMessageWhileDisconnected(t *testing.T) {
+	ctx := context.Background()
+	a := NewAdapter(Config{Channels: []string{"canal"}})
+
+	if err := a.SendMessage(ctx, domain.PlatformTwitch, "canal", "hola"); err == nil || errors.Is(err, ErrDisconnected) {
+		t.Fatalf("before Start: err = %v, want the not initialized error", err)
+	}
+
+	// una conexión que se cayó queda puesta hasta que vuelve
+	a.conn = &irc.Conn{}
+	if err := a.SendMessage(ctx, domain.PlatformTwitch, "canal", "hola"); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("dropped connection: err = %v, want ErrDisconnected", err)
+	}
+	if err := a.SendMessage(ctx, domain.PlatformKick, "canal", "hola"); err == nil {
+		t.Fatal("accepted a Kick message")
+	}
+}
+
+// fakeIRC hace de servidor: acepta el JOIN y, si confirm está, devuelve el
+// eco del JOIN o el ROOMSTATE como lo hace Twitch.
+type fakeIRC struct {
+	irc.IConn
+
+	confirm  func(f *fakeIRC, channel string)
+	mu       sync.Mutex
+	up       bool
+	joins    int
+	onJoin   func(string, string)
+	onUpdate func(irc.RoomState)
+	onDown   func()
+}
+
+func (f *fakeIRC) SetLogin(string, string) error        { return nil }
+func (f *fakeIRC) OnMessage(func(irc.ChatMessage))      {}
+func (f *fakeIRC) OnChannelJoin(h func(string, string)) { f.onJoin = h }
+func (f *fakeIRC) OnChannelUpdate(h func(irc.RoomState)) {
+	f.onUpdate = h
+}
+func (f *fakeIRC) OnDisconnect(h func()) { f.onDown = h }
+
+func (f *fakeIRC) IsConnected() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.up
+}
+
+func (f *fakeIRC) Connect() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.up = true
+	return nil
+}
+
+func (f *fakeIRC) Join(channels ...string) error {
+	f.mu.Lock()
+	f.joins++
+	f.mu.Unlock()
+	if f.confirm != nil {
+		for _, ch := range channels {
+			go f.confirm(f, ch)
+		}
+	}
+	return nil
+}
+
+func (f *fakeIRC) Close() {
+	f.mu.Lock()
+	wasUp := f.up
+	f.up = false
+	f.mu.Unlock()
+	if wasUp && f.onDown != nil {
+		go f.onDown()
+	}
+}
+
+func (f *fakeIRC) joinCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.joins
+}
+
+// startFake arranca el adaptador contra fake; stop cancela y devuelve lo que
+// devolvió Start.
+func startFake(t *testing.T, fake *fakeIRC, channels []string) (states <-chan bool, errs <-chan error, stop func() error) {
+	t.Helper()
+	stateC := make(chan bool, 8)
+	errC := make(chan error, 8)
+	a := NewAdapter(Config{
+		Username:          "ZhatBot",
+		OAuthToken:        "oauth:x",
+		Channels:          channels,
+		ConnectionHandler: func(up bool) { stateC <- up },
+		ErrorHandler:      func(err error) { errC <- err },
+	})
+	a.dial = func() irc.IConn { return fake }
+	a.joinTimeout = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Start(ctx) }()
+	stop = sync.OnceValue(func() error {
+		cancel()
+		return <-done
+	})
+	t.Cleanup(func() { _ = stop() })
+	return stateC, errC, stop
+}
+
+func TestStartWaitsForJoinConfirmation(t *testing.T) {
+	fake := &fakeIRC{}
+	states, errs, stop := startFake(t, fake, []string{"Canal"})
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "canal") {
+			t.Fatalf("error = %v, want the unconfirmed channel", err)
+		}
+	case up := <-states:
+		t.Fatalf("reported connected=%v without a JOIN confirmation", up)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error after the join timeout")
+	}
+	if fake.IsConnected() {
+		t.Fatal("the unconfirmed connection was left open")
+	}
+
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Start = %v", err)
+	}
+	select {
+	case up := <-states:
+		t.Fatalf("reported connected=%v without a JOIN confirmation", up)
+	default:
+	}
+}
+
+func TestStartConnectsOnJoinEchoOrRoomState(t *testing.T) {
+	fake := &fakeIRC{confirm: func(f *fakeIRC, channel string) {
+		// un canal confirma por el eco del JOIN (el de otro usuario no
+		// cuenta) y el otro por el ROOMSTATE
+		if strings.EqualFold(channel, "uno") {
+			f.onJoin("uno", "otro_usuario")
+			f.onJoin("uno", "zhatbot")
+			return
+		}
+		f.onUpdate(irc.RoomState{Name: channel})
+	}}
+	states, errs, _ := startFake(t, fake, []string{"uno", "#Dos"})
+
+	select {
+	case up := <-states:
+		if !up {
+			t.Fatal("reported a disconnection before connecting")
+		}
+	case err := <-errs:
+		t.Fatalf("error = %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("never reported the connection")
+	}
+	if n := fake.joinCount(); n != 1 {
+		t.Fatalf("joins = %d, want 1", n)
+	}
+}
@@ -0,0 +1,41 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+type BotConflictManager interface {
+	Settings() domain.BotConflictSettings
+	Update(ctx context.Context, settings domain.BotConflictSettings) (domain.BotConflictSettings, error)
+}
+
+// handleBotConflictSettings atiende GET/PUT /api/bots/conflicts/settings.
+func (a *apiHandlers) handleBotConflictSettings(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.conflicts == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.conflicts.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.BotConflictSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.conflicts.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// ChatPageUser es el usuario con el que llegan los mensajes enviados desde /chat.
+const ChatPageUser = "web-moderator"
+
+// chatPageClient es el valor de ?client= con el que la página se conecta a /ws/chat.
+const chatPageClient = "chatpage"
+
+//go:embed chat_page.html
+var chatPageHTML string
+
+var chatPageTemplate = template.Must(template.New("chat").Parse(chatPageHTML))
+
+// ChatPageSender manda al chat, como el bot, lo que se escribe en /chat.
+type ChatPageSender interface {
+	SendChatPageMessage(ctx context.Context, msg domain.Message) error
+}
+
+// ChatPageConfig configura la página /chat para que los mods vean el chat y
+// escriban como el bot desde el navegador, sin la app de escritorio.
+type ChatPageConfig struct {
+	// Disabled apaga la página y rechaza sus conexiones. Sin Token la
+	// página queda apagada aunque Disabled sea false.
+	Disabled bool
+	// Token se exige en ?token= para abrir la página, para conectarse desde
+	// ella y para escribir desde cualquier conexión a /ws/chat; las
+	// conexiones sin token solo reciben el chat y las que traen otro token
+	// se rechazan.
+	Token string
+	// Moderator marca como moderador al usuario web-moderator; si no, sus
+	// mensajes tienen permisos de usuario común.
+	Moderator bool
+	// Sender manda los mensajes al chat. Sin Sender solo se procesan como
+	// cualquier mensaje entrante (comandos).
+	Sender ChatPageSender
+}
+
+type chatPageData struct {
+	// Token es el configurado: nunca se repite lo que venga en la URL.
+	Token  string
+	WSPath string
+}
+
+// handleChatPage atiende GET /chat.
+func (s *Server) handleChatPage(w http.ResponseWriter, r *http.Request) {
+	if s.chatPage.Disabled {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.chatPageAuthorized(r) {
+		http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+		return
+	}
+	var buf bytes.Buffer
+	data := chatPageData{Token: s.chatPage.Token, WSPath: "/ws/chat"}
+	if err := chatPageTemplate.Execute(&buf, data); err != nil {
+		log.Printf("ws: chat page: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) chatPageAuthorized(r *http.Request) bool {
+	if s.chatPage.Token == "" {
+		return true
+	}
+	token := requestToken(r)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.chatPage.Token)) == 1
+}
+
+func requestToken(r *http.Request) string {
+	return strings.TrimSpace(r.URL.Query().Get("token"))
+}
+
+func hasToken(r *http.Request) bool {
+	return requestToken(r) != ""
+}
+
+func isChatPageRequest(r *http.Request) bool {
+	return r.URL.Query().Get("client") == chatPageClient
+}
+
+// chatPageMessage arma el mensaje de web-moderator; lo que mande el cliente
+// como usuario o permisos se ignora.
+func (s *Server) chatPageMessage(payload incomingPayload) domain.Message {
+	platform := normalizePlatform(payload.Platform)
+	if platform == "" {
+		platform = domain.PlatformTwitch
+	}
+	return domain.Message{
+		Platform:      platform,
+		ChannelID:     strings.TrimSpace(payload.ChannelID),
+		UserID:        ChatPageUser,
+		Username:      ChatPageUser,
+		Login:         ChatPageUser,
+		DisplayName:   ChatPageUser,
+		Text:          payload.Text,
+		IsPlatformMod: s.chatPage.Moderator,
+	}
+}
@@ -0,0 +1,180 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"zhatBot/internal/domain"
+)
+
+func getChatPage(t *testing.T, srv *Server, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.handleChatPage(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestChatPageIsOffWithoutToken(t *testing.T) {
+	srv := NewServer(Config{})
+
+	if rec := getChatPage(t, srv, "/chat"); rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	if !srv.chatPage.Disabled {
+		t.Fatal("the page stayed enabled without a token")
+	}
+}
+
+func TestChatPageRequiresToken(t *testing.T) {
+	srv := NewServer(Config{ChatPage: ChatPageConfig{Token: " s3cret "}})
+
+	for _, target := range []string{"/chat", "/chat?token=", "/chat?token=otro"} {
+		if rec := getChatPage(t, srv, target); rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s = %d, want 401", target, rec.Code)
+		}
+	}
+	rec := getChatPage(t, srv, "/chat?token=s3cret")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `const token = "s3cret";`) {
+		t.Fatalf("GET with token = %d\n%s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Fatalf("Cache-Control = %q", got)
+	}
+
+	post := httptest.NewRecorder()
+	srv.handleChatPage(post, httptest.NewRequest(http.MethodPost, "/chat?token=s3cret", nil))
+	if post.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("POST = %d", post.Code)
+	}
+}
+
+func TestChatPageDisabled(t *testing.T) {
+	srv := NewServer(Config{ChatPage: ChatPageConfig{Disabled: true}})
+	if rec := getChatPage(t, srv, "/chat"); rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestChatPageSocketRequiresToken(t *testing.T) {
+	srv := NewServer(Config{ChatPage: ChatPageConfig{Token: "s3cret"}})
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.handleWS(r.Context(), w, r)
+	}))
+	defer httpSrv.Close()
+	base := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws/chat?client=chatpage"
+
+	_, resp, err := websocket.DefaultDialer.Dial(base, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("dial without token = %v, %+v", err, resp)
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(base+"&token=s3cret", nil)
+	if err != nil {
+		t.Fatalf("dial with token: %v", err)
+	}
+	conn.Close()
+}
+
+func TestDashboardSocketAuthenticatesWithToken(t *testing.T) {
+	srv := NewServer(Config{ChatPage: ChatPageConfig{Token: "s3cret"}})
+	handled := make(chan domain.Message, 1)
+	srv.SetHandler(func(_ context.Context, msg domain.Message) error {
+		handled <- msg
+		return nil
+	})
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.handleWS(t.Context(), w, r)
+	}))
+	defer httpSrv.Close()
+	base := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws/chat"
+
+	_, resp, err := websocket.DefaultDialer.Dial(base+"?token=otro", nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("dial with a wrong token = %v, %+v", err, resp)
+	}
+
+	// sin token la conexión entra (overlays) pero solo lee
+	reader, _, err := websocket.DefaultDialer.Dial(base, nil)
+	if err != nil {
+		t.Fatalf("dial without token: %v", err)
+	}
+	defer reader.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(base+"?token=s3cret", nil)
+	if err != nil {
+		t.Fatalf("dial with token: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"hola"}`)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	select {
+	case msg := <-handled:
+		if msg.Text != "hola" {
+			t.Fatalf("msg = %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the authenticated dashboard could not write")
+	}
+}
+
+type captureSender struct {
+	sent []domain.Message
+}
+
+func (c *captureSender) SendChatPageMessage(_ context.Context, msg domain.Message) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func TestChatPageMessagesComeFromWebModerator(t *testing.T) {
+	for _, moderator := range []bool{true, false} {
+		sender := &captureSender{}
+		srv := NewServer(Config{ChatPage: ChatPageConfig{Moderator: moderator, Sender: sender}})
+		client := &wsClient{chatPage: true, canWrite: true}
+
+		frame := `{"text":" hola chat ","platform":"kick","channel_id":"99","username":"Zero","user_id":"1","is_owner":true}`
+		if err := srv.dispatchIncoming(context.Background(), client, []byte(frame)); err != nil {
+			t.Fatalf("dispatchIncoming: %v", err)
+		}
+		if len(sender.sent) != 1 {
+			t.Fatalf("sent = %+v", sender.sent)
+		}
+		msg := sender.sent[0]
+		if msg.Username != ChatPageUser || msg.UserID != ChatPageUser || msg.IsPlatformOwner || msg.IsPlatformAdmin {
+			t.Fatalf("the page impersonated a user: %+v", msg)
+		}
+		if msg.Platform != domain.PlatformKick || msg.ChannelID != "99" || msg.Text != "hola chat" {
+			t.Fatalf("msg = %+v", msg)
+		}
+		if msg.IsPlatformMod != moderator {
+			t.Fatalf("IsPlatformMod = %v, want %v", msg.IsPlatformMod, moderator)
+		}
+	}
+}
+
+func TestReadOnlyConnectionsCannotWrite(t *testing.T) {
+	sender := &captureSender{}
+	srv := NewServer(Config{ChatPage: ChatPageConfig{Token: "s3cret", Sender: sender}})
+	var handled []domain.Message
+	srv.SetHandler(func(_ context.Context, msg domain.Message) error {
+		handled = append(handled, msg)
+		return nil
+	})
+
+	for _, client := range []*wsClient{{}, {chatPage: true}} {
+		err := srv.dispatchIncoming(context.Background(), client, []byte("!ping"))
+		if !errors.Is(err, ErrUnauthorizedIncoming) || !isRejectedIncoming(err) {
+			t.Fatalf("dispatchIncoming without token = %v", err)
+		}
+	}
+	if len(handled) != 0 || len(sender.sent) != 0 {
+		t.Fatalf("a read-only connection wrote: %+v %+v", handled, sender.sent)
+	}
+}
@@ -0,0 +1,76 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	configprofileusecase "zhatBot/internal/usecase/configprofile"
+)
+
+// maxProfileBody limita el perfil que se puede importar.
+const maxProfileBody = 4 << 20
+
+type ConfigProfileManager interface {
+	Export(ctx context.Context) (configprofileusecase.Document, error)
+	Import(ctx context.Context, raw []byte, sections []string, overwrite bool) (configprofileusecase.Report, error)
+}
+
+// profileImportPayload trae el perfil tal como lo devuelve el export.
+type profileImportPayload struct {
+	Profile   json.RawMessage `json:"profile"`
+	Sections  []string        `json:"sections"`
+	Overwrite bool            `json:"overwrite"`
+}
+
+// handleProfileExport atiende GET /api/profile/export.
+func (a *apiHandlers) handleProfileExport(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.configProfile == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	doc, err := a.configProfile.Export(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+// handleProfileImport atiende POST /api/profile/import y devuelve el reporte
+// por sección.
+func (a *apiHandlers) handleProfileImport(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.configProfile == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+	var payload profileImportPayload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProfileBody)).Decode(&payload); err != nil {
+		writeAPIError(w, errInvalidPayload())
+		return
+	}
+	if len(payload.Profile) == 0 {
+		writeError(w, http.StatusBadRequest, "profile is required")
+		return
+	}
+	report, err := a.configProfile.Import(r.Context(), payload.Profile, payload.Sections, payload.Overwrite)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, configprofileusecase.ErrInvalidProfile) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
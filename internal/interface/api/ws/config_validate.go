@@ -0,0 +1,25 @@
+package ws
+
+import (
+	"net/http"
+
+	"zhatBot/internal/infrastructure/config"
+)
+
+// ConfigValidator revisa la configuración cargada al arrancar.
+type ConfigValidator interface {
+	ConfigValidation() config.Validation
+}
+
+// handleConfigValidate atiende GET /api/config/validate.
+func (a *apiHandlers) handleConfigValidate(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.configCheck == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, a.configCheck.ConfigValidation())
+}
@@ -0,0 +1,93 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	countdownusecase "zhatBot/internal/usecase/countdown"
+)
+
+type CountdownManager interface {
+	Status() []countdownusecase.Tick
+	Start(ctx context.Context, platform domain.Platform, channelID string, duration time.Duration, label string) (countdownusecase.Tick, error)
+	Cancel(ctx context.Context, platform domain.Platform, channelID string) error
+}
+
+// countdownPayload acepta la duración como texto («5m», «1:30») o en
+// segundos.
+type countdownPayload struct {
+	Platform  string `json:"platform"`
+	ChannelID string `json:"channel_id"`
+	Duration  string `json:"duration"`
+	Seconds   int    `json:"seconds"`
+	Label     string `json:"label"`
+}
+
+// handleCountdown atiende GET/POST/DELETE /api/countdown.
+func (a *apiHandlers) handleCountdown(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.countdowns == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.countdowns.Status())
+	case http.MethodPost:
+		defer r.Body.Close()
+		var payload countdownPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		duration := time.Duration(payload.Seconds) * time.Second
+		if strings.TrimSpace(payload.Duration) != "" {
+			parsed, err := countdownusecase.ParseDuration(payload.Duration)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			duration = parsed
+		}
+		tick, err := a.countdowns.Start(r.Context(), countdownPlatform(payload.Platform), payload.ChannelID, duration, payload.Label)
+		if err != nil {
+			writeError(w, countdownErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, tick)
+	case http.MethodDelete:
+		query := r.URL.Query()
+		err := a.countdowns.Cancel(r.Context(), countdownPlatform(query.Get("platform")), query.Get("channel_id"))
+		if err != nil {
+			writeError(w, countdownErrorStatus(err), err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// countdownPlatform usa Twitch si no se indica la plataforma.
+func countdownPlatform(value string) domain.Platform {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return domain.PlatformTwitch
+	}
+	return domain.Platform(value)
+}
+
+func countdownErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, countdownusecase.ErrInvalidDuration), errors.Is(err, countdownusecase.ErrNoChannel):
+		return http.StatusBadRequest
+	case errors.Is(err, countdownusecase.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,98 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"zhatBot/internal/domain"
+	countersusecase "zhatBot/internal/usecase/counters"
+)
+
+type CounterManager interface {
+	List() []domain.Counter
+	Get(name string) (domain.Counter, error)
+	Add(ctx context.Context, name string, delta int) (domain.Counter, error)
+	Set(ctx context.Context, name string, value int) (domain.Counter, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// counterPayload fija el valor o, si viene delta, lo suma.
+type counterPayload struct {
+	Value *int `json:"value"`
+	Delta *int `json:"delta"`
+}
+
+// handleCounters atiende GET /api/counters y GET/PUT/DELETE
+// /api/counters/{nombre}.
+func (a *apiHandlers) handleCounters(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.counters == nil {
+		http.NotFound(w, r)
+		return
+	}
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/counters"), "/")
+	if name == "" {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, http.StatusOK, a.counters.List())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		counter, err := a.counters.Get(name)
+		if err != nil {
+			writeError(w, counterErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, counter)
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload counterPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		var (
+			counter domain.Counter
+			err     error
+		)
+		switch {
+		case payload.Delta != nil:
+			counter, err = a.counters.Add(r.Context(), name, *payload.Delta)
+		case payload.Value != nil:
+			counter, err = a.counters.Set(r.Context(), name, *payload.Value)
+		default:
+			writeError(w, http.StatusBadRequest, "value or delta is required")
+			return
+		}
+		if err != nil {
+			writeError(w, counterErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, counter)
+	case http.MethodDelete:
+		if err := a.counters.Delete(r.Context(), name); err != nil {
+			writeError(w, counterErrorStatus(err), err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func counterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, countersusecase.ErrCounterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, countersusecase.ErrInvalidName), errors.Is(err, countersusecase.ErrOutOfRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,40 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+// DebugRecorderManager controla el registro de eventos para depuración.
+type DebugRecorderManager interface {
+	DebugRecorderSettings() domain.DebugRecorderSettings
+	SetDebugRecorderSettings(ctx context.Context, settings domain.DebugRecorderSettings) error
+}
+
+// handleDebugRecorder atiende GET/PUT /api/debug/recorder.
+func (a *apiHandlers) handleDebugRecorder(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.recorder == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.recorder.DebugRecorderSettings())
+	case http.MethodPost, http.MethodPut:
+		var settings domain.DebugRecorderSettings
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		if err := a.recorder.SetDebugRecorderSettings(r.Context(), settings); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, a.recorder.DebugRecorderSettings())
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
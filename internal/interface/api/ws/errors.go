@@ -0,0 +1,157 @@
+package ws
+
+import "net/http"
+
+// APIErrorCode identifica un error de la API de forma estable: el frontend
+// decide con el código y muestra el mensaje tal cual. Los valores no cambian
+// aunque cambie el texto del mensaje.
+type APIErrorCode string
+
+// Códigos genéricos; codeForStatus elige uno de estos cuando el handler no
+// indica otro.
+const (
+	// CodeInvalidPayload: el cuerpo no es JSON válido o no tiene la forma esperada.
+	CodeInvalidPayload APIErrorCode = "invalid_payload"
+	// CodeInvalidArgument: un campo o parámetro tiene un valor inválido.
+	CodeInvalidArgument APIErrorCode = "invalid_argument"
+	// CodeMissingField: falta un campo obligatorio; details.field dice cuál.
+	CodeMissingField APIErrorCode = "missing_field"
+	// CodeInvalidPlatform: la plataforma no es twitch ni kick.
+	CodeInvalidPlatform APIErrorCode = "invalid_platform"
+	// CodeUnauthorized: falta la API key o el token, o no es válido.
+	CodeUnauthorized APIErrorCode = "unauthorized"
+	// CodeNotFound: el recurso pedido no existe.
+	CodeNotFound APIErrorCode = "not_found"
+	// CodeConflict: la operación choca con el estado actual.
+	CodeConflict APIErrorCode = "conflict"
+	// CodePayloadTooLarge: el cuerpo o un campo pasa del tamaño permitido.
+	CodePayloadTooLarge APIErrorCode = "payload_too_large"
+	// CodeRateLimited: demasiadas solicitudes; conviene reintentar más tarde.
+	CodeRateLimited APIErrorCode = "rate_limited"
+	// CodeUpstreamFailed: falló la plataforma externa (Twitch, Kick).
+	CodeUpstreamFailed APIErrorCode = "upstream_failed"
+	// CodeFeatureUnavailable: la función no está configurada en este runtime;
+	// details.feature dice cuál.
+	CodeFeatureUnavailable APIErrorCode = "feature_unavailable"
+	// CodeInternal: error interno (base de datos, servicio caído).
+	CodeInternal APIErrorCode = "internal_error"
+)
+
+// Códigos por función.
+const (
+	// CodeOAuthNotConfigured: falta el client ID/secret o la redirect URI del
+	// proveedor; details.provider dice cuál.
+	CodeOAuthNotConfigured APIErrorCode = "oauth_not_configured"
+	// CodeOAuthStartFailed: no se pudo armar la URL de autorización.
+	CodeOAuthStartFailed APIErrorCode = "oauth_start_failed"
+	// CodeCredentialsUnavailable: no se pudieron leer o borrar las credenciales.
+	CodeCredentialsUnavailable APIErrorCode = "credentials_unavailable"
+	// CodeNoRefreshToken: la credencial no tiene refresh token; hay que volver
+	// a iniciar sesión.
+	CodeNoRefreshToken APIErrorCode = "no_refresh_token"
+	// CodeRefreshRejected: el proveedor rechazó el refresh token para siempre;
+	// hay que volver a iniciar sesión.
+	CodeRefreshRejected APIErrorCode = "refresh_rejected"
+	// CodeRefreshFailed: el refresh falló, quizá de forma pasajera; el mensaje
+	// trae lo que respondió el proveedor.
+	CodeRefreshFailed APIErrorCode = "refresh_failed"
+	// CodeCategorySearchFailed: la búsqueda de categorías falló en la plataforma.
+	CodeCategorySearchFailed APIErrorCode = "category_search_failed"
+	// CodeCategoryUpdateFailed: no se pudo cambiar la categoría.
+	CodeCategoryUpdateFailed APIErrorCode = "category_update_failed"
+	// CodeTTSDisabled: el runtime no tiene servicio de TTS.
+	CodeTTSDisabled APIErrorCode = "tts_disabled"
+	// CodeTTSUnsupportedVoice: la voz pedida no existe.
+	CodeTTSUnsupportedVoice APIErrorCode = "tts_unsupported_voice"
+	// CodeCommandConflict: el nombre o un alias ya lo usa otro comando.
+	CodeCommandConflict APIErrorCode = "command_conflict"
+	// CodeCommandNotFound: el comando no existe.
+	CodeCommandNotFound APIErrorCode = "command_not_found"
+	// CodeNotificationFailed: no se pudo guardar, listar o enviar la notificación.
+	CodeNotificationFailed APIErrorCode = "notification_failed"
+)
+
+// APIError es la respuesta de error de la API. Error conserva el mensaje para
+// humanos (la clave que ya leían los clientes); Code es lo que se compara.
+type APIError struct {
+	Status  int            `json:"-"`
+	Code    APIErrorCode   `json:"code"`
+	Message string         `json:"error"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// newAPIError arma un error con status, código y mensaje.
+func newAPIError(status int, code APIErrorCode, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+// With agrega un dato a Details y devuelve el mismo error.
+func (e *APIError) With(key string, value any) *APIError {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// errInvalidPayload es el error de un cuerpo que no se pudo decodificar.
+func errInvalidPayload() *APIError {
+	return newAPIError(http.StatusBadRequest, CodeInvalidPayload, "invalid payload")
+}
+
+// errMissingField es el error de un campo obligatorio vacío.
+func errMissingField(field string) *APIError {
+	return newAPIError(http.StatusBadRequest, CodeMissingField, "missing "+field).With("field", field)
+}
+
+// errFeatureUnavailable es el error de una función que el runtime no tiene.
+func errFeatureUnavailable(feature string) *APIError {
+	return newAPIError(http.StatusNotFound, CodeFeatureUnavailable, feature+" unavailable").With("feature", feature)
+}
+
+// writeAPIError escribe err como {"error": ..., "code": ..., "details": ...}.
+func writeAPIError(w http.ResponseWriter, err *APIError) {
+	if err == nil {
+		err = newAPIError(http.StatusInternalServerError, CodeInternal, "internal error")
+	}
+	status := err.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	if err.Code == "" {
+		err.Code = codeForStatus(status)
+	}
+	writeJSON(w, status, err)
+}
+
+// writeError escribe un error con el código genérico del status. Los handlers
+// que necesitan un código propio usan writeAPIError.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeAPIError(w, newAPIError(status, codeForStatus(status), msg))
+}
+
+// codeForStatus es el código genérico de cada status HTTP.
+func codeForStatus(status int) APIErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidArgument
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return CodeUnauthorized
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CodeUpstreamFailed
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,224 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+	commandsusecase "zhatBot/internal/usecase/commands"
+	ttsusecase "zhatBot/internal/usecase/tts"
+)
+
+var errBackend = errors.New("backend caído")
+
+type failingCategories struct{}
+
+func (failingCategories) Search(context.Context, domain.Platform, string) ([]domain.CategoryOption, error) {
+	return nil, errBackend
+}
+
+func (failingCategories) Update(context.Context, domain.Platform, string) error {
+	return errBackend
+}
+
+// failingTTS rechaza cada cambio; el resto de TTSManager no se usa.
+type failingTTS struct {
+	TTSManager
+	voiceErr error
+}
+
+func (f failingTTS) SetVoice(context.Context, string) (ttsusecase.VoiceOption, error) {
+	return ttsusecase.VoiceOption{}, f.voiceErr
+}
+
+func (failingTTS) SetEnabled(context.Context, bool) error { return errBackend }
+
+func (failingTTS) SetSkipVotesRequired(context.Context, int) error { return errBackend }
+
+func (failingTTS) SetMaxFetchSeconds(context.Context, int) error { return errBackend }
+
+type failingCredentials struct {
+	domain.CredentialRepository
+}
+
+func (failingCredentials) List(context.Context) ([]*domain.Credential, error) { return nil, errBackend }
+
+func (failingCredentials) Delete(context.Context, domain.Platform, string) error { return errBackend }
+
+type failingNotifications struct{}
+
+func (failingNotifications) SaveNotification(context.Context, *domain.Notification) (*domain.Notification, error) {
+	return nil, errBackend
+}
+
+func (failingNotifications) ListNotifications(context.Context, int) ([]*domain.Notification, error) {
+	return nil, errBackend
+}
+
+func (failingNotifications) CountNotifications(context.Context, domain.NotificationFilter) (int, error) {
+	return 0, errBackend
+}
+
+type failingNotifier struct{}
+
+func (failingNotifier) Emit(context.Context, *domain.Notification) (*domain.Notification, error) {
+	return nil, errBackend
+}
+
+func (failingNotifier) Test(context.Context, domain.NotificationType, domain.Platform, string) (*domain.Notification, error) {
+	return nil, errBackend
+}
+
+// newCommandService arma el servicio de comandos real con un !hola guardado.
+func newCommandService(t *testing.T) *commandsusecase.Service {
+	t.Helper()
+	ctx := context.Background()
+	manager, err := commandsusecase.NewCustomCommandManager(ctx, newTestStore(t))
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	svc := commandsusecase.NewService(manager)
+	response := "¡Hola!"
+	if _, err := svc.Upsert(ctx, commandsusecase.CommandMutationDTO{Name: "hola", Response: &response}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	return svc
+}
+
+func TestHandlerErrorCodes(t *testing.T) {
+	commands := newCommandService(t)
+	kick := &KickOAuthConfig{ClientID: "id", ClientSecret: "secret", RedirectURI: "http://localhost/cb"}
+
+	type handler func(*apiHandlers) http.HandlerFunc
+	cases := []struct {
+		name    string
+		cfg     Config
+		handler handler
+		method  string
+		target  string
+		body    string
+		status  int
+		code    APIErrorCode
+		detail  string
+	}{
+		// oauth: status, logout e inicio de sesión
+		{"status list fails", Config{CredentialRepo: failingCredentials{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleStatus }, http.MethodGet, "/api/oauth/status", "", http.StatusInternalServerError, CodeCredentialsUnavailable, ""},
+		{"logout bad json", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleLogout }, http.MethodPost, "/api/oauth/logout", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"logout bad platform", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleLogout }, http.MethodPost, "/api/oauth/logout", `{"platform":"youtube"}`, http.StatusBadRequest, CodeInvalidPlatform, ""},
+		{"logout delete fails", Config{CredentialRepo: failingCredentials{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleLogout }, http.MethodPost, "/api/oauth/logout", `{"platform":"twitch","role":"bot"}`, http.StatusInternalServerError, CodeCredentialsUnavailable, ""},
+		{"twitch not configured", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleTwitchStart }, http.MethodPost, "/api/oauth/twitch/start", "", http.StatusNotFound, CodeOAuthNotConfigured, "provider"},
+		{"kick not configured", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleKickStart }, http.MethodPost, "/api/oauth/kick/start", "", http.StatusNotFound, CodeOAuthNotConfigured, "provider"},
+		{"kick start bad json", Config{Kick: kick}, func(a *apiHandlers) http.HandlerFunc { return a.handleKickStart }, http.MethodPost, "/api/oauth/kick/start", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+
+		// categorías
+		{"categories unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategorySearch }, http.MethodGet, "/api/categories/search", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"search bad platform", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategorySearch }, http.MethodGet, "/api/categories/search?platform=yt&query=x", "", http.StatusBadRequest, CodeInvalidPlatform, ""},
+		{"search missing query", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategorySearch }, http.MethodGet, "/api/categories/search?platform=twitch", "", http.StatusBadRequest, CodeMissingField, "field"},
+		{"search upstream fails", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategorySearch }, http.MethodGet, "/api/categories/search?platform=kick&query=x", "", http.StatusInternalServerError, CodeCategorySearchFailed, "platform"},
+		{"update unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategoryUpdate }, http.MethodPost, "/api/categories/update", "{}", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"update bad json", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategoryUpdate }, http.MethodPost, "/api/categories/update", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"update bad platform", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategoryUpdate }, http.MethodPost, "/api/categories/update", `{"platform":"yt","name":"x"}`, http.StatusBadRequest, CodeInvalidPlatform, ""},
+		{"update missing name", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategoryUpdate }, http.MethodPost, "/api/categories/update", `{"platform":"twitch"}`, http.StatusBadRequest, CodeMissingField, "field"},
+		{"update upstream fails", Config{CategoryManager: failingCategories{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleCategoryUpdate }, http.MethodPost, "/api/categories/update", `{"platform":"twitch","name":"Just Chatting"}`, http.StatusInternalServerError, CodeCategoryUpdateFailed, "platform"},
+
+		// tts
+		{"tts status disabled", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSStatus }, http.MethodGet, "/api/tts/status", "", http.StatusNotFound, CodeTTSDisabled, ""},
+		{"tts voices disabled", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSVoices }, http.MethodGet, "/api/tts/voices", "", http.StatusNotFound, CodeTTSDisabled, ""},
+		{"tts update disabled", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", "{}", http.StatusNotFound, CodeTTSDisabled, ""},
+		{"tts update bad json", Config{TTSManager: failingTTS{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"tts unknown voice", Config{TTSManager: failingTTS{voiceErr: fmt.Errorf("xx: %w", ttsusecase.ErrUnsupportedVoice)}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", `{"voice":"xx"}`, http.StatusBadRequest, CodeTTSUnsupportedVoice, "voice"},
+		{"tts voice save fails", Config{TTSManager: failingTTS{voiceErr: errBackend}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", `{"voice":"es"}`, http.StatusInternalServerError, CodeInternal, ""},
+		{"tts enable fails", Config{TTSManager: failingTTS{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", `{"enabled":true}`, http.StatusInternalServerError, CodeInternal, ""},
+		{"tts skip votes invalid", Config{TTSManager: failingTTS{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", `{"skip_votes":-1}`, http.StatusBadRequest, CodeInvalidArgument, "field"},
+		{"tts max fetch invalid", Config{TTSManager: failingTTS{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleTTSUpdate }, http.MethodPost, "/api/tts/settings", `{"max_fetch_seconds":-1}`, http.StatusBadRequest, CodeInvalidArgument, "field"},
+
+		// notificaciones
+		{"notifications unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodGet, "/api/notifications", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"notifications list fails", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodGet, "/api/notifications", "", http.StatusInternalServerError, CodeNotificationFailed, ""},
+		{"notification bad json", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodPost, "/api/notifications", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"notification too large", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodPost, "/api/notifications", `{"message":"` + strings.Repeat("a", maxNotificationBody) + `"}`, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "max_bytes"},
+		{"notification invalid", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodPost, "/api/notifications", `{"type":"donation","amount":-5}`, http.StatusBadRequest, CodeInvalidArgument, ""},
+		{"notification save fails", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotifications }, http.MethodPost, "/api/notifications", `{"type":"donation","platform":"twitch","username":"fan","amount":5}`, http.StatusInternalServerError, CodeNotificationFailed, ""},
+		{"count unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationsCount }, http.MethodGet, "/api/notifications/count", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"count bad type", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationsCount }, http.MethodGet, "/api/notifications/count?type=nope", "", http.StatusBadRequest, CodeInvalidArgument, "field"},
+		{"count bad since", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationsCount }, http.MethodGet, "/api/notifications/count?since=ayer", "", http.StatusBadRequest, CodeInvalidArgument, "field"},
+		{"count fails", Config{NotificationRepo: failingNotifications{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationsCount }, http.MethodGet, "/api/notifications/count", "", http.StatusInternalServerError, CodeNotificationFailed, ""},
+		{"test alert unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationTest }, http.MethodPost, "/api/notifications/test", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"test alert bad json", Config{Notifier: failingNotifier{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationTest }, http.MethodPost, "/api/notifications/test", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"test alert bad type", Config{Notifier: failingNotifier{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationTest }, http.MethodPost, "/api/notifications/test", `{"type":"nope"}`, http.StatusBadRequest, CodeInvalidArgument, "field"},
+		{"test alert fails", Config{Notifier: failingNotifier{}}, func(a *apiHandlers) http.HandlerFunc { return a.handleNotificationTest }, http.MethodPost, "/api/notifications/test", "", http.StatusInternalServerError, CodeNotificationFailed, ""},
+
+		// comandos y estado del stream
+		{"commands unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodGet, "/api/commands", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"commands count unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommandsCount }, http.MethodGet, "/api/commands/count", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"commands reload unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommandsReload }, http.MethodPost, "/api/commands/reload", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"command bad json", Config{CommandService: commands}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodPost, "/api/commands", "{", http.StatusBadRequest, CodeInvalidPayload, ""},
+		{"command conflict", Config{CommandService: commands}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodPost, "/api/commands", `{"name":"chau","response":"adiós","aliases":["hola"]}`, http.StatusConflict, CodeCommandConflict, "name"},
+		{"command invalid", Config{CommandService: commands}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodPost, "/api/commands", `{"name":""}`, http.StatusBadRequest, CodeInvalidArgument, ""},
+		{"delete missing name", Config{CommandService: commands}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodDelete, "/api/commands", "", http.StatusBadRequest, CodeMissingField, "field"},
+		{"delete unknown", Config{CommandService: commands}, func(a *apiHandlers) http.HandlerFunc { return a.handleCommands }, http.MethodDelete, "/api/commands?name=nada", "", http.StatusNotFound, CodeCommandNotFound, "name"},
+		{"stream status unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleStreamStatus }, http.MethodGet, "/api/stream/status", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+		{"settings reload unavailable", Config{}, func(a *apiHandlers) http.HandlerFunc { return a.handleSettingsReload }, http.MethodPost, "/api/settings/reload", "", http.StatusNotFound, CodeFeatureUnavailable, "feature"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			req.RemoteAddr = "127.0.0.1:5000"
+			rec := httptest.NewRecorder()
+			tc.handler(newAPIHandlers(tc.cfg))(rec, req)
+
+			assertAPIError(t, rec, tc.status, tc.code)
+			var apiErr APIError
+			decodeJSON(t, rec.Body.Bytes(), &apiErr)
+			if apiErr.Message == "" {
+				t.Fatal("the human-readable error key is missing")
+			}
+			if _, ok := apiErr.Details[tc.detail]; tc.detail != "" && !ok {
+				t.Fatalf("details = %v, want %q", apiErr.Details, tc.detail)
+			}
+		})
+	}
+}
+
+func TestWriteAPIErrorDefaults(t *testing.T) {
+	cases := []struct {
+		status int
+		code   APIErrorCode
+	}{
+		{http.StatusBadRequest, CodeInvalidArgument},
+		{http.StatusUnauthorized, CodeUnauthorized},
+		{http.StatusForbidden, CodeUnauthorized},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusRequestEntityTooLarge, CodePayloadTooLarge},
+		{http.StatusTooManyRequests, CodeRateLimited},
+		{http.StatusBadGateway, CodeUpstreamFailed},
+		{http.StatusTeapot, CodeInternal},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		writeError(rec, tc.status, "algo falló")
+		assertAPIError(t, rec, tc.status, tc.code)
+	}
+
+	rec := httptest.NewRecorder()
+	writeAPIError(rec, nil)
+	assertAPIError(t, rec, http.StatusInternalServerError, CodeInternal)
+
+	// sin details no se manda la clave
+	rec = httptest.NewRecorder()
+	writeAPIError(rec, errInvalidPayload())
+	var raw map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["details"]; ok || raw["error"] != "invalid payload" || raw["code"] != string(CodeInvalidPayload) {
+		t.Fatalf("body = %v", raw)
+	}
+}
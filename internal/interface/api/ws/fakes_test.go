@@ -0,0 +1,115 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sqlitestorage "zhatBot/internal/infrastructure/persistence/sqlite"
+)
+
+// newTestAPI levanta solo las rutas /api/* con la configuración dada.
+func newTestAPI(t *testing.T, cfg Config) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	newAPIHandlers(cfg).register(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestStore(t *testing.T) *sqlitestorage.CredentialStore {
+	t.Helper()
+	store, err := sqlitestorage.NewCredentialStore(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("NewCredentialStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// doRequest hace la petición y devuelve el status y el cuerpo crudo.
+func doRequest(t *testing.T, method, url, body string) (int, []byte) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+func decodeJSON(t *testing.T, data []byte, dst any) {
+	t.Helper()
+	if err := json.Unmarshal(data, dst); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+}
+
+// freeAddr reserva un puerto local libre y lo devuelve para reutilizarlo.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+// runServer arranca un Server real en cfg.Addr y devuelve una función que lo
+// apaga y espera a que Start termine.
+func runServer(t *testing.T, srv *Server) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Start(ctx) }()
+	select {
+	case <-srv.Ready():
+	case err := <-done:
+		cancel()
+		t.Fatalf("Start: %v", err)
+	case <-time.After(2 * time.Second):
+		cancel()
+		t.Fatal("server not ready")
+	}
+
+	stopped := false
+	stop = func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("server did not stop")
+		}
+	}
+	t.Cleanup(stop)
+	return stop
+}
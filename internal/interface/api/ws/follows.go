@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+// FollowPolicyManager configura cómo se anuncian los follows en el chat.
+type FollowPolicyManager interface {
+	Settings() domain.FollowAnnounceSettings
+	Update(ctx context.Context, settings domain.FollowAnnounceSettings) (domain.FollowAnnounceSettings, error)
+}
+
+// handleFollowAutomation atiende GET/PUT /api/automations/follows.
+func (a *apiHandlers) handleFollowAutomation(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.follows == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.follows.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.FollowAnnounceSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.follows.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,47 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"zhatBot/internal/domain"
+	gamesusecase "zhatBot/internal/usecase/games"
+)
+
+type ChatGamesManager interface {
+	Settings() domain.ChatGamesSettings
+	Update(ctx context.Context, settings domain.ChatGamesSettings) (domain.ChatGamesSettings, error)
+}
+
+// handleGamesSettings atiende GET/PUT /api/games/settings.
+func (a *apiHandlers) handleGamesSettings(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.games == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.games.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.ChatGamesSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.games.Update(r.Context(), payload)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, gamesusecase.ErrUnknownGame) {
+				status = http.StatusBadRequest
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
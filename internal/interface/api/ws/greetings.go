@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+// GreetingManager configura el saludo a quien vuelve después de mucho tiempo.
+type GreetingManager interface {
+	Settings() domain.GreetingSettings
+	Update(ctx context.Context, settings domain.GreetingSettings) (domain.GreetingSettings, error)
+}
+
+// handleGreetingAutomation atiende GET/PUT /api/automations/greetings.
+func (a *apiHandlers) handleGreetingAutomation(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.greetings == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.greetings.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.GreetingSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.greetings.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
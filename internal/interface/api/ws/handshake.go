@@ -0,0 +1,152 @@
+package ws
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Handshake de /ws/chat pensado para que los overlays (OBS) se reconecten bien
+// después de reiniciar el bot:
+//
+//  1. Al conectar, el servidor envía {"type":"hello","data":{session_id, ...}}.
+//     session_id cambia en cada arranque; si el cliente ve uno distinto al que
+//     tenía, sabe que el servidor se reinició.
+//  2. El cliente puede pedir {"type":"history"} y recibe
+//     {"type":"history","data":[...]} con los últimos envelopes publicados.
+//  3. Al apagarse, el servidor cierra cada conexión con el código 1012
+//     (service restart) y un reason JSON {"retry_after_ms":N}.
+//  4. Mientras tanto el cliente puede consultar GET /api/ping hasta que vuelva
+//     a responder antes de reabrir el WS.
+const (
+	EventTypeHello   = "hello"
+	EventTypeHistory = "history"
+
+	// HandshakeVersion se incrementa si cambia el formato de estos mensajes.
+	HandshakeVersion = 1
+
+	// CloseServiceRestart es el código del close frame enviado al apagar.
+	CloseServiceRestart = websocket.CloseServiceRestart
+
+	DefaultRetryAfter  = 2 * time.Second
+	defaultHistorySize = 50
+)
+
+type helloPayload struct {
+	SessionID    string `json:"session_id"`
+	Version      int    `json:"version"`
+	RetryAfterMS int64  `json:"retry_after_ms"`
+	ServerTime   string `json:"server_time"`
+}
+
+type closeReason struct {
+	RetryAfterMS int64 `json:"retry_after_ms"`
+}
+
+type pingResponse struct {
+	OK         bool   `json:"ok"`
+	SessionID  string `json:"session_id"`
+	ServerTime string `json:"server_time"`
+}
+
+func newSessionID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("s%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// eventHistory guarda los últimos envelopes publicados para reenviarlos a los
+// clientes que se reconectan.
+type eventHistory struct {
+	mu    sync.Mutex
+	size  int
+	items []json.RawMessage
+}
+
+func newEventHistory(size int) *eventHistory {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &eventHistory{size: size}
+}
+
+func (h *eventHistory) add(payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.items = append(h.items, json.RawMessage(append([]byte(nil), payload...)))
+	if extra := len(h.items) - h.size; extra > 0 {
+		h.items = append([]json.RawMessage(nil), h.items[extra:]...)
+	}
+}
+
+func (h *eventHistory) snapshot() []json.RawMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]json.RawMessage{}, h.items...)
+}
+
+// SessionID identifica este arranque del servidor.
+func (s *Server) SessionID() string {
+	return s.sessionID
+}
+
+func (s *Server) sendHello(client *wsClient) error {
+	return client.writeJSON(envelope{
+		Type: EventTypeHello,
+		Data: helloPayload{
+			SessionID:    s.sessionID,
+			Version:      HandshakeVersion,
+			RetryAfterMS: s.retryAfter.Milliseconds(),
+			ServerTime:   time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+func (s *Server) sendHistory(client *wsClient) error {
+	return client.writeJSON(envelope{Type: EventTypeHistory, Data: s.history.snapshot()})
+}
+
+// closeClients avisa a cada cliente que el servidor se reinicia y cuándo
+// conviene reintentar.
+func (s *Server) closeClients() {
+	reason, _ := json.Marshal(closeReason{RetryAfterMS: s.retryAfter.Milliseconds()})
+	frame := websocket.FormatCloseMessage(CloseServiceRestart, string(reason))
+	deadline := time.Now().Add(time.Second)
+
+	s.mu.RLock()
+	clients := make([]*wsClient, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.RUnlock()
+
+	for _, c := range clients {
+		c.mu.Lock()
+		_ = c.conn.WriteControl(websocket.CloseMessage, frame, deadline)
+		c.mu.Unlock()
+		c.conn.Close()
+	}
+}
+
+// handlePing atiende GET /api/ping: respuesta mínima para que los overlays
+// sepan cuándo el servidor volvió.
+func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, pingResponse{
+		OK:         true,
+		SessionID:  s.sessionID,
+		ServerTime: time.Now().UTC().Format(time.RFC3339),
+	})
+}
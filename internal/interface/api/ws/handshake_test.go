@@ -0,0 +1,162 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type frame struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func dialChat(t *testing.T, addr string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+addr+"/ws/chat", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) frame {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var f frame
+	if err := conn.ReadJSON(&f); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return f
+}
+
+func readHello(t *testing.T, conn *websocket.Conn) helloPayload {
+	t.Helper()
+	f := readFrame(t, conn)
+	if f.Type != EventTypeHello {
+		t.Fatalf("first frame = %q, want hello", f.Type)
+	}
+	var hello helloPayload
+	if err := json.Unmarshal(f.Data, &hello); err != nil {
+		t.Fatalf("hello payload: %v", err)
+	}
+	return hello
+}
+
+// waitPing consulta /api/ping hasta que el servidor responde, como haría un overlay.
+func waitPing(t *testing.T, addr string) pingResponse {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := httpGetJSON("http://" + addr + "/api/ping")
+		if err == nil {
+			return resp
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("server never answered /api/ping")
+	return pingResponse{}
+}
+
+func TestOverlaySurvivesServerRestart(t *testing.T) {
+	addr := freeAddr(t)
+	cfg := Config{Addr: addr, RetryAfter: 300 * time.Millisecond}
+
+	first := NewServer(cfg)
+	stopFirst := runServer(t, first)
+
+	conn := dialChat(t, addr)
+	hello := readHello(t, conn)
+	if hello.SessionID != first.SessionID() || hello.Version != HandshakeVersion || hello.RetryAfterMS != 300 {
+		t.Fatalf("hello = %+v", hello)
+	}
+
+	stopFirst()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseServiceRestart {
+		t.Fatalf("close = %v, want code %d", err, CloseServiceRestart)
+	}
+	var reason closeReason
+	if err := json.Unmarshal([]byte(closeErr.Text), &reason); err != nil || reason.RetryAfterMS != 300 {
+		t.Fatalf("close reason = %q (%v)", closeErr.Text, err)
+	}
+
+	second := NewServer(cfg)
+	runServer(t, second)
+	if err := second.PublishEvent(t.Context(), "notification", map[string]string{"username": "ana"}); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+	if err := second.PublishEvent(t.Context(), "tts", map[string]string{"audio": "..."}); err != nil {
+		t.Fatalf("PublishEvent: %v", err)
+	}
+
+	ping := waitPing(t, addr)
+	if !ping.OK || ping.SessionID != second.SessionID() {
+		t.Fatalf("ping = %+v", ping)
+	}
+
+	conn = dialChat(t, addr)
+	hello = readHello(t, conn)
+	if hello.SessionID == "" || hello.SessionID == first.SessionID() {
+		t.Fatalf("session id after restart = %q (before %q)", hello.SessionID, first.SessionID())
+	}
+
+	if err := conn.WriteJSON(map[string]string{"type": "history"}); err != nil {
+		t.Fatalf("history request: %v", err)
+	}
+	f := readFrame(t, conn)
+	if f.Type != EventTypeHistory {
+		t.Fatalf("frame = %q, want history", f.Type)
+	}
+	var history []frame
+	if err := json.Unmarshal(f.Data, &history); err != nil {
+		t.Fatalf("history payload: %v", err)
+	}
+	// el audio TTS no se reenvía
+	if len(history) != 1 || history[0].Type != "notification" {
+		t.Fatalf("history = %+v", history)
+	}
+}
+
+func TestEventHistoryKeepsNewest(t *testing.T) {
+	h := newEventHistory(2)
+	h.add([]byte(`1`))
+	h.add([]byte(`2`))
+	h.add([]byte(`3`))
+	got := h.snapshot()
+	if len(got) != 2 || string(got[0]) != "2" || string(got[1]) != "3" {
+		t.Fatalf("history = %s", got)
+	}
+	if snap := newEventHistory(0).snapshot(); snap == nil || len(snap) != 0 {
+		t.Fatalf("empty history = %v, want []", snap)
+	}
+}
+
+func TestPingRejectsWrites(t *testing.T) {
+	addr := freeAddr(t)
+	runServer(t, NewServer(Config{Addr: addr}))
+	if status, _ := doRequest(t, http.MethodPost, "http://"+addr+"/api/ping", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("POST /api/ping = %d, want 405", status)
+	}
+}
+
+func httpGetJSON(url string) (pingResponse, error) {
+	var out pingResponse
+	resp, err := http.Get(url)
+	if err != nil {
+		return out, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return out, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return out, json.NewDecoder(resp.Body).Decode(&out)
+}
@@ -0,0 +1,55 @@
+package ws
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// EventTypeError es el envelope que recibe un cliente cuando su mensaje se
+	// rechaza: {"type":"error","data":{"error":"..."}}.
+	EventTypeError = "error"
+
+	// MaxIncomingMessageSize es el tamaño máximo de un frame entrante. Los
+	// frames más grandes cierran la conexión con el código 1009.
+	MaxIncomingMessageSize = 16 << 10
+)
+
+var (
+	ErrEmptyIncoming   = errors.New("mensaje vacío")
+	ErrInvalidIncoming = errors.New("mensaje inválido")
+	// ErrUnauthorizedIncoming: la conexión no trae el token que exige
+	// CHAT_PAGE_TOKEN y solo puede leer.
+	ErrUnauthorizedIncoming = errors.New("falta el token para escribir en el chat")
+)
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+// validateIncoming descarta los frames que no tienen sentido como mensaje de
+// chat antes de interpretarlos.
+func validateIncoming(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return ErrEmptyIncoming
+	case len(data) > MaxIncomingMessageSize:
+		return fmt.Errorf("%w: supera %d bytes", ErrInvalidIncoming, MaxIncomingMessageSize)
+	case !utf8.Valid(data):
+		return fmt.Errorf("%w: el texto no es UTF-8", ErrInvalidIncoming)
+	}
+	return nil
+}
+
+// isRejectedIncoming indica si el error se le informa al cliente (en vez de
+// solo registrarlo).
+func isRejectedIncoming(err error) bool {
+	return errors.Is(err, ErrEmptyIncoming) || errors.Is(err, ErrInvalidIncoming) || errors.Is(err, ErrUnauthorizedIncoming)
+}
+
+func (s *Server) sendError(client *wsClient, err error) error {
+	return client.writeJSON(envelope{Type: EventTypeError, Data: errorPayload{Error: err.Error()}})
+}
@@ -0,0 +1,147 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+
+	"zhatBot/internal/domain"
+)
+
+func FuzzDispatchIncoming(f *testing.F) {
+	seeds := []string{
+		"",
+		"   ",
+		"hola chat",
+		"!ping",
+		`{"text":"!ping","platform":"kick","channel_id":"canal","username":"Zero"}`,
+		`{"text":"   "}`,
+		`{"text":`,
+		`{"text":123}`,
+		`{}`,
+		`[1,2,3]`,
+		`null`,
+		"\xff\xfe\xfd",
+		"hola\x00\x07mundo",
+		strings.Repeat("a", MaxIncomingMessageSize+1),
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed), true, false)
+	}
+	f.Add([]byte(`{"text":"hola"}`), false, false)
+	f.Add([]byte(`{"text":"hola","platform":"kick"}`), true, true)
+
+	f.Fuzz(func(t *testing.T, data []byte, canWrite, chatPage bool) {
+		srv := NewServer(Config{})
+		var handled []domain.Message
+		srv.SetHandler(func(_ context.Context, msg domain.Message) error {
+			handled = append(handled, msg)
+			return nil
+		})
+		client := &wsClient{canWrite: canWrite, chatPage: chatPage}
+
+		err := srv.dispatchIncoming(context.Background(), client, data)
+		if err != nil && !isRejectedIncoming(err) {
+			t.Fatalf("unexpected error class for %q: %v", data, err)
+		}
+		if err != nil && len(handled) > 0 {
+			t.Fatalf("rejected frame %q reached the handler", data)
+		}
+		if !canWrite && len(handled) > 0 {
+			t.Fatalf("read-only connection wrote %q", data)
+		}
+		if len(data) > MaxIncomingMessageSize && !errors.Is(err, ErrInvalidIncoming) {
+			t.Fatalf("oversized frame err = %v", err)
+		}
+		for _, msg := range handled {
+			if strings.TrimSpace(msg.Text) == "" || !utf8.ValidString(msg.Text) {
+				t.Fatalf("handler got text %q from %q", msg.Text, data)
+			}
+			if msg.Platform != domain.PlatformTwitch && msg.Platform != domain.PlatformKick {
+				t.Fatalf("handler got platform %q", msg.Platform)
+			}
+			if chatPage && (msg.IsPlatformOwner || msg.Username != ChatPageUser) {
+				t.Fatalf("chat page message impersonated a user: %+v", msg)
+			}
+		}
+	})
+}
+
+func TestDispatchIncomingClassifiesFrames(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		wantErr error
+		want    string
+	}{
+		{"plain text", "  !ping  ", nil, "!ping"},
+		{"json", `{"text":" hola ","platform":"KICK"}`, nil, "hola"},
+		{"empty", " \n ", ErrEmptyIncoming, ""},
+		{"empty json text", `{"text":""}`, ErrEmptyIncoming, ""},
+		{"broken json", `{"text":`, ErrInvalidIncoming, ""},
+		{"invalid utf8", "\xff\xfe", ErrInvalidIncoming, ""},
+		{"oversized", strings.Repeat("a", MaxIncomingMessageSize+1), ErrInvalidIncoming, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := NewServer(Config{})
+			var got []domain.Message
+			srv.SetHandler(func(_ context.Context, msg domain.Message) error {
+				got = append(got, msg)
+				return nil
+			})
+			err := srv.dispatchIncoming(context.Background(), &wsClient{canWrite: true}, []byte(tc.data))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if tc.want == "" {
+				if len(got) != 0 {
+					t.Fatalf("handler called with %+v", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Text != tc.want {
+				t.Fatalf("handled = %+v, want text %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWSRejectsMalformedFrames(t *testing.T) {
+	addr := freeAddr(t)
+	srv := NewServer(Config{Addr: addr})
+	srv.SetHandler(func(context.Context, domain.Message) error { return nil })
+	runServer(t, srv)
+
+	conn := dialChat(t, addr)
+	readHello(t, conn)
+
+	expectError := func(t *testing.T) {
+		t.Helper()
+		f := readFrame(t, conn)
+		if f.Type != EventTypeError {
+			t.Fatalf("frame = %q, want error", f.Type)
+		}
+	}
+
+	conn.WriteMessage(websocket.TextMessage, []byte("   "))
+	expectError(t)
+	conn.WriteMessage(websocket.BinaryMessage, []byte{0x00, 0x01})
+	expectError(t)
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":`))
+	expectError(t)
+
+	// un frame gigante cierra la conexión con 1009
+	conn.WriteMessage(websocket.TextMessage, []byte(strings.Repeat("a", MaxIncomingMessageSize+1)))
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseMessageTooBig {
+		t.Fatalf("oversized frame err = %v, want close 1009", err)
+	}
+}
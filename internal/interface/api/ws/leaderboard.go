@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"zhatBot/internal/domain"
+	trackersusecase "zhatBot/internal/usecase/trackers"
+)
+
+type LeaderboardManager interface {
+	Top(ctx context.Context, scope domain.LeaderboardScope, by string, n int) (trackersusecase.LeaderboardDTO, error)
+	Reset(ctx context.Context, scope domain.LeaderboardScope) error
+	Settings() domain.LeaderboardSettings
+	Update(ctx context.Context, settings domain.LeaderboardSettings) (domain.LeaderboardSettings, error)
+}
+
+// handleLeaderboard atiende GET/DELETE /api/leaderboard. GET acepta scope
+// (session|all_time), by (messages|emotes) y limit; DELETE vacía el scope.
+func (a *apiHandlers) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.leaderboard == nil {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	var scope domain.LeaderboardScope
+	if raw := query.Get("scope"); raw != "" {
+		parsed, err := domain.ParseLeaderboardScope(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		scope = parsed
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		top, err := a.leaderboard.Top(r.Context(), scope, query.Get("by"), limit)
+		if err != nil {
+			writeLeaderboardError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, top)
+	case http.MethodDelete:
+		if scope == "" {
+			writeError(w, http.StatusBadRequest, "missing scope")
+			return
+		}
+		if err := a.leaderboard.Reset(r.Context(), scope); err != nil {
+			writeLeaderboardError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleLeaderboardSettings atiende GET/PUT /api/leaderboard/settings.
+func (a *apiHandlers) handleLeaderboardSettings(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.leaderboard == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.leaderboard.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.LeaderboardSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.leaderboard.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeLeaderboardError(w http.ResponseWriter, err error) {
+	if errors.Is(err, trackersusecase.ErrInvalidLeaderboard) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
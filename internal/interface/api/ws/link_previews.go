@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+	linkpreviewusecase "zhatBot/internal/usecase/linkpreview"
+)
+
+type LinkPreviewManager interface {
+	Settings() domain.LinkPreviewSettings
+	Update(ctx context.Context, settings domain.LinkPreviewSettings) (domain.LinkPreviewSettings, error)
+	Stats() linkpreviewusecase.Stats
+}
+
+// handleLinkPreviews atiende GET/PUT /api/settings/link-previews (vistas
+// previas de links en el chat y dominios permitidos).
+func (a *apiHandlers) handleLinkPreviews(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.links == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.links.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.LinkPreviewSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.links.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
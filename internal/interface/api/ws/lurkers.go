@@ -0,0 +1,56 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+	lurkersusecase "zhatBot/internal/usecase/lurkers"
+)
+
+type LurkerManager interface {
+	List(ctx context.Context) lurkersusecase.ListDTO
+	Settings() domain.LurkSettings
+	Update(ctx context.Context, settings domain.LurkSettings) (domain.LurkSettings, error)
+}
+
+// handleLurkers atiende GET /api/chat/lurkers.
+func (a *apiHandlers) handleLurkers(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.lurkers == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, a.lurkers.List(r.Context()))
+}
+
+// handleLurkSettings atiende GET/PUT /api/chat/lurkers/settings.
+func (a *apiHandlers) handleLurkSettings(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.lurkers == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.lurkers.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.LurkSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.lurkers.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,252 @@
+package ws
+
+import (
+	"crypto/subtle"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNotificationRatePerMinute = 30
+	defaultNotificationBurst         = 10
+	// maxNotificationBody alcanza de sobra para los límites de domain.NewNotification.
+	maxNotificationBody      = 32 << 10
+	notificationAPIKeyHeader = "X-API-Key"
+	// bucketIdleTTL es cuánto se guarda el bucket de una IP que dejó de escribir.
+	bucketIdleTTL = 10 * time.Minute
+)
+
+// NotificationIntakeConfig limita el POST /api/notifications, que puede usar
+// cualquiera que llegue al puerto.
+type NotificationIntakeConfig struct {
+	// RatePerMinute y Burst configuran el token bucket por IP (30 y 10 si son 0).
+	RatePerMinute int
+	Burst         int
+	// APIKey, si está, se exige a las fuentes externas en X-API-Key o
+	// Authorization: Bearer. Las conexiones locales directas (el dashboard)
+	// no la necesitan. Sin clave solo se limita por IP.
+	APIKey string
+	// TrustedProxies son IPs o CIDRs de proxies cuyo X-Real-IP y
+	// X-Forwarded-For se creen. Loopback siempre cuenta como proxy: el nginx
+	// de la imagen de Docker reenvía desde 127.0.0.1.
+	TrustedProxies []string
+}
+
+type notificationIntake struct {
+	apiKey  string
+	proxies []*net.IPNet
+	limiter *ipRateLimiter
+}
+
+func newNotificationIntake(cfg NotificationIntakeConfig) *notificationIntake {
+	rate := cfg.RatePerMinute
+	if rate <= 0 {
+		rate = defaultNotificationRatePerMinute
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = defaultNotificationBurst
+	}
+	return &notificationIntake{
+		apiKey:  strings.TrimSpace(cfg.APIKey),
+		proxies: parseTrustedProxies(cfg.TrustedProxies),
+		limiter: newIPRateLimiter(float64(rate)/60, burst),
+	}
+}
+
+// parseTrustedProxies acepta IPs sueltas y CIDRs; lo que no se entiende se
+// descarta con un aviso.
+func parseTrustedProxies(entries []string) []*net.IPNet {
+	var out []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				log.Printf("notifications: proxy de confianza inválido %q", entry)
+				continue
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Printf("notifications: proxy de confianza inválido %q", entry)
+			continue
+		}
+		out = append(out, network)
+	}
+	return out
+}
+
+// admit revisa la API key y después aplica el límite por IP. Quien trae la
+// clave tiene su propio bucket, así el tráfico anónimo de la misma IP no lo
+// frena; los intentos con una clave mala gastan el bucket anónimo. Si rechaza,
+// ya escribió la respuesta.
+func (n *notificationIntake) admit(w http.ResponseWriter, r *http.Request) bool {
+	if n == nil {
+		return true
+	}
+	ip, direct := n.clientIP(r)
+	bucket := ip
+	switch {
+	case n.apiKey == "" || (direct && isLoopback(ip)):
+	case n.validKey(requestAPIKey(r)):
+		bucket = "key|" + ip
+	default:
+		if ok, retryAfter := n.limiter.Allow(ip); !ok {
+			writeRetryAfter(w, retryAfter)
+			return false
+		}
+		writeError(w, http.StatusUnauthorized, "missing or invalid api key")
+		return false
+	}
+	if ok, retryAfter := n.limiter.Allow(bucket); !ok {
+		writeRetryAfter(w, retryAfter)
+		return false
+	}
+	return true
+}
+
+func writeRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	writeError(w, http.StatusTooManyRequests, "too many notifications, slow down")
+}
+
+func (n *notificationIntake) validKey(key string) bool {
+	return key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(n.apiKey)) == 1
+}
+
+func requestAPIKey(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(notificationAPIKeyHeader)); key != "" {
+		return key
+	}
+	auth := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(auth) > len("Bearer ") && strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(auth[len("Bearer "):])
+	}
+	return ""
+}
+
+// clientIP devuelve la IP de quien mandó la petición y si llegó directo, sin
+// proxy en el medio. X-Real-IP y X-Forwarded-For solo se creen si la conexión
+// viene de un proxy de confianza; de cualquier otro lado se ignoran porque
+// cualquiera los puede mandar.
+func (n *notificationIntake) clientIP(r *http.Request) (string, bool) {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !n.trustedProxy(remote) {
+		return remote, true
+	}
+	if real := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); real != nil {
+		return real.String(), false
+	}
+	// el último salto que no es un proxy propio es el que lo mandó
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop != nil && !n.trustedProxy(hop.String()) {
+			return hop.String(), false
+		}
+	}
+	return remote, true
+}
+
+func (n *notificationIntake) trustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	if parsed.IsLoopback() {
+		return true
+	}
+	for _, network := range n.proxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLoopback(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsLoopback()
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// ipRateLimiter es un token bucket por IP.
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens por segundo
+	burst     float64
+	buckets   map[string]*tokenBucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func newIPRateLimiter(perSecond float64, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		rate:    perSecond,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consume un token de key. Si no hay, devuelve cuánto falta para el
+// próximo.
+func (l *ipRateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweepLocked(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// sweepLocked borra los buckets inactivos para que el mapa no crezca con
+// cada IP que pasó alguna vez.
+func (l *ipRateLimiter) sweepLocked(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketIdleTTL {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const validNotification = `{"type":"donation","platform":"twitch","username":"ana","amount":5}`
+
+// postNotification llama al handler como si la petición llegara desde remoteAddr.
+func postNotification(a *apiHandlers, remoteAddr, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	a.handleNotificationsCreate(rec, req)
+	return rec
+}
+
+func assertAPIError(t *testing.T, rec *httptest.ResponseRecorder, status int, code APIErrorCode) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d (%s)", rec.Code, status, rec.Body.String())
+	}
+	var apiErr APIError
+	decodeJSON(t, rec.Body.Bytes(), &apiErr)
+	if apiErr.Code != code {
+		t.Fatalf("code = %q, want %q", apiErr.Code, code)
+	}
+}
+
+func TestNotificationIntakeRejections(t *testing.T) {
+	const remote = "203.0.113.7:5000"
+	cases := []struct {
+		name   string
+		body   string
+		status int
+		code   APIErrorCode
+	}{
+		{"invalid json", `{"type":`, http.StatusBadRequest, CodeInvalidPayload},
+		{"missing type", `{"platform":"twitch"}`, http.StatusBadRequest, CodeInvalidArgument},
+		{"negative amount", `{"type":"bits","amount":-3}`, http.StatusBadRequest, CodeInvalidArgument},
+		{"oversized body", `{"type":"bits","message":"` + strings.Repeat("a", maxNotificationBody) + `"}`, http.StatusRequestEntityTooLarge, CodePayloadTooLarge},
+		{"oversized field", `{"type":"bits","message":"` + strings.Repeat("a", 501) + `"}`, http.StatusRequestEntityTooLarge, CodePayloadTooLarge},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAPIHandlers(Config{NotificationRepo: newTestStore(t)})
+			assertAPIError(t, postNotification(a, remote, tc.body, nil), tc.status, tc.code)
+		})
+	}
+}
+
+func TestNotificationIntakeAccepts(t *testing.T) {
+	store := newTestStore(t)
+	a := newAPIHandlers(Config{NotificationRepo: store})
+
+	rec := postNotification(a, "203.0.113.7:5000", validNotification, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	items, err := store.ListNotifications(t.Context(), 10)
+	if err != nil || len(items) != 1 || items[0].Username != "ana" {
+		t.Fatalf("stored = %+v, %v", items, err)
+	}
+}
+
+func TestNotificationIntakeRateLimitPerIP(t *testing.T) {
+	a := newAPIHandlers(Config{
+		NotificationRepo:   newTestStore(t),
+		NotificationIntake: NotificationIntakeConfig{RatePerMinute: 60, Burst: 2},
+	})
+	clock := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	a.intake.limiter.now = func() time.Time { return clock }
+
+	for i := 0; i < 2; i++ {
+		if rec := postNotification(a, "203.0.113.7:5000", validNotification, nil); rec.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d", i, rec.Code)
+		}
+	}
+	rec := postNotification(a, "203.0.113.7:5001", validNotification, nil)
+	assertAPIError(t, rec, http.StatusTooManyRequests, CodeRateLimited)
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Fatalf("Retry-After = %q, want 1", got)
+	}
+
+	// otra IP tiene su propio bucket
+	if rec := postNotification(a, "198.51.100.9:5000", validNotification, nil); rec.Code != http.StatusOK {
+		t.Fatalf("other IP status = %d", rec.Code)
+	}
+
+	// con el tiempo se recupera un token
+	clock = clock.Add(time.Second)
+	if rec := postNotification(a, "203.0.113.7:5000", validNotification, nil); rec.Code != http.StatusOK {
+		t.Fatalf("after refill status = %d", rec.Code)
+	}
+}
+
+func TestNotificationIntakeAPIKey(t *testing.T) {
+	a := newAPIHandlers(Config{
+		NotificationRepo:   newTestStore(t),
+		NotificationIntake: NotificationIntakeConfig{APIKey: "secreta", Burst: 50},
+	})
+	const remote = "203.0.113.7:5000"
+
+	assertAPIError(t, postNotification(a, remote, validNotification, nil), http.StatusUnauthorized, CodeUnauthorized)
+	assertAPIError(t, postNotification(a, remote, validNotification, map[string]string{"X-API-Key": "otra"}), http.StatusUnauthorized, CodeUnauthorized)
+
+	for _, headers := range []map[string]string{
+		{"X-API-Key": "secreta"},
+		{"Authorization": "Bearer secreta"},
+		{"Authorization": "bearer  secreta "},
+	} {
+		if rec := postNotification(a, remote, validNotification, headers); rec.Code != http.StatusOK {
+			t.Fatalf("headers %v: status = %d", headers, rec.Code)
+		}
+	}
+
+	// el dashboard local no necesita la clave
+	if rec := postNotification(a, "127.0.0.1:5000", validNotification, nil); rec.Code != http.StatusOK {
+		t.Fatalf("loopback status = %d", rec.Code)
+	}
+	if rec := postNotification(a, "[::1]:5000", validNotification, nil); rec.Code != http.StatusOK {
+		t.Fatalf("ipv6 loopback status = %d", rec.Code)
+	}
+}
+
+func TestNotificationIntakeBehindProxy(t *testing.T) {
+	a := newAPIHandlers(Config{
+		NotificationRepo:   newTestStore(t),
+		NotificationIntake: NotificationIntakeConfig{APIKey: "secreta", Burst: 1},
+	})
+	// así llega todo lo que reenvía el nginx de la imagen de Docker
+	const nginx = "127.0.0.1:41000"
+	external := map[string]string{"X-Real-IP": "203.0.113.7", "X-Forwarded-For": "203.0.113.7"}
+
+	assertAPIError(t, postNotification(a, nginx, validNotification, external), http.StatusUnauthorized, CodeUnauthorized)
+	withKey := map[string]string{"X-Real-IP": "203.0.113.7", "X-API-Key": "secreta"}
+	if rec := postNotification(a, nginx, validNotification, withKey); rec.Code != http.StatusOK {
+		t.Fatalf("proxied with key: status = %d (%s)", rec.Code, rec.Body.String())
+	}
+
+	// cada cliente detrás del proxy tiene su propio bucket
+	other := map[string]string{"X-Real-IP": "198.51.100.9", "X-API-Key": "secreta"}
+	if rec := postNotification(a, nginx, validNotification, other); rec.Code != http.StatusOK {
+		t.Fatalf("second client behind the proxy: status = %d", rec.Code)
+	}
+	assertAPIError(t, postNotification(a, nginx, validNotification, withKey), http.StatusTooManyRequests, CodeRateLimited)
+}
+
+func TestNotificationIntakeKeyHasItsOwnBucket(t *testing.T) {
+	a := newAPIHandlers(Config{
+		NotificationRepo:   newTestStore(t),
+		NotificationIntake: NotificationIntakeConfig{APIKey: "secreta", Burst: 2},
+	})
+	const remote = "203.0.113.7:5000"
+
+	// los intentos con clave mala gastan el bucket anónimo de la IP
+	bad := map[string]string{"X-API-Key": "otra"}
+	assertAPIError(t, postNotification(a, remote, validNotification, bad), http.StatusUnauthorized, CodeUnauthorized)
+	assertAPIError(t, postNotification(a, remote, validNotification, bad), http.StatusUnauthorized, CodeUnauthorized)
+	assertAPIError(t, postNotification(a, remote, validNotification, bad), http.StatusTooManyRequests, CodeRateLimited)
+
+	// y no frenan a quien trae la clave desde la misma IP
+	if rec := postNotification(a, remote, validNotification, map[string]string{"X-API-Key": "secreta"}); rec.Code != http.StatusOK {
+		t.Fatalf("authenticated status = %d (%s)", rec.Code, rec.Body.String())
+	}
+}
+
+func TestNotificationIntakeClientIP(t *testing.T) {
+	intake := newNotificationIntake(NotificationIntakeConfig{TrustedProxies: []string{"10.0.0.0/8", " 192.0.2.1 ", "no-es-ip"}})
+	cases := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		ip      string
+		direct  bool
+	}{
+		{"direct", "203.0.113.7:5000", nil, "203.0.113.7", true},
+		{"spoofed headers from a client", "203.0.113.7:5000", map[string]string{"X-Real-IP": "127.0.0.1", "X-Forwarded-For": "127.0.0.1"}, "203.0.113.7", true},
+		{"loopback without headers", "127.0.0.1:5000", nil, "127.0.0.1", true},
+		{"loopback proxy", "127.0.0.1:5000", map[string]string{"X-Real-IP": "198.51.100.9"}, "198.51.100.9", false},
+		{"configured cidr", "10.1.2.3:5000", map[string]string{"X-Forwarded-For": "127.0.0.1, 198.51.100.9, 10.0.0.5"}, "198.51.100.9", false},
+		{"configured ip", "192.0.2.1:5000", map[string]string{"X-Forwarded-For": "198.51.100.9"}, "198.51.100.9", false},
+		{"unlisted proxy", "192.0.2.2:5000", map[string]string{"X-Real-IP": "198.51.100.9"}, "192.0.2.2", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications", nil)
+			req.RemoteAddr = tc.remote
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+			if ip, direct := intake.clientIP(req); ip != tc.ip || direct != tc.direct {
+				t.Fatalf("clientIP = %q, %v; want %q, %v", ip, direct, tc.ip, tc.direct)
+			}
+		})
+	}
+}
+
+func TestIPRateLimiterSweepsIdleBuckets(t *testing.T) {
+	limiter := newIPRateLimiter(1, 1)
+	clock := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return clock }
+
+	limiter.Allow("a")
+	clock = clock.Add(bucketIdleTTL)
+	limiter.Allow("b")
+	if _, ok := limiter.buckets["a"]; ok {
+		t.Fatal("idle bucket not swept")
+	}
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("buckets = %d", len(limiter.buckets))
+	}
+}
@@ -0,0 +1,68 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"zhatBot/internal/domain"
+	notificationsusecase "zhatBot/internal/usecase/notifications"
+)
+
+// NotificationTemplateManager administra las plantillas de texto por tipo de notificación.
+type NotificationTemplateManager interface {
+	Templates() []notificationsusecase.TemplateDTO
+	SetTemplate(ctx context.Context, notificationType domain.NotificationType, template string) (notificationsusecase.TemplateDTO, error)
+	ResetTemplate(ctx context.Context, notificationType domain.NotificationType) (notificationsusecase.TemplateDTO, error)
+}
+
+type notificationTemplateRequest struct {
+	Type     string `json:"type"`
+	Template string `json:"template"`
+}
+
+// handleNotificationTemplates atiende /api/notifications/templates:
+// GET lista, PUT/POST guarda y DELETE ?type= vuelve a la plantilla de fábrica.
+func (a *apiHandlers) handleNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.templates == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.templates.Templates())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var req notificationTemplateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		item, err := a.templates.SetTemplate(r.Context(), domain.NotificationType(req.Type), req.Template)
+		if err != nil {
+			writeTemplateError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodDelete:
+		notificationType := domain.NotificationType(r.URL.Query().Get("type"))
+		item, err := a.templates.ResetTemplate(r.Context(), notificationType)
+		if err != nil {
+			writeTemplateError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeTemplateError(w http.ResponseWriter, err error) {
+	if errors.Is(err, notificationsusecase.ErrInvalidTemplate) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
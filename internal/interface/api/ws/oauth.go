@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -22,6 +23,7 @@ import (
 	"zhatBot/internal/app/events"
 	"zhatBot/internal/domain"
 	commandsusecase "zhatBot/internal/usecase/commands"
+	connectionsusecase "zhatBot/internal/usecase/connections"
 	statususecase "zhatBot/internal/usecase/status"
 	ttsusecase "zhatBot/internal/usecase/tts"
 )
@@ -32,7 +34,11 @@ const (
 )
 
 type Config struct {
-	Addr             string
+	Addr string
+	// RetryAfter es la espera sugerida a los clientes al apagar (DefaultRetryAfter si es 0).
+	RetryAfter time.Duration
+	// HistorySize es cuántos eventos se reenvían a un cliente que pide historial.
+	HistorySize      int
 	CredentialRepo   domain.CredentialRepository
 	NotificationRepo domain.NotificationRepository
 	CredentialHook   CredentialHook
@@ -41,9 +47,58 @@ type Config struct {
 	CategoryManager  CategoryManager
 	TTSManager       TTSManager
 	TTSRunnerStatus  TTSStatusReporter
+	TTSHistory       TTSHistoryProvider
 	StatusResolver   *statususecase.Resolver
 	CommandManager   *commandsusecase.CustomCommandManager
 	CommandService   *commandsusecase.Service
+	OverlayService   OverlayConfigManager
+	TrackerService   TrackerManager
+	SettingsReloader SettingsReloader
+	SettingsLister   domain.SettingsLister
+	Diagnostics      DiagnosticsProvider
+	ReadOnly         ReadOnlySwitch
+	Notifier         NotificationEmitter
+	DebugRecorder    DebugRecorderManager
+	Templates        NotificationTemplateManager
+	Connections      PlatformConnectionReporter
+	PlatformTester   PlatformTester
+	FollowPolicy     FollowPolicyManager
+	Lurkers          LurkerManager
+	Profiles         ProfileLookup
+	StreamSync       StreamSyncManager
+	APIQuota         APIQuotaReporter
+	BotPause         BotPauseReporter
+	LinkPreviews     LinkPreviewManager
+	Presence         PresenceManager
+	UserNotes        UserNoteManager
+	Countdowns       CountdownManager
+	Leaderboard      LeaderboardManager
+	ConfigProfile    ConfigProfileManager
+	Quotes           QuoteManager
+	ChatGames        ChatGamesManager
+	Counters         CounterManager
+	AutoShoutout     AutoShoutoutManager
+	Greetings        GreetingManager
+	Refresher        CredentialRefresher
+	BotConflicts     BotConflictManager
+	ConfigValidator  ConfigValidator
+	// NotificationIntake limita el POST público de notificaciones.
+	NotificationIntake NotificationIntakeConfig
+	// ChatPage configura la página /chat para los mods.
+	ChatPage ChatPageConfig
+	// TextPolicy sanea el texto de los mensajes que llegan por el WS.
+	TextPolicy domain.ChatTextPolicy
+}
+
+// PlatformConnectionReporter informa cuándo se conectó el chat de cada plataforma.
+type PlatformConnectionReporter interface {
+	PlatformConnections() []connectionsusecase.StatusDTO
+}
+
+// NotificationEmitter es el pipeline de alertas (guardar + publicar).
+type NotificationEmitter interface {
+	Emit(ctx context.Context, notification *domain.Notification) (*domain.Notification, error)
+	Test(ctx context.Context, notificationType domain.NotificationType, platform domain.Platform, requestedBy string) (*domain.Notification, error)
 }
 
 type CategoryManager interface {
@@ -57,6 +112,18 @@ type TTSManager interface {
 	Enabled(ctx context.Context) bool
 	SetVoice(ctx context.Context, code string) (ttsusecase.VoiceOption, error)
 	SetEnabled(ctx context.Context, enabled bool) error
+	AutoPaused() bool
+	AutoPauseSettings() domain.TTSAutoPauseSettings
+	SetAutoPauseSettings(ctx context.Context, settings domain.TTSAutoPauseSettings) (domain.TTSAutoPauseSettings, error)
+	SkipVotesRequired(ctx context.Context) int
+	SetSkipVotesRequired(ctx context.Context, votes int) error
+	MaxFetchSeconds(ctx context.Context) int
+	SetMaxFetchSeconds(ctx context.Context, seconds int) error
+}
+
+// SettingsReloader vuelve a leer las cachés respaldadas por settings.
+type SettingsReloader interface {
+	ReloadSettings(ctx context.Context) error
 }
 
 type TTSStatusReporter interface {
@@ -151,16 +218,49 @@ type apiHandlers struct {
 
 	httpClient *http.Client
 
-	twitchCfg  *TwitchOAuthConfig
-	kickCfg    *KickOAuthConfig
-	kickOAuth  *kicksdk.Client
-	category   CategoryManager
-	tts        TTSManager
-	ttsStatus  TTSStatusReporter
-	status     *statususecase.Resolver
-	commands   *commandsusecase.CustomCommandManager
-	commandSvc *commandsusecase.Service
-	hook       CredentialHook
+	twitchCfg     *TwitchOAuthConfig
+	kickCfg       *KickOAuthConfig
+	kickOAuth     *kicksdk.Client
+	category      CategoryManager
+	tts           TTSManager
+	ttsStatus     TTSStatusReporter
+	ttsHistory    TTSHistoryProvider
+	status        *statususecase.Resolver
+	commands      *commandsusecase.CustomCommandManager
+	commandSvc    *commandsusecase.Service
+	overlays      OverlayConfigManager
+	trackers      TrackerManager
+	reloader      SettingsReloader
+	settings      domain.SettingsLister
+	diagnostics   DiagnosticsProvider
+	readOnly      ReadOnlySwitch
+	notifier      NotificationEmitter
+	recorder      DebugRecorderManager
+	templates     NotificationTemplateManager
+	connections   PlatformConnectionReporter
+	tester        PlatformTester
+	follows       FollowPolicyManager
+	lurkers       LurkerManager
+	profiles      ProfileLookup
+	streamSync    StreamSyncManager
+	apiQuota      APIQuotaReporter
+	botPause      BotPauseReporter
+	links         LinkPreviewManager
+	presence      PresenceManager
+	userNotes     UserNoteManager
+	countdowns    CountdownManager
+	leaderboard   LeaderboardManager
+	configProfile ConfigProfileManager
+	quotes        QuoteManager
+	games         ChatGamesManager
+	counters      CounterManager
+	shoutouts     AutoShoutoutManager
+	greetings     GreetingManager
+	refresher     CredentialRefresher
+	conflicts     BotConflictManager
+	configCheck   ConfigValidator
+	intake        *notificationIntake
+	hook          CredentialHook
 }
 
 func newAPIHandlers(cfg Config) *apiHandlers {
@@ -182,16 +282,49 @@ func newAPIHandlers(cfg Config) *apiHandlers {
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
-		twitchCfg:  cfg.Twitch,
-		kickCfg:    cfg.Kick,
-		kickOAuth:  kickClient,
-		category:   cfg.CategoryManager,
-		tts:        cfg.TTSManager,
-		ttsStatus:  cfg.TTSRunnerStatus,
-		status:     cfg.StatusResolver,
-		commands:   cfg.CommandManager,
-		commandSvc: cfg.CommandService,
-		hook:       cfg.CredentialHook,
+		twitchCfg:     cfg.Twitch,
+		kickCfg:       cfg.Kick,
+		kickOAuth:     kickClient,
+		category:      cfg.CategoryManager,
+		tts:           cfg.TTSManager,
+		ttsStatus:     cfg.TTSRunnerStatus,
+		ttsHistory:    cfg.TTSHistory,
+		status:        cfg.StatusResolver,
+		commands:      cfg.CommandManager,
+		commandSvc:    cfg.CommandService,
+		overlays:      cfg.OverlayService,
+		trackers:      cfg.TrackerService,
+		reloader:      cfg.SettingsReloader,
+		settings:      cfg.SettingsLister,
+		diagnostics:   cfg.Diagnostics,
+		readOnly:      cfg.ReadOnly,
+		notifier:      cfg.Notifier,
+		recorder:      cfg.DebugRecorder,
+		templates:     cfg.Templates,
+		connections:   cfg.Connections,
+		tester:        cfg.PlatformTester,
+		follows:       cfg.FollowPolicy,
+		lurkers:       cfg.Lurkers,
+		profiles:      cfg.Profiles,
+		streamSync:    cfg.StreamSync,
+		apiQuota:      cfg.APIQuota,
+		botPause:      cfg.BotPause,
+		links:         cfg.LinkPreviews,
+		presence:      cfg.Presence,
+		userNotes:     cfg.UserNotes,
+		countdowns:    cfg.Countdowns,
+		leaderboard:   cfg.Leaderboard,
+		configProfile: cfg.ConfigProfile,
+		quotes:        cfg.Quotes,
+		games:         cfg.ChatGames,
+		counters:      cfg.Counters,
+		shoutouts:     cfg.AutoShoutout,
+		greetings:     cfg.Greetings,
+		refresher:     cfg.Refresher,
+		conflicts:     cfg.BotConflicts,
+		configCheck:   cfg.ConfigValidator,
+		intake:        newNotificationIntake(cfg.NotificationIntake),
+		hook:          cfg.CredentialHook,
 	}
 }
 
@@ -200,24 +333,123 @@ func (a *apiHandlers) register(mux *http.ServeMux) {
 		return
 	}
 
+	mux.HandleFunc("/api/health", a.withCORS(a.handleHealth))
 	mux.HandleFunc("/api/oauth/status", a.withCORS(a.handleStatus))
+	mux.HandleFunc("/api/oauth/scopes", a.withCORS(a.handleOAuthScopes))
 	mux.HandleFunc("/api/oauth/logout", a.withCORS(a.handleLogout))
+	if a.refresher != nil {
+		mux.HandleFunc("/api/oauth/refresh", a.withCORS(a.handleOAuthRefresh))
+	}
 	if a.category != nil {
 		mux.HandleFunc("/api/categories/search", a.withCORS(a.handleCategorySearch))
 		mux.HandleFunc("/api/categories/update", a.withCORS(a.handleCategoryUpdate))
 	}
+	if a.streamSync != nil {
+		mux.HandleFunc("/api/categories/sync", a.withCORS(a.handleStreamSync))
+	}
+	if a.links != nil {
+		mux.HandleFunc("/api/settings/link-previews", a.withCORS(a.handleLinkPreviews))
+	}
+	if a.presence != nil {
+		mux.HandleFunc("/api/settings/presence", a.withCORS(a.handlePresence))
+	}
 	if a.tts != nil {
 		mux.HandleFunc("/api/tts/status", a.withCORS(a.handleTTSStatus))
 		mux.HandleFunc("/api/tts/settings", a.withCORS(a.handleTTSUpdate))
+		mux.HandleFunc("/api/tts/voices", a.withCORS(a.handleTTSVoices))
+	}
+	if a.ttsHistory != nil {
+		mux.HandleFunc("/api/tts/history", a.withCORS(a.handleTTSHistory))
 	}
 	if a.notifications != nil {
 		mux.HandleFunc("/api/notifications", a.withCORS(a.handleNotifications))
+		if _, ok := a.notifications.(domain.NotificationCounter); ok {
+			mux.HandleFunc("/api/notifications/count", a.withCORS(a.handleNotificationsCount))
+		}
+	}
+	if a.notifier != nil {
+		mux.HandleFunc("/api/notifications/test", a.withCORS(a.handleNotificationTest))
+	}
+	if a.templates != nil {
+		mux.HandleFunc("/api/notifications/templates", a.withCORS(a.handleNotificationTemplates))
 	}
 	if a.status != nil {
 		mux.HandleFunc("/api/streams/status", a.withCORS(a.handleStreamStatus))
 	}
 	if a.commandSvc != nil {
 		mux.HandleFunc("/api/commands", a.withCORS(a.handleCommands))
+		mux.HandleFunc("/api/commands/count", a.withCORS(a.handleCommandsCount))
+		mux.HandleFunc("/api/commands/reload", a.withCORS(a.handleCommandsReload))
+	}
+	if a.overlays != nil {
+		mux.HandleFunc("/api/overlays/", a.withCORS(a.handleOverlayConfig))
+	}
+	mux.HandleFunc("/api/support/bundle", a.withCORS(a.handleSupportBundle))
+	if a.settings != nil {
+		mux.HandleFunc("/api/settings", a.withCORS(a.handleSettingsList))
+	}
+	if a.reloader != nil {
+		mux.HandleFunc("/api/settings/reload", a.withCORS(a.handleSettingsReload))
+	}
+	if a.recorder != nil {
+		mux.HandleFunc("/api/debug/recorder", a.withCORS(a.handleDebugRecorder))
+	}
+	if a.readOnly != nil {
+		mux.HandleFunc("/api/settings/readonly", a.withCORS(a.handleReadOnly))
+	}
+	if a.connections != nil {
+		mux.HandleFunc("/api/platforms/status", a.withCORS(a.handlePlatformsStatus))
+	}
+	if a.tester != nil {
+		mux.HandleFunc("/api/platforms/test-send", a.withCORS(a.handlePlatformTestSend))
+	}
+	if a.follows != nil {
+		mux.HandleFunc("/api/automations/follows", a.withCORS(a.handleFollowAutomation))
+	}
+	if a.greetings != nil {
+		mux.HandleFunc("/api/automations/greetings", a.withCORS(a.handleGreetingAutomation))
+	}
+	if a.lurkers != nil {
+		mux.HandleFunc("/api/chat/lurkers", a.withCORS(a.handleLurkers))
+		mux.HandleFunc("/api/chat/lurkers/settings", a.withCORS(a.handleLurkSettings))
+	}
+	if a.profiles != nil || a.userNotes != nil {
+		mux.HandleFunc("/api/users/", a.withCORS(a.handleUsers))
+	}
+	if a.leaderboard != nil {
+		mux.HandleFunc("/api/leaderboard", a.withCORS(a.handleLeaderboard))
+		mux.HandleFunc("/api/leaderboard/settings", a.withCORS(a.handleLeaderboardSettings))
+	}
+	if a.countdowns != nil {
+		mux.HandleFunc("/api/countdown", a.withCORS(a.handleCountdown))
+	}
+	if a.counters != nil {
+		mux.HandleFunc("/api/counters", a.withCORS(a.handleCounters))
+		mux.HandleFunc("/api/counters/", a.withCORS(a.handleCounters))
+	}
+	if a.games != nil {
+		mux.HandleFunc("/api/games/settings", a.withCORS(a.handleGamesSettings))
+	}
+	if a.shoutouts != nil {
+		mux.HandleFunc("/api/shoutouts/settings", a.withCORS(a.handleShoutoutSettings))
+	}
+	if a.conflicts != nil {
+		mux.HandleFunc("/api/bots/conflicts/settings", a.withCORS(a.handleBotConflictSettings))
+	}
+	if a.quotes != nil {
+		mux.HandleFunc("/api/quotes", a.withCORS(a.handleQuotes))
+		mux.HandleFunc("/api/quotes/", a.withCORS(a.handleQuotes))
+	}
+	if a.configCheck != nil {
+		mux.HandleFunc("/api/config/validate", a.withCORS(a.handleConfigValidate))
+	}
+	if a.configProfile != nil {
+		mux.HandleFunc("/api/profile/export", a.withCORS(a.handleProfileExport))
+		mux.HandleFunc("/api/profile/import", a.withCORS(a.handleProfileImport))
+	}
+	if a.trackers != nil {
+		mux.HandleFunc("/api/trackers", a.withCORS(a.handleTrackers))
+		mux.HandleFunc("/api/trackers/reset", a.withCORS(a.handleTrackerReset))
 	}
 
 	if a.twitchCfg != nil && a.twitchCfg.enabled() {
@@ -258,8 +490,8 @@ func (a *apiHandlers) withCORS(next http.HandlerFunc) http.HandlerFunc {
 
 func setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,DELETE,OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+notificationAPIKeyHeader)
+	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
 }
 
 type oauthStartRequest struct {
@@ -275,6 +507,17 @@ type CredentialStatus struct {
 	HasRefreshToken bool      `json:"has_refresh_token"`
 	UpdatedAt       time.Time `json:"updated_at,omitempty"`
 	ExpiresAt       time.Time `json:"expires_at,omitempty"`
+	// Scopes y MissingScopes salen de credentialScopes; ScopesKnown es false
+	// en credenciales guardadas antes de registrar los scopes.
+	ScopesKnown   bool     `json:"scopes_known"`
+	Scopes        []string `json:"scopes,omitempty"`
+	MissingScopes []string `json:"missing_scopes,omitempty"`
+	// ReauthRequired es el motivo por el que el refresh token dejó de servir;
+	// vacío si la credencial sigue siendo válida.
+	ReauthRequired string `json:"reauth_required,omitempty"`
+	// MissingProfile indica una credencial de Twitch guardada sin login (falló
+	// la lectura del perfil); el runtime lo reintenta en segundo plano.
+	MissingProfile bool `json:"missing_profile,omitempty"`
 }
 
 type OAuthStatus struct {
@@ -291,24 +534,54 @@ type categoryUpdateRequest struct {
 }
 
 type ttsStatusResponse struct {
-	Enabled           bool               `json:"enabled"`
-	Voice             string             `json:"voice"`
-	VoiceLabel        string             `json:"voice_label,omitempty"`
-	Voices            []ttsVoiceResponse `json:"voices"`
-	RunnerState       string             `json:"runner_state,omitempty"`
-	RunnerQueueLength int                `json:"runner_queue_length,omitempty"`
-	RunnerCurrentID   string             `json:"runner_current_id,omitempty"`
-	RunnerLastError   string             `json:"runner_last_error,omitempty"`
+	Enabled           bool                        `json:"enabled"`
+	Voice             string                      `json:"voice"`
+	VoiceLabel        string                      `json:"voice_label,omitempty"`
+	Voices            []ttsVoiceResponse          `json:"voices"`
+	RunnerState       string                      `json:"runner_state,omitempty"`
+	RunnerQueueLength int                         `json:"runner_queue_length,omitempty"`
+	RunnerCurrentID   string                      `json:"runner_current_id,omitempty"`
+	RunnerLastError   string                      `json:"runner_last_error,omitempty"`
+	AutoPaused        bool                        `json:"auto_paused"`
+	AutoPause         domain.TTSAutoPauseSettings `json:"auto_pause"`
+	SkipVotes         int                         `json:"skip_votes"`
+	MaxFetchSeconds   int                         `json:"max_fetch_seconds"`
 }
 
 type ttsVoiceResponse struct {
-	Code  string `json:"code"`
-	Label string `json:"label"`
+	Code   string `json:"code"`
+	Label  string `json:"label"`
+	Locale string `json:"locale,omitempty"`
+	Gender string `json:"gender,omitempty"`
+	Engine string `json:"engine,omitempty"`
+}
+
+type ttsVoiceGroupResponse struct {
+	Language string             `json:"language"`
+	Engine   string             `json:"engine"`
+	Voices   []ttsVoiceResponse `json:"voices"`
+}
+
+type ttsVoicesResponse struct {
+	Current string                  `json:"current"`
+	Groups  []ttsVoiceGroupResponse `json:"groups"`
+}
+
+func toTTSVoiceResponses(voices []ttsusecase.VoiceOption) []ttsVoiceResponse {
+	out := make([]ttsVoiceResponse, 0, len(voices))
+	for _, v := range voices {
+		out = append(out, ttsVoiceResponse{Code: v.Code, Label: v.Label, Locale: v.Locale, Gender: v.Gender, Engine: v.Engine})
+	}
+	return out
 }
 
 type ttsUpdateRequest struct {
-	Voice   string `json:"voice"`
-	Enabled *bool  `json:"enabled"`
+	Voice     string                       `json:"voice"`
+	Enabled   *bool                        `json:"enabled"`
+	AutoPause *domain.TTSAutoPauseSettings `json:"auto_pause"`
+	SkipVotes *int                         `json:"skip_votes"`
+	// MaxFetchSeconds es el tiempo máximo para descargar el audio de una lectura.
+	MaxFetchSeconds *int `json:"max_fetch_seconds"`
 }
 
 type oauthLogoutRequest struct {
@@ -423,11 +696,17 @@ func (a *apiHandlers) oauthStatus(ctx context.Context) (OAuthStatus, error) {
 			resp.Credentials[plat] = make(map[string]CredentialStatus)
 		}
 
+		scopes := a.credentialScopes(cred)
 		resp.Credentials[plat][cred.Role] = CredentialStatus{
 			HasAccessToken:  cred.AccessToken != "",
 			HasRefreshToken: cred.RefreshToken != "",
 			UpdatedAt:       cred.UpdatedAt,
 			ExpiresAt:       cred.ExpiresAt,
+			ScopesKnown:     scopes.Known,
+			Scopes:          scopes.Granted,
+			MissingScopes:   scopes.Missing,
+			ReauthRequired:  cred.ReauthReason(),
+			MissingProfile:  cred.MissingProfile(),
 		}
 	}
 
@@ -464,7 +743,7 @@ func (a *apiHandlers) oauthLogout(ctx context.Context, platform domain.Platform,
 
 func (a *apiHandlers) handleCategorySearch(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.category == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("categories"))
 		return
 	}
 	if r.Method == http.MethodOptions {
@@ -478,20 +757,20 @@ func (a *apiHandlers) handleCategorySearch(w http.ResponseWriter, r *http.Reques
 
 	platform := parsePlatformParam(r.URL.Query().Get("platform"))
 	if platform == "" {
-		writeError(w, http.StatusBadRequest, "invalid platform")
+		writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidPlatform, "invalid platform"))
 		return
 	}
 
 	query := strings.TrimSpace(r.URL.Query().Get("query"))
 	if query == "" {
-		writeError(w, http.StatusBadRequest, "missing query")
+		writeAPIError(w, errMissingField("query"))
 		return
 	}
 
 	options, err := a.category.Search(r.Context(), platform, query)
 	if err != nil {
 		log.Printf("category search error: %v", err)
-		writeError(w, http.StatusInternalServerError, "category search failed")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeCategorySearchFailed, "category search failed").With("platform", string(platform)))
 		return
 	}
 
@@ -500,7 +779,7 @@ func (a *apiHandlers) handleCategorySearch(w http.ResponseWriter, r *http.Reques
 
 func (a *apiHandlers) handleCategoryUpdate(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.category == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("categories"))
 		return
 	}
 	if r.Method == http.MethodOptions {
@@ -515,25 +794,25 @@ func (a *apiHandlers) handleCategoryUpdate(w http.ResponseWriter, r *http.Reques
 	defer r.Body.Close()
 	var req categoryUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid payload")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
 	platform := parsePlatformParam(req.Platform)
 	if platform == "" {
-		writeError(w, http.StatusBadRequest, "invalid platform")
+		writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidPlatform, "invalid platform"))
 		return
 	}
 
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
-		writeError(w, http.StatusBadRequest, "missing name")
+		writeAPIError(w, errMissingField("name"))
 		return
 	}
 
 	if err := a.category.Update(r.Context(), platform, name); err != nil {
 		log.Printf("category update error: %v", err)
-		writeError(w, http.StatusInternalServerError, "category update failed")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeCategoryUpdateFailed, "category update failed").With("platform", string(platform)))
 		return
 	}
 
@@ -542,7 +821,7 @@ func (a *apiHandlers) handleCategoryUpdate(w http.ResponseWriter, r *http.Reques
 
 func (a *apiHandlers) handleTTSStatus(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.tts == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeTTSDisabled, "tts unavailable"))
 		return
 	}
 	if r.Method == http.MethodOptions {
@@ -555,17 +834,17 @@ func (a *apiHandlers) handleTTSStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	status := ttsStatusResponse{
-		Enabled: a.tts.Enabled(r.Context()),
+		Enabled:         a.tts.Enabled(r.Context()),
+		AutoPaused:      a.tts.AutoPaused(),
+		AutoPause:       a.tts.AutoPauseSettings(),
+		SkipVotes:       a.tts.SkipVotesRequired(r.Context()),
+		MaxFetchSeconds: a.tts.MaxFetchSeconds(r.Context()),
 	}
 	current := a.tts.CurrentVoice(r.Context())
 	status.Voice = current.Code
 	status.VoiceLabel = current.Label
 
-	voices := a.tts.ListVoices()
-	status.Voices = make([]ttsVoiceResponse, 0, len(voices))
-	for _, v := range voices {
-		status.Voices = append(status.Voices, ttsVoiceResponse{Code: v.Code, Label: v.Label})
-	}
+	status.Voices = toTTSVoiceResponses(a.tts.ListVoices())
 
 	if a.ttsStatus != nil {
 		runner := a.ttsStatus.Status()
@@ -578,9 +857,34 @@ func (a *apiHandlers) handleTTSStatus(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, status)
 }
 
+func (a *apiHandlers) handleTTSVoices(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.tts == nil {
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeTTSDisabled, "tts unavailable"))
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	groups := ttsusecase.GroupVoices(a.tts.ListVoices())
+	resp := ttsVoicesResponse{
+		Current: a.tts.CurrentVoice(r.Context()).Code,
+		Groups:  make([]ttsVoiceGroupResponse, 0, len(groups)),
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, ttsVoiceGroupResponse{
+			Language: group.Language,
+			Engine:   group.Engine,
+			Voices:   toTTSVoiceResponses(group.Voices),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func (a *apiHandlers) handleTTSUpdate(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.tts == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeTTSDisabled, "tts unavailable"))
 		return
 	}
 	if r.Method == http.MethodOptions {
@@ -595,35 +899,60 @@ func (a *apiHandlers) handleTTSUpdate(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	var req ttsUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid payload")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
 	if strings.TrimSpace(req.Voice) != "" {
 		if _, err := a.tts.SetVoice(r.Context(), req.Voice); err != nil {
-			writeError(w, http.StatusBadRequest, err.Error())
+			if errors.Is(err, ttsusecase.ErrUnsupportedVoice) {
+				writeAPIError(w, newAPIError(http.StatusBadRequest, CodeTTSUnsupportedVoice, err.Error()).With("voice", req.Voice))
+				return
+			}
+			writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
 			return
 		}
 	}
 
 	if req.Enabled != nil {
 		if err := a.tts.SetEnabled(r.Context(), *req.Enabled); err != nil {
-			writeError(w, http.StatusInternalServerError, err.Error())
+			writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+			return
+		}
+	}
+
+	if req.AutoPause != nil {
+		if _, err := a.tts.SetAutoPauseSettings(r.Context(), *req.AutoPause); err != nil {
+			writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+			return
+		}
+	}
+
+	if req.SkipVotes != nil {
+		if err := a.tts.SetSkipVotesRequired(r.Context(), *req.SkipVotes); err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, err.Error()).With("field", "skip_votes"))
+			return
+		}
+	}
+
+	if req.MaxFetchSeconds != nil {
+		if err := a.tts.SetMaxFetchSeconds(r.Context(), *req.MaxFetchSeconds); err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, err.Error()).With("field", "max_fetch_seconds"))
 			return
 		}
 	}
 
 	status := ttsStatusResponse{
-		Enabled: a.tts.Enabled(r.Context()),
+		Enabled:         a.tts.Enabled(r.Context()),
+		AutoPaused:      a.tts.AutoPaused(),
+		AutoPause:       a.tts.AutoPauseSettings(),
+		SkipVotes:       a.tts.SkipVotesRequired(r.Context()),
+		MaxFetchSeconds: a.tts.MaxFetchSeconds(r.Context()),
 	}
 	current := a.tts.CurrentVoice(r.Context())
 	status.Voice = current.Code
 	status.VoiceLabel = current.Label
-	voices := a.tts.ListVoices()
-	status.Voices = make([]ttsVoiceResponse, 0, len(voices))
-	for _, v := range voices {
-		status.Voices = append(status.Voices, ttsVoiceResponse{Code: v.Code, Label: v.Label})
-	}
+	status.Voices = toTTSVoiceResponses(a.tts.ListVoices())
 
 	writeJSON(w, http.StatusOK, status)
 }
@@ -641,7 +970,7 @@ func (a *apiHandlers) handleNotifications(w http.ResponseWriter, r *http.Request
 
 func (a *apiHandlers) handleNotificationsList(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.notifications == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("notifications"))
 		return
 	}
 
@@ -655,55 +984,164 @@ func (a *apiHandlers) handleNotificationsList(w http.ResponseWriter, r *http.Req
 	ctx := r.Context()
 	items, err := a.notifications.ListNotifications(ctx, limit)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "could not load notifications")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeNotificationFailed, "could not load notifications"))
 		return
 	}
 
+	if excludeTest, _ := strconv.ParseBool(r.URL.Query().Get("exclude_test")); excludeTest {
+		filtered := items[:0]
+		for _, item := range items {
+			if item != nil && !item.IsTest() {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+
 	writeJSON(w, http.StatusOK, toNotificationResponseList(items))
 }
 
+// handleNotificationsCount acepta los filtros type, platform, since (RFC3339)
+// y exclude_test.
+func (a *apiHandlers) handleNotificationsCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if a == nil {
+		writeAPIError(w, errFeatureUnavailable("notifications"))
+		return
+	}
+	counter, ok := a.notifications.(domain.NotificationCounter)
+	if !ok {
+		writeAPIError(w, errFeatureUnavailable("notifications"))
+		return
+	}
+
+	query := r.URL.Query()
+	var filter domain.NotificationFilter
+	if raw := strings.TrimSpace(query.Get("type")); raw != "" {
+		notificationType, err := domain.ParseNotificationType(raw)
+		if err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, err.Error()).With("field", "type"))
+			return
+		}
+		filter.Type = notificationType
+	}
+	if raw := strings.TrimSpace(query.Get("platform")); raw != "" {
+		filter.Platform = domain.Platform(strings.ToLower(raw))
+	}
+	if raw := strings.TrimSpace(query.Get("since")); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, "invalid since (use RFC3339)").With("field", "since"))
+			return
+		}
+		filter.Since = since
+	}
+	filter.ExcludeTest, _ = strconv.ParseBool(query.Get("exclude_test"))
+
+	count, err := counter.CountNotifications(r.Context(), filter)
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeNotificationFailed, "could not count notifications"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"count": count})
+}
+
 func (a *apiHandlers) handleNotificationsCreate(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.notifications == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("notifications"))
+		return
+	}
+
+	if !a.intake.admit(w, r) {
 		return
 	}
 
 	defer r.Body.Close()
 
 	var payload notificationRequest
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid payload")
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxNotificationBody)).Decode(&payload); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAPIError(w, newAPIError(http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "payload too large").With("max_bytes", maxNotificationBody))
+			return
+		}
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
-	notificationType := normalizeNotificationType(payload.Type)
-	if notificationType == "" {
-		writeError(w, http.StatusBadRequest, "invalid type")
+	record, err := domain.NewNotification(payload.Type, payload.Platform, payload.Username, payload.Amount, payload.Message, payload.Metadata)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationTooLarge) {
+			writeAPIError(w, newAPIError(http.StatusRequestEntityTooLarge, CodePayloadTooLarge, err.Error()))
+			return
+		}
+		writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, err.Error()))
 		return
 	}
 
-	record := &domain.Notification{
-		Type:     notificationType,
-		Platform: domain.Platform(strings.TrimSpace(payload.Platform)),
-		Username: strings.TrimSpace(payload.Username),
-		Amount:   payload.Amount,
-		Message:  strings.TrimSpace(payload.Message),
-		Metadata: payload.Metadata,
-	}
-
 	ctx := r.Context()
-	saved, err := a.notifications.SaveNotification(ctx, record)
+	var saved *domain.Notification
+	if a.notifier != nil {
+		saved, err = a.notifier.Emit(ctx, record)
+	} else {
+		saved, err = a.notifications.SaveNotification(ctx, record)
+	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "could not save notification")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeNotificationFailed, "could not save notification"))
 		return
 	}
 
 	writeJSON(w, http.StatusOK, toNotificationResponse(saved))
 }
 
+type notificationTestRequest struct {
+	Type     string `json:"type"`
+	Platform string `json:"platform"`
+}
+
+// handleNotificationTest atiende POST /api/notifications/test: manda una alerta
+// falsa por el pipeline completo, marcada como prueba.
+func (a *apiHandlers) handleNotificationTest(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.notifier == nil {
+		writeAPIError(w, errFeatureUnavailable("notifications"))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	defer r.Body.Close()
+	var payload notificationTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && err != io.EOF {
+		writeAPIError(w, errInvalidPayload())
+		return
+	}
+
+	notificationType := domain.NotificationGeneric
+	if strings.TrimSpace(payload.Type) != "" {
+		parsed, err := domain.ParseNotificationType(payload.Type)
+		if err != nil {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, "invalid type").With("field", "type"))
+			return
+		}
+		notificationType = parsed
+	}
+
+	saved, err := a.notifier.Test(r.Context(), notificationType, domain.Platform(strings.TrimSpace(payload.Platform)), "api")
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeNotificationFailed, "could not send test notification"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toNotificationResponse(saved))
+}
+
 func (a *apiHandlers) handleStreamStatus(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.status == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("stream_status"))
 		return
 	}
 	if r.Method == http.MethodOptions {
@@ -715,7 +1153,13 @@ func (a *apiHandlers) handleStreamStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	statuses := a.status.Snapshot(r.Context())
+	// ?refresh=1 saltea la caché del resolver
+	var statuses []domain.StreamStatus
+	if refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh")); refresh {
+		statuses = a.status.Refresh(r.Context())
+	} else {
+		statuses = a.status.Snapshot(r.Context())
+	}
 	response := make([]streamStatusResponse, 0, len(statuses))
 	for _, entry := range statuses {
 		response = append(response, streamStatusResponse{
@@ -726,15 +1170,33 @@ func (a *apiHandlers) handleStreamStatus(w http.ResponseWriter, r *http.Request)
 			ViewerCount: entry.ViewerCount,
 			URL:         entry.URL,
 			StartedAt:   formatTime(entry.StartedAt),
+			FetchedAt:   formatTime(entry.FetchedAt),
+			Source:      entry.Source,
 		})
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
+func (a *apiHandlers) handleSettingsReload(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.reloader == nil {
+		writeAPIError(w, errFeatureUnavailable("settings"))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := a.reloader.ReloadSettings(r.Context()); err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
 func (a *apiHandlers) handleCommands(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.commandSvc == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, errFeatureUnavailable("commands"))
 		return
 	}
 	switch r.Method {
@@ -752,22 +1214,65 @@ func (a *apiHandlers) handleCommands(w http.ResponseWriter, r *http.Request) {
 func (a *apiHandlers) handleCommandsList(w http.ResponseWriter, r *http.Request) {
 	items, err := a.commandSvc.List(r.Context())
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
 		return
 	}
 	writeJSON(w, http.StatusOK, items)
 }
 
+func (a *apiHandlers) handleCommandsCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if a == nil || a.commandSvc == nil {
+		writeAPIError(w, errFeatureUnavailable("commands"))
+		return
+	}
+	count, err := a.commandSvc.Count(r.Context())
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, count)
+}
+
+// handleCommandsReload vuelve a leer los comandos desde la base de datos.
+func (a *apiHandlers) handleCommandsReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if a == nil || a.commandSvc == nil {
+		writeAPIError(w, errFeatureUnavailable("commands"))
+		return
+	}
+	if err := a.commandSvc.Reload(r.Context()); err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+		return
+	}
+	count, err := a.commandSvc.Count(r.Context())
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, count)
+}
+
 func (a *apiHandlers) handleCommandsSave(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	var payload commandsusecase.CommandMutationDTO
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid payload")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 	result, err := a.commandSvc.Upsert(r.Context(), payload)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		if errors.Is(err, commandsusecase.ErrCommandConflict) {
+			writeAPIError(w, newAPIError(http.StatusConflict, CodeCommandConflict, err.Error()).With("name", payload.Name))
+			return
+		}
+		writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidArgument, err.Error()))
 		return
 	}
 	writeJSON(w, http.StatusOK, result)
@@ -783,16 +1288,16 @@ func (a *apiHandlers) handleCommandsDelete(w http.ResponseWriter, r *http.Reques
 		name = strings.TrimSpace(payload.Name)
 	}
 	if name == "" {
-		writeError(w, http.StatusBadRequest, "missing name")
+		writeAPIError(w, errMissingField("name"))
 		return
 	}
 	deleted, err := a.commandSvc.Delete(r.Context(), name)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeInternal, err.Error()))
 		return
 	}
 	if !deleted {
-		writeError(w, http.StatusNotFound, "command not found")
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeCommandNotFound, "command not found").With("name", name))
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
@@ -800,19 +1305,19 @@ func (a *apiHandlers) handleCommandsDelete(w http.ResponseWriter, r *http.Reques
 
 func (a *apiHandlers) handleTwitchStart(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.twitchCfg == nil || !a.twitchCfg.enabled() {
-		http.NotFound(w, r)
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeOAuthNotConfigured, "twitch oauth not configured").With("provider", "twitch"))
 		return
 	}
 
 	var req oauthStartRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
-		writeError(w, http.StatusBadRequest, "invalid request")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
 	url, err := a.startTwitchOAuth(req.Role)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "could not start oauth")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeOAuthStartFailed, "could not start oauth"))
 		return
 	}
 
@@ -871,6 +1376,7 @@ func (a *apiHandlers) handleTwitchCallback(w http.ResponseWriter, r *http.Reques
 		ExpiresAt:    time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
 		Metadata:     metadata,
 	}
+	cred.SetScopes(tokenResp.Scope)
 
 	if err := a.credRepo.Save(r.Context(), cred); err != nil {
 		log.Printf("twitch oauth: saving credential failed: %v", err)
@@ -977,19 +1483,19 @@ func (a *apiHandlers) fetchTwitchProfile(ctx context.Context, accessToken string
 
 func (a *apiHandlers) handleKickStart(w http.ResponseWriter, r *http.Request) {
 	if a == nil || a.kickCfg == nil || !a.kickCfg.enabled() || a.kickOAuth == nil {
-		http.NotFound(w, r)
+		writeAPIError(w, newAPIError(http.StatusNotFound, CodeOAuthNotConfigured, "kick oauth not configured").With("provider", "kick"))
 		return
 	}
 
 	var req oauthStartRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
-		writeError(w, http.StatusBadRequest, "invalid request")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
 	url, err := a.startKickOAuth(req.Role)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "could not start oauth")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeOAuthStartFailed, "could not start oauth"))
 		return
 	}
 
@@ -1033,6 +1539,7 @@ func (a *apiHandlers) handleKickCallback(w http.ResponseWriter, r *http.Request)
 	}
 
 	payload := resp.Payload
+	metadata := make(map[string]string)
 	if payload.Scope != "" {
 		log.Printf("kick oauth: scope otorgado: %s", payload.Scope)
 	}
@@ -1042,7 +1549,9 @@ func (a *apiHandlers) handleKickCallback(w http.ResponseWriter, r *http.Request)
 		AccessToken:  payload.AccessToken,
 		RefreshToken: payload.RefreshToken,
 		ExpiresAt:    time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second),
+		Metadata:     metadata,
 	}
+	cred.SetScopes([]string{payload.Scope})
 
 	if err := a.credRepo.Save(r.Context(), cred); err != nil {
 		log.Printf("kick oauth: saving credential failed: %v", err)
@@ -1067,7 +1576,7 @@ func (a *apiHandlers) handleStatus(w http.ResponseWriter, r *http.Request) {
 	status, err := a.oauthStatus(r.Context())
 	if err != nil {
 		log.Printf("oauth status: list error: %v", err)
-		writeError(w, http.StatusInternalServerError, "could not load credentials")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeCredentialsUnavailable, "could not load credentials"))
 		return
 	}
 
@@ -1086,19 +1595,19 @@ func (a *apiHandlers) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 	var req oauthLogoutRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
+		writeAPIError(w, errInvalidPayload())
 		return
 	}
 
 	platform := parsePlatformParam(req.Platform)
 	if platform == "" {
-		writeError(w, http.StatusBadRequest, "invalid platform")
+		writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidPlatform, "invalid platform"))
 		return
 	}
 
 	if err := a.oauthLogout(r.Context(), platform, req.Role); err != nil {
 		log.Printf("oauth logout: delete failed (%s/%s): %v", platform, req.Role, err)
-		writeError(w, http.StatusInternalServerError, "could not delete credentials")
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeCredentialsUnavailable, "could not delete credentials"))
 		return
 	}
 
@@ -1129,6 +1638,7 @@ type notificationResponse struct {
 	Amount    float64           `json:"amount"`
 	Message   string            `json:"message"`
 	Metadata  map[string]string `json:"metadata,omitempty"`
+	Test      bool              `json:"test,omitempty"`
 	CreatedAt string            `json:"created_at"`
 }
 
@@ -1140,6 +1650,8 @@ type streamStatusResponse struct {
 	ViewerCount int    `json:"viewer_count,omitempty"`
 	URL         string `json:"url,omitempty"`
 	StartedAt   string `json:"started_at,omitempty"`
+	FetchedAt   string `json:"fetched_at,omitempty"`
+	Source      string `json:"source,omitempty"`
 }
 
 func toNotificationResponse(item *domain.Notification) notificationResponse {
@@ -1160,6 +1672,7 @@ func toNotificationResponse(item *domain.Notification) notificationResponse {
 		Amount:    item.Amount,
 		Message:   item.Message,
 		Metadata:  item.Metadata,
+		Test:      item.IsTest(),
 		CreatedAt: created,
 	}
 }
@@ -1172,25 +1685,6 @@ func toNotificationResponseList(items []*domain.Notification) []notificationResp
 	return out
 }
 
-func normalizeNotificationType(value string) domain.NotificationType {
-	switch strings.ToLower(strings.TrimSpace(value)) {
-	case string(domain.NotificationSubscription):
-		return domain.NotificationSubscription
-	case string(domain.NotificationDonation):
-		return domain.NotificationDonation
-	case string(domain.NotificationBits):
-		return domain.NotificationBits
-	case string(domain.NotificationGiveawayWinner):
-		return domain.NotificationGiveawayWinner
-	case string(domain.NotificationGeneric):
-		return domain.NotificationGeneric
-	case "":
-		return ""
-	default:
-		return domain.NotificationGeneric
-	}
-}
-
 func formatTime(value time.Time) string {
 	if value.IsZero() {
 		return ""
@@ -1215,10 +1709,6 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
-func writeError(w http.ResponseWriter, status int, msg string) {
-	writeJSON(w, status, map[string]string{"error": msg})
-}
-
 func writeHTML(w http.ResponseWriter, status int, body string) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(status)
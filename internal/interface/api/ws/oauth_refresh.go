@@ -0,0 +1,127 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"zhatBot/internal/domain"
+	credentialsusecase "zhatBot/internal/usecase/credentials"
+)
+
+// CredentialRefresher refresca tokens a mano desde el panel.
+type CredentialRefresher interface {
+	RefreshNow(ctx context.Context, platform domain.Platform, role string) ([]credentialsusecase.RefreshResult, error)
+}
+
+type oauthRefreshRequest struct {
+	Platform string `json:"platform"`
+	Role     string `json:"role"`
+}
+
+// OAuthRefreshResult es el resultado de refrescar una credencial. Si falló,
+// Code y Error dicen por qué.
+type OAuthRefreshResult struct {
+	Platform  string       `json:"platform"`
+	Role      string       `json:"role"`
+	OK        bool         `json:"ok"`
+	ExpiresAt string       `json:"expires_at,omitempty"`
+	Code      APIErrorCode `json:"code,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// refreshAPIError traduce el error de un refresh manual.
+func refreshAPIError(err error) *APIError {
+	switch {
+	case errors.Is(err, credentialsusecase.ErrCredentialNotFound):
+		return newAPIError(http.StatusNotFound, CodeNotFound, "credential not found")
+	case errors.Is(err, credentialsusecase.ErrNoRefreshToken):
+		return newAPIError(http.StatusConflict, CodeNoRefreshToken, "credential has no refresh token")
+	case errors.Is(err, credentialsusecase.ErrReauthRequired):
+		return newAPIError(http.StatusConflict, CodeRefreshRejected, err.Error()).With("reauth_required", true)
+	default:
+		return newAPIError(http.StatusBadGateway, CodeRefreshFailed, err.Error())
+	}
+}
+
+func toOAuthRefreshResult(result credentialsusecase.RefreshResult) OAuthRefreshResult {
+	out := OAuthRefreshResult{
+		Platform: string(result.Platform),
+		Role:     result.Role,
+		OK:       result.Err == nil,
+	}
+	if result.Err != nil {
+		apiErr := refreshAPIError(result.Err)
+		out.Code = apiErr.Code
+		out.Error = apiErr.Message
+		return out
+	}
+	if !result.ExpiresAt.IsZero() {
+		out.ExpiresAt = result.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+// handleOAuthRefresh atiende POST /api/oauth/refresh. Con platform refresca
+// esa credencial y responde su vencimiento nuevo o el error; sin cuerpo (o
+// sin platform) refresca todas y responde el resultado de cada una.
+func (a *apiHandlers) handleOAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.refresher == nil {
+		writeAPIError(w, errFeatureUnavailable("oauth refresh"))
+		return
+	}
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	defer r.Body.Close()
+	var req oauthRefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, errInvalidPayload())
+		return
+	}
+
+	var platform domain.Platform
+	role := ""
+	if req.Platform != "" {
+		platform = parsePlatformParam(req.Platform)
+		if platform == "" {
+			writeAPIError(w, newAPIError(http.StatusBadRequest, CodeInvalidPlatform, "invalid platform"))
+			return
+		}
+		role = normalizeRole(req.Role)
+	}
+
+	results, err := a.refresher.RefreshNow(r.Context(), platform, role)
+	if err != nil {
+		log.Printf("oauth refresh: %v", err)
+		writeAPIError(w, newAPIError(http.StatusInternalServerError, CodeCredentialsUnavailable, "could not load credentials"))
+		return
+	}
+
+	if platform != "" {
+		if len(results) == 1 && results[0].Err != nil {
+			writeAPIError(w, refreshAPIError(results[0].Err).With("platform", string(platform)).With("role", role))
+			return
+		}
+		if len(results) == 1 {
+			writeJSON(w, http.StatusOK, toOAuthRefreshResult(results[0]))
+			return
+		}
+	}
+
+	out := make([]OAuthRefreshResult, 0, len(results))
+	for _, result := range results {
+		out = append(out, toOAuthRefreshResult(result))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"credentials": out})
+}
@@ -0,0 +1,93 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	credentialsusecase "zhatBot/internal/usecase/credentials"
+)
+
+// fakeRefresher responde lo que tenga cargado para cada platform/role.
+type fakeRefresher struct {
+	results map[string]credentialsusecase.RefreshResult
+	listErr error
+	asked   []string
+}
+
+func (f *fakeRefresher) RefreshNow(_ context.Context, platform domain.Platform, role string) ([]credentialsusecase.RefreshResult, error) {
+	f.asked = append(f.asked, string(platform)+"/"+role)
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	if platform != "" {
+		return []credentialsusecase.RefreshResult{f.results[string(platform)+"/"+role]}, nil
+	}
+	return []credentialsusecase.RefreshResult{f.results["twitch/bot"], f.results["kick/streamer"]}, nil
+}
+
+func postRefresh(api *apiHandlers, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/refresh", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	api.handleOAuthRefresh(rec, req)
+	return rec
+}
+
+func TestOAuthRefreshEndpoint(t *testing.T) {
+	expires := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	rejected := fmt.Errorf("refresher: twitch status 400: Invalid refresh token: %w", credentialsusecase.ErrReauthRequired)
+	refresher := &fakeRefresher{results: map[string]credentialsusecase.RefreshResult{
+		"twitch/bot":      {Platform: domain.PlatformTwitch, Role: "bot", ExpiresAt: expires},
+		"twitch/streamer": {Platform: domain.PlatformTwitch, Role: "streamer", Err: rejected},
+		"kick/streamer":   {Platform: domain.PlatformKick, Role: "streamer", Err: credentialsusecase.ErrNoRefreshToken},
+	}}
+	api := newAPIHandlers(Config{Refresher: refresher})
+
+	rec := postRefresh(api, `{"platform":"twitch","role":"bot"}`)
+	var ok OAuthRefreshResult
+	decodeJSON(t, rec.Body.Bytes(), &ok)
+	if rec.Code != http.StatusOK || !ok.OK || ok.ExpiresAt != "2024-05-01T20:00:00Z" {
+		t.Fatalf("status = %d, result = %+v", rec.Code, ok)
+	}
+
+	rec = postRefresh(api, `{"platform":"twitch","role":"streamer"}`)
+	assertAPIError(t, rec, http.StatusConflict, CodeRefreshRejected)
+	if body := rec.Body.String(); !strings.Contains(body, "Invalid refresh token") || !strings.Contains(body, `"reauth_required":true`) {
+		t.Fatalf("rejected body = %s", body)
+	}
+	assertAPIError(t, postRefresh(api, `{"platform":"kick","role":"streamer"}`), http.StatusConflict, CodeNoRefreshToken)
+	assertAPIError(t, postRefresh(api, `{"platform":"youtube"}`), http.StatusBadRequest, CodeInvalidPlatform)
+
+	// sin cuerpo refresca todas y responde cada resultado
+	rec = postRefresh(api, "")
+	var all struct {
+		Credentials []OAuthRefreshResult `json:"credentials"`
+	}
+	decodeJSON(t, rec.Body.Bytes(), &all)
+	if rec.Code != http.StatusOK || len(all.Credentials) != 2 || !all.Credentials[0].OK || all.Credentials[1].Code != CodeNoRefreshToken {
+		t.Fatalf("status = %d, all = %+v", rec.Code, all)
+	}
+	if got := refresher.asked[len(refresher.asked)-1]; got != "/" {
+		t.Fatalf("refresh without a body asked for %q", got)
+	}
+}
+
+func TestOAuthRefreshErrors(t *testing.T) {
+	assertAPIError(t, postRefresh(newAPIHandlers(Config{}), ""), http.StatusNotFound, CodeFeatureUnavailable)
+
+	api := newAPIHandlers(Config{Refresher: &fakeRefresher{listErr: errBackend}})
+	assertAPIError(t, postRefresh(api, ""), http.StatusInternalServerError, CodeCredentialsUnavailable)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/refresh", nil)
+	rec := httptest.NewRecorder()
+	api.handleOAuthRefresh(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET status = %d", rec.Code)
+	}
+	assertAPIError(t, postRefresh(api, `{"platform":`), http.StatusBadRequest, CodeInvalidPayload)
+}
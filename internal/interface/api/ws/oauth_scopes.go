@@ -0,0 +1,116 @@
+package ws
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+
+	"zhatBot/internal/domain"
+)
+
+// CredentialScopes compara los scopes de una credencial con los que pide la
+// app para su rol. Known es false si la credencial se guardó antes de que se
+// registraran los scopes: en ese caso hay que volver a iniciar sesión para
+// saberlo.
+type CredentialScopes struct {
+	Platform string   `json:"platform"`
+	Role     string   `json:"role"`
+	Known    bool     `json:"known"`
+	Granted  []string `json:"granted"`
+	Expected []string `json:"expected"`
+	Missing  []string `json:"missing"`
+}
+
+// expectedScopes devuelve los scopes que pide el login OAuth de platform/role.
+func (a *apiHandlers) expectedScopes(platform domain.Platform, role string) []string {
+	switch platform {
+	case domain.PlatformTwitch:
+		if a.twitchCfg != nil {
+			return append([]string(nil), a.twitchCfg.scopesForRole(role)...)
+		}
+	case domain.PlatformKick:
+		if a.kickCfg != nil {
+			var out []string
+			for _, scope := range a.kickCfg.scopesForRole(role) {
+				out = append(out, string(scope))
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+// credentialScopes arma la comparación de una credencial guardada.
+func (a *apiHandlers) credentialScopes(cred *domain.Credential) CredentialScopes {
+	granted := cred.Scopes()
+	expected := a.expectedScopes(cred.Platform, cred.Role)
+	out := CredentialScopes{
+		Platform: string(cred.Platform),
+		Role:     cred.Role,
+		Known:    len(granted) > 0,
+		Granted:  granted,
+		Expected: expected,
+	}
+	if out.Known {
+		out.Missing = domain.MissingScopes(expected, granted)
+	}
+	if out.Granted == nil {
+		out.Granted = []string{}
+	}
+	if out.Expected == nil {
+		out.Expected = []string{}
+	}
+	if out.Missing == nil {
+		out.Missing = []string{}
+	}
+	return out
+}
+
+func (a *apiHandlers) oauthScopes(ctx context.Context) ([]CredentialScopes, error) {
+	out := []CredentialScopes{}
+	if a == nil || a.credRepo == nil {
+		return out, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := a.credRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cred := range list {
+		if cred == nil || cred.Platform == "" {
+			continue
+		}
+		out = append(out, a.credentialScopes(cred))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Platform != out[j].Platform {
+			return out[i].Platform < out[j].Platform
+		}
+		return out[i].Role < out[j].Role
+	})
+	return out, nil
+}
+
+// handleOAuthScopes sirve para ver qué permisos tiene cada token, p. ej. por
+// qué el bot no puede cambiar el título.
+func (a *apiHandlers) handleOAuthScopes(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	scopes, err := a.oauthScopes(r.Context())
+	if err != nil {
+		log.Printf("oauth scopes: list error: %v", err)
+		writeError(w, http.StatusInternalServerError, "could not load credentials")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"credentials": scopes})
+}
@@ -0,0 +1,66 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestOAuthScopesDiff(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	creds := []*domain.Credential{
+		{Platform: domain.PlatformTwitch, Role: "streamer", AccessToken: "a", Metadata: map[string]string{domain.CredentialScopesKey: "channel:manage:broadcast"}},
+		{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "b", Metadata: map[string]string{domain.CredentialScopesKey: "chat:read"}},
+		// guardada antes de registrar los scopes
+		{Platform: domain.PlatformKick, Role: "streamer", AccessToken: "c"},
+	}
+	for _, cred := range creds {
+		if err := store.Save(ctx, cred); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	srv := newTestAPI(t, Config{
+		CredentialRepo: store,
+		Twitch:         &TwitchOAuthConfig{BotScopes: []string{"chat:read", "chat:edit", "moderator:manage:banned_users"}},
+		Kick:           &KickOAuthConfig{StreamerScopes: []string{"chat:write"}},
+	})
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+"/api/oauth/scopes", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (%s)", status, body)
+	}
+	var resp struct {
+		Credentials []CredentialScopes `json:"credentials"`
+	}
+	decodeJSON(t, body, &resp)
+	if len(resp.Credentials) != 3 {
+		t.Fatalf("credentials = %+v", resp.Credentials)
+	}
+
+	kick, twitchBot, twitchStreamer := resp.Credentials[0], resp.Credentials[1], resp.Credentials[2]
+	if kick.Platform != "kick" || kick.Known || len(kick.Granted) != 0 || len(kick.Missing) != 0 || !slices.Equal(kick.Expected, []string{"chat:write"}) {
+		t.Fatalf("kick streamer = %+v, want unknown scopes and nothing reported missing", kick)
+	}
+	if twitchBot.Role != "bot" || !twitchBot.Known || !slices.Equal(twitchBot.Missing, []string{"chat:edit", "moderator:manage:banned_users"}) {
+		t.Fatalf("twitch bot = %+v", twitchBot)
+	}
+	// sin StreamerScopes se usan los de fábrica
+	if twitchStreamer.Role != "streamer" || len(twitchStreamer.Missing) != 0 || !slices.Equal(twitchStreamer.Expected, []string{"channel:manage:broadcast"}) {
+		t.Fatalf("twitch streamer = %+v", twitchStreamer)
+	}
+
+	if status, _ := doRequest(t, http.MethodPost, srv.URL+"/api/oauth/scopes", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("POST status = %d", status)
+	}
+}
+
+func TestOAuthScopesWithoutRepo(t *testing.T) {
+	scopes, err := newAPIHandlers(Config{}).oauthScopes(context.Background())
+	if err != nil || scopes == nil || len(scopes) != 0 {
+		t.Fatalf("oauthScopes = %v, %v; want an empty list", scopes, err)
+	}
+}
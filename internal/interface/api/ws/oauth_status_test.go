@@ -0,0 +1,40 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestOAuthStatusReportsMissingProfile(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	creds := []*domain.Credential{
+		// el perfil no se pudo leer en el callback
+		{Platform: domain.PlatformTwitch, Role: "bot", AccessToken: "a"},
+		{Platform: domain.PlatformTwitch, Role: "streamer", AccessToken: "b", Metadata: map[string]string{"login": "zero"}},
+		// en Kick el login no hace falta para arrancar
+		{Platform: domain.PlatformKick, Role: "streamer", AccessToken: "c"},
+	}
+	for _, cred := range creds {
+		if err := store.Save(ctx, cred); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	srv := newTestAPI(t, Config{CredentialRepo: store})
+
+	code, body := doRequest(t, http.MethodGet, srv.URL+"/api/oauth/status", "")
+	if code != http.StatusOK {
+		t.Fatalf("status = %d (%s)", code, body)
+	}
+	var status OAuthStatus
+	decodeJSON(t, body, &status)
+	if !status.Credentials["twitch"]["bot"].MissingProfile {
+		t.Fatalf("twitch bot = %+v, want missing_profile", status.Credentials["twitch"]["bot"])
+	}
+	if status.Credentials["twitch"]["streamer"].MissingProfile || status.Credentials["kick"]["streamer"].MissingProfile {
+		t.Fatalf("credentials = %+v", status.Credentials)
+	}
+}
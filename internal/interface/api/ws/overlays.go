@@ -0,0 +1,67 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	overlaysusecase "zhatBot/internal/usecase/overlays"
+)
+
+type OverlayConfigManager interface {
+	Get(ctx context.Context, name string) (overlaysusecase.ConfigDTO, error)
+	Set(ctx context.Context, name string, cfg map[string]any) (overlaysusecase.ConfigDTO, error)
+}
+
+// handleOverlayConfig atiende GET/PUT /api/overlays/{name}/config.
+func (a *apiHandlers) handleOverlayConfig(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.overlays == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/overlays/")
+	name, suffix, ok := strings.Cut(rest, "/")
+	if !ok || suffix != "config" || name == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result, err := a.overlays.Get(r.Context(), name)
+		if err != nil {
+			writeOverlayError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	case http.MethodPut, http.MethodPost:
+		defer r.Body.Close()
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		result, err := a.overlays.Set(r.Context(), name, payload)
+		if err != nil {
+			writeOverlayError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeOverlayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, overlaysusecase.ErrUnknownOverlay):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, overlaysusecase.ErrInvalidConfig):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,67 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"zhatBot/internal/domain"
+	connectionsusecase "zhatBot/internal/usecase/connections"
+)
+
+// PlatformTester manda un mensaje de prueba para confirmar que el bot puede escribir.
+type PlatformTester interface {
+	TestSend(ctx context.Context, platform domain.Platform) (connectionsusecase.TestSendResult, error)
+}
+
+// handlePlatformsStatus atiende GET /api/platforms/status.
+func (a *apiHandlers) handlePlatformsStatus(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.connections == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, a.connections.PlatformConnections())
+}
+
+// handlePlatformTestSend atiende POST /api/platforms/test-send con {"platform": "..."}.
+func (a *apiHandlers) handlePlatformTestSend(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.tester == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+	var payload struct {
+		Platform string `json:"platform"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeAPIError(w, errInvalidPayload())
+		return
+	}
+
+	platform := domain.Platform(strings.ToLower(strings.TrimSpace(payload.Platform)))
+	result, err := a.tester.TestSend(r.Context(), platform)
+	if err != nil {
+		switch {
+		case errors.Is(err, connectionsusecase.ErrTestSendTooSoon):
+			writeError(w, http.StatusTooManyRequests, err.Error())
+		case errors.Is(err, connectionsusecase.ErrTestSendReadOnly), errors.Is(err, connectionsusecase.ErrTestSendNoChannel):
+			writeError(w, http.StatusConflict, err.Error())
+		case platform != domain.PlatformTwitch && platform != domain.PlatformKick:
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusBadGateway, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
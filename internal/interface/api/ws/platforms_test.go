@@ -0,0 +1,60 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"zhatBot/internal/domain"
+	connectionsusecase "zhatBot/internal/usecase/connections"
+)
+
+type fakeTester struct {
+	err error
+}
+
+func (f fakeTester) TestSend(_ context.Context, platform domain.Platform) (connectionsusecase.TestSendResult, error) {
+	if f.err != nil {
+		return connectionsusecase.TestSendResult{}, f.err
+	}
+	return connectionsusecase.TestSendResult{Platform: string(platform), ChannelID: "zhatbot"}, nil
+}
+
+func TestPlatformTestSendStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		body   string
+		status int
+	}{
+		{"success", nil, `{"platform":" Twitch "}`, http.StatusOK},
+		{"platform error", errors.New("403"), `{"platform":"twitch"}`, http.StatusBadGateway},
+		{"cooldown", connectionsusecase.ErrTestSendTooSoon, `{"platform":"twitch"}`, http.StatusTooManyRequests},
+		{"read only", connectionsusecase.ErrTestSendReadOnly, `{"platform":"kick"}`, http.StatusConflict},
+		{"no channel", connectionsusecase.ErrTestSendNoChannel, `{"platform":"kick"}`, http.StatusConflict},
+		{"unknown platform", errors.New("plataforma desconocida"), `{"platform":"youtube"}`, http.StatusBadRequest},
+		{"invalid payload", nil, `{`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newTestAPI(t, Config{PlatformTester: fakeTester{err: tc.err}})
+			status, body := doRequest(t, http.MethodPost, srv.URL+"/api/platforms/test-send", tc.body)
+			if status != tc.status {
+				t.Fatalf("status = %d, want %d (%s)", status, tc.status, body)
+			}
+			if status == http.StatusOK {
+				var result connectionsusecase.TestSendResult
+				decodeJSON(t, body, &result)
+				if result.Platform != "twitch" {
+					t.Fatalf("result = %+v", result)
+				}
+			}
+		})
+	}
+
+	srv := newTestAPI(t, Config{PlatformTester: fakeTester{}})
+	if status, _ := doRequest(t, http.MethodGet, srv.URL+"/api/platforms/test-send", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("GET status = %d", status)
+	}
+}
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+type PresenceManager interface {
+	Settings() domain.PresenceSettings
+	Update(ctx context.Context, settings domain.PresenceSettings) (domain.PresenceSettings, error)
+}
+
+// handlePresence atiende GET/PUT /api/settings/presence (mensajes del bot al
+// conectarse a cada chat y al apagarse).
+func (a *apiHandlers) handlePresence(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.presence == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.presence.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.PresenceSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.presence.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,46 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"zhatBot/internal/domain"
+	profilesusecase "zhatBot/internal/usecase/profiles"
+)
+
+type ProfileLookup interface {
+	Lookup(ctx context.Context, platform domain.Platform, userID string) (domain.UserProfile, error)
+}
+
+// handleUserProfile atiende GET /api/users/{platform}/{id}.
+func (a *apiHandlers) handleUserProfile(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.profiles == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/users/")
+	platform, userID, ok := strings.Cut(rest, "/")
+	userID = strings.TrimSpace(userID)
+	if !ok || platform == "" || userID == "" || strings.Contains(userID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	profile, err := a.profiles.Lookup(r.Context(), domain.Platform(strings.ToLower(platform)), userID)
+	if err != nil {
+		if errors.Is(err, profilesusecase.ErrUnsupported) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
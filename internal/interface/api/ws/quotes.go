@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+	quotesusecase "zhatBot/internal/usecase/quotes"
+)
+
+type QuoteManager interface {
+	List(ctx context.Context) ([]*domain.Quote, error)
+	Get(ctx context.Context, number int64) (*domain.Quote, error)
+	Add(ctx context.Context, quote domain.Quote) (*domain.Quote, error)
+	Update(ctx context.Context, number int64, text string) (*domain.Quote, error)
+	Delete(ctx context.Context, number int64) error
+}
+
+type quotePayload struct {
+	Text    string `json:"text"`
+	AddedBy string `json:"added_by"`
+}
+
+// handleQuotes atiende GET/POST /api/quotes y GET/PUT/DELETE
+// /api/quotes/{número}.
+func (a *apiHandlers) handleQuotes(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.quotes == nil {
+		http.NotFound(w, r)
+		return
+	}
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/quotes"), "/")
+	if rest != "" {
+		number, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil || number <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid quote number")
+			return
+		}
+		a.handleQuote(w, r, number)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		quotes, err := a.quotes.List(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if quotes == nil {
+			quotes = []*domain.Quote{}
+		}
+		writeJSON(w, http.StatusOK, quotes)
+	case http.MethodPost:
+		defer r.Body.Close()
+		var payload quotePayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		addedBy := strings.TrimSpace(payload.AddedBy)
+		if addedBy == "" {
+			addedBy = "dashboard"
+		}
+		saved, err := a.quotes.Add(r.Context(), domain.Quote{Text: payload.Text, AddedBy: addedBy})
+		if err != nil {
+			writeError(w, quoteErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusCreated, saved)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (a *apiHandlers) handleQuote(w http.ResponseWriter, r *http.Request, number int64) {
+	switch r.Method {
+	case http.MethodGet:
+		quote, err := a.quotes.Get(r.Context(), number)
+		if err != nil {
+			writeError(w, quoteErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, quote)
+	case http.MethodPut:
+		defer r.Body.Close()
+		var payload quotePayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		quote, err := a.quotes.Update(r.Context(), number, payload.Text)
+		if err != nil {
+			writeError(w, quoteErrorStatus(err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, quote)
+	case http.MethodDelete:
+		if err := a.quotes.Delete(r.Context(), number); err != nil {
+			writeError(w, quoteErrorStatus(err), err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func quoteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, quotesusecase.ErrQuoteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, quotesusecase.ErrEmptyQuote), errors.Is(err, quotesusecase.ErrQuoteTooLong):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+	linkpreviewusecase "zhatBot/internal/usecase/linkpreview"
+)
+
+// ReadOnlySwitch controla el modo solo lectura (procesar todo sin enviar nada).
+type ReadOnlySwitch interface {
+	Enabled() bool
+	Set(ctx context.Context, enabled bool) error
+}
+
+// APIQuotaReporter informa la cuota restante de las APIs externas.
+type APIQuotaReporter interface {
+	APIQuota() []domain.APIQuotaState
+}
+
+// BotPauseReporter indica si los comandos están pausados (!bot pause).
+type BotPauseReporter interface {
+	BotPaused() bool
+}
+
+type healthResponse struct {
+	Status       string                    `json:"status"`
+	ReadOnly     bool                      `json:"read_only"`
+	BotPaused    bool                      `json:"bot_paused"`
+	APIQuota     []domain.APIQuotaState    `json:"api_quota,omitempty"`
+	LinkPreviews *linkpreviewusecase.Stats `json:"link_previews,omitempty"`
+	// StreamUnhealthy lista las plataformas cuyo estado se dejó de consultar
+	// por errores repetidos, con el último error.
+	StreamUnhealthy map[domain.Platform]string `json:"stream_unhealthy,omitempty"`
+}
+
+type readOnlyPayload struct {
+	Enabled bool `json:"enabled"`
+}
+
+// handleHealth atiende GET /api/health.
+func (a *apiHandlers) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if a == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	response := healthResponse{Status: "ok"}
+	if a.readOnly != nil {
+		response.ReadOnly = a.readOnly.Enabled()
+	}
+	if a.botPause != nil {
+		response.BotPaused = a.botPause.BotPaused()
+	}
+	if a.apiQuota != nil {
+		response.APIQuota = a.apiQuota.APIQuota()
+	}
+	if a.links != nil {
+		stats := a.links.Stats()
+		response.LinkPreviews = &stats
+	}
+	if a.status != nil {
+		response.StreamUnhealthy = a.status.Unhealthy()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleReadOnly atiende GET/POST /api/settings/readonly.
+func (a *apiHandlers) handleReadOnly(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.readOnly == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, readOnlyPayload{Enabled: a.readOnly.Enabled()})
+	case http.MethodPost, http.MethodPut:
+		var payload readOnlyPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		if err := a.readOnly.Set(r.Context(), payload.Enabled); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, readOnlyPayload{Enabled: a.readOnly.Enabled()})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,64 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+type memorySwitch struct {
+	mu      sync.Mutex
+	enabled bool
+}
+
+func (s *memorySwitch) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled
+}
+
+func (s *memorySwitch) Set(_ context.Context, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enabled = enabled
+	return nil
+}
+
+func TestReadOnlyEndpointAndHealthFlag(t *testing.T) {
+	mode := &memorySwitch{}
+	srv := newTestAPI(t, Config{ReadOnly: mode})
+
+	var health healthResponse
+	_, body := doRequest(t, http.MethodGet, srv.URL+"/api/health", "")
+	decodeJSON(t, body, &health)
+	if health.ReadOnly {
+		t.Fatal("health reports read-only before enabling it")
+	}
+
+	status, body := doRequest(t, http.MethodPost, srv.URL+"/api/settings/readonly", `{"enabled":true}`)
+	if status != http.StatusOK {
+		t.Fatalf("POST status = %d: %s", status, body)
+	}
+	var payload readOnlyPayload
+	decodeJSON(t, body, &payload)
+	if !payload.Enabled || !mode.Enabled() {
+		t.Fatalf("payload = %+v, mode = %v; want enabled", payload, mode.Enabled())
+	}
+
+	_, body = doRequest(t, http.MethodGet, srv.URL+"/api/health", "")
+	decodeJSON(t, body, &health)
+	if !health.ReadOnly {
+		t.Fatal("health does not report read-only")
+	}
+
+	_, body = doRequest(t, http.MethodGet, srv.URL+"/api/settings/readonly", "")
+	decodeJSON(t, body, &payload)
+	if !payload.Enabled {
+		t.Fatal("GET does not report read-only")
+	}
+
+	if status, _ := doRequest(t, http.MethodPost, srv.URL+"/api/settings/readonly", `{"enabled":`); status != http.StatusBadRequest {
+		t.Fatalf("invalid payload status = %d, want 400", status)
+	}
+}
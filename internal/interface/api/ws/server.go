@@ -1,11 +1,13 @@
 package ws
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -25,8 +27,21 @@ type Server struct {
 	clients map[*wsClient]struct{}
 	handler MessageHandler
 
-	httpSrv *http.Server
-	api     *apiHandlers
+	httpSrv   *http.Server
+	api       *apiHandlers
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	sessionID  string
+	retryAfter time.Duration
+	history    *eventHistory
+	chatPage   ChatPageConfig
+	textPolicy domain.ChatTextPolicy
+}
+
+type envelope struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
 }
 
 type MessageHandler func(ctx context.Context, msg domain.Message) error
@@ -34,6 +49,11 @@ type MessageHandler func(ctx context.Context, msg domain.Message) error
 type wsClient struct {
 	conn *websocket.Conn
 	mu   sync.Mutex
+	// chatPage marca las conexiones de la página /chat.
+	chatPage bool
+	// canWrite indica si la conexión puede mandar mensajes: con
+	// CHAT_PAGE_TOKEN configurado, solo las que traen el token.
+	canWrite bool
 }
 
 func (c *wsClient) writeJSON(v any) error {
@@ -53,6 +73,23 @@ func NewServer(cfg Config) *Server {
 		},
 		clients: make(map[*wsClient]struct{}),
 		api:     newAPIHandlers(cfg),
+		ready:   make(chan struct{}),
+
+		sessionID:  newSessionID(),
+		retryAfter: cfg.RetryAfter,
+		history:    newEventHistory(cfg.HistorySize),
+		chatPage:   cfg.ChatPage,
+		textPolicy: cfg.TextPolicy,
+	}
+	server.chatPage.Token = strings.TrimSpace(server.chatPage.Token)
+	if !server.chatPage.Disabled && server.chatPage.Token == "" {
+		// la página escribe como el bot con permisos de mod: sin token
+		// cualquiera que llegue al puerto podría usarla
+		log.Printf("ws: la página /chat necesita CHAT_PAGE_TOKEN; queda apagada")
+		server.chatPage.Disabled = true
+	}
+	if server.retryAfter <= 0 {
+		server.retryAfter = DefaultRetryAfter
 	}
 
 	return server
@@ -64,6 +101,10 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/ws/chat", func(w http.ResponseWriter, r *http.Request) {
 		s.handleWS(ctx, w, r)
 	})
+	mux.HandleFunc("/api/ping", s.handlePing)
+	if !s.chatPage.Disabled {
+		mux.HandleFunc("/chat", s.handleChatPage)
+	}
 	if s.api != nil {
 		s.api.register(mux)
 	}
@@ -90,6 +131,7 @@ func (s *Server) Start(ctx context.Context) error {
 
 	go func() {
 		<-ctx.Done()
+		s.closeClients()
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
@@ -98,7 +140,17 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
-	err := srv.ListenAndServe()
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.readyOnce.Do(func() { close(s.ready) })
+
+	err = srv.Serve(ln)
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
@@ -107,13 +159,31 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) handleWS(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	chatPage := isChatPageRequest(r)
+	if chatPage && s.chatPage.Disabled {
+		http.NotFound(w, r)
+		return
+	}
+	authorized := s.chatPageAuthorized(r)
+	// la página siempre necesita el token; el resto de las conexiones
+	// (overlays) puede entrar sin él, pero un token que no coincide se
+	// rechaza en vez de dejar la conexión en solo lectura
+	if !authorized && (chatPage || hasToken(r)) {
+		http.Error(w, "missing or invalid token", http.StatusUnauthorized)
+		return
+	}
+
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("ws: upgrade error: %v", err)
 		return
 	}
 
-	client := &wsClient{conn: conn}
+	conn.SetReadLimit(MaxIncomingMessageSize)
+	// sin token la conexión solo recibe el chat: el token protege cualquier
+	// escritura por /ws/chat, no solo la de la página. El dashboard manda
+	// el suyo (WS_TOKEN) para poder escribir.
+	client := &wsClient{conn: conn, chatPage: chatPage, canWrite: authorized}
 
 	s.mu.Lock()
 	s.clients[client] = struct{}{}
@@ -122,6 +192,10 @@ func (s *Server) handleWS(ctx context.Context, w http.ResponseWriter, r *http.Re
 
 	log.Printf("ws: nueva conexión desde %s (%d clientes activos)", r.RemoteAddr, clientCount)
 
+	if err := s.sendHello(client); err != nil {
+		log.Printf("ws: hello error: %v", err)
+	}
+
 	go s.handleClient(ctx, client)
 }
 
@@ -153,30 +227,68 @@ func (s *Server) handleClient(ctx context.Context, client *wsClient) {
 		}
 
 		if msgType != websocket.TextMessage {
+			if err := s.sendError(client, fmt.Errorf("%w: solo se aceptan mensajes de texto", ErrInvalidIncoming)); err != nil {
+				log.Printf("ws: error frame: %v", err)
+			}
 			continue
 		}
 
-		if err := s.dispatchIncoming(ctx, data); err != nil {
+		if isHistoryRequest(data) {
+			if err := s.sendHistory(client); err != nil {
+				log.Printf("ws: history error: %v", err)
+			}
+			continue
+		}
+
+		if err := s.dispatchIncoming(ctx, client, data); err != nil {
+			if isRejectedIncoming(err) {
+				if err := s.sendError(client, err); err != nil {
+					log.Printf("ws: error frame: %v", err)
+				}
+				continue
+			}
 			log.Printf("ws: incoming dispatch error: %v", err)
 		}
 	}
 }
 
-func (s *Server) dispatchIncoming(ctx context.Context, data []byte) error {
+func (s *Server) dispatchIncoming(ctx context.Context, client *wsClient, data []byte) error {
+	if err := validateIncoming(data); err != nil {
+		return err
+	}
+	if !client.canWrite {
+		return ErrUnauthorizedIncoming
+	}
 	handler := s.getHandler()
-	if handler == nil {
+	if handler == nil && (!client.chatPage || s.chatPage.Sender == nil) {
 		return nil
 	}
 
+	// lo que no es un objeto JSON se toma como texto plano; un objeto mal
+	// formado se rechaza en vez de mandarlo tal cual al chat
 	payload := incomingPayload{}
-	if err := json.Unmarshal(data, &payload); err != nil {
-		payload.Text = strings.TrimSpace(string(data))
-	} else {
+	if trimmed := bytes.TrimSpace(data); trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &payload); err != nil {
+			return fmt.Errorf("%w: JSON mal formado", ErrInvalidIncoming)
+		}
 		payload.Text = strings.TrimSpace(payload.Text)
+	} else {
+		payload.Text = strings.TrimSpace(string(trimmed))
 	}
 
 	if payload.Text == "" {
-		return fmt.Errorf("ws: empty incoming text")
+		return ErrEmptyIncoming
+	}
+
+	if client.chatPage {
+		msg := s.textPolicy.Apply(s.chatPageMessage(payload))
+		if msg.Text == "" {
+			return ErrEmptyIncoming
+		}
+		if s.chatPage.Sender != nil {
+			return s.chatPage.Sender.SendChatPageMessage(ctx, msg)
+		}
+		return handler(ctx, msg)
 	}
 
 	platform := normalizePlatform(payload.Platform)
@@ -202,6 +314,8 @@ func (s *Server) dispatchIncoming(ctx context.Context, data []byte) error {
 		ChannelID:       channelID,
 		UserID:          userID,
 		Username:        username,
+		Login:           strings.ToLower(username),
+		DisplayName:     username,
 		Text:            payload.Text,
 		IsPrivate:       payload.IsPrivate,
 		IsPlatformOwner: true,
@@ -209,6 +323,10 @@ func (s *Server) dispatchIncoming(ctx context.Context, data []byte) error {
 		IsPlatformMod:   true,
 		IsPlatformVip:   true,
 	}
+	msg = s.textPolicy.Apply(msg)
+	if msg.Text == "" {
+		return ErrEmptyIncoming
+	}
 
 	return handler(ctx, msg)
 }
@@ -219,6 +337,11 @@ func (s *Server) getHandler() MessageHandler {
 	return s.handler
 }
 
+// Ready se cierra cuando el servidor ya acepta conexiones.
+func (s *Server) Ready() <-chan struct{} {
+	return s.ready
+}
+
 func (s *Server) SetHandler(h MessageHandler) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -248,6 +371,14 @@ func (s *Server) OAuthStart(ctx context.Context, platform domain.Platform, role
 	return s.api.oauthStart(platform, role)
 }
 
+// SupportBundle arma el paquete de soporte (sin secretos).
+func (s *Server) SupportBundle(ctx context.Context) (SupportBundle, error) {
+	if s == nil || s.api == nil {
+		return SupportBundle{}, fmt.Errorf("api no disponible")
+	}
+	return s.api.supportBundle(ctx), nil
+}
+
 func (s *Server) OAuthStatus(ctx context.Context) (OAuthStatus, error) {
 	if s == nil || s.api == nil {
 		return OAuthStatus{Credentials: map[string]map[string]CredentialStatus{}}, fmt.Errorf("oauth server no disponible")
@@ -262,6 +393,17 @@ func (s *Server) OAuthLogout(ctx context.Context, platform domain.Platform, role
 	return s.api.oauthLogout(ctx, platform, role)
 }
 
+// isHistoryRequest detecta {"type":"history"} enviado por un cliente que se reconectó.
+func isHistoryRequest(data []byte) bool {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(probe.Type), EventTypeHistory)
+}
+
 type incomingPayload struct {
 	Text      string `json:"text"`
 	Platform  string `json:"platform"`
@@ -288,6 +430,7 @@ func (s *Server) PublishMessage(ctx context.Context, msg domain.Message) error {
 	if err != nil {
 		return err
 	}
+	s.history.add(payload)
 
 	s.mu.RLock()
 	clients := make([]*wsClient, 0, len(s.clients))
@@ -319,19 +462,24 @@ func (s *Server) PublishMessage(ctx context.Context, msg domain.Message) error {
 }
 
 func (s *Server) PublishTTSEvent(ctx context.Context, event domain.TTSEvent) error {
-	envelope := struct {
-		Type string          `json:"type"`
-		Data domain.TTSEvent `json:"data"`
-	}{
-		Type: "tts",
-		Data: event,
-	}
+	return s.PublishEvent(ctx, "tts", event)
+}
 
-	payload, err := json.Marshal(envelope)
+// PublishEvent envía un envelope {type, data} a todos los clientes WS.
+func (s *Server) PublishEvent(ctx context.Context, eventType string, data any) error {
+	payload, err := json.Marshal(envelope{Type: eventType, Data: data})
 	if err != nil {
 		return err
 	}
 
+	// el audio TTS no se guarda: pesa mucho y reproducirlo de nuevo al reconectar molesta
+	if eventType != "tts" {
+		s.history.add(payload)
+	}
+	return s.broadcast(ctx, payload)
+}
+
+func (s *Server) broadcast(ctx context.Context, payload []byte) error {
 	s.mu.RLock()
 	clients := make([]*wsClient, 0, len(s.clients))
 	for c := range s.clients {
@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const maskedSettingValue = "********"
+
+// secretSettingMarkers: si la clave contiene alguno de estos fragmentos, el
+// valor se oculta en el volcado de settings.
+var secretSettingMarkers = []string{"token", "secret", "password", "passwd", "apikey", "api_key", "auth", "credential", "cookie", "session_key"}
+
+type settingResponse struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Masked    bool   `json:"masked,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// handleSettingsList atiende GET /api/settings: volcado de settings para soporte
+// con los valores sensibles enmascarados.
+func (a *apiHandlers) handleSettingsList(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.settings == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	settings, err := a.settings.ListSettings(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := make([]settingResponse, 0, len(settings))
+	for _, setting := range settings {
+		response = append(response, maskSetting(setting))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func maskSetting(setting domain.Setting) settingResponse {
+	item := settingResponse{
+		Key:   setting.Key,
+		Value: setting.Value,
+	}
+	if !setting.UpdatedAt.IsZero() {
+		item.UpdatedAt = setting.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	if isSecretSettingKey(setting.Key) && setting.Value != "" {
+		item.Value = maskedSettingValue
+		item.Masked = true
+	}
+	return item
+}
+
+func isSecretSettingKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range secretSettingMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
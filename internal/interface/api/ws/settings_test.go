@@ -0,0 +1,113 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type staticSettings struct {
+	settings []domain.Setting
+	err      error
+}
+
+func (s staticSettings) ListSettings(context.Context) ([]domain.Setting, error) {
+	return s.settings, s.err
+}
+
+func TestIsSecretSettingKey(t *testing.T) {
+	secret := []string{"twitch_api_token", "KICK_CLIENT_SECRET", "obs_password", "tts_apikey", "openai_api_key", "oauth_state", "credential_cache", "session_key"}
+	public := []string{"tts_voice", "tts_enabled", "command_prefix", "bot_paused", "twitch_broadcaster_id"}
+
+	for _, key := range secret {
+		if !isSecretSettingKey(key) {
+			t.Errorf("%q should be masked", key)
+		}
+	}
+	for _, key := range public {
+		if isSecretSettingKey(key) {
+			t.Errorf("%q should not be masked", key)
+		}
+	}
+}
+
+func TestSettingsListMasksSecrets(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv := newTestAPI(t, Config{SettingsLister: staticSettings{settings: []domain.Setting{
+		{Key: "bot_paused", Value: "false"},
+		{Key: "tts_voice", Value: "es", UpdatedAt: updated},
+		{Key: "twitch_api_token", Value: "oauth:supersecreto"},
+		{Key: "webhook_secret", Value: ""},
+	}}})
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+"/api/settings", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d: %s", status, body)
+	}
+	if strings.Contains(string(body), "supersecreto") {
+		t.Fatalf("secret leaked: %s", body)
+	}
+
+	var got []settingResponse
+	decodeJSON(t, body, &got)
+	want := []settingResponse{
+		{Key: "bot_paused", Value: "false"},
+		{Key: "tts_voice", Value: "es", UpdatedAt: "2026-03-01T12:00:00Z"},
+		{Key: "twitch_api_token", Value: maskedSettingValue, Masked: true},
+		// un secreto vacío no se marca: así se ve que falta configurarlo
+		{Key: "webhook_secret", Value: ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("settings = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("settings[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSettingsListFromStore(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetTTSVoice(ctx, "es-es"); err != nil {
+		t.Fatalf("SetTTSVoice: %v", err)
+	}
+	if err := store.SetBool(ctx, "bot_paused", true); err != nil {
+		t.Fatalf("SetBool: %v", err)
+	}
+	srv := newTestAPI(t, Config{SettingsLister: store})
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+"/api/settings", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d: %s", status, body)
+	}
+	var got []settingResponse
+	decodeJSON(t, body, &got)
+	if len(got) != 2 || got[0].Key != "bot_paused" || got[0].Value != "true" || got[1].Key != "tts_voice" || got[1].Value != "es-es" {
+		t.Fatalf("settings = %+v", got)
+	}
+	if got[0].UpdatedAt == "" {
+		t.Fatal("updated_at missing")
+	}
+}
+
+func TestSettingsListErrors(t *testing.T) {
+	srv := newTestAPI(t, Config{SettingsLister: staticSettings{err: errors.New("db cerrada")}})
+	if status, _ := doRequest(t, http.MethodGet, srv.URL+"/api/settings", ""); status != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", status)
+	}
+	if status, _ := doRequest(t, http.MethodPost, srv.URL+"/api/settings", "{}"); status != http.StatusMethodNotAllowed {
+		t.Fatalf("POST status = %d, want 405", status)
+	}
+
+	unconfigured := newTestAPI(t, Config{})
+	if status, _ := doRequest(t, http.MethodGet, unconfigured.URL+"/api/settings", ""); status != http.StatusNotFound {
+		t.Fatalf("status without lister = %d, want 404", status)
+	}
+}
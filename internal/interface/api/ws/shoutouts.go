@@ -0,0 +1,41 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+type AutoShoutoutManager interface {
+	Settings() domain.AutoShoutoutSettings
+	Update(ctx context.Context, settings domain.AutoShoutoutSettings) (domain.AutoShoutoutSettings, error)
+}
+
+// handleShoutoutSettings atiende GET/PUT /api/shoutouts/settings.
+func (a *apiHandlers) handleShoutoutSettings(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.shoutouts == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.shoutouts.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.AutoShoutoutSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.shoutouts.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
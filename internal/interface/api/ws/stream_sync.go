@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"zhatBot/internal/domain"
+)
+
+type StreamSyncManager interface {
+	Settings() domain.StreamSyncSettings
+	Update(ctx context.Context, settings domain.StreamSyncSettings) (domain.StreamSyncSettings, error)
+}
+
+// handleStreamSync atiende GET/PUT /api/categories/sync (copia de título y
+// categoría de Twitch a Kick).
+func (a *apiHandlers) handleStreamSync(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.streamSync == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.streamSync.Settings())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload domain.StreamSyncSettings
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		applied, err := a.streamSync.Update(r.Context(), payload)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, applied)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,102 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"zhatBot/internal/app/events"
+	commandsusecase "zhatBot/internal/usecase/commands"
+)
+
+const supportBundleNotifications = 50
+
+// DiagnosticsProvider aporta el estado del runtime al paquete de soporte.
+type DiagnosticsProvider interface {
+	Diagnostics(ctx context.Context) any
+}
+
+// SupportBundle reúne en un JSON lo necesario para dar soporte. No incluye
+// tokens: las credenciales solo indican si existen y los settings sensibles
+// van enmascarados.
+type SupportBundle struct {
+	GeneratedAt   string                       `json:"generated_at"`
+	Diagnostics   any                          `json:"diagnostics,omitempty"`
+	OAuth         OAuthStatus                  `json:"oauth"`
+	TTS           *events.TTSStatusDTO         `json:"tts,omitempty"`
+	Settings      []settingResponse            `json:"settings"`
+	Commands      []commandsusecase.CommandDTO `json:"commands"`
+	Notifications []notificationResponse       `json:"notifications"`
+	Errors        []string                     `json:"errors,omitempty"`
+}
+
+func (a *apiHandlers) supportBundle(ctx context.Context) SupportBundle {
+	bundle := SupportBundle{
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Settings:      []settingResponse{},
+		Commands:      []commandsusecase.CommandDTO{},
+		Notifications: []notificationResponse{},
+	}
+	addErr := func(section string, err error) {
+		bundle.Errors = append(bundle.Errors, section+": "+err.Error())
+	}
+
+	if a.diagnostics != nil {
+		bundle.Diagnostics = a.diagnostics.Diagnostics(ctx)
+	}
+
+	if status, err := a.oauthStatus(ctx); err != nil {
+		addErr("oauth", err)
+	} else {
+		bundle.OAuth = status
+	}
+
+	if a.ttsStatus != nil {
+		status := a.ttsStatus.Status()
+		bundle.TTS = &status
+	}
+
+	if a.settings != nil {
+		if settings, err := a.settings.ListSettings(ctx); err != nil {
+			addErr("settings", err)
+		} else {
+			for _, setting := range settings {
+				bundle.Settings = append(bundle.Settings, maskSetting(setting))
+			}
+		}
+	}
+
+	if a.commandSvc != nil {
+		if commands, err := a.commandSvc.List(ctx); err != nil {
+			addErr("commands", err)
+		} else {
+			bundle.Commands = commands
+		}
+	}
+
+	if a.notifications != nil {
+		if items, err := a.notifications.ListNotifications(ctx, supportBundleNotifications); err != nil {
+			addErr("notifications", err)
+		} else {
+			bundle.Notifications = toNotificationResponseList(items)
+		}
+	}
+
+	return bundle
+}
+
+// handleSupportBundle atiende GET /api/support/bundle y lo sirve como descarga.
+func (a *apiHandlers) handleSupportBundle(w http.ResponseWriter, r *http.Request) {
+	if a == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	bundle := a.supportBundle(r.Context())
+	filename := "zhatbot-support-" + time.Now().UTC().Format("20060102-150405") + ".json"
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	writeJSON(w, http.StatusOK, bundle)
+}
@@ -0,0 +1,153 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/app/events"
+	"zhatBot/internal/domain"
+	commandsusecase "zhatBot/internal/usecase/commands"
+)
+
+type staticDiagnostics map[string]any
+
+func (d staticDiagnostics) Diagnostics(context.Context) any { return map[string]any(d) }
+
+type staticTTSStatus events.TTSStatusDTO
+
+func (s staticTTSStatus) Status() events.TTSStatusDTO { return events.TTSStatusDTO(s) }
+
+func TestSupportBundleShapeWithoutSecrets(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	secrets := []string{"access-muy-secreto", "refresh-muy-secreto", "setting-muy-secreto"}
+	if err := store.Save(ctx, &domain.Credential{
+		Platform:     domain.PlatformTwitch,
+		Role:         "streamer",
+		AccessToken:  secrets[0],
+		RefreshToken: secrets[1],
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Metadata:     map[string]string{"login": "streamer"},
+	}); err != nil {
+		t.Fatalf("Save credential: %v", err)
+	}
+	if err := store.SetJSON(ctx, "twitch_api_token", secrets[2]); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	if err := store.SetTTSVoice(ctx, "es"); err != nil {
+		t.Fatalf("SetTTSVoice: %v", err)
+	}
+	if _, err := store.SaveNotification(ctx, &domain.Notification{Type: domain.NotificationDonation, Platform: domain.PlatformKick, Username: "fan", Amount: 5}); err != nil {
+		t.Fatalf("SaveNotification: %v", err)
+	}
+
+	manager, err := commandsusecase.NewCustomCommandManager(ctx, store)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	commandSvc := commandsusecase.NewService(manager)
+	response := "¡Hola!"
+	if _, err := commandSvc.Upsert(ctx, commandsusecase.CommandMutationDTO{Name: "hola", Response: &response}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	srv := newTestAPI(t, Config{
+		CredentialRepo:   store,
+		NotificationRepo: store,
+		SettingsLister:   store,
+		CommandService:   commandSvc,
+		Diagnostics:      staticDiagnostics{"uptime": "1h"},
+		TTSRunnerStatus:  staticTTSStatus{State: "idle", QueueLength: 2},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/support/bundle", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET bundle: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="zhatbot-support-`) {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, key := range []string{"generated_at", "diagnostics", "oauth", "tts", "settings", "commands", "notifications"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("bundle has no %q section", key)
+		}
+	}
+	if _, ok := raw["errors"]; ok {
+		t.Errorf("unexpected errors: %s", raw["errors"])
+	}
+
+	all, _ := json.Marshal(raw)
+	for _, secret := range secrets {
+		if strings.Contains(string(all), secret) {
+			t.Fatalf("bundle leaks %q: %s", secret, all)
+		}
+	}
+
+	var bundle SupportBundle
+	if err := json.Unmarshal(all, &bundle); err != nil {
+		t.Fatalf("decode bundle: %v", err)
+	}
+	cred := bundle.OAuth.Credentials["twitch"]["streamer"]
+	if !cred.HasAccessToken || !cred.HasRefreshToken {
+		t.Fatalf("oauth = %+v, want token presence flags", bundle.OAuth)
+	}
+	custom := 0
+	for _, cmd := range bundle.Commands {
+		if cmd.Source == "custom" {
+			custom++
+			if cmd.Name != "hola" || cmd.Response != response {
+				t.Fatalf("custom command = %+v", cmd)
+			}
+		}
+	}
+	if custom != 1 {
+		t.Fatalf("bundle has %d custom commands, want 1", custom)
+	}
+	if len(bundle.Notifications) != 1 || bundle.Notifications[0].Username != "fan" {
+		t.Fatalf("notifications = %+v", bundle.Notifications)
+	}
+	if bundle.TTS == nil || bundle.TTS.QueueLength != 2 {
+		t.Fatalf("tts = %+v", bundle.TTS)
+	}
+	masked := false
+	for _, setting := range bundle.Settings {
+		if setting.Key == "twitch_api_token" {
+			masked = setting.Masked && setting.Value == maskedSettingValue
+		}
+	}
+	if !masked {
+		t.Fatalf("settings = %+v, want twitch_api_token masked", bundle.Settings)
+	}
+}
+
+func TestSupportBundleWithoutServices(t *testing.T) {
+	srv := newTestAPI(t, Config{})
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+"/api/support/bundle", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d: %s", status, body)
+	}
+	var bundle map[string]json.RawMessage
+	decodeJSON(t, body, &bundle)
+	// las listas vacías se serializan como [] para que el cliente no tenga que chequear null
+	for _, key := range []string{"settings", "commands", "notifications"} {
+		if string(bundle[key]) != "[]" {
+			t.Errorf("%s = %s, want []", key, bundle[key])
+		}
+	}
+}
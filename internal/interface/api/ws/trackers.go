@@ -0,0 +1,103 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	trackersusecase "zhatBot/internal/usecase/trackers"
+)
+
+type TrackerManager interface {
+	List() []trackersusecase.TrackerDTO
+	Upsert(ctx context.Context, input trackersusecase.TrackerMutationDTO) (trackersusecase.TrackerDTO, error)
+	Delete(ctx context.Context, name string) error
+	Reset(ctx context.Context, name string) (trackersusecase.TrackerDTO, error)
+}
+
+// handleTrackers atiende GET/POST/DELETE /api/trackers.
+func (a *apiHandlers) handleTrackers(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.trackers == nil {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.trackers.List())
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		var payload trackersusecase.TrackerMutationDTO
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		result, err := a.trackers.Upsert(r.Context(), payload)
+		if err != nil {
+			writeTrackerError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	case http.MethodDelete:
+		name := trackerNameFromRequest(r)
+		if name == "" {
+			writeAPIError(w, errMissingField("name"))
+			return
+		}
+		if err := a.trackers.Delete(r.Context(), name); err != nil {
+			writeTrackerError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleTrackerReset atiende POST /api/trackers/reset.
+func (a *apiHandlers) handleTrackerReset(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.trackers == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	name := trackerNameFromRequest(r)
+	if name == "" {
+		writeAPIError(w, errMissingField("name"))
+		return
+	}
+	result, err := a.trackers.Reset(r.Context(), name)
+	if err != nil {
+		writeTrackerError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func trackerNameFromRequest(r *http.Request) string {
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" && r.Body != nil {
+		defer r.Body.Close()
+		var payload struct {
+			Name string `json:"name"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		name = strings.TrimSpace(payload.Name)
+	}
+	return name
+}
+
+func writeTrackerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, trackersusecase.ErrTrackerNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, trackersusecase.ErrInvalidTracker):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,42 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"zhatBot/internal/domain"
+)
+
+// maxTTSHistoryLimit es el máximo de lecturas por consulta.
+const maxTTSHistoryLimit = 500
+
+type TTSHistoryProvider interface {
+	List(ctx context.Context, filter domain.TTSHistoryFilter) ([]domain.TTSHistoryEntry, error)
+}
+
+// handleTTSHistory atiende GET /api/tts/history?limit=&user=.
+func (a *apiHandlers) handleTTSHistory(w http.ResponseWriter, r *http.Request) {
+	if a == nil || a.ttsHistory == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit > maxTTSHistoryLimit {
+		limit = maxTTSHistoryLimit
+	}
+	entries, err := a.ttsHistory.List(r.Context(), domain.TTSHistoryFilter{
+		Limit: limit,
+		User:  query.Get("user"),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
@@ -0,0 +1,115 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+	usernotesusecase "zhatBot/internal/usecase/usernotes"
+)
+
+type UserNoteManager interface {
+	List(ctx context.Context, platform domain.Platform, userID string) ([]*domain.UserNote, error)
+	Add(ctx context.Context, note domain.UserNote) (*domain.UserNote, error)
+	Delete(ctx context.Context, platform domain.Platform, userID string, id int64) error
+}
+
+type userNotePayload struct {
+	Login  string `json:"login"`
+	Note   string `json:"note"`
+	Author string `json:"author"`
+}
+
+// handleUsers reparte /api/users/{platform}/{id}[/notes[/{noteID}]].
+func (a *apiHandlers) handleUsers(w http.ResponseWriter, r *http.Request) {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/users/"), "/")
+	parts := strings.Split(rest, "/")
+	if len(parts) >= 3 && parts[2] == "notes" {
+		a.handleUserNotes(w, r, parts)
+		return
+	}
+	a.handleUserProfile(w, r)
+}
+
+// handleUserNotes atiende GET/POST /api/users/{platform}/{id}/notes y
+// DELETE /api/users/{platform}/{id}/notes/{noteID}.
+func (a *apiHandlers) handleUserNotes(w http.ResponseWriter, r *http.Request, parts []string) {
+	if a == nil || a.userNotes == nil || len(parts) > 4 {
+		http.NotFound(w, r)
+		return
+	}
+	platform := domain.Platform(strings.ToLower(strings.TrimSpace(parts[0])))
+	userID := strings.TrimSpace(parts[1])
+	if platform == "" || userID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(parts) == 4 {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		id, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid note id")
+			return
+		}
+		if err := a.userNotes.Delete(r.Context(), platform, userID, id); err != nil {
+			if errors.Is(err, usernotesusecase.ErrNoteNotFound) {
+				writeError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		notes, err := a.userNotes.List(r.Context(), platform, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if notes == nil {
+			notes = []*domain.UserNote{}
+		}
+		writeJSON(w, http.StatusOK, notes)
+	case http.MethodPost:
+		defer r.Body.Close()
+		var payload userNotePayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeAPIError(w, errInvalidPayload())
+			return
+		}
+		author := strings.TrimSpace(payload.Author)
+		if author == "" {
+			author = "dashboard"
+		}
+		saved, err := a.userNotes.Add(r.Context(), domain.UserNote{
+			Platform: platform,
+			UserID:   userID,
+			Login:    payload.Login,
+			Note:     payload.Note,
+			Author:   author,
+		})
+		if err != nil {
+			if errors.Is(err, usernotesusecase.ErrEmptyNote) || errors.Is(err, usernotesusecase.ErrNoteTooLong) {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusCreated, saved)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
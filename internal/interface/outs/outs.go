@@ -19,6 +19,18 @@ type Sender interface {
 type MultiSender struct {
 	mu      sync.RWMutex
 	senders map[domain.Platform]Sender
+
+	readOnly     func() bool
+	onSuppressed func(ctx context.Context, msg SuppressedMessage)
+}
+
+// SuppressedMessage es un mensaje que no se envió por estar en modo solo lectura.
+type SuppressedMessage struct {
+	Platform  domain.Platform
+	ChannelID string
+	Username  string
+	Text      string
+	Private   bool
 }
 
 // NewMultiSender crea un MultiSender vacío.
@@ -48,11 +60,51 @@ func (m *MultiSender) Unregister(platform domain.Platform) {
 	delete(m.senders, platform)
 }
 
+// Registered indica si hay un sender para la plataforma.
+func (m *MultiSender) Registered(platform domain.Platform) bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.senders[platform]
+	return ok
+}
+
+// SetReadOnly configura el modo solo lectura: mientras enabled devuelva true,
+// los envíos no salen y se entregan a onSuppressed.
+func (m *MultiSender) SetReadOnly(enabled func() bool, onSuppressed func(ctx context.Context, msg SuppressedMessage)) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.readOnly = enabled
+	m.onSuppressed = onSuppressed
+}
+
+// suppress devuelve true si el mensaje no debe enviarse.
+func (m *MultiSender) suppress(ctx context.Context, msg SuppressedMessage) bool {
+	m.mu.RLock()
+	enabled, onSuppressed := m.readOnly, m.onSuppressed
+	m.mu.RUnlock()
+	if enabled == nil || !enabled() {
+		return false
+	}
+	if onSuppressed != nil {
+		onSuppressed(ctx, msg)
+	}
+	return true
+}
+
 // SendMessage busca el sender para esa plataforma y delega el envío.
 func (m *MultiSender) SendMessage(ctx context.Context, platform domain.Platform, channelID, text string) error {
 	if m == nil {
 		return fmt.Errorf("no hay multi sender configurado")
 	}
+	if m.suppress(ctx, SuppressedMessage{Platform: platform, ChannelID: channelID, Text: text}) {
+		return nil
+	}
 	m.mu.RLock()
 	sender, ok := m.senders[platform]
 	m.mu.RUnlock()
@@ -62,3 +114,25 @@ func (m *MultiSender) SendMessage(ctx context.Context, platform domain.Platform,
 
 	return sender.SendMessage(ctx, platform, channelID, text)
 }
+
+// SendPrivateMessage delega en el sender de la plataforma si soporta mensajes privados.
+func (m *MultiSender) SendPrivateMessage(ctx context.Context, platform domain.Platform, userID, username, text string) error {
+	if m == nil {
+		return fmt.Errorf("no hay multi sender configurado")
+	}
+	if m.suppress(ctx, SuppressedMessage{Platform: platform, ChannelID: userID, Username: username, Text: text, Private: true}) {
+		return nil
+	}
+	m.mu.RLock()
+	sender, ok := m.senders[platform]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no hay sender registrado para la plataforma %s", platform)
+	}
+
+	private, ok := sender.(domain.PrivateMessagePort)
+	if !ok {
+		return domain.ErrPrivateMessageUnsupported
+	}
+	return private.SendPrivateMessage(ctx, platform, userID, username, text)
+}
@@ -0,0 +1,200 @@
+// Package away guarda en memoria si el streamer marcó que está ausente
+// (!away) y responde a quien lo menciona mientras tanto.
+package away
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// ChannelCooldown es el tiempo mínimo entre respuestas automáticas en un
+	// mismo canal, para que una ola de menciones no llene el chat.
+	ChannelCooldown = 30 * time.Second
+	// UserCooldown evita responderle varias veces a la misma persona.
+	UserCooldown = 5 * time.Minute
+	// DefaultMessage se usa cuando !away no trae mensaje.
+	DefaultMessage = "Vuelvo en un rato"
+	// maxRepliedUsers limita el mapa de usuarios ya respondidos.
+	maxRepliedUsers = 1000
+)
+
+// State es lo que se publica a los overlays.
+type State struct {
+	Away    bool      `json:"away"`
+	Message string    `json:"message,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+}
+
+type channelKey struct {
+	platform  domain.Platform
+	channelID string
+}
+
+type userKey struct {
+	platform domain.Platform
+	user     string
+}
+
+type Service struct {
+	mu        sync.Mutex
+	state     State
+	autoReply bool
+	names     func() []string
+	onChange  func(State)
+	now       func() time.Time
+
+	lastChannel map[channelKey]time.Time
+	lastUser    map[userKey]time.Time
+}
+
+func NewService() *Service {
+	return &Service{
+		autoReply:   true,
+		now:         time.Now,
+		lastChannel: make(map[channelKey]time.Time),
+		lastUser:    make(map[userKey]time.Time),
+	}
+}
+
+// SetNamesFunc indica los nombres que cuentan como mención (@nombre).
+func (s *Service) SetNamesFunc(fn func() []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.names = fn
+}
+
+// SetAutoReply activa o desactiva la respuesta automática a las menciones.
+func (s *Service) SetAutoReply(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoReply = enabled
+}
+
+// SetChangeHandler se llama cada vez que cambia el estado.
+func (s *Service) SetChangeHandler(fn func(State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+func (s *Service) State() State {
+	if s == nil {
+		return State{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// SetAway marca al streamer como ausente. Si ya lo estaba solo cambia el
+// mensaje y conserva desde cuándo.
+func (s *Service) SetAway(message string) State {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = DefaultMessage
+	}
+	s.mu.Lock()
+	since := s.state.Since
+	if !s.state.Away {
+		since = s.now()
+		s.lastChannel = make(map[channelKey]time.Time)
+		s.lastUser = make(map[userKey]time.Time)
+	}
+	s.state = State{Away: true, Message: message, Since: since}
+	state := s.state
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(state)
+	}
+	return state
+}
+
+// SetBack quita la ausencia. Devuelve cuánto duró y false si no estaba ausente.
+func (s *Service) SetBack() (time.Duration, bool) {
+	s.mu.Lock()
+	if !s.state.Away {
+		s.mu.Unlock()
+		return 0, false
+	}
+	elapsed := s.now().Sub(s.state.Since)
+	s.state = State{}
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(State{})
+	}
+	return elapsed, true
+}
+
+// Observe devuelve la respuesta automática si msg menciona al streamer
+// mientras está ausente, o "" si no corresponde (o si se respondió hace poco
+// en ese canal o a esa persona).
+func (s *Service) Observe(msg domain.Message) string {
+	if s == nil || msg.IsPlatformOwner || strings.TrimSpace(msg.Text) == "" {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.state.Away || !s.autoReply || s.names == nil {
+		return ""
+	}
+	names := s.names()
+	if !mentions(msg.Text, names) || isOneOf(msg.LoginName(), names) {
+		return ""
+	}
+
+	now := s.now()
+	ck := channelKey{platform: msg.Platform, channelID: msg.ChannelID}
+	uk := userKey{platform: msg.Platform, user: strings.ToLower(msg.LoginName())}
+	if last, ok := s.lastChannel[ck]; ok && now.Sub(last) < ChannelCooldown {
+		return ""
+	}
+	if last, ok := s.lastUser[uk]; ok && now.Sub(last) < UserCooldown {
+		return ""
+	}
+	if len(s.lastUser) >= maxRepliedUsers {
+		s.lastUser = make(map[userKey]time.Time)
+	}
+	s.lastChannel[ck] = now
+	s.lastUser[uk] = now
+
+	return "@" + msg.Username + " está ausente: " + s.state.Message
+}
+
+// mentions indica si text tiene @nombre para alguno de names, sin importar
+// mayúsculas ni la puntuación que siga.
+func mentions(text string, names []string) bool {
+	for _, word := range strings.Fields(text) {
+		if !strings.HasPrefix(word, "@") {
+			continue
+		}
+		word = strings.TrimRightFunc(word[1:], func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		})
+		if isOneOf(word, names) {
+			return true
+		}
+	}
+	return false
+}
+
+func isOneOf(value string, names []string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, name := range names {
+		if strings.EqualFold(value, strings.TrimPrefix(strings.TrimSpace(name), "#")) {
+			return true
+		}
+	}
+	return false
+}
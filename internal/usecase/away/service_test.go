@@ -0,0 +1,142 @@
+package away
+
+import (
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestService() (*Service, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)}
+	svc := NewService()
+	svc.now = clock.Now
+	svc.SetNamesFunc(func() []string { return []string{"Zero", "zhatbot", "#zero_dev"} })
+	return svc, clock
+}
+
+func mention(user, channel, text string) domain.Message {
+	return domain.Message{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: channel,
+		UserID:    "id-" + user,
+		Username:  user,
+		Login:     user,
+		Text:      text,
+	}
+}
+
+func TestObserveRepliesToMentionsWhileAway(t *testing.T) {
+	svc, _ := newTestService()
+
+	if got := svc.Observe(mention("ana", "zero", "@zero hola")); got != "" {
+		t.Fatalf("respondió sin estar ausente: %q", got)
+	}
+
+	svc.SetAway("  comiendo  ")
+	if got := svc.Observe(mention("ana", "zero", "hola @Zero!!")); got != "@ana está ausente: comiendo" {
+		t.Fatalf("reply = %q", got)
+	}
+	for _, text := range []string{"hola zero", "mail@zero.com", "@zeroo hola", ""} {
+		if got := svc.Observe(mention("beto", "otro", text)); got != "" {
+			t.Errorf("%q no es una mención y respondió %q", text, got)
+		}
+	}
+
+	owner := mention("Zero", "zero2", "@zhatbot test")
+	owner.IsPlatformOwner = true
+	if got := svc.Observe(owner); got != "" {
+		t.Errorf("le respondió al streamer: %q", got)
+	}
+	if got := svc.Observe(mention("zhatbot", "zero3", "@zero_dev hola")); got != "" {
+		t.Errorf("el bot se respondió a sí mismo: %q", got)
+	}
+}
+
+func TestObserveRateLimits(t *testing.T) {
+	svc, clock := newTestService()
+	svc.SetAway("")
+
+	if got := svc.Observe(mention("ana", "zero", "@zero")); got != "@ana está ausente: "+DefaultMessage {
+		t.Fatalf("reply = %q", got)
+	}
+	// mismo canal dentro del cooldown: nadie recibe respuesta
+	if got := svc.Observe(mention("beto", "zero", "@zero")); got != "" {
+		t.Fatalf("el cooldown del canal no frenó: %q", got)
+	}
+	// otro canal sí responde
+	if got := svc.Observe(mention("beto", "kick", "@zero")); got == "" {
+		t.Fatal("el cooldown de un canal frenó a otro")
+	}
+
+	clock.Advance(ChannelCooldown)
+	if got := svc.Observe(mention("carla", "zero", "@zero")); got == "" {
+		t.Fatal("el canal no se liberó después del cooldown")
+	}
+
+	// ana ya recibió respuesta: espera el cooldown por usuario aunque el
+	// canal esté libre
+	clock.Advance(ChannelCooldown)
+	if got := svc.Observe(mention("ana", "zero", "@zero")); got != "" {
+		t.Fatalf("se le respondió dos veces a la misma persona: %q", got)
+	}
+	clock.Advance(UserCooldown)
+	if got := svc.Observe(mention("ana", "zero", "@zero")); got == "" {
+		t.Fatal("el cooldown por usuario no se liberó")
+	}
+}
+
+func TestSetAwayResetsCooldowns(t *testing.T) {
+	svc, clock := newTestService()
+	svc.SetAway("un rato")
+	svc.Observe(mention("ana", "zero", "@zero"))
+
+	clock.Advance(time.Minute)
+	if _, ok := svc.SetBack(); !ok {
+		t.Fatal("SetBack = false estando ausente")
+	}
+	svc.SetAway("otra vez")
+	if got := svc.Observe(mention("ana", "zero", "@zero")); got != "@ana está ausente: otra vez" {
+		t.Fatalf("una ausencia nueva heredó los cooldowns: %q", got)
+	}
+}
+
+func TestAutoReplyDisabled(t *testing.T) {
+	svc, _ := newTestService()
+	svc.SetAutoReply(false)
+	svc.SetAway("fuera")
+	if got := svc.Observe(mention("ana", "zero", "@zero")); got != "" {
+		t.Fatalf("respondió con AWAY_AUTOREPLY=0: %q", got)
+	}
+}
+
+func TestStateChanges(t *testing.T) {
+	svc, clock := newTestService()
+	var states []State
+	svc.SetChangeHandler(func(s State) { states = append(states, s) })
+
+	since := clock.Now()
+	svc.SetAway("comiendo")
+	clock.Advance(10 * time.Minute)
+	// cambiar el mensaje conserva desde cuándo
+	if got := svc.SetAway("ya casi"); !got.Since.Equal(since) {
+		t.Fatalf("Since = %v, esperaba %v", got.Since, since)
+	}
+	clock.Advance(5 * time.Minute)
+	elapsed, ok := svc.SetBack()
+	if !ok || elapsed != 15*time.Minute {
+		t.Fatalf("SetBack = %v, %v", elapsed, ok)
+	}
+	if _, ok := svc.SetBack(); ok {
+		t.Fatal("SetBack = true sin estar ausente")
+	}
+
+	if len(states) != 3 || !states[0].Away || states[1].Message != "ya casi" || states[2].Away {
+		t.Fatalf("states = %+v", states)
+	}
+}
@@ -0,0 +1,291 @@
+// Package botconflicts detecta otros bots que responden los mismos comandos
+// que este: si uno de la lista escribe poco después de un comando que el bot
+// conoce, el chat recibió dos respuestas.
+package botconflicts
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// TriggerFunc devuelve el comando que dispara text, si el bot lo conoce.
+type TriggerFunc func(text string) (string, bool)
+
+// ConflictDTO describe una respuesta de otro bot a un comando del bot.
+type ConflictDTO struct {
+	Platform  string `json:"platform"`
+	ChannelID string `json:"channel_id"`
+	Command   string `json:"command"`
+	Bot       string `json:"bot"`
+	User      string `json:"user"`
+	// DelayMS es cuánto tardó el otro bot desde el comando.
+	DelayMS int64 `json:"delay_ms"`
+	// Suppressed indica que, por el modo learn, el bot deja de responderlo.
+	Suppressed bool   `json:"suppressed"`
+	At         string `json:"at"`
+}
+
+// trigger es un comando reciente que todavía puede chocar con otro bot.
+type trigger struct {
+	command string
+	user    string
+	at      time.Time
+}
+
+const maxWindowSeconds = 60
+
+type Service struct {
+	repo domain.BotConflictSettingsRepository
+
+	mu         sync.Mutex
+	cfg        domain.BotConflictSettings
+	bots       map[string]bool
+	suppressed map[string]bool
+	// pending son los comandos recientes por canal (plataforma:canal), del
+	// más viejo al más nuevo.
+	pending map[string][]trigger
+	resolve TriggerFunc
+	handler func(ConflictDTO)
+	now     func() time.Time
+}
+
+func NewService(repo domain.BotConflictSettingsRepository) *Service {
+	s := &Service{
+		repo:    repo,
+		pending: make(map[string][]trigger),
+		now:     time.Now,
+	}
+	s.apply(domain.DefaultBotConflictSettings())
+	return s
+}
+
+// SetTriggerFunc indica cómo reconocer los comandos del bot; sin ella no se
+// detecta nada.
+func (s *Service) SetTriggerFunc(fn TriggerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolve = fn
+}
+
+// SetConflictHandler recibe cada conflicto detectado.
+func (s *Service) SetConflictHandler(fn func(ConflictDTO)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = fn
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetBotConflictSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.apply(sanitizeSettings(*stored))
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.BotConflictSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneSettings(s.cfg)
+}
+
+// Update guarda y aplica la configuración. Quitar un comando de Suppressed
+// hace que el bot lo vuelva a responder.
+func (s *Service) Update(ctx context.Context, settings domain.BotConflictSettings) (domain.BotConflictSettings, error) {
+	applied := sanitizeSettings(settings)
+	s.mu.Lock()
+	s.apply(applied)
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetBotConflictSettings(ctx, applied); err != nil {
+			return cloneSettings(applied), err
+		}
+	}
+	return cloneSettings(applied), nil
+}
+
+// Observe mira cada mensaje del chat. Los comandos que el bot conoce quedan
+// anotados durante la ventana; si en ese tiempo escribe uno de los bots de la
+// lista, es un conflicto. Devuelve true si el bot no debe responder msg
+// porque el comando quedó suprimido en modo learn.
+func (s *Service) Observe(ctx context.Context, msg domain.Message) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	if !s.cfg.Enabled {
+		s.mu.Unlock()
+		return false
+	}
+	now := s.now()
+	key := channelKey(msg)
+	s.pruneLocked(key, now)
+
+	if s.bots[msg.LoginName()] {
+		conflict, ok := s.conflictLocked(key, msg, now)
+		handler := s.handler
+		var learned *domain.BotConflictSettings
+		if ok && conflict.Suppressed {
+			snapshot := cloneSettings(s.cfg)
+			learned = &snapshot
+		}
+		s.mu.Unlock()
+		if !ok {
+			return false
+		}
+		log.Printf("bot conflicts: %s también respondió !%s en %s/%s (%dms)", conflict.Bot, conflict.Command, conflict.Platform, conflict.ChannelID, conflict.DelayMS)
+		if learned != nil && s.repo != nil {
+			if err := s.repo.SetBotConflictSettings(ctx, *learned); err != nil {
+				log.Printf("bot conflicts: no pude guardar !%s como suprimido: %v", conflict.Command, err)
+			}
+		}
+		if handler != nil {
+			handler(conflict)
+		}
+		return false
+	}
+
+	if s.resolve == nil {
+		s.mu.Unlock()
+		return false
+	}
+	command, ok := s.resolve(msg.Text)
+	if !ok {
+		s.mu.Unlock()
+		return false
+	}
+	if s.suppressed[command] {
+		s.mu.Unlock()
+		log.Printf("bot conflicts: !%s lo responde otro bot, se ignora", command)
+		return true
+	}
+	s.pending[key] = append(s.pending[key], trigger{command: command, user: msg.Name(), at: now})
+	s.mu.Unlock()
+	return false
+}
+
+// conflictLocked atribuye el mensaje del otro bot al comando más reciente
+// del canal que siga dentro de la ventana; ese comando ya no cuenta para
+// otro conflicto.
+func (s *Service) conflictLocked(key string, msg domain.Message, now time.Time) (ConflictDTO, bool) {
+	queue := s.pending[key]
+	if len(queue) == 0 {
+		return ConflictDTO{}, false
+	}
+	last := queue[len(queue)-1]
+	if len(queue) == 1 {
+		delete(s.pending, key)
+	} else {
+		s.pending[key] = queue[:len(queue)-1]
+	}
+
+	suppressed := false
+	if s.cfg.Mode == domain.BotConflictLearn && !s.suppressed[last.command] {
+		s.suppressed[last.command] = true
+		s.cfg.Suppressed = append(s.cfg.Suppressed, last.command)
+		sort.Strings(s.cfg.Suppressed)
+		suppressed = true
+	}
+	return ConflictDTO{
+		Platform:   string(msg.Platform),
+		ChannelID:  msg.ChannelID,
+		Command:    last.command,
+		Bot:        msg.LoginName(),
+		User:       last.user,
+		DelayMS:    now.Sub(last.at).Milliseconds(),
+		Suppressed: suppressed,
+		At:         now.UTC().Format(time.RFC3339),
+	}, true
+}
+
+// pruneLocked descarta los comandos de key que ya salieron de la ventana.
+func (s *Service) pruneLocked(key string, now time.Time) {
+	queue := s.pending[key]
+	window := time.Duration(s.cfg.WindowSeconds) * time.Second
+	keep := 0
+	for keep < len(queue) && now.Sub(queue[keep].at) > window {
+		keep++
+	}
+	switch {
+	case keep == len(queue):
+		delete(s.pending, key)
+	case keep > 0:
+		s.pending[key] = append([]trigger(nil), queue[keep:]...)
+	}
+}
+
+func (s *Service) apply(cfg domain.BotConflictSettings) {
+	s.cfg = cfg
+	s.bots = make(map[string]bool, len(cfg.Bots))
+	for _, login := range cfg.Bots {
+		s.bots[login] = true
+	}
+	s.suppressed = make(map[string]bool, len(cfg.Suppressed))
+	for _, command := range cfg.Suppressed {
+		s.suppressed[command] = true
+	}
+	if !cfg.Enabled {
+		s.pending = make(map[string][]trigger)
+	}
+}
+
+func sanitizeSettings(settings domain.BotConflictSettings) domain.BotConflictSettings {
+	defaults := domain.DefaultBotConflictSettings()
+	switch settings.Mode {
+	case domain.BotConflictLog, domain.BotConflictLearn:
+	default:
+		settings.Mode = defaults.Mode
+	}
+	if settings.WindowSeconds <= 0 {
+		settings.WindowSeconds = defaults.WindowSeconds
+	}
+	if settings.WindowSeconds > maxWindowSeconds {
+		settings.WindowSeconds = maxWindowSeconds
+	}
+	settings.Bots = normalizeList(settings.Bots, "@")
+	settings.Suppressed = normalizeList(settings.Suppressed, "!")
+	return settings
+}
+
+func normalizeList(values []string, prefix string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), prefix))
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func cloneSettings(settings domain.BotConflictSettings) domain.BotConflictSettings {
+	settings.Bots = append([]string{}, settings.Bots...)
+	settings.Suppressed = append([]string{}, settings.Suppressed...)
+	return settings
+}
+
+func channelKey(msg domain.Message) string {
+	return string(msg.Platform) + ":" + strings.ToLower(strings.TrimSpace(msg.ChannelID))
+}
@@ -0,0 +1,189 @@
+package botconflicts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memorySettings hace de la tabla de configuración.
+type memorySettings struct {
+	saved *domain.BotConflictSettings
+}
+
+func (m *memorySettings) GetBotConflictSettings(context.Context) (*domain.BotConflictSettings, error) {
+	return m.saved, nil
+}
+
+func (m *memorySettings) SetBotConflictSettings(_ context.Context, settings domain.BotConflictSettings) error {
+	m.saved = &settings
+	return nil
+}
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func knownCommands(text string) (string, bool) {
+	if !strings.HasPrefix(text, "!") {
+		return "", false
+	}
+	name := strings.Fields(strings.TrimPrefix(text, "!"))[0]
+	switch name {
+	case "discord", "redes":
+		return name, true
+	}
+	return "", false
+}
+
+func say(channel, login, text string) domain.Message {
+	return domain.Message{Platform: domain.PlatformTwitch, ChannelID: channel, Username: login, Text: text}
+}
+
+func newTestService(t *testing.T, mode domain.BotConflictMode) (*Service, *memorySettings, *fakeClock, *[]ConflictDTO) {
+	t.Helper()
+	repo := &memorySettings{}
+	clock := &fakeClock{t: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)}
+	svc := NewService(repo)
+	svc.now = clock.Now
+	svc.SetTriggerFunc(knownCommands)
+	var conflicts []ConflictDTO
+	svc.SetConflictHandler(func(c ConflictDTO) { conflicts = append(conflicts, c) })
+	if _, err := svc.Update(context.Background(), domain.BotConflictSettings{
+		Enabled:       true,
+		Mode:          mode,
+		Bots:          []string{" @Nightbot ", "streamelements"},
+		WindowSeconds: 5,
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return svc, repo, clock, &conflicts
+}
+
+func TestConflictWindow(t *testing.T) {
+	ctx := context.Background()
+	svc, _, clock, conflicts := newTestService(t, domain.BotConflictLog)
+
+	// dentro de la ventana: conflicto
+	svc.Observe(ctx, say("canal", "ana", "!discord"))
+	clock.Advance(2 * time.Second)
+	svc.Observe(ctx, say("canal", "Nightbot", "discord.gg/abc"))
+	if len(*conflicts) != 1 {
+		t.Fatalf("conflicts = %+v", *conflicts)
+	}
+	got := (*conflicts)[0]
+	if got.Command != "discord" || got.Bot != "nightbot" || got.User != "ana" || got.DelayMS != 2000 || got.Suppressed {
+		t.Fatalf("conflicto = %+v", got)
+	}
+
+	// el mismo comando no cuenta dos veces
+	svc.Observe(ctx, say("canal", "streamelements", "discord.gg/abc"))
+	if len(*conflicts) != 1 {
+		t.Fatalf("un comando generó dos conflictos: %+v", *conflicts)
+	}
+
+	// justo en el borde de la ventana sigue valiendo; un instante después no
+	svc.Observe(ctx, say("canal", "ana", "!redes"))
+	clock.Advance(5 * time.Second)
+	svc.Observe(ctx, say("canal", "nightbot", "twitter.com/x"))
+	svc.Observe(ctx, say("canal", "ana", "!redes"))
+	clock.Advance(5*time.Second + time.Millisecond)
+	svc.Observe(ctx, say("canal", "nightbot", "twitter.com/x"))
+	if len(*conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, esperaba solo el del borde", *conflicts)
+	}
+}
+
+func TestConflictIgnoresUnrelatedMessages(t *testing.T) {
+	ctx := context.Background()
+	svc, _, clock, conflicts := newTestService(t, domain.BotConflictLog)
+
+	// un bot que habla sin comando previo, comandos que el bot no conoce y
+	// otro canal no generan conflictos
+	svc.Observe(ctx, say("canal", "nightbot", "recuerden seguir"))
+	svc.Observe(ctx, say("canal", "ana", "!uptime"))
+	svc.Observe(ctx, say("canal", "nightbot", "3h"))
+	svc.Observe(ctx, say("otro", "ana", "!discord"))
+	clock.Advance(time.Second)
+	svc.Observe(ctx, say("canal", "nightbot", "discord.gg/abc"))
+	svc.Observe(ctx, say("canal", "beto", "discord.gg/abc"))
+	if len(*conflicts) != 0 {
+		t.Fatalf("conflicts = %+v", *conflicts)
+	}
+}
+
+func TestConflictLearnSuppressesNextTime(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, clock, conflicts := newTestService(t, domain.BotConflictLearn)
+
+	if svc.Observe(ctx, say("canal", "ana", "!discord")) {
+		t.Fatal("el primer !discord no debería suprimirse")
+	}
+	clock.Advance(time.Second)
+	svc.Observe(ctx, say("canal", "nightbot", "discord.gg/abc"))
+	if len(*conflicts) != 1 || !(*conflicts)[0].Suppressed {
+		t.Fatalf("conflicts = %+v", *conflicts)
+	}
+	if repo.saved == nil || len(repo.saved.Suppressed) != 1 || repo.saved.Suppressed[0] != "discord" {
+		t.Fatalf("no se guardó lo aprendido: %+v", repo.saved)
+	}
+
+	if !svc.Observe(ctx, say("canal", "beto", "!discord")) {
+		t.Fatal("!discord aprendido no se suprimió")
+	}
+	if svc.Observe(ctx, say("canal", "beto", "!redes")) {
+		t.Fatal("se suprimió un comando sin conflicto")
+	}
+
+	// quitarlo de la lista vuelve a responderlo
+	settings := svc.Settings()
+	settings.Suppressed = nil
+	if _, err := svc.Update(ctx, settings); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if svc.Observe(ctx, say("canal", "beto", "!discord")) {
+		t.Fatal("!discord sigue suprimido tras quitarlo")
+	}
+}
+
+func TestConflictDisabled(t *testing.T) {
+	ctx := context.Background()
+	svc, _, clock, conflicts := newTestService(t, domain.BotConflictLearn)
+	settings := svc.Settings()
+	settings.Enabled = false
+	settings.Suppressed = []string{"discord"}
+	if _, err := svc.Update(ctx, settings); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if svc.Observe(ctx, say("canal", "ana", "!discord")) {
+		t.Fatal("desactivado no debería suprimir")
+	}
+	clock.Advance(time.Second)
+	svc.Observe(ctx, say("canal", "nightbot", "discord.gg/abc"))
+	if len(*conflicts) != 0 {
+		t.Fatalf("conflicts = %+v", *conflicts)
+	}
+}
+
+func TestSanitizeSettings(t *testing.T) {
+	got := sanitizeSettings(domain.BotConflictSettings{
+		Mode:          "otro",
+		Bots:          []string{"@NightBot", "nightbot", " "},
+		WindowSeconds: 600,
+		Suppressed:    []string{"!Discord", "discord"},
+	})
+	if got.Mode != domain.BotConflictLog || got.WindowSeconds != maxWindowSeconds {
+		t.Fatalf("settings = %+v", got)
+	}
+	if len(got.Bots) != 1 || got.Bots[0] != "nightbot" || len(got.Suppressed) != 1 || got.Suppressed[0] != "discord" {
+		t.Fatalf("listas = %v %v", got.Bots, got.Suppressed)
+	}
+	if got := sanitizeSettings(domain.BotConflictSettings{}); got.WindowSeconds != 5 {
+		t.Fatalf("ventana por defecto = %d", got.WindowSeconds)
+	}
+}
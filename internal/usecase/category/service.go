@@ -15,6 +15,15 @@ type Service struct {
 	twitch              domain.TwitchChannelService
 	twitchBroadcasterID string
 	kick                domain.KickStreamService
+	onChange            func(context.Context, Change)
+}
+
+// Change describe un cambio aplicado con el servicio; el campo que no cambió
+// queda vacío.
+type Change struct {
+	Platform domain.Platform
+	Title    string
+	Category string
 }
 
 type Config struct {
@@ -50,6 +59,17 @@ func (s *Service) SetTwitchService(svc domain.TwitchChannelService, broadcasterI
 	s.twitchBroadcasterID = strings.TrimSpace(broadcasterID)
 }
 
+// SetChangeHandler recibe cada título/categoría que se cambió con éxito (lo
+// usa Sync para copiar los cambios de Twitch a Kick).
+func (s *Service) SetChangeHandler(fn func(context.Context, Change)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
 func (s *Service) Search(ctx context.Context, platform domain.Platform, query string) ([]domain.CategoryOption, error) {
 	query = strings.TrimSpace(query)
 	if query == "" {
@@ -84,6 +104,46 @@ func (s *Service) Update(ctx context.Context, platform domain.Platform, category
 		return fmt.Errorf("nombre de categoría vacío")
 	}
 
+	var err error
+	switch platform {
+	case domain.PlatformTwitch:
+		s.mu.RLock()
+		twitchSvc := s.twitch
+		broadcasterID := s.twitchBroadcasterID
+		s.mu.RUnlock()
+		if twitchSvc == nil {
+			return fmt.Errorf("servicio de Twitch no disponible")
+		}
+		if broadcasterID == "" {
+			return fmt.Errorf("broadcasterID de Twitch vacío")
+		}
+		err = twitchSvc.UpdateCategory(ctx, broadcasterID, categoryName)
+	case domain.PlatformKick:
+		s.mu.RLock()
+		kickSvc := s.kick
+		s.mu.RUnlock()
+		if kickSvc == nil {
+			return fmt.Errorf("servicio de Kick no disponible")
+		}
+		err = kickSvc.SetCategory(ctx, categoryName)
+	default:
+		return fmt.Errorf("plataforma no soportada")
+	}
+	if err != nil {
+		return err
+	}
+	s.notify(ctx, Change{Platform: platform, Category: categoryName})
+	return nil
+}
+
+// UpdateTitle cambia el título del stream en la plataforma.
+func (s *Service) UpdateTitle(ctx context.Context, platform domain.Platform, title string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return fmt.Errorf("título vacío")
+	}
+
+	var err error
 	switch platform {
 	case domain.PlatformTwitch:
 		s.mu.RLock()
@@ -96,7 +156,7 @@ func (s *Service) Update(ctx context.Context, platform domain.Platform, category
 		if broadcasterID == "" {
 			return fmt.Errorf("broadcasterID de Twitch vacío")
 		}
-		return twitchSvc.UpdateCategory(ctx, broadcasterID, categoryName)
+		err = twitchSvc.SetTitle(ctx, broadcasterID, title)
 	case domain.PlatformKick:
 		s.mu.RLock()
 		kickSvc := s.kick
@@ -104,8 +164,22 @@ func (s *Service) Update(ctx context.Context, platform domain.Platform, category
 		if kickSvc == nil {
 			return fmt.Errorf("servicio de Kick no disponible")
 		}
-		return kickSvc.SetCategory(ctx, categoryName)
+		err = kickSvc.SetTitle(ctx, title)
 	default:
 		return fmt.Errorf("plataforma no soportada")
 	}
+	if err != nil {
+		return err
+	}
+	s.notify(ctx, Change{Platform: platform, Title: title})
+	return nil
+}
+
+func (s *Service) notify(ctx context.Context, change Change) {
+	s.mu.RLock()
+	fn := s.onChange
+	s.mu.RUnlock()
+	if fn != nil {
+		fn(ctx, change)
+	}
 }
@@ -0,0 +1,249 @@
+package category
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// DefaultSyncInterval es cada cuánto se consulta el estado de Twitch.
+	DefaultSyncInterval = time.Minute
+	// settleWindow es cuánto se ignoran las lecturas que contradicen un cambio
+	// recién hecho: Helix puede devolver el valor anterior durante un rato y
+	// eso no se debe copiar a Kick.
+	settleWindow = 2 * time.Minute
+)
+
+// StatusSnapshotter devuelve el estado de los streams (status.Resolver).
+type StatusSnapshotter interface {
+	Snapshot(ctx context.Context) []domain.StreamStatus
+}
+
+// Sync copia a Kick los cambios de título/categoría de Twitch. Los detecta de
+// dos formas: al instante si se hicieron con Service (dashboard) y con Run,
+// que consulta el estado cada tanto (cambios desde !category o desde Twitch).
+//
+// Para no entrar en bucle solo reacciona a cambios de Twitch, no vuelve a
+// mandar a Kick lo que Kick ya tiene y la primera lectura solo sirve de base.
+type Sync struct {
+	svc  *Service
+	repo domain.StreamSyncSettingsRepository
+	now  func() time.Time
+
+	mu      sync.Mutex
+	cfg     domain.StreamSyncSettings
+	twitch  streamInfo
+	seen    bool
+	kick    streamInfo
+	settled time.Time
+}
+
+type streamInfo struct {
+	title    string
+	category string
+}
+
+func NewSync(svc *Service, repo domain.StreamSyncSettingsRepository) *Sync {
+	return &Sync{
+		svc:  svc,
+		repo: repo,
+		now:  time.Now,
+		cfg:  domain.DefaultStreamSyncSettings(),
+	}
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Sync) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetStreamSyncSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = *stored
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Sync) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Sync) Settings() domain.StreamSyncSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// Update guarda y aplica la configuración. Al activarla la próxima lectura
+// vuelve a ser la base, así no se copia algo que cambió mientras estaba
+// apagada.
+func (s *Sync) Update(ctx context.Context, settings domain.StreamSyncSettings) (domain.StreamSyncSettings, error) {
+	s.mu.Lock()
+	if settings.Enabled && !s.cfg.Enabled {
+		s.seen = false
+		s.settled = time.Time{}
+	}
+	s.cfg = settings
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetStreamSyncSettings(ctx, settings); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
+
+// Run consulta el estado cada interval hasta que se cancele ctx. Mientras la
+// sincronización está apagada no llama a las APIs.
+func (s *Sync) Run(ctx context.Context, status StatusSnapshotter, interval time.Duration) {
+	if s == nil || status == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.Settings().Enabled {
+				continue
+			}
+			callCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			s.Observe(callCtx, status.Snapshot(callCtx))
+			cancel()
+		}
+	}
+}
+
+// Observe procesa una lectura del estado de las plataformas.
+func (s *Sync) Observe(ctx context.Context, statuses []domain.StreamStatus) {
+	var twitch *domain.StreamStatus
+	for i := range statuses {
+		switch statuses[i].Platform {
+		case domain.PlatformTwitch:
+			twitch = &statuses[i]
+		case domain.PlatformKick:
+			s.mu.Lock()
+			s.kick = streamInfo{title: statuses[i].Title, category: statuses[i].GameTitle}
+			s.mu.Unlock()
+		}
+	}
+	if twitch == nil {
+		return
+	}
+
+	current := streamInfo{title: twitch.Title, category: twitch.GameTitle}
+	s.mu.Lock()
+	if !s.cfg.Enabled {
+		s.mu.Unlock()
+		return
+	}
+	if !s.seen {
+		s.seen = true
+		s.twitch = current
+		s.mu.Unlock()
+		return
+	}
+	if s.now().Before(s.settled) {
+		s.mu.Unlock()
+		return
+	}
+	var changed streamInfo
+	if !sameTitle(current.title, s.twitch.title) {
+		changed.title = current.title
+	}
+	if !sameCategory(current.category, s.twitch.category) {
+		changed.category = current.category
+	}
+	s.twitch = current
+	s.mu.Unlock()
+
+	s.mirror(ctx, changed)
+}
+
+// HandleChange recibe los cambios hechos con Service (ver SetChangeHandler).
+func (s *Sync) HandleChange(ctx context.Context, change Change) {
+	s.mu.Lock()
+	switch change.Platform {
+	case domain.PlatformKick:
+		// incluye los que hace el propio Sync; solo se recuerdan
+		if change.Title != "" {
+			s.kick.title = change.Title
+		}
+		if change.Category != "" {
+			s.kick.category = change.Category
+		}
+		s.mu.Unlock()
+		return
+	case domain.PlatformTwitch:
+		if !s.cfg.Enabled {
+			s.mu.Unlock()
+			return
+		}
+	default:
+		s.mu.Unlock()
+		return
+	}
+	if change.Title != "" {
+		s.twitch.title = change.Title
+	}
+	if change.Category != "" {
+		s.twitch.category = change.Category
+	}
+	s.seen = true
+	s.settled = s.now().Add(settleWindow)
+	s.mu.Unlock()
+
+	s.mirror(ctx, streamInfo{title: change.Title, category: change.Category})
+}
+
+// mirror manda a Kick los campos de changed que estén activos y que Kick no
+// tenga ya.
+func (s *Sync) mirror(ctx context.Context, changed streamInfo) {
+	s.mu.Lock()
+	cfg := s.cfg
+	kick := s.kick
+	s.mu.Unlock()
+	if !cfg.Enabled || s.svc == nil {
+		return
+	}
+
+	if cfg.Title && strings.TrimSpace(changed.title) != "" && !sameTitle(changed.title, kick.title) {
+		if err := s.svc.UpdateTitle(ctx, domain.PlatformKick, changed.title); err != nil {
+			log.Printf("stream-sync: no pude copiar el título a Kick: %v", err)
+		} else {
+			log.Printf("stream-sync: título copiado a Kick: %q", changed.title)
+		}
+	}
+	if cfg.Category && strings.TrimSpace(changed.category) != "" && !sameCategory(changed.category, kick.category) {
+		if err := s.svc.Update(ctx, domain.PlatformKick, changed.category); err != nil {
+			log.Printf("stream-sync: no pude copiar la categoría a Kick: %v", err)
+		} else {
+			log.Printf("stream-sync: categoría copiada a Kick: %q", changed.category)
+		}
+	}
+}
+
+func sameTitle(a, b string) bool {
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
+
+// sameCategory ignora mayúsculas: Kick y Twitch no escriben igual algunos
+// nombres.
+func sameCategory(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,190 @@
+package category
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// fakeTwitch implementa solo lo que usa Service; el resto de la interfaz
+// queda sin implementar.
+type fakeTwitch struct {
+	domain.TwitchChannelService
+}
+
+func (fakeTwitch) SetTitle(context.Context, string, string) error       { return nil }
+func (fakeTwitch) UpdateCategory(context.Context, string, string) error { return nil }
+
+type fakeKick struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (k *fakeKick) SetTitle(_ context.Context, title string) error {
+	k.record("title:" + title)
+	return nil
+}
+
+func (k *fakeKick) SetCategory(_ context.Context, name string) error {
+	k.record("category:" + name)
+	return nil
+}
+
+func (k *fakeKick) SearchCategories(context.Context, string) ([]domain.CategoryOption, error) {
+	return nil, nil
+}
+
+func (k *fakeKick) GetStreamStatus(context.Context, int) (domain.StreamStatus, error) {
+	return domain.StreamStatus{}, nil
+}
+
+func (k *fakeKick) record(call string) {
+	k.mu.Lock()
+	k.calls = append(k.calls, call)
+	k.mu.Unlock()
+}
+
+func (k *fakeKick) take() []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	calls := k.calls
+	k.calls = nil
+	return calls
+}
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestSync arma Service + Sync conectados igual que en el runtime.
+func newTestSync(t *testing.T, settings domain.StreamSyncSettings) (*Sync, *Service, *fakeKick, *fakeClock) {
+	t.Helper()
+	kick := &fakeKick{}
+	svc := NewService(Config{Twitch: fakeTwitch{}, TwitchBroadcasterID: "123", Kick: kick})
+	streamSync := NewSync(svc, nil)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)}
+	streamSync.now = clock.Now
+	svc.SetChangeHandler(streamSync.HandleChange)
+	if _, err := streamSync.Update(context.Background(), settings); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return streamSync, svc, kick, clock
+}
+
+func statuses(twitchTitle, twitchGame, kickTitle, kickGame string) []domain.StreamStatus {
+	return []domain.StreamStatus{
+		{Platform: domain.PlatformTwitch, Title: twitchTitle, GameTitle: twitchGame},
+		{Platform: domain.PlatformKick, Title: kickTitle, GameTitle: kickGame},
+	}
+}
+
+var enabledSync = domain.StreamSyncSettings{Enabled: true, Title: true, Category: true}
+
+func TestSyncMirrorsDashboardChanges(t *testing.T) {
+	ctx := context.Background()
+	_, svc, kick, _ := newTestSync(t, enabledSync)
+
+	if err := svc.UpdateTitle(ctx, domain.PlatformTwitch, "Jugando con chat"); err != nil {
+		t.Fatalf("UpdateTitle: %v", err)
+	}
+	if err := svc.Update(ctx, domain.PlatformTwitch, "Just Chatting"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	want := []string{"title:Jugando con chat", "category:Just Chatting"}
+	if got := kick.take(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("kick calls = %q, want %q", got, want)
+	}
+
+	// lo que se copió a Kick no vuelve a Twitch ni se repite
+	if err := svc.Update(ctx, domain.PlatformTwitch, "just chatting"); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("kick already had the category, got %q", got)
+	}
+}
+
+func TestSyncMirrorsPolledChanges(t *testing.T) {
+	ctx := context.Background()
+	streamSync, _, kick, _ := newTestSync(t, enabledSync)
+
+	// la primera lectura es solo la base
+	streamSync.Observe(ctx, statuses("Título A", "Minecraft", "Otro", "Otro juego"))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("baseline mirrored %q", got)
+	}
+
+	streamSync.Observe(ctx, statuses("Título B", "Minecraft", "Otro", "Otro juego"))
+	if got := kick.take(); len(got) != 1 || got[0] != "title:Título B" {
+		t.Fatalf("kick calls = %q", got)
+	}
+
+	// un cambio solo en Kick no se copia a ningún lado
+	streamSync.Observe(ctx, statuses("Título B", "Minecraft", "Cambio en Kick", "Otro juego"))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("kick-only change mirrored %q", got)
+	}
+}
+
+func TestSyncIgnoresStaleReadsAfterDirectChange(t *testing.T) {
+	ctx := context.Background()
+	streamSync, svc, kick, clock := newTestSync(t, enabledSync)
+
+	streamSync.Observe(ctx, statuses("Viejo", "Minecraft", "Viejo", "Minecraft"))
+	if err := svc.UpdateTitle(ctx, domain.PlatformTwitch, "Nuevo"); err != nil {
+		t.Fatalf("UpdateTitle: %v", err)
+	}
+	kick.take()
+
+	// Helix todavía devuelve el título viejo: no se copia de vuelta
+	streamSync.Observe(ctx, statuses("Viejo", "Minecraft", "Nuevo", "Minecraft"))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("stale read mirrored %q", got)
+	}
+
+	clock.Advance(settleWindow)
+	streamSync.Observe(ctx, statuses("Nuevo", "Minecraft", "Nuevo", "Minecraft"))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("settled read mirrored %q", got)
+	}
+}
+
+func TestSyncFieldToggles(t *testing.T) {
+	ctx := context.Background()
+	_, svc, kick, _ := newTestSync(t, domain.StreamSyncSettings{Enabled: true, Title: false, Category: true})
+
+	svc.UpdateTitle(ctx, domain.PlatformTwitch, "Sin copiar")
+	svc.Update(ctx, domain.PlatformTwitch, "Minecraft")
+	if got := kick.take(); len(got) != 1 || got[0] != "category:Minecraft" {
+		t.Fatalf("kick calls = %q", got)
+	}
+}
+
+func TestSyncDisabled(t *testing.T) {
+	ctx := context.Background()
+	streamSync, svc, kick, _ := newTestSync(t, domain.DefaultStreamSyncSettings())
+
+	svc.UpdateTitle(ctx, domain.PlatformTwitch, "Título")
+	streamSync.Observe(ctx, statuses("A", "Minecraft", "", ""))
+	streamSync.Observe(ctx, statuses("B", "Fortnite", "", ""))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("disabled sync mirrored %q", got)
+	}
+
+	// al activarla, la siguiente lectura vuelve a ser la base
+	if _, err := streamSync.Update(ctx, enabledSync); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	streamSync.Observe(ctx, statuses("C", "Fortnite", "", ""))
+	if got := kick.take(); len(got) != 0 {
+		t.Fatalf("first read after enabling mirrored %q", got)
+	}
+	streamSync.Observe(ctx, statuses("D", "Fortnite", "", ""))
+	if got := kick.take(); len(got) != 1 || got[0] != "title:D" {
+		t.Fatalf("kick calls = %q", got)
+	}
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type AccountAgeCommand struct {
+	twitch domain.TwitchChannelService
+	now    func() time.Time
+}
+
+func NewAccountAgeCommand(twitch domain.TwitchChannelService) *AccountAgeCommand {
+	return &AccountAgeCommand{
+		twitch: twitch,
+		now:    time.Now,
+	}
+}
+
+func (c *AccountAgeCommand) Name() string {
+	return "accountage"
+}
+
+func (c *AccountAgeCommand) Aliases() []string {
+	return []string{"age"}
+}
+
+func (c *AccountAgeCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch
+}
+
+func (c *AccountAgeCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+
+	if c.twitch == nil {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"⚠️ El servicio de Twitch no está disponible.")
+	}
+
+	login := msg.LoginName()
+	if len(cmdCtx.Args) > 0 {
+		login = cmdCtx.Args[0]
+	}
+	login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+	if login == "" {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"Uso: !accountage [usuario]")
+	}
+
+	user, err := c.twitch.GetUserByLogin(ctx, login)
+	if err != nil {
+		log.Printf("accountage: error consultando %s: %v", login, err)
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"⚠️ No pude consultar la cuenta en Twitch.")
+	}
+	if user == nil {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			fmt.Sprintf("⚠️ No encontré al usuario %s en Twitch.", login))
+	}
+
+	name := user.DisplayName
+	if name == "" {
+		name = user.Login
+	}
+
+	reply := fmt.Sprintf("%s creó su cuenta hace %s (%s).",
+		name,
+		HumanizeAccountAge(user.CreatedAt, c.now()),
+		user.CreatedAt.Format("2006-01-02"),
+	)
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, reply)
+}
+
+// HumanizeAccountAge describe en español el tiempo transcurrido entre from y now
+// usando años, meses y días de calendario (o horas/minutos si es menos de un día).
+func HumanizeAccountAge(from, now time.Time) string {
+	if from.IsZero() || !now.After(from) {
+		return "menos de un minuto"
+	}
+
+	from = from.In(now.Location())
+
+	years := now.Year() - from.Year()
+	months := int(now.Month()) - int(from.Month())
+	days := now.Day() - from.Day()
+
+	if now.Hour()*3600+now.Minute()*60+now.Second() < from.Hour()*3600+from.Minute()*60+from.Second() {
+		days--
+	}
+	if days < 0 {
+		// días del mes anterior a now
+		days += time.Date(now.Year(), now.Month(), 0, 0, 0, 0, 0, now.Location()).Day()
+		months--
+	}
+	if months < 0 {
+		months += 12
+		years--
+	}
+
+	var parts []string
+	if years > 0 {
+		parts = append(parts, pluralize(years, "año", "años"))
+	}
+	if months > 0 {
+		parts = append(parts, pluralize(months, "mes", "meses"))
+	}
+	if days > 0 {
+		parts = append(parts, pluralize(days, "día", "días"))
+	}
+
+	if len(parts) == 0 {
+		elapsed := now.Sub(from)
+		switch {
+		case elapsed >= time.Hour:
+			return pluralize(int(elapsed.Hours()), "hora", "horas")
+		case elapsed >= time.Minute:
+			return pluralize(int(elapsed.Minutes()), "minuto", "minutos")
+		default:
+			return "menos de un minuto"
+		}
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " y " + parts[len(parts)-1]
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestHumanizeAccountAge(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		from time.Time
+		want string
+	}{
+		{"zero", time.Time{}, "menos de un minuto"},
+		{"future", now.Add(time.Hour), "menos de un minuto"},
+		{"seconds", now.Add(-30 * time.Second), "menos de un minuto"},
+		{"one minute", now.Add(-time.Minute), "1 minuto"},
+		{"minutes", now.Add(-45 * time.Minute), "45 minutos"},
+		{"hours", now.Add(-5 * time.Hour), "5 horas"},
+		{"one day", now.AddDate(0, 0, -1), "1 día"},
+		{"one year", now.AddDate(-1, 0, 0), "1 año"},
+		{"full", time.Date(2021, time.January, 10, 12, 0, 0, 0, time.UTC), "3 años, 2 meses y 5 días"},
+		{"month borrow", time.Date(2024, time.February, 20, 12, 0, 0, 0, time.UTC), "24 días"},
+		{"earlier hour same day", time.Date(2023, time.March, 15, 13, 0, 0, 0, time.UTC), "11 meses y 28 días"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeAccountAge(tt.from, now); got != tt.want {
+				t.Fatalf("HumanizeAccountAge = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountAgeCommandLookup(t *testing.T) {
+	created := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	twitch := &fakeTwitch{users: map[string]*domain.TwitchUser{
+		"alice": {ID: "1", Login: "alice", DisplayName: "Alice", CreatedAt: created},
+		"bob":   {ID: "2", Login: "bob", CreatedAt: created},
+	}}
+	cmd := NewAccountAgeCommand(twitch)
+	cmd.now = func() time.Time { return time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		user string
+		args []string
+		want string
+	}{
+		{"self", "Alice", nil, "Alice creó su cuenta hace 4 años (2020-05-01)."},
+		{"other with at", "alice", []string{"@bob"}, "bob creó su cuenta hace 4 años (2020-05-01)."},
+		{"unknown", "alice", []string{"nadie"}, "⚠️ No encontré al usuario nadie en Twitch."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &captureOut{}
+			if err := cmd.Handle(context.Background(), newCmdContext(twitchMessage(tt.user, "!accountage"), out, tt.args...)); err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if got := out.last(); got != tt.want {
+				t.Fatalf("reply = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountAgeCommandErrors(t *testing.T) {
+	out := &captureOut{}
+	cmd := NewAccountAgeCommand(&fakeTwitch{err: errors.New("helix caído")})
+	if err := cmd.Handle(context.Background(), newCmdContext(twitchMessage("alice", "!accountage"), out)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if !strings.Contains(out.last(), "No pude consultar") {
+		t.Fatalf("reply = %q, want lookup error", out.last())
+	}
+
+	out.reset()
+	if err := NewAccountAgeCommand(nil).Handle(context.Background(), newCmdContext(twitchMessage("alice", "!accountage"), out)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if !strings.Contains(out.last(), "no está disponible") {
+		t.Fatalf("reply = %q, want unavailable service", out.last())
+	}
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// Announcer envía anuncios oficiales de Twitch y, si no se puede (sin scope,
+// sin ID del bot o en otra plataforma), cae a un mensaje normal.
+type Announcer struct {
+	target AnnouncementTarget
+	out    domain.OutgoingMessagePort
+
+	warnOnce sync.Once
+}
+
+// AnnouncementTarget resuelve en el momento del envío el servicio con el token
+// del bot, el broadcasterID y el moderatorID (ID del bot).
+type AnnouncementTarget func() (svc domain.TwitchChannelService, broadcasterID, moderatorID string)
+
+func NewAnnouncer(target AnnouncementTarget, out domain.OutgoingMessagePort) *Announcer {
+	return &Announcer{target: target, out: out}
+}
+
+func (a *Announcer) Announce(ctx context.Context, platform domain.Platform, channelID, text, color string) error {
+	if platform == domain.PlatformTwitch && a.target != nil {
+		svc, broadcasterID, moderatorID := a.target()
+		if svc != nil && broadcasterID != "" && moderatorID != "" {
+			err := svc.SendAnnouncement(ctx, broadcasterID, moderatorID, text, color)
+			if err == nil {
+				return nil
+			}
+			if !errors.Is(err, domain.ErrTwitchMissingScope) {
+				return err
+			}
+			a.warnOnce.Do(func() {
+				log.Printf("announce: el bot no tiene moderator:manage:announcements o no es moderador; se enviarán mensajes normales (%v)", err)
+			})
+		}
+	}
+	if a.out == nil {
+		return nil
+	}
+	return a.out.SendMessage(ctx, platform, channelID, text)
+}
+
+var announcementColors = map[string]struct{}{
+	"primary": {},
+	"blue":    {},
+	"green":   {},
+	"orange":  {},
+	"purple":  {},
+}
+
+type AnnounceCommand struct {
+	announcer *Announcer
+}
+
+func NewAnnounceCommand(announcer *Announcer) *AnnounceCommand {
+	return &AnnounceCommand{announcer: announcer}
+}
+
+func (c *AnnounceCommand) Name() string {
+	return "announce"
+}
+
+func (c *AnnounceCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c *AnnounceCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *AnnounceCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if !msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod {
+		return nil
+	}
+
+	args := cmdCtx.Args
+	color := ""
+	if len(args) > 0 && strings.HasPrefix(strings.ToLower(args[0]), "color:") {
+		color = strings.ToLower(strings.TrimSpace(args[0][len("color:"):]))
+		if _, ok := announcementColors[color]; !ok {
+			return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+				"⚠️ Colores válidos: primary, blue, green, orange, purple.")
+		}
+		args = args[1:]
+	}
+
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"Uso: !announce [color:blue] <texto>")
+	}
+
+	if err := c.announcer.Announce(ctx, msg.Platform, msg.ChannelID, text, color); err != nil {
+		log.Printf("announce command: %v", err)
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"⚠️ No pude enviar el anuncio.")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestAnnouncerUsesHelix(t *testing.T) {
+	twitch := &fakeTwitch{}
+	out := &captureOut{}
+	announcer := NewAnnouncer(func() (domain.TwitchChannelService, string, string) {
+		return twitch, "broadcaster", "bot"
+	}, out)
+
+	if err := announcer.Announce(context.Background(), domain.PlatformTwitch, "canal", "hola", "blue"); err != nil {
+		t.Fatalf("Announce: %v", err)
+	}
+	if got := twitch.recorded(); len(got) != 1 || got[0] != "announce:broadcaster/bot/blue/hola" {
+		t.Fatalf("helix calls = %q", got)
+	}
+	if len(out.texts()) != 0 {
+		t.Fatalf("announcement also sent as chat: %q", out.texts())
+	}
+}
+
+func TestAnnouncerFallsBackToChat(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform domain.Platform
+		target   AnnouncementTarget
+	}{
+		{"kick", domain.PlatformKick, func() (domain.TwitchChannelService, string, string) {
+			return &fakeTwitch{}, "b", "m"
+		}},
+		{"no service", domain.PlatformTwitch, func() (domain.TwitchChannelService, string, string) {
+			return nil, "b", "m"
+		}},
+		{"no bot id", domain.PlatformTwitch, func() (domain.TwitchChannelService, string, string) {
+			return &fakeTwitch{}, "b", ""
+		}},
+		{"missing scope", domain.PlatformTwitch, func() (domain.TwitchChannelService, string, string) {
+			return &fakeTwitch{announceErr: fmt.Errorf("403: %w", domain.ErrTwitchMissingScope)}, "b", "m"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &captureOut{}
+			if err := NewAnnouncer(tt.target, out).Announce(context.Background(), tt.platform, "canal", "hola", ""); err != nil {
+				t.Fatalf("Announce: %v", err)
+			}
+			if got := out.messages(); len(got) != 1 || got[0].Text != "hola" || got[0].Platform != tt.platform {
+				t.Fatalf("sent %+v, want a plain chat message", got)
+			}
+		})
+	}
+}
+
+func TestAnnouncerReturnsOtherErrors(t *testing.T) {
+	out := &captureOut{}
+	announcer := NewAnnouncer(func() (domain.TwitchChannelService, string, string) {
+		return &fakeTwitch{announceErr: errors.New("helix 500")}, "b", "m"
+	}, out)
+	if err := announcer.Announce(context.Background(), domain.PlatformTwitch, "canal", "hola", ""); err == nil {
+		t.Fatalf("helix error swallowed")
+	}
+	if len(out.texts()) != 0 {
+		t.Fatalf("fell back on a non-scope error: %q", out.texts())
+	}
+}
+
+func TestAnnounceCommand(t *testing.T) {
+	twitch := &fakeTwitch{}
+	announcer := NewAnnouncer(func() (domain.TwitchChannelService, string, string) {
+		return twitch, "b", "m"
+	}, nil)
+	cmd := NewAnnounceCommand(announcer)
+
+	run := func(mod bool, args ...string) string {
+		msg := twitchMessage("alice", "!announce")
+		msg.IsPlatformMod = mod
+		out := &captureOut{}
+		if err := cmd.Handle(context.Background(), newCmdContext(msg, out, args...)); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+		return out.last()
+	}
+
+	if reply := run(true, "color:GREEN", "gran", "sorteo"); reply != "" {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+	if got := twitch.recorded(); len(got) != 1 || got[0] != "announce:b/m/green/gran sorteo" {
+		t.Fatalf("helix calls = %q", got)
+	}
+	if reply := run(true, "color:rosa", "hola"); !strings.Contains(reply, "Colores válidos") {
+		t.Fatalf("invalid color reply = %q", reply)
+	}
+	if reply := run(true); !strings.HasPrefix(reply, "Uso:") {
+		t.Fatalf("usage reply = %q", reply)
+	}
+	if run(false, "hola"); len(twitch.recorded()) != 1 {
+		t.Fatalf("viewer sent an announcement")
+	}
+}
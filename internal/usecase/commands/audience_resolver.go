@@ -3,15 +3,71 @@ package commands
 import (
 	"context"
 	"strings"
+	"sync"
+	"time"
 
 	"zhatBot/internal/domain"
 )
 
+// audienceCacheTTL es cuánto se recuerda cada respuesta de Helix: un comando
+// repetido no vuelve a gastar cuota.
+const audienceCacheTTL = 5 * time.Minute
+
+// messageAudience responde con los flags del mensaje. Es lo que se usa cuando
+// la plataforma no tiene resolver o no pudo contestar.
+type messageAudience struct{}
+
+func (messageAudience) IsFollower(context.Context, domain.Message) (bool, error) {
+	return false, nil
+}
+
+func (messageAudience) FollowedAt(context.Context, domain.Message) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (messageAudience) IsSubscriber(_ context.Context, msg domain.Message) (bool, error) {
+	return msg.IsSubscriber, nil
+}
+
+func (messageAudience) IsModerator(_ context.Context, msg domain.Message) (bool, error) {
+	return msg.IsPlatformMod || msg.IsPlatformAdmin || msg.IsPlatformOwner, nil
+}
+
+type audienceCheck string
+
+const (
+	audienceFollow     audienceCheck = "follow"
+	audienceSubscriber audienceCheck = "subscriber"
+	audienceModerator  audienceCheck = "moderator"
+)
+
+type audienceKey struct {
+	check  audienceCheck
+	userID string
+}
+
+type audienceEntry struct {
+	ok         bool
+	followedAt time.Time
+	expires    time.Time
+}
+
+// TwitchAudienceResolver consulta Helix con el token del streamer. Los
+// mensajes de otras plataformas y los errores de Helix (p. ej. falta un
+// scope) caen en los flags del mensaje; el error se devuelve igual para que
+// quien llama lo registre.
 type TwitchAudienceResolver struct {
 	svc           domain.TwitchChannelService
 	broadcasterID string
+	fallback      messageAudience
+
+	mu    sync.Mutex
+	cache map[audienceKey]audienceEntry
+	now   func() time.Time
 }
 
+var _ domain.AudienceService = (*TwitchAudienceResolver)(nil)
+
 func NewTwitchAudienceResolver(svc domain.TwitchChannelService, broadcasterID string) CommandAudienceResolver {
 	if svc == nil || strings.TrimSpace(broadcasterID) == "" {
 		return nil
@@ -19,12 +75,101 @@ func NewTwitchAudienceResolver(svc domain.TwitchChannelService, broadcasterID st
 	return &TwitchAudienceResolver{
 		svc:           svc,
 		broadcasterID: strings.TrimSpace(broadcasterID),
+		cache:         make(map[audienceKey]audienceEntry),
+		now:           time.Now,
 	}
 }
 
 func (r *TwitchAudienceResolver) IsFollower(ctx context.Context, msg domain.Message) (bool, error) {
-	if r == nil || msg.Platform != domain.PlatformTwitch {
-		return false, nil
+	followedAt, err := r.FollowedAt(ctx, msg)
+	return !followedAt.IsZero(), err
+}
+
+func (r *TwitchAudienceResolver) FollowedAt(ctx context.Context, msg domain.Message) (time.Time, error) {
+	if !r.handles(msg) {
+		return r.fallback.FollowedAt(ctx, msg)
+	}
+	key := audienceKey{check: audienceFollow, userID: msg.UserID}
+	if entry, ok := r.cached(key); ok {
+		return entry.followedAt, nil
+	}
+	followedAt, err := r.svc.FollowedAt(ctx, r.broadcasterID, msg.UserID)
+	if err != nil {
+		fallback, _ := r.fallback.FollowedAt(ctx, msg)
+		return fallback, err
+	}
+	r.store(key, audienceEntry{ok: !followedAt.IsZero(), followedAt: followedAt})
+	return followedAt, nil
+}
+
+func (r *TwitchAudienceResolver) IsSubscriber(ctx context.Context, msg domain.Message) (bool, error) {
+	if !r.handles(msg) {
+		return r.fallback.IsSubscriber(ctx, msg)
+	}
+	return r.check(ctx, msg, audienceSubscriber, r.svc.IsSubscriber, r.fallback.IsSubscriber)
+}
+
+// IsModerator cuenta al dueño del canal como moderador: Helix no lo lista.
+func (r *TwitchAudienceResolver) IsModerator(ctx context.Context, msg domain.Message) (bool, error) {
+	if !r.handles(msg) {
+		return r.fallback.IsModerator(ctx, msg)
+	}
+	if msg.UserID == r.broadcasterID {
+		return true, nil
+	}
+	return r.check(ctx, msg, audienceModerator, r.svc.IsModerator, r.fallback.IsModerator)
+}
+
+func (r *TwitchAudienceResolver) check(
+	ctx context.Context,
+	msg domain.Message,
+	kind audienceCheck,
+	lookup func(ctx context.Context, broadcasterID, userID string) (bool, error),
+	fallback func(ctx context.Context, msg domain.Message) (bool, error),
+) (bool, error) {
+	key := audienceKey{check: kind, userID: msg.UserID}
+	if entry, ok := r.cached(key); ok {
+		return entry.ok, nil
+	}
+	ok, err := lookup(ctx, r.broadcasterID, msg.UserID)
+	if err != nil {
+		flag, _ := fallback(ctx, msg)
+		return flag, err
+	}
+	r.store(key, audienceEntry{ok: ok})
+	return ok, nil
+}
+
+func (r *TwitchAudienceResolver) handles(msg domain.Message) bool {
+	return r != nil && msg.Platform == domain.PlatformTwitch && strings.TrimSpace(msg.UserID) != ""
+}
+
+func (r *TwitchAudienceResolver) cached(key audienceKey) (audienceEntry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.cache[key]
+	if !ok {
+		return audienceEntry{}, false
+	}
+	if r.now().After(entry.expires) {
+		delete(r.cache, key)
+		return audienceEntry{}, false
+	}
+	return entry, true
+}
+
+func (r *TwitchAudienceResolver) store(key audienceKey, entry audienceEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	entry.expires = now.Add(audienceCacheTTL)
+	r.cache[key] = entry
+	// se limpia lo vencido de vez en cuando para que no crezca sin límite
+	if len(r.cache) > 1024 {
+		for k, e := range r.cache {
+			if now.After(e.expires) {
+				delete(r.cache, k)
+			}
+		}
 	}
-	return r.svc.IsFollower(ctx, r.broadcasterID, msg.UserID)
 }
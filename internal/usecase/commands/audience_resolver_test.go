@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// fakeHelixAudience contesta los chequeos de audiencia de Helix por userID.
+type fakeHelixAudience struct {
+	domain.TwitchChannelService
+
+	follows map[string]time.Time
+	subs    map[string]bool
+	mods    map[string]bool
+	err     error
+	calls   int
+}
+
+func (f *fakeHelixAudience) FollowedAt(_ context.Context, _, userID string) (time.Time, error) {
+	f.calls++
+	return f.follows[userID], f.err
+}
+
+func (f *fakeHelixAudience) IsSubscriber(_ context.Context, _, userID string) (bool, error) {
+	f.calls++
+	return f.subs[userID], f.err
+}
+
+func (f *fakeHelixAudience) IsModerator(_ context.Context, _, userID string) (bool, error) {
+	f.calls++
+	return f.mods[userID], f.err
+}
+
+func newTestAudience(helix *fakeHelixAudience) *TwitchAudienceResolver {
+	return NewTwitchAudienceResolver(helix, " id-dueño ").(*TwitchAudienceResolver)
+}
+
+func TestNewTwitchAudienceResolverNeedsChannel(t *testing.T) {
+	if NewTwitchAudienceResolver(nil, "1") != nil || NewTwitchAudienceResolver(&fakeHelixAudience{}, " ") != nil {
+		t.Fatal("se armó un resolver sin servicio o sin canal")
+	}
+}
+
+func TestTwitchAudienceAsksHelix(t *testing.T) {
+	ctx := context.Background()
+	followed := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	helix := &fakeHelixAudience{
+		follows: map[string]time.Time{"id-ana": followed},
+		subs:    map[string]bool{"id-ana": true},
+		mods:    map[string]bool{"id-luis": true},
+	}
+	audience := newTestAudience(helix)
+
+	// los flags falsificados no cuentan: manda Helix
+	fake := twitchMessage("beto", "!x")
+	fake.IsSubscriber = true
+	fake.IsPlatformMod = true
+	if ok, err := audience.IsSubscriber(ctx, fake); ok || err != nil {
+		t.Fatalf("sub falso = %v, %v", ok, err)
+	}
+	if ok, err := audience.IsModerator(ctx, fake); ok || err != nil {
+		t.Fatalf("mod falso = %v, %v", ok, err)
+	}
+
+	ana := twitchMessage("ana", "!x")
+	if at, err := audience.FollowedAt(ctx, ana); !at.Equal(followed) || err != nil {
+		t.Fatalf("FollowedAt = %v, %v", at, err)
+	}
+	if ok, _ := audience.IsFollower(ctx, ana); !ok {
+		t.Fatal("ana sigue el canal")
+	}
+	if ok, _ := audience.IsFollower(ctx, fake); ok {
+		t.Fatal("beto no sigue el canal")
+	}
+	if ok, _ := audience.IsSubscriber(ctx, ana); !ok {
+		t.Fatal("ana es sub")
+	}
+	if ok, _ := audience.IsModerator(ctx, twitchMessage("luis", "!x")); !ok {
+		t.Fatal("luis es mod")
+	}
+
+	// el dueño no figura en Get Moderators pero cuenta como mod
+	before := helix.calls
+	if ok, _ := audience.IsModerator(ctx, twitchMessage("dueño", "!x")); !ok || helix.calls != before {
+		t.Fatalf("dueño = %v, pedidos = %d", ok, helix.calls-before)
+	}
+}
+
+func TestTwitchAudienceCachesAnswers(t *testing.T) {
+	ctx := context.Background()
+	helix := &fakeHelixAudience{subs: map[string]bool{"id-ana": true}}
+	audience := newTestAudience(helix)
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	audience.now = func() time.Time { return now }
+
+	ana := twitchMessage("ana", "!x")
+	for range 3 {
+		audience.IsSubscriber(ctx, ana)
+	}
+	if helix.calls != 1 {
+		t.Fatalf("pedidos = %d, esperaba 1 por la caché", helix.calls)
+	}
+	// la caché distingue el chequeo
+	audience.IsModerator(ctx, ana)
+	if helix.calls != 2 {
+		t.Fatalf("pedidos = %d; la caché mezcló sub con mod", helix.calls)
+	}
+
+	now = now.Add(audienceCacheTTL + time.Second)
+	helix.subs["id-ana"] = false
+	if ok, _ := audience.IsSubscriber(ctx, ana); ok || helix.calls != 3 {
+		t.Fatalf("sub = %v, pedidos = %d; la caché no venció", ok, helix.calls)
+	}
+}
+
+func TestTwitchAudienceFallsBackToFlags(t *testing.T) {
+	ctx := context.Background()
+	helixErr := errors.New("missing scope channel:read:subscriptions")
+	helix := &fakeHelixAudience{err: helixErr}
+	audience := newTestAudience(helix)
+
+	sub := twitchMessage("eva", "!x")
+	sub.IsSubscriber = true
+	if ok, err := audience.IsSubscriber(ctx, sub); !ok || !errors.Is(err, helixErr) {
+		t.Fatalf("con Helix caído = %v, %v; esperaba el flag y el error", ok, err)
+	}
+	if ok, err := audience.IsFollower(ctx, sub); ok || !errors.Is(err, helixErr) {
+		t.Fatalf("follow con Helix caído = %v, %v", ok, err)
+	}
+	// un error no se guarda en la caché
+	helix.err = nil
+	if ok, err := audience.IsSubscriber(ctx, sub); ok || err != nil {
+		t.Fatalf("después del error = %v, %v; esperaba la respuesta de Helix", ok, err)
+	}
+
+	// otras plataformas y mensajes sin userID usan los flags sin preguntar
+	before := helix.calls
+	kickMod := kickMessage("ana", "!x")
+	kickMod.IsPlatformMod = true
+	if ok, err := audience.IsModerator(ctx, kickMod); !ok || err != nil {
+		t.Fatalf("mod de kick = %v, %v", ok, err)
+	}
+	anon := twitchMessage("ana", "!x")
+	anon.UserID = ""
+	anon.IsSubscriber = true
+	if ok, _ := audience.IsSubscriber(ctx, anon); !ok || helix.calls != before {
+		t.Fatalf("sin userID = %v, pedidos = %d", ok, helix.calls-before)
+	}
+}
+
+func TestCustomPermissionsUseResolver(t *testing.T) {
+	h := newRouterHarness(t,
+		&domain.CustomCommand{Name: "subs", Response: "solo subs", Permissions: []domain.CommandAccessRole{domain.CommandAccessSubscribers}},
+		&domain.CustomCommand{Name: "fans", Response: "solo seguidores", Permissions: []domain.CommandAccessRole{domain.CommandAccessFollowers}},
+	)
+	helix := &fakeHelixAudience{
+		follows: map[string]time.Time{"id-ana": time.Now()},
+		subs:    map[string]bool{"id-ana": true},
+	}
+	h.mgr.SetAudienceResolver(newTestAudience(helix))
+
+	spoofed := twitchMessage("beto", "!subs")
+	spoofed.IsSubscriber = true
+	if got := h.send(t, spoofed); len(got) != 0 {
+		t.Fatalf("un flag falsificado pasó: %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!subs")); len(got) != 1 || got[0] != "solo subs" {
+		t.Fatalf("sub = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!fans")); len(got) != 1 || got[0] != "solo seguidores" {
+		t.Fatalf("seguidor = %q", got)
+	}
+	if got := h.send(t, twitchMessage("beto", "!fans")); len(got) != 0 {
+		t.Fatalf("no seguidor = %q", got)
+	}
+}
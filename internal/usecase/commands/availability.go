@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	CommandStatusActive           = "active"
+	CommandStatusInactivePlatform = "inactive_platform_unavailable"
+)
+
+// PlatformAvailability indica si hoy hay una conexión capaz de responder en
+// la plataforma.
+type PlatformAvailability func(domain.Platform) bool
+
+// SetPlatformAvailability configura cómo saber qué plataformas están
+// conectadas. Sin esto todas se consideran disponibles.
+func (m *CustomCommandManager) SetPlatformAvailability(fn PlatformAvailability) {
+	if m == nil {
+		return
+	}
+	m.availMu.Lock()
+	defer m.availMu.Unlock()
+	m.available = fn
+}
+
+func (m *CustomCommandManager) platformAvailable(platform domain.Platform) bool {
+	m.availMu.RLock()
+	fn := m.available
+	m.availMu.RUnlock()
+	return fn == nil || fn(platform)
+}
+
+// unavailablePlatforms devuelve las plataformas restringidas del comando que
+// no están conectadas. Los comandos sin restricción no dependen de ninguna.
+func (m *CustomCommandManager) unavailablePlatforms(cmd *domain.CustomCommand) []domain.Platform {
+	if m == nil || cmd == nil {
+		return nil
+	}
+	var out []domain.Platform
+	for _, platform := range cmd.Platforms {
+		if platform != "" && !m.platformAvailable(platform) {
+			out = append(out, platform)
+		}
+	}
+	return out
+}
+
+// platformWarnings avisa (sin bloquear) cuando el comando está limitado a
+// plataformas que no están conectadas.
+func (m *CustomCommandManager) platformWarnings(cmd *domain.CustomCommand) []string {
+	var warnings []string
+	for _, platform := range m.unavailablePlatforms(cmd) {
+		warnings = append(warnings, fmt.Sprintf("⚠️ %s no está conectado; el comando no se va a disparar ahí hasta que lo configures.", platform))
+	}
+	return warnings
+}
+
+// CommandStatus dice si el comando puede dispararse hoy: queda inactivo
+// cuando ninguna de sus plataformas está conectada.
+func (m *CustomCommandManager) CommandStatus(cmd *domain.CustomCommand) string {
+	if cmd == nil || len(cmd.Platforms) == 0 {
+		return CommandStatusActive
+	}
+	if len(m.unavailablePlatforms(cmd)) < len(cmd.Platforms) {
+		return CommandStatusActive
+	}
+	return CommandStatusInactivePlatform
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// fakeAvailability hace de MultiSender: solo las plataformas registradas
+// están disponibles.
+type fakeAvailability struct {
+	mu        sync.Mutex
+	connected map[domain.Platform]bool
+}
+
+func (f *fakeAvailability) available(platform domain.Platform) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connected[platform]
+}
+
+func (f *fakeAvailability) connect(platform domain.Platform) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.connected[platform] = true
+}
+
+func newAvailabilityService(t *testing.T) (*Service, *fakeAvailability) {
+	t.Helper()
+	mgr, err := NewCustomCommandManager(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	avail := &fakeAvailability{connected: map[domain.Platform]bool{domain.PlatformTwitch: true}}
+	mgr.SetPlatformAvailability(avail.available)
+	return NewService(mgr), avail
+}
+
+func findCommandDTO(t *testing.T, svc *Service, name string) CommandDTO {
+	t.Helper()
+	list, err := svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, dto := range list {
+		if dto.Name == name {
+			return dto
+		}
+	}
+	t.Fatalf("no está %s en la lista", name)
+	return CommandDTO{}
+}
+
+func TestUpsertWarnsAboutUnavailablePlatform(t *testing.T) {
+	svc, _ := newAvailabilityService(t)
+	response := "sígueme en kick"
+	platforms := []string{"kick"}
+
+	dto, err := svc.Upsert(context.Background(), CommandMutationDTO{Name: "kick", Response: &response, Platforms: &platforms})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	want := "⚠️ kick no está conectado; el comando no se va a disparar ahí hasta que lo configures."
+	if len(dto.Warnings) != 1 || dto.Warnings[0] != want {
+		t.Fatalf("warnings = %q, esperaba %q", dto.Warnings, want)
+	}
+	if dto.Status != CommandStatusInactivePlatform {
+		t.Fatalf("status = %q", dto.Status)
+	}
+
+	// con una plataforma conectada el comando queda activo, con aviso por la otra
+	both := []string{"twitch", "kick"}
+	dto, err = svc.Upsert(context.Background(), CommandMutationDTO{Name: "redes", Response: &response, Platforms: &both})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if len(dto.Warnings) != 1 || dto.Status != CommandStatusActive {
+		t.Fatalf("dto = %+v", dto)
+	}
+
+	// sin restricción no depende de ninguna plataforma
+	dto, err = svc.Upsert(context.Background(), CommandMutationDTO{Name: "hola", Response: &response})
+	if err != nil || len(dto.Warnings) != 0 || dto.Status != CommandStatusActive {
+		t.Fatalf("dto = %+v, %v", dto, err)
+	}
+}
+
+func TestListStatusFollowsPlatformAvailability(t *testing.T) {
+	svc, avail := newAvailabilityService(t)
+	response := "sígueme en kick"
+	platforms := []string{"kick"}
+	if _, err := svc.Upsert(context.Background(), CommandMutationDTO{Name: "kick", Response: &response, Platforms: &platforms}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	if got := findCommandDTO(t, svc, "kick").Status; got != CommandStatusInactivePlatform {
+		t.Fatalf("status sin kick = %q", got)
+	}
+	avail.connect(domain.PlatformKick)
+	if got := findCommandDTO(t, svc, "kick").Status; got != CommandStatusActive {
+		t.Fatalf("status con kick = %q", got)
+	}
+	if got := findCommandDTO(t, svc, "ping").Status; got != CommandStatusActive {
+		t.Fatalf("status de un builtin = %q", got)
+	}
+}
+
+func TestCommandStatusWithoutAvailability(t *testing.T) {
+	mgr, _ := NewCustomCommandManager(context.Background(), nil)
+	cmd := &domain.CustomCommand{Name: "kick", Platforms: []domain.Platform{domain.PlatformKick}}
+	if got := mgr.CommandStatus(cmd); got != CommandStatusActive {
+		t.Fatalf("status = %q, esperaba active sin verificador", got)
+	}
+	if warnings := mgr.platformWarnings(cmd); len(warnings) != 0 {
+		t.Fatalf("warnings = %q", warnings)
+	}
+}
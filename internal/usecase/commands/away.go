@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	awayusecase "zhatBot/internal/usecase/away"
+)
+
+// AwaySetter marca al streamer como ausente o de vuelta (away.Service).
+type AwaySetter interface {
+	SetAway(message string) awayusecase.State
+	SetBack() (time.Duration, bool)
+}
+
+// AwayCommand implementa !away <mensaje>: mientras dure, el bot responde a
+// quien mencione al streamer.
+type AwayCommand struct {
+	away AwaySetter
+}
+
+func NewAwayCommand(away AwaySetter) *AwayCommand {
+	return &AwayCommand{away: away}
+}
+
+func (c *AwayCommand) Name() string {
+	return "away"
+}
+
+func (c *AwayCommand) Aliases() []string {
+	return []string{"afk"}
+}
+
+func (c *AwayCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *AwayCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.away == nil || !msg.IsPlatformOwner {
+		return nil
+	}
+	state := c.away.SetAway(strings.Join(cmdCtx.Args, " "))
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+		fmt.Sprintf("🌙 Ausente: %s", state.Message))
+}
+
+// BackCommand implementa !back.
+type BackCommand struct {
+	away AwaySetter
+}
+
+func NewBackCommand(away AwaySetter) *BackCommand {
+	return &BackCommand{away: away}
+}
+
+func (c *BackCommand) Name() string {
+	return "back"
+}
+
+func (c *BackCommand) Aliases() []string {
+	return nil
+}
+
+func (c *BackCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *BackCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.away == nil || !msg.IsPlatformOwner {
+		return nil
+	}
+	elapsed, ok := c.away.SetBack()
+	if !ok {
+		return nil
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+		fmt.Sprintf("👋 De vuelta después de %s.", formatAwayDuration(elapsed)))
+}
+
+func formatAwayDuration(elapsed time.Duration) string {
+	switch {
+	case elapsed >= time.Hour:
+		hours := int(elapsed.Hours())
+		minutes := int(elapsed.Minutes()) % 60
+		if minutes == 0 {
+			return pluralize(hours, "hora", "horas")
+		}
+		return pluralize(hours, "hora", "horas") + " y " + pluralize(minutes, "minuto", "minutos")
+	case elapsed >= time.Minute:
+		return pluralize(int(elapsed.Minutes()), "minuto", "minutos")
+	default:
+		return "menos de un minuto"
+	}
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/usecase/away"
+)
+
+func TestAwayCommandsAreOwnerOnly(t *testing.T) {
+	ctx := context.Background()
+	svc := away.NewService()
+	out := &captureOut{}
+
+	mod := twitchMessage("mod", "!away")
+	mod.IsPlatformMod = true
+	if err := NewAwayCommand(svc).Handle(ctx, newCmdContext(mod, out, "comiendo")); err != nil {
+		t.Fatalf("away: %v", err)
+	}
+	if svc.State().Away || len(out.texts()) != 0 {
+		t.Fatal("un mod marcó al streamer como ausente")
+	}
+
+	owner := adminMessage("!away")
+	if err := NewAwayCommand(svc).Handle(ctx, newCmdContext(owner, out, "comiendo", "algo")); err != nil {
+		t.Fatalf("away: %v", err)
+	}
+	if got := out.last(); got != "🌙 Ausente: comiendo algo" {
+		t.Fatalf("away reply = %q", got)
+	}
+
+	out.reset()
+	if err := NewBackCommand(svc).Handle(ctx, newCmdContext(owner, out)); err != nil {
+		t.Fatalf("back: %v", err)
+	}
+	if got := out.last(); got != "👋 De vuelta después de menos de un minuto." {
+		t.Fatalf("back reply = %q", got)
+	}
+	// !back sin estar ausente no responde
+	out.reset()
+	if err := NewBackCommand(svc).Handle(ctx, newCmdContext(owner, out)); err != nil {
+		t.Fatalf("back: %v", err)
+	}
+	if got := out.texts(); len(got) != 0 {
+		t.Fatalf("back without away replied %q", got)
+	}
+}
+
+func TestFormatAwayDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:             "menos de un minuto",
+		time.Minute:                  "1 minuto",
+		45 * time.Minute:             "45 minutos",
+		time.Hour:                    "1 hora",
+		2*time.Hour + 5*time.Minute:  "2 horas y 5 minutos",
+		time.Hour + time.Minute + 59: "1 hora y 1 minuto",
+	}
+	for in, want := range tests {
+		if got := formatAwayDuration(in); got != want {
+			t.Errorf("formatAwayDuration(%v) = %q, esperaba %q", in, got, want)
+		}
+	}
+}
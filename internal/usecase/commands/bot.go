@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// BotPauser pausa y reanuda el procesamiento de comandos (el Router).
+type BotPauser interface {
+	Paused() bool
+	SetPaused(ctx context.Context, paused bool) error
+}
+
+// BotCommand permite a los mods pausar todos los comandos (por ejemplo si
+// alguien está abusando de uno) y reanudarlos sin abrir el dashboard.
+type BotCommand struct {
+	pauser BotPauser
+}
+
+func NewBotCommand(pauser BotPauser) *BotCommand {
+	return &BotCommand{pauser: pauser}
+}
+
+func (c *BotCommand) Name() string {
+	return "bot"
+}
+
+func (c *BotCommand) Aliases() []string {
+	return nil
+}
+
+func (c *BotCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *BotCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.pauser == nil {
+		return nil
+	}
+	if !msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod {
+		return nil
+	}
+
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		if c.pauser.Paused() {
+			return reply("⏸️ Los comandos están pausados. Usa !bot resume para reanudarlos.")
+		}
+		return reply("▶️ Los comandos están activos. Usa !bot pause para pausarlos.")
+	}
+
+	switch strings.ToLower(cmdCtx.Args[0]) {
+	case "pause", "pausa", "pausar":
+		if err := c.pauser.SetPaused(ctx, true); err != nil {
+			log.Printf("bot command: %v", err)
+			return reply("❌ No pude pausar los comandos.")
+		}
+		return reply("⏸️ Comandos pausados. Usa !bot resume para reanudarlos.")
+	case "resume", "reanudar":
+		if err := c.pauser.SetPaused(ctx, false); err != nil {
+			log.Printf("bot command: %v", err)
+			return reply("❌ No pude reanudar los comandos.")
+		}
+		return reply("▶️ Comandos reanudados.")
+	default:
+		return reply("Uso: !bot pause|resume")
+	}
+}
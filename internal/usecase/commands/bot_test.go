@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryPauseRepo hace de la tabla settings: sobrevive al router que la usa.
+type memoryPauseRepo struct {
+	mu     sync.Mutex
+	paused bool
+}
+
+func (r *memoryPauseRepo) GetBotPaused(context.Context) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused, nil
+}
+
+func (r *memoryPauseRepo) SetBotPaused(_ context.Context, paused bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = paused
+	return nil
+}
+
+// newPausableHarness arma un router con !bot y la pausa guardada en store,
+// cargándola como hace el runtime al arrancar.
+func newPausableHarness(t *testing.T, store *memoryPauseRepo) *routerHarness {
+	t.Helper()
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "discord", Response: "Únete: discord.gg/zero"})
+	h.router.Register(NewBotCommand(h.router))
+	h.router.SetPauseStore(store)
+	if err := h.router.LoadPause(context.Background()); err != nil {
+		t.Fatalf("LoadPause: %v", err)
+	}
+	return h
+}
+
+func modMessage(user, text string) domain.Message {
+	msg := twitchMessage(user, text)
+	msg.IsPlatformMod = true
+	return msg
+}
+
+func TestBotPausePersistsAcrossRestart(t *testing.T) {
+	store := &memoryPauseRepo{}
+	h := newPausableHarness(t, store)
+
+	if got := h.send(t, modMessage("mod", "!bot pause")); len(got) != 1 || got[0] != "⏸️ Comandos pausados. Usa !bot resume para reanudarlos." {
+		t.Fatalf("pause replies = %q", got)
+	}
+	if !store.paused {
+		t.Fatal("la pausa no se guardó")
+	}
+
+	// "reinicio": un router nuevo con la misma tabla
+	h = newPausableHarness(t, store)
+	if !h.router.Paused() {
+		t.Fatal("el router arrancó reanudado")
+	}
+	for _, text := range []string{"!ping", "!discord"} {
+		if got := h.send(t, twitchMessage("ana", text)); len(got) != 0 {
+			t.Fatalf("%s respondió con el bot pausado: %q", text, got)
+		}
+	}
+
+	if got := h.send(t, modMessage("mod", "!bot resume")); len(got) != 1 || got[0] != "▶️ Comandos reanudados." {
+		t.Fatalf("resume replies = %q", got)
+	}
+	h = newPausableHarness(t, store)
+	if got := h.send(t, twitchMessage("ana", "!ping")); len(got) != 1 {
+		t.Fatalf("!ping tras reanudar y reiniciar = %q", got)
+	}
+}
+
+func TestBotPauseAdminExceptions(t *testing.T) {
+	h := newPausableHarness(t, &memoryPauseRepo{paused: true})
+
+	tests := []struct {
+		name    string
+		msg     domain.Message
+		replies int
+	}{
+		{"mod usa !bot", modMessage("mod", "!bot"), 1},
+		{"mod ve el uso de !bot", modMessage("mod", "!bot status"), 1},
+		{"owner crea un comando", adminMessage("!command redes Sígueme en @zero"), 1},
+		{"owner borra un comando", adminMessage("!command redes action:delete"), 1},
+		{"viewer no puede reanudar", twitchMessage("ana", "!bot resume"), 0},
+		{"viewer no puede usar !command", twitchMessage("ana", "!command x y"), 0},
+		{"mod no escapa a la pausa con otros comandos", modMessage("mod", "!ping"), 0},
+		{"owner tampoco", adminMessage("!discord"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.send(t, tt.msg); len(got) != tt.replies {
+				t.Fatalf("replies = %q, esperaba %d", got, tt.replies)
+			}
+		})
+	}
+	if !h.router.Paused() {
+		t.Fatal("un viewer reanudó los comandos")
+	}
+}
+
+func TestBotPauseNotifiesOnlyOnChange(t *testing.T) {
+	h := newPausableHarness(t, &memoryPauseRepo{})
+	var changes []bool
+	h.router.SetPauseHandler(func(paused bool) { changes = append(changes, paused) })
+
+	for _, paused := range []bool{true, true, false, false} {
+		if err := h.router.SetPaused(context.Background(), paused); err != nil {
+			t.Fatalf("SetPaused(%v): %v", paused, err)
+		}
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Fatalf("changes = %v, esperaba [true false]", changes)
+	}
+}
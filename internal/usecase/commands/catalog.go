@@ -24,8 +24,8 @@ func BuiltinCommandCatalog() []CommandDescriptor {
 		},
 		{
 			Name:        "command",
-			Description: "Administra los comandos personalizados (crear, editar o eliminar).",
-			Usage:       "!command <nombre> [aliases:a,b] [platforms:twitch] [permissions:everyone] <respuesta>",
+			Description: "Administra los comandos personalizados (crear, editar, eliminar o recargar desde la base).",
+			Usage:       "!command <nombre> [aliases:a,b] [platforms:twitch] [permissions:everyone] [cooldown:30] [usercooldown:30s] [feedback:reply-once] <respuesta> | !command <nombre> add-response [weight:N] \"texto\" | !command <nombre> remove-response <n> | !command reload",
 			Permissions: []domain.CommandAccessRole{domain.CommandAccessOwner},
 		},
 		{
@@ -41,5 +41,175 @@ func BuiltinCommandCatalog() []CommandDescriptor {
 			Usage:       "!tts <texto> | !tts voice:list | !tts voice:start|stop",
 			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
 		},
+		{
+			Name:        "ttsskip",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Vota para saltar la lectura TTS actual; los mods la saltan al instante.",
+			Usage:       "!ttsskip",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "lurk",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Avisa que seguís el stream sin escribir.",
+			Usage:       "!lurk",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "unlurk",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Vuelve del lurk y muestra cuánto tiempo estuviste.",
+			Usage:       "!unlurk",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "lurkers",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Muestra cuántos lurkers hay en el stream actual.",
+			Usage:       "!lurkers",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "accountage",
+			Aliases:     []string{"age"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch},
+			Description: "Muestra hace cuánto se creó una cuenta de Twitch.",
+			Usage:       "!accountage [usuario]",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "slow",
+			Aliases:     []string{"slowmode"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch},
+			Description: "Activa o desactiva el modo lento del chat.",
+			Usage:       "!slow <segundos> | !slow off",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "emoteonly",
+			Platforms:   []domain.Platform{domain.PlatformTwitch},
+			Description: "Activa o desactiva el modo solo emotes.",
+			Usage:       "!emoteonly on|off",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "followersonly",
+			Aliases:     []string{"followonly"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch},
+			Description: "Restringe el chat a followers (con antigüedad mínima opcional).",
+			Usage:       "!followersonly <minutos> | !followersonly off",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "announce",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Envía un anuncio destacado (en Twitch usa /announce si el bot es moderador).",
+			Usage:       "!announce [color:blue] <texto>",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "count",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Muestra los contadores (los de palabras/emotes del stream y los manuales). Los mods suben, bajan o reinician los manuales.",
+			Usage:       "!count [nombre] [+|-|+N|-N|set N|reset]",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "testalert",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Envía una alerta falsa por todo el pipeline para probar overlays y notificaciones.",
+			Usage:       "!testalert subscription|donation|bits|giveaway_winner|generic",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessOwner},
+		},
+		{
+			Name:        "readonly",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Activa el modo solo lectura: el bot procesa todo pero no envía mensajes, TTS ni cambios al canal.",
+			Usage:       "!readonly on|off",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessOwner},
+		},
+		{
+			Name:        "bot",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Pausa o reanuda todos los comandos. Con el bot pausado los mods pueden seguir usando !bot y !command.",
+			Usage:       "!bot pause|resume",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "away",
+			Aliases:     []string{"afk"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Marca al streamer como ausente; el bot responde con el mensaje a quien lo mencione.",
+			Usage:       "!away [mensaje]",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessOwner},
+		},
+		{
+			Name:        "back",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Quita el modo ausente.",
+			Usage:       "!back",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessOwner},
+		},
+		{
+			Name:        "countdown",
+			Aliases:     []string{"cuenta"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Inicia una cuenta regresiva para el overlay; el bot avisa a la mitad, en el último minuto y al terminar. Sin argumentos muestra cuánto falta.",
+			Usage:       "!countdown <duración> [texto] | cancel",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "roll",
+			Aliases:     []string{"dado"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Tira dados (d20 por defecto). Se puede desactivar por canal en la configuración de juegos.",
+			Usage:       "!roll [NdM]",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "8ball",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "La bola 8 mágica responde una pregunta de sí o no.",
+			Usage:       "!8ball <pregunta>",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "coinflip",
+			Aliases:     []string{"moneda"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Lanza una moneda: cara o cruz.",
+			Usage:       "!coinflip",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "quote",
+			Aliases:     []string{"cita"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Muestra una cita al azar o la del número indicado. Los mods agregan citas con !quote add.",
+			Usage:       "!quote [número] | add <texto>",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "top",
+			Aliases:     []string{"leaderboard"},
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Muestra quiénes más escribieron (o más emotes usaron) en el stream o en total.",
+			Usage:       "!top [emotes] [stream|total]",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessEveryone},
+		},
+		{
+			Name:        "note",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Agrega una nota privada sobre un usuario; sus mensajes se marcan en el chat del dashboard.",
+			Usage:       "!note <usuario> <texto>",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
+		{
+			Name:        "notes",
+			Platforms:   []domain.Platform{domain.PlatformTwitch, domain.PlatformKick},
+			Description: "Muestra las notas de un usuario por privado (o recortadas en el chat si no hay privados).",
+			Usage:       "!notes <usuario>",
+			Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators},
+		},
 	}
 }
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// chatModeTarget agrupa lo necesario para cambiar los modos del chat de Twitch.
+type chatModeTarget struct {
+	svc           domain.ChatSettingsService
+	broadcasterID string
+	moderatorID   string
+}
+
+func (t chatModeTarget) allowed(msg domain.Message) bool {
+	return msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod
+}
+
+// RequiredScopes: los modos del chat se cambian con el token del bot, que es
+// el moderator_id.
+func (t chatModeTarget) RequiredScopes(domain.Platform) (string, []string) {
+	return "bot", []string{"moderator:manage:chat_settings"}
+}
+
+func (t chatModeTarget) reply(ctx context.Context, cmdCtx *Context, text string) error {
+	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID, text)
+}
+
+func isOffArg(arg string) bool {
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "off", "0", "no", "false", "desactivar":
+		return true
+	}
+	return false
+}
+
+func isOnArg(arg string) bool {
+	switch strings.ToLower(strings.TrimSpace(arg)) {
+	case "on", "1", "si", "sí", "true", "activar":
+		return true
+	}
+	return false
+}
+
+type SlowModeCommand struct {
+	chatModeTarget
+}
+
+func NewSlowModeCommand(svc domain.ChatSettingsService, broadcasterID, moderatorID string) *SlowModeCommand {
+	return &SlowModeCommand{chatModeTarget{svc: svc, broadcasterID: broadcasterID, moderatorID: moderatorID}}
+}
+
+func (c *SlowModeCommand) Name() string {
+	return "slow"
+}
+
+func (c *SlowModeCommand) Aliases() []string {
+	return []string{"slowmode"}
+}
+
+func (c *SlowModeCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch
+}
+
+func (c *SlowModeCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if !c.allowed(cmdCtx.Message) {
+		return nil
+	}
+	if len(cmdCtx.Args) == 0 {
+		return c.reply(ctx, cmdCtx, "Uso: !slow <segundos 3-120> | !slow off")
+	}
+
+	seconds := 0
+	if !isOffArg(cmdCtx.Args[0]) {
+		n, err := strconv.Atoi(strings.TrimSuffix(cmdCtx.Args[0], "s"))
+		if err != nil || n < 3 || n > 120 {
+			return c.reply(ctx, cmdCtx, "⚠️ El modo lento acepta de 3 a 120 segundos.")
+		}
+		seconds = n
+	}
+
+	if err := c.svc.SetSlowMode(ctx, c.broadcasterID, c.moderatorID, seconds); err != nil {
+		log.Printf("slow command: %v", err)
+		return c.reply(ctx, cmdCtx, "⚠️ No pude cambiar el modo lento.")
+	}
+	if seconds == 0 {
+		return c.reply(ctx, cmdCtx, "✅ Modo lento desactivado.")
+	}
+	return c.reply(ctx, cmdCtx, fmt.Sprintf("✅ Modo lento activado (%ds).", seconds))
+}
+
+type EmoteOnlyCommand struct {
+	chatModeTarget
+}
+
+func NewEmoteOnlyCommand(svc domain.ChatSettingsService, broadcasterID, moderatorID string) *EmoteOnlyCommand {
+	return &EmoteOnlyCommand{chatModeTarget{svc: svc, broadcasterID: broadcasterID, moderatorID: moderatorID}}
+}
+
+func (c *EmoteOnlyCommand) Name() string {
+	return "emoteonly"
+}
+
+func (c *EmoteOnlyCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c *EmoteOnlyCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch
+}
+
+func (c *EmoteOnlyCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if !c.allowed(cmdCtx.Message) {
+		return nil
+	}
+	if len(cmdCtx.Args) == 0 || (!isOnArg(cmdCtx.Args[0]) && !isOffArg(cmdCtx.Args[0])) {
+		return c.reply(ctx, cmdCtx, "Uso: !emoteonly on|off")
+	}
+
+	enabled := isOnArg(cmdCtx.Args[0])
+	if err := c.svc.SetEmoteOnly(ctx, c.broadcasterID, c.moderatorID, enabled); err != nil {
+		log.Printf("emoteonly command: %v", err)
+		return c.reply(ctx, cmdCtx, "⚠️ No pude cambiar el modo solo emotes.")
+	}
+	if enabled {
+		return c.reply(ctx, cmdCtx, "✅ Modo solo emotes activado.")
+	}
+	return c.reply(ctx, cmdCtx, "✅ Modo solo emotes desactivado.")
+}
+
+type FollowersOnlyCommand struct {
+	chatModeTarget
+}
+
+func NewFollowersOnlyCommand(svc domain.ChatSettingsService, broadcasterID, moderatorID string) *FollowersOnlyCommand {
+	return &FollowersOnlyCommand{chatModeTarget{svc: svc, broadcasterID: broadcasterID, moderatorID: moderatorID}}
+}
+
+func (c *FollowersOnlyCommand) Name() string {
+	return "followersonly"
+}
+
+func (c *FollowersOnlyCommand) Aliases() []string {
+	return []string{"followonly"}
+}
+
+func (c *FollowersOnlyCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch
+}
+
+func (c *FollowersOnlyCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if !c.allowed(cmdCtx.Message) {
+		return nil
+	}
+	if len(cmdCtx.Args) == 0 {
+		return c.reply(ctx, cmdCtx, "Uso: !followersonly <minutos> | !followersonly off")
+	}
+
+	arg := cmdCtx.Args[0]
+	if strings.EqualFold(arg, "off") || strings.EqualFold(arg, "no") || strings.EqualFold(arg, "false") {
+		if err := c.svc.SetFollowersOnly(ctx, c.broadcasterID, c.moderatorID, false, 0); err != nil {
+			log.Printf("followersonly command: %v", err)
+			return c.reply(ctx, cmdCtx, "⚠️ No pude cambiar el modo solo followers.")
+		}
+		return c.reply(ctx, cmdCtx, "✅ Modo solo followers desactivado.")
+	}
+
+	// un número siempre son minutos: "!followersonly 1" es un minuto, no "on"
+	minutes := 0
+	n, err := strconv.Atoi(strings.TrimSuffix(arg, "m"))
+	switch {
+	case err == nil:
+		if n < 0 || n > 129600 {
+			return c.reply(ctx, cmdCtx, "⚠️ Los minutos deben estar entre 0 y 129600 (3 meses).")
+		}
+		minutes = n
+	case !isOnArg(arg):
+		return c.reply(ctx, cmdCtx, "⚠️ Los minutos deben estar entre 0 y 129600 (3 meses).")
+	}
+
+	if err := c.svc.SetFollowersOnly(ctx, c.broadcasterID, c.moderatorID, true, minutes); err != nil {
+		log.Printf("followersonly command: %v", err)
+		return c.reply(ctx, cmdCtx, "⚠️ No pude cambiar el modo solo followers.")
+	}
+	if minutes == 0 {
+		return c.reply(ctx, cmdCtx, "✅ Modo solo followers activado.")
+	}
+	return c.reply(ctx, cmdCtx, fmt.Sprintf("✅ Modo solo followers activado (%d min de follow).", minutes))
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeChatSettings registra cada cambio de modo del chat.
+type fakeChatSettings struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeChatSettings) SetSlowMode(_ context.Context, broadcasterID, moderatorID string, seconds int) error {
+	f.calls = append(f.calls, fmt.Sprintf("slow %s/%s %d", broadcasterID, moderatorID, seconds))
+	return f.err
+}
+
+func (f *fakeChatSettings) SetEmoteOnly(_ context.Context, broadcasterID, moderatorID string, enabled bool) error {
+	f.calls = append(f.calls, fmt.Sprintf("emote %s/%s %t", broadcasterID, moderatorID, enabled))
+	return f.err
+}
+
+func (f *fakeChatSettings) SetFollowersOnly(_ context.Context, broadcasterID, moderatorID string, enabled bool, minutes int) error {
+	f.calls = append(f.calls, fmt.Sprintf("followers %s/%s %t %d", broadcasterID, moderatorID, enabled, minutes))
+	return f.err
+}
+
+func runChatModeCommand(t *testing.T, cmd Command, mod bool, args ...string) string {
+	t.Helper()
+	msg := twitchMessage("alice", "!"+cmd.Name())
+	msg.IsPlatformMod = mod
+	out := &captureOut{}
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, args...)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	return out.last()
+}
+
+func TestChatModeCommands(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(svc *fakeChatSettings) Command
+		args  []string
+		call  string
+		reply string
+	}{
+		{"slow on", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"30"}, "slow b/m 30", "✅ Modo lento activado (30s)."},
+		{"slow with suffix", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"10s"}, "slow b/m 10", "✅ Modo lento activado (10s)."},
+		{"slow off", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"off"}, "slow b/m 0", "✅ Modo lento desactivado."},
+		{"slow zero", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"0"}, "slow b/m 0", "✅ Modo lento desactivado."},
+		{"emote on", func(s *fakeChatSettings) Command { return NewEmoteOnlyCommand(s, "b", "m") }, []string{"on"}, "emote b/m true", "✅ Modo solo emotes activado."},
+		{"emote off", func(s *fakeChatSettings) Command { return NewEmoteOnlyCommand(s, "b", "m") }, []string{"desactivar"}, "emote b/m false", "✅ Modo solo emotes desactivado."},
+		{"followers on", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"on"}, "followers b/m true 0", "✅ Modo solo followers activado."},
+		{"followers minutes", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"10m"}, "followers b/m true 10", "✅ Modo solo followers activado (10 min de follow)."},
+		{"followers one minute", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"1"}, "followers b/m true 1", "✅ Modo solo followers activado (1 min de follow)."},
+		{"followers zero", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"0"}, "followers b/m true 0", "✅ Modo solo followers activado."},
+		{"followers off", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"OFF"}, "followers b/m false 0", "✅ Modo solo followers desactivado."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeChatSettings{}
+			reply := runChatModeCommand(t, tt.build(svc), true, tt.args...)
+			if len(svc.calls) != 1 || svc.calls[0] != tt.call {
+				t.Fatalf("calls = %q, want %q", svc.calls, tt.call)
+			}
+			if reply != tt.reply {
+				t.Fatalf("reply = %q, want %q", reply, tt.reply)
+			}
+		})
+	}
+}
+
+func TestChatModeCommandsRejectInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(svc *fakeChatSettings) Command
+		args  []string
+		reply string
+	}{
+		{"slow usage", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, nil, "Uso: !slow"},
+		{"slow too short", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"2"}, "de 3 a 120"},
+		{"slow too long", func(s *fakeChatSettings) Command { return NewSlowModeCommand(s, "b", "m") }, []string{"121"}, "de 3 a 120"},
+		{"emote usage", func(s *fakeChatSettings) Command { return NewEmoteOnlyCommand(s, "b", "m") }, []string{"quizas"}, "Uso: !emoteonly"},
+		{"followers usage", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, nil, "Uso: !followersonly"},
+		{"followers negative", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"-5"}, "entre 0 y 129600"},
+		{"followers too long", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"129601"}, "entre 0 y 129600"},
+		{"followers garbage", func(s *fakeChatSettings) Command { return NewFollowersOnlyCommand(s, "b", "m") }, []string{"mucho"}, "entre 0 y 129600"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeChatSettings{}
+			reply := runChatModeCommand(t, tt.build(svc), true, tt.args...)
+			if len(svc.calls) != 0 {
+				t.Fatalf("invalid input reached the API: %q", svc.calls)
+			}
+			if !strings.Contains(reply, tt.reply) {
+				t.Fatalf("reply = %q, want it to contain %q", reply, tt.reply)
+			}
+		})
+	}
+}
+
+func TestChatModeCommandsRequireModerator(t *testing.T) {
+	svc := &fakeChatSettings{}
+	if reply := runChatModeCommand(t, NewSlowModeCommand(svc, "b", "m"), false, "30"); reply != "" || len(svc.calls) != 0 {
+		t.Fatalf("viewer changed the chat mode: reply=%q calls=%q", reply, svc.calls)
+	}
+}
+
+func TestChatModeCommandsReportAPIErrors(t *testing.T) {
+	svc := &fakeChatSettings{err: errors.New("403")}
+	if reply := runChatModeCommand(t, NewEmoteOnlyCommand(svc, "b", "m"), true, "on"); !strings.Contains(reply, "No pude cambiar") {
+		t.Fatalf("reply = %q, want an error", reply)
+	}
+}
@@ -13,6 +13,15 @@ type Command interface {
 	Handle(ctx context.Context, c *Context) error
 }
 
+// ScopedCommand lo implementan los comandos que llaman a la API de la
+// plataforma: indica qué credencial (rol) usan y qué scopes necesita.
+type ScopedCommand interface {
+	RequiredScopes(p domain.Platform) (role string, scopes []string)
+}
+
+// ScopeChecker responde si la credencial platform/role tiene scope.
+type ScopeChecker func(platform domain.Platform, role, scope string) bool
+
 type Context struct {
 	Message domain.Message
 	Out     domain.OutgoingMessagePort
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// cooldownTracker guarda cuándo se puede volver a usar cada comando (y cada
+// usuario, si el comando tiene cooldown por usuario) y a quién ya se le avisó
+// dentro de la ventana actual para no spamear el chat.
+type cooldownTracker struct {
+	mu          sync.Mutex
+	windows     map[string]*cooldownWindow
+	users       map[string]*cooldownWindow
+	defaultMode domain.CooldownFeedbackMode
+}
+
+// cooldownUserSweep es a partir de cuántas ventanas por usuario se borran las
+// vencidas.
+const cooldownUserSweep = 1024
+
+type cooldownWindow struct {
+	until    time.Time
+	replied  bool
+	notified map[string]struct{}
+}
+
+type cooldownDecision struct {
+	blocked   bool
+	remaining time.Duration
+	mode      domain.CooldownFeedbackMode
+	// notify es false si ya se avisó en esta ventana.
+	notify bool
+}
+
+func newCooldownTracker() *cooldownTracker {
+	return &cooldownTracker{
+		windows:     make(map[string]*cooldownWindow),
+		users:       make(map[string]*cooldownWindow),
+		defaultMode: domain.CooldownFeedbackSilent,
+	}
+}
+
+func (t *cooldownTracker) setDefaultMode(mode domain.CooldownFeedbackMode) {
+	parsed, ok := domain.ParseCooldownFeedbackMode(string(mode))
+	if !ok {
+		parsed = domain.CooldownFeedbackSilent
+	}
+	t.mu.Lock()
+	t.defaultMode = parsed
+	t.mu.Unlock()
+}
+
+func (t *cooldownTracker) defaultModeValue() domain.CooldownFeedbackMode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.defaultMode
+}
+
+// acquire abre nuevas ventanas si el comando y el usuario están libres o
+// devuelve qué feedback corresponde si alguno sigue en cooldown. Un uso
+// bloqueado no abre ninguna ventana.
+func (t *cooldownTracker) acquire(cmd *domain.CustomCommand, msg domain.Message, now time.Time) cooldownDecision {
+	if t == nil || cmd == nil || (cmd.Cooldown <= 0 && cmd.UserCooldown <= 0) {
+		return cooldownDecision{}
+	}
+
+	key := normalizeCommandName(cmd.Name)
+	userKey := key + "|" + cooldownUserKey(msg)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if cmd.Cooldown > 0 {
+		if window := t.windows[key]; window != nil && now.Before(window.until) {
+			return t.blockedLocked(cmd, msg, window, now)
+		}
+	}
+	if cmd.UserCooldown > 0 {
+		if window := t.users[userKey]; window != nil && now.Before(window.until) {
+			return t.blockedLocked(cmd, msg, window, now)
+		}
+	}
+
+	if cmd.Cooldown > 0 {
+		t.windows[key] = &cooldownWindow{until: now.Add(cmd.Cooldown)}
+	}
+	if cmd.UserCooldown > 0 {
+		if len(t.users) >= cooldownUserSweep {
+			for k, window := range t.users {
+				if !now.Before(window.until) {
+					delete(t.users, k)
+				}
+			}
+		}
+		t.users[userKey] = &cooldownWindow{until: now.Add(cmd.UserCooldown)}
+	}
+	return cooldownDecision{}
+}
+
+// forget borra las ventanas del comando, p. ej. al eliminarlo.
+func (t *cooldownTracker) forget(name string) {
+	if t == nil {
+		return
+	}
+	key := normalizeCommandName(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.windows, key)
+	prefix := key + "|"
+	for k := range t.users {
+		if strings.HasPrefix(k, prefix) {
+			delete(t.users, k)
+		}
+	}
+}
+
+// blockedLocked arma la decisión de un uso dentro de window.
+func (t *cooldownTracker) blockedLocked(cmd *domain.CustomCommand, msg domain.Message, window *cooldownWindow, now time.Time) cooldownDecision {
+	mode := cmd.CooldownFeedback
+	if mode == "" {
+		mode = t.defaultMode
+	}
+
+	decision := cooldownDecision{
+		blocked:   true,
+		remaining: window.until.Sub(now),
+		mode:      mode,
+	}
+
+	switch mode {
+	case domain.CooldownFeedbackReplyOnce:
+		if !window.replied {
+			window.replied = true
+			decision.notify = true
+		}
+	case domain.CooldownFeedbackWhisper:
+		userKey := cooldownUserKey(msg)
+		if window.notified == nil {
+			window.notified = make(map[string]struct{})
+		}
+		if _, ok := window.notified[userKey]; !ok {
+			window.notified[userKey] = struct{}{}
+			decision.notify = true
+		}
+	}
+
+	return decision
+}
+
+func cooldownUserKey(msg domain.Message) string {
+	id := strings.TrimSpace(msg.UserID)
+	if id == "" {
+		id = msg.LoginName()
+	}
+	return string(msg.Platform) + ":" + id
+}
+
+func sendCooldownFeedback(ctx context.Context, cmd *domain.CustomCommand, msg domain.Message, out domain.OutgoingMessagePort, decision cooldownDecision) error {
+	if !decision.notify || out == nil {
+		return nil
+	}
+
+	seconds := int(math.Ceil(decision.remaining.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	text := fmt.Sprintf("⏳ El comando %s está en cooldown, faltan %ds.", cmd.Name, seconds)
+
+	switch decision.mode {
+	case domain.CooldownFeedbackReplyOnce:
+		return out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	case domain.CooldownFeedbackWhisper:
+		private, ok := out.(domain.PrivateMessagePort)
+		if !ok {
+			return nil
+		}
+		err := private.SendPrivateMessage(ctx, msg.Platform, msg.UserID, msg.LoginName(), text)
+		if err != nil && !errors.Is(err, domain.ErrPrivateMessageUnsupported) {
+			log.Printf("custom command cooldown whisper failed: %v", err)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryCommandSettings guarda en memoria las opciones globales de los comandos.
+type memoryCommandSettings struct {
+	cooldown   domain.CooldownFeedbackMode
+	permission domain.PermissionReplyMode
+}
+
+func (s *memoryCommandSettings) GetCooldownFeedbackDefault(context.Context) (domain.CooldownFeedbackMode, error) {
+	return s.cooldown, nil
+}
+
+func (s *memoryCommandSettings) SetCooldownFeedbackDefault(_ context.Context, mode domain.CooldownFeedbackMode) error {
+	s.cooldown = mode
+	return nil
+}
+
+func (s *memoryCommandSettings) GetPermissionReplyDefault(context.Context) (domain.PermissionReplyMode, error) {
+	return s.permission, nil
+}
+
+func (s *memoryCommandSettings) SetPermissionReplyDefault(_ context.Context, mode domain.PermissionReplyMode) error {
+	s.permission = mode
+	return nil
+}
+
+func TestCooldownTrackerWindow(t *testing.T) {
+	tracker := newCooldownTracker()
+	cmd := &domain.CustomCommand{Name: "hola", Cooldown: 30 * time.Second}
+	msg := twitchMessage("alice", "!hola")
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if d := tracker.acquire(cmd, msg, t0); d.blocked {
+		t.Fatalf("first use blocked")
+	}
+	d := tracker.acquire(cmd, msg, t0.Add(10*time.Second))
+	if !d.blocked || d.remaining != 20*time.Second {
+		t.Fatalf("decision = %+v, want blocked with 20s left", d)
+	}
+	// un uso bloqueado no corre la ventana
+	if d := tracker.acquire(cmd, msg, t0.Add(30*time.Second)); d.blocked {
+		t.Fatalf("use after the window still blocked")
+	}
+	if d := tracker.acquire(cmd, msg, t0.Add(31*time.Second)); !d.blocked {
+		t.Fatalf("new window not opened")
+	}
+
+	tracker.forget("HOLA")
+	if d := tracker.acquire(cmd, msg, t0.Add(32*time.Second)); d.blocked {
+		t.Fatalf("forget kept the window")
+	}
+}
+
+func TestCooldownTrackerUserWindow(t *testing.T) {
+	tracker := newCooldownTracker()
+	cmd := &domain.CustomCommand{Name: "hola", UserCooldown: time.Minute}
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	alice := twitchMessage("alice", "!hola")
+	bob := twitchMessage("bob", "!hola")
+	if d := tracker.acquire(cmd, alice, t0); d.blocked {
+		t.Fatalf("alice blocked on first use")
+	}
+	if d := tracker.acquire(cmd, bob, t0.Add(time.Second)); d.blocked {
+		t.Fatalf("bob blocked by alice's window")
+	}
+	if d := tracker.acquire(cmd, alice, t0.Add(2*time.Second)); !d.blocked {
+		t.Fatalf("alice not blocked by her own window")
+	}
+
+	// el mismo id en otra plataforma es otro usuario
+	kick := alice
+	kick.Platform = domain.PlatformKick
+	if d := tracker.acquire(cmd, kick, t0.Add(3*time.Second)); d.blocked {
+		t.Fatalf("kick user blocked by twitch window")
+	}
+}
+
+func TestCooldownFeedbackNotifiesOncePerWindow(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	alice := twitchMessage("alice", "!hola")
+	bob := twitchMessage("bob", "!hola")
+
+	t.Run("reply-once", func(t *testing.T) {
+		tracker := newCooldownTracker()
+		cmd := &domain.CustomCommand{Name: "hola", Cooldown: time.Minute, CooldownFeedback: domain.CooldownFeedbackReplyOnce}
+		tracker.acquire(cmd, alice, t0)
+		if d := tracker.acquire(cmd, alice, t0.Add(time.Second)); !d.notify {
+			t.Fatalf("first blocked use not notified")
+		}
+		if d := tracker.acquire(cmd, bob, t0.Add(2*time.Second)); d.notify {
+			t.Fatalf("reply-once notified twice in the same window")
+		}
+	})
+
+	t.Run("whisper", func(t *testing.T) {
+		tracker := newCooldownTracker()
+		cmd := &domain.CustomCommand{Name: "hola", Cooldown: time.Minute, CooldownFeedback: domain.CooldownFeedbackWhisper}
+		tracker.acquire(cmd, alice, t0)
+		if d := tracker.acquire(cmd, alice, t0.Add(time.Second)); !d.notify {
+			t.Fatalf("alice not notified")
+		}
+		if d := tracker.acquire(cmd, alice, t0.Add(2*time.Second)); d.notify {
+			t.Fatalf("alice notified twice")
+		}
+		if d := tracker.acquire(cmd, bob, t0.Add(3*time.Second)); !d.notify {
+			t.Fatalf("bob not notified")
+		}
+	})
+
+	t.Run("default mode", func(t *testing.T) {
+		tracker := newCooldownTracker()
+		cmd := &domain.CustomCommand{Name: "hola", Cooldown: time.Minute}
+		tracker.acquire(cmd, alice, t0)
+		if d := tracker.acquire(cmd, alice, t0.Add(time.Second)); d.mode != domain.CooldownFeedbackSilent || d.notify {
+			t.Fatalf("decision = %+v, want silent", d)
+		}
+		tracker.setDefaultMode(domain.CooldownFeedbackReplyOnce)
+		if d := tracker.acquire(cmd, alice, t0.Add(2*time.Second)); d.mode != domain.CooldownFeedbackReplyOnce || !d.notify {
+			t.Fatalf("decision = %+v, want reply-once from the default", d)
+		}
+		tracker.setDefaultMode("nope")
+		if got := tracker.defaultModeValue(); got != domain.CooldownFeedbackSilent {
+			t.Fatalf("invalid default mode = %q, want silent", got)
+		}
+	})
+}
+
+func TestSendCooldownFeedback(t *testing.T) {
+	ctx := context.Background()
+	cmd := &domain.CustomCommand{Name: "hola"}
+	msg := twitchMessage("alice", "!hola")
+	decision := func(mode domain.CooldownFeedbackMode) cooldownDecision {
+		return cooldownDecision{blocked: true, notify: true, mode: mode, remaining: 1500 * time.Millisecond}
+	}
+
+	out := &whisperOut{}
+	if err := sendCooldownFeedback(ctx, cmd, msg, out, decision(domain.CooldownFeedbackReplyOnce)); err != nil {
+		t.Fatalf("reply-once: %v", err)
+	}
+	if got := out.texts(); len(got) != 1 || got[0] != "⏳ El comando hola está en cooldown, faltan 2s." {
+		t.Fatalf("reply-once sent %q", got)
+	}
+
+	if err := sendCooldownFeedback(ctx, cmd, msg, out, decision(domain.CooldownFeedbackWhisper)); err != nil {
+		t.Fatalf("whisper: %v", err)
+	}
+	if len(out.whispers) != 1 || out.whispers[0].ChannelID != msg.UserID {
+		t.Fatalf("whispers = %+v, want one to %s", out.whispers, msg.UserID)
+	}
+	if len(out.texts()) != 1 {
+		t.Fatalf("whisper also replied in chat: %q", out.texts())
+	}
+
+	// sin privados o si la plataforma no los permite no se avisa en el chat
+	plain := &captureOut{}
+	if err := sendCooldownFeedback(ctx, cmd, msg, plain, decision(domain.CooldownFeedbackWhisper)); err != nil || len(plain.texts()) != 0 {
+		t.Fatalf("whisper without private port: err=%v sent=%q", err, plain.texts())
+	}
+	unsupported := &whisperOut{err: domain.ErrPrivateMessageUnsupported}
+	if err := sendCooldownFeedback(ctx, cmd, msg, unsupported, decision(domain.CooldownFeedbackWhisper)); err != nil || len(unsupported.texts()) != 0 {
+		t.Fatalf("unsupported whisper: err=%v sent=%q", err, unsupported.texts())
+	}
+
+	silent := &captureOut{}
+	if err := sendCooldownFeedback(ctx, cmd, msg, silent, decision(domain.CooldownFeedbackSilent)); err != nil || len(silent.texts()) != 0 {
+		t.Fatalf("silent: err=%v sent=%q", err, silent.texts())
+	}
+}
+
+func TestTryHandleCooldownFeedback(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := NewCustomCommandManager(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := &memoryCommandSettings{}
+	svc := NewService(mgr)
+	svc.SetSettingsRepository(settings)
+
+	response := "hola $user"
+	if _, err := svc.Upsert(ctx, CommandMutationDTO{Name: "hola", Response: &response, CooldownSeconds: intPtr(60)}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if _, err := svc.SetDefaultCooldownFeedback(ctx, "reply_once"); err != nil {
+		t.Fatalf("SetDefaultCooldownFeedback: %v", err)
+	}
+	if settings.cooldown != domain.CooldownFeedbackReplyOnce {
+		t.Fatalf("stored default = %q", settings.cooldown)
+	}
+	if _, err := svc.SetDefaultCooldownFeedback(ctx, "gritar"); err == nil {
+		t.Fatalf("invalid mode accepted")
+	}
+
+	out := &captureOut{}
+	for _, user := range []string{"alice", "bob", "carol"} {
+		handled, err := mgr.TryHandle(ctx, "hola", nil, twitchMessage(user, "!hola"), out)
+		if !handled || err != nil {
+			t.Fatalf("TryHandle(%s) = %v, %v", user, handled, err)
+		}
+	}
+	got := out.texts()
+	if len(got) != 2 || got[0] != "hola alice" || !strings.HasPrefix(got[1], "⏳ El comando hola está en cooldown") {
+		t.Fatalf("sent %q, want the response and a single cooldown notice", got)
+	}
+
+	// el modo propio del comando gana al global
+	silent := string(domain.CooldownFeedbackSilent)
+	if _, err := svc.Upsert(ctx, CommandMutationDTO{Name: "hola", CooldownFeedback: &silent}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	mgr.cooldowns.forget("hola")
+	out.reset()
+	for _, user := range []string{"alice", "bob"} {
+		if _, err := mgr.TryHandle(ctx, "hola", nil, twitchMessage(user, "!hola"), out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := out.texts(); len(got) != 1 {
+		t.Fatalf("silent command sent %q", got)
+	}
+
+	// la configuración guardada se vuelve a aplicar al recargar
+	other, _ := NewCustomCommandManager(ctx, nil)
+	reloaded := NewService(other)
+	reloaded.SetSettingsRepository(settings)
+	if err := reloaded.LoadSettings(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.DefaultCooldownFeedback(); got != string(domain.CooldownFeedbackReplyOnce) {
+		t.Fatalf("loaded default = %q", got)
+	}
+}
+
+func TestUpsertRejectsInvalidCooldown(t *testing.T) {
+	mgr, _ := NewCustomCommandManager(context.Background(), nil)
+	response := "x"
+	_, _, _, err := mgr.Upsert(context.Background(), UpdateCustomCommandInput{Name: "x", Response: &response, HasCooldown: true, Cooldown: -time.Second})
+	if err == nil {
+		t.Fatalf("negative cooldown accepted")
+	}
+	_, _, _, err = mgr.Upsert(context.Background(), UpdateCustomCommandInput{Name: "x", Response: &response, HasCooldownFeedback: true, CooldownFeedback: "gritar"})
+	if err == nil || errors.Is(err, ErrCommandConflict) {
+		t.Fatalf("invalid feedback mode: err = %v", err)
+	}
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestParseCooldownArg(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"30", 30 * time.Second, true},
+		{"15s", 15 * time.Second, true},
+		{"1m", time.Minute, true},
+		{"0", 0, true},
+		{"-5", 0, false},
+		{"-1m", 0, false},
+		{"mucho", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCooldownArg(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseCooldownArg(%q) = %s, %v; esperaba %s, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUserCooldownFromChat(t *testing.T) {
+	h := newRouterHarness(t)
+
+	if got := h.send(t, adminMessage("!command redes cooldown:15s usercooldown:1m Sígueme en @zero")); len(got) != 1 {
+		t.Fatalf("!command = %q", got)
+	}
+	cmd := h.mgr.Find("redes")
+	if cmd == nil || cmd.Cooldown != 15*time.Second || cmd.UserCooldown != time.Minute {
+		t.Fatalf("comando = %+v", cmd)
+	}
+	stored, _ := h.repo.GetCustomCommand(context.Background(), "redes")
+	if stored == nil || stored.UserCooldown != time.Minute {
+		t.Fatalf("guardado = %+v", stored)
+	}
+
+	if got := h.send(t, adminMessage("!command redes usercooldown:pronto")); len(got) != 1 || got[0] != "⚠️ Cooldown por usuario inválido, usa segundos o una duración (ej. usercooldown:30s)." {
+		t.Fatalf("cooldown inválido = %q", got)
+	}
+	if cmd := h.mgr.Find("redes"); cmd.UserCooldown != time.Minute {
+		t.Fatalf("un cooldown inválido cambió el comando: %s", cmd.UserCooldown)
+	}
+}
+
+func TestUserCooldownThrottlesOnlyThatUser(t *testing.T) {
+	ctx := context.Background()
+	mgr, _ := NewCustomCommandManager(ctx, nil)
+	response := "hola $user"
+	if _, _, _, err := mgr.Upsert(ctx, UpdateCustomCommandInput{Name: "hola", Response: &response, HasUserCooldown: true, UserCooldown: time.Minute}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	out := &captureOut{}
+	for _, user := range []string{"alice", "alice", "bob"} {
+		handled, err := mgr.TryHandle(ctx, "hola", nil, twitchMessage(user, "!hola"), out)
+		if !handled || err != nil {
+			t.Fatalf("TryHandle(%s) = %v, %v", user, handled, err)
+		}
+	}
+	if got := out.texts(); len(got) != 2 || got[0] != "hola alice" || got[1] != "hola bob" {
+		t.Fatalf("sent %q, esperaba una respuesta por usuario", got)
+	}
+
+	// un comando recreado con el mismo nombre arranca sin cooldown
+	if _, err := mgr.Delete(ctx, "hola"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, _, err := mgr.Upsert(ctx, UpdateCustomCommandInput{Name: "hola", Response: &response, HasUserCooldown: true, UserCooldown: time.Minute}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	out.reset()
+	if _, err := mgr.TryHandle(ctx, "hola", nil, twitchMessage("alice", "!hola"), out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.texts(); len(got) != 1 {
+		t.Fatalf("sent %q después de borrar el comando", got)
+	}
+
+	if _, _, _, err := mgr.Upsert(ctx, UpdateCustomCommandInput{Name: "hola", HasUserCooldown: true, UserCooldown: -time.Second}); err == nil {
+		t.Fatal("aceptó un cooldown por usuario negativo")
+	}
+}
+
+func TestCooldownTrackerSweepsExpiredUserWindows(t *testing.T) {
+	tracker := newCooldownTracker()
+	cmd := &domain.CustomCommand{Name: "hola", UserCooldown: time.Second}
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range cooldownUserSweep {
+		msg := twitchMessage(fmt.Sprintf("user%d", i), "!hola")
+		tracker.acquire(cmd, msg, t0)
+	}
+	tracker.acquire(cmd, twitchMessage("tarde", "!hola"), t0.Add(time.Minute))
+	if n := len(tracker.users); n != 1 {
+		t.Fatalf("quedaron %d ventanas, esperaba solo la nueva", n)
+	}
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+	countersusecase "zhatBot/internal/usecase/counters"
+)
+
+// WordCounter expone los contadores de palabras/emotes del chat.
+type WordCounter interface {
+	Trackers() []domain.WordTracker
+}
+
+// CounterBook guarda los contadores manuales (counters.Service).
+type CounterBook interface {
+	List() []domain.Counter
+	Get(name string) (domain.Counter, error)
+	Add(ctx context.Context, name string, delta int) (domain.Counter, error)
+	Set(ctx context.Context, name string, value int) (domain.Counter, error)
+	Reset(ctx context.Context, name string) (domain.Counter, error)
+}
+
+const countUsage = "Uso: !count <nombre> [+|-|+N|-N|set N|reset]"
+
+// CountCommand muestra los contadores: los manuales (!count muertes +, solo
+// mods para cambiarlos) y cuántas veces se dijo una palabra/emote en el
+// stream.
+type CountCommand struct {
+	counter  WordCounter
+	counters CounterBook
+}
+
+func NewCountCommand(counter WordCounter, counters CounterBook) *CountCommand {
+	return &CountCommand{counter: counter, counters: counters}
+}
+
+func (c *CountCommand) Name() string {
+	return "count"
+}
+
+func (c *CountCommand) Aliases() []string {
+	return nil
+}
+
+func (c *CountCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *CountCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	var trackers []domain.WordTracker
+	if c.counter != nil {
+		trackers = c.counter.Trackers()
+	}
+	var manual []domain.Counter
+	if c.counters != nil {
+		manual = c.counters.List()
+	}
+
+	if len(cmdCtx.Args) > 1 {
+		return c.mutate(ctx, cmdCtx, trackers, reply)
+	}
+
+	if len(cmdCtx.Args) > 0 {
+		query := strings.TrimSpace(cmdCtx.Args[0])
+		if c.counters != nil {
+			if counter, err := c.counters.Get(query); err == nil {
+				return reply(fmt.Sprintf("📊 %s: %d", counter.Name, counter.Value))
+			}
+		}
+		for _, tracker := range trackers {
+			if strings.EqualFold(tracker.Name, query) || strings.EqualFold(tracker.Term, query) {
+				return reply(fmt.Sprintf("📊 %s: %d en este stream", tracker.Term, tracker.Count))
+			}
+		}
+		return reply(fmt.Sprintf("⚠️ No hay un contador para %s.", query))
+	}
+
+	if len(trackers) == 0 && len(manual) == 0 {
+		return reply("⚠️ No hay contadores configurados.")
+	}
+	parts := make([]string, 0, len(manual)+len(trackers))
+	for _, counter := range manual {
+		parts = append(parts, fmt.Sprintf("%s: %d", counter.Name, counter.Value))
+	}
+	for _, tracker := range trackers {
+		parts = append(parts, fmt.Sprintf("%s: %d", tracker.Term, tracker.Count))
+	}
+	return reply("📊 " + strings.Join(parts, " | "))
+}
+
+// mutate atiende !count <nombre> <operación>; solo para mods.
+func (c *CountCommand) mutate(ctx context.Context, cmdCtx *Context, trackers []domain.WordTracker, reply func(string) error) error {
+	msg := cmdCtx.Message
+	if c.counters == nil || (!msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod) {
+		return nil
+	}
+	name := cmdCtx.Args[0]
+	if _, err := c.counters.Get(name); errors.Is(err, countersusecase.ErrCounterNotFound) {
+		for _, tracker := range trackers {
+			if strings.EqualFold(tracker.Name, name) {
+				return reply(fmt.Sprintf("⚠️ %s cuenta palabras del chat; no se cambia a mano.", tracker.Name))
+			}
+		}
+	}
+
+	op := strings.ToLower(cmdCtx.Args[1])
+	var (
+		counter domain.Counter
+		err     error
+	)
+	switch {
+	case op == "reset":
+		counter, err = c.counters.Reset(ctx, name)
+	case op == "set":
+		if len(cmdCtx.Args) < 3 {
+			return reply(countUsage)
+		}
+		value, parseErr := strconv.Atoi(cmdCtx.Args[2])
+		if parseErr != nil {
+			return reply(countUsage)
+		}
+		counter, err = c.counters.Set(ctx, name, value)
+	case strings.HasPrefix(op, "+") || strings.HasPrefix(op, "-"):
+		delta := 1
+		if len(op) > 1 {
+			parsed, parseErr := strconv.Atoi(op[1:])
+			if parseErr != nil || parsed < 0 {
+				return reply(countUsage)
+			}
+			delta = parsed
+		}
+		if op[0] == '-' {
+			delta = -delta
+		}
+		counter, err = c.counters.Add(ctx, name, delta)
+	default:
+		return reply(countUsage)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, countersusecase.ErrCounterNotFound):
+			return reply(fmt.Sprintf("⚠️ No hay un contador para %s.", name))
+		case errors.Is(err, countersusecase.ErrInvalidName), errors.Is(err, countersusecase.ErrOutOfRange):
+			return reply(fmt.Sprintf("⚠️ %v", err))
+		}
+		log.Printf("count command: %v", err)
+		return reply("❌ No pude guardar el contador.")
+	}
+	return reply(fmt.Sprintf("📊 %s: %d", counter.Name, counter.Value))
+}
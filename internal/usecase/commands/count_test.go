@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/domain"
+	countersusecase "zhatBot/internal/usecase/counters"
+)
+
+type fixedTrackers []domain.WordTracker
+
+func (f fixedTrackers) Trackers() []domain.WordTracker { return f }
+
+func runCount(t *testing.T, cmd *CountCommand, msg domain.Message, args ...string) string {
+	t.Helper()
+	out := &captureOut{}
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, args...)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	return out.last()
+}
+
+func TestCountCommandOperations(t *testing.T) {
+	cmd := NewCountCommand(nil, countersusecase.NewService(nil))
+	mod := modMessage("beto", "!count")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"muertes", "+"}, "📊 muertes: 1"},
+		{[]string{"Muertes", "+4"}, "📊 muertes: 5"},
+		{[]string{"muertes", "-"}, "📊 muertes: 4"},
+		{[]string{"muertes", "-10"}, "📊 muertes: -6"},
+		{[]string{"muertes", "SET", "12"}, "📊 muertes: 12"},
+		{[]string{"muertes", "reset"}, "📊 muertes: 0"},
+		{[]string{"otra", "reset"}, "⚠️ No hay un contador para otra."},
+		{[]string{"muertes", "set"}, countUsage},
+		{[]string{"muertes", "set", "mil"}, countUsage},
+		{[]string{"muertes", "+x"}, countUsage},
+		{[]string{"muertes", "+-3"}, countUsage},
+		{[]string{"muertes", "por2"}, countUsage},
+		{[]string{"muertes", "set", "2000000000"}, "⚠️ " + countersusecase.ErrOutOfRange.Error()},
+		{[]string{"dos palabras", "+"}, "⚠️ " + countersusecase.ErrInvalidName.Error()},
+	}
+	for _, tt := range tests {
+		if got := runCount(t, cmd, mod, tt.args...); got != tt.want {
+			t.Errorf("!count %v = %q, esperaba %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCountCommandMutationIsModOnly(t *testing.T) {
+	counters := countersusecase.NewService(nil)
+	if _, err := counters.Set(context.Background(), "muertes", 3); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	cmd := NewCountCommand(nil, counters)
+	viewer := twitchMessage("ana", "!count")
+
+	if got := runCount(t, cmd, viewer, "muertes", "+"); got != "" {
+		t.Fatalf("un viewer cambió el contador: %q", got)
+	}
+	if got := runCount(t, cmd, viewer, "muertes"); got != "📊 muertes: 3" {
+		t.Fatalf("leer = %q", got)
+	}
+}
+
+func TestCountCommandListsBothKinds(t *testing.T) {
+	counters := countersusecase.NewService(nil)
+	if _, err := counters.Set(context.Background(), "muertes", 2); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	trackers := fixedTrackers{{Name: "saludos", Term: "hola", Count: 7}}
+	cmd := NewCountCommand(trackers, counters)
+	mod := modMessage("beto", "!count")
+
+	if got := runCount(t, cmd, mod); got != "📊 muertes: 2 | hola: 7" {
+		t.Fatalf("lista = %q", got)
+	}
+	if got := runCount(t, cmd, mod, "hola"); got != "📊 hola: 7 en este stream" {
+		t.Fatalf("tracker = %q", got)
+	}
+	// los trackers del chat no se cambian a mano
+	if got := runCount(t, cmd, mod, "saludos", "+"); got != "⚠️ saludos cuenta palabras del chat; no se cambia a mano." {
+		t.Fatalf("cambiar un tracker = %q", got)
+	}
+	if got := runCount(t, NewCountCommand(nil, countersusecase.NewService(nil)), mod); got != "⚠️ No hay contadores configurados." {
+		t.Fatalf("sin contadores = %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	countdownusecase "zhatBot/internal/usecase/countdown"
+)
+
+// CountdownManager maneja las cuentas regresivas (countdown.Service).
+type CountdownManager interface {
+	Start(ctx context.Context, platform domain.Platform, channelID string, duration time.Duration, label string) (countdownusecase.Tick, error)
+	Cancel(ctx context.Context, platform domain.Platform, channelID string) error
+	Find(platform domain.Platform, channelID string) (countdownusecase.Tick, bool)
+}
+
+// CountdownCommand implementa !countdown <duración> [texto] | cancel.
+type CountdownCommand struct {
+	countdowns CountdownManager
+}
+
+func NewCountdownCommand(countdowns CountdownManager) *CountdownCommand {
+	return &CountdownCommand{countdowns: countdowns}
+}
+
+func (c *CountdownCommand) Name() string {
+	return "countdown"
+}
+
+func (c *CountdownCommand) Aliases() []string {
+	return []string{"cuenta"}
+}
+
+func (c *CountdownCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *CountdownCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.countdowns == nil {
+		return nil
+	}
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		tick, ok := c.countdowns.Find(msg.Platform, msg.ChannelID)
+		if !ok {
+			return reply("No hay una cuenta regresiva activa.")
+		}
+		return reply(fmt.Sprintf("⏳ %s: faltan %s.", tick.Label,
+			countdownusecase.FormatRemaining(time.Duration(tick.Remaining)*time.Second)))
+	}
+	if !msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod {
+		return nil
+	}
+
+	switch strings.ToLower(cmdCtx.Args[0]) {
+	case "cancel", "stop", "cancelar":
+		if err := c.countdowns.Cancel(ctx, msg.Platform, msg.ChannelID); err != nil {
+			if errors.Is(err, countdownusecase.ErrNotFound) {
+				return reply("No hay una cuenta regresiva activa.")
+			}
+			log.Printf("countdown command: %v", err)
+			return reply("❌ No pude cancelar la cuenta regresiva.")
+		}
+		return reply("⏹️ Cuenta regresiva cancelada.")
+	}
+
+	duration, err := countdownusecase.ParseDuration(cmdCtx.Args[0])
+	if err != nil {
+		return reply(fmt.Sprintf("Uso: !countdown <duración> [texto] (entre %s y %s, p.ej. 5m) | cancel",
+			countdownusecase.MinDuration, countdownusecase.MaxDuration))
+	}
+	// Start ya anuncia la cuenta en el chat
+	if _, err := c.countdowns.Start(ctx, msg.Platform, msg.ChannelID, duration, strings.Join(cmdCtx.Args[1:], " ")); err != nil {
+		log.Printf("countdown command: %v", err)
+		return reply("❌ No pude iniciar la cuenta regresiva.")
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"slices"
 	"strings"
 	"sync"
@@ -20,6 +22,16 @@ type CustomCommandManager struct {
 	aliasToName      map[string]string
 	isReserved       func(string) bool
 	audienceResolver CommandAudienceResolver
+
+	cooldowns *cooldownTracker
+	denied    *permissionReplier
+
+	// availMu va aparte de mu porque se consulta mientras Upsert tiene mu.
+	availMu   sync.RWMutex
+	available PlatformAvailability
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
 }
 
 type UpdateCustomCommandInput struct {
@@ -31,28 +43,62 @@ type UpdateCustomCommandInput struct {
 	HasPlatforms   bool
 	Permissions    []domain.CommandAccessRole
 	HasPermissions bool
-}
 
-type CommandAudienceResolver interface {
-	IsFollower(ctx context.Context, msg domain.Message) (bool, error)
+	Cooldown            time.Duration
+	HasCooldown         bool
+	UserCooldown        time.Duration
+	HasUserCooldown     bool
+	CooldownFeedback    domain.CooldownFeedbackMode
+	HasCooldownFeedback bool
+
+	PermissionReply    domain.PermissionReplyMode
+	HasPermissionReply bool
+
+	// Responses reemplaza a Response por un pool con pesos; no se pueden
+	// mandar los dos.
+	Responses    []domain.WeightedResponse
+	HasResponses bool
 }
 
+// CommandAudienceResolver verifica seguidores, subs y moderadores para los
+// permisos de los comandos. Sin resolver se usan los flags del mensaje.
+type CommandAudienceResolver = domain.AudienceService
+
 func NewCustomCommandManager(ctx context.Context, repo domain.CustomCommandRepository) (*CustomCommandManager, error) {
 	mgr := &CustomCommandManager{
 		repo:        repo,
 		commands:    make(map[string]*domain.CustomCommand),
 		aliasToName: make(map[string]string),
+		cooldowns:   newCooldownTracker(),
+		denied:      newPermissionReplier(),
+		rng:         rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0)),
 	}
 
 	if repo == nil {
 		return mgr, nil
 	}
 
-	list, err := repo.ListCustomCommands(ctx)
+	if err := mgr.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return mgr, nil
+}
+
+// Reload vuelve a leer los comandos del repositorio (p.ej. si otro cliente los editó).
+// Los mapas se reemplazan juntos bajo el lock, así que TryHandle ve el estado
+// anterior o el nuevo, nunca uno a medias; el checker de reservados y el
+// resolver de audiencia se conservan.
+func (m *CustomCommandManager) Reload(ctx context.Context) error {
+	if m.repo == nil {
+		return nil
+	}
+
+	list, err := m.repo.ListCustomCommands(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("custom manager: list: %w", err)
+		return fmt.Errorf("custom manager: list: %w", err)
 	}
 
+	commands := make(map[string]*domain.CustomCommand, len(list))
 	for _, cmd := range list {
 		if cmd == nil {
 			continue
@@ -61,11 +107,14 @@ func NewCustomCommandManager(ctx context.Context, repo domain.CustomCommandRepos
 		if name == "" {
 			continue
 		}
-		mgr.commands[name] = cloneCommand(cmd)
+		commands[name] = cloneCommand(cmd)
 	}
-	mgr.rebuildAliasesLocked()
 
-	return mgr, nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.commands = commands
+	m.rebuildAliasesLocked()
+	return nil
 }
 
 func (m *CustomCommandManager) rebuildAliasesLocked() {
@@ -86,14 +135,17 @@ func (m *CustomCommandManager) Find(trigger string) *domain.CustomCommand {
 		return nil
 	}
 
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.findLocked(trigger)
+}
+
+// findLocked busca por nombre o alias; hay que tener mu.
+func (m *CustomCommandManager) findLocked(trigger string) *domain.CustomCommand {
 	key := normalizeCommandName(trigger)
 	if key == "" {
 		return nil
 	}
-
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	if cmd, ok := m.commands[key]; ok {
 		return cloneCommand(cmd)
 	}
@@ -105,6 +157,20 @@ func (m *CustomCommandManager) Find(trigger string) *domain.CustomCommand {
 	return nil
 }
 
+// Count devuelve cuántos comandos personalizados hay; si el repositorio sabe
+// contarlos se le pregunta a él.
+func (m *CustomCommandManager) Count(ctx context.Context) (int, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if counter, ok := m.repo.(domain.CustomCommandCounter); ok {
+		return counter.CountCustomCommands(ctx)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.commands), nil
+}
+
 func (m *CustomCommandManager) List() []*domain.CustomCommand {
 	if m == nil {
 		return nil
@@ -122,7 +188,9 @@ func (m *CustomCommandManager) List() []*domain.CustomCommand {
 	return out
 }
 
-func (m *CustomCommandManager) TryHandle(ctx context.Context, trigger string, msg domain.Message, out domain.OutgoingMessagePort) (bool, error) {
+// TryHandle responde con el comando personalizado que dispara trigger. args
+// son las palabras que siguen al comando, para $args y $argN.
+func (m *CustomCommandManager) TryHandle(ctx context.Context, trigger string, args []string, msg domain.Message, out domain.OutgoingMessagePort) (bool, error) {
 	cmd := m.Find(trigger)
 	if cmd == nil {
 		return false, nil
@@ -130,28 +198,78 @@ func (m *CustomCommandManager) TryHandle(ctx context.Context, trigger string, ms
 	if len(cmd.Platforms) > 0 && !containsPlatform(cmd.Platforms, msg.Platform) {
 		return false, nil
 	}
-	if strings.TrimSpace(cmd.Response) == "" {
+	pool := cmd.ResponsePool()
+	if len(pool) == 0 {
 		return false, nil
 	}
 	if !m.isAllowed(ctx, cmd, msg) {
+		if m.denied.shouldReply(cmd, msg, time.Now()) {
+			return true, sendPermissionDenied(ctx, cmd, msg, out)
+		}
 		return true, nil
 	}
-	return true, out.SendMessage(ctx, msg.Platform, msg.ChannelID, cmd.Response)
+	if decision := m.cooldowns.acquire(cmd, msg, time.Now()); decision.blocked {
+		return true, sendCooldownFeedback(ctx, cmd, msg, out, decision)
+	}
+	response := expandPlaceholders(m.pickResponse(pool), msg, args)
+	return true, out.SendMessage(ctx, msg.Platform, msg.ChannelID, response)
+}
+
+// SetRandomSeed fija la semilla con la que se eligen las respuestas de los
+// pools, para poder reproducir una secuencia.
+func (m *CustomCommandManager) SetRandomSeed(seed uint64) {
+	m.rngMu.Lock()
+	defer m.rngMu.Unlock()
+	m.rng = rand.New(rand.NewPCG(seed, 0))
+}
+
+// pickResponse elige una respuesta del pool con probabilidad proporcional a
+// su peso.
+func (m *CustomCommandManager) pickResponse(pool []domain.WeightedResponse) string {
+	if len(pool) == 1 {
+		return pool[0].Text
+	}
+	total := 0
+	for _, r := range pool {
+		total += r.Weight
+	}
+	if total <= 0 {
+		return pool[0].Text
+	}
+
+	m.rngMu.Lock()
+	n := m.rng.IntN(total)
+	m.rngMu.Unlock()
+
+	for _, r := range pool {
+		if n < r.Weight {
+			return r.Text
+		}
+		n -= r.Weight
+	}
+	return pool[len(pool)-1].Text
 }
 
-func (m *CustomCommandManager) Upsert(ctx context.Context, input UpdateCustomCommandInput) (*domain.CustomCommand, bool, error) {
+// Upsert crea o actualiza un comando. Los avisos (p. ej. respuesta demasiado
+// larga para alguna plataforma) no impiden guardarlo.
+func (m *CustomCommandManager) Upsert(ctx context.Context, input UpdateCustomCommandInput) (*domain.CustomCommand, bool, []string, error) {
 	if m == nil {
-		return nil, false, fmt.Errorf("custom manager: nil")
+		return nil, false, nil, fmt.Errorf("custom manager: nil")
 	}
 	name := normalizeCommandName(input.Name)
 	if name == "" {
-		return nil, false, fmt.Errorf("nombre inválido")
+		return nil, false, nil, fmt.Errorf("nombre inválido")
 	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.upsertLocked(ctx, name, input)
+}
 
-	existing := m.commands[name]
+// upsertLocked es Upsert con mu tomado y name ya normalizado.
+func (m *CustomCommandManager) upsertLocked(ctx context.Context, name string, input UpdateCustomCommandInput) (*domain.CustomCommand, bool, []string, error) {
+	// se trabaja sobre una copia para que un error no deje cambios a medias
+	existing := cloneCommand(m.commands[name])
 	created := false
 	if existing == nil {
 		existing = &domain.CustomCommand{
@@ -160,11 +278,23 @@ func (m *CustomCommandManager) Upsert(ctx context.Context, input UpdateCustomCom
 		created = true
 	}
 
+	if input.Response != nil && input.HasResponses {
+		return nil, false, nil, fmt.Errorf("usa una respuesta o un pool de respuestas, no ambos")
+	}
 	if input.Response != nil {
 		existing.Response = strings.TrimSpace(*input.Response)
+		existing.Responses = nil
+	}
+	if input.HasResponses {
+		pool, err := normalizeResponsePool(input.Responses)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		existing.Response = ""
+		existing.Responses = pool
 	}
-	if existing.Response == "" {
-		return nil, false, fmt.Errorf("el contenido del comando es obligatorio")
+	if existing.Response == "" && len(existing.Responses) == 0 {
+		return nil, false, nil, fmt.Errorf("el contenido del comando es obligatorio")
 	}
 
 	proposedAliases := existing.Aliases
@@ -172,7 +302,7 @@ func (m *CustomCommandManager) Upsert(ctx context.Context, input UpdateCustomCom
 		proposedAliases = normalizeAliasList(input.Aliases)
 	}
 	if err := m.ensureNoConflicts(name, created, proposedAliases, input.HasAliases); err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	if input.HasAliases {
@@ -184,18 +314,107 @@ func (m *CustomCommandManager) Upsert(ctx context.Context, input UpdateCustomCom
 	if input.HasPermissions {
 		existing.Permissions = normalizePermissions(input.Permissions)
 	}
+	if input.HasCooldown {
+		if input.Cooldown < 0 {
+			return nil, false, nil, fmt.Errorf("el cooldown no puede ser negativo")
+		}
+		existing.Cooldown = input.Cooldown.Truncate(time.Second)
+	}
+	if input.HasUserCooldown {
+		if input.UserCooldown < 0 {
+			return nil, false, nil, fmt.Errorf("el cooldown por usuario no puede ser negativo")
+		}
+		existing.UserCooldown = input.UserCooldown.Truncate(time.Second)
+	}
+	if input.HasCooldownFeedback {
+		mode := domain.CooldownFeedbackMode("")
+		if strings.TrimSpace(string(input.CooldownFeedback)) != "" {
+			parsed, ok := domain.ParseCooldownFeedbackMode(string(input.CooldownFeedback))
+			if !ok {
+				return nil, false, nil, fmt.Errorf("modo de cooldown inválido %q (silent, reply-once, whisper)", input.CooldownFeedback)
+			}
+			mode = parsed
+		}
+		existing.CooldownFeedback = mode
+	}
+	if input.HasPermissionReply {
+		mode := domain.PermissionReplyMode("")
+		if strings.TrimSpace(string(input.PermissionReply)) != "" {
+			parsed, ok := domain.ParsePermissionReplyMode(string(input.PermissionReply))
+			if !ok {
+				return nil, false, nil, fmt.Errorf("modo de aviso de permisos inválido %q (silent, reply)", input.PermissionReply)
+			}
+			mode = parsed
+		}
+		existing.PermissionReply = mode
+	}
 	existing.UpdatedAt = time.Now()
 
 	if m.repo != nil {
 		if err := m.repo.UpsertCustomCommand(ctx, existing); err != nil {
-			return nil, false, err
+			return nil, false, nil, err
 		}
 	}
 
 	m.commands[name] = cloneCommand(existing)
 	m.rebuildAliasesLocked()
 
-	return cloneCommand(existing), created, nil
+	warnings := append(responseLengthWarnings(existing), m.platformWarnings(existing)...)
+	return cloneCommand(existing), created, warnings, nil
+}
+
+// AddResponse agrega una respuesta al pool del comando. Si el comando tenía
+// una respuesta simple, esa pasa a ser la primera del pool con peso 1.
+func (m *CustomCommandManager) AddResponse(ctx context.Context, name, text string, weight int) (*domain.CustomCommand, []string, error) {
+	if m == nil {
+		return nil, nil, fmt.Errorf("custom manager: nil")
+	}
+	// leer y guardar bajo el mismo lock: dos ediciones a la vez no se pisan
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cmd := m.findLocked(name)
+	if cmd == nil {
+		return nil, nil, fmt.Errorf("comando no encontrado")
+	}
+	pool := append(cmd.ResponsePool(), domain.WeightedResponse{Text: text, Weight: weight})
+	updated, _, warnings, err := m.upsertLocked(ctx, normalizeCommandName(cmd.Name), UpdateCustomCommandInput{
+		Name:         cmd.Name,
+		Responses:    pool,
+		HasResponses: true,
+	})
+	return updated, warnings, err
+}
+
+// RemoveResponse quita la respuesta index (desde 1) del pool. Si queda una
+// sola, el comando vuelve a tener una respuesta simple.
+func (m *CustomCommandManager) RemoveResponse(ctx context.Context, name string, index int) (*domain.CustomCommand, error) {
+	if m == nil {
+		return nil, fmt.Errorf("custom manager: nil")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cmd := m.findLocked(name)
+	if cmd == nil {
+		return nil, fmt.Errorf("comando no encontrado")
+	}
+	pool := cmd.ResponsePool()
+	if index < 1 || index > len(pool) {
+		return nil, fmt.Errorf("el comando tiene %d respuestas, no existe la %d", len(pool), index)
+	}
+	if len(pool) == 1 {
+		return nil, fmt.Errorf("no se puede quitar la única respuesta; borra el comando")
+	}
+	pool = slices.Delete(pool, index-1, index)
+
+	input := UpdateCustomCommandInput{Name: cmd.Name}
+	if len(pool) == 1 {
+		input.Response = &pool[0].Text
+	} else {
+		input.Responses = pool
+		input.HasResponses = true
+	}
+	updated, _, _, err := m.upsertLocked(ctx, normalizeCommandName(cmd.Name), input)
+	return updated, err
 }
 
 func (m *CustomCommandManager) Delete(ctx context.Context, name string) (bool, error) {
@@ -222,16 +441,31 @@ func (m *CustomCommandManager) Delete(ctx context.Context, name string) (bool, e
 
 	delete(m.commands, key)
 	m.rebuildAliasesLocked()
+	m.cooldowns.forget(key)
 	return true, nil
 }
 
+// ErrCommandConflict identifica los errores de nombre o alias ya usados.
+var ErrCommandConflict = errors.New("el nombre o alias ya está en uso")
+
+// conflictError conserva el mensaje puntual para el chat y se reconoce con
+// errors.Is(err, ErrCommandConflict).
+type conflictError struct{ msg string }
+
+func (e *conflictError) Error() string { return e.msg }
+func (e *conflictError) Unwrap() error { return ErrCommandConflict }
+
+func commandConflict(format string, args ...any) error {
+	return &conflictError{msg: fmt.Sprintf(format, args...)}
+}
+
 func normalizeCommandName(name string) string {
 	return strings.ToLower(strings.TrimSpace(name))
 }
 
 func (m *CustomCommandManager) ensureNoConflicts(name string, created bool, aliases []string, hasAliases bool) error {
 	if created && m.isReserved != nil && m.isReserved(name) {
-		return fmt.Errorf("el nombre %q está reservado por otro comando", name)
+		return commandConflict("el nombre %q está reservado por otro comando", name)
 	}
 
 	if hasAliases && m.isReserved != nil {
@@ -240,7 +474,7 @@ func (m *CustomCommandManager) ensureNoConflicts(name string, created bool, alia
 				continue
 			}
 			if m.isReserved(alias) {
-				return fmt.Errorf("el alias %q está reservado por otro comando", alias)
+				return commandConflict("el alias %q está reservado por otro comando", alias)
 			}
 		}
 	}
@@ -248,21 +482,28 @@ func (m *CustomCommandManager) ensureNoConflicts(name string, created bool, alia
 	for existingName, cmd := range m.commands {
 		if existingName == name {
 			if created {
-				return fmt.Errorf("ya existe un comando con ese nombre")
+				return commandConflict("ya existe un comando con ese nombre")
 			}
 			continue
 		}
+		if created {
+			for _, otherAlias := range cmd.Aliases {
+				if name == normalizeCommandName(otherAlias) {
+					return commandConflict("el nombre %q ya es alias de %s", name, existingName)
+				}
+			}
+		}
 		if hasAliases {
 			for _, alias := range aliases {
 				if alias == "" {
 					continue
 				}
 				if alias == existingName {
-					return fmt.Errorf("el alias %q coincide con otro comando", alias)
+					return commandConflict("el alias %q coincide con otro comando", alias)
 				}
 				for _, otherAlias := range cmd.Aliases {
 					if alias == normalizeCommandName(otherAlias) {
-						return fmt.Errorf("el alias %q ya está en uso", alias)
+						return commandConflict("el alias %q ya está en uso", alias)
 					}
 				}
 			}
@@ -278,6 +519,36 @@ func (m *CustomCommandManager) SetReservedChecker(fn func(string) bool) {
 	m.isReserved = fn
 }
 
+// SetDefaultCooldownFeedback define el modo usado por los comandos sin modo propio.
+func (m *CustomCommandManager) SetDefaultCooldownFeedback(mode domain.CooldownFeedbackMode) {
+	if m == nil {
+		return
+	}
+	m.cooldowns.setDefaultMode(mode)
+}
+
+func (m *CustomCommandManager) DefaultCooldownFeedback() domain.CooldownFeedbackMode {
+	if m == nil {
+		return domain.CooldownFeedbackSilent
+	}
+	return m.cooldowns.defaultModeValue()
+}
+
+// SetDefaultPermissionReply define si se avisa cuando falta permiso (por defecto silent).
+func (m *CustomCommandManager) SetDefaultPermissionReply(mode domain.PermissionReplyMode) {
+	if m == nil {
+		return
+	}
+	m.denied.setDefaultMode(mode)
+}
+
+func (m *CustomCommandManager) DefaultPermissionReply() domain.PermissionReplyMode {
+	if m == nil {
+		return domain.PermissionReplySilent
+	}
+	return m.denied.defaultModeValue()
+}
+
 func (m *CustomCommandManager) SetAudienceResolver(resolver CommandAudienceResolver) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -344,11 +615,34 @@ func normalizePermissions(values []domain.CommandAccessRole) []domain.CommandAcc
 	return out
 }
 
+// normalizeResponsePool recorta los textos y exige al menos una respuesta,
+// todas con texto y peso positivo.
+func normalizeResponsePool(values []domain.WeightedResponse) ([]domain.WeightedResponse, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("el pool necesita al menos una respuesta")
+	}
+	out := make([]domain.WeightedResponse, 0, len(values))
+	for i, v := range values {
+		text := strings.TrimSpace(v.Text)
+		if text == "" {
+			return nil, fmt.Errorf("la respuesta %d está vacía", i+1)
+		}
+		if v.Weight <= 0 {
+			return nil, fmt.Errorf("la respuesta %d tiene peso %d; debe ser positivo", i+1, v.Weight)
+		}
+		out = append(out, domain.WeightedResponse{Text: text, Weight: v.Weight})
+	}
+	return out, nil
+}
+
 func cloneCommand(cmd *domain.CustomCommand) *domain.CustomCommand {
 	if cmd == nil {
 		return nil
 	}
 	copyCmd := *cmd
+	if cmd.Responses != nil {
+		copyCmd.Responses = append([]domain.WeightedResponse(nil), cmd.Responses...)
+	}
 	if cmd.Aliases != nil {
 		copyCmd.Aliases = append([]string(nil), cmd.Aliases...)
 	}
@@ -362,6 +656,13 @@ func cloneCommand(cmd *domain.CustomCommand) *domain.CustomCommand {
 }
 
 func (m *CustomCommandManager) isAllowed(ctx context.Context, cmd *domain.CustomCommand, msg domain.Message) bool {
+	m.mu.RLock()
+	var audience CommandAudienceResolver = messageAudience{}
+	if m.audienceResolver != nil {
+		audience = m.audienceResolver
+	}
+	m.mu.RUnlock()
+
 	roles := cmd.Permissions
 	if len(roles) == 0 {
 		return true
@@ -371,11 +672,22 @@ func (m *CustomCommandManager) isAllowed(ctx context.Context, cmd *domain.Custom
 		case domain.CommandAccessEveryone:
 			return true
 		case domain.CommandAccessSubscribers:
-			if msg.IsSubscriber {
+			ok, err := audience.IsSubscriber(ctx, msg)
+			if err != nil {
+				log.Printf("custom command subscriber check failed: %v", err)
+			}
+			if ok {
 				return true
 			}
 		case domain.CommandAccessModerators:
-			if msg.IsPlatformMod || msg.IsPlatformAdmin || msg.IsPlatformOwner {
+			if msg.IsPlatformOwner {
+				return true
+			}
+			ok, err := audience.IsModerator(ctx, msg)
+			if err != nil {
+				log.Printf("custom command moderator check failed: %v", err)
+			}
+			if ok {
 				return true
 			}
 		case domain.CommandAccessVIPs:
@@ -387,14 +699,12 @@ func (m *CustomCommandManager) isAllowed(ctx context.Context, cmd *domain.Custom
 				return true
 			}
 		case domain.CommandAccessFollowers:
-			if m.audienceResolver != nil {
-				ok, err := m.audienceResolver.IsFollower(ctx, msg)
-				if err != nil {
-					log.Printf("custom command follower check failed: %v", err)
-				}
-				if ok {
-					return true
-				}
+			ok, err := audience.IsFollower(ctx, msg)
+			if err != nil {
+				log.Printf("custom command follower check failed: %v", err)
+			}
+			if ok {
+				return true
 			}
 		default:
 			if role == "" {
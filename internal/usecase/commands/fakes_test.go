@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// sentMessage es un mensaje que el comando mandó al chat.
+type sentMessage struct {
+	Platform  domain.Platform
+	ChannelID string
+	Text      string
+}
+
+// captureOut guarda lo que se manda en vez de enviarlo.
+type captureOut struct {
+	mu   sync.Mutex
+	sent []sentMessage
+	err  error
+}
+
+func (o *captureOut) SendMessage(_ context.Context, platform domain.Platform, channelID, text string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, sentMessage{Platform: platform, ChannelID: channelID, Text: text})
+	return o.err
+}
+
+func (o *captureOut) messages() []sentMessage {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]sentMessage(nil), o.sent...)
+}
+
+func (o *captureOut) texts() []string {
+	var out []string
+	for _, m := range o.messages() {
+		out = append(out, m.Text)
+	}
+	return out
+}
+
+func (o *captureOut) last() string {
+	texts := o.texts()
+	if len(texts) == 0 {
+		return ""
+	}
+	return texts[len(texts)-1]
+}
+
+func (o *captureOut) reset() {
+	o.mu.Lock()
+	o.sent = nil
+	o.mu.Unlock()
+}
+
+// whisperOut además acepta mensajes privados.
+type whisperOut struct {
+	captureOut
+	whispers []sentMessage
+	err      error
+}
+
+func (o *whisperOut) SendPrivateMessage(_ context.Context, platform domain.Platform, userID, _ string, text string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.err != nil {
+		return o.err
+	}
+	o.whispers = append(o.whispers, sentMessage{Platform: platform, ChannelID: userID, Text: text})
+	return nil
+}
+
+// fakeTwitch implementa lo que usan los comandos de domain.TwitchChannelService;
+// el resto de los métodos entra en pánico si se llama.
+type fakeTwitch struct {
+	domain.TwitchChannelService
+
+	mu          sync.Mutex
+	users       map[string]*domain.TwitchUser
+	err         error
+	announceErr error
+	calls       []string
+}
+
+func (f *fakeTwitch) record(call string) {
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.mu.Unlock()
+}
+
+func (f *fakeTwitch) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeTwitch) GetUserByLogin(_ context.Context, login string) (*domain.TwitchUser, error) {
+	f.record("user:" + login)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.users[strings.ToLower(login)], nil
+}
+
+func (f *fakeTwitch) SendAnnouncement(_ context.Context, broadcasterID, moderatorID, text, color string) error {
+	f.record("announce:" + broadcasterID + "/" + moderatorID + "/" + color + "/" + text)
+	return f.announceErr
+}
+
+func twitchMessage(user, text string) domain.Message {
+	return domain.Message{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: "canal",
+		UserID:    "id-" + user,
+		Username:  user,
+		Login:     strings.ToLower(user),
+		Text:      text,
+	}
+}
+
+func newCmdContext(msg domain.Message, out domain.OutgoingMessagePort, args ...string) *Context {
+	return &Context{Message: msg, Out: out, Raw: msg.Text, Args: args}
+}
+
+// memoryCommandRepo guarda los comandos personalizados en memoria.
+type memoryCommandRepo struct {
+	mu       sync.Mutex
+	commands map[string]*domain.CustomCommand
+}
+
+func newMemoryCommandRepo(cmds ...*domain.CustomCommand) *memoryCommandRepo {
+	repo := &memoryCommandRepo{commands: make(map[string]*domain.CustomCommand)}
+	for _, cmd := range cmds {
+		repo.commands[cmd.Name] = cmd
+	}
+	return repo
+}
+
+func (r *memoryCommandRepo) UpsertCustomCommand(_ context.Context, cmd *domain.CustomCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *cmd
+	r.commands[cmd.Name] = &copied
+	return nil
+}
+
+func (r *memoryCommandRepo) GetCustomCommand(_ context.Context, name string) (*domain.CustomCommand, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.commands[name], nil
+}
+
+func (r *memoryCommandRepo) ListCustomCommands(context.Context) ([]*domain.CustomCommand, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*domain.CustomCommand, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		copied := *cmd
+		out = append(out, &copied)
+	}
+	return out, nil
+}
+
+func (r *memoryCommandRepo) DeleteCustomCommand(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.commands, name)
+	return nil
+}
+
+// countingCommandRepo además cuenta con COUNT(*) como el store de SQLite.
+type countingCommandRepo struct {
+	*memoryCommandRepo
+	counted int
+}
+
+func (r *countingCommandRepo) CountCustomCommands(context.Context) (int, error) {
+	r.counted++
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.commands), nil
+}
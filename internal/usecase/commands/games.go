@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+	gamesusecase "zhatBot/internal/usecase/games"
+)
+
+// maxListedRolls es cuántos dados se muestran uno por uno; con más solo va
+// la suma.
+const maxListedRolls = 10
+
+// ChatGames tira los dados y elige las respuestas de los juegos
+// (games.Service).
+type ChatGames interface {
+	Allows(game string, platform domain.Platform, channelID string) bool
+	Roll(dice gamesusecase.Dice) ([]int, int)
+	EightBall() string
+	Coinflip() bool
+}
+
+// replyToUser responde en el canal mencionando a quien escribió.
+func replyToUser(ctx context.Context, cmdCtx *Context, text string) error {
+	msg := cmdCtx.Message
+	name := msg.Name()
+	if name == "" {
+		name = "usuario"
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, fmt.Sprintf("@%s %s", name, text))
+}
+
+// RollCommand implementa !roll [NdM].
+type RollCommand struct {
+	games ChatGames
+}
+
+func NewRollCommand(games ChatGames) *RollCommand {
+	return &RollCommand{games: games}
+}
+
+func (c *RollCommand) Name() string {
+	return domain.GameRoll
+}
+
+func (c *RollCommand) Aliases() []string {
+	return []string{"dado"}
+}
+
+func (c *RollCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *RollCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.games == nil || !c.games.Allows(domain.GameRoll, msg.Platform, msg.ChannelID) {
+		return nil
+	}
+	raw := ""
+	if len(cmdCtx.Args) > 0 {
+		raw = cmdCtx.Args[0]
+	}
+	dice, err := gamesusecase.ParseDice(raw)
+	if err != nil {
+		if errors.Is(err, gamesusecase.ErrDiceTooLarge) {
+			return replyToUser(ctx, cmdCtx, fmt.Sprintf("🎲 Son demasiados dados: %v.", err))
+		}
+		return replyToUser(ctx, cmdCtx, "🎲 Uso: !roll [NdM], p. ej. !roll 2d6")
+	}
+	rolls, total := c.games.Roll(dice)
+	if len(rolls) == 1 || len(rolls) > maxListedRolls {
+		return replyToUser(ctx, cmdCtx, fmt.Sprintf("🎲 %s: %d", dice, total))
+	}
+	parts := make([]string, len(rolls))
+	for i, roll := range rolls {
+		parts[i] = strconv.Itoa(roll)
+	}
+	return replyToUser(ctx, cmdCtx, fmt.Sprintf("🎲 %s: %s = %d", dice, strings.Join(parts, " + "), total))
+}
+
+// EightBallCommand implementa !8ball <pregunta>.
+type EightBallCommand struct {
+	games ChatGames
+}
+
+func NewEightBallCommand(games ChatGames) *EightBallCommand {
+	return &EightBallCommand{games: games}
+}
+
+func (c *EightBallCommand) Name() string {
+	return domain.GameEightBall
+}
+
+func (c *EightBallCommand) Aliases() []string {
+	return nil
+}
+
+func (c *EightBallCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *EightBallCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.games == nil || !c.games.Allows(domain.GameEightBall, msg.Platform, msg.ChannelID) {
+		return nil
+	}
+	if len(cmdCtx.Args) == 0 {
+		return replyToUser(ctx, cmdCtx, "🎱 Uso: !8ball <pregunta>")
+	}
+	return replyToUser(ctx, cmdCtx, "🎱 "+c.games.EightBall())
+}
+
+// CoinflipCommand implementa !coinflip.
+type CoinflipCommand struct {
+	games ChatGames
+}
+
+func NewCoinflipCommand(games ChatGames) *CoinflipCommand {
+	return &CoinflipCommand{games: games}
+}
+
+func (c *CoinflipCommand) Name() string {
+	return domain.GameCoinflip
+}
+
+func (c *CoinflipCommand) Aliases() []string {
+	return []string{"moneda"}
+}
+
+func (c *CoinflipCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *CoinflipCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.games == nil || !c.games.Allows(domain.GameCoinflip, msg.Platform, msg.ChannelID) {
+		return nil
+	}
+	if c.games.Coinflip() {
+		return replyToUser(ctx, cmdCtx, "🪙 ¡Cara!")
+	}
+	return replyToUser(ctx, cmdCtx, "🪙 ¡Cruz!")
+}
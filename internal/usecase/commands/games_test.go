@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/domain"
+	gamesusecase "zhatBot/internal/usecase/games"
+)
+
+// fixedGames devuelve siempre el mismo resultado.
+type fixedGames struct {
+	disabled map[string]bool
+	heads    bool
+}
+
+func (g *fixedGames) Allows(game string, _ domain.Platform, _ string) bool {
+	return !g.disabled[game]
+}
+
+func (g *fixedGames) Roll(dice gamesusecase.Dice) ([]int, int) {
+	rolls := make([]int, dice.Count)
+	for i := range rolls {
+		rolls[i] = dice.Sides
+	}
+	return rolls, dice.Count * dice.Sides
+}
+
+func (g *fixedGames) EightBall() string { return "Sí." }
+func (g *fixedGames) Coinflip() bool    { return g.heads }
+
+func runGame(t *testing.T, cmd Command, args ...string) string {
+	t.Helper()
+	out := &captureOut{}
+	if err := cmd.Handle(context.Background(), newCmdContext(twitchMessage("Ana", "!juego"), out, args...)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	return out.last()
+}
+
+func TestRollCommandReplies(t *testing.T) {
+	cmd := NewRollCommand(&fixedGames{})
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "@Ana 🎲 1d20: 20"},
+		{[]string{"3d6"}, "@Ana 🎲 3d6: 6 + 6 + 6 = 18"},
+		{[]string{"12d2"}, "@Ana 🎲 12d2: 24"},
+		{[]string{"9999999d9999999"}, "@Ana 🎲 Son demasiados dados: como mucho 20 dados de 1000 caras."},
+		{[]string{"tres"}, "@Ana 🎲 Uso: !roll [NdM], p. ej. !roll 2d6"},
+	}
+	for _, tt := range tests {
+		if got := runGame(t, cmd, tt.args...); got != tt.want {
+			t.Errorf("!roll %v = %q, esperaba %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestEightBallAndCoinflip(t *testing.T) {
+	games := &fixedGames{heads: true}
+	if got := runGame(t, NewEightBallCommand(games), "¿gano?"); got != "@Ana 🎱 Sí." {
+		t.Fatalf("!8ball = %q", got)
+	}
+	if got := runGame(t, NewEightBallCommand(games)); got != "@Ana 🎱 Uso: !8ball <pregunta>" {
+		t.Fatalf("!8ball sin pregunta = %q", got)
+	}
+	if got := runGame(t, NewCoinflipCommand(games)); got != "@Ana 🪙 ¡Cara!" {
+		t.Fatalf("!coinflip = %q", got)
+	}
+	games.heads = false
+	if got := runGame(t, NewCoinflipCommand(games)); got != "@Ana 🪙 ¡Cruz!" {
+		t.Fatalf("!coinflip = %q", got)
+	}
+}
+
+func TestDisabledGamesStaySilent(t *testing.T) {
+	games := &fixedGames{disabled: map[string]bool{
+		domain.GameRoll:      true,
+		domain.GameEightBall: true,
+		domain.GameCoinflip:  true,
+	}}
+	for _, cmd := range []Command{NewRollCommand(games), NewEightBallCommand(games), NewCoinflipCommand(games)} {
+		if got := runGame(t, cmd, "algo"); got != "" {
+			t.Errorf("%s desactivado respondió %q", cmd.Name(), got)
+		}
+	}
+}
+
+func TestGamesListedInCatalog(t *testing.T) {
+	usage := make(map[string]string)
+	for _, desc := range BuiltinCommandCatalog() {
+		usage[desc.Name] = desc.Usage
+	}
+	for _, game := range domain.ChatGames() {
+		if usage[game] == "" {
+			t.Errorf("%s no está en el catálogo o no tiene uso", game)
+		}
+	}
+}
@@ -29,7 +29,7 @@ func (c *KickCategoryCommand) SupportsPlatform(p domain.Platform) bool {
 func (c *KickCategoryCommand) Handle(ctx context.Context, cmdCtx *Context) error {
 	msg := cmdCtx.Message
 
-	if !strings.EqualFold(msg.Username, c.OwnerName) {
+	if !strings.EqualFold(msg.LoginName(), c.OwnerName) {
 		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
 			"❌ Solo el dueño del canal puede cambiar la categoría en Kick.")
 	}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeKickStream struct {
+	domain.KickStreamService
+	category string
+}
+
+func (f *fakeKickStream) SetCategory(_ context.Context, name string) error {
+	f.category = name
+	return nil
+}
+
+func TestKickCategoryMatchesOwnerByLogin(t *testing.T) {
+	cases := []struct {
+		name        string
+		msg         domain.Message
+		wantAllowed bool
+	}{
+		{
+			name:        "display name differs from login",
+			msg:         domain.Message{Platform: domain.PlatformKick, Username: "Zero_Project", DisplayName: "Zero_Project", Login: "zeroproject"},
+			wantAllowed: true,
+		},
+		{
+			name:        "legacy message without login",
+			msg:         domain.Message{Platform: domain.PlatformKick, Username: "ZeroProject"},
+			wantAllowed: true,
+		},
+		{
+			name:        "display name impersonating the owner",
+			msg:         domain.Message{Platform: domain.PlatformKick, Username: "zeroproject", DisplayName: "zeroproject", Login: "otro"},
+			wantAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeKickStream{}
+			out := &captureOut{}
+			cmd := NewKickCategoryCommand(svc, "zeroproject")
+
+			if err := cmd.Handle(context.Background(), newCmdContext(tc.msg, out, "Just", "Chatting")); err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if got := svc.category == "Just Chatting"; got != tc.wantAllowed {
+				t.Fatalf("category changed = %v (reply %q), want %v", got, out.last(), tc.wantAllowed)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ func (c *KickTitleCommand) Handle(ctx context.Context, cmdCtx *Context) error {
 	msg := cmdCtx.Message
 
 	// Solo el owner del canal en Kick
-	if !strings.EqualFold(msg.Username, c.OwnerName) {
+	if !strings.EqualFold(msg.LoginName(), c.OwnerName) {
 		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
 			"❌ Solo el dueño del canal puede cambiar el título en Kick.")
 	}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"zhatBot/internal/domain"
+)
+
+// LurkTracker lleva la lista de lurkers (lurkers.Service).
+type LurkTracker interface {
+	Lurk(ctx context.Context, msg domain.Message) string
+	Unlurk(ctx context.Context, msg domain.Message) (string, bool)
+	Count(ctx context.Context) int
+}
+
+// LurkCommand implementa !lurk.
+type LurkCommand struct {
+	tracker LurkTracker
+}
+
+func NewLurkCommand(tracker LurkTracker) *LurkCommand {
+	return &LurkCommand{tracker: tracker}
+}
+
+func (c *LurkCommand) Name() string {
+	return "lurk"
+}
+
+func (c *LurkCommand) Aliases() []string {
+	return nil
+}
+
+func (c *LurkCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *LurkCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if c.tracker == nil {
+		return nil
+	}
+	msg := cmdCtx.Message
+	text := c.tracker.Lurk(ctx, msg)
+	if text == "" {
+		return nil
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+}
+
+// UnlurkCommand implementa !unlurk.
+type UnlurkCommand struct {
+	tracker LurkTracker
+}
+
+func NewUnlurkCommand(tracker LurkTracker) *UnlurkCommand {
+	return &UnlurkCommand{tracker: tracker}
+}
+
+func (c *UnlurkCommand) Name() string {
+	return "unlurk"
+}
+
+func (c *UnlurkCommand) Aliases() []string {
+	return nil
+}
+
+func (c *UnlurkCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *UnlurkCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if c.tracker == nil {
+		return nil
+	}
+	msg := cmdCtx.Message
+	text, ok := c.tracker.Unlurk(ctx, msg)
+	if !ok {
+		return nil
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+}
+
+// LurkersCommand implementa !lurkers (solo mods).
+type LurkersCommand struct {
+	tracker LurkTracker
+}
+
+func NewLurkersCommand(tracker LurkTracker) *LurkersCommand {
+	return &LurkersCommand{tracker: tracker}
+}
+
+func (c *LurkersCommand) Name() string {
+	return "lurkers"
+}
+
+func (c *LurkersCommand) Aliases() []string {
+	return nil
+}
+
+func (c *LurkersCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *LurkersCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if c.tracker == nil {
+		return nil
+	}
+	msg := cmdCtx.Message
+	if !msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod {
+		return nil
+	}
+	count := c.tracker.Count(ctx)
+	text := fmt.Sprintf("👀 Hay %d lurkers en este stream.", count)
+	if count == 1 {
+		text = "👀 Hay 1 lurker en este stream."
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/usecase/lurkers"
+)
+
+func TestLurkCommands(t *testing.T) {
+	ctx := context.Background()
+	tracker := lurkers.NewService(nil)
+	out := &captureOut{}
+
+	ana := twitchMessage("ana", "!lurk")
+	if err := NewLurkCommand(tracker).Handle(ctx, newCmdContext(ana, out)); err != nil {
+		t.Fatalf("lurk: %v", err)
+	}
+	if got := out.last(); got != "👀 ana se fue a lurkear. ¡Gracias por quedarte!" {
+		t.Fatalf("lurk reply = %q", got)
+	}
+
+	// !lurkers es solo para mods
+	out.reset()
+	lurkersCmd := NewLurkersCommand(tracker)
+	if err := lurkersCmd.Handle(ctx, newCmdContext(twitchMessage("beto", "!lurkers"), out)); err != nil {
+		t.Fatalf("lurkers: %v", err)
+	}
+	if got := out.texts(); len(got) != 0 {
+		t.Fatalf("non-mod got %q", got)
+	}
+	mod := twitchMessage("beto", "!lurkers")
+	mod.IsPlatformMod = true
+	if err := lurkersCmd.Handle(ctx, newCmdContext(mod, out)); err != nil {
+		t.Fatalf("lurkers: %v", err)
+	}
+	if got := out.last(); got != "👀 Hay 1 lurker en este stream." {
+		t.Fatalf("lurkers reply = %q", got)
+	}
+
+	// !unlurk sin estar lurkeando no responde
+	out.reset()
+	unlurk := NewUnlurkCommand(tracker)
+	if err := unlurk.Handle(ctx, newCmdContext(twitchMessage("beto", "!unlurk"), out)); err != nil {
+		t.Fatalf("unlurk: %v", err)
+	}
+	if got := out.texts(); len(got) != 0 {
+		t.Fatalf("unlurk without lurk replied %q", got)
+	}
+	if err := unlurk.Handle(ctx, newCmdContext(twitchMessage("ana", "!unlurk"), out)); err != nil {
+		t.Fatalf("unlurk: %v", err)
+	}
+	if got := out.last(); got != "👋 ¡Bienvenido de vuelta, ana! Estuviste lurkeando menos de un minuto." {
+		t.Fatalf("unlurk reply = %q", got)
+	}
+}
@@ -3,7 +3,9 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"zhatBot/internal/domain"
 )
@@ -49,6 +51,9 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 	if payload == "" {
 		return c.usage(ctx, cmdCtx)
 	}
+	if strings.EqualFold(payload, "reload") {
+		return c.reload(ctx, cmdCtx)
+	}
 
 	name, rest, found := strings.Cut(payload, " ")
 	if !found {
@@ -60,6 +65,14 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 		return c.usage(ctx, cmdCtx)
 	}
 
+	sub, args := cutNext(rest)
+	switch strings.ToLower(sub) {
+	case "add-response":
+		return c.addResponse(ctx, cmdCtx, name, args)
+	case "remove-response":
+		return c.removeResponse(ctx, cmdCtx, name, args)
+	}
+
 	var aliases []string
 	var platforms []domain.Platform
 	var permissions []domain.CommandAccessRole
@@ -68,6 +81,14 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 	var hasAliases bool
 	var hasPlatforms bool
 	var hasPermissions bool
+	var cooldown time.Duration
+	var hasCooldown bool
+	var userCooldown time.Duration
+	var hasUserCooldown bool
+	var feedback domain.CooldownFeedbackMode
+	var hasFeedback bool
+	var permissionReply domain.PermissionReplyMode
+	var hasPermissionReply bool
 	action := ""
 
 	for {
@@ -93,6 +114,36 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 			permissions = parsePermissions(token[len("permissions:"):])
 			rest = remaining
 			continue
+		case strings.HasPrefix(lower, "cooldown:"):
+			parsed, ok := parseCooldownArg(token[len("cooldown:"):])
+			if !ok {
+				return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+					"⚠️ Cooldown inválido, usa segundos o una duración (ej. cooldown:30 o cooldown:1m).")
+			}
+			hasCooldown = true
+			cooldown = parsed
+			rest = remaining
+			continue
+		case strings.HasPrefix(lower, "usercooldown:"):
+			parsed, ok := parseCooldownArg(token[len("usercooldown:"):])
+			if !ok {
+				return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+					"⚠️ Cooldown por usuario inválido, usa segundos o una duración (ej. usercooldown:30s).")
+			}
+			hasUserCooldown = true
+			userCooldown = parsed
+			rest = remaining
+			continue
+		case strings.HasPrefix(lower, "feedback:"):
+			hasFeedback = true
+			feedback = domain.CooldownFeedbackMode(token[len("feedback:"):])
+			rest = remaining
+			continue
+		case strings.HasPrefix(lower, "denied:"):
+			hasPermissionReply = true
+			permissionReply = domain.PermissionReplyMode(token[len("denied:"):])
+			rest = remaining
+			continue
 		case strings.HasPrefix(lower, "action:"):
 			action = strings.TrimSpace(token[len("action:"):])
 			rest = remaining
@@ -134,7 +185,7 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 			fmt.Sprintf("🗑️ Comando %s eliminado.", name))
 	}
 
-	result, created, err := c.manager.Upsert(ctx, UpdateCustomCommandInput{
+	result, created, warnings, err := c.manager.Upsert(ctx, UpdateCustomCommandInput{
 		Name:           name,
 		Response:       responsePtr,
 		Aliases:        aliases,
@@ -143,6 +194,16 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 		HasPlatforms:   hasPlatforms,
 		Permissions:    permissions,
 		HasPermissions: hasPermissions,
+
+		Cooldown:            cooldown,
+		HasCooldown:         hasCooldown,
+		UserCooldown:        userCooldown,
+		HasUserCooldown:     hasUserCooldown,
+		CooldownFeedback:    feedback,
+		HasCooldownFeedback: hasFeedback,
+
+		PermissionReply:    permissionReply,
+		HasPermissionReply: hasPermissionReply,
 	})
 	if err != nil {
 		return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
@@ -154,13 +215,87 @@ func (c *ManageCustomCommand) Handle(ctx context.Context, cmdCtx *Context) error
 		actionMsg = "creado"
 	}
 
+	reply := fmt.Sprintf("✅ Comando %s %s.", result.Name, actionMsg)
+	if len(warnings) > 0 {
+		reply += " " + strings.Join(warnings, " ")
+	}
+	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID, reply)
+}
+
+// addResponse maneja `!command <nombre> add-response [weight:N] "texto"`.
+func (c *ManageCustomCommand) addResponse(ctx context.Context, cmdCtx *Context, name, args string) error {
+	weight := 1
+	if token, remaining := cutNext(args); strings.HasPrefix(strings.ToLower(token), "weight:") {
+		parsed, err := strconv.Atoi(strings.TrimSpace(token[len("weight:"):]))
+		if err != nil || parsed <= 0 {
+			return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+				"⚠️ El peso debe ser un número positivo (ej. weight:3).")
+		}
+		weight = parsed
+		args = remaining
+	}
+	text := unquote(args)
+	if text == "" {
+		return c.usage(ctx, cmdCtx)
+	}
+
+	result, warnings, err := c.manager.AddResponse(ctx, name, text, weight)
+	if err != nil {
+		return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+			fmt.Sprintf("⚠️ %v", err))
+	}
+	reply := fmt.Sprintf("✅ Respuesta agregada a %s (%d en total).", result.Name, len(result.ResponsePool()))
+	if len(warnings) > 0 {
+		reply += " " + strings.Join(warnings, " ")
+	}
+	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID, reply)
+}
+
+// removeResponse maneja `!command <nombre> remove-response <n>` (n desde 1).
+func (c *ManageCustomCommand) removeResponse(ctx context.Context, cmdCtx *Context, name, args string) error {
+	index, err := strconv.Atoi(args)
+	if err != nil {
+		return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+			"⚠️ Indica el número de la respuesta a quitar (ej. remove-response 2).")
+	}
+	result, err := c.manager.RemoveResponse(ctx, name, index)
+	if err != nil {
+		return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+			fmt.Sprintf("⚠️ %v", err))
+	}
+	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+		fmt.Sprintf("🗑️ Respuesta %d quitada de %s (quedan %d).", index, result.Name, len(result.ResponsePool())))
+}
+
+// reload vuelve a leer los comandos de la base (solo el dueño del canal).
+func (c *ManageCustomCommand) reload(ctx context.Context, cmdCtx *Context) error {
+	if !cmdCtx.Message.IsPlatformOwner {
+		return nil
+	}
+	if err := c.manager.Reload(ctx); err != nil {
+		return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
+			fmt.Sprintf("⚠️ No pude recargar los comandos: %v", err))
+	}
 	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
-		fmt.Sprintf("✅ Comando %s %s.", result.Name, actionMsg))
+		fmt.Sprintf("✅ Comandos recargados (%d personalizados).", len(c.manager.List())))
 }
 
 func (c *ManageCustomCommand) usage(ctx context.Context, cmdCtx *Context) error {
 	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
-		"Uso: !command <nombre> [aliases:a,b] [platforms:twitch,kick] [permissions:everyone,subscribers] [action:delete] <respuesta>")
+		"Uso: !command <nombre> [aliases:a,b] [platforms:twitch,kick] [permissions:everyone,subscribers] [cooldown:30] [usercooldown:30s] [feedback:silent|reply-once|whisper] [denied:silent|reply] [action:delete] <respuesta> | !command <nombre> add-response [weight:N] \"texto\" | !command <nombre> remove-response <n> | !command reload")
+}
+
+// parseCooldownArg acepta segundos ("30") o una duración ("30s", "1m").
+func parseCooldownArg(raw string) (time.Duration, bool) {
+	raw = strings.TrimSpace(raw)
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(seconds) * time.Second, seconds >= 0
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
 }
 
 func cutNext(input string) (token string, rest string) {
@@ -176,6 +311,18 @@ func cutNext(input string) (token string, rest string) {
 	return token, rest
 }
 
+// unquote recorta espacios y, si el texto viene entre comillas, las quita.
+func unquote(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 2 {
+		first, last := raw[0], raw[len(raw)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			raw = strings.TrimSpace(raw[1 : len(raw)-1])
+		}
+	}
+	return raw
+}
+
 func parseCSV(raw string) []string {
 	var out []string
 	for _, part := range strings.Split(raw, ",") {
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"zhatBot/internal/domain"
+	moderationusecase "zhatBot/internal/usecase/moderation"
+)
+
+// defaultTimeout es la duración de !timeout sin argumento (la misma que usa
+// /timeout en Twitch).
+const defaultTimeout = 10 * time.Minute
+
+// ModerationActions aplica sanciones en la plataforma (moderation.Actions).
+type ModerationActions interface {
+	Ban(ctx context.Context, platform domain.Platform, login, reason string) (string, error)
+	Timeout(ctx context.Context, platform domain.Platform, login string, duration time.Duration, reason string) (time.Duration, error)
+	DeleteLast(ctx context.Context, platform domain.Platform, login string) error
+}
+
+func canModerate(msg domain.Message) bool {
+	return msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod
+}
+
+// moderationTarget lee el usuario del primer argumento y descarta sancionarse
+// a uno mismo.
+func moderationTarget(msg domain.Message, args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	login := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(args[0]), "@"))
+	if login == "" || login == msg.LoginName() {
+		return "", false
+	}
+	return login, true
+}
+
+// parseTimeoutDuration acepta segundos ("600"), un número con unidad ("10m",
+// "2h", "1d", "1w") o una duración de Go ("1h30m").
+func parseTimeoutDuration(arg string) (time.Duration, bool) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if arg == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(arg); err == nil {
+		return time.Duration(n) * time.Second, n > 0
+	}
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[arg[len(arg)-1]]; ok {
+		n, err := strconv.Atoi(arg[:len(arg)-1])
+		return time.Duration(n) * unit, err == nil && n > 0
+	}
+	d, err := time.ParseDuration(arg)
+	return d, err == nil && d > 0
+}
+
+// formatTimeout muestra la duración en la unidad más grande que sea exacta.
+func formatTimeout(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// moderationFailure traduce el error de una sanción a un aviso para el chat.
+func moderationFailure(login string, err error) string {
+	switch {
+	case errors.Is(err, moderationusecase.ErrModerationUnavailable):
+		return "⚠️ La moderación no está disponible: conecta la cuenta del streamer."
+	case errors.Is(err, moderationusecase.ErrProtectedUser):
+		return fmt.Sprintf("⚠️ No puedo sancionar a %s (es moderador o el streamer).", login)
+	case errors.Is(err, moderationusecase.ErrNoRecentMessage):
+		return fmt.Sprintf("⚠️ No vi mensajes recientes de %s.", login)
+	case errors.Is(err, domain.ErrModerationUserNotFound):
+		return fmt.Sprintf("⚠️ No encontré a %s.", login)
+	}
+	return fmt.Sprintf("⚠️ No pude sancionar a %s.", login)
+}
+
+type moderationCommand struct {
+	actions ModerationActions
+}
+
+func (c moderationCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c moderationCommand) reply(ctx context.Context, cmdCtx *Context, text string) error {
+	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID, text)
+}
+
+// BanCommand banea a un usuario: !ban <usuario> [motivo].
+// banScopes son los que necesitan !ban y !timeout en cada plataforma. En
+// Twitch sanciona el bot con su token; en Kick, el streamer.
+func banScopes(p domain.Platform) (string, []string) {
+	switch p {
+	case domain.PlatformTwitch:
+		return "bot", []string{"moderator:manage:banned_users"}
+	case domain.PlatformKick:
+		return "streamer", []string{"moderation:ban"}
+	}
+	return "", nil
+}
+
+type BanCommand struct {
+	moderationCommand
+}
+
+func NewBanCommand(actions ModerationActions) *BanCommand {
+	return &BanCommand{moderationCommand{actions: actions}}
+}
+
+func (c *BanCommand) Name() string {
+	return "ban"
+}
+
+func (c *BanCommand) RequiredScopes(p domain.Platform) (string, []string) {
+	return banScopes(p)
+}
+
+func (c *BanCommand) Aliases() []string {
+	return nil
+}
+
+func (c *BanCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.actions == nil || !canModerate(msg) {
+		return nil
+	}
+	login, ok := moderationTarget(msg, cmdCtx.Args)
+	if !ok {
+		return c.reply(ctx, cmdCtx, "Uso: !ban <usuario> [motivo]")
+	}
+	reason := strings.Join(cmdCtx.Args[1:], " ")
+
+	if _, err := c.actions.Ban(ctx, msg.Platform, login, reason); err != nil {
+		log.Printf("ban command: %v", err)
+		return c.reply(ctx, cmdCtx, moderationFailure(login, err))
+	}
+	return c.reply(ctx, cmdCtx, fmt.Sprintf("🔨 %s fue baneado.", login))
+}
+
+// TimeoutCommand silencia a un usuario: !timeout <usuario> [duración] [motivo].
+type TimeoutCommand struct {
+	moderationCommand
+}
+
+func NewTimeoutCommand(actions ModerationActions) *TimeoutCommand {
+	return &TimeoutCommand{moderationCommand{actions: actions}}
+}
+
+func (c *TimeoutCommand) Name() string {
+	return "timeout"
+}
+
+func (c *TimeoutCommand) RequiredScopes(p domain.Platform) (string, []string) {
+	return banScopes(p)
+}
+
+func (c *TimeoutCommand) Aliases() []string {
+	return []string{"to"}
+}
+
+func (c *TimeoutCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.actions == nil || !canModerate(msg) {
+		return nil
+	}
+	login, ok := moderationTarget(msg, cmdCtx.Args)
+	if !ok {
+		return c.reply(ctx, cmdCtx, "Uso: !timeout <usuario> [duración: 600, 10m, 1h, 1d] [motivo]")
+	}
+
+	// sin duración válida se usa la de siempre y el resto es el motivo
+	duration, rest := defaultTimeout, cmdCtx.Args[1:]
+	if len(rest) > 0 {
+		if d, ok := parseTimeoutDuration(rest[0]); ok {
+			duration, rest = d, rest[1:]
+		}
+	}
+
+	applied, err := c.actions.Timeout(ctx, msg.Platform, login, duration, strings.Join(rest, " "))
+	if err != nil {
+		log.Printf("timeout command: %v", err)
+		return c.reply(ctx, cmdCtx, moderationFailure(login, err))
+	}
+	return c.reply(ctx, cmdCtx, fmt.Sprintf("⏳ %s silenciado por %s.", login, formatTimeout(applied)))
+}
+
+// DeleteCommand borra el último mensaje de un usuario: !delete <usuario>.
+type DeleteCommand struct {
+	moderationCommand
+}
+
+func NewDeleteCommand(actions ModerationActions) *DeleteCommand {
+	return &DeleteCommand{moderationCommand{actions: actions}}
+}
+
+func (c *DeleteCommand) Name() string {
+	return "delete"
+}
+
+func (c *DeleteCommand) RequiredScopes(p domain.Platform) (string, []string) {
+	switch p {
+	case domain.PlatformTwitch:
+		return "bot", []string{"moderator:manage:chat_messages"}
+	case domain.PlatformKick:
+		return "streamer", []string{"moderation:chat_message:manage"}
+	}
+	return "", nil
+}
+
+func (c *DeleteCommand) Aliases() []string {
+	return []string{"del"}
+}
+
+func (c *DeleteCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.actions == nil || !canModerate(msg) {
+		return nil
+	}
+	login, ok := moderationTarget(msg, cmdCtx.Args)
+	if !ok {
+		return c.reply(ctx, cmdCtx, "Uso: !delete <usuario>")
+	}
+
+	if err := c.actions.DeleteLast(ctx, msg.Platform, login); err != nil {
+		log.Printf("delete command: %v", err)
+		return c.reply(ctx, cmdCtx, moderationFailure(login, err))
+	}
+	return c.reply(ctx, cmdCtx, fmt.Sprintf("🗑️ Borré el último mensaje de %s.", login))
+}
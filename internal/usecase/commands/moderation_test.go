@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	moderationusecase "zhatBot/internal/usecase/moderation"
+)
+
+// fakeActions anota lo que los comandos le piden a la moderación.
+type fakeActions struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeActions) Ban(_ context.Context, platform domain.Platform, login, reason string) (string, error) {
+	f.calls = append(f.calls, fmt.Sprintf("ban %s %s %q", platform, login, reason))
+	return "42", f.err
+}
+
+func (f *fakeActions) Timeout(_ context.Context, platform domain.Platform, login string, duration time.Duration, reason string) (time.Duration, error) {
+	f.calls = append(f.calls, fmt.Sprintf("timeout %s %s %s %q", platform, login, duration, reason))
+	return domain.ClampTimeout(platform, duration), f.err
+}
+
+func (f *fakeActions) DeleteLast(_ context.Context, platform domain.Platform, login string) error {
+	f.calls = append(f.calls, fmt.Sprintf("delete %s %s", platform, login))
+	return f.err
+}
+
+func TestParseTimeoutDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"600", 10 * time.Minute, true},
+		{"10m", 10 * time.Minute, true},
+		{"2H", 2 * time.Hour, true},
+		{"1d", 24 * time.Hour, true},
+		{"1w", 7 * 24 * time.Hour, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"0", 0, false},
+		{"-5", 0, false},
+		{"0d", 0, false},
+		{"xd", 0, false},
+		{"spam", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTimeoutDuration(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseTimeoutDuration(%q) = %s, %v; esperaba %s, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatTimeout(t *testing.T) {
+	tests := map[time.Duration]string{
+		48 * time.Hour:   "2d",
+		2 * time.Hour:    "2h",
+		90 * time.Minute: "90m",
+		90 * time.Second: "90s",
+	}
+	for in, want := range tests {
+		if got := formatTimeout(in); got != want {
+			t.Errorf("formatTimeout(%s) = %q, esperaba %q", in, got, want)
+		}
+	}
+}
+
+func runModeration(t *testing.T, cmd Command, msg domain.Message, args ...string) string {
+	t.Helper()
+	out := &captureOut{}
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, args...)); err != nil {
+		t.Fatalf("Handle(%v): %v", args, err)
+	}
+	return out.last()
+}
+
+func TestModerationCommandsCallActions(t *testing.T) {
+	actions := &fakeActions{}
+	mod := modMessage("luis", "")
+	kickMod := mod
+	kickMod.Platform = domain.PlatformKick
+
+	tests := []struct {
+		cmd   Command
+		msg   domain.Message
+		args  []string
+		reply string
+		call  string
+	}{
+		{NewBanCommand(actions), mod, []string{"@Troll", "spam", "de", "links"}, "🔨 troll fue baneado.", `ban twitch troll "spam de links"`},
+		{NewTimeoutCommand(actions), mod, []string{"troll"}, "⏳ troll silenciado por 10m.", `timeout twitch troll 10m0s ""`},
+		{NewTimeoutCommand(actions), mod, []string{"troll", "1h", "calmate"}, "⏳ troll silenciado por 1h.", `timeout twitch troll 1h0m0s "calmate"`},
+		// sin duración válida, todo es el motivo
+		{NewTimeoutCommand(actions), mod, []string{"troll", "calmate", "ya"}, "⏳ troll silenciado por 10m.", `timeout twitch troll 10m0s "calmate ya"`},
+		// Kick redondea al minuto
+		{NewTimeoutCommand(actions), kickMod, []string{"troll", "90"}, "⏳ troll silenciado por 2m.", `timeout kick troll 1m30s ""`},
+		{NewDeleteCommand(actions), mod, []string{"troll"}, "🗑️ Borré el último mensaje de troll.", "delete twitch troll"},
+	}
+	for _, tt := range tests {
+		actions.calls = nil
+		if got := runModeration(t, tt.cmd, tt.msg, tt.args...); got != tt.reply {
+			t.Errorf("%v: respuesta = %q, esperaba %q", tt.args, got, tt.reply)
+		}
+		if len(actions.calls) != 1 || actions.calls[0] != tt.call {
+			t.Errorf("%v: llamadas = %q, esperaba %q", tt.args, actions.calls, tt.call)
+		}
+	}
+}
+
+func TestModerationCommandsUsage(t *testing.T) {
+	actions := &fakeActions{}
+	self := modMessage("luis", "")
+
+	if got := runModeration(t, NewBanCommand(actions), self); got != "Uso: !ban <usuario> [motivo]" {
+		t.Fatalf("sin usuario = %q", got)
+	}
+	if got := runModeration(t, NewTimeoutCommand(actions), self, "@Luis"); got != "Uso: !timeout <usuario> [duración: 600, 10m, 1h, 1d] [motivo]" {
+		t.Fatalf("a sí mismo = %q", got)
+	}
+	if got := runModeration(t, NewDeleteCommand(actions), self, "@"); got != "Uso: !delete <usuario>" {
+		t.Fatalf("usuario vacío = %q", got)
+	}
+	// un viewer no recibe respuesta ni sanciona
+	if got := runModeration(t, NewBanCommand(actions), twitchMessage("ana", ""), "troll"); got != "" {
+		t.Fatalf("viewer = %q", got)
+	}
+	if len(actions.calls) != 0 {
+		t.Fatalf("llamadas = %q", actions.calls)
+	}
+}
+
+func TestModerationCommandsReportFailures(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{moderationusecase.ErrModerationUnavailable, "⚠️ La moderación no está disponible: conecta la cuenta del streamer."},
+		{moderationusecase.ErrProtectedUser, "⚠️ No puedo sancionar a troll (es moderador o el streamer)."},
+		{moderationusecase.ErrNoRecentMessage, "⚠️ No vi mensajes recientes de troll."},
+		{fmt.Errorf("moderation: resolver troll: %w", domain.ErrModerationUserNotFound), "⚠️ No encontré a troll."},
+		{fmt.Errorf("helix 500"), "⚠️ No pude sancionar a troll."},
+	}
+	for _, tt := range tests {
+		actions := &fakeActions{err: tt.err}
+		if got := runModeration(t, NewBanCommand(actions), modMessage("luis", ""), "troll"); got != tt.want {
+			t.Errorf("%v: respuesta = %q, esperaba %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestModerationCommandScopes(t *testing.T) {
+	role, scopes := NewBanCommand(nil).RequiredScopes(domain.PlatformTwitch)
+	if role != "bot" || len(scopes) != 1 || scopes[0] != "moderator:manage:banned_users" {
+		t.Fatalf("ban twitch = %s %v", role, scopes)
+	}
+	if _, scopes := NewDeleteCommand(nil).RequiredScopes(domain.PlatformKick); len(scopes) != 1 || scopes[0] != "moderation:chat_message:manage" {
+		t.Fatalf("delete kick = %v", scopes)
+	}
+	if role, _ := NewTimeoutCommand(nil).RequiredScopes(domain.Platform("youtube")); role != "" {
+		t.Fatalf("otra plataforma = %q", role)
+	}
+}
@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// publicNotesLength es el máximo de !notes cuando no se puede responder por
+// privado y la respuesta sale en el chat.
+const publicNotesLength = 200
+
+// UserNoteBook guarda las notas de los mods (usernotes.Service).
+type UserNoteBook interface {
+	ResolveUser(platform domain.Platform, name string) (string, bool)
+	List(ctx context.Context, platform domain.Platform, userID string) ([]*domain.UserNote, error)
+	Add(ctx context.Context, note domain.UserNote) (*domain.UserNote, error)
+}
+
+func canManageNotes(msg domain.Message) bool {
+	return msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod
+}
+
+// NoteCommand agrega una nota a un usuario: !note <usuario> <texto>.
+type NoteCommand struct {
+	notes UserNoteBook
+}
+
+func NewNoteCommand(notes UserNoteBook) *NoteCommand {
+	return &NoteCommand{notes: notes}
+}
+
+func (c *NoteCommand) Name() string {
+	return "note"
+}
+
+func (c *NoteCommand) Aliases() []string {
+	return nil
+}
+
+func (c *NoteCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *NoteCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.notes == nil || !canManageNotes(msg) {
+		return nil
+	}
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) < 2 {
+		return reply("Uso: !note <usuario> <texto>")
+	}
+	login := strings.TrimPrefix(cmdCtx.Args[0], "@")
+	userID, ok := c.notes.ResolveUser(msg.Platform, login)
+	if !ok {
+		return reply(fmt.Sprintf("⚠️ No vi a %s en el chat todavía.", login))
+	}
+
+	_, err := c.notes.Add(ctx, domain.UserNote{
+		Platform: msg.Platform,
+		UserID:   userID,
+		Login:    login,
+		Note:     strings.Join(cmdCtx.Args[1:], " "),
+		Author:   msg.Name(),
+	})
+	if err != nil {
+		log.Printf("note command: %v", err)
+		return reply(fmt.Sprintf("❌ No pude guardar la nota: %v", err))
+	}
+	return reply(fmt.Sprintf("📝 Nota guardada para %s.", login))
+}
+
+// NotesCommand muestra las notas de un usuario: !notes <usuario>. Responde
+// por privado y, si la plataforma no lo permite, en el chat recortado.
+type NotesCommand struct {
+	notes UserNoteBook
+}
+
+func NewNotesCommand(notes UserNoteBook) *NotesCommand {
+	return &NotesCommand{notes: notes}
+}
+
+func (c *NotesCommand) Name() string {
+	return "notes"
+}
+
+func (c *NotesCommand) Aliases() []string {
+	return nil
+}
+
+func (c *NotesCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *NotesCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.notes == nil || !canManageNotes(msg) {
+		return nil
+	}
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		return reply("Uso: !notes <usuario>")
+	}
+	login := strings.TrimPrefix(cmdCtx.Args[0], "@")
+	userID, ok := c.notes.ResolveUser(msg.Platform, login)
+	if !ok {
+		return reply(fmt.Sprintf("%s no tiene notas.", login))
+	}
+	notes, err := c.notes.List(ctx, msg.Platform, userID)
+	if err != nil {
+		log.Printf("notes command: %v", err)
+		return reply("❌ No pude leer las notas.")
+	}
+	if len(notes) == 0 {
+		return reply(fmt.Sprintf("%s no tiene notas.", login))
+	}
+
+	text := formatUserNotes(login, notes)
+	if private, ok := cmdCtx.Out.(domain.PrivateMessagePort); ok {
+		err := private.SendPrivateMessage(ctx, msg.Platform, msg.UserID, msg.LoginName(), text)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, domain.ErrPrivateMessageUnsupported) {
+			log.Printf("notes command: whisper: %v", err)
+		}
+	}
+	return reply(truncateRunes(text, publicNotesLength))
+}
+
+func formatUserNotes(login string, notes []*domain.UserNote) string {
+	parts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		entry := note.CreatedAt.Format("2006-01-02") + " " + note.Note
+		if note.Author != "" {
+			entry += " (" + note.Author + ")"
+		}
+		parts = append(parts, entry)
+	}
+	return fmt.Sprintf("📝 Notas de %s (%d): %s", login, len(notes), strings.Join(parts, " | "))
+}
+
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// permissionReplyInterval limita los avisos de "sin permiso" por usuario y comando.
+const permissionReplyInterval = 30 * time.Second
+
+type permissionReplier struct {
+	mu          sync.Mutex
+	defaultMode domain.PermissionReplyMode
+	lastReply   map[string]time.Time
+}
+
+func newPermissionReplier() *permissionReplier {
+	return &permissionReplier{
+		defaultMode: domain.PermissionReplySilent,
+		lastReply:   make(map[string]time.Time),
+	}
+}
+
+func (p *permissionReplier) setDefaultMode(mode domain.PermissionReplyMode) {
+	parsed, ok := domain.ParsePermissionReplyMode(string(mode))
+	if !ok {
+		parsed = domain.PermissionReplySilent
+	}
+	p.mu.Lock()
+	p.defaultMode = parsed
+	p.mu.Unlock()
+}
+
+func (p *permissionReplier) defaultModeValue() domain.PermissionReplyMode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.defaultMode
+}
+
+// shouldReply indica si hay que avisar y registra el aviso para el rate limit.
+func (p *permissionReplier) shouldReply(cmd *domain.CustomCommand, msg domain.Message, now time.Time) bool {
+	if p == nil || cmd == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	mode := cmd.PermissionReply
+	if mode == "" {
+		mode = p.defaultMode
+	}
+	if mode != domain.PermissionReplyReply {
+		return false
+	}
+
+	key := normalizeCommandName(cmd.Name) + "|" + cooldownUserKey(msg)
+	if last, ok := p.lastReply[key]; ok && now.Sub(last) < permissionReplyInterval {
+		return false
+	}
+	p.lastReply[key] = now
+
+	// limpieza simple para que el mapa no crezca sin límite
+	for k, t := range p.lastReply {
+		if now.Sub(t) >= permissionReplyInterval {
+			delete(p.lastReply, k)
+		}
+	}
+	return true
+}
+
+func sendPermissionDenied(ctx context.Context, cmd *domain.CustomCommand, msg domain.Message, out domain.OutgoingMessagePort) error {
+	if out == nil {
+		return nil
+	}
+	name := msg.Name()
+	if name == "" {
+		name = "usuario"
+	}
+	return out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+		fmt.Sprintf("⛔ @%s, no tienes permiso para usar %s.", name, cmd.Name))
+}
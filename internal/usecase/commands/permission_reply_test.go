@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestPermissionReplierModes(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	alice := twitchMessage("alice", "!mods")
+	bob := twitchMessage("bob", "!mods")
+
+	t.Run("silent by default", func(t *testing.T) {
+		p := newPermissionReplier()
+		if p.shouldReply(&domain.CustomCommand{Name: "mods"}, alice, t0) {
+			t.Fatalf("silent default replied")
+		}
+	})
+
+	t.Run("reply rate limited per user", func(t *testing.T) {
+		p := newPermissionReplier()
+		cmd := &domain.CustomCommand{Name: "mods", PermissionReply: domain.PermissionReplyReply}
+		if !p.shouldReply(cmd, alice, t0) {
+			t.Fatalf("first denial not answered")
+		}
+		if p.shouldReply(cmd, alice, t0.Add(10*time.Second)) {
+			t.Fatalf("second denial inside the interval answered")
+		}
+		if !p.shouldReply(cmd, bob, t0.Add(10*time.Second)) {
+			t.Fatalf("another user was rate limited")
+		}
+		if !p.shouldReply(cmd, alice, t0.Add(permissionReplyInterval)) {
+			t.Fatalf("denial after the interval not answered")
+		}
+	})
+
+	t.Run("command overrides default", func(t *testing.T) {
+		p := newPermissionReplier()
+		p.setDefaultMode(domain.PermissionReplyReply)
+		if !p.shouldReply(&domain.CustomCommand{Name: "a"}, alice, t0) {
+			t.Fatalf("default reply mode ignored")
+		}
+		if p.shouldReply(&domain.CustomCommand{Name: "b", PermissionReply: domain.PermissionReplySilent}, alice, t0) {
+			t.Fatalf("silent command replied")
+		}
+		p.setDefaultMode("nope")
+		if p.defaultModeValue() != domain.PermissionReplySilent {
+			t.Fatalf("invalid mode not reset to silent")
+		}
+	})
+}
+
+func TestTryHandlePermissionReply(t *testing.T) {
+	ctx := context.Background()
+	mgr, _ := NewCustomCommandManager(ctx, nil)
+	settings := &memoryCommandSettings{}
+	svc := NewService(mgr)
+	svc.SetSettingsRepository(settings)
+
+	response := "solo mods"
+	perms := []domain.CommandAccessRole{domain.CommandAccessModerators}
+	if _, err := svc.Upsert(ctx, CommandMutationDTO{Name: "mods", Response: &response, Permissions: &perms}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	viewer := twitchMessage("alice", "!mods")
+	viewer.DisplayName = "Alice"
+	out := &captureOut{}
+
+	handled, err := mgr.TryHandle(ctx, "mods", nil, viewer, out)
+	if !handled || err != nil || len(out.texts()) != 0 {
+		t.Fatalf("silent: handled=%v err=%v sent=%q", handled, err, out.texts())
+	}
+
+	if _, err := svc.SetDefaultPermissionReply(ctx, "on"); err != nil {
+		t.Fatalf("SetDefaultPermissionReply: %v", err)
+	}
+	if settings.permission != domain.PermissionReplyReply {
+		t.Fatalf("stored default = %q", settings.permission)
+	}
+	if _, err := svc.SetDefaultPermissionReply(ctx, "gritar"); err == nil {
+		t.Fatalf("invalid mode accepted")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := mgr.TryHandle(ctx, "mods", nil, viewer, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := out.texts(); len(got) != 1 || got[0] != "⛔ @Alice, no tienes permiso para usar mods." {
+		t.Fatalf("reply mode sent %q", got)
+	}
+
+	mod := twitchMessage("bob", "!mods")
+	mod.IsPlatformMod = true
+	out.reset()
+	if _, err := mgr.TryHandle(ctx, "mods", nil, mod, out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.texts(); len(got) != 1 || got[0] != "solo mods" {
+		t.Fatalf("moderator got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// expandPlaceholders reemplaza las variables de la respuesta de un comando
+// personalizado: $user, $channel, $platform, $args (todo lo que sigue al
+// comando) y $argN (el argumento N, desde 1; vacío si no lo hay). $$ deja un
+// $ literal y cualquier otra variable queda tal cual.
+func expandPlaceholders(text string, msg domain.Message, args []string) string {
+	if !strings.Contains(text, "$") {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); {
+		if text[i] != '$' {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == '$' {
+			b.WriteByte('$')
+			i += 2
+			continue
+		}
+
+		end := i + 1
+		for end < len(text) && isPlaceholderChar(text[end]) {
+			end++
+		}
+		value, ok := placeholderValue(text[i+1:end], msg, args)
+		if !ok {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		b.WriteString(value)
+		i = end
+	}
+	return b.String()
+}
+
+func placeholderValue(name string, msg domain.Message, args []string) (string, bool) {
+	switch name {
+	case "user":
+		return msg.Username, true
+	case "channel":
+		return msg.ChannelID, true
+	case "platform":
+		return string(msg.Platform), true
+	case "args":
+		return strings.Join(args, " "), true
+	}
+
+	digits, ok := strings.CutPrefix(name, "arg")
+	if !ok || digits == "" {
+		return "", false
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || n < 1 {
+		return "", false
+	}
+	if n > len(args) {
+		return "", true
+	}
+	return args[n-1], true
+}
+
+func isPlaceholderChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestExpandPlaceholders(t *testing.T) {
+	msg := twitchMessage("Ana", "!hug beto ya")
+	args := []string{"beto", "ya"}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"sin variables", "hola chat", "hola chat"},
+		{"user y channel", "@$user pidió ayuda en $channel", "@Ana pidió ayuda en canal"},
+		{"platform", "estás en $platform", "estás en twitch"},
+		{"args", "Dijiste: $args", "Dijiste: beto ya"},
+		{"posicionales", "$user abraza a $arg1 ($arg2)", "Ana abraza a beto (ya)"},
+		{"argumento que falta", "[$arg3]", "[]"},
+		{"pegado a puntuación", "¡$user!", "¡Ana!"},
+		{"escape", "cuesta $$5", "cuesta $5"},
+		{"escape antes de variable", "$$user", "$user"},
+		{"desconocidas quedan", "$users $arg0 $argx $ $", "$users $arg0 $argx $ $"},
+		{"dólar al final", "precio$", "precio$"},
+		{"acentos alrededor", "ñandú $user ñandú", "ñandú Ana ñandú"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandPlaceholders(tt.in, msg, args); got != tt.want {
+				t.Fatalf("expandPlaceholders(%q) = %q, esperaba %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if got := expandPlaceholders("Dijiste: $args.", msg, nil); got != "Dijiste: ." {
+		t.Fatalf("sin argumentos = %q", got)
+	}
+}
+
+func TestCustomCommandExpandsArgsFromRouter(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "hug", Response: "$user abraza a $arg1 en $platform"})
+
+	if got := h.send(t, kickMessage("ana", "!hug  beto")); len(got) != 1 || got[0] != "ana abraza a beto en kick" {
+		t.Fatalf("respuesta = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!hug")); len(got) != 1 || got[0] != "ana abraza a  en twitch" {
+		t.Fatalf("sin argumentos = %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"zhatBot/internal/domain"
+)
+
+// maxPrefixLength es el largo máximo del prefijo, en caracteres.
+const maxPrefixLength = 3
+
+// prefixConfirmWindow es cuánto espera !prefix la confirmación.
+const prefixConfirmWindow = time.Minute
+
+// ValidatePrefix normaliza y valida un prefijo de comandos. Solo se aceptan
+// símbolos: con letras o números cualquier palabra podría ser un comando, y
+// "/" o "." al principio los interpreta el chat de Twitch.
+func ValidatePrefix(prefix string) (string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return "", fmt.Errorf("el prefijo no puede estar vacío")
+	}
+	if !utf8.ValidString(prefix) {
+		return "", fmt.Errorf("el prefijo no es texto válido")
+	}
+	if utf8.RuneCountInString(prefix) > maxPrefixLength {
+		return "", fmt.Errorf("el prefijo puede tener hasta %d caracteres", maxPrefixLength)
+	}
+	for _, r := range prefix {
+		if !unicode.IsPunct(r) && !unicode.IsSymbol(r) {
+			return "", fmt.Errorf("el prefijo solo puede tener símbolos (p. ej. ! ? # ~)")
+		}
+	}
+	switch prefix[0] {
+	case '/', '.':
+		return "", fmt.Errorf("el prefijo no puede empezar con %q: lo usa el chat de Twitch", prefix[:1])
+	case '@':
+		return "", fmt.Errorf("el prefijo no puede empezar con @: es una mención")
+	}
+	return prefix, nil
+}
+
+// PrefixSetter cambia el prefijo de los comandos (el Router).
+type PrefixSetter interface {
+	Prefix() string
+	SetPrefix(ctx context.Context, prefix string) error
+}
+
+type pendingPrefix struct {
+	prefix  string
+	expires time.Time
+}
+
+// PrefixCommand deja que el dueño del canal cambie el prefijo desde el chat.
+// El cambio se confirma aparte: después de aplicarlo, el mismo !prefix deja de
+// funcionar con el prefijo viejo.
+type PrefixCommand struct {
+	setter PrefixSetter
+
+	mu      sync.Mutex
+	pending map[string]pendingPrefix
+	now     func() time.Time
+}
+
+func NewPrefixCommand(setter PrefixSetter) *PrefixCommand {
+	return &PrefixCommand{
+		setter:  setter,
+		pending: make(map[string]pendingPrefix),
+		now:     time.Now,
+	}
+}
+
+func (c *PrefixCommand) Name() string {
+	return "prefix"
+}
+
+func (c *PrefixCommand) Aliases() []string {
+	return []string{"prefijo"}
+}
+
+func (c *PrefixCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *PrefixCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if !msg.IsPlatformOwner || c.setter == nil {
+		return nil
+	}
+
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+	current := c.setter.Prefix()
+	key := string(msg.Platform) + ":" + msg.ChannelID
+
+	if len(cmdCtx.Args) == 0 {
+		return reply(fmt.Sprintf("El prefijo de comandos es %s. Usa %sprefix <nuevo> para cambiarlo.", current, current))
+	}
+
+	switch strings.ToLower(cmdCtx.Args[0]) {
+	case "confirm", "confirmar":
+		pending, ok := c.takePending(key)
+		if !ok {
+			return reply("No hay ningún cambio de prefijo pendiente.")
+		}
+		if err := c.setter.SetPrefix(ctx, pending.prefix); err != nil {
+			log.Printf("prefix command: %v", err)
+			return reply("❌ No pude cambiar el prefijo.")
+		}
+		return reply(fmt.Sprintf("✅ Prefijo cambiado a %s. Ahora los comandos son %sprefix, %stts…", pending.prefix, pending.prefix, pending.prefix))
+	case "cancel", "cancelar":
+		if _, ok := c.takePending(key); !ok {
+			return reply("No hay ningún cambio de prefijo pendiente.")
+		}
+		return reply(fmt.Sprintf("Cambio de prefijo cancelado; sigue siendo %s.", current))
+	}
+
+	prefix, err := ValidatePrefix(cmdCtx.Args[0])
+	if err != nil {
+		return reply(fmt.Sprintf("❌ Prefijo inválido: %v.", err))
+	}
+	if prefix == current {
+		return reply(fmt.Sprintf("El prefijo ya es %s.", current))
+	}
+
+	c.mu.Lock()
+	c.pending[key] = pendingPrefix{prefix: prefix, expires: c.now().Add(prefixConfirmWindow)}
+	c.mu.Unlock()
+	return reply(fmt.Sprintf("⚠️ Con %s los comandos pasarán a ser %sprefix, %stts… y %sprefix dejará de funcionar. Confirma con %sprefix confirm en el próximo minuto.",
+		prefix, prefix, prefix, current, current))
+}
+
+func (c *PrefixCommand) takePending(key string) (pendingPrefix, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending, ok := c.pending[key]
+	delete(c.pending, key)
+	if !ok || c.now().After(pending.expires) {
+		return pendingPrefix{}, false
+	}
+	return pending, true
+}
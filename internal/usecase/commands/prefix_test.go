@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidatePrefix(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"!", "!", true},
+		{" ? ", "?", true},
+		{"~~", "~~", true},
+		{"$#!", "$#!", true},
+		{"¡", "¡", true},
+		{"", "", false},
+		{"   ", "", false},
+		{"!!!!", "", false},
+		{"a", "", false},
+		{"!1", "", false},
+		{"! !", "", false},
+		{"/", "", false},
+		{".!", "", false},
+		{"@", "", false},
+		{"\xff", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ValidatePrefix(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ValidatePrefix(%q) = %q, %v; esperaba %q (válido=%v)", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+// fakePrefixSetter hace del Router.
+type fakePrefixSetter struct {
+	prefix string
+}
+
+func (f *fakePrefixSetter) Prefix() string { return f.prefix }
+
+func (f *fakePrefixSetter) SetPrefix(_ context.Context, prefix string) error {
+	f.prefix = prefix
+	return nil
+}
+
+func TestPrefixCommandNeedsConfirmation(t *testing.T) {
+	ctx := context.Background()
+	setter := &fakePrefixSetter{prefix: "!"}
+	cmd := NewPrefixCommand(setter)
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	cmd.now = func() time.Time { return now }
+	out := &captureOut{}
+	owner := adminMessage("!prefix")
+
+	run := func(args ...string) string {
+		t.Helper()
+		if err := cmd.Handle(ctx, newCmdContext(owner, out, args...)); err != nil {
+			t.Fatalf("Handle(%v): %v", args, err)
+		}
+		return out.last()
+	}
+
+	if got := run("abc"); !strings.HasPrefix(got, "❌ Prefijo inválido") || setter.prefix != "!" {
+		t.Fatalf("prefijo inválido: %q, prefijo = %q", got, setter.prefix)
+	}
+	if got := run("confirm"); got != "No hay ningún cambio de prefijo pendiente." {
+		t.Fatalf("confirm sin pendiente = %q", got)
+	}
+
+	// pedir el cambio no lo aplica todavía
+	if got := run("?"); !strings.Contains(got, "!prefix confirm") || setter.prefix != "!" {
+		t.Fatalf("pedido = %q, prefijo = %q", got, setter.prefix)
+	}
+	if got := run("confirm"); !strings.HasPrefix(got, "✅ Prefijo cambiado a ?") || setter.prefix != "?" {
+		t.Fatalf("confirm = %q, prefijo = %q", got, setter.prefix)
+	}
+	if got := run("confirm"); got != "No hay ningún cambio de prefijo pendiente." {
+		t.Fatalf("se confirmó dos veces: %q", got)
+	}
+
+	// la confirmación vence
+	run("~")
+	now = now.Add(prefixConfirmWindow + time.Second)
+	if got := run("confirm"); got != "No hay ningún cambio de prefijo pendiente." || setter.prefix != "?" {
+		t.Fatalf("confirm vencido = %q, prefijo = %q", got, setter.prefix)
+	}
+
+	run("~")
+	if got := run("cancel"); got != "Cambio de prefijo cancelado; sigue siendo ?." || setter.prefix != "?" {
+		t.Fatalf("cancel = %q, prefijo = %q", got, setter.prefix)
+	}
+}
+
+func TestPrefixCommandOwnerOnly(t *testing.T) {
+	setter := &fakePrefixSetter{prefix: "!"}
+	cmd := NewPrefixCommand(setter)
+	out := &captureOut{}
+
+	for _, msg := range []string{"?", "confirm"} {
+		if err := cmd.Handle(context.Background(), newCmdContext(modMessage("mod", "!prefix "+msg), out, msg)); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+	}
+	if len(out.texts()) != 0 || setter.prefix != "!" {
+		t.Fatalf("un mod cambió el prefijo: %q, %q", out.texts(), setter.prefix)
+	}
+}
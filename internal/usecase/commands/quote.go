@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+	quotesusecase "zhatBot/internal/usecase/quotes"
+)
+
+// QuoteBook guarda las citas del canal (quotes.Service).
+type QuoteBook interface {
+	Get(ctx context.Context, number int64) (*domain.Quote, error)
+	Random(ctx context.Context) (*domain.Quote, error)
+	Add(ctx context.Context, quote domain.Quote) (*domain.Quote, error)
+}
+
+// QuoteCommand implementa !quote [número] | add <texto>.
+type QuoteCommand struct {
+	quotes QuoteBook
+}
+
+func NewQuoteCommand(quotes QuoteBook) *QuoteCommand {
+	return &QuoteCommand{quotes: quotes}
+}
+
+func (c *QuoteCommand) Name() string {
+	return "quote"
+}
+
+func (c *QuoteCommand) Aliases() []string {
+	return []string{"cita"}
+}
+
+func (c *QuoteCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *QuoteCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.quotes == nil {
+		return nil
+	}
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		quote, err := c.quotes.Random(ctx)
+		if err != nil {
+			if errors.Is(err, quotesusecase.ErrNoQuotes) {
+				return reply("Todavía no hay citas. Un mod puede agregar una con !quote add <texto>.")
+			}
+			log.Printf("quote command: %v", err)
+			return reply("❌ No pude leer las citas.")
+		}
+		return reply(formatQuote(quote))
+	}
+
+	if strings.EqualFold(cmdCtx.Args[0], "add") {
+		if !msg.IsPlatformOwner && !msg.IsPlatformAdmin && !msg.IsPlatformMod {
+			return nil
+		}
+		quote, err := c.quotes.Add(ctx, domain.Quote{
+			Text:     strings.Join(cmdCtx.Args[1:], " "),
+			AddedBy:  msg.Name(),
+			Platform: msg.Platform,
+		})
+		if err != nil {
+			if errors.Is(err, quotesusecase.ErrEmptyQuote) {
+				return reply("Uso: !quote add <texto>")
+			}
+			if errors.Is(err, quotesusecase.ErrQuoteTooLong) {
+				return reply(fmt.Sprintf("⚠️ La cita no puede pasar de %d caracteres.", quotesusecase.MaxQuoteLength))
+			}
+			log.Printf("quote command: %v", err)
+			return reply("❌ No pude guardar la cita.")
+		}
+		return reply(fmt.Sprintf("💬 Cita #%d guardada.", quote.Number))
+	}
+
+	number, err := strconv.ParseInt(strings.TrimPrefix(cmdCtx.Args[0], "#"), 10, 64)
+	if err != nil || number <= 0 {
+		return reply("Uso: !quote [número] | add <texto>")
+	}
+	quote, err := c.quotes.Get(ctx, number)
+	if err != nil {
+		if errors.Is(err, quotesusecase.ErrQuoteNotFound) {
+			return reply(fmt.Sprintf("No existe la cita #%d.", number))
+		}
+		log.Printf("quote command: %v", err)
+		return reply("❌ No pude leer las citas.")
+	}
+	return reply(formatQuote(quote))
+}
+
+func formatQuote(quote *domain.Quote) string {
+	meta := quote.CreatedAt.Format("2006-01-02")
+	if quote.AddedBy != "" {
+		meta = quote.AddedBy + ", " + meta
+	}
+	return fmt.Sprintf("💬 #%d: \"%s\" (%s)", quote.Number, quote.Text, meta)
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	quotesusecase "zhatBot/internal/usecase/quotes"
+)
+
+// memoryQuotes hace de la tabla quotes: numera como AUTOINCREMENT.
+type memoryQuotes struct {
+	mu     sync.Mutex
+	last   int64
+	quotes []*domain.Quote
+}
+
+func (r *memoryQuotes) AddQuote(_ context.Context, quote *domain.Quote) (*domain.Quote, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last++
+	quote.Number = r.last
+	saved := *quote
+	r.quotes = append(r.quotes, &saved)
+	return quote, nil
+}
+
+func (r *memoryQuotes) GetQuote(_ context.Context, number int64) (*domain.Quote, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, quote := range r.quotes {
+		if quote.Number == number {
+			copied := *quote
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// RandomQuote devuelve siempre la última para que el test sea determinista.
+func (r *memoryQuotes) RandomQuote(context.Context) (*domain.Quote, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.quotes) == 0 {
+		return nil, nil
+	}
+	copied := *r.quotes[len(r.quotes)-1]
+	return &copied, nil
+}
+
+func (r *memoryQuotes) ListQuotes(context.Context) ([]*domain.Quote, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*domain.Quote(nil), r.quotes...), nil
+}
+
+func (r *memoryQuotes) UpdateQuote(_ context.Context, number int64, text string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, quote := range r.quotes {
+		if quote.Number == number {
+			quote.Text = text
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryQuotes) DeleteQuote(_ context.Context, number int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, quote := range r.quotes {
+		if quote.Number == number {
+			r.quotes = append(r.quotes[:i], r.quotes[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func runQuote(t *testing.T, cmd *QuoteCommand, msg domain.Message, args ...string) string {
+	t.Helper()
+	out := &captureOut{}
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, args...)); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	return out.last()
+}
+
+func TestQuoteAddGetRandom(t *testing.T) {
+	quotes := quotesusecase.NewService(&memoryQuotes{})
+	cmd := NewQuoteCommand(quotes)
+	viewer := twitchMessage("ana", "!quote")
+	mod := modMessage("beto", "!quote add")
+
+	if got := runQuote(t, cmd, viewer); !strings.HasPrefix(got, "Todavía no hay citas") {
+		t.Fatalf("sin citas = %q", got)
+	}
+
+	if got := runQuote(t, cmd, viewer, "add", "no", "vale"); got != "" {
+		t.Fatalf("un viewer agregó una cita: %q", got)
+	}
+	if got := runQuote(t, cmd, mod, "add", "esto", "es", "un", "clásico"); got != "💬 Cita #1 guardada." {
+		t.Fatalf("add = %q", got)
+	}
+	if got := runQuote(t, cmd, mod, "ADD", "otra"); got != "💬 Cita #2 guardada." {
+		t.Fatalf("add = %q", got)
+	}
+	if got := runQuote(t, cmd, mod, "add", "  "); got != "Uso: !quote add <texto>" {
+		t.Fatalf("add vacío = %q", got)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	if got := runQuote(t, cmd, viewer, "#1"); got != `💬 #1: "esto es un clásico" (beto, `+today+`)` {
+		t.Fatalf("quote 1 = %q", got)
+	}
+	if got := runQuote(t, cmd, viewer); !strings.HasPrefix(got, `💬 #2: "otra"`) {
+		t.Fatalf("random = %q", got)
+	}
+	if got := runQuote(t, cmd, viewer, "7"); got != "No existe la cita #7." {
+		t.Fatalf("inexistente = %q", got)
+	}
+	if got := runQuote(t, cmd, viewer, "abc"); got != "Uso: !quote [número] | add <texto>" {
+		t.Fatalf("número inválido = %q", got)
+	}
+}
+
+func TestQuoteNumbersSurviveDeletes(t *testing.T) {
+	ctx := context.Background()
+	quotes := quotesusecase.NewService(&memoryQuotes{})
+	cmd := NewQuoteCommand(quotes)
+	mod := modMessage("beto", "!quote add")
+
+	for _, text := range []string{"uno", "dos", "tres"} {
+		runQuote(t, cmd, mod, "add", text)
+	}
+	if err := quotes.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if got := runQuote(t, cmd, mod, "3"); !strings.HasPrefix(got, `💬 #3: "tres"`) {
+		t.Fatalf("la cita 3 cambió de número: %q", got)
+	}
+	if got := runQuote(t, cmd, mod, "2"); got != "No existe la cita #2." {
+		t.Fatalf("cita borrada = %q", got)
+	}
+	if got := runQuote(t, cmd, mod, "add", "cuatro"); got != "💬 Cita #4 guardada." {
+		t.Fatalf("se reusó un número: %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"log"
+
+	"zhatBot/internal/domain"
+)
+
+// ReadOnlySwitch controla el modo solo lectura del bot.
+type ReadOnlySwitch interface {
+	Enabled() bool
+	Set(ctx context.Context, enabled bool) error
+}
+
+// ReadOnlyCommand permite al dueño del canal activar el modo solo lectura
+// para probar comandos en vivo sin que el bot envíe nada.
+type ReadOnlyCommand struct {
+	mode ReadOnlySwitch
+}
+
+func NewReadOnlyCommand(mode ReadOnlySwitch) *ReadOnlyCommand {
+	return &ReadOnlyCommand{mode: mode}
+}
+
+func (c *ReadOnlyCommand) Name() string {
+	return "readonly"
+}
+
+func (c *ReadOnlyCommand) Aliases() []string {
+	return nil
+}
+
+func (c *ReadOnlyCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *ReadOnlyCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if !msg.IsPlatformOwner || c.mode == nil {
+		return nil
+	}
+
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		if c.mode.Enabled() {
+			return reply("🔇 Modo solo lectura activado. Usa !readonly off para desactivarlo.")
+		}
+		return reply("🔊 Modo solo lectura desactivado. Usa !readonly on para activarlo.")
+	}
+
+	switch {
+	case isOnArg(cmdCtx.Args[0]):
+		// se avisa antes de activarlo; después ya no saldría el mensaje
+		if err := reply("🔇 Modo solo lectura activado: proceso todo pero no envío nada."); err != nil {
+			log.Printf("readonly command: %v", err)
+		}
+		if err := c.mode.Set(ctx, true); err != nil {
+			log.Printf("readonly command: %v", err)
+			return reply("❌ No pude activar el modo solo lectura.")
+		}
+		return nil
+	case isOffArg(cmdCtx.Args[0]):
+		if err := c.mode.Set(ctx, false); err != nil {
+			log.Printf("readonly command: %v", err)
+			return reply("❌ No pude desactivar el modo solo lectura.")
+		}
+		return reply("🔊 Modo solo lectura desactivado.")
+	default:
+		return reply("Uso: !readonly on|off")
+	}
+}
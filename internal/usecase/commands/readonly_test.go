@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"testing"
+)
+
+type memoryReadOnly struct{ enabled bool }
+
+func (m *memoryReadOnly) Enabled() bool { return m.enabled }
+
+func (m *memoryReadOnly) Set(_ context.Context, enabled bool) error {
+	m.enabled = enabled
+	return nil
+}
+
+func TestReadOnlyCommandIsOwnerOnly(t *testing.T) {
+	mode := &memoryReadOnly{}
+	out := &captureOut{}
+	msg := twitchMessage("mod", "!readonly on")
+	msg.IsPlatformMod = true
+
+	if err := NewReadOnlyCommand(mode).Handle(context.Background(), newCmdContext(msg, out, "on")); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if mode.enabled || len(out.texts()) != 0 {
+		t.Fatalf("a mod toggled read-only (enabled=%v, replies=%v)", mode.enabled, out.texts())
+	}
+}
+
+func TestReadOnlyCommandToggles(t *testing.T) {
+	mode := &memoryReadOnly{}
+	out := &captureOut{}
+	cmd := NewReadOnlyCommand(mode)
+	owner := twitchMessage("streamer", "!readonly")
+	owner.IsPlatformOwner = true
+
+	if err := cmd.Handle(context.Background(), newCmdContext(owner, out, "on")); err != nil {
+		t.Fatalf("Handle(on): %v", err)
+	}
+	if !mode.enabled {
+		t.Fatal("read-only not enabled")
+	}
+	// el aviso sale antes de activarlo, si no el MultiSender lo suprimiría
+	if got := out.last(); got != "🔇 Modo solo lectura activado: proceso todo pero no envío nada." {
+		t.Fatalf("reply = %q", got)
+	}
+
+	out.reset()
+	if err := cmd.Handle(context.Background(), newCmdContext(owner, out)); err != nil {
+		t.Fatalf("Handle(status): %v", err)
+	}
+	if got := out.last(); got != "🔇 Modo solo lectura activado. Usa !readonly off para desactivarlo." {
+		t.Fatalf("status reply = %q", got)
+	}
+
+	if err := cmd.Handle(context.Background(), newCmdContext(owner, out, "off")); err != nil {
+		t.Fatalf("Handle(off): %v", err)
+	}
+	if mode.enabled {
+		t.Fatal("read-only still enabled")
+	}
+}
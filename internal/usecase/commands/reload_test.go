@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// setCommands reemplaza de una vez todo el contenido del repo.
+func (r *memoryCommandRepo) setCommands(cmds ...*domain.CustomCommand) {
+	next := make(map[string]*domain.CustomCommand, len(cmds))
+	for _, cmd := range cmds {
+		next[cmd.Name] = cmd
+	}
+	r.mu.Lock()
+	r.commands = next
+	r.mu.Unlock()
+}
+
+func TestReloadWhileHandling(t *testing.T) {
+	stateA := []*domain.CustomCommand{{Name: "saludo", Response: "A", Aliases: []string{"hola"}}}
+	stateB := []*domain.CustomCommand{{Name: "saludo", Response: "B", Aliases: []string{"hey"}}}
+
+	h := newRouterHarness(t, stateA...)
+	ctx := context.Background()
+
+	// con un estado a medias "!hola" podría responder "B" o "!hey" responder "A"
+	want := map[string]string{"!hola": "A", "!hey": "B"}
+
+	stop := make(chan struct{})
+	var reloads sync.WaitGroup
+	reloads.Add(1)
+	go func() {
+		defer reloads.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				h.repo.setCommands(stateB...)
+			} else {
+				h.repo.setCommands(stateA...)
+			}
+			if err := h.mgr.Reload(ctx); err != nil {
+				t.Errorf("Reload: %v", err)
+				return
+			}
+		}
+	}()
+
+	var handlers sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		handlers.Add(1)
+		go func() {
+			defer handlers.Done()
+			out := &captureOut{}
+			for i := 0; i < 300; i++ {
+				for _, text := range []string{"!saludo", "!hola", "!hey"} {
+					out.reset()
+					if err := h.router.Handle(ctx, twitchMessage("ana", text), out); err != nil {
+						t.Errorf("Handle(%q): %v", text, err)
+						return
+					}
+					got := out.texts()
+					if len(got) > 1 {
+						t.Errorf("%q got %d replies", text, len(got))
+						return
+					}
+					if len(got) == 0 {
+						if text == "!saludo" {
+							t.Errorf("!saludo went unanswered during reload")
+							return
+						}
+						continue
+					}
+					if expected, ok := want[text]; ok && got[0] != expected {
+						t.Errorf("%q answered %q from a partial reload", text, got[0])
+						return
+					}
+					if text == "!saludo" && got[0] != "A" && got[0] != "B" {
+						t.Errorf("!saludo answered %q", got[0])
+						return
+					}
+				}
+			}
+		}()
+	}
+	handlers.Wait()
+	close(stop)
+	reloads.Wait()
+}
+
+func TestManageReloadCommand(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "saludo", Response: "viejo"})
+	h.repo.setCommands(
+		&domain.CustomCommand{Name: "saludo", Response: "nuevo"},
+		&domain.CustomCommand{Name: "discord", Response: "dc"},
+	)
+
+	// sin recargar se sigue usando lo que estaba en memoria
+	if got := h.send(t, twitchMessage("ana", "!saludo")); len(got) != 1 || got[0] != "viejo" {
+		t.Fatalf("before reload = %q", got)
+	}
+
+	mod := adminMessage("!command reload")
+	mod.IsPlatformOwner = false
+	if got := h.send(t, mod); len(got) != 0 {
+		t.Fatalf("non-owner reload replied %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!discord")); len(got) != 0 {
+		t.Fatalf("non-owner reload took effect: %q", got)
+	}
+
+	if got := h.send(t, adminMessage("!command reload")); len(got) != 1 || got[0] != "✅ Comandos recargados (2 personalizados)." {
+		t.Fatalf("reload replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!saludo")); len(got) != 1 || got[0] != "nuevo" {
+		t.Fatalf("after reload = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!discord")); len(got) != 1 || got[0] != "dc" {
+		t.Fatalf("new command after reload = %q", got)
+	}
+}
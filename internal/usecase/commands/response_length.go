@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+
+	"zhatBot/internal/domain"
+)
+
+// lengthWarningRatio es la fracción del límite a partir de la cual se avisa que
+// la respuesta está cerca de cortarse.
+const lengthWarningRatio = 0.9
+
+// commandTargetPlatforms son las plataformas donde puede responder un comando
+// sin restricción de plataformas.
+var commandTargetPlatforms = []domain.Platform{domain.PlatformTwitch, domain.PlatformKick}
+
+// responseLengthWarnings avisa (sin bloquear) cuando alguna respuesta puede
+// pasarse del límite de alguna de las plataformas del comando.
+func responseLengthWarnings(cmd *domain.CustomCommand) []string {
+	if cmd == nil {
+		return nil
+	}
+	pool := cmd.ResponsePool()
+	var warnings []string
+	for i, r := range pool {
+		label := ""
+		if len(pool) > 1 {
+			label = fmt.Sprintf(" (respuesta %d)", i+1)
+		}
+		warnings = append(warnings, textLengthWarnings(cmd, r.Text, label)...)
+	}
+	return warnings
+}
+
+func textLengthWarnings(cmd *domain.CustomCommand, text, label string) []string {
+	platforms := cmd.Platforms
+	if len(platforms) == 0 {
+		platforms = commandTargetPlatforms
+	}
+
+	var warnings []string
+	for _, platform := range platforms {
+		limit := domain.MaxMessageLength(platform)
+		length := domain.MeasureMessage(platform, text)
+		if float64(length.Raw) < float64(limit)*lengthWarningRatio {
+			continue
+		}
+		var warning string
+		if length.Raw > limit {
+			warning = fmt.Sprintf("⚠️ En %s la respuesta%s ocupa %d de %d caracteres y se va a cortar.", platform, label, length.Raw, limit)
+		} else {
+			warning = fmt.Sprintf("⚠️ En %s la respuesta%s usa %d de %d caracteres; está cerca del límite.", platform, label, length.Raw, limit)
+		}
+		if length.Emotes() {
+			warning += fmt.Sprintf(" Se ven %d, pero el código de los emotes cuenta completo.", length.Display)
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
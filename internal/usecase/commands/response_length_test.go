@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+const kickEmote = "[emote:12345:wave]" // 18 caracteres, se ve "wave"
+
+func TestResponseLengthWarnings(t *testing.T) {
+	cases := []struct {
+		name      string
+		platforms []domain.Platform
+		text      string
+		want      []string
+	}{
+		{
+			name: "short response",
+			text: "hola " + kickEmote,
+		},
+		{
+			name: "just under the warning threshold",
+			text: strings.Repeat("a", 449),
+		},
+		{
+			name: "near the limit on every platform",
+			text: strings.Repeat("a", 450),
+			want: []string{
+				"⚠️ En twitch la respuesta usa 450 de 500 caracteres; está cerca del límite.",
+				"⚠️ En kick la respuesta usa 450 de 500 caracteres; está cerca del límite.",
+			},
+		},
+		{
+			name:      "over the limit only where targeted",
+			platforms: []domain.Platform{domain.PlatformTwitch},
+			text:      strings.Repeat("a", 501),
+			want:      []string{"⚠️ En twitch la respuesta ocupa 501 de 500 caracteres y se va a cortar."},
+		},
+		{
+			name:      "kick emotes near the limit",
+			platforms: []domain.Platform{domain.PlatformKick},
+			text:      strings.Repeat(kickEmote, 25) + strings.Repeat(" ", 5),
+			want:      []string{"⚠️ En kick la respuesta usa 455 de 500 caracteres; está cerca del límite. Se ven 105, pero el código de los emotes cuenta completo."},
+		},
+		{
+			name:      "mixed emotes and text over the limit",
+			platforms: []domain.Platform{domain.PlatformKick},
+			text:      strings.Repeat("hola "+kickEmote+" ", 21),
+			want:      []string{"⚠️ En kick la respuesta ocupa 504 de 500 caracteres y se va a cortar. Se ven 210, pero el código de los emotes cuenta completo."},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := responseLengthWarnings(&domain.CustomCommand{Name: "x", Response: tc.text, Platforms: tc.platforms})
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Fatalf("warnings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tc.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestResponseLengthWarningsPerPoolEntry(t *testing.T) {
+	cmd := &domain.CustomCommand{
+		Name:      "x",
+		Platforms: []domain.Platform{domain.PlatformTwitch},
+		Responses: []domain.WeightedResponse{
+			{Text: "corta", Weight: 1},
+			{Text: strings.Repeat("b", 480), Weight: 1},
+		},
+	}
+	got := responseLengthWarnings(cmd)
+	if len(got) != 1 || got[0] != "⚠️ En twitch la respuesta (respuesta 2) usa 480 de 500 caracteres; está cerca del límite." {
+		t.Fatalf("warnings = %q", got)
+	}
+}
+
+func TestUpsertReturnsLengthWarnings(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := NewCustomCommandManager(ctx, newMemoryCommandRepo())
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	svc := NewService(mgr)
+
+	long := strings.Repeat(kickEmote, 28)
+	platforms := []string{"kick"}
+	dto, err := svc.Upsert(ctx, CommandMutationDTO{Name: "emotes", Response: &long, Platforms: &platforms})
+	if err != nil {
+		t.Fatalf("Upsert should not fail on a length warning: %v", err)
+	}
+	if len(dto.Warnings) != 1 || !strings.Contains(dto.Warnings[0], "504 de 500") {
+		t.Fatalf("warnings = %q", dto.Warnings)
+	}
+
+	short := "hola"
+	dto, err = svc.Upsert(ctx, CommandMutationDTO{Name: "emotes", Response: &short})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if len(dto.Warnings) != 0 {
+		t.Fatalf("short response warnings = %q", dto.Warnings)
+	}
+}
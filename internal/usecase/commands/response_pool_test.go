@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestPickResponseDistribution(t *testing.T) {
+	h := newRouterHarness(t)
+	h.mgr.SetRandomSeed(42)
+	pool := []domain.WeightedResponse{
+		{Text: "raro", Weight: 1},
+		{Text: "normal", Weight: 3},
+		{Text: "común", Weight: 6},
+	}
+
+	const draws = 20000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		counts[h.mgr.pickResponse(pool)]++
+	}
+
+	// chi-cuadrado con 2 grados de libertad; 13.8 es p=0.001
+	chi2 := 0.0
+	for _, r := range pool {
+		expected := float64(draws) * float64(r.Weight) / 10
+		diff := float64(counts[r.Text]) - expected
+		chi2 += diff * diff / expected
+	}
+	if chi2 > 13.8 || math.IsNaN(chi2) {
+		t.Fatalf("distribution %v does not match weights 1:3:6 (chi2 = %.2f)", counts, chi2)
+	}
+}
+
+func TestPickResponseSeedIsReproducible(t *testing.T) {
+	pool := []domain.WeightedResponse{{Text: "a", Weight: 1}, {Text: "b", Weight: 1}, {Text: "c", Weight: 1}}
+	sequence := func() []string {
+		h := newRouterHarness(t)
+		h.mgr.SetRandomSeed(7)
+		out := make([]string, 20)
+		for i := range out {
+			out[i] = h.mgr.pickResponse(pool)
+		}
+		return out
+	}
+	first, second := sequence(), sequence()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed produced %q and %q", first, second)
+		}
+	}
+}
+
+func TestResponsePoolValidation(t *testing.T) {
+	ctx := context.Background()
+	h := newRouterHarness(t)
+	text := "simple"
+
+	cases := []struct {
+		name  string
+		input UpdateCustomCommandInput
+	}{
+		{"empty pool", UpdateCustomCommandInput{Name: "abrazo", HasResponses: true}},
+		{"zero weight", UpdateCustomCommandInput{Name: "abrazo", HasResponses: true, Responses: []domain.WeightedResponse{{Text: "a", Weight: 0}}}},
+		{"negative weight", UpdateCustomCommandInput{Name: "abrazo", HasResponses: true, Responses: []domain.WeightedResponse{{Text: "a", Weight: -2}}}},
+		{"blank entry", UpdateCustomCommandInput{Name: "abrazo", HasResponses: true, Responses: []domain.WeightedResponse{{Text: "  ", Weight: 1}}}},
+		{"response and pool", UpdateCustomCommandInput{Name: "abrazo", Response: &text, HasResponses: true, Responses: []domain.WeightedResponse{{Text: "a", Weight: 1}}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, _, err := h.mgr.Upsert(ctx, tc.input); err == nil {
+				t.Fatal("expected an error")
+			}
+			if h.mgr.Find("abrazo") != nil {
+				t.Fatal("invalid pool was saved")
+			}
+		})
+	}
+}
+
+func TestManageResponsePoolFromChat(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "abrazo", Response: "🤗"})
+
+	if got := h.send(t, adminMessage(`!command abrazo add-response weight:3 "un abrazo fuerte"`)); len(got) != 1 || got[0] != "✅ Respuesta agregada a abrazo (2 en total)." {
+		t.Fatalf("add-response = %q", got)
+	}
+	pool := h.mgr.Find("abrazo").ResponsePool()
+	if len(pool) != 2 || pool[0] != (domain.WeightedResponse{Text: "🤗", Weight: 1}) || pool[1] != (domain.WeightedResponse{Text: "un abrazo fuerte", Weight: 3}) {
+		t.Fatalf("pool = %+v", pool)
+	}
+	if saved, _ := h.repo.GetCustomCommand(context.Background(), "abrazo"); saved == nil || len(saved.Responses) != 2 {
+		t.Fatalf("repo = %+v", saved)
+	}
+
+	if got := h.send(t, adminMessage(`!command abrazo add-response weight:0 "nada"`)); len(got) != 1 || got[0] != "⚠️ El peso debe ser un número positivo (ej. weight:3)." {
+		t.Fatalf("bad weight = %q", got)
+	}
+	if got := h.send(t, adminMessage("!command abrazo remove-response 5")); len(got) != 1 || got[0] != "⚠️ el comando tiene 2 respuestas, no existe la 5" {
+		t.Fatalf("bad index = %q", got)
+	}
+
+	// al quedar una sola vuelve a ser una respuesta simple
+	if got := h.send(t, adminMessage("!command abrazo remove-response 1")); len(got) != 1 || got[0] != "🗑️ Respuesta 1 quitada de abrazo (quedan 1)." {
+		t.Fatalf("remove-response = %q", got)
+	}
+	cmd := h.mgr.Find("abrazo")
+	if cmd.Response != "un abrazo fuerte" || len(cmd.Responses) != 0 {
+		t.Fatalf("command = %+v", cmd)
+	}
+	if got := h.send(t, adminMessage("!command abrazo remove-response 1")); len(got) != 1 || got[0] != "⚠️ no se puede quitar la única respuesta; borra el comando" {
+		t.Fatalf("remove last = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!abrazo")); len(got) != 1 || got[0] != "un abrazo fuerte" {
+		t.Fatalf("!abrazo = %q", got)
+	}
+}
+
+// slowCommandRepo tarda en guardar, para que dos ediciones se crucen.
+type slowCommandRepo struct {
+	*memoryCommandRepo
+}
+
+func (r slowCommandRepo) UpsertCustomCommand(ctx context.Context, cmd *domain.CustomCommand) error {
+	time.Sleep(time.Millisecond)
+	return r.memoryCommandRepo.UpsertCustomCommand(ctx, cmd)
+}
+
+func TestConcurrentResponseEditsKeepEveryChange(t *testing.T) {
+	ctx := context.Background()
+	repo := slowCommandRepo{newMemoryCommandRepo(&domain.CustomCommand{Name: "abrazo", Response: "🤗"})}
+	mgr, err := NewCustomCommandManager(ctx, repo)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+
+	const editors = 10
+	var wg sync.WaitGroup
+	for i := range editors {
+		wg.Go(func() {
+			if _, _, err := mgr.AddResponse(ctx, "abrazo", fmt.Sprintf("abrazo %d", i), 1); err != nil {
+				t.Errorf("AddResponse(%d): %v", i, err)
+			}
+		})
+	}
+	wg.Wait()
+	if pool := mgr.Find("abrazo").ResponsePool(); len(pool) != editors+1 {
+		t.Fatalf("pool con %d respuestas, esperaba %d: %+v", len(pool), editors+1, pool)
+	}
+
+	// quitar a la vez tampoco pierde ninguna baja
+	for range editors / 2 {
+		wg.Go(func() {
+			if _, err := mgr.RemoveResponse(ctx, "abrazo", 1); err != nil {
+				t.Errorf("RemoveResponse: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	if pool := mgr.Find("abrazo").ResponsePool(); len(pool) != editors+1-editors/2 {
+		t.Fatalf("pool con %d respuestas, esperaba %d", len(pool), editors+1-editors/2)
+	}
+	if saved, _ := repo.GetCustomCommand(ctx, "abrazo"); saved == nil || len(saved.Responses) != editors+1-editors/2 {
+		t.Fatalf("repo = %+v", saved)
+	}
+}
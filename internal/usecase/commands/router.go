@@ -2,16 +2,38 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"zhatBot/internal/domain"
 )
 
 type Router struct {
-	prefix   string
+	mu       sync.RWMutex
 	cmdIndex map[string]Command
 	customs  *CustomCommandManager
+
+	pauseMu  sync.RWMutex
+	paused   bool
+	pauseRep domain.BotPauseRepository
+	onPause  func(paused bool)
+
+	prefixMu  sync.RWMutex
+	prefix    string
+	prefixRep domain.CommandPrefixRepository
+	onPrefix  func(prefix string)
+
+	scopeMu sync.RWMutex
+	scopes  ScopeChecker
+}
+
+// pauseExempt son los comandos que los mods siguen pudiendo usar con el bot
+// pausado: sin !bot no habría forma de reanudarlo desde el chat.
+var pauseExempt = map[string]bool{
+	"bot":     true,
+	"command": true,
 }
 
 func NewRouter(prefix string) *Router {
@@ -21,7 +43,10 @@ func NewRouter(prefix string) *Router {
 	}
 }
 
+// Register puede llamarse con el bot en marcha (p.ej. cuando el streamer conecta su cuenta).
 func (r *Router) Register(cmd Command) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.cmdIndex[strings.ToLower(cmd.Name())] = cmd
 	for _, alias := range cmd.Aliases() {
 		r.cmdIndex[strings.ToLower(alias)] = cmd
@@ -41,11 +66,12 @@ func (r *Router) Handle(ctx context.Context, msg domain.Message, out domain.Outg
 		return nil
 	}
 
-	if !strings.HasPrefix(text, r.prefix) {
+	prefix := r.Prefix()
+	if !strings.HasPrefix(text, prefix) {
 		return nil
 	}
 
-	withoutPrefix := strings.TrimPrefix(text, r.prefix)
+	withoutPrefix := strings.TrimPrefix(text, prefix)
 	parts := strings.Fields(withoutPrefix)
 	if len(parts) == 0 {
 		return nil
@@ -54,13 +80,17 @@ func (r *Router) Handle(ctx context.Context, msg domain.Message, out domain.Outg
 	cmdName := strings.ToLower(parts[0])
 	args := parts[1:]
 
-	cmd, ok := r.cmdIndex[cmdName]
+	cmd, ok := r.lookup(cmdName)
+	if r.Paused() && !(ok && allowedWhilePaused(cmd, msg)) {
+		log.Printf("router: bot pausado, se ignora %q de %s en %s", cmdName, msg.Username, msg.Platform)
+		return nil
+	}
 	if !ok {
-		return r.handleDynamic(ctx, cmdName, msg, out)
+		return r.handleDynamic(ctx, cmdName, args, msg, out)
 	}
 
 	if !cmd.SupportsPlatform(msg.Platform) {
-		if handled, err := r.tryCustom(ctx, cmdName, msg, out); handled {
+		if handled, err := r.tryCustom(ctx, cmdName, args, msg, out); handled {
 			return err
 		}
 		log.Printf("router: comando %q no soportado en plataforma=%s canal=%s usuario=%s", cmdName, msg.Platform, msg.ChannelID, msg.Username)
@@ -74,22 +104,253 @@ func (r *Router) Handle(ctx context.Context, msg domain.Message, out domain.Outg
 		Args:    args,
 	}
 
+	if missing := r.missingScope(cmd, msg.Platform); missing != "" {
+		log.Printf("router: %q necesita el scope %s en %s", cmdName, missing, msg.Platform)
+		// solo los mods pueden arreglarlo; a los viewers no se les responde
+		if !canModerate(msg) {
+			return nil
+		}
+		return out.SendMessage(ctx, msg.Platform, msg.ChannelID, missingScopeReply(missing))
+	}
+
 	return cmd.Handle(ctx, ctxCmd)
 }
 
-func (r *Router) handleDynamic(ctx context.Context, trigger string, msg domain.Message, out domain.OutgoingMessagePort) error {
-	if handled, err := r.tryCustom(ctx, trigger, msg, out); handled {
+// SetScopeChecker activa la verificación de scopes antes de ejecutar los
+// comandos que implementan ScopedCommand.
+func (r *Router) SetScopeChecker(checker ScopeChecker) {
+	r.scopeMu.Lock()
+	defer r.scopeMu.Unlock()
+	r.scopes = checker
+}
+
+// missingScope devuelve el primer scope que le falta a la credencial que usa
+// cmd, o "" si están todos (o si no hay verificador).
+func (r *Router) missingScope(cmd Command, platform domain.Platform) string {
+	scoped, ok := cmd.(ScopedCommand)
+	if !ok {
+		return ""
+	}
+	r.scopeMu.RLock()
+	checker := r.scopes
+	r.scopeMu.RUnlock()
+	if checker == nil {
+		return ""
+	}
+	role, scopes := scoped.RequiredScopes(platform)
+	for _, scope := range scopes {
+		if !checker(platform, role, scope) {
+			return scope
+		}
+	}
+	return ""
+}
+
+func missingScopeReply(scope string) string {
+	return fmt.Sprintf("Falta el permiso %s: hay que volver a iniciar sesión desde el panel.", scope)
+}
+
+// SetPauseStore persiste la pausa en repo; LoadPause la recupera al arrancar.
+func (r *Router) SetPauseStore(repo domain.BotPauseRepository) {
+	r.pauseMu.Lock()
+	defer r.pauseMu.Unlock()
+	r.pauseRep = repo
+}
+
+// LoadPause lee la pausa guardada, así un reinicio no reanuda los comandos
+// sin avisar.
+func (r *Router) LoadPause(ctx context.Context) error {
+	r.pauseMu.RLock()
+	repo := r.pauseRep
+	r.pauseMu.RUnlock()
+	if repo == nil {
+		return nil
+	}
+	paused, err := repo.GetBotPaused(ctx)
+	if err != nil {
+		return err
+	}
+	r.applyPause(paused)
+	return nil
+}
+
+// Paused indica si los comandos están pausados.
+func (r *Router) Paused() bool {
+	if r == nil {
+		return false
+	}
+	r.pauseMu.RLock()
+	defer r.pauseMu.RUnlock()
+	return r.paused
+}
+
+// SetPaused pausa o reanuda todos los comandos y lo persiste. Los mensajes
+// siguen llegando al chat y al bus.
+func (r *Router) SetPaused(ctx context.Context, paused bool) error {
+	r.pauseMu.RLock()
+	repo := r.pauseRep
+	r.pauseMu.RUnlock()
+	if repo != nil {
+		if err := repo.SetBotPaused(ctx, paused); err != nil {
+			return err
+		}
+	}
+	r.applyPause(paused)
+	return nil
+}
+
+// SetPauseHandler se llama cada vez que la pausa cambia.
+func (r *Router) SetPauseHandler(fn func(paused bool)) {
+	r.pauseMu.Lock()
+	defer r.pauseMu.Unlock()
+	r.onPause = fn
+}
+
+func (r *Router) applyPause(paused bool) {
+	r.pauseMu.Lock()
+	changed := r.paused != paused
+	r.paused = paused
+	onPause := r.onPause
+	r.pauseMu.Unlock()
+
+	if !changed {
+		return
+	}
+	if paused {
+		log.Printf("router: comandos pausados")
+	} else {
+		log.Printf("router: comandos reanudados")
+	}
+	if onPause != nil {
+		onPause(paused)
+	}
+}
+
+func allowedWhilePaused(cmd Command, msg domain.Message) bool {
+	if !pauseExempt[strings.ToLower(cmd.Name())] {
+		return false
+	}
+	return msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod
+}
+
+// IsCommand indica si el texto empieza con el prefijo de comandos.
+func (r *Router) IsCommand(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), r.Prefix())
+}
+
+// SetPrefixStore persiste el prefijo en repo; LoadPrefix lo recupera al arrancar.
+func (r *Router) SetPrefixStore(repo domain.CommandPrefixRepository) {
+	r.prefixMu.Lock()
+	defer r.prefixMu.Unlock()
+	r.prefixRep = repo
+}
+
+// LoadPrefix aplica el prefijo guardado. Uno inválido se ignora: mejor seguir
+// con el actual que dejar al bot sin comandos.
+func (r *Router) LoadPrefix(ctx context.Context) error {
+	r.prefixMu.RLock()
+	repo := r.prefixRep
+	r.prefixMu.RUnlock()
+	if repo == nil {
+		return nil
+	}
+	stored, err := repo.GetCommandPrefix(ctx)
+	if err != nil || stored == "" {
+		return err
+	}
+	prefix, err := ValidatePrefix(stored)
+	if err != nil {
+		return fmt.Errorf("prefijo guardado %q: %w", stored, err)
+	}
+	r.applyPrefix(prefix)
+	return nil
+}
+
+// Prefix devuelve el prefijo de los comandos.
+func (r *Router) Prefix() string {
+	r.prefixMu.RLock()
+	defer r.prefixMu.RUnlock()
+	return r.prefix
+}
+
+// SetPrefix valida, persiste y aplica el prefijo en caliente.
+func (r *Router) SetPrefix(ctx context.Context, prefix string) error {
+	prefix, err := ValidatePrefix(prefix)
+	if err != nil {
+		return err
+	}
+	r.prefixMu.RLock()
+	repo := r.prefixRep
+	r.prefixMu.RUnlock()
+	if repo != nil {
+		if err := repo.SetCommandPrefix(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	r.applyPrefix(prefix)
+	return nil
+}
+
+// SetPrefixHandler se llama cada vez que el prefijo cambia.
+func (r *Router) SetPrefixHandler(fn func(prefix string)) {
+	r.prefixMu.Lock()
+	defer r.prefixMu.Unlock()
+	r.onPrefix = fn
+}
+
+func (r *Router) applyPrefix(prefix string) {
+	r.prefixMu.Lock()
+	changed := r.prefix != prefix
+	r.prefix = prefix
+	onPrefix := r.onPrefix
+	r.prefixMu.Unlock()
+
+	if !changed {
+		return
+	}
+	log.Printf("router: prefijo de comandos %q", prefix)
+	if onPrefix != nil {
+		onPrefix(prefix)
+	}
+}
+
+// Trigger devuelve el nombre (sin prefijo, en minúsculas) del comando que
+// dispara text, si el bot lo conoce: propio o personalizado, por nombre o alias.
+func (r *Router) Trigger(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	prefix := r.Prefix()
+	if !strings.HasPrefix(text, prefix) {
+		return "", false
+	}
+	parts := strings.Fields(strings.TrimPrefix(text, prefix))
+	if len(parts) == 0 {
+		return "", false
+	}
+	name := strings.ToLower(parts[0])
+	if cmd, ok := r.lookup(name); ok {
+		return strings.ToLower(cmd.Name()), true
+	}
+	if r.customs != nil {
+		if custom := r.customs.Find(name); custom != nil {
+			return strings.ToLower(custom.Name), true
+		}
+	}
+	return "", false
+}
+
+func (r *Router) handleDynamic(ctx context.Context, trigger string, args []string, msg domain.Message, out domain.OutgoingMessagePort) error {
+	if handled, err := r.tryCustom(ctx, trigger, args, msg, out); handled {
 		return err
 	}
 	log.Printf("router: comando no encontrado %q plataforma=%s canal=%s usuario=%s", trigger, msg.Platform, msg.ChannelID, msg.Username)
 	return nil
 }
 
-func (r *Router) tryCustom(ctx context.Context, trigger string, msg domain.Message, out domain.OutgoingMessagePort) (bool, error) {
+func (r *Router) tryCustom(ctx context.Context, trigger string, args []string, msg domain.Message, out domain.OutgoingMessagePort) (bool, error) {
 	if r.customs == nil {
 		return false, nil
 	}
-	return r.customs.TryHandle(ctx, trigger, msg, out)
+	return r.customs.TryHandle(ctx, trigger, args, msg, out)
 }
 
 func (r *Router) isReservedCommand(name string) bool {
@@ -97,6 +358,13 @@ func (r *Router) isReservedCommand(name string) bool {
 	if name == "" {
 		return false
 	}
-	_, ok := r.cmdIndex[name]
+	_, ok := r.lookup(name)
 	return ok
 }
+
+func (r *Router) lookup(name string) (Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cmd, ok := r.cmdIndex[name]
+	return cmd, ok
+}
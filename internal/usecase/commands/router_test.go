@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// routerHarness arma el flujo completo: router con los comandos propios, un
+// manager de comandos personalizados en memoria y una salida que captura.
+type routerHarness struct {
+	router *Router
+	mgr    *CustomCommandManager
+	repo   *memoryCommandRepo
+	out    *captureOut
+}
+
+func newRouterHarness(t *testing.T, cmds ...*domain.CustomCommand) *routerHarness {
+	t.Helper()
+	repo := newMemoryCommandRepo(cmds...)
+	mgr, err := NewCustomCommandManager(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	router := NewRouter(domain.DefaultCommandPrefix)
+	router.Register(NewPingCommand())
+	router.Register(NewManageCustomCommand(mgr))
+	router.SetCustomManager(mgr)
+	return &routerHarness{router: router, mgr: mgr, repo: repo, out: &captureOut{}}
+}
+
+// send despacha msg y devuelve lo que el bot respondió.
+func (h *routerHarness) send(t *testing.T, msg domain.Message) []string {
+	t.Helper()
+	h.out.reset()
+	if err := h.router.Handle(context.Background(), msg, h.out); err != nil {
+		t.Fatalf("Handle(%q): %v", msg.Text, err)
+	}
+	return h.out.texts()
+}
+
+func kickMessage(user, text string) domain.Message {
+	msg := twitchMessage(user, text)
+	msg.Platform = domain.PlatformKick
+	return msg
+}
+
+func adminMessage(text string) domain.Message {
+	msg := twitchMessage("Zero", text)
+	msg.IsPlatformOwner = true
+	msg.IsPlatformAdmin = true
+	return msg
+}
+
+func TestRouterBuiltinPing(t *testing.T) {
+	h := newRouterHarness(t)
+
+	if got := h.send(t, twitchMessage("ana", "!ping")); len(got) != 1 || got[0] != "pong desde twitch" {
+		t.Fatalf("twitch replies = %q", got)
+	}
+	if got := h.send(t, kickMessage("ana", "  !PING extra")); len(got) != 1 || got[0] != "pong desde kick" {
+		t.Fatalf("kick replies = %q", got)
+	}
+	reply := h.out.messages()[0]
+	if reply.Platform != domain.PlatformKick || reply.ChannelID != "canal" {
+		t.Fatalf("reply sent to %s/%s", reply.Platform, reply.ChannelID)
+	}
+}
+
+func TestRouterIgnoresNonCommands(t *testing.T) {
+	h := newRouterHarness(t)
+	for _, text := range []string{"", "   ", "ping", "hola !ping", "!", "! "} {
+		if got := h.send(t, twitchMessage("ana", text)); len(got) != 0 {
+			t.Fatalf("%q got replies %q", text, got)
+		}
+	}
+}
+
+func TestRouterCustomCommandHitAndMiss(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{
+		Name:     "discord",
+		Response: "Únete: discord.gg/zero",
+		Aliases:  []string{"dc"},
+	})
+
+	if got := h.send(t, twitchMessage("ana", "!discord")); len(got) != 1 || got[0] != "Únete: discord.gg/zero" {
+		t.Fatalf("hit replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!DC")); len(got) != 1 || got[0] != "Únete: discord.gg/zero" {
+		t.Fatalf("alias replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!twitter")); len(got) != 0 {
+		t.Fatalf("miss replies = %q", got)
+	}
+}
+
+func TestRouterPermissionGating(t *testing.T) {
+	h := newRouterHarness(t,
+		&domain.CustomCommand{Name: "modonly", Response: "solo mods", Permissions: []domain.CommandAccessRole{domain.CommandAccessModerators}},
+		&domain.CustomCommand{Name: "subs", Response: "solo subs", Permissions: []domain.CommandAccessRole{domain.CommandAccessSubscribers},
+			PermissionReply: domain.PermissionReplyReply},
+	)
+
+	if got := h.send(t, twitchMessage("ana", "!modonly")); len(got) != 0 {
+		t.Fatalf("viewer got %q", got)
+	}
+	mod := twitchMessage("luis", "!modonly")
+	mod.IsPlatformMod = true
+	if got := h.send(t, mod); len(got) != 1 || got[0] != "solo mods" {
+		t.Fatalf("mod replies = %q", got)
+	}
+
+	if got := h.send(t, twitchMessage("ana", "!subs")); len(got) != 1 || got[0] != "⛔ @ana, no tienes permiso para usar subs." {
+		t.Fatalf("denied reply = %q", got)
+	}
+	sub := twitchMessage("eva", "!subs")
+	sub.IsSubscriber = true
+	if got := h.send(t, sub); len(got) != 1 || got[0] != "solo subs" {
+		t.Fatalf("subscriber replies = %q", got)
+	}
+}
+
+func TestRouterPlatformFiltering(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{
+		Name:      "kickonly",
+		Response:  "solo en kick",
+		Platforms: []domain.Platform{domain.PlatformKick},
+	})
+
+	if got := h.send(t, twitchMessage("ana", "!kickonly")); len(got) != 0 {
+		t.Fatalf("twitch got %q", got)
+	}
+	if got := h.send(t, kickMessage("ana", "!kickonly")); len(got) != 1 || got[0] != "solo en kick" {
+		t.Fatalf("kick replies = %q", got)
+	}
+
+	// un comando propio que no existe en la plataforma no responde
+	h.router.Register(NewSlowModeCommand(nil, "", ""))
+	if got := h.send(t, kickMessage("ana", "!slow 10")); len(got) != 0 {
+		t.Fatalf("unsupported builtin replied %q", got)
+	}
+}
+
+func TestRouterManageCustomCommandFlow(t *testing.T) {
+	h := newRouterHarness(t)
+
+	// solo los admins pueden administrar comandos
+	if got := h.send(t, twitchMessage("ana", "!command redes Sígueme en @zero")); len(got) != 0 {
+		t.Fatalf("viewer managed commands: %q", got)
+	}
+	if h.mgr.Find("redes") != nil {
+		t.Fatal("viewer created a command")
+	}
+
+	if got := h.send(t, adminMessage("!command redes aliases:social platforms:twitch Sígueme en @zero")); len(got) != 1 || got[0] != "✅ Comando redes creado." {
+		t.Fatalf("create replies = %q", got)
+	}
+	stored, _ := h.repo.GetCustomCommand(context.Background(), "redes")
+	if stored == nil || stored.Response != "Sígueme en @zero" {
+		t.Fatalf("stored = %+v", stored)
+	}
+	if got := h.send(t, twitchMessage("ana", "!social")); len(got) != 1 || got[0] != "Sígueme en @zero" {
+		t.Fatalf("new alias replies = %q", got)
+	}
+	if got := h.send(t, kickMessage("ana", "!redes")); len(got) != 0 {
+		t.Fatalf("platform filter ignored: %q", got)
+	}
+
+	if got := h.send(t, adminMessage("!command redes Ahora en @zero_dev")); len(got) != 1 || got[0] != "✅ Comando redes actualizado." {
+		t.Fatalf("update replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!redes")); len(got) != 1 || got[0] != "Ahora en @zero_dev" {
+		t.Fatalf("updated replies = %q", got)
+	}
+
+	if got := h.send(t, adminMessage("!command ping hola")); len(got) != 1 || !strings.HasPrefix(got[0], "⚠️") {
+		t.Fatalf("builtin name replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!ping")); len(got) != 1 || got[0] != "pong desde twitch" {
+		t.Fatalf("builtin was shadowed: %q", got)
+	}
+
+	if got := h.send(t, adminMessage("!command redes action:delete")); len(got) != 1 || got[0] != "🗑️ Comando redes eliminado." {
+		t.Fatalf("delete replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!redes")); len(got) != 0 {
+		t.Fatalf("deleted command replied %q", got)
+	}
+	if got := h.send(t, adminMessage("!command redes action:delete")); len(got) != 1 || got[0] != "⚠️ Comando no encontrado." {
+		t.Fatalf("second delete replies = %q", got)
+	}
+}
+
+func TestRouterFailedUpsertLeavesCommandUntouched(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "redes", Response: "original"})
+
+	// cooldown inválido: el comando no debe quedar modificado a medias
+	_, _, _, err := h.mgr.Upsert(context.Background(), UpdateCustomCommandInput{
+		Name:        "redes",
+		Response:    stringPtr("cambiado"),
+		Cooldown:    -1,
+		HasCooldown: true,
+	})
+	if err == nil {
+		t.Fatal("expected an error for a negative cooldown")
+	}
+	if got := h.send(t, twitchMessage("ana", "!redes")); len(got) != 1 || got[0] != "original" {
+		t.Fatalf("after failed upsert = %q", got)
+	}
+}
+
+func TestRouterRejectsNameTakenByAlias(t *testing.T) {
+	h := newRouterHarness(t, &domain.CustomCommand{Name: "discord", Response: "dc", Aliases: []string{"server"}})
+
+	if got := h.send(t, adminMessage("!command server otra cosa")); len(got) != 1 || !strings.HasPrefix(got[0], "⚠️") {
+		t.Fatalf("alias clash replies = %q", got)
+	}
+	if got := h.send(t, twitchMessage("ana", "!server")); len(got) != 1 || got[0] != "dc" {
+		t.Fatalf("alias was shadowed: %q", got)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// scopeHarness registra !ban con un verificador que responde granted.
+func scopeHarness(t *testing.T, granted bool) (*routerHarness, *fakeActions, *[]string) {
+	t.Helper()
+	h := newRouterHarness(t)
+	actions := &fakeActions{}
+	h.router.Register(NewBanCommand(actions))
+	var asked []string
+	h.router.SetScopeChecker(func(platform domain.Platform, role, scope string) bool {
+		asked = append(asked, string(platform)+"/"+role+"/"+scope)
+		return granted
+	})
+	return h, actions, &asked
+}
+
+func TestRouterRepliesMissingScopeToMods(t *testing.T) {
+	h, actions, asked := scopeHarness(t, false)
+
+	got := h.send(t, modMessage("luis", "!ban troll"))
+	want := "Falta el permiso moderator:manage:banned_users: hay que volver a iniciar sesión desde el panel."
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("respuesta = %q, esperaba %q", got, want)
+	}
+	if len(actions.calls) != 0 {
+		t.Fatalf("el comando corrió sin scope: %q", actions.calls)
+	}
+	if len(*asked) != 1 || (*asked)[0] != "twitch/bot/moderator:manage:banned_users" {
+		t.Fatalf("consultas = %q", *asked)
+	}
+
+	// a un viewer no se le explica nada
+	if got := h.send(t, twitchMessage("ana", "!ban troll")); len(got) != 0 {
+		t.Fatalf("viewer recibió %q", got)
+	}
+}
+
+func TestRouterRunsScopedCommandWhenGranted(t *testing.T) {
+	h, actions, _ := scopeHarness(t, true)
+
+	if got := h.send(t, modMessage("luis", "!ban troll")); len(got) != 1 || got[0] != "🔨 troll fue baneado." {
+		t.Fatalf("respuesta = %q", got)
+	}
+	if len(actions.calls) != 1 {
+		t.Fatalf("llamadas = %q", actions.calls)
+	}
+}
+
+func TestRouterSkipsScopeCheckForPlainCommands(t *testing.T) {
+	h, _, asked := scopeHarness(t, false)
+
+	if got := h.send(t, twitchMessage("ana", "!ping")); len(got) != 1 {
+		t.Fatalf("!ping = %q", got)
+	}
+	if len(*asked) != 0 {
+		t.Fatalf("se consultaron scopes para !ping: %q", *asked)
+	}
+}
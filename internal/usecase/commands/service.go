@@ -17,32 +17,148 @@ const (
 type CommandDTO struct {
 	Name        string                     `json:"name"`
 	Response    string                     `json:"response"`
+	Responses   []domain.WeightedResponse  `json:"responses,omitempty"`
 	Aliases     []string                   `json:"aliases"`
 	Platforms   []string                   `json:"platforms"`
 	Permissions []domain.CommandAccessRole `json:"permissions"`
 	UpdatedAt   string                     `json:"updated_at"`
 	Source      string                     `json:"source"`
-	Editable    bool                       `json:"editable"`
-	Description string                     `json:"description,omitempty"`
-	Usage       string                     `json:"usage,omitempty"`
+	// Status es active o inactive_platform_unavailable si ninguna de sus
+	// plataformas está conectada.
+	Status      string `json:"status"`
+	Editable    bool   `json:"editable"`
+	Description string `json:"description,omitempty"`
+	Usage       string `json:"usage,omitempty"`
+
+	// Cooldown en segundos; los modos vacíos usan el valor global.
+	CooldownSeconds     int    `json:"cooldown_seconds"`
+	UserCooldownSeconds int    `json:"user_cooldown_seconds"`
+	CooldownFeedback    string `json:"cooldown_feedback,omitempty"`
+	PermissionReply     string `json:"permission_reply,omitempty"`
+
+	// Warnings son avisos no bloqueantes del último guardado.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type CommandMutationDTO struct {
 	Name        string                      `json:"name"`
 	Response    *string                     `json:"response,omitempty"`
+	Responses   *[]domain.WeightedResponse  `json:"responses,omitempty"`
 	Aliases     *[]string                   `json:"aliases,omitempty"`
 	Platforms   *[]string                   `json:"platforms,omitempty"`
 	Permissions *[]domain.CommandAccessRole `json:"permissions,omitempty"`
+
+	CooldownSeconds     *int    `json:"cooldown_seconds,omitempty"`
+	UserCooldownSeconds *int    `json:"user_cooldown_seconds,omitempty"`
+	CooldownFeedback    *string `json:"cooldown_feedback,omitempty"`
+	PermissionReply     *string `json:"permission_reply,omitempty"`
+}
+
+// CommandCountDTO resume cuántos comandos hay de cada tipo.
+type CommandCountDTO struct {
+	Builtin int `json:"builtin"`
+	Custom  int `json:"custom"`
+	Total   int `json:"total"`
 }
 
 type Service struct {
-	manager *CustomCommandManager
+	manager  *CustomCommandManager
+	settings domain.CommandSettingsRepository
 }
 
 func NewService(manager *CustomCommandManager) *Service {
 	return &Service{manager: manager}
 }
 
+// SetSettingsRepository permite persistir las opciones globales (modo de cooldown).
+func (s *Service) SetSettingsRepository(repo domain.CommandSettingsRepository) {
+	if s == nil {
+		return
+	}
+	s.settings = repo
+}
+
+// LoadSettings aplica al manager las opciones globales guardadas.
+// Reload vuelve a leer los comandos personalizados y los valores por defecto.
+func (s *Service) Reload(ctx context.Context) error {
+	if s == nil || s.manager == nil {
+		return nil
+	}
+	if err := s.manager.Reload(ctx); err != nil {
+		return err
+	}
+	return s.LoadSettings(ctx)
+}
+
+func (s *Service) LoadSettings(ctx context.Context) error {
+	if s == nil || s.manager == nil || s.settings == nil {
+		return nil
+	}
+	mode, err := s.settings.GetCooldownFeedbackDefault(ctx)
+	if err != nil {
+		return err
+	}
+	if mode != "" {
+		s.manager.SetDefaultCooldownFeedback(mode)
+	}
+	replyMode, err := s.settings.GetPermissionReplyDefault(ctx)
+	if err != nil {
+		return err
+	}
+	if replyMode != "" {
+		s.manager.SetDefaultPermissionReply(replyMode)
+	}
+	return nil
+}
+
+func (s *Service) DefaultCooldownFeedback() string {
+	if s == nil || s.manager == nil {
+		return string(domain.CooldownFeedbackSilent)
+	}
+	return string(s.manager.DefaultCooldownFeedback())
+}
+
+func (s *Service) SetDefaultCooldownFeedback(ctx context.Context, raw string) (string, error) {
+	if s == nil || s.manager == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	mode, ok := domain.ParseCooldownFeedbackMode(raw)
+	if !ok {
+		return "", fmt.Errorf("invalid cooldown feedback mode %q", raw)
+	}
+	if s.settings != nil {
+		if err := s.settings.SetCooldownFeedbackDefault(ctx, mode); err != nil {
+			return "", err
+		}
+	}
+	s.manager.SetDefaultCooldownFeedback(mode)
+	return string(mode), nil
+}
+
+func (s *Service) DefaultPermissionReply() string {
+	if s == nil || s.manager == nil {
+		return string(domain.PermissionReplySilent)
+	}
+	return string(s.manager.DefaultPermissionReply())
+}
+
+func (s *Service) SetDefaultPermissionReply(ctx context.Context, raw string) (string, error) {
+	if s == nil || s.manager == nil {
+		return "", fmt.Errorf("commands service unavailable")
+	}
+	mode, ok := domain.ParsePermissionReplyMode(raw)
+	if !ok {
+		return "", fmt.Errorf("invalid permission reply mode %q", raw)
+	}
+	if s.settings != nil {
+		if err := s.settings.SetPermissionReplyDefault(ctx, mode); err != nil {
+			return "", err
+		}
+	}
+	s.manager.SetDefaultPermissionReply(mode)
+	return string(mode), nil
+}
+
 func (s *Service) List(ctx context.Context) ([]CommandDTO, error) {
 	_ = ctx
 	out := builtinCommandDTOs()
@@ -51,8 +167,24 @@ func (s *Service) List(ctx context.Context) ([]CommandDTO, error) {
 	}
 	customCommands := s.manager.List()
 	for _, cmd := range customCommands {
-		out = append(out, commandDTOFromDomain(cmd))
+		dto := commandDTOFromDomain(cmd)
+		dto.Status = s.manager.CommandStatus(cmd)
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+// Count cuenta los comandos sin armar la lista completa.
+func (s *Service) Count(ctx context.Context) (CommandCountDTO, error) {
+	out := CommandCountDTO{Builtin: len(BuiltinCommandCatalog())}
+	if s != nil && s.manager != nil {
+		custom, err := s.manager.Count(ctx)
+		if err != nil {
+			return CommandCountDTO{}, err
+		}
+		out.Custom = custom
 	}
+	out.Total = out.Builtin + out.Custom
 	return out, nil
 }
 
@@ -61,11 +193,14 @@ func (s *Service) Upsert(ctx context.Context, input CommandMutationDTO) (Command
 		return CommandDTO{}, fmt.Errorf("commands service unavailable")
 	}
 	update := convertMutationToInput(input)
-	result, _, err := s.manager.Upsert(ctx, update)
+	result, _, warnings, err := s.manager.Upsert(ctx, update)
 	if err != nil {
 		return CommandDTO{}, err
 	}
-	return commandDTOFromDomain(result), nil
+	dto := commandDTOFromDomain(result)
+	dto.Warnings = warnings
+	dto.Status = s.manager.CommandStatus(result)
+	return dto, nil
 }
 
 func (s *Service) Delete(ctx context.Context, name string) (bool, error) {
@@ -93,15 +228,49 @@ func commandDTOFromDomain(cmd *domain.CustomCommand) CommandDTO {
 	return CommandDTO{
 		Name:        cmd.Name,
 		Response:    cmd.Response,
+		Responses:   append([]domain.WeightedResponse(nil), cmd.Responses...),
 		Aliases:     append([]string(nil), cmd.Aliases...),
 		Platforms:   platforms,
 		Permissions: append([]domain.CommandAccessRole(nil), cmd.Permissions...),
 		UpdatedAt:   updated,
 		Source:      CommandSourceCustom,
+		Status:      CommandStatusActive,
 		Editable:    true,
+
+		CooldownSeconds:     int(cmd.Cooldown / time.Second),
+		UserCooldownSeconds: int(cmd.UserCooldown / time.Second),
+		CooldownFeedback:    string(cmd.CooldownFeedback),
+		PermissionReply:     string(cmd.PermissionReply),
 	}
 }
 
+// Mutation arma el cambio que recrea el comando, p. ej. en otra instalación.
+func (d CommandDTO) Mutation() CommandMutationDTO {
+	out := CommandMutationDTO{Name: d.Name}
+	if len(d.Responses) > 0 {
+		responses := append([]domain.WeightedResponse(nil), d.Responses...)
+		out.Responses = &responses
+	} else {
+		response := d.Response
+		out.Response = &response
+	}
+	aliases := append([]string{}, d.Aliases...)
+	platforms := append([]string{}, d.Platforms...)
+	permissions := append([]domain.CommandAccessRole{}, d.Permissions...)
+	cooldown := d.CooldownSeconds
+	userCooldown := d.UserCooldownSeconds
+	feedback := d.CooldownFeedback
+	reply := d.PermissionReply
+	out.Aliases = &aliases
+	out.Platforms = &platforms
+	out.Permissions = &permissions
+	out.CooldownSeconds = &cooldown
+	out.UserCooldownSeconds = &userCooldown
+	out.CooldownFeedback = &feedback
+	out.PermissionReply = &reply
+	return out
+}
+
 func builtinCommandDTOs() []CommandDTO {
 	catalog := BuiltinCommandCatalog()
 	out := make([]CommandDTO, 0, len(catalog))
@@ -119,6 +288,7 @@ func builtinCommandDTOs() []CommandDTO {
 			Platforms:   platforms,
 			Permissions: append([]domain.CommandAccessRole(nil), item.Permissions...),
 			Source:      CommandSourceBuiltin,
+			Status:      CommandStatusActive,
 			Editable:    false,
 			Description: item.Description,
 			Usage:       item.Usage,
@@ -135,6 +305,14 @@ func convertMutationToInput(payload CommandMutationDTO) UpdateCustomCommandInput
 		trimmed := strings.TrimSpace(*payload.Response)
 		input.Response = &trimmed
 	}
+	if payload.Responses != nil {
+		input.HasResponses = true
+		input.Responses = append([]domain.WeightedResponse(nil), *payload.Responses...)
+		// un formulario puede mandar response vacío junto al pool
+		if input.Response != nil && *input.Response == "" {
+			input.Response = nil
+		}
+	}
 	if payload.Aliases != nil {
 		input.HasAliases = true
 		input.Aliases = append([]string(nil), *payload.Aliases...)
@@ -159,5 +337,21 @@ func convertMutationToInput(payload CommandMutationDTO) UpdateCustomCommandInput
 			input.Permissions = append(input.Permissions, val)
 		}
 	}
+	if payload.CooldownSeconds != nil {
+		input.HasCooldown = true
+		input.Cooldown = time.Duration(*payload.CooldownSeconds) * time.Second
+	}
+	if payload.UserCooldownSeconds != nil {
+		input.HasUserCooldown = true
+		input.UserCooldown = time.Duration(*payload.UserCooldownSeconds) * time.Second
+	}
+	if payload.CooldownFeedback != nil {
+		input.HasCooldownFeedback = true
+		input.CooldownFeedback = domain.CooldownFeedbackMode(strings.TrimSpace(*payload.CooldownFeedback))
+	}
+	if payload.PermissionReply != nil {
+		input.HasPermissionReply = true
+		input.PermissionReply = domain.PermissionReplyMode(strings.TrimSpace(*payload.PermissionReply))
+	}
 	return input
 }
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestServiceCountWithoutCounter(t *testing.T) {
+	ctx := context.Background()
+	mgr, err := NewCustomCommandManager(ctx, newMemoryCommandRepo(
+		&domain.CustomCommand{Name: "discord", Response: "discord.gg/x"},
+		&domain.CustomCommand{Name: "redes", Response: "@zero"},
+	))
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+
+	got, err := NewService(mgr).Count(ctx)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	builtin := len(BuiltinCommandCatalog())
+	want := CommandCountDTO{Builtin: builtin, Custom: 2, Total: builtin + 2}
+	if got != want {
+		t.Fatalf("Count = %+v, want %+v", got, want)
+	}
+}
+
+func TestServiceCountUsesRepositoryCounter(t *testing.T) {
+	ctx := context.Background()
+	repo := &countingCommandRepo{memoryCommandRepo: newMemoryCommandRepo(&domain.CustomCommand{Name: "discord", Response: "x"})}
+	mgr, err := NewCustomCommandManager(ctx, repo)
+	if err != nil {
+		t.Fatalf("NewCustomCommandManager: %v", err)
+	}
+	// un comando agregado por otro cliente se ve sin recargar
+	repo.UpsertCustomCommand(ctx, &domain.CustomCommand{Name: "horario", Response: "y"})
+
+	got, err := NewService(mgr).Count(ctx)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if got.Custom != 2 || repo.counted != 1 {
+		t.Fatalf("Count = %+v (counter calls %d), want 2 custom from the counter", got, repo.counted)
+	}
+}
+
+func TestServiceCountWithoutManager(t *testing.T) {
+	got, err := NewService(nil).Count(context.Background())
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if got.Custom != 0 || got.Total != got.Builtin || got.Builtin == 0 {
+		t.Fatalf("Count = %+v", got)
+	}
+}
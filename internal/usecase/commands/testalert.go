@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"zhatBot/internal/domain"
+)
+
+// AlertTester pasa una notificación de prueba por el pipeline de alertas.
+type AlertTester interface {
+	Test(ctx context.Context, notificationType domain.NotificationType, platform domain.Platform, requestedBy string) (*domain.Notification, error)
+}
+
+// TestAlertCommand permite al dueño del canal probar sus alertas sin esperar
+// una sub real.
+type TestAlertCommand struct {
+	tester AlertTester
+}
+
+func NewTestAlertCommand(tester AlertTester) *TestAlertCommand {
+	return &TestAlertCommand{tester: tester}
+}
+
+func (c *TestAlertCommand) Name() string {
+	return "testalert"
+}
+
+func (c *TestAlertCommand) Aliases() []string {
+	return nil
+}
+
+func (c *TestAlertCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *TestAlertCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if !msg.IsPlatformOwner || c.tester == nil {
+		return nil
+	}
+
+	if len(cmdCtx.Args) == 0 {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"Uso: !testalert subscription|donation|bits|giveaway_winner|generic")
+	}
+	notificationType, err := domain.ParseNotificationType(cmdCtx.Args[0])
+	if err != nil {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"⚠️ Tipo desconocido. Usa: subscription, donation, bits, giveaway_winner o generic")
+	}
+
+	if _, err := c.tester.Test(ctx, notificationType, msg.Platform, msg.Name()); err != nil {
+		log.Printf("testalert: %v", err)
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+			"❌ No pude enviar la alerta de prueba.")
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+		fmt.Sprintf("🧪 Alerta de prueba enviada: %s", notificationType))
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+type alertCall struct {
+	Type        domain.NotificationType
+	Platform    domain.Platform
+	RequestedBy string
+}
+
+type fakeAlertTester struct {
+	calls []alertCall
+	err   error
+}
+
+func (f *fakeAlertTester) Test(_ context.Context, notificationType domain.NotificationType, platform domain.Platform, requestedBy string) (*domain.Notification, error) {
+	f.calls = append(f.calls, alertCall{notificationType, platform, requestedBy})
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.Notification{ID: 1, Type: notificationType}, nil
+}
+
+func TestTestAlertOwnerOnly(t *testing.T) {
+	tester := &fakeAlertTester{}
+	cmd := NewTestAlertCommand(tester)
+	out := &captureOut{}
+
+	msg := twitchMessage("Viewer", "!testalert bits")
+	msg.IsPlatformMod = true
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, "bits")); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if len(tester.calls) != 0 || len(out.messages()) != 0 {
+		t.Fatal("non-owner triggered a test alert")
+	}
+}
+
+func TestTestAlertSynthesizesType(t *testing.T) {
+	tester := &fakeAlertTester{}
+	cmd := NewTestAlertCommand(tester)
+	out := &captureOut{}
+
+	msg := twitchMessage("Zero", "!testalert Donation")
+	msg.IsPlatformOwner = true
+	if err := cmd.Handle(context.Background(), newCmdContext(msg, out, "Donation")); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	want := alertCall{domain.NotificationDonation, domain.PlatformTwitch, "Zero"}
+	if len(tester.calls) != 1 || tester.calls[0] != want {
+		t.Fatalf("calls = %+v, want %+v", tester.calls, want)
+	}
+	if got := out.last(); got != "🧪 Alerta de prueba enviada: donation" {
+		t.Fatalf("reply = %q", got)
+	}
+}
+
+func TestTestAlertRejectsBadInput(t *testing.T) {
+	tester := &fakeAlertTester{}
+	cmd := NewTestAlertCommand(tester)
+	out := &captureOut{}
+	msg := twitchMessage("Zero", "!testalert")
+	msg.IsPlatformOwner = true
+
+	cmd.Handle(context.Background(), newCmdContext(msg, out))
+	if got := out.last(); got != "Uso: !testalert subscription|donation|bits|giveaway_winner|generic" {
+		t.Fatalf("usage reply = %q", got)
+	}
+
+	cmd.Handle(context.Background(), newCmdContext(msg, out, "raid"))
+	if got := out.last(); got != "⚠️ Tipo desconocido. Usa: subscription, donation, bits, giveaway_winner o generic" {
+		t.Fatalf("unknown type reply = %q", got)
+	}
+	if len(tester.calls) != 0 {
+		t.Fatalf("tester called for bad input: %+v", tester.calls)
+	}
+
+	tester.err = errors.New("db down")
+	cmd.Handle(context.Background(), newCmdContext(msg, out, "bits"))
+	if got := out.last(); got != "❌ No pude enviar la alerta de prueba." {
+		t.Fatalf("failure reply = %q", got)
+	}
+}
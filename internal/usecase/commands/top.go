@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"zhatBot/internal/domain"
+	trackersusecase "zhatBot/internal/usecase/trackers"
+)
+
+// LeaderboardReader devuelve el ranking de usuarios más activos
+// (trackers.Leaderboard).
+type LeaderboardReader interface {
+	Top(ctx context.Context, scope domain.LeaderboardScope, by string, n int) (trackersusecase.LeaderboardDTO, error)
+}
+
+// TopCommand implementa !top [emotes] [stream|total].
+type TopCommand struct {
+	leaderboard LeaderboardReader
+}
+
+func NewTopCommand(leaderboard LeaderboardReader) *TopCommand {
+	return &TopCommand{leaderboard: leaderboard}
+}
+
+func (c *TopCommand) Name() string {
+	return "top"
+}
+
+func (c *TopCommand) Aliases() []string {
+	return []string{"leaderboard"}
+}
+
+func (c *TopCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *TopCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if c.leaderboard == nil {
+		return nil
+	}
+	msg := cmdCtx.Message
+
+	var scope domain.LeaderboardScope
+	by := trackersusecase.LeaderboardByMessages
+	for _, arg := range cmdCtx.Args {
+		switch strings.ToLower(arg) {
+		case "emotes", "emote":
+			by = trackersusecase.LeaderboardByEmotes
+		case "stream", "sesion", "sesión", "session":
+			scope = domain.LeaderboardSession
+		case "total", "all", "siempre":
+			scope = domain.LeaderboardAllTime
+		}
+	}
+
+	top, err := c.leaderboard.Top(ctx, scope, by, 0)
+	if err != nil {
+		log.Printf("top command: %v", err)
+		return nil
+	}
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, formatLeaderboard(top))
+}
+
+func formatLeaderboard(top trackersusecase.LeaderboardDTO) string {
+	title := "🏆 Top del stream"
+	if top.Scope == string(domain.LeaderboardAllTime) {
+		title = "🏆 Top histórico"
+	}
+	unit := "mensajes"
+	if top.By == trackersusecase.LeaderboardByEmotes {
+		title += " en emotes"
+		unit = "emotes"
+	}
+	if len(top.Entries) == 0 {
+		return title + ": todavía no hay nadie."
+	}
+
+	parts := make([]string, 0, len(top.Entries))
+	for _, entry := range top.Entries {
+		value := entry.Messages
+		if top.By == trackersusecase.LeaderboardByEmotes {
+			value = entry.Emotes
+		}
+		parts = append(parts, fmt.Sprintf("%d. %s (%d %s)", entry.Rank, entry.Name, value, unit))
+	}
+	return title + ": " + strings.Join(parts, " · ")
+}
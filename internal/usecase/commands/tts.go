@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"zhatBot/internal/domain"
 	ttsusecase "zhatBot/internal/usecase/tts"
 )
 
+// TTSHistoryReader da la última lectura que sonó, para !tts last.
+type TTSHistoryReader interface {
+	Last(ctx context.Context) (domain.TTSHistoryEntry, bool)
+}
+
 type TTSCommand struct {
 	service *ttsusecase.Service
+	history TTSHistoryReader
 }
 
-func NewTTSCommand(service *ttsusecase.Service) *TTSCommand {
-	return &TTSCommand{service: service}
+func NewTTSCommand(service *ttsusecase.Service, history TTSHistoryReader) *TTSCommand {
+	return &TTSCommand{service: service, history: history}
 }
 
 func (c *TTSCommand) Name() string {
@@ -42,6 +49,8 @@ func (c *TTSCommand) Handle(ctx context.Context, cmdCtx *Context) error {
 	lower := strings.ToLower(first)
 
 	switch {
+	case lower == "last" && len(cmdCtx.Args) == 1 && canSeeTTSHistory(cmdCtx.Message):
+		return c.handleLast(ctx, cmdCtx)
 	case lower == "voice:list":
 		return c.handleList(ctx, cmdCtx)
 	case strings.HasPrefix(lower, "voice:"):
@@ -59,7 +68,7 @@ func (c *TTSCommand) handleList(ctx context.Context, cmdCtx *Context) error {
 	voices := c.service.ListVoices()
 	parts := make([]string, 0, len(voices))
 	for _, voice := range voices {
-		parts = append(parts, fmt.Sprintf("%s (%s)", voice.Code, voice.Label))
+		parts = append(parts, voice.Code)
 	}
 	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
 		"Voces disponibles: "+strings.Join(parts, ", "))
@@ -95,6 +104,31 @@ func (c *TTSCommand) handleRequest(ctx context.Context, cmdCtx *Context, text st
 		fmt.Sprintf("🔊 Enviado a reproducción (%s)", voice.Code))
 }
 
+// canSeeTTSHistory: para el resto, "!tts last" es una lectura más.
+func canSeeTTSHistory(msg domain.Message) bool {
+	return msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod
+}
+
+// handleLast responde quién pidió la última lectura; el texto no se repite
+// en el chat.
+func (c *TTSCommand) handleLast(ctx context.Context, cmdCtx *Context) error {
+	msg := cmdCtx.Message
+	if c.history == nil {
+		return nil
+	}
+	last, ok := c.history.Last(ctx)
+	if !ok {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, "🔇 Todavía no sonó ningún TTS.")
+	}
+	requester := strings.TrimSpace(last.RequestedBy)
+	if requester == "" {
+		requester = "desconocido"
+	}
+	ago := time.Since(last.FinishedAt).Round(time.Second)
+	return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID,
+		fmt.Sprintf("🔊 El último TTS lo pidió %s en %s (hace %s).", requester, last.Platform, ago))
+}
+
 func (c *TTSCommand) usage(ctx context.Context, cmdCtx *Context) error {
 	return cmdCtx.Out.SendMessage(ctx, cmdCtx.Message.Platform, cmdCtx.Message.ChannelID,
 		"Uso: !tts voice:list | !tts voice:<id|start|stop> | !tts <texto>")
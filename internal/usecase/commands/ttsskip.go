@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// TTSSkipper controla la lectura TTS en curso.
+type TTSSkipper interface {
+	CurrentID() string
+	VoteSkip(ctx context.Context, requestID, voter string) (votes, required int, ok bool)
+	Skip(requestID string) bool
+}
+
+// TTSSkipCommand deja que el chat vote para saltar la lectura TTS actual. Los
+// mods y el dueño del canal la saltan directamente.
+type TTSSkipCommand struct {
+	player TTSSkipper
+}
+
+func NewTTSSkipCommand(player TTSSkipper) *TTSSkipCommand {
+	return &TTSSkipCommand{player: player}
+}
+
+func (c *TTSSkipCommand) Name() string {
+	return "ttsskip"
+}
+
+func (c *TTSSkipCommand) Aliases() []string {
+	return nil
+}
+
+func (c *TTSSkipCommand) SupportsPlatform(p domain.Platform) bool {
+	return p == domain.PlatformTwitch || p == domain.PlatformKick
+}
+
+func (c *TTSSkipCommand) Handle(ctx context.Context, cmdCtx *Context) error {
+	if c.player == nil {
+		return nil
+	}
+	msg := cmdCtx.Message
+	reply := func(text string) error {
+		return cmdCtx.Out.SendMessage(ctx, msg.Platform, msg.ChannelID, text)
+	}
+
+	requestID := c.player.CurrentID()
+	if requestID == "" {
+		return nil
+	}
+
+	if msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod {
+		if c.player.Skip(requestID) {
+			return reply(fmt.Sprintf("⏭️ %s saltó el TTS.", msg.Name()))
+		}
+		return nil
+	}
+
+	voter := strings.TrimSpace(msg.UserID)
+	if voter == "" {
+		voter = msg.LoginName()
+	}
+	if voter == "" {
+		return nil
+	}
+
+	votes, required, ok := c.player.VoteSkip(ctx, requestID, voter)
+	if !ok {
+		// la lectura terminó mientras llegaba el voto
+		return nil
+	}
+	if votes < required {
+		return reply(fmt.Sprintf("🗳️ Votos para saltar el TTS: %d/%d", votes, required))
+	}
+	if c.player.Skip(requestID) {
+		return reply(fmt.Sprintf("⏭️ TTS saltado por votación (%d/%d).", votes, required))
+	}
+	return nil
+}
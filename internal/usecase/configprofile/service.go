@@ -0,0 +1,226 @@
+// Package configprofile exporta e importa la configuración del bot (comandos,
+// plantillas, ajustes de cada función) como un solo JSON para compartirla con
+// otro streamer. Cada sección pasa por el servicio de su función, así que se
+// valida igual que si se editara desde la UI. Nunca incluye credenciales ni
+// rutas de la máquina.
+package configprofile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Format identifica el documento para no importar cualquier JSON.
+	Format = "zhatbot-profile"
+	// FormatVersion se sube cuando cambia la estructura del documento (no la
+	// de una sección: cada una tiene su versión).
+	FormatVersion = 1
+)
+
+const (
+	StatusImported = "imported"
+	StatusPartial  = "partial"
+	StatusSkipped  = "skipped"
+	StatusFailed   = "failed"
+)
+
+var ErrInvalidProfile = errors.New("perfil inválido")
+
+// Section sabe exportar e importar una parte de la configuración.
+type Section struct {
+	Name string
+	// Version se sube cuando cambia el formato de los datos de la sección.
+	Version int
+	Export  func(ctx context.Context) (any, error)
+	// Import aplica los datos. Con overwrite en false no toca las entradas
+	// que ya existen; las secciones de ajustes se aplican siempre.
+	Import func(ctx context.Context, data json.RawMessage, overwrite bool) (Result, error)
+}
+
+// Result es lo que devuelve cada sección al importar.
+type Result struct {
+	Applied int
+	Skipped int
+	Errors  []string
+}
+
+// Fail anota un error de una entrada sin cortar el resto de la sección.
+func (r *Result) Fail(entry string, err error) {
+	r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", entry, err))
+}
+
+type Document struct {
+	Format     string                     `json:"format"`
+	Version    int                        `json:"version"`
+	ExportedAt string                     `json:"exported_at"`
+	Sections   map[string]SectionDocument `json:"sections"`
+}
+
+type SectionDocument struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type SectionReport struct {
+	Section string   `json:"section"`
+	Status  string   `json:"status"`
+	Applied int      `json:"applied"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+type Report struct {
+	Sections []SectionReport `json:"sections"`
+}
+
+type Service struct {
+	sections []Section
+	now      func() time.Time
+}
+
+func NewService(sections ...Section) *Service {
+	return &Service{sections: sections, now: time.Now}
+}
+
+// Sections devuelve los nombres de las secciones en el orden en que se importan.
+func (s *Service) Sections() []string {
+	out := make([]string, 0, len(s.sections))
+	for _, section := range s.sections {
+		out = append(out, section.Name)
+	}
+	return out
+}
+
+// Export arma el documento con todas las secciones. Si una falla no se
+// devuelve un perfil a medias.
+func (s *Service) Export(ctx context.Context) (Document, error) {
+	doc := Document{
+		Format:     Format,
+		Version:    FormatVersion,
+		ExportedAt: s.now().UTC().Format(time.RFC3339),
+		Sections:   make(map[string]SectionDocument, len(s.sections)),
+	}
+	for _, section := range s.sections {
+		value, err := section.Export(ctx)
+		if err != nil {
+			return Document{}, fmt.Errorf("%s: %w", section.Name, err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return Document{}, fmt.Errorf("%s: %w", section.Name, err)
+		}
+		doc.Sections[section.Name] = SectionDocument{Version: section.Version, Data: data}
+	}
+	return doc, nil
+}
+
+// Import aplica las secciones pedidas (todas las del documento si names está
+// vacío). Un error en una sección no corta las demás: queda en el reporte.
+func (s *Service) Import(ctx context.Context, raw []byte, names []string, overwrite bool) (Report, error) {
+	var doc Document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return Report{}, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+	}
+	if doc.Format != Format {
+		return Report{}, fmt.Errorf("%w: formato %q", ErrInvalidProfile, doc.Format)
+	}
+	if doc.Version < 1 || doc.Version > FormatVersion {
+		return Report{}, fmt.Errorf("%w: versión %d no soportada", ErrInvalidProfile, doc.Version)
+	}
+
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			requested[name] = true
+		}
+	}
+
+	report := Report{Sections: []SectionReport{}}
+	for _, section := range s.sections {
+		if len(requested) > 0 && !requested[section.Name] {
+			continue
+		}
+		delete(requested, section.Name)
+		stored, ok := doc.Sections[section.Name]
+		if !ok {
+			if len(names) > 0 {
+				report.Sections = append(report.Sections, SectionReport{
+					Section: section.Name,
+					Status:  StatusSkipped,
+					Errors:  []string{"no está en el perfil"},
+				})
+			}
+			continue
+		}
+		report.Sections = append(report.Sections, s.importSection(ctx, section, stored, overwrite))
+	}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !requested[name] {
+			continue
+		}
+		delete(requested, name)
+		report.Sections = append(report.Sections, SectionReport{
+			Section: name,
+			Status:  StatusFailed,
+			Errors:  []string{"sección desconocida"},
+		})
+	}
+	return report, nil
+}
+
+func (s *Service) importSection(ctx context.Context, section Section, stored SectionDocument, overwrite bool) SectionReport {
+	out := SectionReport{Section: section.Name}
+	if stored.Version < 1 || stored.Version > section.Version {
+		out.Status = StatusFailed
+		out.Errors = []string{fmt.Sprintf("versión %d no soportada", stored.Version)}
+		return out
+	}
+	result, err := section.Import(ctx, stored.Data, overwrite)
+	out.Applied = result.Applied
+	out.Skipped = result.Skipped
+	out.Errors = result.Errors
+	if err != nil {
+		out.Errors = append(out.Errors, err.Error())
+	}
+	switch {
+	case len(out.Errors) == 0 && out.Applied == 0 && out.Skipped > 0:
+		out.Status = StatusSkipped
+	case len(out.Errors) == 0:
+		out.Status = StatusImported
+	case out.Applied > 0:
+		out.Status = StatusPartial
+	default:
+		out.Status = StatusFailed
+	}
+	return out
+}
+
+// Settings arma una sección para un servicio con Settings/Update. Los ajustes
+// se reemplazan enteros: no hay entradas que conservar.
+func Settings[T any](name string, get func() T, update func(ctx context.Context, settings T) (T, error)) Section {
+	return Section{
+		Name:    name,
+		Version: 1,
+		Export: func(ctx context.Context) (any, error) {
+			return get(), nil
+		},
+		Import: func(ctx context.Context, data json.RawMessage, overwrite bool) (Result, error) {
+			// los campos que falten conservan el valor actual
+			settings := get()
+			if err := json.Unmarshal(data, &settings); err != nil {
+				return Result{}, fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+			}
+			if _, err := update(ctx, settings); err != nil {
+				return Result{}, err
+			}
+			return Result{Applied: 1}, nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package connections
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// Presence manda el saludo configurado cuando el chat de una plataforma
+// confirma la conexión y la despedida al apagar el bot. Los saludos respetan
+// domain.PresenceDedupeWindow para que las reconexiones no llenen el chat.
+type Presence struct {
+	out      Sender
+	channel  func(domain.Platform) string
+	repo     domain.PresenceSettingsRepository
+	readOnly func() bool
+	now      func() time.Time
+
+	mu       sync.Mutex
+	settings domain.PresenceSettings
+	greeted  map[domain.Platform]time.Time
+	online   map[domain.Platform]bool
+}
+
+// NewPresence recibe el sender, cómo resolver el canal de cada plataforma y
+// dónde guardar los mensajes (puede ser nil).
+func NewPresence(out Sender, channel func(domain.Platform) string, repo domain.PresenceSettingsRepository) *Presence {
+	return &Presence{
+		out:      out,
+		channel:  channel,
+		repo:     repo,
+		now:      time.Now,
+		settings: domain.PresenceSettings{},
+		greeted:  make(map[domain.Platform]time.Time),
+		online:   make(map[domain.Platform]bool),
+	}
+}
+
+// SetReadOnly evita escribir mientras el modo solo lectura está activo.
+func (p *Presence) SetReadOnly(enabled func() bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.readOnly = enabled
+}
+
+// Load lee los mensajes guardados.
+func (p *Presence) Load(ctx context.Context) error {
+	if p == nil || p.repo == nil {
+		return nil
+	}
+	stored, err := p.repo.GetPresenceSettings(ctx)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.settings = normalizePresence(stored)
+	p.mu.Unlock()
+	return nil
+}
+
+// Settings devuelve los mensajes de cada plataforma.
+func (p *Presence) Settings() domain.PresenceSettings {
+	if p == nil {
+		return domain.PresenceSettings{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return clonePresence(p.settings)
+}
+
+// Update reemplaza los mensajes y los guarda.
+func (p *Presence) Update(ctx context.Context, settings domain.PresenceSettings) (domain.PresenceSettings, error) {
+	if p == nil {
+		return nil, fmt.Errorf("presence unavailable")
+	}
+	settings = normalizePresence(settings)
+	if p.repo != nil {
+		if err := p.repo.SetPresenceSettings(ctx, settings); err != nil {
+			return nil, err
+		}
+	}
+	p.mu.Lock()
+	p.settings = settings
+	p.mu.Unlock()
+	return clonePresence(settings), nil
+}
+
+// Connected anota la conexión y manda el saludo si hay uno y no se mandó
+// dentro de la ventana de dedupe.
+func (p *Presence) Connected(ctx context.Context, platform domain.Platform) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.online[platform] = true
+	text := p.settings[platform].ConnectMessage
+	now := p.now()
+	if text == "" {
+		p.mu.Unlock()
+		return
+	}
+	if last, ok := p.greeted[platform]; ok && now.Sub(last) < domain.PresenceDedupeWindow {
+		p.mu.Unlock()
+		log.Printf("presence: %s reconectó, no se repite el saludo", platform)
+		return
+	}
+	p.greeted[platform] = now
+	p.mu.Unlock()
+
+	p.send(ctx, platform, text)
+}
+
+// Disconnected anota que la plataforma ya no está conectada, así la
+// despedida no se intenta en un chat caído.
+func (p *Presence) Disconnected(platform domain.Platform) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.online, platform)
+}
+
+// Goodbye manda la despedida a las plataformas conectadas. Se llama antes
+// de cerrar los adaptadores.
+func (p *Presence) Goodbye(ctx context.Context) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	pending := make(map[domain.Platform]string)
+	for platform := range p.online {
+		if text := p.settings[platform].DisconnectMessage; text != "" {
+			pending[platform] = text
+		}
+	}
+	p.mu.Unlock()
+
+	for platform, text := range pending {
+		p.send(ctx, platform, text)
+	}
+}
+
+func (p *Presence) send(ctx context.Context, platform domain.Platform, text string) {
+	p.mu.Lock()
+	readOnly := p.readOnly
+	p.mu.Unlock()
+	if readOnly != nil && readOnly() {
+		return
+	}
+	var channelID string
+	if p.channel != nil {
+		channelID = p.channel(platform)
+	}
+	if channelID == "" || p.out == nil {
+		log.Printf("presence: no hay canal de %s para el mensaje", platform)
+		return
+	}
+	if err := p.out.SendMessage(ctx, platform, channelID, text); err != nil {
+		log.Printf("presence: no pude escribir en %s: %v", platform, err)
+	}
+}
+
+// normalizePresence descarta plataformas desconocidas y las que no tienen
+// ningún mensaje.
+func normalizePresence(settings domain.PresenceSettings) domain.PresenceSettings {
+	out := domain.PresenceSettings{}
+	for platform, messages := range settings {
+		platform = domain.Platform(strings.ToLower(strings.TrimSpace(string(platform))))
+		switch platform {
+		case domain.PlatformTwitch, domain.PlatformKick:
+		default:
+			continue
+		}
+		messages.ConnectMessage = strings.TrimSpace(messages.ConnectMessage)
+		messages.DisconnectMessage = strings.TrimSpace(messages.DisconnectMessage)
+		if messages.ConnectMessage == "" && messages.DisconnectMessage == "" {
+			continue
+		}
+		out[platform] = messages
+	}
+	return out
+}
+
+func clonePresence(settings domain.PresenceSettings) domain.PresenceSettings {
+	out := make(domain.PresenceSettings, len(settings))
+	for platform, messages := range settings {
+		out[platform] = messages
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package connections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// flappingAdapter imita un adaptador cuya conexión se cae y vuelve: avisa
+// cada cambio por el callback, como hace el runtime con Presence.
+type flappingAdapter struct {
+	platform domain.Platform
+	presence *Presence
+}
+
+func (a *flappingAdapter) report(connected bool) {
+	if connected {
+		a.presence.Connected(context.Background(), a.platform)
+	} else {
+		a.presence.Disconnected(a.platform)
+	}
+}
+
+func (a *flappingAdapter) flap(times int) {
+	for range times {
+		a.report(false)
+		a.report(true)
+	}
+}
+
+// memoryPresence hace de la fila de presence en settings.
+type memoryPresence struct {
+	settings domain.PresenceSettings
+}
+
+func (m *memoryPresence) GetPresenceSettings(context.Context) (domain.PresenceSettings, error) {
+	return m.settings, nil
+}
+
+func (m *memoryPresence) SetPresenceSettings(_ context.Context, settings domain.PresenceSettings) error {
+	m.settings = settings
+	return nil
+}
+
+func newTestPresence(t *testing.T) (*Presence, *fakeSender, *fakeClock) {
+	t.Helper()
+	out := &fakeSender{}
+	presence := NewPresence(out, func(p domain.Platform) string { return "canal-" + string(p) }, nil)
+	clock := &fakeClock{t: time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)}
+	presence.now = clock.Now
+	_, err := presence.Update(context.Background(), domain.PresenceSettings{
+		domain.PlatformTwitch: {ConnectMessage: "Bot online ✅", DisconnectMessage: "Chau 👋"},
+		domain.PlatformKick:   {ConnectMessage: "Hola Kick"},
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return presence, out, clock
+}
+
+func TestPresenceDedupesFlappingConnection(t *testing.T) {
+	presence, out, clock := newTestPresence(t)
+	twitch := &flappingAdapter{platform: domain.PlatformTwitch, presence: presence}
+
+	twitch.report(true)
+	twitch.flap(5)
+	if len(out.sends) != 1 || out.sends[0] != "twitch/canal-twitch: Bot online ✅" {
+		t.Fatalf("sends = %q, want a single greeting", out.sends)
+	}
+
+	// dentro de la ventana sigue sin repetirse
+	clock.Advance(domain.PresenceDedupeWindow - time.Second)
+	twitch.flap(1)
+	if len(out.sends) != 1 {
+		t.Fatalf("sends = %q inside the dedupe window", out.sends)
+	}
+
+	// pasada la ventana desde el último saludo, vuelve a saludar una vez
+	clock.Advance(time.Second)
+	twitch.flap(3)
+	if len(out.sends) != 2 {
+		t.Fatalf("sends = %q after the window", out.sends)
+	}
+
+	// cada plataforma tiene su propia ventana
+	kick := &flappingAdapter{platform: domain.PlatformKick, presence: presence}
+	kick.report(true)
+	kick.flap(2)
+	if len(out.sends) != 3 || out.sends[2] != "kick/canal-kick: Hola Kick" {
+		t.Fatalf("sends = %q", out.sends)
+	}
+}
+
+func TestPresenceGoodbyeOnlyToConnectedPlatforms(t *testing.T) {
+	presence, out, _ := newTestPresence(t)
+	presence.Connected(context.Background(), domain.PlatformTwitch)
+	presence.Connected(context.Background(), domain.PlatformKick)
+	out.sends = nil
+
+	// Kick no tiene despedida configurada
+	presence.Goodbye(context.Background())
+	if len(out.sends) != 1 || out.sends[0] != "twitch/canal-twitch: Chau 👋" {
+		t.Fatalf("sends = %q", out.sends)
+	}
+
+	// si el chat se cayó antes de apagar, no hay despedida
+	out.sends = nil
+	presence.Disconnected(domain.PlatformTwitch)
+	presence.Goodbye(context.Background())
+	if len(out.sends) != 0 {
+		t.Fatalf("goodbye to a disconnected chat: %q", out.sends)
+	}
+}
+
+func TestPresenceRespectsReadOnly(t *testing.T) {
+	presence, out, _ := newTestPresence(t)
+	readOnly := true
+	presence.SetReadOnly(func() bool { return readOnly })
+
+	presence.Connected(context.Background(), domain.PlatformTwitch)
+	presence.Goodbye(context.Background())
+	if len(out.sends) != 0 {
+		t.Fatalf("sends = %q in read-only mode", out.sends)
+	}
+}
+
+func TestPresenceSettingsNormalized(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryPresence{}
+	presence := NewPresence(&fakeSender{}, nil, repo)
+
+	saved, err := presence.Update(ctx, domain.PresenceSettings{
+		" Twitch ": {ConnectMessage: "  hola  "},
+		"kick":     {},
+		"youtube":  {ConnectMessage: "hola"},
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(saved) != 1 || saved[domain.PlatformTwitch].ConnectMessage != "hola" {
+		t.Fatalf("saved = %+v", saved)
+	}
+	if len(repo.settings) != 1 {
+		t.Fatalf("stored = %+v", repo.settings)
+	}
+
+	reloaded := NewPresence(&fakeSender{}, nil, repo)
+	if err := reloaded.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := reloaded.Settings(); got[domain.PlatformTwitch].ConnectMessage != "hola" {
+		t.Fatalf("Settings() = %+v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// TestSendCooldown es la espera mínima entre pruebas de la misma plataforma.
+const TestSendCooldown = 30 * time.Second
+
+const testSendText = "✅ zhatBot: mensaje de prueba, el bot puede escribir en este chat."
+
+var (
+	ErrTestSendTooSoon   = errors.New("espera antes de volver a probar")
+	ErrTestSendNoChannel = errors.New("no hay canal configurado para la plataforma")
+	ErrTestSendReadOnly  = errors.New("el modo solo lectura está activo")
+)
+
+// Sender es el MultiSender del runtime.
+type Sender interface {
+	SendMessage(ctx context.Context, platform domain.Platform, channelID, text string) error
+}
+
+// TestSendResult describe una prueba exitosa.
+type TestSendResult struct {
+	Platform  string    `json:"platform"`
+	ChannelID string    `json:"channel_id"`
+	Text      string    `json:"text"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// TestSender manda un mensaje inofensivo al canal propio del bot para
+// confirmar que puede escribir. Cada plataforma se puede probar una vez cada
+// TestSendCooldown, salga bien o mal.
+type TestSender struct {
+	out      Sender
+	channel  func(domain.Platform) string
+	readOnly func() bool
+	now      func() time.Time
+
+	mu   sync.Mutex
+	last map[domain.Platform]time.Time
+}
+
+// NewTestSender recibe el sender y cómo resolver el canal de cada plataforma.
+func NewTestSender(out Sender, channel func(domain.Platform) string) *TestSender {
+	return &TestSender{
+		out:     out,
+		channel: channel,
+		now:     time.Now,
+		last:    make(map[domain.Platform]time.Time),
+	}
+}
+
+// SetReadOnly evita probar mientras el modo solo lectura suprime los envíos.
+func (t *TestSender) SetReadOnly(enabled func() bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.readOnly = enabled
+}
+
+// Send envía la prueba. Los errores de la plataforma se devuelven envueltos.
+func (t *TestSender) Send(ctx context.Context, platform domain.Platform) (TestSendResult, error) {
+	switch platform {
+	case domain.PlatformTwitch, domain.PlatformKick:
+	default:
+		return TestSendResult{}, fmt.Errorf("plataforma desconocida: %q", platform)
+	}
+
+	t.mu.Lock()
+	readOnly := t.readOnly
+	now := t.now()
+	if last, ok := t.last[platform]; ok && now.Sub(last) < TestSendCooldown {
+		wait := TestSendCooldown - now.Sub(last)
+		t.mu.Unlock()
+		return TestSendResult{}, fmt.Errorf("%w (%ds)", ErrTestSendTooSoon, int(wait.Seconds())+1)
+	}
+	t.last[platform] = now
+	t.mu.Unlock()
+
+	if readOnly != nil && readOnly() {
+		return TestSendResult{}, ErrTestSendReadOnly
+	}
+	var channelID string
+	if t.channel != nil {
+		channelID = t.channel(platform)
+	}
+	if channelID == "" {
+		return TestSendResult{}, ErrTestSendNoChannel
+	}
+	if t.out == nil {
+		return TestSendResult{}, fmt.Errorf("sender no disponible")
+	}
+	if err := t.out.SendMessage(ctx, platform, channelID, testSendText); err != nil {
+		return TestSendResult{}, fmt.Errorf("no se pudo enviar a %s: %w", platform, err)
+	}
+	return TestSendResult{
+		Platform:  string(platform),
+		ChannelID: channelID,
+		Text:      testSendText,
+		SentAt:    now,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeSender struct {
+	err   error
+	sends []string
+}
+
+func (s *fakeSender) SendMessage(_ context.Context, platform domain.Platform, channelID, text string) error {
+	s.sends = append(s.sends, string(platform)+"/"+channelID+": "+text)
+	return s.err
+}
+
+func newTestSender(out Sender) (*TestSender, *fakeClock) {
+	sender := NewTestSender(out, func(p domain.Platform) string {
+		if p == domain.PlatformTwitch {
+			return "zhatbot"
+		}
+		return ""
+	})
+	clock := &fakeClock{t: time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)}
+	sender.now = clock.Now
+	return sender, clock
+}
+
+func TestTestSendSuccess(t *testing.T) {
+	out := &fakeSender{}
+	sender, clock := newTestSender(out)
+
+	result, err := sender.Send(context.Background(), domain.PlatformTwitch)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if result.Platform != "twitch" || result.ChannelID != "zhatbot" || !result.SentAt.Equal(clock.Now()) {
+		t.Fatalf("result = %+v", result)
+	}
+	if len(out.sends) != 1 || out.sends[0] != "twitch/zhatbot: "+testSendText {
+		t.Fatalf("sends = %q", out.sends)
+	}
+}
+
+func TestTestSendPlatformError(t *testing.T) {
+	rejected := errors.New("403 forbidden")
+	sender, _ := newTestSender(&fakeSender{err: rejected})
+
+	_, err := sender.Send(context.Background(), domain.PlatformTwitch)
+	if !errors.Is(err, rejected) {
+		t.Fatalf("err = %v, want the platform error wrapped", err)
+	}
+}
+
+func TestTestSendCooldown(t *testing.T) {
+	out := &fakeSender{err: errors.New("falla")}
+	sender, clock := newTestSender(out)
+	ctx := context.Background()
+
+	sender.Send(ctx, domain.PlatformTwitch)
+	// el intento fallido también cuenta para la espera
+	clock.Advance(10 * time.Second)
+	if _, err := sender.Send(ctx, domain.PlatformTwitch); !errors.Is(err, ErrTestSendTooSoon) {
+		t.Fatalf("err = %v, want ErrTestSendTooSoon", err)
+	}
+	// cada plataforma tiene su propia espera
+	if _, err := sender.Send(ctx, domain.PlatformKick); errors.Is(err, ErrTestSendTooSoon) {
+		t.Fatalf("kick blocked by twitch cooldown: %v", err)
+	}
+
+	out.err = nil
+	clock.Advance(TestSendCooldown)
+	if _, err := sender.Send(ctx, domain.PlatformTwitch); err != nil {
+		t.Fatalf("after cooldown: %v", err)
+	}
+	if len(out.sends) != 2 {
+		t.Fatalf("sends = %d, want 2", len(out.sends))
+	}
+}
+
+func TestTestSendRefusals(t *testing.T) {
+	ctx := context.Background()
+	out := &fakeSender{}
+
+	sender, _ := newTestSender(out)
+	if _, err := sender.Send(ctx, domain.PlatformKick); !errors.Is(err, ErrTestSendNoChannel) {
+		t.Fatalf("no channel err = %v", err)
+	}
+	if _, err := sender.Send(ctx, "youtube"); err == nil {
+		t.Fatal("unknown platform accepted")
+	}
+
+	readOnly, _ := newTestSender(out)
+	readOnly.SetReadOnly(func() bool { return true })
+	if _, err := readOnly.Send(ctx, domain.PlatformTwitch); !errors.Is(err, ErrTestSendReadOnly) {
+		t.Fatalf("read-only err = %v", err)
+	}
+	if len(out.sends) != 0 {
+		t.Fatalf("sent %q despite the refusals", out.sends)
+	}
+}
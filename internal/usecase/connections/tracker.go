@@ -0,0 +1,201 @@
+// Package connections registra cuándo se conecta y desconecta el chat de cada
+// plataforma para mostrarlo en el panel.
+package connections
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// persistInterval limita las escrituras por plataforma cuando la conexión
+	// se cae y vuelve seguido.
+	persistInterval = time.Minute
+	flushInterval   = 10 * time.Second
+)
+
+// StatusDTO es el estado de conexión de una plataforma.
+type StatusDTO struct {
+	Platform           string `json:"platform"`
+	Connected          bool   `json:"connected"`
+	LastConnectedAt    string `json:"last_connected_at,omitempty"`
+	LastDisconnectedAt string `json:"last_disconnected_at,omitempty"`
+	// SessionUptimeSeconds es el tiempo conectado acumulado desde que arrancó la app.
+	SessionUptimeSeconds int64 `json:"session_uptime_seconds"`
+}
+
+type platformState struct {
+	record    domain.PlatformConnection
+	connected bool
+	since     time.Time
+	uptime    time.Duration
+
+	lastPersist time.Time
+	dirty       bool
+}
+
+type Tracker struct {
+	repo domain.PlatformConnectionRepository
+	now  func() time.Time
+
+	mu     sync.Mutex
+	states map[domain.Platform]*platformState
+}
+
+func NewTracker(repo domain.PlatformConnectionRepository) *Tracker {
+	return &Tracker{
+		repo:   repo,
+		now:    time.Now,
+		states: make(map[domain.Platform]*platformState),
+	}
+}
+
+// Load trae los últimos valores guardados de cada plataforma.
+func (t *Tracker) Load(ctx context.Context, platforms ...domain.Platform) error {
+	if t.repo == nil {
+		return nil
+	}
+	for _, platform := range platforms {
+		record, err := t.repo.GetPlatformConnection(ctx, platform)
+		if err != nil {
+			return err
+		}
+		t.mu.Lock()
+		state := t.stateLocked(platform)
+		if state.record.LastConnectedAt.IsZero() {
+			state.record.LastConnectedAt = record.LastConnectedAt
+		}
+		if state.record.LastDisconnectedAt.IsZero() {
+			state.record.LastDisconnectedAt = record.LastDisconnectedAt
+		}
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// Connected registra que el chat de la plataforma se conectó.
+func (t *Tracker) Connected(ctx context.Context, platform domain.Platform) {
+	t.mu.Lock()
+	state := t.stateLocked(platform)
+	if state.connected {
+		t.mu.Unlock()
+		return
+	}
+	now := t.now()
+	state.connected = true
+	state.since = now
+	state.record.LastConnectedAt = now.UTC()
+	state.dirty = true
+	t.mu.Unlock()
+
+	t.flush(ctx, false)
+}
+
+// Disconnected registra que el chat de la plataforma se desconectó.
+func (t *Tracker) Disconnected(ctx context.Context, platform domain.Platform) {
+	t.mu.Lock()
+	state := t.stateLocked(platform)
+	if !state.connected {
+		t.mu.Unlock()
+		return
+	}
+	now := t.now()
+	state.connected = false
+	state.uptime += now.Sub(state.since)
+	state.record.LastDisconnectedAt = now.UTC()
+	state.dirty = true
+	t.mu.Unlock()
+
+	t.flush(ctx, false)
+}
+
+// Statuses devuelve el estado de cada plataforma conocida.
+func (t *Tracker) Statuses() []StatusDTO {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	out := make([]StatusDTO, 0, len(t.states))
+	for platform, state := range t.states {
+		uptime := state.uptime
+		if state.connected {
+			uptime += now.Sub(state.since)
+		}
+		out = append(out, StatusDTO{
+			Platform:             string(platform),
+			Connected:            state.connected,
+			LastConnectedAt:      formatTime(state.record.LastConnectedAt),
+			LastDisconnectedAt:   formatTime(state.record.LastDisconnectedAt),
+			SessionUptimeSeconds: int64(uptime / time.Second),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Platform < out[j].Platform })
+	return out
+}
+
+// Run guarda los cambios que quedaron pendientes por el límite de escrituras.
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			t.Flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			t.flush(ctx, false)
+		}
+	}
+}
+
+// Flush guarda todos los cambios pendientes sin respetar el límite (al apagar).
+func (t *Tracker) Flush(ctx context.Context) {
+	t.flush(ctx, true)
+}
+
+func (t *Tracker) flush(ctx context.Context, force bool) {
+	if t.repo == nil {
+		return
+	}
+	t.mu.Lock()
+	now := t.now()
+	var pending []domain.PlatformConnection
+	for _, state := range t.states {
+		if !state.dirty {
+			continue
+		}
+		if !force && !state.lastPersist.IsZero() && now.Sub(state.lastPersist) < persistInterval {
+			continue
+		}
+		state.dirty = false
+		state.lastPersist = now
+		pending = append(pending, state.record)
+	}
+	t.mu.Unlock()
+
+	for _, record := range pending {
+		if err := t.repo.SetPlatformConnection(ctx, record); err != nil {
+			log.Printf("connections: no pude guardar %s: %v", record.Platform, err)
+		}
+	}
+}
+
+func (t *Tracker) stateLocked(platform domain.Platform) *platformState {
+	state, ok := t.states[platform]
+	if !ok {
+		state = &platformState{record: domain.PlatformConnection{Platform: platform}}
+		t.states[platform] = state
+	}
+	return state
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
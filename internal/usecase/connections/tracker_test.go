@@ -0,0 +1,200 @@
+package connections
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type memoryConnections struct {
+	mu      sync.Mutex
+	records map[domain.Platform]domain.PlatformConnection
+	writes  map[domain.Platform]int
+}
+
+func newMemoryConnections() *memoryConnections {
+	return &memoryConnections{
+		records: make(map[domain.Platform]domain.PlatformConnection),
+		writes:  make(map[domain.Platform]int),
+	}
+}
+
+func (m *memoryConnections) GetPlatformConnection(_ context.Context, platform domain.Platform) (domain.PlatformConnection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record, ok := m.records[platform]
+	if !ok {
+		record.Platform = platform
+	}
+	return record, nil
+}
+
+func (m *memoryConnections) SetPlatformConnection(_ context.Context, record domain.PlatformConnection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[record.Platform] = record
+	m.writes[record.Platform]++
+	return nil
+}
+
+func (m *memoryConnections) get(platform domain.Platform) (domain.PlatformConnection, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.records[platform], m.writes[platform]
+}
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTracker() (*Tracker, *memoryConnections, *fakeClock) {
+	repo := newMemoryConnections()
+	clock := &fakeClock{t: time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)}
+	tracker := NewTracker(repo)
+	tracker.now = clock.Now
+	return tracker, repo, clock
+}
+
+func TestTrackerThrottlesFlapping(t *testing.T) {
+	ctx := context.Background()
+	tracker, repo, clock := newTestTracker()
+
+	var lastConnect, lastDisconnect time.Time
+	for i := 0; i < 20; i++ {
+		lastConnect = clock.Now()
+		tracker.Connected(ctx, domain.PlatformTwitch)
+		clock.Advance(time.Second)
+		lastDisconnect = clock.Now()
+		tracker.Disconnected(ctx, domain.PlatformTwitch)
+		clock.Advance(time.Second)
+	}
+
+	if _, writes := repo.get(domain.PlatformTwitch); writes != 1 {
+		t.Fatalf("writes while flapping = %d, want 1", writes)
+	}
+
+	// el tick del Run antes del minuto no escribe
+	tracker.flush(ctx, false)
+	if _, writes := repo.get(domain.PlatformTwitch); writes != 1 {
+		t.Fatalf("writes before the interval = %d, want 1", writes)
+	}
+
+	clock.Advance(time.Minute)
+	tracker.flush(ctx, false)
+	record, writes := repo.get(domain.PlatformTwitch)
+	if writes != 2 {
+		t.Fatalf("writes after the interval = %d, want 2", writes)
+	}
+	if !record.LastConnectedAt.Equal(lastConnect) || !record.LastDisconnectedAt.Equal(lastDisconnect) {
+		t.Fatalf("persisted = %+v, want connect %v disconnect %v", record, lastConnect, lastDisconnect)
+	}
+
+	// sin cambios nuevos no se vuelve a escribir
+	clock.Advance(2 * time.Minute)
+	tracker.flush(ctx, false)
+	if _, writes := repo.get(domain.PlatformTwitch); writes != 2 {
+		t.Fatalf("clean state was written again (%d writes)", writes)
+	}
+}
+
+func TestTrackerThrottlesPerPlatform(t *testing.T) {
+	ctx := context.Background()
+	tracker, repo, _ := newTestTracker()
+
+	tracker.Connected(ctx, domain.PlatformTwitch)
+	tracker.Connected(ctx, domain.PlatformKick)
+	tracker.Disconnected(ctx, domain.PlatformTwitch)
+
+	if _, writes := repo.get(domain.PlatformTwitch); writes != 1 {
+		t.Fatalf("twitch writes = %d, want 1", writes)
+	}
+	if _, writes := repo.get(domain.PlatformKick); writes != 1 {
+		t.Fatalf("kick was throttled by twitch (%d writes)", writes)
+	}
+}
+
+func TestTrackerFlushIgnoresThrottle(t *testing.T) {
+	ctx := context.Background()
+	tracker, repo, clock := newTestTracker()
+
+	tracker.Connected(ctx, domain.PlatformKick)
+	clock.Advance(5 * time.Second)
+	tracker.Disconnected(ctx, domain.PlatformKick)
+
+	tracker.Flush(ctx)
+	record, writes := repo.get(domain.PlatformKick)
+	if writes != 2 || !record.LastDisconnectedAt.Equal(clock.Now()) {
+		t.Fatalf("after Flush: %+v (%d writes)", record, writes)
+	}
+}
+
+func TestTrackerSessionUptime(t *testing.T) {
+	ctx := context.Background()
+	tracker, _, clock := newTestTracker()
+
+	tracker.Connected(ctx, domain.PlatformTwitch)
+	clock.Advance(90 * time.Second)
+	tracker.Disconnected(ctx, domain.PlatformTwitch)
+	clock.Advance(time.Hour)
+	tracker.Connected(ctx, domain.PlatformTwitch)
+	// repetir el evento no reinicia la cuenta
+	clock.Advance(10 * time.Second)
+	tracker.Connected(ctx, domain.PlatformTwitch)
+	clock.Advance(20 * time.Second)
+
+	statuses := tracker.Statuses()
+	if len(statuses) != 1 {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+	got := statuses[0]
+	if !got.Connected || got.SessionUptimeSeconds != 120 {
+		t.Fatalf("status = %+v, want connected with 120s uptime", got)
+	}
+	if got.LastConnectedAt != "2026-10-17T21:01:30Z" || got.LastDisconnectedAt != "2026-10-17T20:01:30Z" {
+		t.Fatalf("timestamps = %q / %q", got.LastConnectedAt, got.LastDisconnectedAt)
+	}
+}
+
+func TestTrackerLoadThenConnect(t *testing.T) {
+	ctx := context.Background()
+	tracker, repo, clock := newTestTracker()
+	saved := time.Date(2026, 10, 14, 18, 0, 0, 0, time.UTC)
+	repo.records[domain.PlatformKick] = domain.PlatformConnection{
+		Platform:           domain.PlatformKick,
+		LastConnectedAt:    saved,
+		LastDisconnectedAt: saved.Add(time.Hour),
+	}
+
+	if err := tracker.Load(ctx, domain.PlatformKick, domain.PlatformTwitch); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	tracker.Connected(ctx, domain.PlatformKick)
+
+	statuses := tracker.Statuses()
+	if len(statuses) != 2 || statuses[0].Platform != "kick" || statuses[1].Platform != "twitch" {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+	kick := statuses[0]
+	if kick.LastConnectedAt != clock.Now().Format(time.RFC3339) {
+		t.Fatalf("last connect = %q, want the new connection", kick.LastConnectedAt)
+	}
+	if kick.LastDisconnectedAt != "2026-10-14T19:00:00Z" {
+		t.Fatalf("last disconnect = %q, want the stored value", kick.LastDisconnectedAt)
+	}
+	if statuses[1].Connected || statuses[1].LastConnectedAt != "" {
+		t.Fatalf("twitch = %+v, want never connected", statuses[1])
+	}
+
+	// lo guardado conserva la desconexión anterior
+	record, _ := repo.get(domain.PlatformKick)
+	if !record.LastDisconnectedAt.Equal(saved.Add(time.Hour)) || !record.LastConnectedAt.Equal(clock.Now()) {
+		t.Fatalf("persisted = %+v", record)
+	}
+}
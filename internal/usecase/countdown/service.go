@@ -0,0 +1,445 @@
+// Package countdown maneja las cuentas regresivas de los canales («empezamos
+// pronto»). Cada segundo publica un Tick para los overlays y solo anuncia en
+// el chat los hitos: la mitad, el último minuto y el final. La hora de fin se
+// guarda, así que un reinicio del bot retoma la cuenta donde iba.
+package countdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	MinDuration  = 5 * time.Second
+	MaxDuration  = 3 * time.Hour
+	DefaultLabel = "Empezamos pronto"
+
+	tickInterval = time.Second
+	maxLabelLen  = 100
+)
+
+const (
+	StateRunning   = "running"
+	StateFinished  = "finished"
+	StateCancelled = "cancelled"
+)
+
+var (
+	ErrInvalidDuration = errors.New("duración inválida")
+	ErrNoChannel       = errors.New("no hay canal para la cuenta regresiva")
+	ErrNotFound        = errors.New("no hay una cuenta regresiva activa")
+)
+
+// Tick es lo que se publica como countdown:tick.
+type Tick struct {
+	Platform  string    `json:"platform"`
+	ChannelID string    `json:"channel_id"`
+	Label     string    `json:"label"`
+	EndsAt    time.Time `json:"ends_at"`
+	Remaining int       `json:"remaining"`
+	Total     int       `json:"total"`
+	State     string    `json:"state"`
+}
+
+type key struct {
+	platform  domain.Platform
+	channelID string
+}
+
+type milestone struct {
+	at   time.Time
+	text string
+}
+
+type active struct {
+	countdown domain.Countdown
+	// milestones son los anuncios pendientes, en orden.
+	milestones []milestone
+}
+
+type Service struct {
+	repo domain.CountdownRepository
+	now  func() time.Time
+
+	mu      sync.Mutex
+	active  map[key]*active
+	out     domain.OutgoingMessagePort
+	channel func(domain.Platform) string
+	onTick  func(Tick)
+}
+
+func NewService(repo domain.CountdownRepository) *Service {
+	return &Service{
+		repo:   repo,
+		now:    time.Now,
+		active: make(map[key]*active),
+	}
+}
+
+// SetSender indica por dónde se anuncian los hitos en el chat.
+func (s *Service) SetSender(out domain.OutgoingMessagePort) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out = out
+}
+
+// SetChannelResolver da el canal por defecto de una plataforma para las
+// cuentas que se inician desde la API sin channel_id.
+func (s *Service) SetChannelResolver(fn func(domain.Platform) string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channel = fn
+}
+
+// SetTickHandler recibe un Tick por segundo de cada cuenta activa, y uno
+// final al terminar o cancelarse.
+func (s *Service) SetTickHandler(fn func(Tick)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onTick = fn
+}
+
+// Load retoma las cuentas guardadas que todavía no terminaron. Los hitos que
+// pasaron con el bot apagado no se anuncian.
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	saved, err := s.repo.GetCountdowns(ctx)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	loaded := make(map[key]*active, len(saved))
+	for _, countdown := range saved {
+		if !countdown.EndsAt.After(now) {
+			continue
+		}
+		entry := newActive(countdown)
+		entry.skipUntil(now)
+		loaded[key{platform: countdown.Platform, channelID: countdown.ChannelID}] = entry
+		log.Printf("countdown: retomando %q en %s/%s (%s)", countdown.Label, countdown.Platform, countdown.ChannelID, FormatRemaining(countdown.EndsAt.Sub(now)))
+	}
+
+	s.mu.Lock()
+	s.active = loaded
+	s.mu.Unlock()
+
+	if len(loaded) != len(saved) {
+		s.persist(ctx)
+	}
+	return nil
+}
+
+// Start inicia (o reemplaza) la cuenta regresiva del canal y la anuncia.
+func (s *Service) Start(ctx context.Context, platform domain.Platform, channelID string, duration time.Duration, label string) (Tick, error) {
+	if duration < MinDuration || duration > MaxDuration {
+		return Tick{}, ErrInvalidDuration
+	}
+	label = clipLabel(label)
+
+	s.mu.Lock()
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" && s.channel != nil {
+		channelID = s.channel(platform)
+	}
+	if channelID == "" {
+		s.mu.Unlock()
+		return Tick{}, ErrNoChannel
+	}
+	now := s.now()
+	countdown := domain.Countdown{
+		Platform:  platform,
+		ChannelID: channelID,
+		Label:     label,
+		StartedAt: now,
+		EndsAt:    now.Add(duration),
+	}
+	s.active[key{platform: platform, channelID: channelID}] = newActive(countdown)
+	out := s.out
+	onTick := s.onTick
+	s.mu.Unlock()
+
+	s.persist(ctx)
+	tick := newTick(countdown, now, StateRunning)
+	if onTick != nil {
+		onTick(tick)
+	}
+	s.announce(ctx, out, countdown, fmt.Sprintf("⏳ %s: faltan %s.", label, FormatRemaining(duration)))
+	return tick, nil
+}
+
+// Cancel detiene la cuenta regresiva del canal.
+func (s *Service) Cancel(ctx context.Context, platform domain.Platform, channelID string) error {
+	s.mu.Lock()
+	channelID = strings.TrimSpace(channelID)
+	if channelID == "" && s.channel != nil {
+		channelID = s.channel(platform)
+	}
+	k := key{platform: platform, channelID: channelID}
+	entry, ok := s.active[k]
+	if !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	delete(s.active, k)
+	onTick := s.onTick
+	s.mu.Unlock()
+
+	s.persist(ctx)
+	if onTick != nil {
+		onTick(newTick(entry.countdown, s.now(), StateCancelled))
+	}
+	return nil
+}
+
+// Status devuelve las cuentas activas ordenadas por hora de fin.
+func (s *Service) Status() []Tick {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	out := make([]Tick, 0, len(s.active))
+	for _, entry := range s.active {
+		out = append(out, newTick(entry.countdown, now, StateRunning))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EndsAt.Before(out[j].EndsAt) })
+	return out
+}
+
+// Find devuelve la cuenta activa del canal.
+func (s *Service) Find(platform domain.Platform, channelID string) (Tick, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.active[key{platform: platform, channelID: channelID}]
+	if !ok {
+		return Tick{}, false
+	}
+	return newTick(entry.countdown, s.now(), StateRunning), true
+}
+
+// Run publica los ticks y anuncia los hitos hasta que ctx termine.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+type announcement struct {
+	countdown domain.Countdown
+	text      string
+}
+
+func (s *Service) tick(ctx context.Context) {
+	s.mu.Lock()
+	if len(s.active) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	now := s.now()
+	var (
+		ticks    []Tick
+		messages []announcement
+		finished bool
+	)
+	for k, entry := range s.active {
+		// si se atrasó (p.ej. la PC estuvo suspendida) solo se anuncia el
+		// último hito vencido
+		due := ""
+		for len(entry.milestones) > 0 && !entry.milestones[0].at.After(now) {
+			due = entry.milestones[0].text
+			entry.milestones = entry.milestones[1:]
+		}
+		if due != "" {
+			messages = append(messages, announcement{countdown: entry.countdown, text: due})
+		}
+		state := StateRunning
+		if !entry.countdown.EndsAt.After(now) {
+			state = StateFinished
+			delete(s.active, k)
+			finished = true
+		}
+		ticks = append(ticks, newTick(entry.countdown, now, state))
+	}
+	out := s.out
+	onTick := s.onTick
+	s.mu.Unlock()
+
+	if finished {
+		s.persist(ctx)
+	}
+	if onTick != nil {
+		for _, tick := range ticks {
+			onTick(tick)
+		}
+	}
+	for _, msg := range messages {
+		s.announce(ctx, out, msg.countdown, msg.text)
+	}
+}
+
+func (s *Service) announce(ctx context.Context, out domain.OutgoingMessagePort, countdown domain.Countdown, text string) {
+	if out == nil {
+		return
+	}
+	if err := out.SendMessage(ctx, countdown.Platform, countdown.ChannelID, text); err != nil {
+		log.Printf("countdown: no pude anunciar en %s/%s: %v", countdown.Platform, countdown.ChannelID, err)
+	}
+}
+
+func (s *Service) persist(ctx context.Context) {
+	if s.repo == nil {
+		return
+	}
+	s.mu.Lock()
+	countdowns := make([]domain.Countdown, 0, len(s.active))
+	for _, entry := range s.active {
+		countdowns = append(countdowns, entry.countdown)
+	}
+	s.mu.Unlock()
+	sort.Slice(countdowns, func(i, j int) bool { return countdowns[i].EndsAt.Before(countdowns[j].EndsAt) })
+
+	if err := s.repo.SetCountdowns(ctx, countdowns); err != nil {
+		log.Printf("countdown: no pude guardar las cuentas regresivas: %v", err)
+	}
+}
+
+// newActive arma los hitos: la mitad (si dura 2 minutos o más), el último
+// minuto y el final.
+func newActive(countdown domain.Countdown) *active {
+	total := countdown.EndsAt.Sub(countdown.StartedAt)
+	label := countdown.Label
+	var milestones []milestone
+	if total >= 2*time.Minute && total/2 != time.Minute {
+		milestones = append(milestones, milestone{
+			at:   countdown.StartedAt.Add(total / 2),
+			text: fmt.Sprintf("⏳ %s: faltan %s.", label, FormatRemaining(total-total/2)),
+		})
+	}
+	if total > time.Minute {
+		milestones = append(milestones, milestone{
+			at:   countdown.EndsAt.Add(-time.Minute),
+			text: fmt.Sprintf("⏳ %s: ¡falta 1 minuto!", label),
+		})
+	}
+	milestones = append(milestones, milestone{
+		at:   countdown.EndsAt,
+		text: fmt.Sprintf("🚀 %s: ¡ya!", label),
+	})
+	return &active{countdown: countdown, milestones: milestones}
+}
+
+// skipUntil descarta los hitos anteriores a now sin anunciarlos.
+func (a *active) skipUntil(now time.Time) {
+	for len(a.milestones) > 0 && !a.milestones[0].at.After(now) {
+		a.milestones = a.milestones[1:]
+	}
+}
+
+func newTick(countdown domain.Countdown, now time.Time, state string) Tick {
+	remaining := countdown.EndsAt.Sub(now)
+	if remaining < 0 || state != StateRunning {
+		remaining = 0
+	}
+	return Tick{
+		Platform:  string(countdown.Platform),
+		ChannelID: countdown.ChannelID,
+		Label:     countdown.Label,
+		EndsAt:    countdown.EndsAt,
+		Remaining: int((remaining + time.Second - 1) / time.Second),
+		Total:     int(countdown.EndsAt.Sub(countdown.StartedAt) / time.Second),
+		State:     state,
+	}
+}
+
+// ParseDuration acepta «5m», «1m30s», «90s», «mm:ss» o un número solo (en
+// minutos).
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0, ErrInvalidDuration
+	}
+	if minutes, err := strconv.Atoi(value); err == nil {
+		return checkDuration(time.Duration(minutes) * time.Minute)
+	}
+	if mins, secs, ok := strings.Cut(value, ":"); ok {
+		m, errM := strconv.Atoi(mins)
+		sec, errS := strconv.Atoi(secs)
+		if errM != nil || errS != nil || m < 0 || sec < 0 || sec >= 60 {
+			return 0, ErrInvalidDuration
+		}
+		return checkDuration(time.Duration(m)*time.Minute + time.Duration(sec)*time.Second)
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, ErrInvalidDuration
+	}
+	return checkDuration(duration)
+}
+
+func checkDuration(duration time.Duration) (time.Duration, error) {
+	if duration < MinDuration || duration > MaxDuration {
+		return 0, ErrInvalidDuration
+	}
+	return duration, nil
+}
+
+func clipLabel(label string) string {
+	label = strings.Join(strings.Fields(label), " ")
+	if label == "" {
+		return DefaultLabel
+	}
+	runes := []rune(label)
+	if len(runes) > maxLabelLen {
+		label = string(runes[:maxLabelLen])
+	}
+	return label
+}
+
+// FormatRemaining escribe d en palabras («2 minutos y 30 segundos»).
+func FormatRemaining(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	var parts []string
+	if hours > 0 {
+		parts = append(parts, plural(hours, "hora", "horas"))
+	}
+	if minutes > 0 {
+		parts = append(parts, plural(minutes, "minuto", "minutos"))
+	}
+	if seconds > 0 || len(parts) == 0 {
+		parts = append(parts, plural(seconds, "segundo", "segundos"))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " y " + parts[len(parts)-1]
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return strconv.Itoa(n) + " " + pluralForm
+}
@@ -0,0 +1,264 @@
+package countdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.t }
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// memoryRepo hace de la tabla settings.
+type memoryRepo struct {
+	mu    sync.Mutex
+	saved []domain.Countdown
+}
+
+func (r *memoryRepo) GetCountdowns(context.Context) ([]domain.Countdown, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Countdown(nil), r.saved...), nil
+}
+
+func (r *memoryRepo) SetCountdowns(_ context.Context, countdowns []domain.Countdown) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved = append([]domain.Countdown(nil), countdowns...)
+	return nil
+}
+
+// chatOut guarda lo que se anunció en el chat.
+type chatOut struct{ texts []string }
+
+func (o *chatOut) SendMessage(_ context.Context, _ domain.Platform, _ string, text string) error {
+	o.texts = append(o.texts, text)
+	return nil
+}
+
+type harness struct {
+	svc   *Service
+	clock *fakeClock
+	out   *chatOut
+	ticks []Tick
+}
+
+func newHarness(t *testing.T, repo *memoryRepo, clock *fakeClock) *harness {
+	t.Helper()
+	h := &harness{svc: NewService(repo), clock: clock, out: &chatOut{}}
+	h.svc.now = clock.Now
+	h.svc.SetSender(h.out)
+	h.svc.SetTickHandler(func(tick Tick) { h.ticks = append(h.ticks, tick) })
+	if err := h.svc.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return h
+}
+
+// run avanza el reloj de a un segundo como Run.
+func (h *harness) run(d time.Duration) {
+	for elapsed := time.Duration(0); elapsed < d; elapsed += tickInterval {
+		h.clock.Advance(tickInterval)
+		h.svc.tick(context.Background())
+	}
+}
+
+func start() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)}
+}
+
+func TestMilestoneSchedule(t *testing.T) {
+	h := newHarness(t, &memoryRepo{}, start())
+	if _, err := h.svc.Start(context.Background(), domain.PlatformTwitch, "zero", 5*time.Minute, ""); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	h.run(5 * time.Minute)
+
+	want := []string{
+		"⏳ Empezamos pronto: faltan 5 minutos.",
+		"⏳ Empezamos pronto: faltan 2 minutos y 30 segundos.",
+		"⏳ Empezamos pronto: ¡falta 1 minuto!",
+		"🚀 Empezamos pronto: ¡ya!",
+	}
+	if len(h.out.texts) != len(want) {
+		t.Fatalf("anuncios = %q, esperaba %q", h.out.texts, want)
+	}
+	for i := range want {
+		if h.out.texts[i] != want[i] {
+			t.Fatalf("anuncios = %q, esperaba %q", h.out.texts, want)
+		}
+	}
+
+	// un tick al iniciar y uno por segundo, el último con el estado final
+	if len(h.ticks) != 301 {
+		t.Fatalf("hubo %d ticks, esperaba 301", len(h.ticks))
+	}
+	if tick := h.ticks[150]; tick.Remaining != 150 || tick.Total != 300 || tick.State != StateRunning {
+		t.Fatalf("tick de la mitad = %+v", tick)
+	}
+	if last := h.ticks[300]; last.State != StateFinished || last.Remaining != 0 {
+		t.Fatalf("último tick = %+v", last)
+	}
+	if len(h.svc.Status()) != 0 {
+		t.Fatal("la cuenta sigue activa después de terminar")
+	}
+}
+
+func TestShortCountdownMilestones(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     int
+	}{
+		// inicio y final
+		{30 * time.Second, 2},
+		// inicio, último minuto y final: la mitad caería en el último minuto
+		{90 * time.Second, 3},
+		// la mitad coincide con el último minuto: se anuncia una sola vez
+		{2 * time.Minute, 3},
+	}
+	for _, tt := range tests {
+		h := newHarness(t, &memoryRepo{}, start())
+		if _, err := h.svc.Start(context.Background(), domain.PlatformTwitch, "zero", tt.duration, "Intro"); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		h.run(tt.duration)
+		if len(h.out.texts) != tt.want {
+			t.Errorf("%v: anuncios = %q, esperaba %d", tt.duration, h.out.texts, tt.want)
+		}
+	}
+}
+
+func TestCountdownResumesAfterRestart(t *testing.T) {
+	repo := &memoryRepo{}
+	clock := start()
+	h := newHarness(t, repo, clock)
+	if _, err := h.svc.Start(context.Background(), domain.PlatformKick, "zero", 10*time.Minute, "Charla"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	h.run(time.Minute)
+
+	// el bot se cae 5 minutos: la mitad pasa con el bot apagado
+	clock.Advance(5 * time.Minute)
+	restarted := newHarness(t, repo, clock)
+	status := restarted.svc.Status()
+	if len(status) != 1 || status[0].Remaining != 240 || status[0].Label != "Charla" {
+		t.Fatalf("Status tras reiniciar = %+v", status)
+	}
+
+	restarted.run(4 * time.Minute)
+	want := []string{"⏳ Charla: ¡falta 1 minuto!", "🚀 Charla: ¡ya!"}
+	if len(restarted.out.texts) != len(want) || restarted.out.texts[0] != want[0] || restarted.out.texts[1] != want[1] {
+		t.Fatalf("anuncios tras reiniciar = %q, esperaba %q", restarted.out.texts, want)
+	}
+	if len(repo.saved) != 0 {
+		t.Fatalf("la cuenta terminada sigue guardada: %+v", repo.saved)
+	}
+}
+
+func TestLoadDropsFinishedCountdowns(t *testing.T) {
+	clock := start()
+	repo := &memoryRepo{saved: []domain.Countdown{{
+		Platform:  domain.PlatformTwitch,
+		ChannelID: "zero",
+		Label:     "Vieja",
+		StartedAt: clock.Now().Add(-time.Hour),
+		EndsAt:    clock.Now().Add(-time.Minute),
+	}}}
+
+	h := newHarness(t, repo, clock)
+	if len(h.svc.Status()) != 0 || len(repo.saved) != 0 {
+		t.Fatal("se retomó una cuenta que terminó con el bot apagado")
+	}
+	h.run(time.Second)
+	if len(h.out.texts) != 0 {
+		t.Fatalf("se anunció una cuenta vencida: %q", h.out.texts)
+	}
+}
+
+func TestCancelAndReplace(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryRepo{}
+	h := newHarness(t, repo, start())
+
+	if _, err := h.svc.Start(ctx, domain.PlatformTwitch, "zero", time.Minute, "Una"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	// una cuenta por canal: la nueva reemplaza a la anterior
+	if _, err := h.svc.Start(ctx, domain.PlatformTwitch, "zero", 2*time.Minute, "Otra"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if status := h.svc.Status(); len(status) != 1 || status[0].Label != "Otra" {
+		t.Fatalf("Status = %+v", status)
+	}
+
+	if err := h.svc.Cancel(ctx, domain.PlatformTwitch, "zero"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if last := h.ticks[len(h.ticks)-1]; last.State != StateCancelled {
+		t.Fatalf("último tick = %+v", last)
+	}
+	if err := h.svc.Cancel(ctx, domain.PlatformTwitch, "zero"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Cancel repetido = %v", err)
+	}
+	if len(repo.saved) != 0 {
+		t.Fatal("la cuenta cancelada sigue guardada")
+	}
+}
+
+func TestStartValidates(t *testing.T) {
+	ctx := context.Background()
+	h := newHarness(t, &memoryRepo{}, start())
+	if _, err := h.svc.Start(ctx, domain.PlatformTwitch, "zero", time.Second, ""); !errors.Is(err, ErrInvalidDuration) {
+		t.Fatalf("duración corta = %v", err)
+	}
+	if _, err := h.svc.Start(ctx, domain.PlatformTwitch, " ", time.Minute, ""); !errors.Is(err, ErrNoChannel) {
+		t.Fatalf("sin canal = %v", err)
+	}
+	h.svc.SetChannelResolver(func(domain.Platform) string { return "zero" })
+	tick, err := h.svc.Start(ctx, domain.PlatformTwitch, "", time.Minute, "")
+	if err != nil || tick.ChannelID != "zero" {
+		t.Fatalf("Start con canal por defecto = %+v, %v", tick, err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"5":     5 * time.Minute,
+		"5m":    5 * time.Minute,
+		"1m30s": 90 * time.Second,
+		"01:30": 90 * time.Second,
+		" 90S ": 90 * time.Second,
+	}
+	for in, want := range tests {
+		if got, err := ParseDuration(in); err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; esperaba %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "1:75", "-5", "2s", "4h", "0:03"} {
+		if _, err := ParseDuration(in); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("ParseDuration(%q) = %v, esperaba ErrInvalidDuration", in, err)
+		}
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                     "0 segundos",
+		time.Second:                           "1 segundo",
+		150 * time.Second:                     "2 minutos y 30 segundos",
+		time.Hour + time.Minute + time.Second: "1 hora, 1 minuto y 1 segundo",
+		2*time.Hour + 400*time.Millisecond:    "2 horas",
+	}
+	for in, want := range tests {
+		if got := FormatRemaining(in); got != want {
+			t.Errorf("FormatRemaining(%v) = %q, esperaba %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package counters lleva los contadores manuales del canal (!count muertes +).
+// Se guardan en cada cambio: son pocos y cambian a mano.
+package counters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// MaxValue acota el valor (en positivo y negativo) para que nadie lo
+// desborde a fuerza de sumas.
+const MaxValue = 1_000_000_000
+
+var (
+	ErrInvalidName     = errors.New("nombre de contador inválido (letras, números, - y _, hasta 32)")
+	ErrCounterNotFound = errors.New("contador no encontrado")
+	ErrOutOfRange      = fmt.Errorf("el valor tiene que estar entre -%d y %d", MaxValue, MaxValue)
+)
+
+var namePattern = regexp.MustCompile(`^[\p{L}0-9_-]{1,32}$`)
+
+type Service struct {
+	repo domain.CounterRepository
+	now  func() time.Time
+
+	mu       sync.Mutex
+	counters map[string]*domain.Counter
+	onChange func(domain.Counter)
+}
+
+func NewService(repo domain.CounterRepository) *Service {
+	return &Service{
+		repo:     repo,
+		now:      time.Now,
+		counters: make(map[string]*domain.Counter),
+	}
+}
+
+// Load lee los contadores guardados.
+func (s *Service) Load(ctx context.Context) error {
+	if s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.ListCounters(ctx)
+	if err != nil {
+		return err
+	}
+	counters := make(map[string]*domain.Counter, len(stored))
+	for _, counter := range stored {
+		if counter != nil {
+			counters[counter.Name] = counter
+		}
+	}
+	s.mu.Lock()
+	s.counters = counters
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+// SetChangeHandler se llama con el contador después de cada cambio. Al
+// borrarlo llega con Value 0 y UpdatedAt vacío.
+func (s *Service) SetChangeHandler(fn func(domain.Counter)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// NormalizeName pasa el nombre a minúsculas y lo valida.
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !namePattern.MatchString(name) {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+func (s *Service) List() []domain.Counter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]domain.Counter, 0, len(s.counters))
+	for _, counter := range s.counters {
+		out = append(out, *counter)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *Service) Get(name string) (domain.Counter, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return domain.Counter{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counter, ok := s.counters[name]
+	if !ok {
+		return domain.Counter{}, ErrCounterNotFound
+	}
+	return *counter, nil
+}
+
+// Add suma delta (negativo para restar). Crea el contador si no existe.
+func (s *Service) Add(ctx context.Context, name string, delta int) (domain.Counter, error) {
+	if delta > 2*MaxValue || delta < -2*MaxValue {
+		return domain.Counter{}, ErrOutOfRange
+	}
+	return s.apply(ctx, name, true, func(current int) int { return current + delta })
+}
+
+// Set fija el valor. Crea el contador si no existe.
+func (s *Service) Set(ctx context.Context, name string, value int) (domain.Counter, error) {
+	return s.apply(ctx, name, true, func(int) int { return value })
+}
+
+// Reset vuelve a 0 un contador existente.
+func (s *Service) Reset(ctx context.Context, name string) (domain.Counter, error) {
+	return s.apply(ctx, name, false, func(int) int { return 0 })
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if _, ok := s.counters[name]; !ok {
+		s.mu.Unlock()
+		return ErrCounterNotFound
+	}
+	if s.repo != nil {
+		if _, err := s.repo.DeleteCounter(ctx, name); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	delete(s.counters, name)
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(domain.Counter{Name: name})
+	}
+	return nil
+}
+
+func (s *Service) apply(ctx context.Context, name string, create bool, next func(current int) int) (domain.Counter, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return domain.Counter{}, err
+	}
+	s.mu.Lock()
+	current, ok := s.counters[name]
+	if !ok && !create {
+		s.mu.Unlock()
+		return domain.Counter{}, ErrCounterNotFound
+	}
+	value := 0
+	if ok {
+		value = current.Value
+	}
+	value = next(value)
+	if value > MaxValue || value < -MaxValue {
+		s.mu.Unlock()
+		return domain.Counter{}, ErrOutOfRange
+	}
+	updated := &domain.Counter{Name: name, Value: value, UpdatedAt: s.now().UTC()}
+	if s.repo != nil {
+		if err := s.repo.SaveCounter(ctx, updated); err != nil {
+			s.mu.Unlock()
+			return domain.Counter{}, err
+		}
+	}
+	s.counters[name] = updated
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(*updated)
+	}
+	return *updated, nil
+}
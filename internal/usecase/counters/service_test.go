@@ -0,0 +1,148 @@
+package counters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryRepo hace de la tabla counters.
+type memoryRepo struct {
+	mu       sync.Mutex
+	counters map[string]domain.Counter
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{counters: make(map[string]domain.Counter)}
+}
+
+func (r *memoryRepo) ListCounters(context.Context) ([]*domain.Counter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*domain.Counter, 0, len(r.counters))
+	for _, counter := range r.counters {
+		copied := counter
+		out = append(out, &copied)
+	}
+	return out, nil
+}
+
+func (r *memoryRepo) SaveCounter(_ context.Context, counter *domain.Counter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[counter.Name] = *counter
+	return nil
+}
+
+func (r *memoryRepo) DeleteCounter(_ context.Context, name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.counters[name]
+	delete(r.counters, name)
+	return ok, nil
+}
+
+func newTestService(repo *memoryRepo) *Service {
+	svc := NewService(repo)
+	svc.now = func() time.Time { return time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func TestCounterArithmetic(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(newMemoryRepo())
+
+	steps := []struct {
+		name string
+		op   func() (domain.Counter, error)
+		want int
+	}{
+		{"+1 crea el contador", func() (domain.Counter, error) { return svc.Add(ctx, "Muertes", 1) }, 1},
+		{"+5", func() (domain.Counter, error) { return svc.Add(ctx, "muertes", 5) }, 6},
+		{"-2", func() (domain.Counter, error) { return svc.Add(ctx, " MUERTES ", -2) }, 4},
+		{"set", func() (domain.Counter, error) { return svc.Set(ctx, "muertes", 40) }, 40},
+		{"bajo cero", func() (domain.Counter, error) { return svc.Add(ctx, "muertes", -50) }, -10},
+		{"reset", func() (domain.Counter, error) { return svc.Reset(ctx, "muertes") }, 0},
+	}
+	for _, step := range steps {
+		counter, err := step.op()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if counter.Name != "muertes" || counter.Value != step.want {
+			t.Fatalf("%s = %+v, esperaba %d", step.name, counter, step.want)
+		}
+	}
+}
+
+func TestCounterErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(newMemoryRepo())
+
+	if _, err := svc.Reset(ctx, "muertes"); !errors.Is(err, ErrCounterNotFound) {
+		t.Fatalf("Reset sin contador = %v", err)
+	}
+	if _, err := svc.Get("muertes"); !errors.Is(err, ErrCounterNotFound) {
+		t.Fatalf("Get sin contador = %v", err)
+	}
+	for _, name := range []string{"", "dos palabras", "emoji😀", "un_nombre_que_pasa_de_los_32_caracteres"} {
+		if _, err := svc.Add(ctx, name, 1); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Add(%q) = %v, esperaba ErrInvalidName", name, err)
+		}
+	}
+
+	if _, err := svc.Set(ctx, "muertes", MaxValue); err != nil {
+		t.Fatalf("Set al máximo: %v", err)
+	}
+	if _, err := svc.Add(ctx, "muertes", 1); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("pasarse del máximo = %v", err)
+	}
+	if _, err := svc.Set(ctx, "muertes", -MaxValue-1); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("pasarse del mínimo = %v", err)
+	}
+	if counter, _ := svc.Get("muertes"); counter.Value != MaxValue {
+		t.Fatalf("un cambio rechazado modificó el valor: %+v", counter)
+	}
+}
+
+func TestCountersPersist(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo()
+	svc := newTestService(repo)
+	var changes []domain.Counter
+	svc.SetChangeHandler(func(c domain.Counter) { changes = append(changes, c) })
+
+	if _, err := svc.Add(ctx, "muertes", 3); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := svc.Set(ctx, "victorias", 1); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	restarted := newTestService(repo)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	list := restarted.List()
+	if len(list) != 2 || list[0].Name != "muertes" || list[0].Value != 3 || list[1].Name != "victorias" {
+		t.Fatalf("tras reiniciar = %+v", list)
+	}
+
+	if err := svc.Delete(ctx, "muertes"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := repo.counters["muertes"]; ok {
+		t.Fatal("el contador borrado sigue guardado")
+	}
+	if err := svc.Delete(ctx, "muertes"); !errors.Is(err, ErrCounterNotFound) {
+		t.Fatalf("Delete repetido = %v", err)
+	}
+
+	if len(changes) != 3 || changes[2].Name != "muertes" || !changes[2].UpdatedAt.IsZero() {
+		t.Fatalf("changes = %+v", changes)
+	}
+}
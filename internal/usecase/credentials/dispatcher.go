@@ -0,0 +1,180 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// DefaultHookQueueSize es cuántos avisos pendientes acepta el dispatcher ya
+// arrancado antes de que Enqueue espere.
+const DefaultHookQueueSize = 64
+
+// HookDispatcher ejecuta un CredentialHook fuera de la goroutine de quien
+// avisa: reiniciar un adaptador puede tardar segundos y no debe frenar el
+// callback OAuth ni el refresher. Los avisos de una misma credencial
+// (plataforma/rol) se procesan en orden; los de credenciales distintas pueden
+// intercalarse.
+type HookDispatcher struct {
+	hook CredentialHook
+	size int
+
+	mu      sync.Mutex
+	space   *sync.Cond
+	ctx     context.Context
+	started bool
+	closed  bool
+	queues  map[string][]*domain.Credential
+	active  map[string]bool
+	// pending cuenta los avisos encolados más los que se están ejecutando.
+	pending int
+	waiters []chan struct{}
+}
+
+func NewHookDispatcher(hook CredentialHook, queueSize int) *HookDispatcher {
+	if queueSize <= 0 {
+		queueSize = DefaultHookQueueSize
+	}
+	d := &HookDispatcher{
+		hook:   hook,
+		size:   queueSize,
+		queues: make(map[string][]*domain.Credential),
+		active: make(map[string]bool),
+	}
+	d.space = sync.NewCond(&d.mu)
+	return d
+}
+
+// Start empieza a ejecutar el hook con ctx. Lo encolado antes de Start queda
+// esperando, así el hook no corre mientras el runtime se arma.
+func (d *HookDispatcher) Start(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.started {
+		return
+	}
+	d.started = true
+	d.ctx = ctx
+	for key := range d.queues {
+		d.runLocked(key)
+	}
+}
+
+// Enqueue encola el aviso y vuelve enseguida (espera solo si el dispatcher ya
+// arrancó y la cola está llena; antes de Start nadie la vacía, así que la
+// cola crece). Tiene la firma de CredentialHook para reemplazarlo
+// directamente; ctx no se usa: el hook corre con el contexto de Start.
+func (d *HookDispatcher) Enqueue(_ context.Context, cred *domain.Credential) {
+	if d == nil || cred == nil {
+		return
+	}
+	copyCred := *cred
+	key := hookKey(&copyCred)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for d.started && !d.closed && d.pending >= d.size {
+		d.space.Wait()
+	}
+	if d.closed {
+		log.Printf("credential hooks: dispatcher cerrado, se descarta el aviso de %s", key)
+		return
+	}
+	d.queues[key] = append(d.queues[key], &copyCred)
+	d.pending++
+	if d.started {
+		d.runLocked(key)
+	}
+}
+
+// Flush espera a que no quede ningún aviso pendiente o a que venza ctx. Si
+// el dispatcher no arrancó, lo encolado no se procesa y Flush espera a ctx.
+func (d *HookDispatcher) Flush(ctx context.Context) error {
+	d.mu.Lock()
+	if d.pending == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+	done := make(chan struct{})
+	d.waiters = append(d.waiters, done)
+	d.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close deja de aceptar avisos y espera (hasta que venza ctx) a que terminen
+// los pendientes.
+func (d *HookDispatcher) Close(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	d.closed = true
+	d.space.Broadcast()
+	d.mu.Unlock()
+	return d.Flush(ctx)
+}
+
+// runLocked lanza el worker de key si tiene avisos y no hay otro corriendo.
+func (d *HookDispatcher) runLocked(key string) {
+	if d.active[key] || len(d.queues[key]) == 0 {
+		return
+	}
+	d.active[key] = true
+	go d.work(key)
+}
+
+func (d *HookDispatcher) work(key string) {
+	for {
+		d.mu.Lock()
+		queue := d.queues[key]
+		if len(queue) == 0 {
+			delete(d.queues, key)
+			delete(d.active, key)
+			d.mu.Unlock()
+			return
+		}
+		cred := queue[0]
+		d.queues[key] = queue[1:]
+		ctx := d.ctx
+		d.mu.Unlock()
+
+		d.call(ctx, cred)
+
+		d.mu.Lock()
+		d.pending--
+		d.space.Signal()
+		if d.pending == 0 {
+			for _, done := range d.waiters {
+				close(done)
+			}
+			d.waiters = nil
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *HookDispatcher) call(ctx context.Context, cred *domain.Credential) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Printf("credential hooks: panic con %s: %v\n%s", hookKey(cred), rec, debug.Stack())
+		}
+	}()
+	if d.hook != nil {
+		d.hook(ctx, cred)
+	}
+}
+
+func hookKey(cred *domain.Credential) string {
+	return fmt.Sprintf("%s/%s", cred.Platform, strings.ToLower(strings.TrimSpace(cred.Role)))
+}
@@ -0,0 +1,184 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// recordingHook anota cada aviso como «plataforma/rol:token».
+type recordingHook struct {
+	mu    sync.Mutex
+	calls []string
+	block chan struct{}
+}
+
+func (h *recordingHook) hook(_ context.Context, cred *domain.Credential) {
+	if h.block != nil {
+		<-h.block
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, hookKey(cred)+":"+cred.AccessToken)
+}
+
+func (h *recordingHook) recorded() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.calls...)
+}
+
+func cred(platform domain.Platform, role string, token string) *domain.Credential {
+	return &domain.Credential{Platform: platform, Role: role, AccessToken: token}
+}
+
+func flush(t *testing.T, d *HookDispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := d.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func TestHookDispatcherKeepsOrderPerCredential(t *testing.T) {
+	rec := &recordingHook{}
+	d := NewHookDispatcher(rec.hook, 0)
+	d.Start(context.Background())
+
+	const n = 50
+	for i := range n {
+		d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "bot", fmt.Sprint(i)))
+		d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "Streamer ", fmt.Sprint(i)))
+		d.Enqueue(context.Background(), cred(domain.PlatformKick, "bot", fmt.Sprint(i)))
+	}
+	flush(t, d)
+
+	calls := rec.recorded()
+	if len(calls) != 3*n {
+		t.Fatalf("se ejecutaron %d avisos, esperaba %d", len(calls), 3*n)
+	}
+	next := map[string]int{}
+	for _, call := range calls {
+		sep := strings.LastIndex(call, ":")
+		key := call[:sep]
+		if i, _ := strconv.Atoi(call[sep+1:]); i != next[key] {
+			t.Fatalf("%s llegó fuera de orden, esperaba %d: %v", key, next[key], calls)
+		}
+		next[key]++
+	}
+	if len(next) != 3 || next["twitch/streamer"] != n {
+		t.Fatalf("claves = %v", next)
+	}
+}
+
+func TestHookDispatcherDoesNotBlockCallers(t *testing.T) {
+	rec := &recordingHook{block: make(chan struct{})}
+	d := NewHookDispatcher(rec.hook, 4)
+	d.Start(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		// el hook está trabado: los avisos que entran en la cola vuelven enseguida
+		for i := range 4 {
+			d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "bot", fmt.Sprint(i)))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue esperó al hook")
+	}
+
+	close(rec.block)
+	flush(t, d)
+	if got := rec.recorded(); len(got) != 4 {
+		t.Fatalf("calls = %v", got)
+	}
+}
+
+func TestHookDispatcherWaitsForStart(t *testing.T) {
+	rec := &recordingHook{}
+	d := NewHookDispatcher(rec.hook, 2)
+	// antes de Start la cola crece aunque pase del tamaño
+	for i := range 5 {
+		d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "bot", fmt.Sprint(i)))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := d.Flush(ctx); err == nil {
+		t.Fatal("Flush terminó sin que el dispatcher arrancara")
+	}
+	if got := rec.recorded(); len(got) != 0 {
+		t.Fatalf("el hook corrió antes de Start: %v", got)
+	}
+
+	d.Start(context.Background())
+	flush(t, d)
+	if got := rec.recorded(); len(got) != 5 || got[0] != "twitch/bot:0" || got[4] != "twitch/bot:4" {
+		t.Fatalf("calls = %v", got)
+	}
+}
+
+func TestHookDispatcherRecoversPanics(t *testing.T) {
+	rec := &recordingHook{}
+	d := NewHookDispatcher(func(ctx context.Context, c *domain.Credential) {
+		if c.AccessToken == "boom" {
+			panic("adaptador roto")
+		}
+		rec.hook(ctx, c)
+	}, 0)
+	d.Start(context.Background())
+
+	d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "bot", "boom"))
+	d.Enqueue(context.Background(), cred(domain.PlatformTwitch, "bot", "ok"))
+	flush(t, d)
+	if got := rec.recorded(); len(got) != 1 || got[0] != "twitch/bot:ok" {
+		t.Fatalf("un panic frenó la cola: %v", got)
+	}
+}
+
+func TestHookDispatcherCloseDrains(t *testing.T) {
+	rec := &recordingHook{block: make(chan struct{})}
+	d := NewHookDispatcher(rec.hook, 0)
+	d.Start(context.Background())
+	for i := range 3 {
+		d.Enqueue(context.Background(), cred(domain.PlatformKick, "bot", fmt.Sprint(i)))
+	}
+
+	closed := make(chan error, 1)
+	go func() { closed <- d.Close(context.Background()) }()
+	select {
+	case <-closed:
+		t.Fatal("Close volvió con avisos pendientes")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(rec.block)
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close no terminó")
+	}
+	if got := rec.recorded(); len(got) != 3 {
+		t.Fatalf("Close no esperó lo pendiente: %v", got)
+	}
+
+	// cerrado: lo nuevo se descarta
+	d.Enqueue(context.Background(), cred(domain.PlatformKick, "bot", "tarde"))
+	flush(t, d)
+	if got := rec.recorded(); len(got) != 3 {
+		t.Fatalf("se ejecutó un aviso después de Close: %v", got)
+	}
+}
@@ -0,0 +1,128 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestManualRefreshSucceeds(t *testing.T) {
+	ctx := context.Background()
+	// falta mucho para que venza: el refresh automático no la tocaría
+	cred := expiringTwitch("bot")
+	cred.ExpiresAt = time.Now().Add(3 * time.Hour)
+	repo := newMemoryCredentials(cred)
+	r, ts := newTwitchRefresher(t, repo)
+	var hooked []string
+	r.RegisterHook(func(_ context.Context, cred *domain.Credential) { hooked = append(hooked, cred.AccessToken) })
+
+	results, err := r.RefreshNow(ctx, domain.PlatformTwitch, "bot")
+	if err != nil || len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("RefreshNow = %+v, %v", results, err)
+	}
+	if until := time.Until(results[0].ExpiresAt); until < 3*time.Hour || until > 4*time.Hour {
+		t.Fatalf("vencimiento nuevo = %v", results[0].ExpiresAt)
+	}
+	if len(hooked) != 1 || hooked[0] != "nuevo" || ts.calls.Load() != 1 {
+		t.Fatalf("hooks = %q, pedidos = %d", hooked, ts.calls.Load())
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "bot"); stored.RefreshToken != "refresh-2" {
+		t.Fatalf("credencial = %+v", stored)
+	}
+}
+
+func TestManualRefreshWithoutRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	cred := expiringTwitch("bot")
+	cred.RefreshToken = ""
+	repo := newMemoryCredentials(cred)
+	r, ts := newTwitchRefresher(t, repo)
+	hooked := false
+	r.RegisterHook(func(context.Context, *domain.Credential) { hooked = true })
+
+	if err := r.RefreshCredential(ctx, domain.PlatformTwitch, "bot"); !errors.Is(err, ErrNoRefreshToken) {
+		t.Fatalf("RefreshCredential = %v, esperaba ErrNoRefreshToken", err)
+	}
+	if err := r.RefreshCredential(ctx, domain.PlatformTwitch, "streamer"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("sin credencial = %v, esperaba ErrCredentialNotFound", err)
+	}
+	if hooked || ts.calls.Load() != 0 {
+		t.Fatalf("hooks = %v, pedidos = %d", hooked, ts.calls.Load())
+	}
+}
+
+func TestManualRefreshRejected(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryCredentials(expiringTwitch("bot"))
+	r, ts := newTwitchRefresher(t, repo)
+	ts.respond(http.StatusBadRequest, `{"status":400,"message":"Invalid refresh token"}`)
+	hooked := false
+	r.RegisterHook(func(context.Context, *domain.Credential) { hooked = true })
+
+	results, err := r.RefreshNow(ctx, domain.PlatformTwitch, "bot")
+	if err != nil || len(results) != 1 {
+		t.Fatalf("RefreshNow = %+v, %v", results, err)
+	}
+	got := results[0].Err
+	if !errors.Is(got, ErrReauthRequired) || !strings.Contains(got.Error(), "Invalid refresh token") {
+		t.Fatalf("error = %v, esperaba el motivo de Twitch", got)
+	}
+	if hooked || !results[0].ExpiresAt.IsZero() {
+		t.Fatalf("un refresh rechazado disparó los hooks (%v) o informó vencimiento (%v)", hooked, results[0].ExpiresAt)
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "bot"); stored.AccessToken != "viejo" {
+		t.Fatalf("credencial = %+v", stored)
+	}
+}
+
+func TestRefreshNowAllSkipsMissingRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	noRefresh := expiringTwitch("streamer")
+	noRefresh.RefreshToken = ""
+	repo := newMemoryCredentials(expiringTwitch("bot"), noRefresh)
+	r, ts := newTwitchRefresher(t, repo)
+
+	results, err := r.RefreshNow(ctx, "", "")
+	if err != nil || len(results) != 1 || results[0].Role != "bot" || results[0].Err != nil {
+		t.Fatalf("RefreshNow = %+v, %v", results, err)
+	}
+	if ts.calls.Load() != 1 {
+		t.Fatalf("pedidos = %d", ts.calls.Load())
+	}
+}
+
+func TestManualAndScheduledRefreshAreSerialized(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryCredentials(expiringTwitch("bot"))
+	r, ts := newTwitchRefresher(t, repo)
+	ts.delay.Store(int64(20 * time.Millisecond))
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.RefreshCredential(ctx, domain.PlatformTwitch, "bot")
+		}()
+		go func() {
+			defer wg.Done()
+			r.RefreshAll(ctx)
+		}()
+	}
+	wg.Wait()
+
+	if ts.peak.Load() != 1 {
+		t.Fatalf("hubo %d refresh de la misma credencial a la vez", ts.peak.Load())
+	}
+	// los manuales refrescan siempre; la vuelta automática ve el token nuevo
+	// y no vuelve a pedir
+	if calls := ts.calls.Load(); calls < 3 || calls > 4 {
+		t.Fatalf("pedidos = %d, esperaba los 3 manuales y como mucho uno automático", calls)
+	}
+}
@@ -3,6 +3,7 @@ package credentials
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -37,10 +38,88 @@ type Refresher struct {
 
 	hooksMu sync.RWMutex
 	hooks   []CredentialHook
+	reauth  ReauthHook
+
+	// locks evita refrescar la misma credencial dos veces a la vez (timer,
+	// vuelta periódica o botón del panel): Kick rota el refresh token y el
+	// segundo intento fallaría con invalid_grant.
+	locksMu sync.Mutex
+	locks   map[credentialKey]*sync.Mutex
+
+	// timers son los refresh programados por credencial; schedCtx es el
+	// contexto de Start (nil antes de Start).
+	schedMu  sync.Mutex
+	schedCtx context.Context
+	timers   map[credentialKey]*time.Timer
+	jitter   func(max time.Duration) time.Duration
+	now      func() time.Time
 }
 
 type CredentialHook func(ctx context.Context, cred *domain.Credential)
 
+// ReauthHook recibe la credencial que quedó marcada para volver a iniciar
+// sesión y el motivo que dio el proveedor.
+type ReauthHook func(ctx context.Context, cred *domain.Credential, reason string)
+
+// ErrReauthRequired indica que el proveedor rechazó el refresh token (revocado,
+// vencido o de otra app): reintentar no sirve, hay que volver a iniciar sesión.
+var ErrReauthRequired = errors.New("refresher: el refresh token ya no es válido")
+
+// ErrRefreshFailed es un rechazo del endpoint de tokens que puede ser
+// pasajero (5xx, 429, app mal configurada).
+var ErrRefreshFailed = errors.New("refresher: el proveedor no refrescó el token")
+
+// Errores de RefreshCredential.
+var (
+	ErrCredentialNotFound = errors.New("refresher: no hay credencial guardada")
+	ErrNoRefreshToken     = errors.New("refresher: la credencial no tiene refresh token")
+)
+
+// refreshError es un fallo de refresh con el motivo del proveedor. Si es
+// permanente se reconoce con errors.Is(err, ErrReauthRequired); si no, con
+// ErrRefreshFailed.
+type refreshError struct {
+	reason    string
+	permanent bool
+}
+
+func (e *refreshError) Error() string {
+	return "refresher: " + e.reason
+}
+
+func (e *refreshError) Unwrap() error {
+	if e.permanent {
+		return ErrReauthRequired
+	}
+	return ErrRefreshFailed
+}
+
+// refreshFailure clasifica la respuesta de error del endpoint de tokens.
+// invalid_grant (o el "Invalid refresh token" de Twitch) es permanente; el
+// resto (5xx, 429, credenciales de la app mal configuradas) se reintenta en
+// la próxima vuelta.
+func refreshFailure(platform domain.Platform, status int, oauthError, message string) error {
+	reason := strings.TrimSpace(oauthError)
+	if msg := strings.TrimSpace(message); msg != "" {
+		if reason != "" {
+			reason += ": "
+		}
+		reason += msg
+	}
+	if reason == "" {
+		reason = http.StatusText(status)
+	}
+	permanent := false
+	if status == http.StatusBadRequest || status == http.StatusUnauthorized {
+		lower := strings.ToLower(oauthError + " " + message)
+		permanent = strings.Contains(lower, "invalid_grant") || strings.Contains(lower, "invalid refresh token")
+	}
+	return &refreshError{
+		reason:    fmt.Sprintf("%s status %d: %s", platform, status, reason),
+		permanent: permanent,
+	}
+}
+
 func NewRefresher(repo domain.CredentialRepository, twitchCfg TwitchConfig, kickCfg KickConfig) *Refresher {
 	var kickClient *kicksdk.Client
 	if kickCfg.ClientID != "" && kickCfg.ClientSecret != "" && kickCfg.RedirectURI != "" {
@@ -61,6 +140,10 @@ func NewRefresher(repo domain.CredentialRepository, twitchCfg TwitchConfig, kick
 		httpCli: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		locks:  make(map[credentialKey]*sync.Mutex),
+		timers: make(map[credentialKey]*time.Timer),
+		jitter: randomJitter,
+		now:    time.Now,
 	}
 }
 
@@ -73,6 +156,14 @@ func (r *Refresher) RegisterHook(h CredentialHook) {
 	r.hooks = append(r.hooks, h)
 }
 
+// SetReauthHandler recibe las credenciales que dejan de refrescarse porque
+// el proveedor rechazó el refresh token.
+func (r *Refresher) SetReauthHandler(h ReauthHook) {
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+	r.reauth = h
+}
+
 func (r *Refresher) notifyHooks(ctx context.Context, cred *domain.Credential) {
 	if cred == nil {
 		return
@@ -85,14 +176,27 @@ func (r *Refresher) notifyHooks(ctx context.Context, cred *domain.Credential) {
 	}
 }
 
+// Start programa el refresh de cada credencial cerca de su propio
+// vencimiento, con un jitter para repartir la carga en el proveedor. Cada
+// interval hay además una vuelta completa por si algún timer se perdió y para
+// programar las credenciales nuevas.
 func (r *Refresher) Start(ctx context.Context, interval time.Duration) {
 	if interval <= 0 {
 		interval = 30 * time.Minute
 	}
+	if r.repo == nil {
+		return
+	}
+
+	r.schedMu.Lock()
+	r.schedCtx = ctx
+	r.schedMu.Unlock()
+	r.scheduleAll(ctx)
 
 	ticker := time.NewTicker(interval)
 	go func() {
 		defer ticker.Stop()
+		defer r.stopTimers()
 		for {
 			select {
 			case <-ctx.Done():
@@ -101,6 +205,7 @@ func (r *Refresher) Start(ctx context.Context, interval time.Duration) {
 				if err := r.RefreshAll(ctx); err != nil {
 					log.Printf("token refresher: %v", err)
 				}
+				r.scheduleAll(ctx)
 			}
 		}
 	}()
@@ -116,44 +221,187 @@ func (r *Refresher) RefreshAll(ctx context.Context) error {
 		return fmt.Errorf("refresher: list credentials: %w", err)
 	}
 
+	var errs []error
 	for _, cred := range creds {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		if cred == nil || cred.RefreshToken == "" {
-			continue
+		// una credencial que falla no frena el refresh de las demás
+		if err := r.refreshCredential(ctx, cred, refreshLead); err != nil {
+			errs = append(errs, err)
 		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// refreshCredential refresca cred si le quedan menos de window. Después de
+// refrescarla programa el próximo refresh; si falla de forma transitoria
+// programa un reintento y si el proveedor rechazó el refresh token la marca
+// para volver a iniciar sesión.
+func (r *Refresher) refreshCredential(ctx context.Context, cred *domain.Credential, window time.Duration) error {
+	// ya se pidió volver a iniciar sesión; reintentar no sirve
+	if !schedulable(cred) || !needsRefreshWithin(cred, window) {
+		return nil
+	}
+
+	unlock := r.lock(keyOf(cred))
+	defer unlock()
+	// otro refresh pudo ganar mientras se esperaba el lock
+	current, err := r.repo.Get(ctx, cred.Platform, cred.Role)
+	if err != nil {
+		return fmt.Errorf("refresher: get %s/%s: %w", cred.Platform, cred.Role, err)
+	}
+	if !schedulable(current) || !needsRefreshWithin(current, window) {
+		r.schedule(current)
+		return nil
+	}
+	return r.refreshLocked(ctx, current)
+}
+
+// RefreshCredential refresca ya la credencial de platform/role, aunque no
+// esté por vencer ni marcada para volver a iniciar sesión (es el botón del
+// panel). Si sale bien los hooks reciben el token nuevo como en cualquier
+// refresh; si el proveedor lo rechaza el error dice por qué.
+func (r *Refresher) RefreshCredential(ctx context.Context, platform domain.Platform, role string) error {
+	if r.repo == nil {
+		return ErrCredentialNotFound
+	}
+	key := credentialKey{platform: platform, role: role}
+	unlock := r.lock(key)
+	defer unlock()
 
-		if !needsRefresh(cred) {
+	cred, err := r.repo.Get(ctx, platform, role)
+	if err != nil {
+		return fmt.Errorf("refresher: get %s/%s: %w", platform, role, err)
+	}
+	if cred == nil {
+		return ErrCredentialNotFound
+	}
+	if strings.TrimSpace(cred.RefreshToken) == "" {
+		return ErrNoRefreshToken
+	}
+	return r.refreshLocked(ctx, cred)
+}
+
+// RefreshResult es el resultado de refrescar una credencial a mano.
+type RefreshResult struct {
+	Platform  domain.Platform
+	Role      string
+	ExpiresAt time.Time
+	Err       error
+}
+
+// RefreshNow refresca a mano platform/role, o todas las credenciales con
+// refresh token si platform está vacío. Un fallo en una no frena las demás;
+// el error solo es de no poder listarlas.
+func (r *Refresher) RefreshNow(ctx context.Context, platform domain.Platform, role string) ([]RefreshResult, error) {
+	if platform != "" {
+		return []RefreshResult{r.refreshNow(ctx, platform, role)}, nil
+	}
+	if r.repo == nil {
+		return nil, nil
+	}
+	creds, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("refresher: list credentials: %w", err)
+	}
+	var out []RefreshResult
+	for _, cred := range creds {
+		if cred == nil || strings.TrimSpace(cred.RefreshToken) == "" {
 			continue
 		}
+		out = append(out, r.refreshNow(ctx, cred.Platform, cred.Role))
+	}
+	return out, nil
+}
 
-		log.Println("Refreshing: " + cred.Platform)
+func (r *Refresher) refreshNow(ctx context.Context, platform domain.Platform, role string) RefreshResult {
+	result := RefreshResult{Platform: platform, Role: role}
+	if result.Err = r.RefreshCredential(ctx, platform, role); result.Err != nil {
+		return result
+	}
+	if cred, err := r.repo.Get(ctx, platform, role); err == nil && cred != nil {
+		result.ExpiresAt = cred.ExpiresAt
+	}
+	return result
+}
 
-		switch cred.Platform {
-		case domain.PlatformTwitch:
-			if err := r.refreshTwitch(ctx, cred); err != nil {
-				return err
-			}
-		case domain.PlatformKick:
-			if err := r.refreshKick(ctx, cred); err != nil {
-				return err
-			}
-		}
+// lock toma el mutex de la credencial y devuelve cómo soltarlo.
+func (r *Refresher) lock(key credentialKey) func() {
+	r.locksMu.Lock()
+	mu, ok := r.locks[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		r.locks[key] = mu
 	}
+	r.locksMu.Unlock()
+	mu.Lock()
+	return mu.Unlock
+}
 
-	return nil
+// refreshLocked pide el token nuevo; quien llama tiene el lock de cred.
+func (r *Refresher) refreshLocked(ctx context.Context, cred *domain.Credential) error {
+	log.Println("Refreshing: " + cred.Platform)
+
+	var err error
+	switch cred.Platform {
+	case domain.PlatformTwitch:
+		err = r.refreshTwitch(ctx, cred)
+	case domain.PlatformKick:
+		err = r.refreshKick(ctx, cred)
+	default:
+		return fmt.Errorf("refresher: no sé refrescar credenciales de %s", cred.Platform)
+	}
+	switch {
+	case err == nil:
+		r.schedule(cred)
+	case errors.Is(err, ErrReauthRequired):
+		r.markReauth(ctx, cred, err)
+	default:
+		r.scheduleAt(keyOf(cred), r.now().Add(refreshRetryDelay+r.jitter(maxRefreshJitter)))
+	}
+	return err
 }
 
-func needsRefresh(cred *domain.Credential) bool {
+// markReauth marca la credencial para que no se vuelva a refrescar y avisa al
+// ReauthHook. La credencial no se borra: el access token puede seguir
+// sirviendo un rato y el panel muestra de qué cuenta era.
+func (r *Refresher) markReauth(ctx context.Context, cred *domain.Credential, cause error) {
+	reason := cause.Error()
+	var refreshErr *refreshError
+	if errors.As(cause, &refreshErr) {
+		reason = refreshErr.reason
+	}
+	log.Printf("refresher: %s/%s necesita volver a iniciar sesión (%s)", cred.Platform, cred.Role, reason)
+
+	if cred.Metadata == nil {
+		cred.Metadata = make(map[string]string)
+	}
+	cred.Metadata[domain.CredentialReauthKey] = reason
+	if err := r.repo.Save(ctx, cred); err != nil {
+		log.Printf("refresher: no pude marcar %s/%s: %v", cred.Platform, cred.Role, err)
+	}
+
+	r.hooksMu.RLock()
+	hook := r.reauth
+	r.hooksMu.RUnlock()
+	if hook != nil {
+		hook(ctx, cred, reason)
+	}
+}
+
+// needsRefreshWithin indica si a cred le quedan menos de window (o si no se
+// sabe cuándo vence).
+func needsRefreshWithin(cred *domain.Credential, window time.Duration) bool {
 	if cred == nil {
 		return false
 	}
 	if cred.ExpiresAt.IsZero() {
 		return true
 	}
-	return time.Until(cred.ExpiresAt) < 10*time.Minute
+	return time.Until(cred.ExpiresAt) < window
 }
 
 func (r *Refresher) refreshTwitch(ctx context.Context, cred *domain.Credential) error {
@@ -185,7 +433,11 @@ func (r *Refresher) refreshTwitch(ctx context.Context, cred *domain.Credential)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("refresher: twitch status %d: %s", resp.StatusCode, string(body))
+		var failure twitchErrorPayload
+		if err := json.Unmarshal(body, &failure); err != nil || (failure.Error == "" && failure.Message == "") {
+			failure.Message = strings.TrimSpace(string(body))
+		}
+		return refreshFailure(domain.PlatformTwitch, resp.StatusCode, failure.Error, failure.Message)
 	}
 
 	var payload twitchTokenPayload
@@ -199,6 +451,8 @@ func (r *Refresher) refreshTwitch(ctx context.Context, cred *domain.Credential)
 	}
 	cred.ExpiresAt = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
 	cred.UpdatedAt = time.Now()
+	cred.SetScopes(payload.Scope)
+	delete(cred.Metadata, domain.CredentialReauthKey)
 
 	if err := r.repo.Save(ctx, cred); err != nil {
 		return err
@@ -219,6 +473,10 @@ func (r *Refresher) refreshKick(ctx context.Context, cred *domain.Credential) er
 	if err != nil {
 		return fmt.Errorf("refresher: kick refresh: %w", err)
 	}
+	// el SDK no devuelve error cuando Kick responde con un status de error
+	if meta := resp.ResponseMetadata; meta.StatusCode != http.StatusOK {
+		return refreshFailure(domain.PlatformKick, meta.StatusCode, meta.KickError, meta.KickErrorDescription)
+	}
 
 	payload := resp.Payload
 	cred.AccessToken = payload.AccessToken
@@ -227,6 +485,8 @@ func (r *Refresher) refreshKick(ctx context.Context, cred *domain.Credential) er
 	}
 	cred.ExpiresAt = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
 	cred.UpdatedAt = time.Now()
+	cred.SetScopes([]string{payload.Scope})
+	delete(cred.Metadata, domain.CredentialReauthKey)
 
 	if err := r.repo.Save(ctx, cred); err != nil {
 		return err
@@ -235,8 +495,16 @@ func (r *Refresher) refreshKick(ctx context.Context, cred *domain.Credential) er
 	return nil
 }
 
+// twitchErrorPayload es el cuerpo de error de id.twitch.tv, p. ej.
+// {"status":400,"message":"Invalid refresh token"}.
+type twitchErrorPayload struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
 type twitchTokenPayload struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresIn    int64  `json:"expires_in"`
+	AccessToken  string   `json:"access_token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	Scope        []string `json:"scope"`
 }
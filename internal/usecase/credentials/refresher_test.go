@@ -0,0 +1,282 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryCredentials hace de la tabla credentials; guarda copias para que los
+// cambios del refresher solo se vean después de Save.
+type memoryCredentials struct {
+	mu    sync.Mutex
+	creds map[credentialKey]domain.Credential
+}
+
+func newMemoryCredentials(creds ...*domain.Credential) *memoryCredentials {
+	m := &memoryCredentials{creds: make(map[credentialKey]domain.Credential)}
+	for _, cred := range creds {
+		m.creds[keyOf(cred)] = copyCredential(cred)
+	}
+	return m
+}
+
+func copyCredential(cred *domain.Credential) domain.Credential {
+	out := *cred
+	out.Metadata = make(map[string]string, len(cred.Metadata))
+	for k, v := range cred.Metadata {
+		out.Metadata[k] = v
+	}
+	return out
+}
+
+func (m *memoryCredentials) Get(_ context.Context, platform domain.Platform, role string) (*domain.Credential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cred, ok := m.creds[credentialKey{platform: platform, role: role}]
+	if !ok {
+		return nil, nil
+	}
+	out := copyCredential(&cred)
+	return &out, nil
+}
+
+func (m *memoryCredentials) Save(_ context.Context, cred *domain.Credential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.creds[keyOf(cred)] = copyCredential(cred)
+	return nil
+}
+
+func (m *memoryCredentials) List(context.Context) ([]*domain.Credential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*domain.Credential
+	for _, cred := range m.creds {
+		c := copyCredential(&cred)
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (m *memoryCredentials) Delete(_ context.Context, platform domain.Platform, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.creds, credentialKey{platform: platform, role: role})
+	return nil
+}
+
+// rewriteHost manda a target los pedidos que iban a id.twitch.tv.
+type rewriteHost struct{ target *url.URL }
+
+func (rw rewriteHost) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rw.target.Scheme
+	req.URL.Host = rw.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// tokenServer hace del endpoint de tokens de Twitch: responde status y body
+// y cuenta los pedidos. Con delay cada respuesta tarda; peak es la mayor
+// cantidad de pedidos en vuelo a la vez.
+type tokenServer struct {
+	status atomic.Int32
+	body   atomic.Value
+	calls  atomic.Int32
+	delay  atomic.Int64
+	active atomic.Int32
+	peak   atomic.Int32
+}
+
+func newTwitchRefresher(t *testing.T, repo domain.CredentialRepository) (*Refresher, *tokenServer) {
+	t.Helper()
+	ts := &tokenServer{}
+	ts.respond(http.StatusOK, `{"access_token":"nuevo","refresh_token":"refresh-2","expires_in":14400,"scope":["chat:read","chat:edit"]}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.calls.Add(1)
+		active := ts.active.Add(1)
+		defer ts.active.Add(-1)
+		for {
+			peak := ts.peak.Load()
+			if active <= peak || ts.peak.CompareAndSwap(peak, active) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(ts.delay.Load()))
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "refresh_token" {
+			t.Errorf("pedido inválido: %v %v", err, r.Form)
+		}
+		w.WriteHeader(int(ts.status.Load()))
+		fmt.Fprint(w, ts.body.Load().(string))
+	}))
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+
+	r := NewRefresher(repo, TwitchConfig{ClientID: "client", ClientSecret: "secret"}, KickConfig{})
+	r.httpCli = &http.Client{Transport: rewriteHost{target: target}}
+	r.jitter = func(time.Duration) time.Duration { return 0 }
+	return r, ts
+}
+
+func (ts *tokenServer) respond(status int, body string) {
+	ts.status.Store(int32(status))
+	ts.body.Store(body)
+}
+
+func expiringTwitch(role string) *domain.Credential {
+	return &domain.Credential{
+		Platform:     domain.PlatformTwitch,
+		Role:         role,
+		AccessToken:  "viejo",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(time.Minute),
+		Metadata:     map[string]string{"login": "zero"},
+	}
+}
+
+func TestRefreshFailureClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		oauthErr  string
+		message   string
+		permanent bool
+	}{
+		{"kick invalid_grant", http.StatusBadRequest, "invalid_grant", "refresh token revoked", true},
+		{"twitch invalid refresh token", http.StatusBadRequest, "", "Invalid refresh token", true},
+		{"invalid_grant as 401", http.StatusUnauthorized, "invalid_grant", "", true},
+		{"bad client secret", http.StatusBadRequest, "invalid_client", "", false},
+		{"rate limited", http.StatusTooManyRequests, "", "slow down", false},
+		{"server error mentioning invalid_grant", http.StatusBadGateway, "invalid_grant", "", false},
+		{"empty body", http.StatusServiceUnavailable, "", "", false},
+	}
+	for _, tt := range tests {
+		err := refreshFailure(domain.PlatformTwitch, tt.status, tt.oauthErr, tt.message)
+		if got := errors.Is(err, ErrReauthRequired); got != tt.permanent {
+			t.Errorf("%s: permanente = %v, esperaba %v (%v)", tt.name, got, tt.permanent, err)
+		}
+		if got := errors.Is(err, ErrRefreshFailed); got == tt.permanent {
+			t.Errorf("%s: pasajero = %v, esperaba %v", tt.name, got, !tt.permanent)
+		}
+	}
+	if err := refreshFailure(domain.PlatformKick, http.StatusServiceUnavailable, "", ""); err.Error() != "refresher: kick status 503: Service Unavailable" {
+		t.Fatalf("motivo sin cuerpo = %q", err)
+	}
+}
+
+func TestPermanentFailureAsksForReauth(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryCredentials(expiringTwitch("bot"))
+	r, ts := newTwitchRefresher(t, repo)
+	ts.respond(http.StatusBadRequest, `{"status":400,"message":"Invalid refresh token"}`)
+
+	var reauths []string
+	r.SetReauthHandler(func(_ context.Context, cred *domain.Credential, reason string) {
+		reauths = append(reauths, string(cred.Platform)+"/"+cred.Role+": "+reason)
+	})
+	var refreshed int
+	r.RegisterHook(func(context.Context, *domain.Credential) { refreshed++ })
+
+	if err := r.RefreshAll(ctx); !errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("RefreshAll = %v, esperaba ErrReauthRequired", err)
+	}
+	if len(reauths) != 1 || reauths[0] != "twitch/bot: twitch status 400: Invalid refresh token" || refreshed != 0 {
+		t.Fatalf("reauth = %q, hooks = %d", reauths, refreshed)
+	}
+	stored, _ := repo.Get(ctx, domain.PlatformTwitch, "bot")
+	if stored.ReauthReason() == "" || stored.AccessToken != "viejo" {
+		t.Fatalf("credencial = %+v, esperaba marcada y sin borrar", stored)
+	}
+
+	// marcada: las vueltas siguientes no vuelven a pedirle al proveedor
+	if err := r.RefreshAll(ctx); err != nil {
+		t.Fatalf("RefreshAll después del reauth = %v", err)
+	}
+	if ts.calls.Load() != 1 || len(reauths) != 1 {
+		t.Fatalf("pedidos = %d, reauths = %d; un token rechazado se siguió reintentando", ts.calls.Load(), len(reauths))
+	}
+}
+
+func TestTransientFailureKeepsRetrying(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryCredentials(expiringTwitch("bot"))
+	r, ts := newTwitchRefresher(t, repo)
+	ts.respond(http.StatusServiceUnavailable, "upstream down")
+	reauthed := false
+	r.SetReauthHandler(func(context.Context, *domain.Credential, string) { reauthed = true })
+
+	err := r.RefreshAll(ctx)
+	if !errors.Is(err, ErrRefreshFailed) || errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("RefreshAll = %v, esperaba un fallo pasajero", err)
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "bot"); reauthed || stored.ReauthReason() != "" {
+		t.Fatalf("un fallo pasajero pidió reauth: %+v", stored)
+	}
+
+	// cuando el proveedor vuelve, el refresh sale y limpia todo
+	ts.respond(http.StatusOK, `{"access_token":"nuevo","refresh_token":"refresh-2","expires_in":14400,"scope":["chat:read"]}`)
+	if err := r.RefreshAll(ctx); err != nil {
+		t.Fatalf("RefreshAll = %v", err)
+	}
+	stored, _ := repo.Get(ctx, domain.PlatformTwitch, "bot")
+	if stored.AccessToken != "nuevo" || stored.RefreshToken != "refresh-2" || !stored.HasScope("chat:read") || ts.calls.Load() != 2 {
+		t.Fatalf("credencial = %+v, pedidos = %d", stored, ts.calls.Load())
+	}
+}
+
+func TestSuccessfulRefreshClearsReauthFlag(t *testing.T) {
+	ctx := context.Background()
+	cred := expiringTwitch("streamer")
+	cred.Metadata[domain.CredentialReauthKey] = "twitch status 400: Invalid refresh token"
+	repo := newMemoryCredentials(cred)
+	r, _ := newTwitchRefresher(t, repo)
+
+	// la vuelta automática la saltea, el botón del panel no
+	if err := r.RefreshAll(ctx); err != nil {
+		t.Fatalf("RefreshAll = %v", err)
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "streamer"); stored.AccessToken != "viejo" {
+		t.Fatal("la vuelta periódica tocó una credencial marcada")
+	}
+	if err := r.RefreshCredential(ctx, domain.PlatformTwitch, "streamer"); err != nil {
+		t.Fatalf("RefreshCredential = %v", err)
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "streamer"); stored.ReauthReason() != "" || stored.AccessToken != "nuevo" {
+		t.Fatalf("credencial = %+v, esperaba la marca borrada", stored)
+	}
+}
+
+func TestRefreshPersistsScopes(t *testing.T) {
+	ctx := context.Background()
+	cred := expiringTwitch("streamer")
+	cred.Metadata[domain.CredentialScopesKey] = "chat:read"
+	repo := newMemoryCredentials(cred)
+	r, ts := newTwitchRefresher(t, repo)
+
+	if err := r.RefreshCredential(ctx, domain.PlatformTwitch, "streamer"); err != nil {
+		t.Fatalf("RefreshCredential = %v", err)
+	}
+	stored, _ := repo.Get(ctx, domain.PlatformTwitch, "streamer")
+	if got := stored.Metadata[domain.CredentialScopesKey]; got != "chat:edit chat:read" {
+		t.Fatalf("scopes = %q, esperaba los de la respuesta ordenados", got)
+	}
+
+	// una respuesta sin scope conserva los que ya estaban guardados
+	ts.respond(http.StatusOK, `{"access_token":"otro","refresh_token":"refresh-3","expires_in":14400}`)
+	if err := r.RefreshCredential(ctx, domain.PlatformTwitch, "streamer"); err != nil {
+		t.Fatalf("RefreshCredential = %v", err)
+	}
+	stored, _ = repo.Get(ctx, domain.PlatformTwitch, "streamer")
+	if stored.AccessToken != "otro" || !stored.HasScope("chat:edit") {
+		t.Fatalf("credencial = %+v, esperaba los scopes anteriores", stored)
+	}
+}
@@ -0,0 +1,148 @@
+package credentials
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// refreshLead es cuánto antes del vencimiento se refresca un token.
+	refreshLead = 10 * time.Minute
+	// maxRefreshJitter adelanta cada refresh programado un poco al azar para
+	// que los tokens que vencen juntos no pidan al proveedor en la misma ráfaga.
+	maxRefreshJitter = 2 * time.Minute
+	// refreshRetryDelay es la espera antes de reintentar un fallo transitorio.
+	refreshRetryDelay = 5 * time.Minute
+)
+
+type credentialKey struct {
+	platform domain.Platform
+	role     string
+}
+
+func keyOf(cred *domain.Credential) credentialKey {
+	return credentialKey{platform: cred.Platform, role: cred.Role}
+}
+
+// nextRefreshAt devuelve cuándo refrescar cred: refreshLead antes de que
+// venza, adelantado jitter. Sin vencimiento conocido, o si ese momento ya
+// pasó, dentro de jitter.
+func nextRefreshAt(cred *domain.Credential, now time.Time, jitter time.Duration) time.Time {
+	if cred.ExpiresAt.IsZero() {
+		return now.Add(jitter)
+	}
+	at := cred.ExpiresAt.Add(-refreshLead - jitter)
+	if at.Before(now) {
+		return now.Add(jitter)
+	}
+	return at
+}
+
+// schedulable indica si tiene sentido refrescar cred: tiene refresh token,
+// es de una plataforma que sabemos refrescar y no espera un nuevo login.
+func schedulable(cred *domain.Credential) bool {
+	if cred == nil || cred.RefreshToken == "" || cred.ReauthReason() != "" {
+		return false
+	}
+	return cred.Platform == domain.PlatformTwitch || cred.Platform == domain.PlatformKick
+}
+
+// randomJitter es un jitter al azar en [0, max).
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+// scheduleAll programa las credenciales que todavía no tienen refresh
+// programado (p. ej. las que se guardaron con un login nuevo).
+func (r *Refresher) scheduleAll(ctx context.Context) {
+	creds, err := r.repo.List(ctx)
+	if err != nil {
+		log.Printf("token refresher: no pude listar credenciales: %v", err)
+		return
+	}
+	for _, cred := range creds {
+		if !schedulable(cred) {
+			continue
+		}
+		r.schedMu.Lock()
+		_, scheduled := r.timers[keyOf(cred)]
+		r.schedMu.Unlock()
+		if !scheduled {
+			r.schedule(cred)
+		}
+	}
+}
+
+// schedule programa el próximo refresh de cred, reemplazando el anterior.
+// No hace nada hasta que se llama a Start.
+func (r *Refresher) schedule(cred *domain.Credential) {
+	if !schedulable(cred) {
+		return
+	}
+	at := nextRefreshAt(cred, r.now(), r.jitter(maxRefreshJitter))
+	r.scheduleAt(keyOf(cred), at)
+}
+
+func (r *Refresher) scheduleAt(key credentialKey, at time.Time) {
+	r.schedMu.Lock()
+	defer r.schedMu.Unlock()
+	if r.schedCtx == nil || r.schedCtx.Err() != nil {
+		return
+	}
+	if timer, ok := r.timers[key]; ok {
+		timer.Stop()
+	}
+	r.timers[key] = time.AfterFunc(at.Sub(r.now()), func() {
+		r.refreshScheduled(key)
+	})
+}
+
+// refreshScheduled es el refresh programado de una credencial. La relee
+// porque pudo cambiar (login nuevo, refresh de la vuelta periódica) desde
+// que se programó.
+func (r *Refresher) refreshScheduled(key credentialKey) {
+	r.schedMu.Lock()
+	ctx := r.schedCtx
+	delete(r.timers, key)
+	r.schedMu.Unlock()
+	if ctx == nil || ctx.Err() != nil {
+		return
+	}
+
+	cred, err := r.repo.Get(ctx, key.platform, key.role)
+	if err != nil {
+		log.Printf("token refresher: no pude leer %s/%s: %v", key.platform, key.role, err)
+		r.scheduleAt(key, r.now().Add(refreshRetryDelay+r.jitter(maxRefreshJitter)))
+		return
+	}
+	if !schedulable(cred) {
+		return
+	}
+	// el jitter pudo adelantar el timer hasta maxRefreshJitter; si vence más
+	// tarde (otro refresh llegó antes) se reprograma
+	window := refreshLead + maxRefreshJitter
+	if !needsRefreshWithin(cred, window) {
+		r.schedule(cred)
+		return
+	}
+	if err := r.refreshCredential(ctx, cred, window); err != nil {
+		log.Printf("token refresher: %v", err)
+	}
+}
+
+// stopTimers cancela los refresh programados.
+func (r *Refresher) stopTimers() {
+	r.schedMu.Lock()
+	defer r.schedMu.Unlock()
+	for key, timer := range r.timers {
+		timer.Stop()
+		delete(r.timers, key)
+	}
+}
@@ -0,0 +1,148 @@
+package credentials
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+func TestNextRefreshAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		expires time.Time
+		jitter  time.Duration
+		want    time.Time
+	}{
+		{"lead before expiry", now.Add(time.Hour), 0, now.Add(50 * time.Minute)},
+		{"jitter moves it earlier", now.Add(time.Hour), 90 * time.Second, now.Add(48*time.Minute + 30*time.Second)},
+		{"inside the lead", now.Add(5 * time.Minute), 30 * time.Second, now.Add(30 * time.Second)},
+		{"already expired", now.Add(-time.Hour), 0, now},
+		{"unknown expiry", time.Time{}, time.Minute, now.Add(time.Minute)},
+	}
+	for _, tt := range tests {
+		cred := &domain.Credential{Platform: domain.PlatformTwitch, ExpiresAt: tt.expires}
+		if got := nextRefreshAt(cred, now, tt.jitter); !got.Equal(tt.want) {
+			t.Errorf("%s: nextRefreshAt = %v, esperaba %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSchedulable(t *testing.T) {
+	flagged := expiringTwitch("bot")
+	flagged.Metadata[domain.CredentialReauthKey] = "revocado"
+	noRefresh := expiringTwitch("bot")
+	noRefresh.RefreshToken = ""
+	kick := expiringTwitch("streamer")
+	kick.Platform = domain.PlatformKick
+	other := expiringTwitch("bot")
+	other.Platform = domain.Platform("youtube")
+
+	tests := []struct {
+		name string
+		cred *domain.Credential
+		want bool
+	}{
+		{"twitch", expiringTwitch("bot"), true},
+		{"kick", kick, true},
+		{"nil", nil, false},
+		{"no refresh token", noRefresh, false},
+		{"waiting for reauth", flagged, false},
+		{"unknown platform", other, false},
+	}
+	for _, tt := range tests {
+		if got := schedulable(tt.cred); got != tt.want {
+			t.Errorf("%s: schedulable = %v, esperaba %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// waitFor espera a que cond se cumpla; los timers del refresher son reales.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("no pasó a tiempo: %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func isScheduled(r *Refresher, key credentialKey) bool {
+	r.schedMu.Lock()
+	defer r.schedMu.Unlock()
+	_, ok := r.timers[key]
+	return ok
+}
+
+func TestStartRefreshesEachCredentialOnItsOwn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	later := expiringTwitch("streamer")
+	later.ExpiresAt = time.Now().Add(3 * time.Hour)
+	repo := newMemoryCredentials(expiringTwitch("bot"), later)
+	r, ts := newTwitchRefresher(t, repo)
+	hooked := make(chan string, 4)
+	r.RegisterHook(func(_ context.Context, cred *domain.Credential) { hooked <- cred.Role })
+
+	// la vuelta periódica no llega a correr: todo sale de los timers
+	r.Start(ctx, time.Hour)
+
+	select {
+	case role := <-hooked:
+		if role != "bot" {
+			t.Fatalf("se refrescó %s, esperaba solo la que vence", role)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("el timer del bot no refrescó")
+	}
+	bot := credentialKey{platform: domain.PlatformTwitch, role: "bot"}
+	streamer := credentialKey{platform: domain.PlatformTwitch, role: "streamer"}
+	waitFor(t, "reprogramar el bot con el vencimiento nuevo", func() bool { return isScheduled(r, bot) })
+	if !isScheduled(r, streamer) {
+		t.Fatal("la credencial que vence más tarde no quedó programada")
+	}
+	if stored, _ := repo.Get(ctx, domain.PlatformTwitch, "streamer"); stored.AccessToken != "viejo" || ts.calls.Load() != 1 {
+		t.Fatalf("streamer = %+v, pedidos = %d; se refrescó antes de tiempo", stored, ts.calls.Load())
+	}
+
+	cancel()
+	waitFor(t, "cancelar los timers", func() bool { return !isScheduled(r, bot) && !isScheduled(r, streamer) })
+}
+
+func TestTransientFailureSchedulesRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newMemoryCredentials(expiringTwitch("bot"))
+	r, ts := newTwitchRefresher(t, repo)
+	ts.respond(http.StatusServiceUnavailable, "upstream down")
+	r.Start(ctx, time.Hour)
+
+	key := credentialKey{platform: domain.PlatformTwitch, role: "bot"}
+	waitFor(t, "el primer intento", func() bool { return ts.calls.Load() == 1 })
+	waitFor(t, "programar el reintento", func() bool { return isScheduled(r, key) })
+	if ts.calls.Load() != 1 {
+		t.Fatalf("pedidos = %d; el reintento no esperó refreshRetryDelay", ts.calls.Load())
+	}
+}
+
+func TestFlaggedCredentialIsNotScheduled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	flagged := expiringTwitch("bot")
+	flagged.Metadata[domain.CredentialReauthKey] = "revocado"
+	repo := newMemoryCredentials(flagged)
+	r, ts := newTwitchRefresher(t, repo)
+	r.Start(ctx, time.Hour)
+
+	if isScheduled(r, keyOf(flagged)) || ts.calls.Load() != 0 {
+		t.Fatalf("se programó una credencial que espera un login nuevo (pedidos = %d)", ts.calls.Load())
+	}
+}
@@ -0,0 +1,290 @@
+// Package follows guarda los follows como notificación y decide cuándo
+// anunciarlos en el chat para que una oleada (o un ataque de bots) no lo llene.
+package follows
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const rateWindow = time.Minute
+
+// Emitter es el pipeline de notificaciones (guardar + publicar).
+type Emitter interface {
+	Emit(ctx context.Context, notification *domain.Notification) (*domain.Notification, error)
+}
+
+// Announcer manda el texto al chat de la plataforma.
+type Announcer func(ctx context.Context, platform domain.Platform, text string) error
+
+// BotAlert se emite al detectar una oleada de follows por encima de BotThreshold.
+type BotAlert struct {
+	Platform      domain.Platform `json:"platform"`
+	Rate          int             `json:"rate"`
+	SilencedUntil time.Time       `json:"silenced_until,omitempty"`
+	At            time.Time       `json:"at"`
+}
+
+type Service struct {
+	emitter  Emitter
+	announce Announcer
+	repo     domain.FollowSettingsRepository
+
+	mu        sync.Mutex
+	cfg       domain.FollowAnnounceSettings
+	now       func() time.Time
+	hits      map[domain.Platform][]time.Time
+	pending   map[domain.Platform]int
+	lastBatch map[domain.Platform]time.Time
+	silenced  map[domain.Platform]time.Time
+	alerted   map[domain.Platform]bool
+	onAlert   func(BotAlert)
+}
+
+func NewService(emitter Emitter, announce Announcer) *Service {
+	return &Service{
+		emitter:   emitter,
+		announce:  announce,
+		cfg:       domain.DefaultFollowAnnounceSettings(),
+		now:       time.Now,
+		hits:      make(map[domain.Platform][]time.Time),
+		pending:   make(map[domain.Platform]int),
+		lastBatch: make(map[domain.Platform]time.Time),
+		silenced:  make(map[domain.Platform]time.Time),
+		alerted:   make(map[domain.Platform]bool),
+	}
+}
+
+// SetAlertHandler recibe las alertas de ataque de bots (el runtime las publica en el bus).
+func (s *Service) SetAlertHandler(fn func(BotAlert)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onAlert = fn
+}
+
+func (s *Service) SetSettingsRepository(repo domain.FollowSettingsRepository) {
+	s.repo = repo
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetFollowAnnounceSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = sanitizeSettings(*stored)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.FollowAnnounceSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// Update guarda y aplica la nueva configuración. Desactivar los anuncios
+// también levanta el silencio por ataque.
+func (s *Service) Update(ctx context.Context, settings domain.FollowAnnounceSettings) (domain.FollowAnnounceSettings, error) {
+	s.mu.Lock()
+	s.cfg = sanitizeSettings(settings)
+	if !s.cfg.AutoSilence {
+		s.silenced = make(map[domain.Platform]time.Time)
+	}
+	applied := s.cfg
+	s.mu.Unlock()
+
+	if s.repo != nil {
+		if err := s.repo.SetFollowAnnounceSettings(ctx, applied); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// HandleFollow guarda el follow como notificación y lo anuncia o lo acumula
+// para el próximo resumen según el ritmo actual.
+func (s *Service) HandleFollow(ctx context.Context, platform domain.Platform, username string) error {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return fmt.Errorf("follow sin usuario")
+	}
+
+	if s.emitter != nil {
+		notification, err := domain.NewNotification(string(domain.NotificationFollow), string(platform), username, 0, "", nil)
+		if err != nil {
+			return err
+		}
+		if _, err := s.emitter.Emit(ctx, notification); err != nil {
+			return fmt.Errorf("no pude guardar el follow: %w", err)
+		}
+	}
+
+	text, alert := s.observe(platform, username)
+	if alert != nil {
+		log.Printf("follows: posible ataque de bots en %s (%d follows/min)", platform, alert.Rate)
+		s.mu.Lock()
+		onAlert := s.onAlert
+		s.mu.Unlock()
+		if onAlert != nil {
+			onAlert(*alert)
+		}
+	}
+	if text == "" || s.announce == nil {
+		return nil
+	}
+	return s.announce(ctx, platform, text)
+}
+
+// observe cuenta el follow y devuelve el texto a anunciar ya mismo (vacío si
+// se acumula o está silenciado) y la alerta de bots si corresponde.
+func (s *Service) observe(platform domain.Platform, username string) (string, *BotAlert) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := s.rateLocked(platform, now, true)
+
+	var alert *BotAlert
+	if rate >= s.cfg.BotThreshold {
+		if !s.alerted[platform] {
+			s.alerted[platform] = true
+			alert = &BotAlert{Platform: platform, Rate: rate, At: now}
+			if s.cfg.AutoSilence {
+				until := now.Add(time.Duration(s.cfg.SilenceMinutes) * time.Minute)
+				s.silenced[platform] = until
+				s.pending[platform] = 0
+				alert.SilencedUntil = until
+			}
+		}
+	} else if rate < s.cfg.AnnounceBelow {
+		s.alerted[platform] = false
+	}
+
+	if !s.cfg.Enabled || s.silencedLocked(platform, now) {
+		return "", alert
+	}
+	if rate < s.cfg.AnnounceBelow && s.pending[platform] == 0 {
+		return fmt.Sprintf("💜 ¡Gracias por el follow, %s!", username), alert
+	}
+	if s.pending[platform] == 0 {
+		s.lastBatch[platform] = now
+	}
+	s.pending[platform]++
+	return "", alert
+}
+
+// Tick anuncia los resúmenes pendientes cuyo intervalo ya se cumplió. El
+// runtime lo llama periódicamente.
+func (s *Service) Tick(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	now := s.now()
+	interval := time.Duration(s.cfg.BatchIntervalSeconds) * time.Second
+	batches := make(map[domain.Platform]int)
+	for platform, count := range s.pending {
+		if count == 0 || now.Sub(s.lastBatch[platform]) < interval {
+			continue
+		}
+		s.pending[platform] = 0
+		if !s.cfg.Enabled || s.silencedLocked(platform, now) {
+			continue
+		}
+		batches[platform] = count
+	}
+	for platform := range s.hits {
+		s.rateLocked(platform, now, false)
+	}
+	s.mu.Unlock()
+
+	if s.announce == nil {
+		return
+	}
+	for platform, count := range batches {
+		text := fmt.Sprintf("💜 ¡+%d nuevos followers! Gracias a todos.", count)
+		if count == 1 {
+			text = "💜 ¡+1 nuevo follower! Gracias."
+		}
+		if err := s.announce(ctx, platform, text); err != nil {
+			log.Printf("follows: no pude anunciar el resumen en %s: %v", platform, err)
+		}
+	}
+}
+
+// SilencedUntil devuelve hasta cuándo están silenciados los anuncios de la
+// plataforma (cero si no lo están).
+func (s *Service) SilencedUntil(platform domain.Platform) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.silencedLocked(platform, s.now()) {
+		return time.Time{}
+	}
+	return s.silenced[platform]
+}
+
+func (s *Service) silencedLocked(platform domain.Platform, now time.Time) bool {
+	until, ok := s.silenced[platform]
+	if !ok {
+		return false
+	}
+	if now.Before(until) {
+		return true
+	}
+	delete(s.silenced, platform)
+	return false
+}
+
+// rateLocked limpia la ventana y devuelve los follows del último minuto,
+// contando uno nuevo si add es true.
+func (s *Service) rateLocked(platform domain.Platform, now time.Time, add bool) int {
+	hits := s.hits[platform]
+	cutoff := now.Add(-rateWindow)
+	idx := 0
+	for idx < len(hits) && !hits[idx].After(cutoff) {
+		idx++
+	}
+	hits = append(hits[:0], hits[idx:]...)
+	if add {
+		hits = append(hits, now)
+	}
+	s.hits[platform] = hits
+	return len(hits)
+}
+
+func sanitizeSettings(cfg domain.FollowAnnounceSettings) domain.FollowAnnounceSettings {
+	def := domain.DefaultFollowAnnounceSettings()
+	if cfg.AnnounceBelow <= 0 {
+		cfg.AnnounceBelow = def.AnnounceBelow
+	}
+	if cfg.BatchIntervalSeconds <= 0 {
+		cfg.BatchIntervalSeconds = def.BatchIntervalSeconds
+	}
+	if cfg.BotThreshold <= cfg.AnnounceBelow {
+		cfg.BotThreshold = def.BotThreshold
+		if cfg.BotThreshold <= cfg.AnnounceBelow {
+			cfg.BotThreshold = cfg.AnnounceBelow * 10
+		}
+	}
+	if cfg.SilenceMinutes <= 0 {
+		cfg.SilenceMinutes = def.SilenceMinutes
+	}
+	return cfg
+}
@@ -0,0 +1,245 @@
+package follows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type memoryEmitter struct {
+	mu    sync.Mutex
+	saved []*domain.Notification
+}
+
+func (e *memoryEmitter) Emit(_ context.Context, n *domain.Notification) (*domain.Notification, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.saved = append(e.saved, n)
+	return n, nil
+}
+
+type chatLog struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (c *chatLog) announce(_ context.Context, _ domain.Platform, text string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lines = append(c.lines, text)
+	return nil
+}
+
+func (c *chatLog) take() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lines := c.lines
+	c.lines = nil
+	return lines
+}
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestService(t *testing.T, cfg domain.FollowAnnounceSettings) (*Service, *memoryEmitter, *chatLog, *fakeClock) {
+	t.Helper()
+	emitter := &memoryEmitter{}
+	chat := &chatLog{}
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)}
+	svc := NewService(emitter, chat.announce)
+	svc.now = clock.Now
+	if _, err := svc.Update(context.Background(), cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return svc, emitter, chat, clock
+}
+
+func follow(t *testing.T, svc *Service, name string) {
+	t.Helper()
+	if err := svc.HandleFollow(context.Background(), domain.PlatformTwitch, name); err != nil {
+		t.Fatalf("HandleFollow(%s): %v", name, err)
+	}
+}
+
+func TestFollowsNormalRateThanksEachFollower(t *testing.T) {
+	svc, emitter, chat, clock := newTestService(t, domain.DefaultFollowAnnounceSettings())
+
+	for _, name := range []string{"ana", "beto", "carla"} {
+		follow(t, svc, name)
+		clock.Advance(20 * time.Second)
+	}
+
+	want := []string{
+		"💜 ¡Gracias por el follow, ana!",
+		"💜 ¡Gracias por el follow, beto!",
+		"💜 ¡Gracias por el follow, carla!",
+	}
+	if got := chat.take(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("chat = %q", got)
+	}
+	if len(emitter.saved) != 3 {
+		t.Fatalf("saved %d notifications, want 3", len(emitter.saved))
+	}
+	for _, n := range emitter.saved {
+		if n.Type != domain.NotificationFollow {
+			t.Fatalf("saved type %q", n.Type)
+		}
+	}
+
+	svc.Tick(context.Background())
+	if got := chat.take(); len(got) != 0 {
+		t.Fatalf("Tick without pending follows announced %q", got)
+	}
+}
+
+func TestFollowsBombIsBatched(t *testing.T) {
+	cfg := domain.FollowAnnounceSettings{Enabled: true, AnnounceBelow: 5, BatchIntervalSeconds: 30, BotThreshold: 100, SilenceMinutes: 60}
+	svc, emitter, chat, clock := newTestService(t, cfg)
+
+	for i := 1; i <= 20; i++ {
+		follow(t, svc, fmt.Sprintf("user%d", i))
+		clock.Advance(500 * time.Millisecond)
+	}
+
+	// los primeros 4 pasan antes de llegar al umbral, el resto se acumula
+	if got := chat.take(); len(got) != 4 {
+		t.Fatalf("individual thanks = %q, want 4", got)
+	}
+	if len(emitter.saved) != 20 {
+		t.Fatalf("saved %d notifications, want all 20", len(emitter.saved))
+	}
+
+	svc.Tick(context.Background())
+	if got := chat.take(); len(got) != 0 {
+		t.Fatalf("batch announced before the interval: %q", got)
+	}
+
+	clock.Advance(30 * time.Second)
+	svc.Tick(context.Background())
+	if got := chat.take(); len(got) != 1 || got[0] != "💜 ¡+16 nuevos followers! Gracias a todos." {
+		t.Fatalf("batch = %q", got)
+	}
+
+	// pasada la oleada se vuelve a agradecer de a uno
+	clock.Advance(time.Minute)
+	follow(t, svc, "tarde")
+	if got := chat.take(); len(got) != 1 || got[0] != "💜 ¡Gracias por el follow, tarde!" {
+		t.Fatalf("after the bomb = %q", got)
+	}
+}
+
+func TestFollowsBatchOfOne(t *testing.T) {
+	cfg := domain.FollowAnnounceSettings{Enabled: true, AnnounceBelow: 1, BatchIntervalSeconds: 10, BotThreshold: 100, SilenceMinutes: 60}
+	svc, _, chat, clock := newTestService(t, cfg)
+
+	follow(t, svc, "ana")
+	clock.Advance(10 * time.Second)
+	svc.Tick(context.Background())
+	if got := chat.take(); len(got) != 1 || got[0] != "💜 ¡+1 nuevo follower! Gracias." {
+		t.Fatalf("batch = %q", got)
+	}
+}
+
+func TestFollowBotAttackAlertsAndSilences(t *testing.T) {
+	cfg := domain.FollowAnnounceSettings{Enabled: true, AnnounceBelow: 5, BatchIntervalSeconds: 30, BotThreshold: 50, AutoSilence: true, SilenceMinutes: 60}
+	svc, emitter, chat, clock := newTestService(t, cfg)
+
+	var alerts []BotAlert
+	svc.SetAlertHandler(func(a BotAlert) { alerts = append(alerts, a) })
+
+	for i := 1; i <= 120; i++ {
+		follow(t, svc, fmt.Sprintf("bot%d", i))
+		clock.Advance(100 * time.Millisecond)
+	}
+	chat.take()
+
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %d, want exactly one per attack", len(alerts))
+	}
+	if alerts[0].Rate != 50 || alerts[0].Platform != domain.PlatformTwitch {
+		t.Fatalf("alert = %+v", alerts[0])
+	}
+	if want := alerts[0].At.Add(time.Hour); !alerts[0].SilencedUntil.Equal(want) {
+		t.Fatalf("SilencedUntil = %v, want %v", alerts[0].SilencedUntil, want)
+	}
+	if len(emitter.saved) != 120 {
+		t.Fatalf("saved %d notifications, want all 120", len(emitter.saved))
+	}
+
+	// lo acumulado antes del ataque se descarta y durante el silencio no se anuncia nada
+	clock.Advance(time.Minute)
+	svc.Tick(context.Background())
+	follow(t, svc, "real")
+	if got := chat.take(); len(got) != 0 {
+		t.Fatalf("announced while silenced: %q", got)
+	}
+	if svc.SilencedUntil(domain.PlatformTwitch).IsZero() {
+		t.Fatal("SilencedUntil is zero during the silence")
+	}
+
+	clock.Advance(time.Hour)
+	if !svc.SilencedUntil(domain.PlatformTwitch).IsZero() {
+		t.Fatal("still silenced after SilenceMinutes")
+	}
+	follow(t, svc, "despues")
+	if got := chat.take(); len(got) != 1 || got[0] != "💜 ¡Gracias por el follow, despues!" {
+		t.Fatalf("after the silence = %q", got)
+	}
+}
+
+func TestFollowBotAttackWithoutAutoSilence(t *testing.T) {
+	cfg := domain.FollowAnnounceSettings{Enabled: true, AnnounceBelow: 5, BatchIntervalSeconds: 30, BotThreshold: 50, SilenceMinutes: 60}
+	svc, _, chat, clock := newTestService(t, cfg)
+
+	var alerts []BotAlert
+	svc.SetAlertHandler(func(a BotAlert) { alerts = append(alerts, a) })
+
+	for i := 1; i <= 60; i++ {
+		follow(t, svc, fmt.Sprintf("bot%d", i))
+	}
+	chat.take()
+	if len(alerts) != 1 || !alerts[0].SilencedUntil.IsZero() {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+
+	clock.Advance(30 * time.Second)
+	svc.Tick(context.Background())
+	if got := chat.take(); len(got) != 1 || got[0] != "💜 ¡+56 nuevos followers! Gracias a todos." {
+		t.Fatalf("batch = %q", got)
+	}
+}
+
+func TestFollowsDisabledStillStores(t *testing.T) {
+	cfg := domain.DefaultFollowAnnounceSettings()
+	cfg.Enabled = false
+	svc, emitter, chat, _ := newTestService(t, cfg)
+
+	follow(t, svc, "ana")
+	if got := chat.take(); len(got) != 0 {
+		t.Fatalf("announced while disabled: %q", got)
+	}
+	if len(emitter.saved) != 1 {
+		t.Fatalf("saved %d notifications, want 1", len(emitter.saved))
+	}
+	if err := svc.HandleFollow(context.Background(), domain.PlatformTwitch, "  "); err == nil {
+		t.Fatal("HandleFollow accepted an empty username")
+	}
+}
+
+func TestSanitizeSettings(t *testing.T) {
+	got := sanitizeSettings(domain.FollowAnnounceSettings{AnnounceBelow: 300})
+	def := domain.DefaultFollowAnnounceSettings()
+	if got.BatchIntervalSeconds != def.BatchIntervalSeconds || got.SilenceMinutes != def.SilenceMinutes {
+		t.Fatalf("defaults not applied: %+v", got)
+	}
+	// el umbral de bots siempre queda por encima del de anuncios individuales
+	if got.BotThreshold != 3000 {
+		t.Fatalf("BotThreshold = %d, want 3000", got.BotThreshold)
+	}
+}
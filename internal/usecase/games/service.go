@@ -0,0 +1,267 @@
+// Package games tiene los juegos simples del chat (!roll, !8ball, !coinflip)
+// y la configuración que los activa por canal.
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// MaxDice y MaxSides acotan !roll para que nadie pida millones de dados.
+	MaxDice  = 20
+	MaxSides = 1000
+)
+
+var (
+	ErrInvalidDice  = errors.New("tirada inválida")
+	ErrDiceTooLarge = fmt.Errorf("como mucho %d dados de %d caras", MaxDice, MaxSides)
+	ErrUnknownGame  = errors.New("juego desconocido")
+)
+
+// Dice es una tirada en notación NdM.
+type Dice struct {
+	Count int
+	Sides int
+}
+
+// DefaultDice es lo que tira !roll sin argumentos.
+var DefaultDice = Dice{Count: 1, Sides: 20}
+
+func (d Dice) String() string {
+	return fmt.Sprintf("%dd%d", d.Count, d.Sides)
+}
+
+// ParseDice acepta «NdM», «dM» o solo «M» (un dado de M caras).
+func ParseDice(raw string) (Dice, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return DefaultDice, nil
+	}
+	countText, sidesText, found := strings.Cut(raw, "d")
+	if !found {
+		countText, sidesText = "1", raw
+	}
+	if countText == "" {
+		countText = "1"
+	}
+	count, err := parseDiceNumber(countText)
+	if err != nil {
+		return Dice{}, err
+	}
+	sides, err := parseDiceNumber(sidesText)
+	if err != nil {
+		return Dice{}, err
+	}
+	if count < 1 || sides < 2 {
+		return Dice{}, ErrInvalidDice
+	}
+	if count > MaxDice || sides > MaxSides {
+		return Dice{}, ErrDiceTooLarge
+	}
+	return Dice{Count: count, Sides: sides}, nil
+}
+
+func parseDiceNumber(text string) (int, error) {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return 0, ErrInvalidDice
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrDiceTooLarge
+		}
+		return 0, ErrInvalidDice
+	}
+	return n, nil
+}
+
+// eightBallAnswers son las respuestas de !8ball.
+var eightBallAnswers = []string{
+	"Sí, sin duda.",
+	"Es seguro.",
+	"Todo indica que sí.",
+	"Muy probablemente.",
+	"Sí.",
+	"Pregunta de nuevo más tarde.",
+	"Mejor no te lo digo ahora.",
+	"No puedo predecirlo ahora.",
+	"Concéntrate y vuelve a preguntar.",
+	"No cuentes con ello.",
+	"Mi respuesta es no.",
+	"Mis fuentes dicen que no.",
+	"Muy dudoso.",
+}
+
+type Service struct {
+	repo domain.ChatGamesSettingsRepository
+
+	mu  sync.Mutex
+	cfg domain.ChatGamesSettings
+	rng *rand.Rand
+}
+
+func NewService(repo domain.ChatGamesSettingsRepository) *Service {
+	return &Service{
+		repo: repo,
+		cfg:  domain.DefaultChatGamesSettings(),
+		// una semilla por proceso: las tiradas no se repiten entre reinicios
+		rng: rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0)),
+	}
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetChatGamesSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = sanitizeSettings(*stored)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.ChatGamesSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneSettings(s.cfg)
+}
+
+// Update guarda la configuración. Rechaza juegos desconocidos para que un
+// error de tipeo no quede guardado sin efecto.
+func (s *Service) Update(ctx context.Context, settings domain.ChatGamesSettings) (domain.ChatGamesSettings, error) {
+	for game := range settings.Enabled {
+		if !isGame(game) {
+			return domain.ChatGamesSettings{}, fmt.Errorf("%w: %q", ErrUnknownGame, game)
+		}
+	}
+	for _, channel := range settings.Channels {
+		for game := range channel {
+			if !isGame(game) {
+				return domain.ChatGamesSettings{}, fmt.Errorf("%w: %q", ErrUnknownGame, game)
+			}
+		}
+	}
+	applied := sanitizeSettings(settings)
+	if s.repo != nil {
+		if err := s.repo.SetChatGamesSettings(ctx, applied); err != nil {
+			return domain.ChatGamesSettings{}, err
+		}
+	}
+	s.mu.Lock()
+	s.cfg = applied
+	s.mu.Unlock()
+	return cloneSettings(applied), nil
+}
+
+// Allows indica si el juego está activo en el canal.
+func (s *Service) Allows(game string, platform domain.Platform, channelID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg.Allows(game, platform, channelID)
+}
+
+// Roll tira los dados y devuelve cada resultado y la suma.
+func (s *Service) Roll(dice Dice) ([]int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rolls := make([]int, dice.Count)
+	total := 0
+	for i := range rolls {
+		rolls[i] = s.rng.IntN(dice.Sides) + 1
+		total += rolls[i]
+	}
+	return rolls, total
+}
+
+func (s *Service) EightBall() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return eightBallAnswers[s.rng.IntN(len(eightBallAnswers))]
+}
+
+// Coinflip devuelve true para cara.
+func (s *Service) Coinflip() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.IntN(2) == 0
+}
+
+func isGame(game string) bool {
+	for _, known := range domain.ChatGames() {
+		if game == known {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeSettings completa los juegos que falten (activos) y normaliza las
+// claves de canal.
+func sanitizeSettings(settings domain.ChatGamesSettings) domain.ChatGamesSettings {
+	out := domain.DefaultChatGamesSettings()
+	for game, enabled := range settings.Enabled {
+		if isGame(game) {
+			out.Enabled[game] = enabled
+		}
+	}
+	for key, channel := range settings.Channels {
+		if platform, channelID, ok := strings.Cut(key, ":"); ok {
+			key = domain.ChatGamesChannelKey(domain.Platform(strings.TrimSpace(platform)), channelID)
+		} else {
+			key = ""
+		}
+		games := make(map[string]bool, len(channel))
+		for game, enabled := range channel {
+			if isGame(game) {
+				games[game] = enabled
+			}
+		}
+		if key == "" || len(games) == 0 {
+			continue
+		}
+		if out.Channels == nil {
+			out.Channels = make(map[string]map[string]bool)
+		}
+		out.Channels[key] = games
+	}
+	return out
+}
+
+func cloneSettings(settings domain.ChatGamesSettings) domain.ChatGamesSettings {
+	out := domain.ChatGamesSettings{Enabled: make(map[string]bool, len(settings.Enabled))}
+	for game, enabled := range settings.Enabled {
+		out.Enabled[game] = enabled
+	}
+	for key, channel := range settings.Channels {
+		if out.Channels == nil {
+			out.Channels = make(map[string]map[string]bool, len(settings.Channels))
+		}
+		games := make(map[string]bool, len(channel))
+		for game, enabled := range channel {
+			games[game] = enabled
+		}
+		out.Channels[key] = games
+	}
+	return out
+}
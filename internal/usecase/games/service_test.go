@@ -0,0 +1,150 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestParseDice(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Dice
+		err  error
+	}{
+		{"", DefaultDice, nil},
+		{"  ", DefaultDice, nil},
+		{"2d6", Dice{Count: 2, Sides: 6}, nil},
+		{"D20", Dice{Count: 1, Sides: 20}, nil},
+		{"d100", Dice{Count: 1, Sides: 100}, nil},
+		{"12", Dice{Count: 1, Sides: 12}, nil},
+		{"20d1000", Dice{Count: MaxDice, Sides: MaxSides}, nil},
+		{"21d6", Dice{}, ErrDiceTooLarge},
+		{"1d1001", Dice{}, ErrDiceTooLarge},
+		{"9999999d9999999", Dice{}, ErrDiceTooLarge},
+		{"99999999999999999999d6", Dice{}, ErrDiceTooLarge},
+		{"0d6", Dice{}, ErrInvalidDice},
+		{"2d1", Dice{}, ErrInvalidDice},
+		{"2d", Dice{}, ErrInvalidDice},
+		{"-1d6", Dice{}, ErrInvalidDice},
+		{"2d-6", Dice{}, ErrInvalidDice},
+		{"2x6", Dice{}, ErrInvalidDice},
+		{"d6d6", Dice{}, ErrInvalidDice},
+		{"+3", Dice{}, ErrInvalidDice},
+		{"１d6", Dice{}, ErrInvalidDice},
+	}
+	for _, tt := range tests {
+		got, err := ParseDice(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("ParseDice(%q) = %v, %v; esperaba %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestRollStaysInRange(t *testing.T) {
+	svc := NewService(nil)
+	dice := Dice{Count: 3, Sides: 4}
+	for range 200 {
+		rolls, total := svc.Roll(dice)
+		if len(rolls) != 3 {
+			t.Fatalf("rolls = %v", rolls)
+		}
+		sum := 0
+		for _, roll := range rolls {
+			if roll < 1 || roll > 4 {
+				t.Fatalf("un d4 sacó %d", roll)
+			}
+			sum += roll
+		}
+		if sum != total {
+			t.Fatalf("total = %d, la suma es %d", total, sum)
+		}
+	}
+}
+
+// memorySettings hace de la fila chat_games de settings.
+type memorySettings struct {
+	saved *domain.ChatGamesSettings
+}
+
+func (m *memorySettings) GetChatGamesSettings(context.Context) (*domain.ChatGamesSettings, error) {
+	return m.saved, nil
+}
+
+func (m *memorySettings) SetChatGamesSettings(_ context.Context, settings domain.ChatGamesSettings) error {
+	m.saved = &settings
+	return nil
+}
+
+func TestPerChannelToggles(t *testing.T) {
+	ctx := context.Background()
+	repo := &memorySettings{}
+	svc := NewService(repo)
+
+	for _, game := range domain.ChatGames() {
+		if !svc.Allows(game, domain.PlatformTwitch, "zero") {
+			t.Fatalf("%s arranca desactivado", game)
+		}
+	}
+
+	_, err := svc.Update(ctx, domain.ChatGamesSettings{
+		Enabled: map[string]bool{domain.GameEightBall: false},
+		Channels: map[string]map[string]bool{
+			" Twitch:#Zero":  {domain.GameRoll: false, domain.GameEightBall: true},
+			"sin-plataforma": {domain.GameCoinflip: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		game     string
+		platform domain.Platform
+		channel  string
+		want     bool
+	}{
+		{domain.GameRoll, domain.PlatformTwitch, "zero", false},
+		{domain.GameRoll, domain.PlatformTwitch, "otro", true},
+		{domain.GameRoll, domain.PlatformKick, "zero", true},
+		{domain.GameEightBall, domain.PlatformTwitch, "#ZERO", true},
+		{domain.GameEightBall, domain.PlatformKick, "123", false},
+		{domain.GameCoinflip, domain.PlatformTwitch, "zero", true},
+	}
+	for _, tt := range tests {
+		if got := svc.Allows(tt.game, tt.platform, tt.channel); got != tt.want {
+			t.Errorf("Allows(%s, %s:%s) = %v, esperaba %v", tt.game, tt.platform, tt.channel, got, tt.want)
+		}
+	}
+
+	// se guarda normalizado y sobrevive un reinicio
+	restarted := NewService(repo)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	settings := restarted.Settings()
+	if len(settings.Channels) != 1 || settings.Channels["twitch:zero"] == nil {
+		t.Fatalf("canales guardados = %+v", settings.Channels)
+	}
+	if restarted.Allows(domain.GameRoll, domain.PlatformTwitch, "zero") {
+		t.Fatal("el toggle por canal no sobrevivió el reinicio")
+	}
+}
+
+func TestUpdateRejectsUnknownGames(t *testing.T) {
+	repo := &memorySettings{}
+	svc := NewService(repo)
+	if _, err := svc.Update(context.Background(), domain.ChatGamesSettings{Enabled: map[string]bool{"dados": false}}); !errors.Is(err, ErrUnknownGame) {
+		t.Fatalf("Update = %v, esperaba ErrUnknownGame", err)
+	}
+	if _, err := svc.Update(context.Background(), domain.ChatGamesSettings{
+		Channels: map[string]map[string]bool{"twitch:zero": {"ruleta": true}},
+	}); !errors.Is(err, ErrUnknownGame) {
+		t.Fatalf("Update por canal = %v, esperaba ErrUnknownGame", err)
+	}
+	if repo.saved != nil {
+		t.Fatal("se guardó una configuración rechazada")
+	}
+}
@@ -0,0 +1,288 @@
+// Package greetings detecta a quien vuelve al chat después de una ausencia
+// larga y, si está activado, lo saluda una vez por sesión de stream.
+package greetings
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// SessionFunc devuelve el ID de la sesión de stream actual ("" si se desconoce).
+type SessionFunc func(ctx context.Context) string
+
+const (
+	flushInterval    = time.Second
+	maxThresholdDays = 3650
+	day              = 24 * time.Hour
+)
+
+// ReturningViewerDTO describe a un usuario que volvió después de la ausencia
+// configurada. Greeting es el saludo enviado ("" si el saludo está apagado).
+type ReturningViewerDTO struct {
+	Platform      string `json:"platform"`
+	ChannelID     string `json:"channel_id"`
+	UserID        string `json:"user_id"`
+	User          string `json:"user"`
+	Login         string `json:"login"`
+	Days          int    `json:"days"`
+	AbsentSeconds int64  `json:"absent_seconds"`
+	LastSeen      string `json:"last_seen"`
+	Greeting      string `json:"greeting,omitempty"`
+	At            string `json:"at"`
+}
+
+type userKey struct {
+	platform domain.Platform
+	userID   string
+}
+
+type Service struct {
+	repo  domain.GreetingSettingsRepository
+	users domain.ChatUserRepository
+
+	mu  sync.Mutex
+	cfg domain.GreetingSettings
+	// lastSeen guarda la última vez que escribió cada usuario ya consultado;
+	// dirty son los que hay que persistir en el próximo Flush.
+	lastSeen map[userKey]domain.ChatUser
+	dirty    map[userKey]struct{}
+	// greeted son los que ya volvieron en esta sesión de stream.
+	greeted   map[userKey]bool
+	session   SessionFunc
+	sessionID string
+	handler   func(ReturningViewerDTO)
+	now       func() time.Time
+}
+
+func NewService(repo domain.GreetingSettingsRepository, users domain.ChatUserRepository) *Service {
+	return &Service{
+		repo:     repo,
+		users:    users,
+		cfg:      domain.DefaultGreetingSettings(),
+		lastSeen: make(map[userKey]domain.ChatUser),
+		dirty:    make(map[userKey]struct{}),
+		greeted:  make(map[userKey]bool),
+		now:      time.Now,
+	}
+}
+
+// SetSessionFunc hace que cada usuario se pueda saludar de nuevo cuando
+// empieza otra sesión de stream.
+func (s *Service) SetSessionFunc(fn SessionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = fn
+}
+
+// SetReturningHandler recibe cada usuario que vuelve, haya saludo o no.
+func (s *Service) SetReturningHandler(fn func(ReturningViewerDTO)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = fn
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetGreetingSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = sanitizeSettings(*stored)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.GreetingSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// Update guarda y aplica la configuración; la plantilla vacía vuelve a la de
+// fábrica y el umbral fuera de rango al de 30 días.
+func (s *Service) Update(ctx context.Context, settings domain.GreetingSettings) (domain.GreetingSettings, error) {
+	applied := sanitizeSettings(settings)
+	s.mu.Lock()
+	s.cfg = applied
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetGreetingSettings(ctx, applied); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// Observe anota que el autor escribió y, si su mensaje anterior es más viejo
+// que el umbral y todavía no volvió en esta sesión, avisa al handler.
+// Devuelve el saludo a enviar ("" si no hay).
+func (s *Service) Observe(ctx context.Context, msg domain.Message) string {
+	key := keyFor(msg)
+	if key.userID == "" {
+		return ""
+	}
+	login := msg.LoginName()
+	name := strings.TrimSpace(msg.Name())
+	if name == "" {
+		name = login
+	}
+
+	s.mu.Lock()
+	prev, known := s.lastSeen[key]
+	s.mu.Unlock()
+	if !known && s.users != nil {
+		// primer mensaje desde que arrancó el bot: se consulta fuera del lock
+		seen, err := s.users.GetChatUserLastSeen(ctx, key.platform, key.userID)
+		if err != nil {
+			log.Printf("greetings: no pude leer la última visita de %s: %v", login, err)
+		}
+		prev.LastSeen = seen
+	}
+
+	s.mu.Lock()
+	s.syncSessionLocked(ctx)
+	now := s.now()
+	if cached, ok := s.lastSeen[key]; ok && !known {
+		// otro mensaje del mismo usuario llegó mientras se consultaba
+		prev = cached
+	}
+	s.lastSeen[key] = domain.ChatUser{Platform: key.platform, UserID: key.userID, Name: name, LastSeen: now}
+	s.dirty[key] = struct{}{}
+
+	if s.greeted[key] || msg.IsPlatformOwner || prev.LastSeen.IsZero() {
+		s.mu.Unlock()
+		return ""
+	}
+	absence := now.Sub(prev.LastSeen)
+	if !returning(absence, s.cfg.ThresholdDays) {
+		s.mu.Unlock()
+		return ""
+	}
+	s.greeted[key] = true
+	days := int(absence / day)
+	var text string
+	if s.cfg.Enabled {
+		text = renderTemplate(s.cfg.Template, name, days)
+	}
+	handler := s.handler
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler(ReturningViewerDTO{
+			Platform:      string(msg.Platform),
+			ChannelID:     msg.ChannelID,
+			UserID:        key.userID,
+			User:          name,
+			Login:         login,
+			Days:          days,
+			AbsentSeconds: int64(absence / time.Second),
+			LastSeen:      prev.LastSeen.UTC().Format(time.RFC3339),
+			Greeting:      text,
+			At:            now.UTC().Format(time.RFC3339),
+		})
+	}
+	return text
+}
+
+// Reset olvida a quién se saludó: cada usuario puede volver a ser saludado.
+func (s *Service) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.greeted = make(map[userKey]bool)
+}
+
+// Run guarda las visitas pendientes como mucho una vez por segundo.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			s.Flush(ctx)
+		}
+	}
+}
+
+// Flush persiste la última visita de los usuarios que escribieron.
+func (s *Service) Flush(ctx context.Context) {
+	s.mu.Lock()
+	changed := make([]domain.ChatUser, 0, len(s.dirty))
+	for key := range s.dirty {
+		changed = append(changed, s.lastSeen[key])
+	}
+	s.dirty = make(map[userKey]struct{})
+	s.mu.Unlock()
+
+	if s.users == nil || len(changed) == 0 {
+		return
+	}
+	if err := s.users.SaveChatUsers(ctx, changed); err != nil {
+		log.Printf("greetings: no pude guardar las visitas: %v", err)
+	}
+}
+
+// syncSessionLocked olvida a quién se saludó si la sesión de stream cambió.
+func (s *Service) syncSessionLocked(ctx context.Context) {
+	if s.session == nil {
+		return
+	}
+	current := s.session(ctx)
+	if current == "" || current == s.sessionID {
+		return
+	}
+	if s.sessionID != "" {
+		s.greeted = make(map[userKey]bool)
+	}
+	s.sessionID = current
+}
+
+// returning indica si la ausencia llega al umbral; justo el umbral cuenta.
+func returning(absence time.Duration, thresholdDays int) bool {
+	return absence >= time.Duration(thresholdDays)*day
+}
+
+func sanitizeSettings(settings domain.GreetingSettings) domain.GreetingSettings {
+	defaults := domain.DefaultGreetingSettings()
+	settings.Template = strings.TrimSpace(settings.Template)
+	if settings.Template == "" {
+		settings.Template = defaults.Template
+	}
+	if settings.ThresholdDays <= 0 || settings.ThresholdDays > maxThresholdDays {
+		settings.ThresholdDays = defaults.ThresholdDays
+	}
+	return settings
+}
+
+func keyFor(msg domain.Message) userKey {
+	user := strings.TrimSpace(msg.UserID)
+	if user == "" {
+		user = msg.LoginName()
+	}
+	return userKey{platform: msg.Platform, userID: user}
+}
+
+func renderTemplate(template, user string, days int) string {
+	return strings.NewReplacer(
+		"{user}", user,
+		"{days}", strconv.Itoa(days),
+	).Replace(template)
+}
@@ -0,0 +1,178 @@
+package greetings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryUsers hace de la tabla chat_users.
+type memoryUsers struct {
+	seen  map[userKey]time.Time
+	saves int
+}
+
+func (m *memoryUsers) GetChatUserLastSeen(_ context.Context, platform domain.Platform, userID string) (time.Time, error) {
+	return m.seen[userKey{platform: platform, userID: userID}], nil
+}
+
+func (m *memoryUsers) SaveChatUsers(_ context.Context, users []domain.ChatUser) error {
+	for _, user := range users {
+		m.seen[userKey{platform: user.Platform, userID: user.UserID}] = user.LastSeen
+	}
+	m.saves++
+	return nil
+}
+
+var start = time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+
+func chatter(userID, name string) domain.Message {
+	return domain.Message{Platform: domain.PlatformTwitch, ChannelID: "canal", UserID: userID, Username: name, Login: name, Text: "hola"}
+}
+
+func newTestService(t *testing.T, lastSeen map[string]time.Time) (*Service, *memoryUsers, *[]ReturningViewerDTO) {
+	t.Helper()
+	users := &memoryUsers{seen: make(map[userKey]time.Time)}
+	for userID, at := range lastSeen {
+		users.seen[userKey{platform: domain.PlatformTwitch, userID: userID}] = at
+	}
+	svc := NewService(nil, users)
+	svc.now = func() time.Time { return start }
+	var returned []ReturningViewerDTO
+	svc.SetReturningHandler(func(dto ReturningViewerDTO) { returned = append(returned, dto) })
+	if _, err := svc.Update(context.Background(), domain.GreetingSettings{Enabled: true, ThresholdDays: 30, Template: "hola {user}, {days} días"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return svc, users, &returned
+}
+
+func TestThresholdBoundary(t *testing.T) {
+	ctx := context.Background()
+	svc, _, returned := newTestService(t, map[string]time.Time{
+		"justo":   start.Add(-30 * day),
+		"casi":    start.Add(-30*day + time.Second),
+		"lejos":   start.Add(-45 * day),
+		"nuevo":   {},
+		"seguido": start.Add(-time.Hour),
+	})
+
+	tests := []struct {
+		userID string
+		want   string
+	}{
+		{"justo", "hola justo, 30 días"},
+		{"casi", ""},
+		{"lejos", "hola lejos, 45 días"},
+		{"nuevo", ""},
+		{"seguido", ""},
+	}
+	for _, tt := range tests {
+		if got := svc.Observe(ctx, chatter(tt.userID, tt.userID)); got != tt.want {
+			t.Errorf("%s: saludo = %q, esperaba %q", tt.userID, got, tt.want)
+		}
+	}
+	if len(*returned) != 2 {
+		t.Fatalf("avisos = %+v, esperaba 2", *returned)
+	}
+	got := (*returned)[0]
+	if got.User != "justo" || got.Days != 30 || got.AbsentSeconds != int64(30*day/time.Second) || got.Greeting != "hola justo, 30 días" {
+		t.Fatalf("aviso = %+v", got)
+	}
+
+	// el dueño del canal nunca se saluda
+	owner := chatter("dueño", "dueño")
+	owner.IsPlatformOwner = true
+	svc2, _, returned2 := newTestService(t, map[string]time.Time{"dueño": start.Add(-60 * day)})
+	if got := svc2.Observe(ctx, owner); got != "" || len(*returned2) != 0 {
+		t.Fatalf("se saludó al dueño: %q", got)
+	}
+}
+
+func TestDisabledStillPublishes(t *testing.T) {
+	ctx := context.Background()
+	svc, _, returned := newTestService(t, map[string]time.Time{"ana": start.Add(-40 * day)})
+	settings := svc.Settings()
+	settings.Enabled = false
+	svc.Update(ctx, settings)
+
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "" {
+		t.Fatalf("apagado saludó: %q", got)
+	}
+	if len(*returned) != 1 || (*returned)[0].Greeting != "" {
+		t.Fatalf("avisos = %+v, esperaba uno sin saludo", *returned)
+	}
+}
+
+func TestGreetedOncePerSession(t *testing.T) {
+	ctx := context.Background()
+	svc, users, _ := newTestService(t, map[string]time.Time{"ana": start.Add(-40 * day)})
+	session := "stream-1"
+	svc.SetSessionFunc(func(context.Context) string { return session })
+
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got == "" {
+		t.Fatal("no saludó a quien volvió")
+	}
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "" {
+		t.Fatalf("saludó dos veces en la misma sesión: %q", got)
+	}
+
+	// otra sesión: vuelve a poder saludarse, pero su última visita ya es de hoy
+	session = "stream-2"
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "" {
+		t.Fatalf("saludó sin ausencia: %q", got)
+	}
+
+	// cuarenta días después, en una sesión nueva, se saluda de nuevo
+	svc.Flush(ctx)
+	if users.saves != 1 || !users.seen[userKey{platform: domain.PlatformTwitch, userID: "ana"}].Equal(start) {
+		t.Fatalf("Flush no guardó la visita: %+v", users.seen)
+	}
+	later := start.Add(40 * day)
+	svc.now = func() time.Time { return later }
+	session = "stream-3"
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "hola ana, 40 días" {
+		t.Fatalf("sesión nueva = %q", got)
+	}
+}
+
+func TestResetForgetsGreeted(t *testing.T) {
+	ctx := context.Background()
+	svc, _, returned := newTestService(t, map[string]time.Time{"ana": start.Add(-40 * day)})
+	now := start
+	svc.now = func() time.Time { return now }
+
+	svc.Observe(ctx, chatter("ana", "ana"))
+	// sin sesión conocida, lo saludado dura hasta el Reset
+	now = now.Add(40 * day)
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "" {
+		t.Fatalf("saludó dos veces sin Reset: %q", got)
+	}
+	svc.Reset()
+	now = now.Add(40 * day)
+	if got := svc.Observe(ctx, chatter("ana", "ana")); got != "hola ana, 40 días" {
+		t.Fatalf("después del Reset = %q", got)
+	}
+	if len(*returned) != 2 {
+		t.Fatalf("avisos = %d, esperaba 2", len(*returned))
+	}
+}
+
+func TestSanitizeSettings(t *testing.T) {
+	defaults := domain.DefaultGreetingSettings()
+	tests := []struct {
+		in   domain.GreetingSettings
+		want domain.GreetingSettings
+	}{
+		{domain.GreetingSettings{}, defaults},
+		{domain.GreetingSettings{ThresholdDays: -1, Template: "  "}, defaults},
+		{domain.GreetingSettings{ThresholdDays: maxThresholdDays + 1}, defaults},
+		{domain.GreetingSettings{Enabled: true, ThresholdDays: 7, Template: " hola {user} "}, domain.GreetingSettings{Enabled: true, ThresholdDays: 7, Template: "hola {user}"}},
+	}
+	for _, tt := range tests {
+		if got := sanitizeSettings(tt.in); got != tt.want {
+			t.Errorf("sanitizeSettings(%+v) = %+v, esperaba %+v", tt.in, got, tt.want)
+		}
+	}
+}
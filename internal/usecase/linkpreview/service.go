@@ -0,0 +1,451 @@
+// Package linkpreview arma las vistas previas de los links que se pegan en
+// el chat. Como los perfiles, las consultas son asíncronas: el mensaje sale
+// sin vista previa y, cuando llega, se avisa con un Event que referencia el
+// mensaje original.
+package linkpreview
+
+import (
+	"container/list"
+	"context"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	DefaultCapacity = 500
+	DefaultWorkers  = 2
+
+	queueSize = 128
+	// maxLinksPerMessage evita que un mensaje lleno de links ocupe la cola.
+	maxLinksPerMessage = 3
+	previewTTL         = time.Hour
+	// failureTTL evita volver a pedir en cada mensaje un link que falló.
+	failureTTL = 10 * time.Minute
+	// domainInterval es el tiempo mínimo entre dos consultas al mismo dominio.
+	domainInterval = 2 * time.Second
+	fetchTimeout   = 5 * time.Second
+)
+
+// Event se publica como chat:link-preview cuando llega la vista previa de
+// un link del mensaje MessageID.
+type Event struct {
+	MessageID string             `json:"message_id"`
+	Platform  string             `json:"platform"`
+	ChannelID string             `json:"channel_id"`
+	Preview   domain.LinkPreview `json:"preview"`
+}
+
+// Stats resume el estado del caché para /api/health.
+type Stats struct {
+	Enabled  bool   `json:"enabled"`
+	Entries  int    `json:"entries"`
+	Pending  int    `json:"pending"`
+	Hits     uint64 `json:"hits"`
+	Misses   uint64 `json:"misses"`
+	Failures uint64 `json:"failures"`
+	Dropped  uint64 `json:"dropped"`
+}
+
+type messageRef struct {
+	id        string
+	platform  domain.Platform
+	channelID string
+}
+
+type entry struct {
+	url     string
+	preview domain.LinkPreview
+	failed  bool
+	expires time.Time
+}
+
+type job struct {
+	url    string
+	domain string
+}
+
+type Service struct {
+	fetcher  domain.LinkPreviewFetcher
+	repo     domain.LinkPreviewSettingsRepository
+	capacity int
+	jobs     chan job
+
+	mu        sync.Mutex
+	cfg       domain.LinkPreviewSettings
+	order     *list.List
+	entries   map[string]*list.Element
+	pending   map[string][]messageRef
+	nextFetch map[string]time.Time
+	stats     Stats
+	now       func() time.Time
+	onPreview func(Event)
+}
+
+// NewService crea el servicio con un caché LRU de capacity links.
+func NewService(fetcher domain.LinkPreviewFetcher, repo domain.LinkPreviewSettingsRepository, capacity int) *Service {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Service{
+		fetcher:   fetcher,
+		repo:      repo,
+		capacity:  capacity,
+		jobs:      make(chan job, queueSize),
+		cfg:       normalizeSettings(domain.DefaultLinkPreviewSettings()),
+		order:     list.New(),
+		entries:   make(map[string]*list.Element),
+		pending:   make(map[string][]messageRef),
+		nextFetch: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetLinkPreviewSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = normalizeSettings(*stored)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.LinkPreviewSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneSettings(s.cfg)
+}
+
+// Update guarda y aplica la configuración. Los dominios se normalizan
+// ("https://www.youtube.com/" queda "youtube.com").
+func (s *Service) Update(ctx context.Context, settings domain.LinkPreviewSettings) (domain.LinkPreviewSettings, error) {
+	settings = normalizeSettings(settings)
+	s.mu.Lock()
+	s.cfg = settings
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetLinkPreviewSettings(ctx, settings); err != nil {
+			return cloneSettings(settings), err
+		}
+	}
+	return cloneSettings(settings), nil
+}
+
+// SetPreviewHandler recibe cada vista previa lista (el runtime la publica
+// como chat:link-preview).
+func (s *Service) SetPreviewHandler(fn func(Event)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onPreview = fn
+}
+
+// Stats devuelve el estado del caché.
+func (s *Service) Stats() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Enabled = s.cfg.Enabled
+	stats.Entries = s.order.Len()
+	stats.Pending = len(s.pending)
+	return stats
+}
+
+// Run atiende la cola de consultas hasta que se cancele ctx. El runtime
+// arranca DefaultWorkers goroutines con Run.
+func (s *Service) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-s.jobs:
+			s.process(ctx, j)
+		}
+	}
+}
+
+// Observe busca links permitidos en el mensaje. Los que están en caché se
+// avisan enseguida y el resto se encola; nunca bloquea.
+func (s *Service) Observe(msg domain.Message) {
+	if s == nil || s.fetcher == nil || msg.ID == "" {
+		return
+	}
+	ref := messageRef{id: msg.ID, platform: msg.Platform, channelID: msg.ChannelID}
+
+	s.mu.Lock()
+	if !s.cfg.Enabled {
+		s.mu.Unlock()
+		return
+	}
+	var ready []Event
+	for _, raw := range extractLinks(msg.Text) {
+		link := CleanURL(raw)
+		if link == "" {
+			continue
+		}
+		matched := s.allowedDomainLocked(hostOf(link))
+		if matched == "" {
+			continue
+		}
+		if el, ok := s.entries[link]; ok {
+			e := el.Value.(*entry)
+			if s.now().Before(e.expires) {
+				s.order.MoveToFront(el)
+				if !e.failed {
+					s.stats.Hits++
+					ready = append(ready, newEvent(ref, e.preview))
+				}
+				continue
+			}
+		}
+		if waiting, ok := s.pending[link]; ok {
+			s.pending[link] = append(waiting, ref)
+			continue
+		}
+		s.stats.Misses++
+		select {
+		case s.jobs <- job{url: link, domain: matched}:
+			s.pending[link] = []messageRef{ref}
+		default:
+			// cola llena: se reintenta la próxima vez que alguien lo pegue
+			s.stats.Dropped++
+		}
+	}
+	onPreview := s.onPreview
+	s.mu.Unlock()
+
+	if onPreview != nil {
+		for _, event := range ready {
+			onPreview(event)
+		}
+	}
+}
+
+// Allowed indica si host está en la lista de dominios permitidos.
+func (s *Service) Allowed(host string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.allowedDomainLocked(host) != ""
+}
+
+func (s *Service) process(ctx context.Context, j job) {
+	if err := s.waitTurn(ctx, j.domain); err != nil {
+		s.mu.Lock()
+		delete(s.pending, j.url)
+		s.mu.Unlock()
+		return
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	preview, err := s.fetcher.FetchLinkPreview(callCtx, j.url, s.Allowed)
+	cancel()
+
+	s.mu.Lock()
+	waiting := s.pending[j.url]
+	delete(s.pending, j.url)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("linkpreview: no pude obtener %s: %v", j.url, err)
+		}
+		s.stats.Failures++
+		s.putLocked(&entry{url: j.url, failed: true, expires: s.now().Add(failureTTL)})
+		s.mu.Unlock()
+		return
+	}
+	stored := *preview
+	stored.URL = j.url
+	stored.Domain = hostOf(j.url)
+	if stored.FetchedAt.IsZero() {
+		stored.FetchedAt = s.now()
+	}
+	s.putLocked(&entry{url: j.url, preview: stored, expires: s.now().Add(previewTTL)})
+	onPreview := s.onPreview
+	s.mu.Unlock()
+
+	if onPreview != nil {
+		for _, ref := range waiting {
+			onPreview(newEvent(ref, stored))
+		}
+	}
+}
+
+// waitTurn reserva el próximo turno del dominio y espera hasta que llegue.
+func (s *Service) waitTurn(ctx context.Context, domainName string) error {
+	s.mu.Lock()
+	now := s.now()
+	turn := s.nextFetch[domainName]
+	if turn.Before(now) {
+		turn = now
+	}
+	s.nextFetch[domainName] = turn.Add(domainInterval)
+	s.mu.Unlock()
+
+	wait := turn.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (s *Service) putLocked(e *entry) {
+	if el, ok := s.entries[e.url]; ok {
+		el.Value = e
+		s.order.MoveToFront(el)
+		return
+	}
+	s.entries[e.url] = s.order.PushFront(e)
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*entry).url)
+	}
+}
+
+// allowedDomainLocked devuelve el dominio de la lista que cubre host, o "".
+func (s *Service) allowedDomainLocked(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return ""
+	}
+	for _, allowed := range s.cfg.Domains {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return allowed
+		}
+	}
+	return ""
+}
+
+func newEvent(ref messageRef, preview domain.LinkPreview) Event {
+	return Event{
+		MessageID: ref.id,
+		Platform:  string(ref.platform),
+		ChannelID: ref.channelID,
+		Preview:   preview,
+	}
+}
+
+var linkRe = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)
+
+// extractLinks devuelve hasta maxLinksPerMessage links distintos del texto.
+func extractLinks(text string) []string {
+	matches := linkRe.FindAllString(text, -1)
+	out := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		match = strings.TrimRight(match, ".,;:!?)]}'")
+		if seen[match] {
+			continue
+		}
+		seen[match] = true
+		out = append(out, match)
+		if len(out) == maxLinksPerMessage {
+			break
+		}
+	}
+	return out
+}
+
+// trackingParams son los parámetros que se sacan del link antes de mostrarlo
+// y de usarlo como clave del caché.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"si":      true,
+	"feature": true,
+	"ref":     true,
+	"ref_src": true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+// CleanURL normaliza el link y le quita los parámetros de tracking (utm_* y
+// los de trackingParams). Devuelve "" si no es un link http(s) válido.
+func CleanURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ""
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.User = nil
+	parsed.Fragment = ""
+	query := parsed.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func hostOf(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+func normalizeSettings(settings domain.LinkPreviewSettings) domain.LinkPreviewSettings {
+	domains := make([]string, 0, len(settings.Domains))
+	seen := make(map[string]bool, len(settings.Domains))
+	for _, raw := range settings.Domains {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if i := strings.Index(name, "://"); i >= 0 {
+			name = name[i+3:]
+		}
+		if i := strings.IndexAny(name, "/?#"); i >= 0 {
+			name = name[:i]
+		}
+		name = strings.TrimPrefix(strings.Trim(name, "."), "www.")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		domains = append(domains, name)
+	}
+	settings.Domains = domains
+	return settings
+}
+
+func cloneSettings(settings domain.LinkPreviewSettings) domain.LinkPreviewSettings {
+	settings.Domains = append([]string(nil), settings.Domains...)
+	return settings
+}
@@ -0,0 +1,249 @@
+// Package lurkers lleva en memoria quién avisó con !lurk que sigue el stream
+// sin escribir.
+package lurkers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// SessionFunc devuelve el ID de la sesión de stream actual ("" si se desconoce).
+type SessionFunc func(ctx context.Context) string
+
+type LurkerDTO struct {
+	Platform string `json:"platform"`
+	User     string `json:"user"`
+	Since    string `json:"since"`
+}
+
+type ListDTO struct {
+	Count   int         `json:"count"`
+	Lurkers []LurkerDTO `json:"lurkers"`
+}
+
+type lurker struct {
+	platform domain.Platform
+	name     string
+	since    time.Time
+}
+
+type Service struct {
+	repo domain.LurkSettingsRepository
+
+	mu        sync.Mutex
+	cfg       domain.LurkSettings
+	lurkers   map[string]lurker
+	session   SessionFunc
+	sessionID string
+	now       func() time.Time
+}
+
+func NewService(repo domain.LurkSettingsRepository) *Service {
+	return &Service{
+		repo:    repo,
+		cfg:     domain.DefaultLurkSettings(),
+		lurkers: make(map[string]lurker),
+		now:     time.Now,
+	}
+}
+
+// SetSessionFunc hace que la lista se vacíe cuando empieza otra sesión de stream.
+func (s *Service) SetSessionFunc(fn SessionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = fn
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetLurkSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.cfg = sanitizeSettings(*stored)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.LurkSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// Update guarda y aplica la configuración; las plantillas vacías vuelven a las de fábrica.
+func (s *Service) Update(ctx context.Context, settings domain.LurkSettings) (domain.LurkSettings, error) {
+	applied := sanitizeSettings(settings)
+	s.mu.Lock()
+	s.cfg = applied
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetLurkSettings(ctx, applied); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// Lurk marca al autor del mensaje como lurker y devuelve la respuesta.
+func (s *Service) Lurk(ctx context.Context, msg domain.Message) string {
+	key := lurkerKey(msg)
+	if key == "" {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncSessionLocked(ctx)
+	s.lurkers[key] = lurker{platform: msg.Platform, name: msg.Name(), since: s.now()}
+	return renderTemplate(s.cfg.LurkTemplate, msg.Name(), 0)
+}
+
+// Unlurk saca al usuario de la lista y devuelve la bienvenida (ok=false si no estaba).
+func (s *Service) Unlurk(ctx context.Context, msg domain.Message) (string, bool) {
+	key := lurkerKey(msg)
+	if key == "" {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncSessionLocked(ctx)
+	entry, ok := s.lurkers[key]
+	if !ok {
+		return "", false
+	}
+	delete(s.lurkers, key)
+	return renderTemplate(s.cfg.UnlurkTemplate, msg.Name(), s.now().Sub(entry.since)), true
+}
+
+// Observe aplica el auto-unlurk: si está activo y el autor estaba lurkeando,
+// devuelve la bienvenida. El runtime no le pasa los comandos.
+func (s *Service) Observe(ctx context.Context, msg domain.Message) string {
+	s.mu.Lock()
+	auto := s.cfg.AutoUnlurk
+	s.mu.Unlock()
+	if !auto {
+		return ""
+	}
+	text, _ := s.Unlurk(ctx, msg)
+	return text
+}
+
+// List devuelve los lurkers de la sesión actual, del más antiguo al más nuevo.
+func (s *Service) List(ctx context.Context) ListDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncSessionLocked(ctx)
+
+	out := ListDTO{Lurkers: make([]LurkerDTO, 0, len(s.lurkers))}
+	for _, entry := range s.lurkers {
+		out.Lurkers = append(out.Lurkers, LurkerDTO{
+			Platform: string(entry.platform),
+			User:     entry.name,
+			Since:    entry.since.UTC().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(out.Lurkers, func(i, j int) bool { return out.Lurkers[i].Since < out.Lurkers[j].Since })
+	out.Count = len(out.Lurkers)
+	return out
+}
+
+// Count devuelve cuántos lurkers hay en la sesión actual.
+func (s *Service) Count(ctx context.Context) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.syncSessionLocked(ctx)
+	return len(s.lurkers)
+}
+
+// Reset vacía la lista.
+func (s *Service) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lurkers = make(map[string]lurker)
+}
+
+// syncSessionLocked vacía la lista si la sesión de stream cambió.
+func (s *Service) syncSessionLocked(ctx context.Context) {
+	if s.session == nil {
+		return
+	}
+	current := s.session(ctx)
+	if current == "" || current == s.sessionID {
+		return
+	}
+	if s.sessionID != "" {
+		s.lurkers = make(map[string]lurker)
+	}
+	s.sessionID = current
+}
+
+func lurkerKey(msg domain.Message) string {
+	user := strings.TrimSpace(msg.UserID)
+	if user == "" {
+		user = msg.LoginName()
+	}
+	if user == "" {
+		return ""
+	}
+	return string(msg.Platform) + ":" + strings.ToLower(user)
+}
+
+func renderTemplate(template, user string, lurked time.Duration) string {
+	return strings.NewReplacer(
+		"{user}", user,
+		"{duration}", humanizeDuration(lurked),
+	).Replace(template)
+}
+
+// humanizeDuration describe el tiempo lurkeado en horas y minutos.
+func humanizeDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "menos de un minuto"
+	}
+	hours := int(d / time.Hour)
+	minutes := int(d%time.Hour) / int(time.Minute)
+	var parts []string
+	if hours > 0 {
+		parts = append(parts, pluralize(hours, "hora", "horas"))
+	}
+	if minutes > 0 {
+		parts = append(parts, pluralize(minutes, "minuto", "minutos"))
+	}
+	return strings.Join(parts, " y ")
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+func sanitizeSettings(cfg domain.LurkSettings) domain.LurkSettings {
+	def := domain.DefaultLurkSettings()
+	cfg.LurkTemplate = strings.TrimSpace(cfg.LurkTemplate)
+	if cfg.LurkTemplate == "" {
+		cfg.LurkTemplate = def.LurkTemplate
+	}
+	cfg.UnlurkTemplate = strings.TrimSpace(cfg.UnlurkTemplate)
+	if cfg.UnlurkTemplate == "" {
+		cfg.UnlurkTemplate = def.UnlurkTemplate
+	}
+	return cfg
+}
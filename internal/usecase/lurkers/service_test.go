@@ -0,0 +1,165 @@
+package lurkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestService(t *testing.T, autoUnlurk bool) (*Service, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)}
+	svc := NewService(nil)
+	svc.now = clock.Now
+	cfg := domain.DefaultLurkSettings()
+	cfg.AutoUnlurk = autoUnlurk
+	if _, err := svc.Update(context.Background(), cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	return svc, clock
+}
+
+func chatter(platform domain.Platform, id, name string) domain.Message {
+	return domain.Message{Platform: platform, UserID: id, Username: name, ChannelID: "canal", Text: "hola"}
+}
+
+func TestLurkAndUnlurk(t *testing.T) {
+	ctx := context.Background()
+	svc, clock := newTestService(t, false)
+	ana := chatter(domain.PlatformTwitch, "1", "Ana")
+
+	if got := svc.Lurk(ctx, ana); got != "👀 Ana se fue a lurkear. ¡Gracias por quedarte!" {
+		t.Fatalf("Lurk = %q", got)
+	}
+	if got := svc.Count(ctx); got != 1 {
+		t.Fatalf("Count = %d", got)
+	}
+
+	clock.Advance(time.Hour + 5*time.Minute)
+	got, ok := svc.Unlurk(ctx, ana)
+	if !ok || got != "👋 ¡Bienvenido de vuelta, Ana! Estuviste lurkeando 1 hora y 5 minutos." {
+		t.Fatalf("Unlurk = %q, %v", got, ok)
+	}
+	if _, ok := svc.Unlurk(ctx, ana); ok {
+		t.Fatal("second Unlurk found the user again")
+	}
+}
+
+func TestAutoUnlurkOnMessage(t *testing.T) {
+	ctx := context.Background()
+	svc, clock := newTestService(t, true)
+	ana := chatter(domain.PlatformTwitch, "1", "Ana")
+
+	svc.Lurk(ctx, ana)
+	clock.Advance(30 * time.Second)
+	if got := svc.Observe(ctx, ana); got != "👋 ¡Bienvenido de vuelta, Ana! Estuviste lurkeando menos de un minuto." {
+		t.Fatalf("Observe = %q", got)
+	}
+	if got := svc.Count(ctx); got != 0 {
+		t.Fatalf("Count after auto-unlurk = %d", got)
+	}
+	// quien no estaba lurkeando no recibe bienvenida
+	if got := svc.Observe(ctx, ana); got != "" {
+		t.Fatalf("Observe without lurk = %q", got)
+	}
+}
+
+func TestAutoUnlurkDisabled(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, false)
+	ana := chatter(domain.PlatformTwitch, "1", "Ana")
+
+	svc.Lurk(ctx, ana)
+	if got := svc.Observe(ctx, ana); got != "" {
+		t.Fatalf("Observe with auto_unlurk off = %q", got)
+	}
+	if got := svc.Count(ctx); got != 1 {
+		t.Fatalf("Count = %d, want the lurker kept", got)
+	}
+}
+
+func TestLurkersAreKeyedByPlatformAndUser(t *testing.T) {
+	ctx := context.Background()
+	svc, clock := newTestService(t, true)
+
+	svc.Lurk(ctx, chatter(domain.PlatformTwitch, "1", "Ana"))
+	clock.Advance(time.Minute)
+	svc.Lurk(ctx, chatter(domain.PlatformKick, "1", "Ana"))
+	// sin UserID se usa el login
+	svc.Lurk(ctx, domain.Message{Platform: domain.PlatformTwitch, Username: "Beto"})
+	svc.Lurk(ctx, domain.Message{Platform: domain.PlatformTwitch, Username: "beto"})
+
+	list := svc.List(ctx)
+	if list.Count != 3 || len(list.Lurkers) != 3 {
+		t.Fatalf("List = %+v", list)
+	}
+	if list.Lurkers[0].Platform != "twitch" || list.Lurkers[0].User != "Ana" {
+		t.Fatalf("oldest lurker = %+v", list.Lurkers[0])
+	}
+
+	if got := svc.Observe(ctx, chatter(domain.PlatformKick, "1", "Ana")); got == "" {
+		t.Fatal("kick Ana not welcomed back")
+	}
+	if got := svc.Count(ctx); got != 2 {
+		t.Fatalf("Count = %d, twitch Ana should still be lurking", got)
+	}
+}
+
+func TestLurkersResetOnNewSession(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, true)
+	session := "stream-1"
+	svc.SetSessionFunc(func(context.Context) string { return session })
+
+	svc.Lurk(ctx, chatter(domain.PlatformTwitch, "1", "Ana"))
+	if got := svc.Count(ctx); got != 1 {
+		t.Fatalf("Count = %d", got)
+	}
+
+	// offline (sesión desconocida) no borra nada
+	session = ""
+	if got := svc.Count(ctx); got != 1 {
+		t.Fatalf("Count while offline = %d", got)
+	}
+
+	session = "stream-2"
+	if got := svc.Count(ctx); got != 0 {
+		t.Fatalf("Count after a new session = %d", got)
+	}
+	if _, ok := svc.Unlurk(ctx, chatter(domain.PlatformTwitch, "1", "Ana")); ok {
+		t.Fatal("lurker from the previous session still listed")
+	}
+}
+
+func TestUpdateRestoresDefaultTemplates(t *testing.T) {
+	svc, _ := newTestService(t, false)
+	got, err := svc.Update(context.Background(), domain.LurkSettings{LurkTemplate: "  ", UnlurkTemplate: "{user} volvió"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got.LurkTemplate != domain.DefaultLurkSettings().LurkTemplate || got.UnlurkTemplate != "{user} volvió" {
+		t.Fatalf("settings = %+v", got)
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		10 * time.Second:            "menos de un minuto",
+		time.Minute:                 "1 minuto",
+		45 * time.Minute:            "45 minutos",
+		time.Hour:                   "1 hora",
+		2*time.Hour + 1*time.Minute: "2 horas y 1 minuto",
+	}
+	for d, want := range cases {
+		if got := humanizeDuration(d); got != want {
+			t.Errorf("humanizeDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// maxRecentChatters limita cuántos usuarios recientes se recuerdan por si
+// hay que sancionarlos; al llenarse se empieza de cero.
+const maxRecentChatters = 5000
+
+var (
+	// ErrModerationUnavailable indica que la plataforma no tiene servicio de
+	// moderación (sin token de streamer o sin conectar).
+	ErrModerationUnavailable = errors.New("moderación no disponible en esta plataforma")
+	// ErrProtectedUser evita sancionar al streamer o a un moderador.
+	ErrProtectedUser = errors.New("no se puede sancionar a un moderador o al streamer")
+	// ErrNoRecentMessage indica que no hay un mensaje reciente del usuario.
+	ErrNoRecentMessage = errors.New("no hay mensajes recientes del usuario")
+)
+
+type chatterKey struct {
+	platform domain.Platform
+	login    string
+}
+
+type recentChatter struct {
+	userID    string
+	messageID string
+	protected bool
+}
+
+// Actions reparte las sanciones entre los ModerationService de cada
+// plataforma. Recuerda a quienes escribieron hace poco para resolver su ID y
+// su último mensaje sin consultar la API.
+type Actions struct {
+	mu       sync.RWMutex
+	services map[domain.Platform]domain.ModerationService
+	recent   map[chatterKey]recentChatter
+}
+
+func NewActions() *Actions {
+	return &Actions{
+		services: make(map[domain.Platform]domain.ModerationService),
+		recent:   make(map[chatterKey]recentChatter),
+	}
+}
+
+// Set registra (o quita con nil) el servicio de una plataforma.
+func (a *Actions) Set(platform domain.Platform, svc domain.ModerationService) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if svc == nil {
+		delete(a.services, platform)
+		return
+	}
+	a.services[platform] = svc
+}
+
+// Available indica si hay servicio de moderación para la plataforma.
+func (a *Actions) Available(platform domain.Platform) bool {
+	_, ok := a.service(platform)
+	return ok
+}
+
+// Observe recuerda el ID y el último mensaje de quien escribió.
+func (a *Actions) Observe(msg domain.Message) {
+	if a == nil || msg.UserID == "" {
+		return
+	}
+	login := normalizeLogin(msg.LoginName())
+	if login == "" {
+		return
+	}
+	k := chatterKey{platform: msg.Platform, login: login}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, known := a.recent[k]; !known && len(a.recent) >= maxRecentChatters {
+		a.recent = make(map[chatterKey]recentChatter)
+	}
+	a.recent[k] = recentChatter{
+		userID:    msg.UserID,
+		messageID: msg.PlatformMessageID,
+		protected: msg.IsPlatformOwner || msg.IsPlatformMod,
+	}
+}
+
+// Ban banea a login de forma permanente y devuelve su ID.
+func (a *Actions) Ban(ctx context.Context, platform domain.Platform, login, reason string) (string, error) {
+	svc, userID, err := a.target(ctx, platform, login)
+	if err != nil {
+		return "", err
+	}
+	if err := svc.BanUser(ctx, userID, reason); err != nil {
+		return "", err
+	}
+	log.Printf("moderation: %s baneado en %s", normalizeLogin(login), platform)
+	return userID, nil
+}
+
+// Timeout silencia a login y devuelve la duración que aplicó la plataforma.
+func (a *Actions) Timeout(ctx context.Context, platform domain.Platform, login string, duration time.Duration, reason string) (time.Duration, error) {
+	svc, userID, err := a.target(ctx, platform, login)
+	if err != nil {
+		return 0, err
+	}
+	duration = domain.ClampTimeout(platform, duration)
+	if err := svc.TimeoutUser(ctx, userID, duration, reason); err != nil {
+		return 0, err
+	}
+	log.Printf("moderation: %s silenciado %s en %s", normalizeLogin(login), duration, platform)
+	return duration, nil
+}
+
+// DeleteLast borra el último mensaje que se vio de login.
+func (a *Actions) DeleteLast(ctx context.Context, platform domain.Platform, login string) error {
+	svc, ok := a.service(platform)
+	if !ok {
+		return ErrModerationUnavailable
+	}
+	chatter, ok := a.lookup(platform, login)
+	if !ok || chatter.messageID == "" {
+		return ErrNoRecentMessage
+	}
+	if chatter.protected {
+		return ErrProtectedUser
+	}
+	if err := svc.DeleteMessage(ctx, chatter.messageID); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	k := chatterKey{platform: platform, login: normalizeLogin(login)}
+	if current, ok := a.recent[k]; ok && current.messageID == chatter.messageID {
+		current.messageID = ""
+		a.recent[k] = current
+	}
+	a.mu.Unlock()
+	return nil
+}
+
+// target resuelve el servicio y el ID del usuario a sancionar.
+func (a *Actions) target(ctx context.Context, platform domain.Platform, login string) (domain.ModerationService, string, error) {
+	svc, ok := a.service(platform)
+	if !ok {
+		return nil, "", ErrModerationUnavailable
+	}
+	if chatter, ok := a.lookup(platform, login); ok {
+		if chatter.protected {
+			return nil, "", ErrProtectedUser
+		}
+		return svc, chatter.userID, nil
+	}
+	resolver, ok := svc.(domain.ModerationUserResolver)
+	if !ok {
+		return nil, "", domain.ErrModerationUserNotFound
+	}
+	userID, err := resolver.ResolveUserID(ctx, normalizeLogin(login))
+	if err != nil {
+		return nil, "", fmt.Errorf("moderation: resolver %s: %w", login, err)
+	}
+	return svc, userID, nil
+}
+
+func (a *Actions) service(platform domain.Platform) (domain.ModerationService, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	svc, ok := a.services[platform]
+	return svc, ok
+}
+
+func (a *Actions) lookup(platform domain.Platform, login string) (recentChatter, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	chatter, ok := a.recent[chatterKey{platform: platform, login: normalizeLogin(login)}]
+	return chatter, ok
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+}
@@ -0,0 +1,181 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// fakeModeration anota cada sanción que le llega a la plataforma.
+type fakeModeration struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeModeration) BanUser(_ context.Context, userID, reason string) error {
+	f.calls = append(f.calls, "ban:"+userID+":"+reason)
+	return f.err
+}
+
+func (f *fakeModeration) TimeoutUser(_ context.Context, userID string, duration time.Duration, reason string) error {
+	f.calls = append(f.calls, fmt.Sprintf("timeout:%s:%s:%s", userID, duration, reason))
+	return f.err
+}
+
+func (f *fakeModeration) DeleteMessage(_ context.Context, messageID string) error {
+	f.calls = append(f.calls, "delete:"+messageID)
+	return f.err
+}
+
+// resolvingModeration además busca IDs de usuarios que no escribieron.
+type resolvingModeration struct {
+	fakeModeration
+	ids map[string]string
+}
+
+func (r *resolvingModeration) ResolveUserID(_ context.Context, login string) (string, error) {
+	id, ok := r.ids[login]
+	if !ok {
+		return "", domain.ErrModerationUserNotFound
+	}
+	return id, nil
+}
+
+func chatter(platform domain.Platform, login, userID, messageID string) domain.Message {
+	return domain.Message{Platform: platform, ChannelID: "canal", UserID: userID, Login: login, Username: login, PlatformMessageID: messageID, Text: "hola"}
+}
+
+func TestActionsUseRecentChatters(t *testing.T) {
+	ctx := context.Background()
+	svc := &fakeModeration{}
+	actions := NewActions()
+	actions.Set(domain.PlatformTwitch, svc)
+	actions.Observe(chatter(domain.PlatformTwitch, "troll", "42", "m1"))
+	actions.Observe(chatter(domain.PlatformTwitch, "troll", "42", "m2"))
+
+	if id, err := actions.Ban(ctx, domain.PlatformTwitch, "@Troll", "spam"); err != nil || id != "42" {
+		t.Fatalf("Ban = %q, %v", id, err)
+	}
+	applied, err := actions.Timeout(ctx, domain.PlatformTwitch, "troll", 90*time.Second+300*time.Millisecond, "")
+	if err != nil || applied != 90*time.Second {
+		t.Fatalf("Timeout = %s, %v", applied, err)
+	}
+	if err := actions.DeleteLast(ctx, domain.PlatformTwitch, "troll"); err != nil {
+		t.Fatalf("DeleteLast: %v", err)
+	}
+	// el mensaje ya borrado no se vuelve a borrar
+	if err := actions.DeleteLast(ctx, domain.PlatformTwitch, "troll"); !errors.Is(err, ErrNoRecentMessage) {
+		t.Fatalf("segundo DeleteLast = %v, esperaba ErrNoRecentMessage", err)
+	}
+
+	want := []string{"ban:42:spam", "timeout:42:1m30s:", "delete:m2"}
+	if fmt.Sprint(svc.calls) != fmt.Sprint(want) {
+		t.Fatalf("llamadas = %q, esperaba %q", svc.calls, want)
+	}
+}
+
+func TestActionsProtectModerators(t *testing.T) {
+	ctx := context.Background()
+	svc := &fakeModeration{}
+	actions := NewActions()
+	actions.Set(domain.PlatformTwitch, svc)
+	mod := chatter(domain.PlatformTwitch, "luis", "7", "m1")
+	mod.IsPlatformMod = true
+	owner := chatter(domain.PlatformTwitch, "zero", "1", "m2")
+	owner.IsPlatformOwner = true
+	actions.Observe(mod)
+	actions.Observe(owner)
+
+	for _, login := range []string{"luis", "zero"} {
+		if _, err := actions.Ban(ctx, domain.PlatformTwitch, login, ""); !errors.Is(err, ErrProtectedUser) {
+			t.Errorf("Ban(%s) = %v, esperaba ErrProtectedUser", login, err)
+		}
+		if err := actions.DeleteLast(ctx, domain.PlatformTwitch, login); !errors.Is(err, ErrProtectedUser) {
+			t.Errorf("DeleteLast(%s) = %v, esperaba ErrProtectedUser", login, err)
+		}
+	}
+	if len(svc.calls) != 0 {
+		t.Fatalf("se sancionó a un protegido: %q", svc.calls)
+	}
+}
+
+func TestActionsResolveUnknownUsers(t *testing.T) {
+	ctx := context.Background()
+	svc := &resolvingModeration{ids: map[string]string{"nuevo": "99"}}
+	actions := NewActions()
+	actions.Set(domain.PlatformKick, svc)
+
+	// Kick trabaja en minutos: 90s se redondea a 2m
+	applied, err := actions.Timeout(ctx, domain.PlatformKick, "Nuevo", 90*time.Second, "")
+	if err != nil || applied != 2*time.Minute || svc.calls[0] != "timeout:99:2m0s:" {
+		t.Fatalf("Timeout = %s, %v, llamadas = %q", applied, err, svc.calls)
+	}
+	if _, err := actions.Ban(ctx, domain.PlatformKick, "fantasma", ""); !errors.Is(err, domain.ErrModerationUserNotFound) {
+		t.Fatalf("Ban desconocido = %v", err)
+	}
+	// sin mensaje visto no hay nada que borrar
+	if err := actions.DeleteLast(ctx, domain.PlatformKick, "nuevo"); !errors.Is(err, ErrNoRecentMessage) {
+		t.Fatalf("DeleteLast = %v", err)
+	}
+
+	// sin resolver, quien no escribió no se encuentra
+	plain := NewActions()
+	plain.Set(domain.PlatformTwitch, &fakeModeration{})
+	if _, err := plain.Ban(ctx, domain.PlatformTwitch, "nuevo", ""); !errors.Is(err, domain.ErrModerationUserNotFound) {
+		t.Fatalf("Ban sin resolver = %v", err)
+	}
+}
+
+func TestActionsUnavailablePlatform(t *testing.T) {
+	ctx := context.Background()
+	actions := NewActions()
+	actions.Observe(chatter(domain.PlatformKick, "troll", "42", "m1"))
+	if actions.Available(domain.PlatformKick) {
+		t.Fatal("Available sin servicio")
+	}
+	if _, err := actions.Ban(ctx, domain.PlatformKick, "troll", ""); !errors.Is(err, ErrModerationUnavailable) {
+		t.Fatalf("Ban = %v", err)
+	}
+	if err := actions.DeleteLast(ctx, domain.PlatformKick, "troll"); !errors.Is(err, ErrModerationUnavailable) {
+		t.Fatalf("DeleteLast = %v", err)
+	}
+
+	// Set(nil) quita el servicio
+	actions.Set(domain.PlatformKick, &fakeModeration{})
+	actions.Set(domain.PlatformKick, nil)
+	if actions.Available(domain.PlatformKick) {
+		t.Fatal("Set(nil) no quitó el servicio")
+	}
+
+	// un error de la plataforma llega tal cual
+	failing := &fakeModeration{err: errors.New("403")}
+	actions.Set(domain.PlatformKick, failing)
+	if _, err := actions.Ban(ctx, domain.PlatformKick, "troll", ""); err == nil || err.Error() != "403" {
+		t.Fatalf("Ban = %v", err)
+	}
+}
+
+func TestClampTimeout(t *testing.T) {
+	tests := []struct {
+		platform domain.Platform
+		in       time.Duration
+		want     time.Duration
+	}{
+		{domain.PlatformTwitch, 0, time.Second},
+		{domain.PlatformTwitch, 1500 * time.Millisecond, time.Second},
+		{domain.PlatformTwitch, 30 * 24 * time.Hour, domain.MaxTwitchTimeout},
+		{domain.PlatformKick, time.Second, time.Minute},
+		{domain.PlatformKick, 61 * time.Second, 2 * time.Minute},
+		{domain.PlatformKick, 10 * time.Minute, 10 * time.Minute},
+		{domain.PlatformKick, 14 * 24 * time.Hour, domain.MaxKickTimeout},
+	}
+	for _, tt := range tests {
+		if got := domain.ClampTimeout(tt.platform, tt.in); got != tt.want {
+			t.Errorf("ClampTimeout(%s, %s) = %s, esperaba %s", tt.platform, tt.in, got, tt.want)
+		}
+	}
+}
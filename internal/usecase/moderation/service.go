@@ -0,0 +1,125 @@
+// Package moderation agrupa las reglas automáticas que revisan el chat.
+package moderation
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// Rule es una regla de moderación que revisa cada mensaje entrante.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, msg domain.Message) error
+}
+
+type Service struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+func NewService(rules ...Rule) *Service {
+	return &Service{rules: rules}
+}
+
+func (s *Service) AddRule(rule Rule) {
+	if s == nil || rule == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append(s.rules, rule)
+}
+
+// Evaluate ejecuta todas las reglas; los errores solo se registran para no
+// cortar el flujo normal del mensaje.
+func (s *Service) Evaluate(ctx context.Context, msg domain.Message) {
+	if s == nil {
+		return
+	}
+	s.mu.RLock()
+	rules := append([]Rule(nil), s.rules...)
+	s.mu.RUnlock()
+
+	for _, rule := range rules {
+		if err := rule.Evaluate(ctx, msg); err != nil {
+			log.Printf("moderation: regla %s falló: %v", rule.Name(), err)
+		}
+	}
+}
+
+// BurstHandler reacciona a una ráfaga de spam detectada.
+type BurstHandler func(ctx context.Context, burst SpamBurst, settings domain.SpamProtectionSettings) error
+
+// SpamRule conecta el detector con la acción configurada.
+type SpamRule struct {
+	detector *SpamDetector
+	onBurst  BurstHandler
+	settings domain.ModerationSettingsRepository
+}
+
+func NewSpamRule(detector *SpamDetector, onBurst BurstHandler) *SpamRule {
+	return &SpamRule{detector: detector, onBurst: onBurst}
+}
+
+func (r *SpamRule) Name() string {
+	return "spam"
+}
+
+func (r *SpamRule) Evaluate(ctx context.Context, msg domain.Message) error {
+	if r == nil || r.detector == nil {
+		return nil
+	}
+	burst := r.detector.Observe(msg)
+	if burst == nil {
+		return nil
+	}
+	settings := r.detector.Settings()
+	log.Printf("moderation: ráfaga de spam en %s/%s (%d usuarios): %q", burst.Platform, burst.ChannelID, len(burst.Users), burst.Text)
+	if r.onBurst == nil || settings.Action == domain.SpamActionNone {
+		return nil
+	}
+	return r.onBurst(ctx, *burst, settings)
+}
+
+// SetSettingsRepository permite persistir cambios de configuración.
+func (r *SpamRule) SetSettingsRepository(repo domain.ModerationSettingsRepository) {
+	r.settings = repo
+}
+
+// Load aplica la configuración guardada (si existe).
+func (r *SpamRule) Load(ctx context.Context) error {
+	if r == nil || r.settings == nil {
+		return nil
+	}
+	stored, err := r.settings.GetSpamProtectionSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		r.detector.Configure(*stored)
+	}
+	return nil
+}
+
+func (r *SpamRule) Reload(ctx context.Context) error {
+	return r.Load(ctx)
+}
+
+func (r *SpamRule) Settings() domain.SpamProtectionSettings {
+	return r.detector.Settings()
+}
+
+// Update guarda y aplica la nueva configuración.
+func (r *SpamRule) Update(ctx context.Context, settings domain.SpamProtectionSettings) (domain.SpamProtectionSettings, error) {
+	r.detector.Configure(settings)
+	applied := r.detector.Settings()
+	if r.settings != nil {
+		if err := r.settings.SetSpamProtectionSettings(ctx, applied); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,206 @@
+package moderation
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"zhatBot/internal/domain"
+)
+
+// SpamBurst describe una ráfaga de mensajes iguales o parecidos de varios usuarios.
+type SpamBurst struct {
+	Platform  domain.Platform
+	ChannelID string
+	Text      string
+	Users     []string
+	At        time.Time
+}
+
+type spamEntry struct {
+	user   string
+	text   string
+	tokens map[string]struct{}
+	at     time.Time
+}
+
+// SpamDetector mantiene una ventana deslizante de mensajes recientes por canal.
+type SpamDetector struct {
+	mu        sync.Mutex
+	cfg       domain.SpamProtectionSettings
+	recent    map[string][]spamEntry
+	lastBurst map[string]time.Time
+	now       func() time.Time
+}
+
+func NewSpamDetector(cfg domain.SpamProtectionSettings) *SpamDetector {
+	return &SpamDetector{
+		cfg:       sanitizeSpamSettings(cfg),
+		recent:    make(map[string][]spamEntry),
+		lastBurst: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+func (d *SpamDetector) Settings() domain.SpamProtectionSettings {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cfg
+}
+
+// Configure cambia los umbrales y limpia la ventana actual.
+func (d *SpamDetector) Configure(cfg domain.SpamProtectionSettings) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cfg = sanitizeSpamSettings(cfg)
+	d.recent = make(map[string][]spamEntry)
+}
+
+// Observe registra el mensaje y devuelve una ráfaga si se superó el umbral.
+func (d *SpamDetector) Observe(msg domain.Message) *SpamBurst {
+	if d == nil {
+		return nil
+	}
+	if msg.IsPlatformOwner || msg.IsPlatformAdmin || msg.IsPlatformMod {
+		return nil
+	}
+
+	text := normalizeSpamText(msg.Text)
+	if text == "" {
+		return nil
+	}
+	user := strings.ToLower(strings.TrimSpace(msg.UserID))
+	if user == "" {
+		user = msg.LoginName()
+	}
+	if user == "" {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.cfg.Enabled {
+		return nil
+	}
+
+	now := d.now()
+	key := string(msg.Platform) + "|" + msg.ChannelID
+	window := time.Duration(d.cfg.WindowSeconds) * time.Second
+
+	entries := d.recent[key]
+	kept := entries[:0]
+	for _, e := range entries {
+		if now.Sub(e.at) <= window {
+			kept = append(kept, e)
+		}
+	}
+
+	current := spamEntry{user: user, text: text, tokens: tokenSet(text), at: now}
+	kept = append(kept, current)
+	d.recent[key] = kept
+
+	users := make(map[string]struct{})
+	var names []string
+	for _, e := range kept {
+		if !similarEntries(current, e, d.cfg.Similarity) {
+			continue
+		}
+		if _, ok := users[e.user]; ok {
+			continue
+		}
+		users[e.user] = struct{}{}
+		names = append(names, e.user)
+	}
+
+	if len(users) < d.cfg.MinUsers {
+		return nil
+	}
+
+	cooldown := time.Duration(d.cfg.CooldownSeconds) * time.Second
+	if last, ok := d.lastBurst[key]; ok && now.Sub(last) < cooldown {
+		return nil
+	}
+	d.lastBurst[key] = now
+	delete(d.recent, key)
+
+	return &SpamBurst{
+		Platform:  msg.Platform,
+		ChannelID: msg.ChannelID,
+		Text:      strings.TrimSpace(msg.Text),
+		Users:     names,
+		At:        now,
+	}
+}
+
+func sanitizeSpamSettings(cfg domain.SpamProtectionSettings) domain.SpamProtectionSettings {
+	def := domain.DefaultSpamProtectionSettings()
+	if cfg.WindowSeconds <= 0 {
+		cfg.WindowSeconds = def.WindowSeconds
+	}
+	if cfg.MinUsers < 2 {
+		cfg.MinUsers = def.MinUsers
+	}
+	if cfg.Similarity <= 0 || cfg.Similarity > 1 {
+		cfg.Similarity = def.Similarity
+	}
+	if cfg.CooldownSeconds < 0 {
+		cfg.CooldownSeconds = def.CooldownSeconds
+	}
+	if cfg.Action == "" {
+		cfg.Action = def.Action
+	}
+	return cfg
+}
+
+// normalizeSpamText quita mayúsculas, signos y espacios repetidos.
+func normalizeSpamText(text string) string {
+	var b strings.Builder
+	lastSpace := true
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastSpace = false
+		case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			if !lastSpace {
+				b.WriteByte(' ')
+				lastSpace = true
+			}
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func tokenSet(text string) map[string]struct{} {
+	out := make(map[string]struct{})
+	for _, tok := range strings.Fields(text) {
+		out[tok] = struct{}{}
+	}
+	return out
+}
+
+func similarEntries(a, b spamEntry, threshold float64) bool {
+	if a.text == b.text {
+		return true
+	}
+	if threshold >= 1 {
+		return false
+	}
+	return jaccard(a.tokens, b.tokens) >= threshold
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for tok := range a {
+		if _, ok := b[tok]; ok {
+			inter++
+		}
+	}
+	union := len(a) + len(b) - inter
+	return float64(inter) / float64(union)
+}
@@ -0,0 +1,169 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestDetector(cfg domain.SpamProtectionSettings) (*SpamDetector, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	d := NewSpamDetector(cfg)
+	d.now = clock.now
+	return d, clock
+}
+
+func spamSettings() domain.SpamProtectionSettings {
+	return domain.SpamProtectionSettings{
+		Enabled:         true,
+		WindowSeconds:   10,
+		MinUsers:        3,
+		Similarity:      1,
+		CooldownSeconds: 30,
+		Action:          domain.SpamActionAnnounce,
+	}
+}
+
+func chatMessage(user, text string) domain.Message {
+	return domain.Message{Platform: domain.PlatformTwitch, ChannelID: "canal", UserID: user, Username: user, Text: text}
+}
+
+func TestSpamDetectorBurstWithinWindow(t *testing.T) {
+	d, clock := newTestDetector(spamSettings())
+
+	if b := d.Observe(chatMessage("a", "COPYPASTA!!")); b != nil {
+		t.Fatalf("burst after one user")
+	}
+	clock.advance(3 * time.Second)
+	// el mismo usuario repitiendo no cuenta dos veces
+	if b := d.Observe(chatMessage("a", "copypasta")); b != nil {
+		t.Fatalf("burst counting the same user twice")
+	}
+	if b := d.Observe(chatMessage("b", "copypasta")); b != nil {
+		t.Fatalf("burst with two users")
+	}
+	clock.advance(3 * time.Second)
+	b := d.Observe(chatMessage("c", "Copy-pasta? no: copypasta"))
+	if b != nil {
+		t.Fatalf("different text counted as the same message")
+	}
+	b = d.Observe(chatMessage("c", "  CopyPasta.  "))
+	if b == nil {
+		t.Fatalf("no burst with three users inside the window")
+	}
+	if len(b.Users) != 3 || b.Text != "CopyPasta." {
+		t.Fatalf("burst = %+v", b)
+	}
+}
+
+func TestSpamDetectorWindowExpires(t *testing.T) {
+	d, clock := newTestDetector(spamSettings())
+
+	d.Observe(chatMessage("a", "hola hola"))
+	d.Observe(chatMessage("b", "hola hola"))
+	clock.advance(11 * time.Second)
+	if b := d.Observe(chatMessage("c", "hola hola")); b != nil {
+		t.Fatalf("burst counted messages outside the window")
+	}
+	clock.advance(5 * time.Second)
+	if b := d.Observe(chatMessage("d", "hola hola")); b != nil {
+		t.Fatalf("burst with two users in the window")
+	}
+	if b := d.Observe(chatMessage("e", "hola hola")); b == nil {
+		t.Fatalf("no burst with c, d and e inside the window")
+	}
+}
+
+func TestSpamDetectorCooldownAndChannels(t *testing.T) {
+	d, clock := newTestDetector(spamSettings())
+
+	burst := func(prefix string, channel string) *SpamBurst {
+		var last *SpamBurst
+		for i := 0; i < 3; i++ {
+			msg := chatMessage(fmt.Sprintf("%s%d", prefix, i), "raid raid raid")
+			msg.ChannelID = channel
+			if b := d.Observe(msg); b != nil {
+				last = b
+			}
+		}
+		return last
+	}
+
+	if burst("a", "canal") == nil {
+		t.Fatalf("first burst not detected")
+	}
+	clock.advance(5 * time.Second)
+	if burst("b", "canal") != nil {
+		t.Fatalf("burst fired again inside the cooldown")
+	}
+	if burst("c", "otro") == nil {
+		t.Fatalf("cooldown of one channel blocked another")
+	}
+	clock.advance(31 * time.Second)
+	if burst("d", "canal") == nil {
+		t.Fatalf("burst not detected after the cooldown")
+	}
+}
+
+func TestSpamDetectorSimilarityAndExemptions(t *testing.T) {
+	cfg := spamSettings()
+	cfg.Similarity = 0.6
+	d, _ := newTestDetector(cfg)
+
+	d.Observe(chatMessage("a", "sigan a mi canal de youtube"))
+	d.Observe(chatMessage("b", "sigan a mi canal de youtube ya"))
+	mod := chatMessage("m", "sigan a mi canal de youtube")
+	mod.IsPlatformMod = true
+	if b := d.Observe(mod); b != nil {
+		t.Fatalf("moderator counted toward a burst")
+	}
+	if b := d.Observe(chatMessage("c", "sigan mi canal de youtube")); b == nil {
+		t.Fatalf("similar messages not grouped")
+	}
+
+	disabled := spamSettings()
+	disabled.Enabled = false
+	off, _ := newTestDetector(disabled)
+	for _, user := range []string{"a", "b", "c", "d"} {
+		if b := off.Observe(chatMessage(user, "spam")); b != nil {
+			t.Fatalf("disabled detector fired")
+		}
+	}
+}
+
+func TestSpamRuleAction(t *testing.T) {
+	d, _ := newTestDetector(spamSettings())
+	var bursts []SpamBurst
+	rule := NewSpamRule(d, func(_ context.Context, b SpamBurst, _ domain.SpamProtectionSettings) error {
+		bursts = append(bursts, b)
+		return nil
+	})
+	for _, user := range []string{"a", "b", "c"} {
+		if err := rule.Evaluate(context.Background(), chatMessage(user, "spam")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(bursts) != 1 {
+		t.Fatalf("handler called %d times, want 1", len(bursts))
+	}
+
+	cfg := spamSettings()
+	cfg.Action = domain.SpamActionNone
+	if _, err := rule.Update(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range []string{"x", "y", "z"} {
+		_ = rule.Evaluate(context.Background(), chatMessage(user, "otro spam"))
+	}
+	if len(bursts) != 1 {
+		t.Fatalf("handler called with action none")
+	}
+}
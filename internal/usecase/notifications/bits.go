@@ -0,0 +1,27 @@
+package notifications
+
+import (
+	"fmt"
+
+	"zhatBot/internal/domain"
+)
+
+// BitsNotification arma la notificación de bits de un cheer que llegó como
+// mensaje de chat. Devuelve nil si el mensaje no trae bits.
+func BitsNotification(msg domain.Message) (*domain.Notification, error) {
+	if msg.Bits <= 0 {
+		return nil, nil
+	}
+	metadata := map[string]string{
+		"source":  "chat",
+		"user_id": msg.UserID,
+	}
+	if msg.ID != "" {
+		metadata["message_id"] = msg.ID
+	}
+	notification, err := domain.NewNotification(string(domain.NotificationBits), string(msg.Platform), msg.Name(), float64(msg.Bits), msg.Text, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("bits de %s: %w", msg.Name(), err)
+	}
+	return notification, nil
+}
@@ -0,0 +1,37 @@
+package notifications
+
+import (
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestBitsNotification(t *testing.T) {
+	msg := domain.Message{
+		ID:       "c1",
+		Platform: domain.PlatformTwitch,
+		UserID:   "42",
+		Username: "Ana",
+		Text:     "Cheer100 Cheer150 ¡vamos!",
+		Bits:     250,
+	}
+	notification, err := BitsNotification(msg)
+	if err != nil {
+		t.Fatalf("BitsNotification: %v", err)
+	}
+	if notification.Type != domain.NotificationBits || notification.Platform != domain.PlatformTwitch ||
+		notification.Username != "Ana" || notification.Amount != 250 || notification.Message != msg.Text {
+		t.Fatalf("notification = %+v", notification)
+	}
+	want := map[string]string{"source": "chat", "user_id": "42", "message_id": "c1"}
+	for key, value := range want {
+		if notification.Metadata[key] != value {
+			t.Fatalf("metadata = %v, want %v", notification.Metadata, want)
+		}
+	}
+
+	msg.Bits = 0
+	if notification, err := BitsNotification(msg); notification != nil || err != nil {
+		t.Fatalf("without bits = %+v, %v", notification, err)
+	}
+}
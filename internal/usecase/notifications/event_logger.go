@@ -8,12 +8,16 @@ import (
 
 	"github.com/adeithe/go-twitch/irc"
 	kickchatwrapper "github.com/johanvandegriff/kick-chat-wrapper"
+
+	"zhatBot/internal/domain"
 )
 
 // EventLogger centraliza los logs de eventos de plataformas para facilitar la
-// futura ingesta (subs, bits, tips, etc.).
+// futura ingesta (subs, bits, tips, etc.). Con un RawEventStore además los
+// guarda en la base.
 type EventLogger struct {
-	now func() time.Time
+	now   func() time.Time
+	store *RawEventStore
 }
 
 func NewEventLogger() *EventLogger {
@@ -22,13 +26,18 @@ func NewEventLogger() *EventLogger {
 	}
 }
 
+// SetStore guarda cada evento en store además de loguearlo; nil lo apaga.
+func (l *EventLogger) SetStore(store *RawEventStore) {
+	l.store = store
+}
+
 // HandleKickMessage registra los mensajes del websocket de Kick que no son chat normal.
 func (l *EventLogger) HandleKickMessage(msg kickchatwrapper.ChatMessage) {
 	if strings.EqualFold(strings.TrimSpace(msg.Type), "chat") || strings.EqualFold(strings.TrimSpace(msg.Type), "message") {
 		return
 	}
 
-	l.logPayload("kick", map[string]any{
+	l.logPayload(domain.PlatformKick, msg.Type, map[string]any{
 		"timestamp":   l.now().UTC().Format(time.RFC3339Nano),
 		"event_type":  msg.Type,
 		"chatroom_id": msg.ChatroomID,
@@ -46,14 +55,22 @@ func (l *EventLogger) HandleTwitchUserNotice(notice irc.UserNotice) {
 		"sender":     notice.Sender,
 		"raw_tags":   notice.IRCMessage.Tags,
 	}
-	l.logPayload("twitch", payload)
+	l.logPayload(domain.PlatformTwitch, notice.Type, payload)
 }
 
-func (l *EventLogger) logPayload(source string, payload any) {
+func (l *EventLogger) logPayload(platform domain.Platform, eventType string, payload any) {
 	data, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("[%s-events] %v", source, payload)
+		log.Printf("[%s-events] %v", platform, payload)
 		return
 	}
-	log.Printf("[%s-events] %s", source, data)
+	log.Printf("[%s-events] %s", platform, data)
+	if l.store != nil {
+		l.store.Record(domain.RawPlatformEvent{
+			Platform:   platform,
+			EventType:  eventType,
+			Payload:    string(data),
+			ReceivedAt: l.now(),
+		})
+	}
 }
@@ -0,0 +1,112 @@
+package notifications
+
+import (
+	"context"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryNotificationRepo guarda las notificaciones en memoria y les asigna IDs
+// consecutivos como la tabla de SQLite.
+type memoryNotificationRepo struct {
+	mu     sync.Mutex
+	items  []*domain.Notification
+	nextID int64
+	err    error
+}
+
+func (r *memoryNotificationRepo) SaveNotification(_ context.Context, notification *domain.Notification) (*domain.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.nextID++
+	saved := *notification
+	saved.ID = r.nextID
+	r.items = append(r.items, &saved)
+	return &saved, nil
+}
+
+func (r *memoryNotificationRepo) ListNotifications(_ context.Context, limit int) ([]*domain.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.Notification
+	for i := len(r.items) - 1; i >= 0; i-- {
+		if limit > 0 && len(out) == limit {
+			break
+		}
+		out = append(out, r.items[i])
+	}
+	return out, nil
+}
+
+func (r *memoryNotificationRepo) saved() []*domain.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*domain.Notification(nil), r.items...)
+}
+
+type publishedEvent struct {
+	Type string
+	Data any
+}
+
+// capturePublisher hace de servidor WS y guarda lo que se publica.
+type capturePublisher struct {
+	mu     sync.Mutex
+	events []publishedEvent
+	err    error
+}
+
+func (p *capturePublisher) PublishEvent(_ context.Context, eventType string, data any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, publishedEvent{Type: eventType, Data: data})
+	return p.err
+}
+
+func (p *capturePublisher) published() []publishedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]publishedEvent(nil), p.events...)
+}
+
+// pipeline arma un Service con repo, overlays y listener del bus capturados.
+func pipeline() (*Service, *memoryNotificationRepo, *capturePublisher, *[]NotificationDTO) {
+	repo := &memoryNotificationRepo{}
+	pub := &capturePublisher{}
+	var mu sync.Mutex
+	bus := &[]NotificationDTO{}
+	svc := NewService(repo)
+	svc.SetPublisher(pub)
+	svc.SetListener(func(dto NotificationDTO) {
+		mu.Lock()
+		*bus = append(*bus, dto)
+		mu.Unlock()
+	})
+	return svc, repo, pub, bus
+}
+
+// memoryTemplateRepo guarda las plantillas personalizadas en memoria.
+type memoryTemplateRepo struct {
+	templates map[domain.NotificationType]string
+	err       error
+}
+
+func (r *memoryTemplateRepo) GetNotificationTemplates(context.Context) (map[domain.NotificationType]string, error) {
+	out := make(map[domain.NotificationType]string, len(r.templates))
+	for k, v := range r.templates {
+		out[k] = v
+	}
+	return out, r.err
+}
+
+func (r *memoryTemplateRepo) SetNotificationTemplates(_ context.Context, templates map[domain.NotificationType]string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.templates = templates
+	return nil
+}
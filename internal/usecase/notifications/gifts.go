@@ -0,0 +1,290 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"zhatBot/internal/domain"
+)
+
+// Claves de Metadata de los subs regalados. Una suscripción es regalada si
+// trae gifter (Username es quien la recibe) o gift="true" (Username es quien
+// regala y recipient quien la recibe).
+const (
+	MetadataGift           = "gift"
+	MetadataGifter         = "gifter"
+	MetadataRecipient      = "recipient"
+	MetadataGiftCount      = "gift_count"
+	MetadataRecipients     = "recipients"
+	MetadataAggregatedInto = "aggregated_into"
+)
+
+const (
+	// DefaultGiftWindow es cuánto se espera otro regalo del mismo usuario
+	// antes de cerrar el grupo.
+	DefaultGiftWindow = 10 * time.Second
+	// DefaultGiftMaxDelay es lo máximo que se demora la alerta de un grupo.
+	DefaultGiftMaxDelay = 15 * time.Second
+	// maxGiftRecipients es cuántos nombres se guardan en recipients.
+	maxGiftRecipients = 20
+	// giftTemplate reemplaza a la plantilla de fábrica de suscripción en los
+	// grupos de regalos; si el usuario cambió esa plantilla, se usa la suya.
+	giftTemplate = "🎁 {username}: {message}"
+)
+
+// GiftAggregation agrupa los subs regalados: una bomba de 50 regalos es una
+// sola notificación (y una sola alerta) en lugar de 50.
+type GiftAggregation struct {
+	Disabled bool
+	// Window es la ventana móvil: cada regalo nuevo del mismo usuario la
+	// extiende, hasta MaxDelay desde el primero.
+	Window   time.Duration
+	MaxDelay time.Duration
+	// KeepRecipients guarda además un registro por regalo, con
+	// aggregated_into=<id del grupo> y sin publicarlo.
+	KeepRecipients bool
+}
+
+// giftBomb es un grupo de regalos abierto.
+type giftBomb struct {
+	record     *domain.Notification
+	recipients []string
+	count      int
+	first      time.Time
+	last       time.Time
+	stop       func() bool
+	// gen distingue el timer vigente de uno que ya se reprogramó.
+	gen int
+}
+
+type giftAggregator struct {
+	mu    sync.Mutex
+	cfg   GiftAggregation
+	bombs map[string]*giftBomb
+	now   func() time.Time
+	// afterFunc programa el cierre; devuelve cómo cancelarlo.
+	afterFunc func(d time.Duration, fn func()) func() bool
+}
+
+func newGiftAggregator() *giftAggregator {
+	return &giftAggregator{
+		cfg:   sanitizeGiftAggregation(GiftAggregation{}),
+		bombs: make(map[string]*giftBomb),
+		now:   time.Now,
+		afterFunc: func(d time.Duration, fn func()) func() bool {
+			return time.AfterFunc(d, fn).Stop
+		},
+	}
+}
+
+// SetGiftAggregation cambia cómo se agrupan los subs regalados. Los grupos
+// abiertos siguen con la configuración anterior hasta cerrarse.
+func (s *Service) SetGiftAggregation(cfg GiftAggregation) {
+	s.gifts.mu.Lock()
+	defer s.gifts.mu.Unlock()
+	s.gifts.cfg = sanitizeGiftAggregation(cfg)
+}
+
+// FlushGifts cierra y publica ya todos los grupos abiertos (p. ej. al apagar).
+func (s *Service) FlushGifts(ctx context.Context) {
+	s.gifts.mu.Lock()
+	bombs := make([]*giftBomb, 0, len(s.gifts.bombs))
+	for key, bomb := range s.gifts.bombs {
+		if bomb.stop != nil {
+			bomb.stop()
+		}
+		delete(s.gifts.bombs, key)
+		bombs = append(bombs, bomb)
+	}
+	s.gifts.mu.Unlock()
+	for _, bomb := range bombs {
+		s.publish(ctx, bomb.record)
+	}
+}
+
+// emitGift suma el regalo al grupo de quien regala (o abre uno nuevo) y
+// devuelve el registro del grupo. La alerta sale cuando el grupo se cierra.
+func (s *Service) emitGift(ctx context.Context, notification *domain.Notification, gifter, recipient string) (*domain.Notification, error) {
+	g := s.gifts
+	key := string(notification.Platform) + ":" + strings.ToLower(gifter)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	cfg := g.cfg
+
+	bomb := g.bombs[key]
+	if bomb == nil {
+		bomb = &giftBomb{first: now}
+		bomb.record = newGiftRecord(notification, gifter, now)
+	}
+	bomb.count++
+	bomb.last = now
+	if recipient != "" && len(bomb.recipients) < maxGiftRecipients {
+		bomb.recipients = append(bomb.recipients, recipient)
+	}
+	fillGiftRecord(bomb.record, bomb.count, bomb.recipients)
+
+	if err := s.storeGift(ctx, bomb.record); err != nil {
+		if bomb.record.ID == 0 {
+			return nil, err
+		}
+		// el grupo ya existe: se publica igual con lo que tenga al cerrar
+		log.Printf("notifications: no pude actualizar el regalo de %s: %v", gifter, err)
+	}
+
+	if cfg.KeepRecipients && s.repo != nil {
+		single := *notification
+		single.Metadata = cloneMetadata(notification.Metadata)
+		single.Metadata[MetadataAggregatedInto] = strconv.FormatInt(bomb.record.ID, 10)
+		if _, err := s.repo.SaveNotification(ctx, &single); err != nil {
+			log.Printf("notifications: no pude guardar el regalo a %s: %v", recipient, err)
+		}
+	}
+
+	deadline := bomb.last.Add(cfg.Window)
+	if limit := bomb.first.Add(cfg.MaxDelay); limit.Before(deadline) {
+		deadline = limit
+	}
+	if bomb.stop != nil {
+		bomb.stop()
+	}
+	bomb.gen++
+	gen := bomb.gen
+	bomb.stop = g.afterFunc(deadline.Sub(now), func() {
+		s.closeGift(key, gen)
+	})
+	g.bombs[key] = bomb
+
+	out := *bomb.record
+	out.Metadata = cloneMetadata(bomb.record.Metadata)
+	return &out, nil
+}
+
+// closeGift publica el grupo key si gen sigue siendo su último timer.
+func (s *Service) closeGift(key string, gen int) {
+	g := s.gifts
+	g.mu.Lock()
+	bomb := g.bombs[key]
+	if bomb == nil || bomb.gen != gen {
+		g.mu.Unlock()
+		return
+	}
+	delete(g.bombs, key)
+	g.mu.Unlock()
+	s.publish(context.Background(), bomb.record)
+}
+
+// storeGift guarda el grupo la primera vez y lo reescribe con cada regalo.
+func (s *Service) storeGift(ctx context.Context, record *domain.Notification) error {
+	if s.repo == nil {
+		return nil
+	}
+	if record.ID == 0 {
+		saved, err := s.repo.SaveNotification(ctx, record)
+		if err != nil {
+			return err
+		}
+		if saved == nil {
+			return fmt.Errorf("notification not saved")
+		}
+		*record = *saved
+		return nil
+	}
+	updater, ok := s.repo.(domain.NotificationUpdater)
+	if !ok {
+		return nil
+	}
+	return updater.UpdateNotification(ctx, record)
+}
+
+// giftParties devuelve quién regala y quién recibe. ok es false si la
+// notificación no es un sub regalado.
+func giftParties(notification *domain.Notification) (gifter, recipient string, ok bool) {
+	if notification.Type != domain.NotificationSubscription || notification.IsTest() {
+		return "", "", false
+	}
+	meta := notification.Metadata
+	if gifter = strings.TrimSpace(meta[MetadataGifter]); gifter != "" {
+		return gifter, strings.TrimSpace(notification.Username), true
+	}
+	if strings.EqualFold(strings.TrimSpace(meta[MetadataGift]), "true") {
+		gifter = strings.TrimSpace(notification.Username)
+		return gifter, strings.TrimSpace(meta[MetadataRecipient]), gifter != ""
+	}
+	return "", "", false
+}
+
+func newGiftRecord(first *domain.Notification, gifter string, now time.Time) *domain.Notification {
+	metadata := cloneMetadata(first.Metadata)
+	delete(metadata, MetadataRecipient)
+	metadata[MetadataGift] = "true"
+	metadata[MetadataGifter] = gifter
+	return &domain.Notification{
+		Type:      domain.NotificationSubscription,
+		Platform:  first.Platform,
+		Username:  gifter,
+		Metadata:  metadata,
+		CreatedAt: now,
+	}
+}
+
+// fillGiftRecord actualiza el grupo; Amount es la cantidad de regalos.
+func fillGiftRecord(record *domain.Notification, count int, recipients []string) {
+	record.Amount = float64(count)
+	record.Metadata[MetadataGiftCount] = strconv.Itoa(count)
+	record.Metadata[MetadataRecipients] = joinRecipients(recipients)
+	if count == 1 && len(recipients) == 1 {
+		record.Message = fmt.Sprintf("¡Le regaló una suscripción a %s!", recipients[0])
+		return
+	}
+	record.Message = fmt.Sprintf("¡Regaló %d suscripciones!", count)
+}
+
+// isGiftRecord indica si n es un grupo de regalos armado por emitGift.
+func isGiftRecord(n *domain.Notification) bool {
+	return n.Type == domain.NotificationSubscription && n.Metadata[MetadataGiftCount] != "" && n.Metadata[MetadataAggregatedInto] == ""
+}
+
+// joinRecipients junta los nombres sin pasar el largo máximo de metadata.
+func joinRecipients(recipients []string) string {
+	out := ""
+	for _, name := range recipients {
+		next := name
+		if out != "" {
+			next = out + ", " + name
+		}
+		if utf8.RuneCountInString(next) > domain.MaxNotificationMetadataValueLen {
+			break
+		}
+		out = next
+	}
+	return out
+}
+
+func sanitizeGiftAggregation(cfg GiftAggregation) GiftAggregation {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultGiftWindow
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = DefaultGiftMaxDelay
+	}
+	if cfg.MaxDelay < cfg.Window {
+		cfg.MaxDelay = cfg.Window
+	}
+	return cfg
+}
+
+func cloneMetadata(metadata map[string]string) map[string]string {
+	out := make(map[string]string, len(metadata)+4)
+	for key, value := range metadata {
+		out[key] = value
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// updatingRepo además reescribe los grupos por ID, como la tabla de SQLite.
+type updatingRepo struct {
+	*memoryNotificationRepo
+	updates int
+}
+
+func (r *updatingRepo) UpdateNotification(_ context.Context, notification *domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, item := range r.items {
+		if item.ID == notification.ID {
+			saved := *notification
+			saved.Metadata = cloneMetadata(notification.Metadata)
+			r.items[i] = &saved
+			r.updates++
+			return nil
+		}
+	}
+	return fmt.Errorf("notification %d not found", notification.ID)
+}
+
+// fakeTimers reemplaza al reloj y a time.AfterFunc del agrupador: los cierres
+// solo corren al avanzar el reloj.
+type fakeTimers struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at   time.Time
+	fn   func()
+	done bool
+}
+
+func (f *fakeTimers) Now() time.Time { return f.now }
+
+func (f *fakeTimers) AfterFunc(d time.Duration, fn func()) func() bool {
+	timer := &fakeTimer{at: f.now.Add(d), fn: fn}
+	f.timers = append(f.timers, timer)
+	return func() bool {
+		stopped := !timer.done
+		timer.done = true
+		return stopped
+	}
+}
+
+// Advance mueve el reloj y dispara los cierres vencidos.
+func (f *fakeTimers) Advance(d time.Duration) {
+	f.now = f.now.Add(d)
+	for _, timer := range f.timers {
+		if !timer.done && !timer.at.After(f.now) {
+			timer.done = true
+			timer.fn()
+		}
+	}
+}
+
+func giftPipeline() (*Service, *updatingRepo, *capturePublisher, *fakeTimers) {
+	svc, base, pub, _ := pipeline()
+	repo := &updatingRepo{memoryNotificationRepo: base}
+	svc.repo = repo
+	clock := &fakeTimers{now: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)}
+	svc.gifts.now = clock.Now
+	svc.gifts.afterFunc = clock.AfterFunc
+	return svc, repo, pub, clock
+}
+
+// giftSub es un sub regalado como lo manda Twitch: Username es quien recibe.
+func giftSub(gifter, recipient string) *domain.Notification {
+	return &domain.Notification{
+		Type:     domain.NotificationSubscription,
+		Platform: domain.PlatformTwitch,
+		Username: recipient,
+		Metadata: map[string]string{MetadataGifter: gifter, "tier": "1000"},
+	}
+}
+
+func publishedGifts(t *testing.T, pub *capturePublisher) []NotificationDTO {
+	t.Helper()
+	var out []NotificationDTO
+	for _, event := range pub.published() {
+		dto, ok := event.Data.(NotificationDTO)
+		if !ok {
+			t.Fatalf("payload = %#v", event.Data)
+		}
+		out = append(out, dto)
+	}
+	return out
+}
+
+func TestGiftBombIsOneNotification(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, pub, clock := giftPipeline()
+
+	var group *domain.Notification
+	for i := range 50 {
+		saved, err := svc.Emit(ctx, giftSub("Ana", fmt.Sprintf("viewer%d", i)))
+		if err != nil {
+			t.Fatalf("Emit: %v", err)
+		}
+		if group != nil && saved.ID != group.ID {
+			t.Fatalf("regalo %d abrió otro grupo: %d != %d", i, saved.ID, group.ID)
+		}
+		group = saved
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	if got := repo.saved(); len(got) != 1 || repo.updates != 49 {
+		t.Fatalf("registros = %d, updates = %d; esperaba 1 y 49", len(got), repo.updates)
+	}
+	if len(pub.published()) != 0 {
+		t.Fatal("se publicó antes de cerrar la ventana")
+	}
+
+	// la ventana cuenta desde el último regalo
+	clock.Advance(DefaultGiftWindow - 200*time.Millisecond)
+	if len(pub.published()) != 0 {
+		t.Fatal("se cerró antes de la ventana")
+	}
+	clock.Advance(200 * time.Millisecond)
+
+	dtos := publishedGifts(t, pub)
+	if len(dtos) != 1 {
+		t.Fatalf("alertas = %d, esperaba 1", len(dtos))
+	}
+	dto := dtos[0]
+	if dto.Username != "Ana" || dto.Amount != 50 || dto.Metadata[MetadataGiftCount] != "50" || dto.Message != "¡Regaló 50 suscripciones!" {
+		t.Fatalf("alerta = %+v", dto)
+	}
+	if names := strings.Split(dto.Metadata[MetadataRecipients], ", "); len(names) != maxGiftRecipients || names[0] != "viewer0" {
+		t.Fatalf("recipients = %q", dto.Metadata[MetadataRecipients])
+	}
+	if stored := repo.saved()[0]; stored.Metadata[MetadataGiftCount] != "50" {
+		t.Fatalf("el registro guardado no se actualizó: %+v", stored.Metadata)
+	}
+}
+
+func TestOverlappingGiftBombs(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, pub, clock := giftPipeline()
+
+	// ana empieza; beto regala en medio de la bomba de ana
+	for range 3 {
+		svc.Emit(ctx, giftSub("ana", "x"))
+		clock.Advance(time.Second)
+	}
+	for range 5 {
+		svc.Emit(ctx, giftSub("beto", "y"))
+		svc.Emit(ctx, giftSub("ana", "x"))
+		clock.Advance(time.Second)
+	}
+	if len(repo.saved()) != 2 {
+		t.Fatalf("registros = %+v, esperaba un grupo por persona", repo.saved())
+	}
+
+	clock.Advance(DefaultGiftWindow)
+	counts := map[string]string{}
+	for _, dto := range publishedGifts(t, pub) {
+		if _, dup := counts[dto.Username]; dup {
+			t.Fatalf("%s se publicó dos veces", dto.Username)
+		}
+		counts[dto.Username] = dto.Metadata[MetadataGiftCount]
+	}
+	if len(counts) != 2 || counts["ana"] != "8" || counts["beto"] != "5" {
+		t.Fatalf("grupos = %v", counts)
+	}
+}
+
+func TestGiftBombMaxDelay(t *testing.T) {
+	ctx := context.Background()
+	svc, _, pub, clock := giftPipeline()
+
+	// un regalo cada 4s extendería la ventana para siempre
+	for range 4 {
+		svc.Emit(ctx, giftSub("ana", "x"))
+		clock.Advance(4 * time.Second)
+	}
+	if len(pub.published()) != 1 {
+		t.Fatalf("a los 16s se publicaron %d alertas, esperaba 1 por el tope de 15s", len(pub.published()))
+	}
+
+	// lo que llega después abre otro grupo
+	svc.Emit(ctx, giftSub("ana", "x"))
+	clock.Advance(DefaultGiftWindow)
+	dtos := publishedGifts(t, pub)
+	if len(dtos) != 2 || dtos[0].Metadata[MetadataGiftCount] != "4" || dtos[1].Metadata[MetadataGiftCount] != "1" {
+		t.Fatalf("alertas = %+v", dtos)
+	}
+	if dtos[1].Message != "¡Le regaló una suscripción a x!" {
+		t.Fatalf("un solo regalo = %q", dtos[1].Message)
+	}
+}
+
+func TestGiftsThatAreNotGrouped(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, pub, _ := giftPipeline()
+
+	test := giftSub("ana", "viewer")
+	test.Metadata[domain.NotificationTestKey] = "true"
+	plain := &domain.Notification{Type: domain.NotificationSubscription, Platform: domain.PlatformTwitch, Username: "carla"}
+	for _, n := range []*domain.Notification{test, plain} {
+		if _, err := svc.Emit(ctx, n); err != nil {
+			t.Fatalf("Emit: %v", err)
+		}
+	}
+	if len(pub.published()) != 2 || len(repo.saved()) != 2 {
+		t.Fatalf("una alerta de prueba o un sub propio esperó a agruparse: %d publicadas", len(pub.published()))
+	}
+
+	svc.SetGiftAggregation(GiftAggregation{Disabled: true})
+	svc.Emit(ctx, giftSub("ana", "x"))
+	svc.Emit(ctx, giftSub("ana", "y"))
+	if len(pub.published()) != 4 {
+		t.Fatalf("desactivado se agrupó: %d publicadas", len(pub.published()))
+	}
+}
+
+func TestGiftKeepRecipientsAndFlush(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, pub, _ := giftPipeline()
+	svc.SetGiftAggregation(GiftAggregation{KeepRecipients: true})
+
+	// formato de Kick: Username es quien regala
+	for _, recipient := range []string{"x", "y"} {
+		svc.Emit(ctx, &domain.Notification{
+			Type:     domain.NotificationSubscription,
+			Platform: domain.PlatformKick,
+			Username: "beto",
+			Metadata: map[string]string{MetadataGift: "true", MetadataRecipient: recipient},
+		})
+	}
+	saved := repo.saved()
+	if len(saved) != 3 {
+		t.Fatalf("registros = %d, esperaba el grupo y uno por regalo", len(saved))
+	}
+	for _, single := range saved[1:] {
+		if single.Metadata[MetadataAggregatedInto] != fmt.Sprint(saved[0].ID) {
+			t.Fatalf("regalo sin aggregated_into: %+v", single.Metadata)
+		}
+	}
+
+	svc.FlushGifts(ctx)
+	dtos := publishedGifts(t, pub)
+	if len(dtos) != 1 || dtos[0].Metadata[MetadataRecipients] != "x, y" {
+		t.Fatalf("FlushGifts publicó %+v", dtos)
+	}
+}
@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// rawEventBatchSize es cuántos eventos se juntan antes de escribir.
+	rawEventBatchSize = 50
+	// rawEventFlushInterval es lo máximo que un evento espera a guardarse.
+	rawEventFlushInterval = 5 * time.Second
+	rawEventPruneInterval = time.Hour
+	// rawEventMaxPending limita lo que se acumula si la base falla; al pasarlo
+	// se descarta lo más viejo.
+	rawEventMaxPending = 2000
+)
+
+// RawEventStore guarda los eventos crudos de las plataformas. Record no toca
+// la base: se escribe por tandas en segundo plano y lo más viejo que la
+// retención se borra solo.
+type RawEventStore struct {
+	repo      domain.RawEventRepository
+	retention time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	pending []domain.RawPlatformEvent
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+}
+
+// NewRawEventStore crea el almacén; retention <= 0 usa
+// DefaultRawEventRetention.
+func NewRawEventStore(repo domain.RawEventRepository, retention time.Duration) *RawEventStore {
+	if retention <= 0 {
+		retention = domain.DefaultRawEventRetention
+	}
+	return &RawEventStore{
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start empieza a escribir en segundo plano y poda lo vencido.
+func (s *RawEventStore) Start(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = true
+	s.mu.Unlock()
+	go s.run(ctx)
+}
+
+// Record anota un evento. No bloquea.
+func (s *RawEventStore) Record(event domain.RawPlatformEvent) {
+	if s == nil {
+		return
+	}
+	if event.ReceivedAt.IsZero() {
+		event.ReceivedAt = s.now()
+	}
+	s.mu.Lock()
+	s.pending = append(s.pending, event)
+	if over := len(s.pending) - rawEventMaxPending; over > 0 {
+		log.Printf("raw events: se descartan %d eventos sin guardar", over)
+		s.pending = append([]domain.RawPlatformEvent(nil), s.pending[over:]...)
+	}
+	full := len(s.pending) >= rawEventBatchSize
+	s.mu.Unlock()
+	if full {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush escribe ya lo pendiente. Si falla, lo pendiente se reintenta en la
+// próxima tanda.
+func (s *RawEventStore) Flush(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	s.mu.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := s.repo.SaveRawEvents(ctx, batch); err != nil {
+		s.mu.Lock()
+		s.pending = append(batch, s.pending...)
+		if over := len(s.pending) - rawEventMaxPending; over > 0 {
+			s.pending = append([]domain.RawPlatformEvent(nil), s.pending[over:]...)
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Prune borra lo recibido antes de la retención.
+func (s *RawEventStore) Prune(ctx context.Context) (int64, error) {
+	if s == nil || s.repo == nil {
+		return 0, nil
+	}
+	return s.repo.PruneRawEvents(ctx, s.now().Add(-s.retention))
+}
+
+// Close deja de escribir en segundo plano y guarda lo pendiente.
+func (s *RawEventStore) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	started := s.started
+	s.started = false
+	s.mu.Unlock()
+	if started {
+		close(s.stop)
+		<-s.done
+	}
+	return s.Flush(ctx)
+}
+
+func (s *RawEventStore) run(ctx context.Context) {
+	defer close(s.done)
+	s.prune(ctx)
+
+	flush := time.NewTicker(rawEventFlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(rawEventPruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		case <-flush.C:
+		case <-s.wake:
+		case <-prune.C:
+			s.prune(ctx)
+			continue
+		}
+		if err := s.Flush(ctx); err != nil {
+			log.Printf("raw events: no pude guardar eventos: %v", err)
+		}
+	}
+}
+
+func (s *RawEventStore) prune(ctx context.Context) {
+	n, err := s.Prune(ctx)
+	if err != nil {
+		log.Printf("raw events: no pude borrar eventos viejos: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("raw events: %d eventos viejos borrados", n)
+	}
+}
@@ -0,0 +1,192 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adeithe/go-twitch/irc"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryRawEvents hace de la tabla raw_events.
+type memoryRawEvents struct {
+	mu      sync.Mutex
+	events  []domain.RawPlatformEvent
+	batches int
+	err     error
+	before  time.Time
+}
+
+func (m *memoryRawEvents) SaveRawEvents(_ context.Context, events []domain.RawPlatformEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.batches++
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *memoryRawEvents) ListRawEvents(_ context.Context, filter domain.RawEventFilter) ([]domain.RawPlatformEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []domain.RawPlatformEvent
+	for _, event := range m.events {
+		if filter.Platform != "" && event.Platform != filter.Platform {
+			continue
+		}
+		if filter.EventType != "" && !strings.EqualFold(event.EventType, filter.EventType) {
+			continue
+		}
+		out = append(out, event)
+	}
+	return out, nil
+}
+
+func (m *memoryRawEvents) PruneRawEvents(_ context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.before = before
+	return 0, nil
+}
+
+func (m *memoryRawEvents) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func (m *memoryRawEvents) saved() ([]domain.RawPlatformEvent, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]domain.RawPlatformEvent(nil), m.events...), m.batches
+}
+
+func rawEvent(eventType string) domain.RawPlatformEvent {
+	return domain.RawPlatformEvent{Platform: domain.PlatformTwitch, EventType: eventType, Payload: "{}"}
+}
+
+func TestRawEventStoreFlushKeepsFailedBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryRawEvents{}
+	store := NewRawEventStore(repo, 0)
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	store.Record(rawEvent("sub"))
+	repo.fail(errors.New("database is locked"))
+	if err := store.Flush(ctx); err == nil {
+		t.Fatal("Flush returned nil with a failing repo")
+	}
+	store.Record(rawEvent("raid"))
+
+	// lo que falló se reintenta antes que lo nuevo
+	repo.fail(nil)
+	if err := store.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	saved, batches := repo.saved()
+	if batches != 1 || len(saved) != 2 || saved[0].EventType != "sub" || saved[1].EventType != "raid" {
+		t.Fatalf("saved = %+v in %d batches", saved, batches)
+	}
+	if !saved[0].ReceivedAt.Equal(now) {
+		t.Fatalf("ReceivedAt = %s, want %s", saved[0].ReceivedAt, now)
+	}
+
+	// sin pendientes no se escribe nada
+	if err := store.Flush(ctx); err != nil {
+		t.Fatalf("empty Flush: %v", err)
+	}
+	if _, batches := repo.saved(); batches != 1 {
+		t.Fatalf("empty Flush wrote a batch")
+	}
+
+	if _, err := store.Prune(ctx); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if want := now.Add(-domain.DefaultRawEventRetention); !repo.before.Equal(want) {
+		t.Fatalf("pruned before %s, want %s", repo.before, want)
+	}
+}
+
+func TestRawEventStoreDropsOldestWhenBacklogged(t *testing.T) {
+	repo := &memoryRawEvents{err: errors.New("disk full")}
+	store := NewRawEventStore(repo, time.Hour)
+	for i := range rawEventMaxPending + 5 {
+		store.Record(domain.RawPlatformEvent{Platform: domain.PlatformKick, EventType: "e", Payload: string(rune('a' + i%26))})
+	}
+	if err := store.Flush(context.Background()); err == nil {
+		t.Fatal("Flush returned nil with a failing repo")
+	}
+	store.mu.Lock()
+	pending := len(store.pending)
+	first := store.pending[0].Payload
+	store.mu.Unlock()
+	if pending != rawEventMaxPending || first != "f" {
+		t.Fatalf("pending = %d starting at %q, want %d starting at the 6th", pending, first, rawEventMaxPending)
+	}
+}
+
+func TestRawEventStoreWritesFullBatchInBackground(t *testing.T) {
+	repo := &memoryRawEvents{}
+	store := NewRawEventStore(repo, 0)
+	store.Start(t.Context())
+
+	for range rawEventBatchSize {
+		store.Record(rawEvent("sub"))
+	}
+	// una tanda llena despierta al escritor sin esperar al ticker
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if saved, _ := repo.saved(); len(saved) == rawEventBatchSize {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("full batch was not written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Close guarda lo que quedó a medias
+	store.Record(rawEvent("raid"))
+	if err := store.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if saved, _ := repo.saved(); len(saved) != rawEventBatchSize+1 {
+		t.Fatalf("saved %d events after Close", len(saved))
+	}
+}
+
+func TestEventLoggerRecordsIntoStore(t *testing.T) {
+	repo := &memoryRawEvents{}
+	store := NewRawEventStore(repo, 0)
+	logger := NewEventLogger()
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	logger.now = func() time.Time { return now }
+
+	// sin store solo se loguea
+	logger.HandleTwitchUserNotice(irc.UserNotice{Type: "sub"})
+	logger.SetStore(store)
+	logger.HandleTwitchUserNotice(irc.UserNotice{Type: "raid", Message: "llegamos"})
+	if err := store.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	saved, _ := repo.saved()
+	if len(saved) != 1 {
+		t.Fatalf("saved = %+v", saved)
+	}
+	got := saved[0]
+	if got.Platform != domain.PlatformTwitch || got.EventType != "raid" || !got.ReceivedAt.Equal(now) {
+		t.Fatalf("event = %+v", got)
+	}
+	if want := `"message":"llegamos"`; !strings.Contains(got.Payload, want) {
+		t.Fatalf("payload = %s, want it to contain %s", got.Payload, want)
+	}
+}
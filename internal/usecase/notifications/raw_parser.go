@@ -0,0 +1,113 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+// twitchNoticePayload es lo que EventLogger guarda de cada USERNOTICE.
+type twitchNoticePayload struct {
+	EventType string `json:"event_type"`
+	Message   string `json:"message"`
+	Sender    struct {
+		Username    string
+		DisplayName string
+	} `json:"sender"`
+	Tags map[string]string `json:"raw_tags"`
+}
+
+// ParseRawEvent convierte un evento crudo guardado en las notificaciones que
+// representa. Los eventos que no generan alertas devuelven nil sin error.
+// Por ahora solo se reconocen los USERNOTICE de Twitch: los frames de Kick
+// que no son chat se guardan, pero todavía no se sabe armar alertas con ellos.
+func ParseRawEvent(event domain.RawPlatformEvent) ([]*domain.Notification, error) {
+	switch event.Platform {
+	case domain.PlatformTwitch:
+		return parseTwitchNotice(event)
+	}
+	return nil, nil
+}
+
+func parseTwitchNotice(event domain.RawPlatformEvent) ([]*domain.Notification, error) {
+	var payload twitchNoticePayload
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("twitch usernotice: %w", err)
+	}
+	tags := payload.Tags
+	user := firstNonEmpty(tags["display-name"], payload.Sender.DisplayName, tags["login"], payload.Sender.Username)
+	msgID := strings.ToLower(firstNonEmpty(tags["msg-id"], payload.EventType, event.EventType))
+
+	metadata := map[string]string{"source": "usernotice"}
+	setTag(metadata, "user_id", tags["user-id"])
+	setTag(metadata, "message_id", tags["id"])
+	setTag(metadata, "tier", tags["msg-param-sub-plan"])
+
+	var (
+		notificationType = domain.NotificationSubscription
+		amount           float64
+		message          = payload.Message
+	)
+	switch msgID {
+	case "sub", "resub":
+		months := tagInt(tags, "msg-param-cumulative-months")
+		if months > 0 {
+			metadata["months"] = strconv.Itoa(months)
+		}
+		amount = float64(months)
+	case "subgift", "anonsubgift":
+		recipient := firstNonEmpty(tags["msg-param-recipient-display-name"], tags["msg-param-recipient-user-name"])
+		if recipient == "" {
+			return nil, fmt.Errorf("twitch usernotice %s sin destinatario", msgID)
+		}
+		// igual que las alertas en vivo: el usuario es quien recibe y
+		// MetadataGifter quien regala, así se agrupan los regalos
+		metadata[MetadataGifter] = user
+		user = recipient
+		amount = 1
+	case "raid":
+		notificationType = domain.NotificationGeneric
+		user = firstNonEmpty(tags["msg-param-displayName"], user)
+		amount = float64(tagInt(tags, "msg-param-viewerCount"))
+		metadata["event"] = "raid"
+		message = fmt.Sprintf("¡Raid con %d espectadores!", int(amount))
+	default:
+		// submysterygift llega seguido de un subgift por cada regalo
+		return nil, nil
+	}
+
+	notification, err := domain.NewNotification(string(notificationType), string(domain.PlatformTwitch), user, amount, message, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("twitch usernotice %s de %s: %w", msgID, user, err)
+	}
+	if !event.ReceivedAt.IsZero() {
+		notification.CreatedAt = event.ReceivedAt
+	}
+	return []*domain.Notification{notification}, nil
+}
+
+func setTag(metadata map[string]string, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		metadata[key] = value
+	}
+}
+
+func tagInt(tags map[string]string, key string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(tags[key]))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
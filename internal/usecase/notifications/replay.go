@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"zhatBot/internal/domain"
+)
+
+// ReplayFailure es un evento guardado que el parser no pudo leer.
+type ReplayFailure struct {
+	Event domain.RawPlatformEvent `json:"event"`
+	Error string                  `json:"error"`
+}
+
+// ReplayResult resume una repetición: cuántos eventos se leyeron, cuántos no
+// generan alertas y las notificaciones que salieron.
+type ReplayResult struct {
+	Events        int                    `json:"events"`
+	Skipped       int                    `json:"skipped"`
+	Failed        []ReplayFailure        `json:"failed"`
+	Notifications []*domain.Notification `json:"notifications"`
+}
+
+// ReplayEvents vuelve a pasar por ParseRawEvent los eventos guardados que
+// cumplen filter. No emite ni guarda nada: sirve para probar el parser con
+// datos reales sin estar en vivo.
+func (s *RawEventStore) ReplayEvents(ctx context.Context, filter domain.RawEventFilter) (ReplayResult, error) {
+	result := ReplayResult{
+		Failed:        []ReplayFailure{},
+		Notifications: []*domain.Notification{},
+	}
+	if s == nil || s.repo == nil {
+		return result, fmt.Errorf("raw events unavailable")
+	}
+	// lo pendiente también cuenta
+	if err := s.Flush(ctx); err != nil {
+		return result, err
+	}
+	events, err := s.repo.ListRawEvents(ctx, filter)
+	if err != nil {
+		return result, err
+	}
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Events++
+		notifications, err := ParseRawEvent(event)
+		if err != nil {
+			result.Failed = append(result.Failed, ReplayFailure{Event: event, Error: err.Error()})
+			continue
+		}
+		if len(notifications) == 0 {
+			result.Skipped++
+			continue
+		}
+		result.Notifications = append(result.Notifications, notifications...)
+	}
+	return result, nil
+}
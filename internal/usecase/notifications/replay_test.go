@@ -0,0 +1,106 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adeithe/go-twitch/irc"
+
+	"zhatBot/internal/domain"
+)
+
+// replayFixtures son USERNOTICE con el formato de Twitch, con menos tags.
+var replayFixtures = []string{
+	`@badge-info=subscriber/14;display-name=Ana;id=n-1;login=ana;msg-id=resub;msg-param-cumulative-months=14;msg-param-sub-plan=1000;system-msg=Ana\ssubscribed\sat\sTier\s1.;user-id=11 :tmi.twitch.tv USERNOTICE #canal :catorce meses!`,
+	`@display-name=Beto;id=n-2;login=beto;msg-id=submysterygift;msg-param-mass-gift-count=2;msg-param-sub-plan=1000;system-msg=Beto\sis\sgifting\s2\sTier\s1\sSubs!;user-id=12 :tmi.twitch.tv USERNOTICE #canal`,
+	`@display-name=Beto;id=n-3;login=beto;msg-id=subgift;msg-param-recipient-display-name=Caro;msg-param-recipient-user-name=caro;msg-param-sub-plan=1000;system-msg=Beto\sgifted\sa\sTier\s1\ssub\sto\sCaro!;user-id=12 :tmi.twitch.tv USERNOTICE #canal`,
+	`@display-name=Beto;id=n-4;login=beto;msg-id=subgift;msg-param-recipient-display-name=Dani;msg-param-sub-plan=1000;system-msg=Beto\sgifted\sa\sTier\s1\ssub\sto\sDani!;user-id=12 :tmi.twitch.tv USERNOTICE #canal`,
+	`@display-name=Eva;id=n-5;login=eva;msg-id=raid;msg-param-displayName=Eva;msg-param-viewerCount=37;system-msg=37\sraiders\sfrom\sEva;user-id=13 :tmi.twitch.tv USERNOTICE #canal`,
+	`@display-name=Fede;id=n-6;login=fede;msg-id=subgift;msg-param-sub-plan=1000;system-msg=regalo\sroto;user-id=14 :tmi.twitch.tv USERNOTICE #canal`,
+}
+
+// newReplayStore guarda las fixtures como lo hace EventLogger en vivo, más
+// un payload roto y un frame de Kick.
+func newReplayStore(t *testing.T) *RawEventStore {
+	t.Helper()
+	store := NewRawEventStore(&memoryRawEvents{}, 0)
+	logger := NewEventLogger()
+	now := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	logger.now = func() time.Time { return now }
+	logger.SetStore(store)
+
+	for _, raw := range replayFixtures {
+		msg, err := irc.NewParsedMessage(raw)
+		if err != nil {
+			t.Fatalf("NewParsedMessage(%q): %v", raw, err)
+		}
+		logger.HandleTwitchUserNotice(irc.NewUserNotice(msg))
+		now = now.Add(time.Second)
+	}
+	store.Record(domain.RawPlatformEvent{Platform: domain.PlatformTwitch, EventType: "sub", Payload: "{roto"})
+	store.Record(domain.RawPlatformEvent{Platform: domain.PlatformKick, EventType: `App\Events\SubscriptionEvent`, Payload: `{"usernames":["gus"]}`})
+	return store
+}
+
+func TestReplayEventsFixtureSet(t *testing.T) {
+	store := newReplayStore(t)
+
+	result, err := store.ReplayEvents(context.Background(), domain.RawEventFilter{})
+	if err != nil {
+		t.Fatalf("ReplayEvents: %v", err)
+	}
+	// submysterygift y el frame de Kick no generan alertas; el subgift sin
+	// destinatario y el payload roto fallan
+	if result.Events != 8 || result.Skipped != 2 || len(result.Failed) != 2 || len(result.Notifications) != 4 {
+		t.Fatalf("result = %d events, %d skipped, %d failed, %d notifications", result.Events, result.Skipped, len(result.Failed), len(result.Notifications))
+	}
+
+	want := []struct {
+		kind   domain.NotificationType
+		user   string
+		amount float64
+		gifter string
+	}{
+		{domain.NotificationSubscription, "Ana", 14, ""},
+		{domain.NotificationSubscription, "Caro", 1, "Beto"},
+		{domain.NotificationSubscription, "Dani", 1, "Beto"},
+		{domain.NotificationGeneric, "Eva", 37, ""},
+	}
+	for i, w := range want {
+		got := result.Notifications[i]
+		if got.Type != w.kind || got.Username != w.user || got.Amount != w.amount || got.Metadata[MetadataGifter] != w.gifter {
+			t.Errorf("notification %d = %+v, want %+v", i, got, w)
+		}
+		if got.Platform != domain.PlatformTwitch {
+			t.Errorf("notification %d platform = %q", i, got.Platform)
+		}
+	}
+
+	resub := result.Notifications[0]
+	if resub.Metadata["months"] != "14" || resub.Metadata["tier"] != "1000" || resub.Metadata["user_id"] != "11" || resub.Message != "Ana subscribed at Tier 1." {
+		t.Fatalf("resub = %+v", resub)
+	}
+	if want := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC); !resub.CreatedAt.Equal(want) {
+		t.Fatalf("CreatedAt = %s, want the time the event was received", resub.CreatedAt)
+	}
+	if raid := result.Notifications[3]; raid.Metadata["event"] != "raid" || raid.Message != "¡Raid con 37 espectadores!" {
+		t.Fatalf("raid = %+v", raid)
+	}
+}
+
+func TestReplayEventsFilter(t *testing.T) {
+	store := newReplayStore(t)
+
+	result, err := store.ReplayEvents(context.Background(), domain.RawEventFilter{Platform: domain.PlatformTwitch, EventType: "SUBGIFT"})
+	if err != nil {
+		t.Fatalf("ReplayEvents: %v", err)
+	}
+	if result.Events != 3 || len(result.Notifications) != 2 || len(result.Failed) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	if _, err := (&RawEventStore{}).ReplayEvents(context.Background(), domain.RawEventFilter{}); err == nil {
+		t.Fatal("replay without a repository returned nil")
+	}
+}
@@ -0,0 +1,182 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// EventTypeNotification es el tipo del envelope WS con cada alerta nueva.
+const EventTypeNotification = "notification"
+
+// Publisher envía eventos a los clientes WS conectados (overlays).
+type Publisher interface {
+	PublishEvent(ctx context.Context, eventType string, data any) error
+}
+
+type NotificationDTO struct {
+	ID        int64             `json:"id"`
+	Type      string            `json:"type"`
+	Platform  string            `json:"platform,omitempty"`
+	Username  string            `json:"username,omitempty"`
+	Amount    float64           `json:"amount,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Test      bool              `json:"test,omitempty"`
+	CreatedAt string            `json:"created_at"`
+}
+
+// Service es el pipeline de alertas: guarda la notificación y la reparte al
+// bus y a los overlays.
+type Service struct {
+	repo domain.NotificationRepository
+
+	mu           sync.RWMutex
+	publisher    Publisher
+	listener     func(NotificationDTO)
+	templateRepo domain.NotificationTemplateRepository
+	templates    map[domain.NotificationType]string
+
+	gifts *giftAggregator
+}
+
+func NewService(repo domain.NotificationRepository) *Service {
+	return &Service{repo: repo, gifts: newGiftAggregator()}
+}
+
+func (s *Service) SetPublisher(p Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publisher = p
+}
+
+// SetListener recibe cada notificación emitida (lo usa el runtime para el bus).
+func (s *Service) SetListener(fn func(NotificationDTO)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listener = fn
+}
+
+// Emit guarda la notificación y la publica. Los subs regalados se agrupan
+// por quien regala (ver GiftAggregation): se devuelve el registro del grupo y
+// la alerta sale al cerrarse.
+func (s *Service) Emit(ctx context.Context, notification *domain.Notification) (*domain.Notification, error) {
+	if notification == nil {
+		return nil, fmt.Errorf("notificación vacía")
+	}
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	if notification.Metadata == nil {
+		notification.Metadata = make(map[string]string)
+	}
+
+	if gifter, recipient, ok := giftParties(notification); ok && !s.giftsDisabled() {
+		return s.emitGift(ctx, notification, gifter, recipient)
+	}
+
+	saved := notification
+	if s.repo != nil {
+		var err error
+		saved, err = s.repo.SaveNotification(ctx, notification)
+		if err != nil {
+			return nil, err
+		}
+		if saved == nil {
+			return nil, fmt.Errorf("notification not saved")
+		}
+	}
+
+	s.publish(ctx, saved)
+	return saved, nil
+}
+
+// publish reparte una notificación ya guardada al bus y a los overlays.
+func (s *Service) publish(ctx context.Context, saved *domain.Notification) {
+	dto := ToDTO(saved)
+	dto.Text = s.FormatNotification(saved)
+	s.mu.RLock()
+	publisher, listener := s.publisher, s.listener
+	s.mu.RUnlock()
+	if listener != nil {
+		listener(dto)
+	}
+	if publisher != nil {
+		if err := publisher.PublishEvent(ctx, EventTypeNotification, dto); err != nil {
+			log.Printf("notifications: publish error: %v", err)
+		}
+	}
+}
+
+func (s *Service) giftsDisabled() bool {
+	s.gifts.mu.Lock()
+	defer s.gifts.mu.Unlock()
+	return s.gifts.cfg.Disabled
+}
+
+// Test arma una notificación falsa del tipo pedido y la pasa por el pipeline
+// completo. Queda marcada con Metadata["test"]="true".
+func (s *Service) Test(ctx context.Context, notificationType domain.NotificationType, platform domain.Platform, requestedBy string) (*domain.Notification, error) {
+	notification := TestNotification(notificationType, platform)
+	if requestedBy = strings.TrimSpace(requestedBy); requestedBy != "" {
+		notification.Metadata["requested_by"] = requestedBy
+	}
+	return s.Emit(ctx, notification)
+}
+
+// TestNotification devuelve datos de ejemplo para cada tipo de alerta.
+func TestNotification(notificationType domain.NotificationType, platform domain.Platform) *domain.Notification {
+	if notificationType == "" {
+		notificationType = domain.NotificationGeneric
+	}
+	notification := &domain.Notification{
+		Type:      notificationType,
+		Platform:  platform,
+		Username:  "zhatbot_test",
+		Metadata:  map[string]string{domain.NotificationTestKey: "true"},
+		CreatedAt: time.Now(),
+	}
+	switch notificationType {
+	case domain.NotificationSubscription:
+		notification.Message = "¡Gracias por la suscripción! (prueba)"
+		notification.Metadata["tier"] = "1000"
+	case domain.NotificationDonation:
+		notification.Amount = 5
+		notification.Message = "¡Esto es una donación de prueba!"
+	case domain.NotificationBits:
+		notification.Amount = 100
+		notification.Message = "Cheer100 ¡bits de prueba!"
+	case domain.NotificationGiveawayWinner:
+		notification.Message = "¡Ganó el sorteo de prueba!"
+	default:
+		notification.Message = "Alerta de prueba"
+	}
+	return notification
+}
+
+func ToDTO(item *domain.Notification) NotificationDTO {
+	if item == nil {
+		return NotificationDTO{}
+	}
+	created := ""
+	if !item.CreatedAt.IsZero() {
+		created = item.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return NotificationDTO{
+		ID:        item.ID,
+		Type:      string(item.Type),
+		Platform:  string(item.Platform),
+		Username:  item.Username,
+		Amount:    item.Amount,
+		Message:   item.Message,
+		Metadata:  item.Metadata,
+		Test:      item.IsTest(),
+		CreatedAt: created,
+	}
+}
@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestTestAlertRunsFullPipeline(t *testing.T) {
+	svc, repo, pub, bus := pipeline()
+
+	saved, err := svc.Test(context.Background(), domain.NotificationSubscription, domain.PlatformTwitch, "Zero")
+	if err != nil {
+		t.Fatalf("Test: %v", err)
+	}
+	if saved.ID == 0 || !saved.IsTest() {
+		t.Fatalf("saved = %+v, want persisted test notification", saved)
+	}
+	if saved.Metadata["requested_by"] != "Zero" || saved.Metadata["tier"] != "1000" {
+		t.Fatalf("metadata = %v", saved.Metadata)
+	}
+
+	if got := repo.saved(); len(got) != 1 || !got[0].IsTest() {
+		t.Fatalf("repo = %+v, want one test notification", got)
+	}
+	if len(*bus) != 1 || !(*bus)[0].Test || (*bus)[0].ID != saved.ID {
+		t.Fatalf("bus = %+v, want the saved test notification", *bus)
+	}
+	events := pub.published()
+	if len(events) != 1 || events[0].Type != EventTypeNotification {
+		t.Fatalf("overlay events = %+v", events)
+	}
+	dto, ok := events[0].Data.(NotificationDTO)
+	if !ok || !dto.Test || dto.Type != string(domain.NotificationSubscription) || dto.Platform != string(domain.PlatformTwitch) {
+		t.Fatalf("overlay payload = %#v", events[0].Data)
+	}
+}
+
+func TestTestNotificationSampleData(t *testing.T) {
+	cases := []struct {
+		typ    domain.NotificationType
+		amount float64
+	}{
+		{domain.NotificationSubscription, 0},
+		{domain.NotificationDonation, 5},
+		{domain.NotificationBits, 100},
+		{domain.NotificationGiveawayWinner, 0},
+		{domain.NotificationGeneric, 0},
+		{"", 0},
+	}
+	for _, tc := range cases {
+		n := TestNotification(tc.typ, domain.PlatformKick)
+		if !n.IsTest() || n.Message == "" || n.Username == "" {
+			t.Errorf("%q: sample = %+v", tc.typ, n)
+		}
+		if n.Amount != tc.amount {
+			t.Errorf("%q: amount = %v, want %v", tc.typ, n.Amount, tc.amount)
+		}
+		if tc.typ == "" && n.Type != domain.NotificationGeneric {
+			t.Errorf("empty type became %q, want generic", n.Type)
+		}
+	}
+}
+
+func TestTestAlertSaveErrorPublishesNothing(t *testing.T) {
+	svc, repo, pub, bus := pipeline()
+	repo.err = errors.New("disk full")
+
+	if _, err := svc.Test(context.Background(), domain.NotificationBits, domain.PlatformTwitch, ""); err == nil {
+		t.Fatal("expected save error")
+	}
+	if len(*bus) != 0 || len(pub.published()) != 0 {
+		t.Fatal("failed save was still published")
+	}
+}
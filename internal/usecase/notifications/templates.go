@@ -0,0 +1,157 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"zhatBot/internal/domain"
+)
+
+var ErrInvalidTemplate = errors.New("plantilla inválida")
+
+type TemplateDTO struct {
+	Type     string `json:"type"`
+	Template string `json:"template"`
+	Default  bool   `json:"default"`
+}
+
+// SetTemplateRepository habilita guardar plantillas personalizadas.
+func (s *Service) SetTemplateRepository(repo domain.NotificationTemplateRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templateRepo = repo
+}
+
+// LoadTemplates lee las plantillas personalizadas guardadas.
+func (s *Service) LoadTemplates(ctx context.Context) error {
+	s.mu.RLock()
+	repo := s.templateRepo
+	s.mu.RUnlock()
+	if repo == nil {
+		return nil
+	}
+	stored, err := repo.GetNotificationTemplates(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates = make(map[domain.NotificationType]string, len(stored))
+	for notificationType, template := range stored {
+		if strings.TrimSpace(template) != "" {
+			s.templates[notificationType] = template
+		}
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.LoadTemplates(ctx)
+}
+
+// Templates devuelve la plantilla efectiva de cada tipo.
+func (s *Service) Templates() []TemplateDTO {
+	defaults := domain.DefaultNotificationTemplates()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]TemplateDTO, 0, len(defaults))
+	for notificationType, template := range defaults {
+		item := TemplateDTO{Type: string(notificationType), Template: template, Default: true}
+		if custom, ok := s.templates[notificationType]; ok {
+			item.Template = custom
+			item.Default = false
+		}
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Type < out[j].Type })
+	return out
+}
+
+// SetTemplate guarda la plantilla de un tipo. Una plantilla vacía vuelve a la
+// de fábrica.
+func (s *Service) SetTemplate(ctx context.Context, notificationType domain.NotificationType, template string) (TemplateDTO, error) {
+	notificationType, err := domain.ParseNotificationType(string(notificationType))
+	if err != nil {
+		return TemplateDTO{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	template = strings.TrimSpace(template)
+
+	s.mu.Lock()
+	next := make(map[domain.NotificationType]string, len(s.templates)+1)
+	for key, value := range s.templates {
+		next[key] = value
+	}
+	if template == "" {
+		delete(next, notificationType)
+	} else {
+		next[notificationType] = template
+	}
+	repo := s.templateRepo
+	s.mu.Unlock()
+
+	if repo != nil {
+		if err := repo.SetNotificationTemplates(ctx, next); err != nil {
+			return TemplateDTO{}, fmt.Errorf("no pude guardar la plantilla: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.templates = next
+	s.mu.Unlock()
+
+	if template == "" {
+		return TemplateDTO{Type: string(notificationType), Template: domain.DefaultNotificationTemplates()[notificationType], Default: true}, nil
+	}
+	return TemplateDTO{Type: string(notificationType), Template: template}, nil
+}
+
+// ResetTemplate vuelve a la plantilla de fábrica.
+func (s *Service) ResetTemplate(ctx context.Context, notificationType domain.NotificationType) (TemplateDTO, error) {
+	return s.SetTemplate(ctx, notificationType, "")
+}
+
+// FormatNotification arma el texto de la notificación con la plantilla de su tipo.
+func (s *Service) FormatNotification(n *domain.Notification) string {
+	if n == nil {
+		return ""
+	}
+	template := ""
+	if s != nil {
+		s.mu.RLock()
+		template = s.templates[n.Type]
+		s.mu.RUnlock()
+	}
+	if template == "" && isGiftRecord(n) {
+		template = giftTemplate
+	}
+	if template == "" {
+		defaults := domain.DefaultNotificationTemplates()
+		template = defaults[n.Type]
+		if template == "" {
+			template = defaults[domain.NotificationGeneric]
+		}
+	}
+	return RenderTemplate(template, n)
+}
+
+// RenderTemplate reemplaza {username}, {amount} y {message}. Si el mensaje está
+// vacío se limpian los separadores que quedan colgando al final.
+func RenderTemplate(template string, n *domain.Notification) string {
+	if n == nil {
+		return template
+	}
+	amount := ""
+	if n.Amount != 0 {
+		amount = strconv.FormatFloat(n.Amount, 'f', -1, 64)
+	}
+	text := strings.NewReplacer(
+		"{username}", strings.TrimSpace(n.Username),
+		"{amount}", amount,
+		"{message}", strings.TrimSpace(n.Message),
+	).Replace(template)
+	return strings.TrimRight(strings.TrimSpace(text), " :-")
+}
@@ -0,0 +1,139 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestFormatNotificationDefaults(t *testing.T) {
+	svc := NewService(nil)
+	cases := []struct {
+		name string
+		n    domain.Notification
+		want string
+	}{
+		{"subscription", domain.Notification{Type: domain.NotificationSubscription, Username: "ana", Message: "¡hola!"}, "🎉 ¡ana se suscribió! ¡hola!"},
+		{"subscription without message", domain.Notification{Type: domain.NotificationSubscription, Username: "ana"}, "🎉 ¡ana se suscribió!"},
+		{"donation", domain.Notification{Type: domain.NotificationDonation, Username: "luis", Amount: 2.5, Message: "gracias"}, "💸 luis donó 2.5: gracias"},
+		{"donation without message", domain.Notification{Type: domain.NotificationDonation, Username: "luis", Amount: 10}, "💸 luis donó 10"},
+		{"bits", domain.Notification{Type: domain.NotificationBits, Username: "eva", Amount: 100, Message: "Cheer100"}, "💎 eva envió 100 bits: Cheer100"},
+		{"giveaway", domain.Notification{Type: domain.NotificationGiveawayWinner, Username: "max"}, "🏆 ¡max ganó el sorteo!"},
+		{"follow", domain.Notification{Type: domain.NotificationFollow, Username: "sol"}, "💜 ¡sol empezó a seguir el canal!"},
+		{"generic", domain.Notification{Type: domain.NotificationGeneric, Username: "bot", Message: "aviso"}, "🔔 bot: aviso"},
+		{"unknown type uses generic", domain.Notification{Type: "raid", Username: "bot", Message: "aviso"}, "🔔 bot: aviso"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := svc.FormatNotification(&tc.n); got != tc.want {
+				t.Fatalf("FormatNotification = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if got := svc.FormatNotification(nil); got != "" {
+		t.Fatalf("nil notification = %q", got)
+	}
+}
+
+func TestTemplateCRUD(t *testing.T) {
+	repo := &memoryTemplateRepo{}
+	svc := NewService(nil)
+	svc.SetTemplateRepository(repo)
+	ctx := context.Background()
+	n := &domain.Notification{Type: domain.NotificationBits, Username: "eva", Amount: 50}
+
+	item, err := svc.SetTemplate(ctx, "Bits", "  {username} tiró {amount} 💎  ")
+	if err != nil {
+		t.Fatalf("SetTemplate: %v", err)
+	}
+	if item.Type != "bits" || item.Template != "{username} tiró {amount} 💎" || item.Default {
+		t.Fatalf("item = %+v", item)
+	}
+	if repo.templates[domain.NotificationBits] != "{username} tiró {amount} 💎" {
+		t.Fatalf("stored = %v", repo.templates)
+	}
+	if got := svc.FormatNotification(n); got != "eva tiró 50 💎" {
+		t.Fatalf("custom render = %q", got)
+	}
+
+	found := false
+	for _, tpl := range svc.Templates() {
+		if tpl.Type == "bits" {
+			found = true
+			if tpl.Default {
+				t.Fatal("custom template listed as default")
+			}
+		} else if !tpl.Default {
+			t.Fatalf("%s should still be the default", tpl.Type)
+		}
+	}
+	if !found || len(svc.Templates()) != len(domain.DefaultNotificationTemplates()) {
+		t.Fatalf("templates = %+v", svc.Templates())
+	}
+
+	reset, err := svc.ResetTemplate(ctx, domain.NotificationBits)
+	if err != nil {
+		t.Fatalf("ResetTemplate: %v", err)
+	}
+	if !reset.Default || reset.Template != domain.DefaultNotificationTemplates()[domain.NotificationBits] {
+		t.Fatalf("reset = %+v", reset)
+	}
+	if _, ok := repo.templates[domain.NotificationBits]; ok {
+		t.Fatal("reset template still stored")
+	}
+	if got := svc.FormatNotification(n); got != "💎 eva envió 50 bits" {
+		t.Fatalf("render after reset = %q", got)
+	}
+}
+
+func TestSetTemplateErrors(t *testing.T) {
+	repo := &memoryTemplateRepo{}
+	svc := NewService(nil)
+	svc.SetTemplateRepository(repo)
+
+	if _, err := svc.SetTemplate(context.Background(), "raid", "x"); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("unknown type err = %v, want ErrInvalidTemplate", err)
+	}
+
+	repo.err = errors.New("db locked")
+	if _, err := svc.SetTemplate(context.Background(), domain.NotificationFollow, "{username} llegó"); err == nil || errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("save err = %v", err)
+	}
+	n := &domain.Notification{Type: domain.NotificationFollow, Username: "sol"}
+	if got := svc.FormatNotification(n); got != "💜 ¡sol empezó a seguir el canal!" {
+		t.Fatalf("failed save changed the template: %q", got)
+	}
+}
+
+func TestLoadTemplatesFromRepository(t *testing.T) {
+	repo := &memoryTemplateRepo{templates: map[domain.NotificationType]string{
+		domain.NotificationFollow:       "{username} ya sigue",
+		domain.NotificationSubscription: "   ",
+	}}
+	svc := NewService(nil)
+	svc.SetTemplateRepository(repo)
+	if err := svc.LoadTemplates(context.Background()); err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+
+	if got := svc.FormatNotification(&domain.Notification{Type: domain.NotificationFollow, Username: "sol"}); got != "sol ya sigue" {
+		t.Fatalf("follow = %q", got)
+	}
+	if got := svc.FormatNotification(&domain.Notification{Type: domain.NotificationSubscription, Username: "ana"}); got != "🎉 ¡ana se suscribió!" {
+		t.Fatalf("blank stored template should fall back to default, got %q", got)
+	}
+}
+
+func TestEmitCarriesFormattedText(t *testing.T) {
+	svc, _, pub, _ := pipeline()
+	if _, err := svc.Emit(context.Background(), &domain.Notification{Type: domain.NotificationDonation, Username: "luis", Amount: 3}); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	dto := pub.published()[0].Data.(NotificationDTO)
+	if dto.Text != "💸 luis donó 3" {
+		t.Fatalf("text = %q", dto.Text)
+	}
+}
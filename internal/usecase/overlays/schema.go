@@ -0,0 +1,128 @@
+package overlays
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type fieldKind int
+
+const (
+	kindNumber fieldKind = iota
+	kindBool
+	kindString
+	kindColor
+)
+
+type fieldSpec struct {
+	kind     fieldKind
+	min, max float64
+	maxLen   int
+}
+
+// schemas define los campos permitidos por tipo de overlay.
+var schemas = map[string]map[string]fieldSpec{
+	"chat": {
+		"font_size":        {kind: kindNumber, min: 8, max: 96},
+		"font_family":      {kind: kindString, maxLen: 100},
+		"text_color":       {kind: kindColor},
+		"background_color": {kind: kindColor},
+		"hide_commands":    {kind: kindBool},
+		"show_badges":      {kind: kindBool},
+		"show_platform":    {kind: kindBool},
+		"max_messages":     {kind: kindNumber, min: 1, max: 200},
+		"message_timeout":  {kind: kindNumber, min: 0, max: 3600},
+	},
+	"alerts": {
+		"font_size":        {kind: kindNumber, min: 8, max: 96},
+		"font_family":      {kind: kindString, maxLen: 100},
+		"text_color":       {kind: kindColor},
+		"background_color": {kind: kindColor},
+		"accent_color":     {kind: kindColor},
+		"duration_ms":      {kind: kindNumber, min: 1000, max: 60000},
+		"volume":           {kind: kindNumber, min: 0, max: 1},
+	},
+}
+
+// defaults se devuelven cuando el overlay aún no tiene configuración.
+var defaults = map[string]map[string]any{
+	"chat": {
+		"font_size":        18,
+		"text_color":       "#ffffff",
+		"background_color": "transparent",
+		"hide_commands":    false,
+		"show_badges":      true,
+		"show_platform":    true,
+		"max_messages":     30,
+	},
+	"alerts": {
+		"font_size":    32,
+		"text_color":   "#ffffff",
+		"accent_color": "#9146ff",
+		"duration_ms":  6000,
+		"volume":       0.8,
+	},
+}
+
+var (
+	overlayNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,47}$`)
+	colorPattern       = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+)
+
+// overlayType obtiene el tipo a partir del nombre: "chat" o "chat-vertical" -> "chat".
+func overlayType(name string) (string, error) {
+	if !overlayNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: nombre inválido %q", ErrUnknownOverlay, name)
+	}
+	kind, _, _ := strings.Cut(name, "-")
+	if _, ok := schemas[kind]; !ok {
+		return "", fmt.Errorf("%w: tipo %q", ErrUnknownOverlay, kind)
+	}
+	return kind, nil
+}
+
+func validate(kind string, cfg map[string]any) error {
+	schema := schemas[kind]
+	for key, value := range cfg {
+		spec, ok := schema[key]
+		if !ok {
+			return fmt.Errorf("%w: campo desconocido %q", ErrInvalidConfig, key)
+		}
+		if err := spec.check(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f fieldSpec) check(key string, value any) error {
+	switch f.kind {
+	case kindNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%w: %s debe ser un número", ErrInvalidConfig, key)
+		}
+		if n < f.min || n > f.max {
+			return fmt.Errorf("%w: %s fuera de rango (%g-%g)", ErrInvalidConfig, key, f.min, f.max)
+		}
+	case kindBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%w: %s debe ser true/false", ErrInvalidConfig, key)
+		}
+	case kindString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s debe ser texto", ErrInvalidConfig, key)
+		}
+		if f.maxLen > 0 && len(s) > f.maxLen {
+			return fmt.Errorf("%w: %s es demasiado largo", ErrInvalidConfig, key)
+		}
+	case kindColor:
+		s, ok := value.(string)
+		if !ok || (s != "transparent" && !colorPattern.MatchString(s)) {
+			return fmt.Errorf("%w: %s debe ser un color #RRGGBB o transparent", ErrInvalidConfig, key)
+		}
+	}
+	return nil
+}
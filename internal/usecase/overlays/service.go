@@ -0,0 +1,151 @@
+// Package overlays guarda y publica la configuración de los overlays para OBS.
+package overlays
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// EventTypeConfig es el tipo del envelope WS que reciben los overlays.
+const EventTypeConfig = "overlay-config"
+
+var (
+	ErrUnknownOverlay = errors.New("overlay desconocido")
+	ErrInvalidConfig  = errors.New("configuración de overlay inválida")
+)
+
+// Publisher envía eventos a los clientes WS conectados.
+type Publisher interface {
+	PublishEvent(ctx context.Context, eventType string, data any) error
+}
+
+type ConfigDTO struct {
+	Name   string         `json:"name"`
+	Type   string         `json:"type"`
+	Config map[string]any `json:"config"`
+}
+
+type Service struct {
+	repo domain.OverlayConfigRepository
+
+	mu        sync.RWMutex
+	publisher Publisher
+}
+
+func NewService(repo domain.OverlayConfigRepository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) SetPublisher(p Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publisher = p
+}
+
+// Get devuelve la configuración guardada o los valores por defecto del tipo.
+func (s *Service) Get(ctx context.Context, name string) (ConfigDTO, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	kind, err := overlayType(name)
+	if err != nil {
+		return ConfigDTO{}, err
+	}
+
+	cfg := cloneMap(defaults[kind])
+	if s.repo != nil {
+		raw, err := s.repo.GetOverlayConfig(ctx, name)
+		if err != nil {
+			return ConfigDTO{}, err
+		}
+		if len(raw) > 0 {
+			stored := map[string]any{}
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				log.Printf("overlays: configuración corrupta para %s: %v", name, err)
+			} else {
+				cfg = stored
+			}
+		}
+	}
+
+	return ConfigDTO{Name: name, Type: kind, Config: cfg}, nil
+}
+
+// Set valida, guarda y notifica a los overlays conectados.
+func (s *Service) Set(ctx context.Context, name string, cfg map[string]any) (ConfigDTO, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	kind, err := overlayType(name)
+	if err != nil {
+		return ConfigDTO{}, err
+	}
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+
+	// normaliza números (p. ej. int desde Wails) pasando por JSON
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ConfigDTO{}, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	normalized := map[string]any{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return ConfigDTO{}, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if err := validate(kind, normalized); err != nil {
+		return ConfigDTO{}, err
+	}
+
+	if s.repo != nil {
+		if err := s.repo.SaveOverlayConfig(ctx, name, data); err != nil {
+			return ConfigDTO{}, err
+		}
+	}
+
+	result := ConfigDTO{Name: name, Type: kind, Config: normalized}
+
+	s.mu.RLock()
+	publisher := s.publisher
+	s.mu.RUnlock()
+	if publisher != nil {
+		if err := publisher.PublishEvent(ctx, EventTypeConfig, result); err != nil {
+			log.Printf("overlays: no pude notificar el cambio de %s: %v", name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// List devuelve los overlays que tienen configuración guardada (los que
+// usan los valores por defecto no aparecen).
+func (s *Service) List(ctx context.Context) ([]ConfigDTO, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	names, err := s.repo.ListOverlayConfigNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ConfigDTO, 0, len(names))
+	for _, name := range names {
+		cfg, err := s.Get(ctx, name)
+		if err != nil {
+			// nombres viejos que ya no tienen tipo conocido
+			continue
+		}
+		out = append(out, cfg)
+	}
+	return out, nil
+}
+
+func cloneMap(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
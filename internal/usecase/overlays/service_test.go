@@ -0,0 +1,196 @@
+package overlays
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type memoryOverlayRepo struct {
+	mu    sync.Mutex
+	data  map[string][]byte
+	saves int
+}
+
+func newMemoryOverlayRepo() *memoryOverlayRepo {
+	return &memoryOverlayRepo{data: map[string][]byte{}}
+}
+
+func (r *memoryOverlayRepo) GetOverlayConfig(_ context.Context, name string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data[name], nil
+}
+
+func (r *memoryOverlayRepo) SaveOverlayConfig(_ context.Context, name string, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data[name] = append([]byte(nil), data...)
+	r.saves++
+	return nil
+}
+
+func (r *memoryOverlayRepo) ListOverlayConfigNames(context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.data))
+	for name := range r.data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+type publishedEvent struct {
+	eventType string
+	data      any
+}
+
+// fakeWSClient hace de cliente WS: recibe lo que se publica a los overlays.
+type fakeWSClient struct {
+	mu     sync.Mutex
+	events []publishedEvent
+	err    error
+}
+
+func (c *fakeWSClient) PublishEvent(_ context.Context, eventType string, data any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, publishedEvent{eventType: eventType, data: data})
+	return c.err
+}
+
+func (c *fakeWSClient) received() []publishedEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]publishedEvent(nil), c.events...)
+}
+
+func TestSetRejectsInvalidConfig(t *testing.T) {
+	cases := []struct {
+		name    string
+		overlay string
+		cfg     map[string]any
+		want    error
+	}{
+		{"unknown overlay type", "ticker", map[string]any{}, ErrUnknownOverlay},
+		{"invalid overlay name", "../chat", map[string]any{}, ErrUnknownOverlay},
+		{"unknown field", "chat", map[string]any{"volume": 0.5}, ErrInvalidConfig},
+		{"number below range", "chat", map[string]any{"font_size": 4}, ErrInvalidConfig},
+		{"number above range", "alerts", map[string]any{"volume": 2}, ErrInvalidConfig},
+		{"number as text", "chat", map[string]any{"max_messages": "10"}, ErrInvalidConfig},
+		{"bool as text", "chat", map[string]any{"show_badges": "yes"}, ErrInvalidConfig},
+		{"bad color", "alerts", map[string]any{"accent_color": "purple"}, ErrInvalidConfig},
+		{"text too long", "chat", map[string]any{"font_family": string(make([]byte, 101))}, ErrInvalidConfig},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newMemoryOverlayRepo()
+			client := &fakeWSClient{}
+			svc := NewService(repo)
+			svc.SetPublisher(client)
+
+			_, err := svc.Set(context.Background(), tc.overlay, tc.cfg)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Set error = %v, want %v", err, tc.want)
+			}
+			if repo.saves != 0 {
+				t.Fatalf("invalid config was saved %d times", repo.saves)
+			}
+			if got := client.received(); len(got) != 0 {
+				t.Fatalf("invalid config was pushed to overlays: %+v", got)
+			}
+		})
+	}
+}
+
+func TestSetPushesConfigToConnectedOverlays(t *testing.T) {
+	repo := newMemoryOverlayRepo()
+	client := &fakeWSClient{}
+	svc := NewService(repo)
+	svc.SetPublisher(client)
+
+	got, err := svc.Set(context.Background(), " Chat-Vertical ", map[string]any{
+		"font_size":  24,
+		"text_color": "#ff0000",
+	})
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got.Name != "chat-vertical" || got.Type != "chat" {
+		t.Fatalf("Set = %+v, want name chat-vertical of type chat", got)
+	}
+
+	events := client.received()
+	if len(events) != 1 {
+		t.Fatalf("pushed %d events, want 1", len(events))
+	}
+	if events[0].eventType != EventTypeConfig {
+		t.Fatalf("event type = %q, want %q", events[0].eventType, EventTypeConfig)
+	}
+	pushed, ok := events[0].data.(ConfigDTO)
+	if !ok {
+		t.Fatalf("event data = %T, want ConfigDTO", events[0].data)
+	}
+	if pushed.Name != "chat-vertical" || pushed.Config["font_size"] != float64(24) || pushed.Config["text_color"] != "#ff0000" {
+		t.Fatalf("pushed config = %+v", pushed)
+	}
+}
+
+func TestSetSavesEvenWhenPushFails(t *testing.T) {
+	repo := newMemoryOverlayRepo()
+	svc := NewService(repo)
+	svc.SetPublisher(&fakeWSClient{err: errors.New("sin clientes")})
+
+	if _, err := svc.Set(context.Background(), "alerts", map[string]any{"volume": 0.3}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, err := svc.Get(context.Background(), "alerts")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Config["volume"] != 0.3 {
+		t.Fatalf("stored volume = %v, want 0.3", got.Config["volume"])
+	}
+}
+
+func TestGetReturnsDefaultsUntilSaved(t *testing.T) {
+	svc := NewService(newMemoryOverlayRepo())
+
+	got, err := svc.Get(context.Background(), "chat")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Config["font_size"] != 18 || got.Config["show_badges"] != true {
+		t.Fatalf("defaults = %+v", got.Config)
+	}
+
+	// modificar lo devuelto no debe tocar los valores por defecto
+	got.Config["font_size"] = 99
+	again, _ := svc.Get(context.Background(), "chat")
+	if again.Config["font_size"] != 18 {
+		t.Fatalf("defaults were mutated: %+v", again.Config)
+	}
+}
+
+func TestListOnlyReturnsSavedOverlays(t *testing.T) {
+	repo := newMemoryOverlayRepo()
+	repo.data["viejo"] = []byte(`{}`)
+	svc := NewService(repo)
+
+	if _, err := svc.Set(context.Background(), "chat-main", map[string]any{"hide_commands": true}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	list, err := svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "chat-main" || list[0].Config["hide_commands"] != true {
+		t.Fatalf("List = %+v, want only chat-main", list)
+	}
+}
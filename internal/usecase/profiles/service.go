@@ -0,0 +1,263 @@
+// Package profiles completa los mensajes del chat con datos del perfil del
+// usuario (avatar, verificado) que la plataforma no manda en cada mensaje.
+// Las consultas son asíncronas: el primer mensaje de un usuario sale sin
+// enriquecer y, cuando llega el perfil, se avisa con onEnriched.
+package profiles
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	DefaultCapacity = 2000
+	DefaultWorkers  = 2
+
+	queueSize = 256
+	// profileTTL es cada cuánto se vuelve a pedir un perfil ya conocido.
+	profileTTL = time.Hour
+	// failureTTL evita reintentar en cada mensaje a un usuario que falló.
+	failureTTL   = 5 * time.Minute
+	fetchTimeout = 5 * time.Second
+)
+
+// ErrUnsupported indica que la plataforma no tiene consulta de perfiles (o
+// no está conectada).
+var ErrUnsupported = errors.New("profiles: plataforma sin consulta de perfiles")
+
+// FetcherFunc devuelve el fetcher de la plataforma, o nil si no hay.
+type FetcherFunc func(platform domain.Platform) domain.UserProfileFetcher
+
+type key struct {
+	platform domain.Platform
+	userID   string
+}
+
+type entry struct {
+	key     key
+	profile domain.UserProfile
+	failed  bool
+	expires time.Time
+}
+
+type job struct {
+	key   key
+	login string
+	name  string
+}
+
+type Service struct {
+	fetchers FetcherFunc
+	capacity int
+	jobs     chan job
+
+	mu         sync.Mutex
+	order      *list.List
+	entries    map[key]*list.Element
+	pending    map[key]bool
+	verified   map[key]bool
+	now        func() time.Time
+	onEnriched func(domain.UserProfile)
+}
+
+// NewService crea el servicio con un caché LRU de capacity perfiles.
+func NewService(fetchers FetcherFunc, capacity int) *Service {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Service{
+		fetchers: fetchers,
+		capacity: capacity,
+		jobs:     make(chan job, queueSize),
+		order:    list.New(),
+		entries:  make(map[key]*list.Element),
+		pending:  make(map[key]bool),
+		verified: make(map[key]bool),
+		now:      time.Now,
+	}
+}
+
+// SetEnrichedHandler recibe cada perfil nuevo que trae un worker (el runtime
+// lo publica como user:enriched).
+func (s *Service) SetEnrichedHandler(fn func(domain.UserProfile)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onEnriched = fn
+}
+
+// Run atiende la cola de consultas hasta que se cancele ctx. El runtime
+// arranca DefaultWorkers goroutines con Run.
+func (s *Service) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-s.jobs:
+			s.process(ctx, j)
+		}
+	}
+}
+
+// Enrich completa el mensaje con el perfil en caché. Si no está (o venció)
+// encola la consulta y devuelve el mensaje tal cual; nunca bloquea.
+func (s *Service) Enrich(msg domain.Message) domain.Message {
+	if s == nil || strings.TrimSpace(msg.UserID) == "" || s.fetcher(msg.Platform) == nil {
+		return msg
+	}
+	k := key{platform: msg.Platform, userID: msg.UserID}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if msg.IsVerified {
+		s.verified[k] = true
+	}
+	if el, ok := s.entries[k]; ok {
+		s.order.MoveToFront(el)
+		e := el.Value.(*entry)
+		if !e.failed {
+			msg.AvatarURL = e.profile.AvatarURL
+			if msg.IsVerified {
+				e.profile.Verified = true
+			}
+		}
+		if s.now().Before(e.expires) {
+			return msg
+		}
+	}
+
+	if s.pending[k] {
+		return msg
+	}
+	select {
+	case s.jobs <- job{key: k, login: msg.LoginName(), name: msg.Name()}:
+		s.pending[k] = true
+	default:
+		// cola llena: se reintenta con el próximo mensaje del usuario
+	}
+	return msg
+}
+
+// Lookup devuelve el perfil desde el caché o lo consulta en el momento.
+func (s *Service) Lookup(ctx context.Context, platform domain.Platform, userID string) (domain.UserProfile, error) {
+	userID = strings.TrimSpace(userID)
+	k := key{platform: platform, userID: userID}
+
+	s.mu.Lock()
+	if el, ok := s.entries[k]; ok {
+		e := el.Value.(*entry)
+		if !e.failed && s.now().Before(e.expires) {
+			s.order.MoveToFront(el)
+			profile := e.profile
+			s.mu.Unlock()
+			return profile, nil
+		}
+	}
+	s.mu.Unlock()
+
+	fetcher := s.fetcher(platform)
+	if fetcher == nil {
+		return domain.UserProfile{}, ErrUnsupported
+	}
+	profile, err := fetcher.FetchUserProfile(ctx, userID)
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+	return s.store(k, *profile), nil
+}
+
+// Len devuelve cuántos perfiles hay en caché.
+func (s *Service) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *Service) process(ctx context.Context, j job) {
+	fetcher := s.fetcher(j.key.platform)
+	if fetcher == nil {
+		s.mu.Lock()
+		delete(s.pending, j.key)
+		s.mu.Unlock()
+		return
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	profile, err := fetcher.FetchUserProfile(callCtx, j.key.userID)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("profiles: no pude obtener %s/%s: %v", j.key.platform, j.key.userID, err)
+		}
+		s.mu.Lock()
+		delete(s.pending, j.key)
+		s.putLocked(&entry{
+			key:     j.key,
+			profile: domain.UserProfile{Platform: j.key.platform, UserID: j.key.userID, Login: j.login, DisplayName: j.name},
+			failed:  true,
+			expires: s.now().Add(failureTTL),
+		})
+		s.mu.Unlock()
+		return
+	}
+
+	stored := s.store(j.key, *profile)
+
+	s.mu.Lock()
+	onEnriched := s.onEnriched
+	s.mu.Unlock()
+	if onEnriched != nil {
+		onEnriched(stored)
+	}
+}
+
+// store guarda el perfil en el caché y devuelve la versión guardada.
+func (s *Service) store(k key, profile domain.UserProfile) domain.UserProfile {
+	profile.Platform = k.platform
+	profile.UserID = k.userID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if profile.FetchedAt.IsZero() {
+		profile.FetchedAt = s.now()
+	}
+	if s.verified[k] {
+		profile.Verified = true
+	}
+	delete(s.pending, k)
+	s.putLocked(&entry{key: k, profile: profile, expires: s.now().Add(profileTTL)})
+	return profile
+}
+
+func (s *Service) putLocked(e *entry) {
+	if el, ok := s.entries[e.key]; ok {
+		el.Value = e
+		s.order.MoveToFront(el)
+		return
+	}
+	s.entries[e.key] = s.order.PushFront(e)
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		evicted := oldest.Value.(*entry)
+		s.order.Remove(oldest)
+		delete(s.entries, evicted.key)
+		delete(s.verified, evicted.key)
+	}
+}
+
+func (s *Service) fetcher(platform domain.Platform) domain.UserProfileFetcher {
+	if s.fetchers == nil {
+		return nil
+	}
+	return s.fetchers(platform)
+}
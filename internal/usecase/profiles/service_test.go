@@ -0,0 +1,229 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeFetcher struct {
+	mu    sync.Mutex
+	calls []string
+	err   error
+}
+
+func (f *fakeFetcher) FetchUserProfile(_ context.Context, userID string) (*domain.UserProfile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, userID)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.UserProfile{AvatarURL: "https://img/" + userID + ".png"}, nil
+}
+
+func (f *fakeFetcher) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func (f *fakeFetcher) setErr(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func newTestService(capacity int) (*Service, *fakeFetcher, *fakeClock) {
+	fetcher := &fakeFetcher{}
+	svc := NewService(func(p domain.Platform) domain.UserProfileFetcher {
+		if p != domain.PlatformKick {
+			return nil
+		}
+		return fetcher
+	}, capacity)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)}
+	svc.now = clock.Now
+	return svc, fetcher, clock
+}
+
+func kickChatter(id string) domain.Message {
+	return domain.Message{Platform: domain.PlatformKick, UserID: id, Username: "user" + id}
+}
+
+// drain procesa en el momento lo que haya en la cola, sin workers.
+func drain(svc *Service) {
+	for {
+		select {
+		case j := <-svc.jobs:
+			svc.process(context.Background(), j)
+		default:
+			return
+		}
+	}
+}
+
+func TestEnrichFirstMessageGoesOutUnenriched(t *testing.T) {
+	svc, fetcher, _ := newTestService(10)
+
+	msg := svc.Enrich(kickChatter("1"))
+	if msg.AvatarURL != "" {
+		t.Fatalf("first message enriched synchronously: %q", msg.AvatarURL)
+	}
+	// un segundo mensaje antes de que llegue el perfil no encola otra consulta
+	svc.Enrich(kickChatter("1"))
+	if got := len(svc.jobs); got != 1 {
+		t.Fatalf("queued %d jobs, want 1", got)
+	}
+
+	drain(svc)
+	if got := svc.Enrich(kickChatter("1")).AvatarURL; got != "https://img/1.png" {
+		t.Fatalf("cached AvatarURL = %q", got)
+	}
+	if fetcher.callCount() != 1 || len(svc.jobs) != 0 {
+		t.Fatalf("calls = %d, queued = %d", fetcher.callCount(), len(svc.jobs))
+	}
+}
+
+func TestEnrichSkipsUnsupportedPlatforms(t *testing.T) {
+	svc, _, _ := newTestService(10)
+	svc.Enrich(domain.Message{Platform: domain.PlatformTwitch, UserID: "1"})
+	svc.Enrich(domain.Message{Platform: domain.PlatformKick})
+	if got := len(svc.jobs); got != 0 {
+		t.Fatalf("queued %d jobs", got)
+	}
+	if _, err := svc.Lookup(context.Background(), domain.PlatformTwitch, "1"); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Lookup err = %v", err)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	svc, fetcher, _ := newTestService(2)
+	ctx := context.Background()
+
+	for _, id := range []string{"1", "2"} {
+		if _, err := svc.Lookup(ctx, domain.PlatformKick, id); err != nil {
+			t.Fatalf("Lookup: %v", err)
+		}
+	}
+	// usar "1" lo vuelve el más reciente, así que sale "2"
+	svc.Enrich(kickChatter("1"))
+	if _, err := svc.Lookup(ctx, domain.PlatformKick, "3"); err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+	if svc.Len() != 2 {
+		t.Fatalf("Len = %d", svc.Len())
+	}
+
+	before := fetcher.callCount()
+	svc.Lookup(ctx, domain.PlatformKick, "1")
+	if fetcher.callCount() != before {
+		t.Fatal("recently used profile was evicted")
+	}
+	svc.Lookup(ctx, domain.PlatformKick, "2")
+	if fetcher.callCount() != before+1 {
+		t.Fatal("least recently used profile was not evicted")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	svc, fetcher, clock := newTestService(10)
+
+	svc.Enrich(kickChatter("1"))
+	drain(svc)
+
+	// vencido se sigue usando el dato viejo mientras se vuelve a pedir
+	clock.Advance(profileTTL + time.Second)
+	if got := svc.Enrich(kickChatter("1")).AvatarURL; got == "" {
+		t.Fatal("expired profile not used while refreshing")
+	}
+	drain(svc)
+	if fetcher.callCount() != 2 {
+		t.Fatalf("calls = %d, want a refresh after the TTL", fetcher.callCount())
+	}
+}
+
+func TestFailedLookupIsNotRetriedEveryMessage(t *testing.T) {
+	svc, fetcher, clock := newTestService(10)
+	fetcher.setErr(errors.New("404"))
+
+	svc.Enrich(kickChatter("1"))
+	drain(svc)
+	svc.Enrich(kickChatter("1"))
+	drain(svc)
+	if fetcher.callCount() != 1 {
+		t.Fatalf("calls = %d, want 1 within failureTTL", fetcher.callCount())
+	}
+
+	fetcher.setErr(nil)
+	clock.Advance(failureTTL + time.Second)
+	svc.Enrich(kickChatter("1"))
+	drain(svc)
+	if got := svc.Enrich(kickChatter("1")).AvatarURL; got != "https://img/1.png" {
+		t.Fatalf("AvatarURL after retry = %q", got)
+	}
+}
+
+func TestVerifiedBadgeIsKept(t *testing.T) {
+	svc, _, _ := newTestService(10)
+	msg := kickChatter("1")
+	msg.IsVerified = true
+	svc.Enrich(msg)
+	drain(svc)
+
+	profile, err := svc.Lookup(context.Background(), domain.PlatformKick, "1")
+	if err != nil || !profile.Verified {
+		t.Fatalf("profile = %+v, %v", profile, err)
+	}
+}
+
+func TestWorkerPublishesEnrichedProfile(t *testing.T) {
+	svc, _, _ := newTestService(10)
+	enriched := make(chan domain.UserProfile, 1)
+	svc.SetEnrichedHandler(func(p domain.UserProfile) { enriched <- p })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < DefaultWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.Run(ctx)
+		}()
+	}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	svc.Enrich(kickChatter("7"))
+	select {
+	case p := <-enriched:
+		if p.Platform != domain.PlatformKick || p.UserID != "7" || p.AvatarURL != "https://img/7.png" || p.FetchedAt.IsZero() {
+			t.Fatalf("enriched = %+v", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no user:enriched event")
+	}
+}
@@ -0,0 +1,113 @@
+// Package quotes guarda las citas del canal (!quote). Los números los pone
+// la base y no se renumeran al borrar, así «la cita 12» es siempre la misma.
+package quotes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"zhatBot/internal/domain"
+)
+
+// MaxQuoteLength es el largo máximo de una cita.
+const MaxQuoteLength = 400
+
+var (
+	ErrEmptyQuote    = errors.New("la cita está vacía")
+	ErrQuoteTooLong  = errors.New("la cita es demasiado larga")
+	ErrQuoteNotFound = errors.New("cita no encontrada")
+	ErrNoQuotes      = errors.New("todavía no hay citas")
+)
+
+type Service struct {
+	repo domain.QuoteRepository
+	now  func() time.Time
+}
+
+func NewService(repo domain.QuoteRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+func (s *Service) List(ctx context.Context) ([]*domain.Quote, error) {
+	return s.repo.ListQuotes(ctx)
+}
+
+// Get devuelve la cita con ese número.
+func (s *Service) Get(ctx context.Context, number int64) (*domain.Quote, error) {
+	quote, err := s.repo.GetQuote(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, ErrQuoteNotFound
+	}
+	return quote, nil
+}
+
+func (s *Service) Random(ctx context.Context) (*domain.Quote, error) {
+	quote, err := s.repo.RandomQuote(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, ErrNoQuotes
+	}
+	return quote, nil
+}
+
+// Add guarda la cita con el número siguiente.
+func (s *Service) Add(ctx context.Context, quote domain.Quote) (*domain.Quote, error) {
+	text, err := normalizeText(quote.Text)
+	if err != nil {
+		return nil, err
+	}
+	quote.Number = 0
+	quote.Text = text
+	quote.AddedBy = strings.TrimSpace(quote.AddedBy)
+	if quote.CreatedAt.IsZero() {
+		quote.CreatedAt = s.now().UTC()
+	}
+	return s.repo.AddQuote(ctx, &quote)
+}
+
+// Update cambia el texto; el número, el autor y la fecha no cambian.
+func (s *Service) Update(ctx context.Context, number int64, text string) (*domain.Quote, error) {
+	text, err := normalizeText(text)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.UpdateQuote(ctx, number, text)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrQuoteNotFound
+	}
+	return s.Get(ctx, number)
+}
+
+// Delete borra la cita; su número queda libre y no se vuelve a usar.
+func (s *Service) Delete(ctx context.Context, number int64) error {
+	deleted, err := s.repo.DeleteQuote(ctx, number)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrQuoteNotFound
+	}
+	return nil
+}
+
+func normalizeText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmptyQuote
+	}
+	if utf8.RuneCountInString(text) > MaxQuoteLength {
+		return "", ErrQuoteTooLong
+	}
+	return text, nil
+}
@@ -0,0 +1,174 @@
+package readonly
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"zhatBot/internal/domain"
+	ttsusecase "zhatBot/internal/usecase/tts"
+)
+
+// TwitchChannel suprime los cambios de título y categoría en Twitch; el resto
+// de llamadas pasan tal cual.
+func TwitchChannel(svc domain.TwitchChannelService, mode *Mode) domain.TwitchChannelService {
+	if svc == nil || mode == nil {
+		return svc
+	}
+	return &twitchChannel{TwitchChannelService: svc, mode: mode}
+}
+
+type twitchChannel struct {
+	domain.TwitchChannelService
+	mode *Mode
+}
+
+func (s *twitchChannel) SetTitle(ctx context.Context, broadcasterID, newTitle string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: título de Twitch suprimido: %q", newTitle)
+		return nil
+	}
+	return s.TwitchChannelService.SetTitle(ctx, broadcasterID, newTitle)
+}
+
+func (s *twitchChannel) UpdateCategory(ctx context.Context, broadcasterID, gameName string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: categoría de Twitch suprimida: %q", gameName)
+		return nil
+	}
+	return s.TwitchChannelService.UpdateCategory(ctx, broadcasterID, gameName)
+}
+
+// KickStream suprime los cambios de título y categoría en Kick.
+func KickStream(svc domain.KickStreamService, mode *Mode) domain.KickStreamService {
+	if svc == nil || mode == nil {
+		return svc
+	}
+	return &kickStream{KickStreamService: svc, mode: mode}
+}
+
+type kickStream struct {
+	domain.KickStreamService
+	mode *Mode
+}
+
+func (s *kickStream) SetTitle(ctx context.Context, newTitle string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: título de Kick suprimido: %q", newTitle)
+		return nil
+	}
+	return s.KickStreamService.SetTitle(ctx, newTitle)
+}
+
+func (s *kickStream) SetCategory(ctx context.Context, categoryName string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: categoría de Kick suprimida: %q", categoryName)
+		return nil
+	}
+	return s.KickStreamService.SetCategory(ctx, categoryName)
+}
+
+// ChatSettings suprime los cambios de modo del chat (lento, solo emotes y
+// solo followers).
+func ChatSettings(svc domain.ChatSettingsService, mode *Mode) domain.ChatSettingsService {
+	if svc == nil || mode == nil {
+		return svc
+	}
+	return &chatSettings{svc: svc, mode: mode}
+}
+
+type chatSettings struct {
+	svc  domain.ChatSettingsService
+	mode *Mode
+}
+
+func (s *chatSettings) SetSlowMode(ctx context.Context, broadcasterID, moderatorID string, seconds int) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: modo lento suprimido (%ds)", seconds)
+		return nil
+	}
+	return s.svc.SetSlowMode(ctx, broadcasterID, moderatorID, seconds)
+}
+
+func (s *chatSettings) SetEmoteOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: modo solo emotes suprimido (enabled=%t)", enabled)
+		return nil
+	}
+	return s.svc.SetEmoteOnly(ctx, broadcasterID, moderatorID, enabled)
+}
+
+func (s *chatSettings) SetFollowersOnly(ctx context.Context, broadcasterID, moderatorID string, enabled bool, minutes int) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: modo solo followers suprimido (enabled=%t, %d min)", enabled, minutes)
+		return nil
+	}
+	return s.svc.SetFollowersOnly(ctx, broadcasterID, moderatorID, enabled, minutes)
+}
+
+// Moderation suprime bans, timeouts y borrados de mensajes.
+func Moderation(svc domain.ModerationService, mode *Mode) domain.ModerationService {
+	if svc == nil || mode == nil {
+		return svc
+	}
+	return &moderation{ModerationService: svc, mode: mode}
+}
+
+type moderation struct {
+	domain.ModerationService
+	mode *Mode
+}
+
+func (s *moderation) BanUser(ctx context.Context, userID, reason string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: ban suprimido (user_id=%s)", userID)
+		return nil
+	}
+	return s.ModerationService.BanUser(ctx, userID, reason)
+}
+
+func (s *moderation) TimeoutUser(ctx context.Context, userID string, duration time.Duration, reason string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: timeout de %s suprimido (user_id=%s)", duration, userID)
+		return nil
+	}
+	return s.ModerationService.TimeoutUser(ctx, userID, duration, reason)
+}
+
+func (s *moderation) DeleteMessage(ctx context.Context, messageID string) error {
+	if s.mode.Enabled() {
+		log.Printf("readonly: borrado de mensaje suprimido (%s)", messageID)
+		return nil
+	}
+	return s.ModerationService.DeleteMessage(ctx, messageID)
+}
+
+// ResolveUserID es solo lectura: pasa siempre.
+func (s *moderation) ResolveUserID(ctx context.Context, login string) (string, error) {
+	resolver, ok := s.ModerationService.(domain.ModerationUserResolver)
+	if !ok {
+		return "", domain.ErrModerationUserNotFound
+	}
+	return resolver.ResolveUserID(ctx, login)
+}
+
+// TTSQueue descarta las lecturas TTS en vez de encolarlas para reproducirlas.
+func TTSQueue(queue ttsusecase.Queue, mode *Mode) ttsusecase.Queue {
+	if queue == nil || mode == nil {
+		return queue
+	}
+	return &ttsQueue{queue: queue, mode: mode}
+}
+
+type ttsQueue struct {
+	queue ttsusecase.Queue
+	mode  *Mode
+}
+
+func (q *ttsQueue) Enqueue(ctx context.Context, req ttsusecase.Request) (string, error) {
+	if q.mode.Enabled() {
+		log.Printf("readonly: TTS suprimido (%s): %q", req.RequestedBy, req.Text)
+		return req.ID, nil
+	}
+	return q.queue.Enqueue(ctx, req)
+}
@@ -0,0 +1,221 @@
+package readonly
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+	"zhatBot/internal/interface/outs"
+	ttsusecase "zhatBot/internal/usecase/tts"
+)
+
+type memoryReadOnlyRepo struct {
+	mu      sync.Mutex
+	enabled bool
+}
+
+func (r *memoryReadOnlyRepo) GetReadOnly(context.Context) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled, nil
+}
+
+func (r *memoryReadOnlyRepo) SetReadOnly(_ context.Context, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+	return nil
+}
+
+// backend registra cada llamada que llegaría de verdad a una plataforma.
+type backend struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (b *backend) record(call string) error {
+	b.mu.Lock()
+	b.calls = append(b.calls, call)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *backend) recorded() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+func (b *backend) SendMessage(context.Context, domain.Platform, string, string) error {
+	return b.record("chat")
+}
+
+func (b *backend) SendPrivateMessage(context.Context, domain.Platform, string, string, string) error {
+	return b.record("private")
+}
+
+func (b *backend) twitch() domain.TwitchChannelService { return twitchBackend{b: b} }
+func (b *backend) kick() domain.KickStreamService      { return kickBackend{b: b} }
+
+// twitchBackend y kickBackend solo implementan las escrituras; el resto de los
+// métodos entra en pánico si se llama.
+type twitchBackend struct {
+	domain.TwitchChannelService
+	b *backend
+}
+
+func (t twitchBackend) SetTitle(context.Context, string, string) error {
+	return t.b.record("twitch-title")
+}
+func (t twitchBackend) UpdateCategory(context.Context, string, string) error {
+	return t.b.record("twitch-category")
+}
+
+type kickBackend struct {
+	domain.KickStreamService
+	b *backend
+}
+
+func (k kickBackend) SetTitle(context.Context, string) error    { return k.b.record("kick-title") }
+func (k kickBackend) SetCategory(context.Context, string) error { return k.b.record("kick-category") }
+
+func (b *backend) SetSlowMode(context.Context, string, string, int) error { return b.record("slow") }
+func (b *backend) SetEmoteOnly(context.Context, string, string, bool) error {
+	return b.record("emote-only")
+}
+func (b *backend) SetFollowersOnly(context.Context, string, string, bool, int) error {
+	return b.record("followers-only")
+}
+func (b *backend) BanUser(context.Context, string, string) error { return b.record("ban") }
+func (b *backend) TimeoutUser(context.Context, string, time.Duration, string) error {
+	return b.record("timeout")
+}
+func (b *backend) DeleteMessage(context.Context, string) error { return b.record("delete") }
+func (b *backend) Enqueue(context.Context, ttsusecase.Request) (string, error) {
+	return "tts-id", b.record("tts")
+}
+
+// writePaths son todas las salidas que el modo solo lectura debe cortar.
+func writePaths(b *backend, mode *Mode, multi *outs.MultiSender) map[string]func(ctx context.Context) error {
+	twitch := TwitchChannel(b.twitch(), mode)
+	kick := KickStream(b.kick(), mode)
+	chat := ChatSettings(b, mode)
+	mod := Moderation(b, mode)
+	tts := TTSQueue(b, mode)
+
+	return map[string]func(ctx context.Context) error{
+		"chat": func(ctx context.Context) error {
+			return multi.SendMessage(ctx, domain.PlatformTwitch, "canal", "hola")
+		},
+		"private": func(ctx context.Context) error {
+			return multi.SendPrivateMessage(ctx, domain.PlatformTwitch, "42", "viewer", "psst")
+		},
+		"twitch-title":    func(ctx context.Context) error { return twitch.SetTitle(ctx, "b", "Nuevo título") },
+		"twitch-category": func(ctx context.Context) error { return twitch.UpdateCategory(ctx, "b", "Just Chatting") },
+		"kick-title":      func(ctx context.Context) error { return kick.SetTitle(ctx, "Nuevo título") },
+		"kick-category":   func(ctx context.Context) error { return kick.SetCategory(ctx, "Just Chatting") },
+		"slow":            func(ctx context.Context) error { return chat.SetSlowMode(ctx, "b", "m", 30) },
+		"emote-only":      func(ctx context.Context) error { return chat.SetEmoteOnly(ctx, "b", "m", true) },
+		"followers-only":  func(ctx context.Context) error { return chat.SetFollowersOnly(ctx, "b", "m", true, 10) },
+		"ban":             func(ctx context.Context) error { return mod.BanUser(ctx, "42", "spam") },
+		"timeout":         func(ctx context.Context) error { return mod.TimeoutUser(ctx, "42", time.Minute, "spam") },
+		"delete":          func(ctx context.Context) error { return mod.DeleteMessage(ctx, "m1") },
+		"tts": func(ctx context.Context) error {
+			_, err := tts.Enqueue(ctx, ttsusecase.Request{ID: "r1", Text: "hola"})
+			return err
+		},
+	}
+}
+
+func TestReadOnlySuppressesEveryWritePath(t *testing.T) {
+	ctx := context.Background()
+	b := &backend{}
+	mode := NewMode(&memoryReadOnlyRepo{})
+
+	var suppressed []outs.SuppressedMessage
+	multi := outs.NewMultiSender()
+	multi.Register(domain.PlatformTwitch, b)
+	multi.SetReadOnly(mode.Enabled, func(_ context.Context, msg outs.SuppressedMessage) {
+		suppressed = append(suppressed, msg)
+	})
+
+	if err := mode.Set(ctx, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	paths := writePaths(b, mode, multi)
+	for name, write := range paths {
+		if err := write(ctx); err != nil {
+			t.Errorf("%s returned %v while read-only", name, err)
+		}
+	}
+	if calls := b.recorded(); len(calls) != 0 {
+		t.Fatalf("real sends while read-only: %v", calls)
+	}
+	// los caminos salen de un map: el orden de los suprimidos varía
+	var chatMsgs, private int
+	for _, msg := range suppressed {
+		if msg.Private && msg.Username == "viewer" {
+			private++
+		} else if !msg.Private {
+			chatMsgs++
+		}
+	}
+	if len(suppressed) != 2 || chatMsgs != 1 || private != 1 {
+		t.Fatalf("suppressed = %+v, want the chat and the private message", suppressed)
+	}
+
+	// al desactivarlo todo vuelve a salir
+	if err := mode.Set(ctx, false); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	for name, write := range paths {
+		if err := write(ctx); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if calls := b.recorded(); len(calls) != len(paths) {
+		t.Fatalf("sent %v after disabling read-only, want all %d paths", calls, len(paths))
+	}
+}
+
+func TestModePersistsAndNotifies(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryReadOnlyRepo{}
+	mode := NewMode(repo)
+
+	var changes []bool
+	mode.SetChangeHandler(func(enabled bool) { changes = append(changes, enabled) })
+
+	if err := mode.Set(ctx, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := mode.Set(ctx, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !repo.enabled || !mode.Enabled() {
+		t.Fatal("read-only was not persisted")
+	}
+	if len(changes) != 1 || !changes[0] {
+		t.Fatalf("changes = %v, want a single notification", changes)
+	}
+
+	// otro proceso lo apagó en la base
+	repo.enabled = false
+	restarted := NewMode(repo)
+	if err := restarted.Load(ctx); err != nil || restarted.Enabled() {
+		t.Fatalf("Load() = %v, enabled %v", err, restarted.Enabled())
+	}
+}
+
+func TestNilModeIsDisabled(t *testing.T) {
+	var mode *Mode
+	if mode.Enabled() {
+		t.Fatal("nil mode must be disabled")
+	}
+	b := &backend{}
+	if got := ChatSettings(b, nil); got != domain.ChatSettingsService(b) {
+		t.Fatal("decorators must return the service unchanged without a mode")
+	}
+}
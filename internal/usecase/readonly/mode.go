@@ -0,0 +1,95 @@
+// Package readonly implementa el modo solo lectura: el bot procesa los
+// mensajes y comandos como siempre pero no envía nada ni cambia el canal.
+package readonly
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"zhatBot/internal/domain"
+)
+
+// Mode guarda el estado del modo solo lectura. Un Mode nil equivale a
+// desactivado.
+type Mode struct {
+	repo domain.ReadOnlyRepository
+
+	mu       sync.RWMutex
+	enabled  bool
+	onChange func(enabled bool)
+}
+
+func NewMode(repo domain.ReadOnlyRepository) *Mode {
+	return &Mode{repo: repo}
+}
+
+// Load lee el estado guardado.
+func (m *Mode) Load(ctx context.Context) error {
+	return m.Reload(ctx)
+}
+
+func (m *Mode) Reload(ctx context.Context) error {
+	if m == nil || m.repo == nil {
+		return nil
+	}
+	enabled, err := m.repo.GetReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	m.apply(enabled)
+	return nil
+}
+
+func (m *Mode) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Set activa o desactiva el modo y lo persiste.
+func (m *Mode) Set(ctx context.Context, enabled bool) error {
+	if m == nil {
+		return nil
+	}
+	if m.repo != nil {
+		if err := m.repo.SetReadOnly(ctx, enabled); err != nil {
+			return err
+		}
+	}
+	m.apply(enabled)
+	return nil
+}
+
+// SetChangeHandler se llama cada vez que el modo cambia.
+func (m *Mode) SetChangeHandler(fn func(enabled bool)) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = fn
+}
+
+func (m *Mode) apply(enabled bool) {
+	m.mu.Lock()
+	changed := m.enabled != enabled
+	m.enabled = enabled
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if enabled {
+		log.Printf("readonly: modo solo lectura activado")
+	} else {
+		log.Printf("readonly: modo solo lectura desactivado")
+	}
+	if onChange != nil {
+		onChange(enabled)
+	}
+}
@@ -0,0 +1,256 @@
+// Package shoutouts hace el shoutout automático: recomienda a un streamer
+// conocido (o a un VIP) la primera vez que escribe en la sesión de stream.
+package shoutouts
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// SessionFunc devuelve el ID de la sesión de stream actual ("" si se desconoce).
+type SessionFunc func(ctx context.Context) string
+
+// Motivos del shoutout.
+const (
+	ReasonStreamer = "streamer"
+	ReasonVIP      = "vip"
+)
+
+// ShoutoutDTO describe un shoutout automático enviado al chat.
+type ShoutoutDTO struct {
+	Platform  string `json:"platform"`
+	ChannelID string `json:"channel_id"`
+	User      string `json:"user"`
+	Login     string `json:"login"`
+	Reason    string `json:"reason"`
+	Text      string `json:"text"`
+	At        string `json:"at"`
+}
+
+type Service struct {
+	repo domain.AutoShoutoutSettingsRepository
+
+	mu        sync.Mutex
+	cfg       domain.AutoShoutoutSettings
+	streamers map[string]bool
+	// seen son los usuarios que ya escribieron en la sesión; solo el primer
+	// mensaje de cada uno puede disparar el shoutout.
+	seen      map[string]bool
+	session   SessionFunc
+	sessionID string
+	handler   func(ShoutoutDTO)
+	now       func() time.Time
+}
+
+func NewService(repo domain.AutoShoutoutSettingsRepository) *Service {
+	s := &Service{
+		repo: repo,
+		seen: make(map[string]bool),
+		now:  time.Now,
+	}
+	s.apply(domain.DefaultAutoShoutoutSettings())
+	return s
+}
+
+// SetSessionFunc hace que los shoutouts se vuelvan a dar cuando empieza otra
+// sesión de stream.
+func (s *Service) SetSessionFunc(fn SessionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = fn
+}
+
+// SetShoutoutHandler recibe cada shoutout que se dispara.
+func (s *Service) SetShoutoutHandler(fn func(ShoutoutDTO)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = fn
+}
+
+// Load aplica la configuración guardada (si existe).
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.GetAutoShoutoutSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.mu.Lock()
+		s.apply(sanitizeSettings(*stored))
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+func (s *Service) Settings() domain.AutoShoutoutSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cloneSettings(s.cfg)
+}
+
+// Update guarda y aplica la configuración; la plantilla vacía vuelve a la de fábrica.
+func (s *Service) Update(ctx context.Context, settings domain.AutoShoutoutSettings) (domain.AutoShoutoutSettings, error) {
+	applied := sanitizeSettings(settings)
+	s.mu.Lock()
+	s.apply(applied)
+	s.mu.Unlock()
+	if s.repo != nil {
+		if err := s.repo.SetAutoShoutoutSettings(ctx, applied); err != nil {
+			return cloneSettings(applied), err
+		}
+	}
+	return cloneSettings(applied), nil
+}
+
+// Observe anota al autor del mensaje y, si es su primer mensaje de la sesión
+// y corresponde, devuelve el shoutout a enviar ("" si no hay).
+func (s *Service) Observe(ctx context.Context, msg domain.Message) string {
+	key := userKey(msg)
+	if key == "" {
+		return ""
+	}
+	s.mu.Lock()
+	s.syncSessionLocked(ctx)
+	if s.seen[key] {
+		s.mu.Unlock()
+		return ""
+	}
+	s.seen[key] = true
+
+	reason := s.reasonLocked(msg)
+	if reason == "" {
+		s.mu.Unlock()
+		return ""
+	}
+	login := msg.LoginName()
+	name := strings.TrimSpace(msg.Name())
+	if name == "" {
+		name = login
+	}
+	text := renderTemplate(s.cfg.Template, name, login, channelURL(msg.Platform, login))
+	handler := s.handler
+	now := s.now()
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler(ShoutoutDTO{
+			Platform:  string(msg.Platform),
+			ChannelID: msg.ChannelID,
+			User:      name,
+			Login:     login,
+			Reason:    reason,
+			Text:      text,
+			At:        now.UTC().Format(time.RFC3339),
+		})
+	}
+	return text
+}
+
+// Reset olvida quién escribió: los shoutouts se vuelven a dar.
+func (s *Service) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen = make(map[string]bool)
+}
+
+// reasonLocked decide si el autor merece shoutout. El dueño del canal nunca.
+func (s *Service) reasonLocked(msg domain.Message) string {
+	if !s.cfg.Enabled || msg.IsPlatformOwner {
+		return ""
+	}
+	if s.streamers[msg.LoginName()] {
+		return ReasonStreamer
+	}
+	if s.cfg.IncludeVIPs && msg.IsPlatformVip {
+		return ReasonVIP
+	}
+	return ""
+}
+
+// syncSessionLocked olvida quién escribió si la sesión de stream cambió.
+func (s *Service) syncSessionLocked(ctx context.Context) {
+	if s.session == nil {
+		return
+	}
+	current := s.session(ctx)
+	if current == "" || current == s.sessionID {
+		return
+	}
+	if s.sessionID != "" {
+		s.seen = make(map[string]bool)
+	}
+	s.sessionID = current
+}
+
+func (s *Service) apply(cfg domain.AutoShoutoutSettings) {
+	s.cfg = cfg
+	s.streamers = make(map[string]bool, len(cfg.Streamers))
+	for _, login := range cfg.Streamers {
+		s.streamers[login] = true
+	}
+}
+
+func sanitizeSettings(settings domain.AutoShoutoutSettings) domain.AutoShoutoutSettings {
+	settings.Template = strings.TrimSpace(settings.Template)
+	if settings.Template == "" {
+		settings.Template = domain.DefaultAutoShoutoutSettings().Template
+	}
+	seen := make(map[string]bool, len(settings.Streamers))
+	streamers := make([]string, 0, len(settings.Streamers))
+	for _, login := range settings.Streamers {
+		login = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+		if login == "" || seen[login] {
+			continue
+		}
+		seen[login] = true
+		streamers = append(streamers, login)
+	}
+	sort.Strings(streamers)
+	settings.Streamers = streamers
+	return settings
+}
+
+func cloneSettings(settings domain.AutoShoutoutSettings) domain.AutoShoutoutSettings {
+	settings.Streamers = append([]string{}, settings.Streamers...)
+	return settings
+}
+
+func userKey(msg domain.Message) string {
+	user := strings.TrimSpace(msg.UserID)
+	if user == "" {
+		user = msg.LoginName()
+	}
+	if user == "" {
+		return ""
+	}
+	return string(msg.Platform) + ":" + user
+}
+
+func channelURL(platform domain.Platform, login string) string {
+	switch platform {
+	case domain.PlatformTwitch:
+		return "https://twitch.tv/" + login
+	case domain.PlatformKick:
+		return "https://kick.com/" + login
+	}
+	return login
+}
+
+func renderTemplate(template, user, login, url string) string {
+	return strings.NewReplacer(
+		"{user}", user,
+		"{login}", login,
+		"{url}", url,
+	).Replace(template)
+}
@@ -0,0 +1,144 @@
+package shoutouts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type memorySettings struct {
+	saved *domain.AutoShoutoutSettings
+}
+
+func (m *memorySettings) GetAutoShoutoutSettings(context.Context) (*domain.AutoShoutoutSettings, error) {
+	return m.saved, nil
+}
+
+func (m *memorySettings) SetAutoShoutoutSettings(_ context.Context, settings domain.AutoShoutoutSettings) error {
+	m.saved = &settings
+	return nil
+}
+
+func chatter(platform domain.Platform, login string) domain.Message {
+	return domain.Message{
+		Platform:  platform,
+		ChannelID: "zero",
+		UserID:    "id-" + login,
+		Username:  login,
+		Login:     login,
+		Text:      "hola",
+	}
+}
+
+func vip(login string) domain.Message {
+	msg := chatter(domain.PlatformTwitch, login)
+	msg.IsPlatformVip = true
+	return msg
+}
+
+func newTestService(t *testing.T, settings domain.AutoShoutoutSettings) (*Service, *[]ShoutoutDTO) {
+	t.Helper()
+	svc := NewService(&memorySettings{})
+	svc.now = func() time.Time { return time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC) }
+	if _, err := svc.Update(context.Background(), settings); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var shoutouts []ShoutoutDTO
+	svc.SetShoutoutHandler(func(dto ShoutoutDTO) { shoutouts = append(shoutouts, dto) })
+	return svc, &shoutouts
+}
+
+func TestShoutoutTriggerConditions(t *testing.T) {
+	ctx := context.Background()
+	svc, shoutouts := newTestService(t, domain.AutoShoutoutSettings{
+		Enabled:     true,
+		Template:    "Sigan a {user} ({login}) en {url}",
+		Streamers:   []string{" @Ana ", "ana", "Beto"},
+		IncludeVIPs: true,
+	})
+
+	owner := chatter(domain.PlatformTwitch, "beto")
+	owner.IsPlatformOwner = true
+	kickAna := chatter(domain.PlatformKick, "ana")
+	kickAna.Username = "Ana"
+
+	tests := []struct {
+		name string
+		msg  domain.Message
+		want string
+	}{
+		{"streamer de la lista", chatter(domain.PlatformTwitch, "ana"), "Sigan a ana (ana) en https://twitch.tv/ana"},
+		{"segundo mensaje del mismo", chatter(domain.PlatformTwitch, "ana"), ""},
+		{"la lista vale en cualquier plataforma", kickAna, "Sigan a Ana (ana) en https://kick.com/ana"},
+		{"viewer común", chatter(domain.PlatformTwitch, "carla"), ""},
+		{"vip", vip("dani"), "Sigan a dani (dani) en https://twitch.tv/dani"},
+		{"el dueño del canal nunca", owner, ""},
+		{"sin usuario", domain.Message{Platform: domain.PlatformTwitch, Text: "hola"}, ""},
+	}
+	for _, tt := range tests {
+		if got := svc.Observe(ctx, tt.msg); got != tt.want {
+			t.Errorf("%s: Observe = %q, esperaba %q", tt.name, got, tt.want)
+		}
+	}
+
+	if len(*shoutouts) != 3 {
+		t.Fatalf("shoutouts = %+v", *shoutouts)
+	}
+	if first := (*shoutouts)[0]; first.Reason != ReasonStreamer || first.Login != "ana" || first.Platform != "twitch" || first.At != "2024-05-01T20:00:00Z" {
+		t.Fatalf("evento = %+v", first)
+	}
+	if (*shoutouts)[2].Reason != ReasonVIP {
+		t.Fatalf("evento del vip = %+v", (*shoutouts)[2])
+	}
+}
+
+func TestShoutoutDisabledAndVIPsOff(t *testing.T) {
+	ctx := context.Background()
+	svc, shoutouts := newTestService(t, domain.AutoShoutoutSettings{Streamers: []string{"ana"}, IncludeVIPs: true})
+	if got := svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana")); got != "" {
+		t.Fatalf("desactivado respondió %q", got)
+	}
+
+	svc, shoutouts = newTestService(t, domain.AutoShoutoutSettings{Enabled: true})
+	if got := svc.Observe(ctx, vip("dani")); got != "" {
+		t.Fatalf("include_vips=false respondió %q", got)
+	}
+	if len(*shoutouts) != 0 {
+		t.Fatalf("shoutouts = %+v", *shoutouts)
+	}
+	if got := svc.Settings().Template; got != domain.DefaultAutoShoutoutSettings().Template {
+		t.Fatalf("una plantilla vacía no volvió a la de fábrica: %q", got)
+	}
+}
+
+func TestShoutoutOncePerSession(t *testing.T) {
+	ctx := context.Background()
+	svc, shoutouts := newTestService(t, domain.AutoShoutoutSettings{Enabled: true, Streamers: []string{"ana"}})
+	session := ""
+	svc.SetSessionFunc(func(context.Context) string { return session })
+
+	// sin sesión conocida se cuenta igual, una vez
+	svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana"))
+	session = "stream-1"
+	if got := svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana")); got != "" {
+		t.Fatalf("conocer la primera sesión repitió el shoutout: %q", got)
+	}
+
+	session = "stream-2"
+	if got := svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana")); got == "" {
+		t.Fatal("una sesión nueva no repitió el shoutout")
+	}
+	if got := svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana")); got != "" {
+		t.Fatalf("dos shoutouts en la misma sesión: %q", got)
+	}
+
+	svc.Reset()
+	if got := svc.Observe(ctx, chatter(domain.PlatformTwitch, "ana")); got == "" {
+		t.Fatal("Reset no permitió otro shoutout")
+	}
+	if len(*shoutouts) != 3 {
+		t.Fatalf("shoutouts = %d, esperaba 3", len(*shoutouts))
+	}
+}
@@ -3,20 +3,145 @@ package status
 import (
 	"context"
 	"log"
+	"sort"
 	"sync"
+	"time"
 
 	"zhatBot/internal/domain"
 )
 
+// DefaultCacheTTL es cuánto se reutiliza el estado de una plataforma. El
+// dashboard, los overlays y el poll de la API piden snapshots seguido y sin
+// caché cada uno terminaba en una llamada a Helix/Kick.
+const DefaultCacheTTL = 10 * time.Second
+
+const (
+	// DefaultFetchTimeout es lo máximo que se espera a cada plataforma; una
+	// API lenta no frena al resto ni a quien pidió el snapshot.
+	DefaultFetchTimeout = 8 * time.Second
+	// maxParallelFetches limita cuántas plataformas se consultan a la vez.
+	maxParallelFetches = 4
+	// DefaultFailureThreshold es cuántos errores seguidos se toleran antes de
+	// dejar de consultar una plataforma (p.ej. con el token revocado).
+	DefaultFailureThreshold = 5
+)
+
 type Resolver struct {
 	mu       sync.RWMutex
 	services map[domain.Platform]domain.StreamStatusService
+	cache    map[domain.Platform]domain.StreamStatus
+	ttl      time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+	// version cambia con cada Set/Invalidate; así una consulta que empezó
+	// antes no vuelve a llenar la caché con un estado viejo.
+	version uint64
+
+	// failures cuenta errores seguidos por plataforma; al llegar a threshold
+	// la plataforma queda en unhealthy y no se consulta hasta que Set o
+	// ResetHealth la vuelvan a habilitar.
+	failures    map[domain.Platform]int
+	unhealthy   map[domain.Platform]error
+	threshold   int
+	onUnhealthy func(platform domain.Platform, err error)
 }
 
 func NewResolver() *Resolver {
 	return &Resolver{
-		services: make(map[domain.Platform]domain.StreamStatusService),
+		services:  make(map[domain.Platform]domain.StreamStatusService),
+		cache:     make(map[domain.Platform]domain.StreamStatus),
+		ttl:       DefaultCacheTTL,
+		timeout:   DefaultFetchTimeout,
+		now:       time.Now,
+		failures:  make(map[domain.Platform]int),
+		unhealthy: make(map[domain.Platform]error),
+		threshold: DefaultFailureThreshold,
+	}
+}
+
+// SetTTL cambia cuánto dura la caché; 0 o menos la desactiva.
+func (r *Resolver) SetTTL(ttl time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ttl = ttl
+}
+
+// SetFetchTimeout cambia cuánto se espera a cada plataforma; 0 o menos usa
+// DefaultFetchTimeout.
+func (r *Resolver) SetFetchTimeout(timeout time.Duration) {
+	if r == nil {
+		return
+	}
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeout = timeout
+}
+
+// SetFailureThreshold cambia cuántos errores seguidos deshabilitan una
+// plataforma; 0 o menos usa DefaultFailureThreshold.
+func (r *Resolver) SetFailureThreshold(n int) {
+	if r == nil {
+		return
+	}
+	if n <= 0 {
+		n = DefaultFailureThreshold
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.threshold = n
+}
+
+// SetUnhealthyHandler se llama una sola vez cuando una plataforma se
+// deshabilita, con el último error.
+func (r *Resolver) SetUnhealthyHandler(fn func(platform domain.Platform, err error)) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onUnhealthy = fn
+}
+
+// ResetHealth vuelve a habilitar platform (p.ej. al actualizar sus
+// credenciales). Devuelve true si estaba deshabilitada.
+func (r *Resolver) ResetHealth(platform domain.Platform) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resetHealthLocked(platform)
+}
+
+func (r *Resolver) resetHealthLocked(platform domain.Platform) bool {
+	_, was := r.unhealthy[platform]
+	delete(r.unhealthy, platform)
+	delete(r.failures, platform)
+	if was {
+		log.Printf("stream-status: %s habilitado de nuevo", platform)
+	}
+	return was
+}
+
+// Unhealthy devuelve las plataformas deshabilitadas y el error que las
+// deshabilitó.
+func (r *Resolver) Unhealthy() map[domain.Platform]string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[domain.Platform]string, len(r.unhealthy))
+	for platform, err := range r.unhealthy {
+		out[platform] = err.Error()
 	}
+	return out
 }
 
 func (r *Resolver) Set(platform domain.Platform, svc domain.StreamStatusService) {
@@ -27,6 +152,9 @@ func (r *Resolver) Set(platform domain.Platform, svc domain.StreamStatusService)
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	delete(r.cache, platform)
+	r.version++
+	r.resetHealthLocked(platform)
 	if svc == nil {
 		delete(r.services, platform)
 		return
@@ -34,7 +162,30 @@ func (r *Resolver) Set(platform domain.Platform, svc domain.StreamStatusService)
 	r.services[platform] = svc
 }
 
+// Invalidate descarta el estado guardado de platform (p.ej. después de
+// cambiar el título) para que el próximo Snapshot lo vuelva a pedir.
+func (r *Resolver) Invalidate(platform domain.Platform) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, platform)
+	r.version++
+}
+
+// Snapshot devuelve el estado de cada plataforma, reutilizando el de la
+// caché si tiene menos de TTL.
 func (r *Resolver) Snapshot(ctx context.Context) []domain.StreamStatus {
+	return r.snapshot(ctx, false)
+}
+
+// Refresh es como Snapshot pero consulta todas las plataformas.
+func (r *Resolver) Refresh(ctx context.Context) []domain.StreamStatus {
+	return r.snapshot(ctx, true)
+}
+
+func (r *Resolver) snapshot(ctx context.Context, force bool) []domain.StreamStatus {
 	if r == nil {
 		return nil
 	}
@@ -42,22 +193,135 @@ func (r *Resolver) Snapshot(ctx context.Context) []domain.StreamStatus {
 	r.mu.RLock()
 	services := make(map[domain.Platform]domain.StreamStatusService, len(r.services))
 	for platform, svc := range r.services {
+		if _, down := r.unhealthy[platform]; down {
+			continue
+		}
 		if svc != nil {
 			services[platform] = svc
 		}
 	}
+	version := r.version
+	timeout := r.timeout
 	r.mu.RUnlock()
 
-	out := make([]domain.StreamStatus, 0, len(services))
-	for platform, svc := range services {
-		status, err := svc.Status(ctx)
-		if err != nil {
-			log.Printf("stream-status: %s status failed: %v", platform, err)
-			continue
+	platforms := make([]domain.Platform, 0, len(services))
+	for platform := range services {
+		platforms = append(platforms, platform)
+	}
+	sort.Slice(platforms, func(i, j int) bool { return platforms[i] < platforms[j] })
+
+	// cada plataforma escribe en su lugar; las que fallan quedan en nil
+	results := make([]*domain.StreamStatus, len(platforms))
+	sem := make(chan struct{}, maxParallelFetches)
+	var wg sync.WaitGroup
+	for i, platform := range platforms {
+		if !force {
+			if status, ok := r.cached(platform); ok {
+				results[i] = &status
+				continue
+			}
 		}
-		status.Platform = platform
-		out = append(out, status)
+		wg.Add(1)
+		go func(i int, platform domain.Platform, svc domain.StreamStatusService) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				log.Printf("stream-status: %s status failed: %v", platform, ctx.Err())
+				return
+			}
+			results[i] = r.fetch(ctx, platform, svc, version, timeout)
+		}(i, platform, services[platform])
 	}
+	wg.Wait()
 
+	out := make([]domain.StreamStatus, 0, len(results))
+	for _, status := range results {
+		if status != nil {
+			out = append(out, *status)
+		}
+	}
 	return out
 }
+
+// fetch consulta una plataforma con su propio timeout. Si falla lo registra
+// y devuelve nil.
+func (r *Resolver) fetch(ctx context.Context, platform domain.Platform, svc domain.StreamStatusService, version uint64, timeout time.Duration) *domain.StreamStatus {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status, err := svc.Status(callCtx)
+	if err != nil {
+		// si se canceló quien pidió el snapshot no es culpa de la plataforma
+		if ctx.Err() == nil {
+			r.recordFailure(platform, err)
+		}
+		return nil
+	}
+	r.recordSuccess(platform)
+	status.Platform = platform
+	if status.FetchedAt.IsZero() {
+		status.FetchedAt = r.now()
+	}
+	r.store(platform, version, status)
+	return &status
+}
+
+func (r *Resolver) cached(platform domain.Platform) (domain.StreamStatus, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.ttl <= 0 {
+		return domain.StreamStatus{}, false
+	}
+	status, ok := r.cache[platform]
+	if !ok || r.now().Sub(status.FetchedAt) >= r.ttl {
+		return domain.StreamStatus{}, false
+	}
+	return status, true
+}
+
+// store guarda status salvo que la caché se haya invalidado mientras se
+// consultaba.
+func (r *Resolver) store(platform domain.Platform, version uint64, status domain.StreamStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ttl <= 0 || r.version != version {
+		return
+	}
+	r.cache[platform] = status
+}
+
+// recordFailure cuenta el error y, al llegar al umbral, deshabilita la
+// plataforma y avisa una sola vez. Una vez deshabilitada ya no se registra.
+func (r *Resolver) recordFailure(platform domain.Platform, err error) {
+	r.mu.Lock()
+	if _, down := r.unhealthy[platform]; down {
+		r.mu.Unlock()
+		return
+	}
+	r.failures[platform]++
+	failures := r.failures[platform]
+	tripped := failures >= r.threshold
+	var onUnhealthy func(domain.Platform, error)
+	if tripped {
+		r.unhealthy[platform] = err
+		delete(r.cache, platform)
+		onUnhealthy = r.onUnhealthy
+	}
+	r.mu.Unlock()
+
+	log.Printf("stream-status: %s status failed (%d seguidos): %v", platform, failures, err)
+	if !tripped {
+		return
+	}
+	log.Printf("stream-status: %s deshabilitado tras %d errores; se reintenta al actualizar las credenciales", platform, failures)
+	if onUnhealthy != nil {
+		onUnhealthy(platform, err)
+	}
+}
+
+func (r *Resolver) recordSuccess(platform domain.Platform) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, platform)
+}
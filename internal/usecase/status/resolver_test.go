@@ -0,0 +1,315 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// fakeService devuelve lo que diga status y cuenta las llamadas.
+type fakeService struct {
+	calls  atomic.Int32
+	status func(ctx context.Context) (domain.StreamStatus, error)
+}
+
+func (f *fakeService) Status(ctx context.Context) (domain.StreamStatus, error) {
+	f.calls.Add(1)
+	if f.status == nil {
+		return domain.StreamStatus{IsLive: true}, nil
+	}
+	return f.status(ctx)
+}
+
+func fixedNow(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestSnapshotSetsFetchedAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+	r := NewResolver()
+	r.now = fixedNow(now)
+	r.Set(domain.PlatformTwitch, &fakeService{})
+
+	got := r.Snapshot(t.Context())
+	if len(got) != 1 {
+		t.Fatalf("esperaba 1 estado, hay %d", len(got))
+	}
+	if !got[0].FetchedAt.Equal(now) {
+		t.Errorf("FetchedAt = %v, esperaba %v", got[0].FetchedAt, now)
+	}
+	if got[0].Platform != domain.PlatformTwitch {
+		t.Errorf("Platform = %q, esperaba %q", got[0].Platform, domain.PlatformTwitch)
+	}
+}
+
+func TestSnapshotKeepsServiceFetchedAt(t *testing.T) {
+	fetched := time.Date(2024, 5, 1, 19, 59, 58, 0, time.UTC)
+	r := NewResolver()
+	r.now = fixedNow(fetched.Add(time.Second))
+	r.Set(domain.PlatformKick, &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		return domain.StreamStatus{FetchedAt: fetched, Source: "kick-api"}, nil
+	}})
+
+	got := r.Snapshot(t.Context())
+	if len(got) != 1 {
+		t.Fatalf("esperaba 1 estado, hay %d", len(got))
+	}
+	if !got[0].FetchedAt.Equal(fetched) {
+		t.Errorf("FetchedAt = %v, esperaba el del servicio %v", got[0].FetchedAt, fetched)
+	}
+	if got[0].Source != "kick-api" {
+		t.Errorf("Source = %q, esperaba kick-api", got[0].Source)
+	}
+}
+
+// clock es un reloj manual para vencer la caché sin esperar.
+type clock struct{ t time.Time }
+
+func (c *clock) Now() time.Time          { return c.t }
+func (c *clock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestSnapshotCache(t *testing.T) {
+	clk := &clock{t: time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)}
+	svc := &fakeService{}
+	r := NewResolver()
+	r.now = clk.Now
+	r.SetTTL(10 * time.Second)
+	r.Set(domain.PlatformTwitch, svc)
+
+	r.Snapshot(t.Context())
+	clk.Advance(9 * time.Second)
+	r.Snapshot(t.Context())
+	if n := svc.calls.Load(); n != 1 {
+		t.Fatalf("dentro del TTL hubo %d consultas, esperaba 1", n)
+	}
+
+	clk.Advance(time.Second)
+	r.Snapshot(t.Context())
+	if n := svc.calls.Load(); n != 2 {
+		t.Fatalf("con la caché vencida hubo %d consultas, esperaba 2", n)
+	}
+
+	r.Refresh(t.Context())
+	if n := svc.calls.Load(); n != 3 {
+		t.Fatalf("Refresh no consultó: %d consultas", n)
+	}
+
+	r.Invalidate(domain.PlatformTwitch)
+	r.Snapshot(t.Context())
+	if n := svc.calls.Load(); n != 4 {
+		t.Fatalf("Invalidate no descartó la caché: %d consultas", n)
+	}
+}
+
+func TestSnapshotCacheDisabled(t *testing.T) {
+	svc := &fakeService{}
+	r := NewResolver()
+	r.SetTTL(0)
+	r.Set(domain.PlatformTwitch, svc)
+
+	for range 3 {
+		r.Snapshot(t.Context())
+	}
+	if n := svc.calls.Load(); n != 3 {
+		t.Fatalf("sin TTL hubo %d consultas, esperaba 3", n)
+	}
+}
+
+func TestSnapshotCacheIgnoresStaleFetch(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	slow := &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		close(started)
+		<-release
+		return domain.StreamStatus{Title: "viejo"}, nil
+	}}
+	r := NewResolver()
+	r.Set(domain.PlatformTwitch, slow)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Snapshot(t.Context())
+	}()
+	<-started
+	// el título cambió mientras la consulta seguía en vuelo
+	r.Invalidate(domain.PlatformTwitch)
+	close(release)
+	<-done
+
+	fresh := &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		return domain.StreamStatus{Title: "nuevo"}, nil
+	}}
+	r.mu.Lock()
+	r.services[domain.PlatformTwitch] = fresh
+	r.mu.Unlock()
+
+	got := r.Snapshot(t.Context())
+	if len(got) != 1 || got[0].Title != "nuevo" {
+		t.Fatalf("Snapshot = %+v, la consulta vieja quedó en la caché", got)
+	}
+}
+
+func TestSnapshotFetchesConcurrently(t *testing.T) {
+	// cada servicio espera a que el otro haya empezado: en serie se trabarían
+	var ready sync.WaitGroup
+	ready.Add(2)
+	waitBoth := func(ctx context.Context) (domain.StreamStatus, error) {
+		ready.Done()
+		done := make(chan struct{})
+		go func() { ready.Wait(); close(done) }()
+		select {
+		case <-done:
+			return domain.StreamStatus{IsLive: true}, nil
+		case <-ctx.Done():
+			return domain.StreamStatus{}, ctx.Err()
+		}
+	}
+	r := NewResolver()
+	r.SetFetchTimeout(2 * time.Second)
+	r.Set(domain.PlatformTwitch, &fakeService{status: waitBoth})
+	r.Set(domain.PlatformKick, &fakeService{status: waitBoth})
+
+	got := r.Snapshot(t.Context())
+	if len(got) != 2 {
+		t.Fatalf("esperaba 2 estados, hay %d: las consultas no corrieron en paralelo", len(got))
+	}
+	// el orden es estable aunque terminen en cualquier orden
+	if got[0].Platform != domain.PlatformKick || got[1].Platform != domain.PlatformTwitch {
+		t.Fatalf("orden = %s, %s", got[0].Platform, got[1].Platform)
+	}
+}
+
+func TestSnapshotSkipsFailingPlatform(t *testing.T) {
+	r := NewResolver()
+	r.SetFetchTimeout(50 * time.Millisecond)
+	r.Set(domain.PlatformTwitch, &fakeService{status: func(ctx context.Context) (domain.StreamStatus, error) {
+		<-ctx.Done()
+		return domain.StreamStatus{}, ctx.Err()
+	}})
+	r.Set(domain.PlatformKick, &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		return domain.StreamStatus{Title: "en vivo"}, nil
+	}})
+	r.Set(domain.Platform("youtube"), &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		return domain.StreamStatus{}, errors.New("token revocado")
+	}})
+
+	start := time.Now()
+	got := r.Snapshot(t.Context())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Snapshot tardó %v: la plataforma colgada no respetó el timeout", elapsed)
+	}
+	if len(got) != 1 || got[0].Platform != domain.PlatformKick || got[0].Title != "en vivo" {
+		t.Fatalf("Snapshot = %+v, esperaba solo kick", got)
+	}
+}
+
+func TestSnapshotCancelled(t *testing.T) {
+	r := NewResolver()
+	r.Set(domain.PlatformTwitch, &fakeService{status: func(ctx context.Context) (domain.StreamStatus, error) {
+		<-ctx.Done()
+		return domain.StreamStatus{}, ctx.Err()
+	}})
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	if got := r.Snapshot(ctx); len(got) != 0 {
+		t.Fatalf("Snapshot = %+v", got)
+	}
+	// cancelar el pedido no cuenta como falla de la plataforma
+	if n := r.failures[domain.PlatformTwitch]; n != 0 {
+		t.Fatalf("failures = %d, esperaba 0", n)
+	}
+}
+
+func TestResolverTripsAfterConsecutiveFailures(t *testing.T) {
+	failing := true
+	svc := &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		if failing {
+			return domain.StreamStatus{}, errors.New("401 token revocado")
+		}
+		return domain.StreamStatus{IsLive: true}, nil
+	}}
+	r := NewResolver()
+	r.SetTTL(0)
+	r.SetFailureThreshold(3)
+	var tripped []string
+	r.SetUnhealthyHandler(func(platform domain.Platform, err error) {
+		tripped = append(tripped, string(platform)+": "+err.Error())
+	})
+	r.Set(domain.PlatformTwitch, svc)
+
+	for range 5 {
+		r.Snapshot(t.Context())
+	}
+	if n := svc.calls.Load(); n != 3 {
+		t.Fatalf("se consultó %d veces, esperaba que parara en 3", n)
+	}
+	if len(tripped) != 1 || tripped[0] != "twitch: 401 token revocado" {
+		t.Fatalf("avisos = %q, esperaba uno solo", tripped)
+	}
+	if got := r.Unhealthy(); got[domain.PlatformTwitch] != "401 token revocado" {
+		t.Fatalf("Unhealthy = %v", got)
+	}
+
+	// actualizar las credenciales la vuelve a habilitar
+	failing = false
+	if !r.ResetHealth(domain.PlatformTwitch) {
+		t.Fatal("ResetHealth = false con la plataforma deshabilitada")
+	}
+	if got := r.Snapshot(t.Context()); len(got) != 1 {
+		t.Fatalf("Snapshot = %+v tras ResetHealth", got)
+	}
+	if len(r.Unhealthy()) != 0 || r.ResetHealth(domain.PlatformTwitch) {
+		t.Fatal("la plataforma sigue deshabilitada")
+	}
+}
+
+func TestResolverSuccessResetsFailureCount(t *testing.T) {
+	calls := 0
+	svc := &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		calls++
+		// falla dos de cada tres: nunca llega a tres seguidas
+		if calls%3 != 0 {
+			return domain.StreamStatus{}, errors.New("timeout")
+		}
+		return domain.StreamStatus{}, nil
+	}}
+	r := NewResolver()
+	r.SetTTL(0)
+	r.SetFailureThreshold(3)
+	r.Set(domain.PlatformKick, svc)
+
+	for range 9 {
+		r.Snapshot(t.Context())
+	}
+	if len(r.Unhealthy()) != 0 {
+		t.Fatalf("se deshabilitó con errores intercalados: %v", r.Unhealthy())
+	}
+}
+
+func TestResolverSetReenablesPlatform(t *testing.T) {
+	r := NewResolver()
+	r.SetFailureThreshold(1)
+	r.Set(domain.PlatformTwitch, &fakeService{status: func(context.Context) (domain.StreamStatus, error) {
+		return domain.StreamStatus{}, errors.New("revocado")
+	}})
+	r.Snapshot(t.Context())
+	if len(r.Unhealthy()) != 1 {
+		t.Fatal("no se deshabilitó")
+	}
+
+	// un servicio nuevo (credenciales nuevas) empieza sano
+	r.Set(domain.PlatformTwitch, &fakeService{})
+	if len(r.Unhealthy()) != 0 {
+		t.Fatal("Set no rehabilitó la plataforma")
+	}
+	if got := r.Snapshot(t.Context()); len(got) != 1 {
+		t.Fatalf("Snapshot = %+v", got)
+	}
+}
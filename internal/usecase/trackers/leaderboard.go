@@ -0,0 +1,374 @@
+package trackers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	maxLeaderboardSize = 25
+	// LeaderboardByMessages y LeaderboardByEmotes son los criterios de orden.
+	LeaderboardByMessages = "messages"
+	LeaderboardByEmotes   = "emotes"
+)
+
+var ErrInvalidLeaderboard = errors.New("ranking inválido")
+
+type LeaderboardEntryDTO struct {
+	Rank     int    `json:"rank"`
+	Platform string `json:"platform"`
+	UserID   string `json:"user_id"`
+	Name     string `json:"name"`
+	Messages int    `json:"messages"`
+	Emotes   int    `json:"emotes"`
+}
+
+type LeaderboardDTO struct {
+	Scope     string                `json:"scope"`
+	By        string                `json:"by"`
+	SessionID string                `json:"session_id,omitempty"`
+	Entries   []LeaderboardEntryDTO `json:"entries"`
+}
+
+type chatterKey struct {
+	platform domain.Platform
+	userID   string
+}
+
+// Leaderboard cuenta mensajes y emotes por usuario, en la sesión de stream
+// actual y en total. Como los contadores, guarda los cambios como mucho una
+// vez por segundo.
+type Leaderboard struct {
+	repo domain.LeaderboardRepository
+	now  func() time.Time
+
+	mu        sync.Mutex
+	cfg       domain.LeaderboardSettings
+	session   SessionFunc
+	sessionID string
+	counts    map[domain.LeaderboardScope]map[chatterKey]*domain.ChatterStats
+	dirty     map[domain.LeaderboardScope]map[chatterKey]struct{}
+	// clearSession pide borrar la sesión guardada en el próximo Flush.
+	clearSession bool
+}
+
+func NewLeaderboard(repo domain.LeaderboardRepository) *Leaderboard {
+	l := &Leaderboard{
+		repo: repo,
+		now:  time.Now,
+		cfg:  domain.DefaultLeaderboardSettings(),
+	}
+	l.resetLocked()
+	return l
+}
+
+func (l *Leaderboard) resetLocked() {
+	l.counts = map[domain.LeaderboardScope]map[chatterKey]*domain.ChatterStats{
+		domain.LeaderboardSession: make(map[chatterKey]*domain.ChatterStats),
+		domain.LeaderboardAllTime: make(map[chatterKey]*domain.ChatterStats),
+	}
+	l.dirty = map[domain.LeaderboardScope]map[chatterKey]struct{}{
+		domain.LeaderboardSession: make(map[chatterKey]struct{}),
+		domain.LeaderboardAllTime: make(map[chatterKey]struct{}),
+	}
+}
+
+// SetSessionFunc hace que el ranking de sesión se vacíe cuando empieza otro
+// directo. Sin estado del stream la sesión dura hasta que se resetee a mano.
+func (l *Leaderboard) SetSessionFunc(fn SessionFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.session = fn
+}
+
+// Load lee la configuración y los conteos guardados.
+func (l *Leaderboard) Load(ctx context.Context) error {
+	if l.repo == nil {
+		return nil
+	}
+	cfg := domain.DefaultLeaderboardSettings()
+	stored, err := l.repo.GetLeaderboardSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		cfg = normalizeLeaderboardSettings(*stored)
+	}
+
+	counts := make(map[domain.LeaderboardScope]map[chatterKey]*domain.ChatterStats, 2)
+	sessionID := ""
+	for _, scope := range []domain.LeaderboardScope{domain.LeaderboardSession, domain.LeaderboardAllTime} {
+		rows, err := l.repo.ListChatterStats(ctx, scope)
+		if err != nil {
+			return err
+		}
+		counts[scope] = make(map[chatterKey]*domain.ChatterStats, len(rows))
+		for i := range rows {
+			row := rows[i]
+			counts[scope][chatterKey{platform: row.Platform, userID: row.UserID}] = &row
+			if scope == domain.LeaderboardSession && row.SessionID != "" {
+				sessionID = row.SessionID
+			}
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = cfg
+	l.resetLocked()
+	l.counts = counts
+	l.sessionID = sessionID
+	return nil
+}
+
+// Reload solo vuelve a leer la configuración; los conteos en memoria mandan.
+func (l *Leaderboard) Reload(ctx context.Context) error {
+	if l.repo == nil {
+		return nil
+	}
+	stored, err := l.repo.GetLeaderboardSettings(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := domain.DefaultLeaderboardSettings()
+	if stored != nil {
+		cfg = normalizeLeaderboardSettings(*stored)
+	}
+	l.mu.Lock()
+	l.cfg = cfg
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *Leaderboard) Settings() domain.LeaderboardSettings {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cfg
+}
+
+func (l *Leaderboard) Update(ctx context.Context, settings domain.LeaderboardSettings) (domain.LeaderboardSettings, error) {
+	if settings.Scope != "" {
+		scope, err := domain.ParseLeaderboardScope(string(settings.Scope))
+		if err != nil {
+			return domain.LeaderboardSettings{}, err
+		}
+		settings.Scope = scope
+	}
+	settings = normalizeLeaderboardSettings(settings)
+	if l.repo != nil {
+		if err := l.repo.SetLeaderboardSettings(ctx, settings); err != nil {
+			return domain.LeaderboardSettings{}, err
+		}
+	}
+	l.mu.Lock()
+	l.cfg = settings
+	l.mu.Unlock()
+	return settings, nil
+}
+
+// Observe suma el mensaje a los dos rankings. Los comandos cuentan como
+// mensajes igual que el resto.
+func (l *Leaderboard) Observe(ctx context.Context, msg domain.Message) {
+	if msg.UserID == "" || strings.TrimSpace(msg.Text) == "" {
+		return
+	}
+	name := msg.Name()
+	if name == "" {
+		name = msg.LoginName()
+	}
+	k := chatterKey{platform: msg.Platform, userID: msg.UserID}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.syncSessionLocked(ctx)
+	now := l.now().UTC()
+	for scope, counts := range l.counts {
+		stats, ok := counts[k]
+		if !ok {
+			stats = &domain.ChatterStats{Platform: msg.Platform, UserID: msg.UserID}
+			counts[k] = stats
+		}
+		stats.Name = name
+		stats.Messages++
+		stats.Emotes += msg.Emotes
+		stats.LastSeen = now
+		if scope == domain.LeaderboardSession {
+			stats.SessionID = l.sessionID
+		}
+		l.dirty[scope][k] = struct{}{}
+	}
+}
+
+// Top devuelve los n usuarios más activos del scope ordenados por by
+// (messages o emotes). scope vacío usa el de la configuración y n <= 0 el
+// tamaño configurado.
+func (l *Leaderboard) Top(ctx context.Context, scope domain.LeaderboardScope, by string, n int) (LeaderboardDTO, error) {
+	by = strings.ToLower(strings.TrimSpace(by))
+	if by == "" {
+		by = LeaderboardByMessages
+	}
+	if by != LeaderboardByMessages && by != LeaderboardByEmotes {
+		return LeaderboardDTO{}, ErrInvalidLeaderboard
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.syncSessionLocked(ctx)
+	if scope == "" {
+		scope = l.cfg.Scope
+	}
+	counts, ok := l.counts[scope]
+	if !ok {
+		return LeaderboardDTO{}, ErrInvalidLeaderboard
+	}
+	if n <= 0 {
+		n = l.cfg.Size
+	}
+	if n > maxLeaderboardSize {
+		n = maxLeaderboardSize
+	}
+
+	entries := make([]LeaderboardEntryDTO, 0, len(counts))
+	for _, stats := range counts {
+		if by == LeaderboardByEmotes && stats.Emotes == 0 {
+			continue
+		}
+		entries = append(entries, LeaderboardEntryDTO{
+			Platform: string(stats.Platform),
+			UserID:   stats.UserID,
+			Name:     stats.Name,
+			Messages: stats.Messages,
+			Emotes:   stats.Emotes,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		primaryA, primaryB, secondaryA, secondaryB := a.Messages, b.Messages, a.Emotes, b.Emotes
+		if by == LeaderboardByEmotes {
+			primaryA, primaryB, secondaryA, secondaryB = a.Emotes, b.Emotes, a.Messages, b.Messages
+		}
+		if primaryA != primaryB {
+			return primaryA > primaryB
+		}
+		if secondaryA != secondaryB {
+			return secondaryA > secondaryB
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	out := LeaderboardDTO{Scope: string(scope), By: by, Entries: entries}
+	if scope == domain.LeaderboardSession {
+		out.SessionID = l.sessionID
+	}
+	return out, nil
+}
+
+// Reset vacía un ranking.
+func (l *Leaderboard) Reset(ctx context.Context, scope domain.LeaderboardScope) error {
+	l.mu.Lock()
+	if _, ok := l.counts[scope]; !ok {
+		l.mu.Unlock()
+		return ErrInvalidLeaderboard
+	}
+	l.counts[scope] = make(map[chatterKey]*domain.ChatterStats)
+	l.dirty[scope] = make(map[chatterKey]struct{})
+	if scope == domain.LeaderboardSession {
+		l.clearSession = false
+	}
+	l.mu.Unlock()
+
+	if l.repo == nil {
+		return nil
+	}
+	return l.repo.ClearChatterStats(ctx, scope)
+}
+
+// syncSessionLocked vacía el ranking de sesión cuando empieza otro directo.
+func (l *Leaderboard) syncSessionLocked(ctx context.Context) {
+	if l.session == nil {
+		return
+	}
+	current := l.session(ctx)
+	if current == "" || current == l.sessionID {
+		return
+	}
+	if l.sessionID != "" || len(l.counts[domain.LeaderboardSession]) > 0 {
+		l.counts[domain.LeaderboardSession] = make(map[chatterKey]*domain.ChatterStats)
+		l.dirty[domain.LeaderboardSession] = make(map[chatterKey]struct{})
+		l.clearSession = true
+	}
+	l.sessionID = current
+}
+
+// Run guarda los conteos pendientes como mucho una vez por segundo.
+func (l *Leaderboard) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.Flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			l.Flush(ctx)
+		}
+	}
+}
+
+// Flush persiste los usuarios que cambiaron.
+func (l *Leaderboard) Flush(ctx context.Context) {
+	l.mu.Lock()
+	clearSession := l.clearSession
+	l.clearSession = false
+	changed := make(map[domain.LeaderboardScope][]domain.ChatterStats, len(l.dirty))
+	for scope, keys := range l.dirty {
+		for k := range keys {
+			if stats, ok := l.counts[scope][k]; ok {
+				changed[scope] = append(changed[scope], *stats)
+			}
+		}
+		l.dirty[scope] = make(map[chatterKey]struct{})
+	}
+	l.mu.Unlock()
+
+	if l.repo == nil {
+		return
+	}
+	if clearSession {
+		if err := l.repo.ClearChatterStats(ctx, domain.LeaderboardSession); err != nil {
+			log.Printf("leaderboard: no pude vaciar la sesión anterior: %v", err)
+		}
+	}
+	for scope, stats := range changed {
+		if err := l.repo.SaveChatterStats(ctx, scope, stats); err != nil {
+			log.Printf("leaderboard: no pude guardar %s: %v", scope, err)
+		}
+	}
+}
+
+func normalizeLeaderboardSettings(settings domain.LeaderboardSettings) domain.LeaderboardSettings {
+	defaults := domain.DefaultLeaderboardSettings()
+	if settings.Scope != domain.LeaderboardSession && settings.Scope != domain.LeaderboardAllTime {
+		settings.Scope = defaults.Scope
+	}
+	if settings.Size <= 0 {
+		settings.Size = defaults.Size
+	}
+	if settings.Size > maxLeaderboardSize {
+		settings.Size = maxLeaderboardSize
+	}
+	return settings
+}
@@ -0,0 +1,228 @@
+package trackers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryLeaderboardRepo hace de la tabla chatter_stats.
+type memoryLeaderboardRepo struct {
+	mu       sync.Mutex
+	settings *domain.LeaderboardSettings
+	stats    map[domain.LeaderboardScope]map[chatterKey]domain.ChatterStats
+}
+
+func newMemoryLeaderboardRepo() *memoryLeaderboardRepo {
+	return &memoryLeaderboardRepo{stats: map[domain.LeaderboardScope]map[chatterKey]domain.ChatterStats{
+		domain.LeaderboardSession: {},
+		domain.LeaderboardAllTime: {},
+	}}
+}
+
+func (r *memoryLeaderboardRepo) GetLeaderboardSettings(context.Context) (*domain.LeaderboardSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.settings, nil
+}
+
+func (r *memoryLeaderboardRepo) SetLeaderboardSettings(_ context.Context, settings domain.LeaderboardSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings = &settings
+	return nil
+}
+
+func (r *memoryLeaderboardRepo) ListChatterStats(_ context.Context, scope domain.LeaderboardScope) ([]domain.ChatterStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.ChatterStats, 0, len(r.stats[scope]))
+	for _, stats := range r.stats[scope] {
+		out = append(out, stats)
+	}
+	return out, nil
+}
+
+func (r *memoryLeaderboardRepo) SaveChatterStats(_ context.Context, scope domain.LeaderboardScope, stats []domain.ChatterStats) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range stats {
+		r.stats[scope][chatterKey{platform: row.Platform, userID: row.UserID}] = row
+	}
+	return nil
+}
+
+func (r *memoryLeaderboardRepo) ClearChatterStats(_ context.Context, scope domain.LeaderboardScope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats[scope] = map[chatterKey]domain.ChatterStats{}
+	return nil
+}
+
+func chatter(platform domain.Platform, user, text string, emotes int) domain.Message {
+	return domain.Message{
+		Platform: platform,
+		UserID:   "id-" + user,
+		Username: user,
+		Text:     text,
+		Emotes:   emotes,
+	}
+}
+
+func names(dto LeaderboardDTO) []string {
+	out := make([]string, 0, len(dto.Entries))
+	for _, entry := range dto.Entries {
+		out = append(out, entry.Name)
+	}
+	return out
+}
+
+func TestLeaderboardCountsAndRanks(t *testing.T) {
+	ctx := context.Background()
+	board := NewLeaderboard(nil)
+
+	for _, msg := range []domain.Message{
+		chatter(domain.PlatformTwitch, "ana", "hola", 0),
+		chatter(domain.PlatformTwitch, "ana", "Kappa Kappa", 2),
+		chatter(domain.PlatformTwitch, "ana", "jaja", 0),
+		chatter(domain.PlatformTwitch, "beto", "PogChamp PogChamp PogChamp", 3),
+		chatter(domain.PlatformTwitch, "beto", "gg", 0),
+		// mismo ID en otra plataforma: es otra persona
+		chatter(domain.PlatformKick, "ana", "hola desde kick", 0),
+		chatter(domain.PlatformKick, "carla", "[emote:1:wave]", 1),
+		// sin texto o sin usuario no cuenta
+		chatter(domain.PlatformTwitch, "ana", "   ", 0),
+		{Platform: domain.PlatformTwitch, Text: "anónimo"},
+	} {
+		board.Observe(ctx, msg)
+	}
+
+	top, err := board.Top(ctx, domain.LeaderboardAllTime, LeaderboardByMessages, 0)
+	if err != nil {
+		t.Fatalf("Top: %v", err)
+	}
+	// desempata por emotes y después por nombre
+	want := []string{"ana", "beto", "carla", "ana"}
+	if got := names(top); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+		t.Fatalf("ranking por mensajes = %v, esperaba %v", got, want)
+	}
+	first := top.Entries[0]
+	if first.Rank != 1 || first.Platform != "twitch" || first.Messages != 3 || first.Emotes != 2 {
+		t.Fatalf("primer puesto = %+v", first)
+	}
+
+	byEmotes, err := board.Top(ctx, domain.LeaderboardAllTime, "EMOTES", 2)
+	if err != nil {
+		t.Fatalf("Top emotes: %v", err)
+	}
+	if got := names(byEmotes); len(got) != 2 || got[0] != "beto" || got[1] != "ana" {
+		t.Fatalf("ranking por emotes = %v", got)
+	}
+	if byEmotes.Entries[1].Rank != 2 {
+		t.Fatalf("rank = %d", byEmotes.Entries[1].Rank)
+	}
+
+	if _, err := board.Top(ctx, domain.LeaderboardAllTime, "bits", 0); !errors.Is(err, ErrInvalidLeaderboard) {
+		t.Fatalf("criterio inválido = %v", err)
+	}
+	if _, err := board.Top(ctx, "semana", "", 0); !errors.Is(err, ErrInvalidLeaderboard) {
+		t.Fatalf("scope inválido = %v", err)
+	}
+}
+
+func TestLeaderboardResetsOnNewSession(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLeaderboardRepo()
+	board := NewLeaderboard(repo)
+	session := "stream-1"
+	board.SetSessionFunc(func(context.Context) string { return session })
+
+	board.Observe(ctx, chatter(domain.PlatformTwitch, "ana", "hola", 0))
+	board.Flush(ctx)
+
+	session = "stream-2"
+	board.Observe(ctx, chatter(domain.PlatformTwitch, "beto", "llegué", 0))
+	board.Flush(ctx)
+
+	current, err := board.Top(ctx, domain.LeaderboardSession, "", 0)
+	if err != nil {
+		t.Fatalf("Top: %v", err)
+	}
+	if got := names(current); len(got) != 1 || got[0] != "beto" || current.SessionID != "stream-2" {
+		t.Fatalf("sesión = %v (%s), esperaba solo beto en stream-2", got, current.SessionID)
+	}
+	total, _ := board.Top(ctx, domain.LeaderboardAllTime, "", 0)
+	if len(total.Entries) != 2 {
+		t.Fatalf("el total perdió usuarios al cambiar de sesión: %v", names(total))
+	}
+	if saved, _ := repo.ListChatterStats(ctx, domain.LeaderboardSession); len(saved) != 1 || saved[0].SessionID != "stream-2" {
+		t.Fatalf("sesión guardada = %+v", saved)
+	}
+}
+
+func TestLeaderboardSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLeaderboardRepo()
+	board := NewLeaderboard(repo)
+	board.SetSessionFunc(func(context.Context) string { return "stream-1" })
+	for range 3 {
+		board.Observe(ctx, chatter(domain.PlatformTwitch, "ana", "hola", 1))
+	}
+	board.Flush(ctx)
+
+	restarted := NewLeaderboard(repo)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	restarted.SetSessionFunc(func(context.Context) string { return "stream-1" })
+	restarted.Observe(ctx, chatter(domain.PlatformTwitch, "ana", "sigo acá", 0))
+
+	top, err := restarted.Top(ctx, domain.LeaderboardSession, "", 0)
+	if err != nil {
+		t.Fatalf("Top: %v", err)
+	}
+	if len(top.Entries) != 1 || top.Entries[0].Messages != 4 || top.Entries[0].Emotes != 3 {
+		t.Fatalf("tras reiniciar en la misma sesión = %+v", top.Entries)
+	}
+}
+
+func TestLeaderboardSettingsAndReset(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLeaderboardRepo()
+	board := NewLeaderboard(repo)
+
+	settings, err := board.Update(ctx, domain.LeaderboardSettings{Scope: "all", Size: 100})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if settings.Scope != domain.LeaderboardAllTime || settings.Size != maxLeaderboardSize {
+		t.Fatalf("settings = %+v", settings)
+	}
+	if _, err := board.Update(ctx, domain.LeaderboardSettings{Scope: "semana"}); err == nil {
+		t.Fatal("se aceptó un scope inválido")
+	}
+
+	board.Observe(ctx, chatter(domain.PlatformTwitch, "ana", "hola", 0))
+	board.Flush(ctx)
+	top, _ := board.Top(ctx, "", "", 0)
+	if top.Scope != string(domain.LeaderboardAllTime) || len(top.Entries) != 1 {
+		t.Fatalf("Top con el scope por defecto = %+v", top)
+	}
+
+	if err := board.Reset(ctx, domain.LeaderboardAllTime); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	top, _ = board.Top(ctx, "", "", 0)
+	if len(top.Entries) != 0 {
+		t.Fatalf("Reset no vació el ranking: %v", names(top))
+	}
+	if saved, _ := repo.ListChatterStats(ctx, domain.LeaderboardAllTime); len(saved) != 0 {
+		t.Fatalf("Reset no vació lo guardado: %+v", saved)
+	}
+	if session, _ := board.Top(ctx, domain.LeaderboardSession, "", 0); len(session.Entries) != 1 {
+		t.Fatal("Reset del total vació la sesión")
+	}
+}
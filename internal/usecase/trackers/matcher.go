@@ -0,0 +1,80 @@
+package trackers
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"zhatBot/internal/domain"
+)
+
+// CountMatches cuenta las apariciones de term en text según el tipo de contador.
+func CountMatches(kind domain.TrackerKind, term, text string) int {
+	term = strings.TrimSpace(term)
+	if term == "" || text == "" {
+		return 0
+	}
+	if kind == domain.TrackerKindEmote {
+		return countEmote(term, text)
+	}
+	return countWord(term, text)
+}
+
+// countEmote compara tokens completos: los códigos de emote distinguen mayúsculas.
+func countEmote(code, text string) int {
+	total := 0
+	for _, token := range strings.Fields(text) {
+		if token == code {
+			total++
+		}
+	}
+	return total
+}
+
+// countWord busca la palabra sin distinguir mayúsculas y solo cuando no está
+// pegada a otras letras o números ("gg" cuenta en "gg!" pero no en "eggs").
+func countWord(word, text string) int {
+	word = strings.ToLower(word)
+	text = strings.ToLower(text)
+
+	total := 0
+	offset := 0
+	for {
+		idx := strings.Index(text[offset:], word)
+		if idx < 0 {
+			return total
+		}
+		start := offset + idx
+		end := start + len(word)
+		if isBoundaryBefore(text, start) && isBoundaryAfter(text, end) {
+			total++
+			offset = end
+		} else {
+			_, size := utf8.DecodeRuneInString(text[start:])
+			offset = start + size
+		}
+		if offset >= len(text) {
+			return total
+		}
+	}
+}
+
+func isBoundaryBefore(text string, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:idx])
+	return !isWordRune(r)
+}
+
+func isBoundaryAfter(text string, idx int) bool {
+	if idx >= len(text) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text[idx:])
+	return !isWordRune(r)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
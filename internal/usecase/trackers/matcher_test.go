@@ -0,0 +1,54 @@
+package trackers
+
+import (
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+func TestCountMatchesWordBoundaries(t *testing.T) {
+	cases := []struct {
+		term string
+		text string
+		want int
+	}{
+		{"gg", "gg", 1},
+		{"gg", "GG gg Gg", 3},
+		{"gg", "gg! gg, (gg)", 3},
+		{"gg", "eggs", 0},
+		{"gg", "ggwp gg_ez", 0},
+		{"gg", "gg2 2gg", 0},
+		{"gg", "gggg", 0},
+		{"jaja", "JAJA jajaja", 1},
+		{"año", "feliz año, añoranza", 1},
+		{"pog", "ápog pogá pog", 1},
+		{"buenas noches", "buenas noches chat, BUENAS NOCHES", 2},
+		{" gg ", "gg", 1},
+		{"", "gg", 0},
+		{"gg", "", 0},
+	}
+	for _, tc := range cases {
+		if got := CountMatches(domain.TrackerKindWord, tc.term, tc.text); got != tc.want {
+			t.Errorf("CountMatches(word, %q, %q) = %d, want %d", tc.term, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestCountMatchesEmoteIsExactToken(t *testing.T) {
+	cases := []struct {
+		code string
+		text string
+		want int
+	}{
+		{"Kappa", "Kappa Kappa", 2},
+		{"Kappa", "kappa KAPPA", 0},
+		{"Kappa", "KappaPride Kappa!", 0},
+		{"Kappa", "hola\tKappa\nKappa", 2},
+		{"LUL", "jaja LUL", 1},
+	}
+	for _, tc := range cases {
+		if got := CountMatches(domain.TrackerKindEmote, tc.code, tc.text); got != tc.want {
+			t.Errorf("CountMatches(emote, %q, %q) = %d, want %d", tc.code, tc.text, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+// Package trackers cuenta palabras/emotes del chat por sesión de stream.
+package trackers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// EventTypeCount es el tipo del envelope WS con los conteos actualizados.
+const EventTypeCount = "tracker-count"
+
+const (
+	defaultMilestoneEvery = 100
+	flushInterval         = time.Second
+)
+
+var (
+	ErrTrackerNotFound = errors.New("contador no encontrado")
+	ErrInvalidTracker  = errors.New("contador inválido")
+)
+
+// Publisher envía eventos a los clientes WS conectados.
+type Publisher interface {
+	PublishEvent(ctx context.Context, eventType string, data any) error
+}
+
+// SessionFunc devuelve el ID de la sesión de stream actual ("" si se desconoce).
+type SessionFunc func(ctx context.Context) string
+
+type TrackerDTO struct {
+	Name           string `json:"name"`
+	Term           string `json:"term"`
+	Kind           string `json:"kind"`
+	MilestoneEvery int    `json:"milestone_every"`
+	Enabled        bool   `json:"enabled"`
+	Count          int    `json:"count"`
+	SessionID      string `json:"session_id,omitempty"`
+}
+
+type TrackerMutationDTO struct {
+	Name           string `json:"name"`
+	Term           string `json:"term"`
+	Kind           string `json:"kind"`
+	MilestoneEvery *int   `json:"milestone_every,omitempty"`
+	Enabled        *bool  `json:"enabled,omitempty"`
+}
+
+type Service struct {
+	repo domain.TrackerRepository
+	out  domain.OutgoingMessagePort
+
+	mu        sync.Mutex
+	trackers  map[string]*domain.WordTracker
+	dirty     map[string]struct{}
+	publisher Publisher
+	session   SessionFunc
+}
+
+func NewService(ctx context.Context, repo domain.TrackerRepository, out domain.OutgoingMessagePort) (*Service, error) {
+	s := &Service{
+		repo:     repo,
+		out:      out,
+		trackers: make(map[string]*domain.WordTracker),
+		dirty:    make(map[string]struct{}),
+	}
+	if repo == nil {
+		return s, nil
+	}
+	stored, err := repo.ListTrackers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tracker := range stored {
+		if tracker == nil {
+			continue
+		}
+		s.trackers[strings.ToLower(tracker.Name)] = tracker
+	}
+	return s, nil
+}
+
+// Reload vuelve a leer la configuración de los contadores. Conserva los conteos
+// en memoria que todavía no se guardaron.
+func (s *Service) Reload(ctx context.Context) error {
+	if s.repo == nil {
+		return nil
+	}
+	stored, err := s.repo.ListTrackers(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := make(map[string]*domain.WordTracker, len(stored))
+	for _, tracker := range stored {
+		if tracker == nil {
+			continue
+		}
+		key := strings.ToLower(tracker.Name)
+		if current, ok := s.trackers[key]; ok && current.SessionID == tracker.SessionID && current.Count > tracker.Count {
+			tracker.Count = current.Count
+		}
+		next[key] = tracker
+	}
+	s.trackers = next
+	for key := range s.dirty {
+		if _, ok := next[key]; !ok {
+			delete(s.dirty, key)
+		}
+	}
+	return nil
+}
+
+func (s *Service) SetPublisher(p Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publisher = p
+}
+
+func (s *Service) SetSessionFunc(fn SessionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = fn
+}
+
+func (s *Service) List() []TrackerDTO {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]TrackerDTO, 0, len(s.trackers))
+	for _, tracker := range s.trackers {
+		out = append(out, toDTO(tracker))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Trackers devuelve los contadores activos (lo usa !count).
+func (s *Service) Trackers() []domain.WordTracker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]domain.WordTracker, 0, len(s.trackers))
+	for _, tracker := range s.trackers {
+		if tracker.Enabled {
+			out = append(out, *tracker)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *Service) Upsert(ctx context.Context, input TrackerMutationDTO) (TrackerDTO, error) {
+	name := strings.ToLower(strings.TrimSpace(input.Name))
+	if name == "" {
+		return TrackerDTO{}, fmt.Errorf("%w: falta el nombre", ErrInvalidTracker)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tracker, exists := s.trackers[name]
+	var next domain.WordTracker
+	if exists {
+		next = *tracker
+	} else {
+		next = domain.WordTracker{
+			Name:           name,
+			Kind:           domain.TrackerKindWord,
+			MilestoneEvery: defaultMilestoneEvery,
+			Enabled:        true,
+		}
+	}
+
+	if term := strings.TrimSpace(input.Term); term != "" {
+		if next.Term != "" && next.Term != term {
+			next.Count = 0
+		}
+		next.Term = term
+	}
+	if next.Term == "" {
+		return TrackerDTO{}, fmt.Errorf("%w: falta la palabra o emote", ErrInvalidTracker)
+	}
+	if strings.TrimSpace(input.Kind) != "" {
+		kind, err := domain.ParseTrackerKind(input.Kind)
+		if err != nil {
+			return TrackerDTO{}, fmt.Errorf("%w: %v", ErrInvalidTracker, err)
+		}
+		next.Kind = kind
+	}
+	if input.MilestoneEvery != nil {
+		if *input.MilestoneEvery < 0 {
+			return TrackerDTO{}, fmt.Errorf("%w: milestone_every no puede ser negativo", ErrInvalidTracker)
+		}
+		next.MilestoneEvery = *input.MilestoneEvery
+	}
+	if input.Enabled != nil {
+		next.Enabled = *input.Enabled
+	}
+	next.UpdatedAt = time.Now().UTC()
+
+	if s.repo != nil {
+		if err := s.repo.UpsertTracker(ctx, &next); err != nil {
+			return TrackerDTO{}, err
+		}
+	}
+	s.trackers[name] = &next
+	s.dirty[name] = struct{}{}
+	return toDTO(&next), nil
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.trackers[name]; !ok {
+		return ErrTrackerNotFound
+	}
+	if s.repo != nil {
+		if err := s.repo.DeleteTracker(ctx, name); err != nil {
+			return err
+		}
+	}
+	delete(s.trackers, name)
+	delete(s.dirty, name)
+	return nil
+}
+
+// Reset pone el contador a cero en la sesión actual.
+func (s *Service) Reset(ctx context.Context, name string) (TrackerDTO, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tracker, ok := s.trackers[name]
+	if !ok {
+		return TrackerDTO{}, ErrTrackerNotFound
+	}
+	tracker.Count = 0
+	if s.repo != nil {
+		if err := s.repo.UpdateTrackerCount(ctx, tracker.Name, tracker.SessionID, 0); err != nil {
+			return TrackerDTO{}, err
+		}
+	}
+	s.dirty[name] = struct{}{}
+	return toDTO(tracker), nil
+}
+
+// Observe cuenta las apariciones en un mensaje del chat y anuncia los hitos.
+func (s *Service) Observe(ctx context.Context, msg domain.Message) {
+	if strings.TrimSpace(msg.Text) == "" {
+		return
+	}
+
+	var milestones []string
+
+	s.mu.Lock()
+	sessionID := ""
+	if s.session != nil && len(s.trackers) > 0 {
+		sessionID = s.session(ctx)
+	}
+	for key, tracker := range s.trackers {
+		if !tracker.Enabled {
+			continue
+		}
+		if sessionID != "" && tracker.SessionID != sessionID {
+			tracker.SessionID = sessionID
+			tracker.Count = 0
+			s.dirty[key] = struct{}{}
+		}
+		hits := CountMatches(tracker.Kind, tracker.Term, msg.Text)
+		if hits == 0 {
+			continue
+		}
+		before := tracker.Count
+		tracker.Count += hits
+		s.dirty[key] = struct{}{}
+
+		if every := tracker.MilestoneEvery; every > 0 && tracker.Count/every > before/every {
+			milestones = append(milestones,
+				fmt.Sprintf("🎉 ¡%s ya va %d veces en este stream!", tracker.Term, tracker.Count/every*every))
+		}
+	}
+	s.mu.Unlock()
+
+	if s.out == nil {
+		return
+	}
+	for _, text := range milestones {
+		if err := s.out.SendMessage(ctx, msg.Platform, msg.ChannelID, text); err != nil {
+			log.Printf("trackers: no pude anunciar el hito: %v", err)
+		}
+	}
+}
+
+// Run guarda y publica los conteos pendientes como mucho una vez por segundo.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			s.Flush(ctx)
+		}
+	}
+}
+
+// Flush persiste y publica en un solo evento los contadores que cambiaron.
+func (s *Service) Flush(ctx context.Context) {
+	s.mu.Lock()
+	if len(s.dirty) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	changed := make([]TrackerDTO, 0, len(s.dirty))
+	for key := range s.dirty {
+		if tracker, ok := s.trackers[key]; ok {
+			changed = append(changed, toDTO(tracker))
+		}
+	}
+	s.dirty = make(map[string]struct{})
+	publisher := s.publisher
+	s.mu.Unlock()
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+
+	if s.repo != nil {
+		for _, tracker := range changed {
+			if err := s.repo.UpdateTrackerCount(ctx, tracker.Name, tracker.SessionID, tracker.Count); err != nil {
+				log.Printf("trackers: no pude guardar %s: %v", tracker.Name, err)
+			}
+		}
+	}
+	if publisher != nil && len(changed) > 0 {
+		if err := publisher.PublishEvent(ctx, EventTypeCount, changed); err != nil {
+			log.Printf("trackers: publish error: %v", err)
+		}
+	}
+}
+
+func toDTO(tracker *domain.WordTracker) TrackerDTO {
+	return TrackerDTO{
+		Name:           tracker.Name,
+		Term:           tracker.Term,
+		Kind:           string(tracker.Kind),
+		MilestoneEvery: tracker.MilestoneEvery,
+		Enabled:        tracker.Enabled,
+		Count:          tracker.Count,
+		SessionID:      tracker.SessionID,
+	}
+}
@@ -0,0 +1,220 @@
+package trackers
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type memoryTrackerRepo struct {
+	mu       sync.Mutex
+	trackers map[string]*domain.WordTracker
+	updates  int
+}
+
+func newMemoryTrackerRepo() *memoryTrackerRepo {
+	return &memoryTrackerRepo{trackers: map[string]*domain.WordTracker{}}
+}
+
+func (r *memoryTrackerRepo) ListTrackers(context.Context) ([]*domain.WordTracker, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]*domain.WordTracker, 0, len(r.trackers))
+	for _, tracker := range r.trackers {
+		copied := *tracker
+		out = append(out, &copied)
+	}
+	return out, nil
+}
+
+func (r *memoryTrackerRepo) UpsertTracker(_ context.Context, tracker *domain.WordTracker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *tracker
+	r.trackers[strings.ToLower(tracker.Name)] = &copied
+	return nil
+}
+
+func (r *memoryTrackerRepo) DeleteTracker(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.trackers, strings.ToLower(name))
+	return nil
+}
+
+func (r *memoryTrackerRepo) UpdateTrackerCount(_ context.Context, name, sessionID string, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates++
+	if tracker, ok := r.trackers[strings.ToLower(name)]; ok {
+		tracker.SessionID = sessionID
+		tracker.Count = count
+	}
+	return nil
+}
+
+type countEvents struct {
+	mu     sync.Mutex
+	events [][]TrackerDTO
+}
+
+func (p *countEvents) PublishEvent(_ context.Context, eventType string, data any) error {
+	if eventType != EventTypeCount {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, data.([]TrackerDTO))
+	return nil
+}
+
+func (p *countEvents) published() [][]TrackerDTO {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([][]TrackerDTO(nil), p.events...)
+}
+
+type chatOut struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (o *chatOut) SendMessage(_ context.Context, _ domain.Platform, _ string, text string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.texts = append(o.texts, text)
+	return nil
+}
+
+func newTestTrackers(t *testing.T, repo *memoryTrackerRepo, out domain.OutgoingMessagePort, inputs ...TrackerMutationDTO) (*Service, *countEvents) {
+	t.Helper()
+	svc, err := NewService(context.Background(), repo, out)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	for _, input := range inputs {
+		if _, err := svc.Upsert(context.Background(), input); err != nil {
+			t.Fatalf("Upsert(%s): %v", input.Name, err)
+		}
+	}
+	events := &countEvents{}
+	svc.SetPublisher(events)
+	svc.SetSessionFunc(func(context.Context) string { return "stream-1" })
+	return svc, events
+}
+
+func chat(text string) domain.Message {
+	return domain.Message{Platform: domain.PlatformTwitch, ChannelID: "canal", Text: text}
+}
+
+func TestFlushBatchesIncrementsIntoOneEvent(t *testing.T) {
+	repo := newMemoryTrackerRepo()
+	svc, events := newTestTrackers(t, repo, nil,
+		TrackerMutationDTO{Name: "gg", Term: "gg"},
+		TrackerMutationDTO{Name: "kappa", Term: "Kappa", Kind: "emote"},
+	)
+	svc.Flush(context.Background())
+	events.mu.Lock()
+	events.events = nil
+	events.mu.Unlock()
+	repo.updates = 0
+
+	for i := 0; i < 50; i++ {
+		svc.Observe(context.Background(), chat("gg Kappa"))
+	}
+	svc.Observe(context.Background(), chat("nada que contar"))
+	svc.Flush(context.Background())
+
+	published := events.published()
+	if len(published) != 1 {
+		t.Fatalf("published %d events for 51 messages, want 1", len(published))
+	}
+	batch := published[0]
+	if len(batch) != 2 || batch[0].Name != "gg" || batch[0].Count != 50 || batch[1].Name != "kappa" || batch[1].Count != 50 {
+		t.Fatalf("batch = %+v, want gg=50 and kappa=50", batch)
+	}
+	if repo.updates != 2 {
+		t.Fatalf("saved %d counts, want one per changed tracker", repo.updates)
+	}
+
+	// sin cambios no se publica nada
+	svc.Flush(context.Background())
+	if got := len(events.published()); got != 1 {
+		t.Fatalf("empty flush published an event (%d total)", got)
+	}
+}
+
+func TestRunPublishesAtMostOncePerInterval(t *testing.T) {
+	svc, events := newTestTrackers(t, newMemoryTrackerRepo(), nil, TrackerMutationDTO{Name: "gg", Term: "gg"})
+	svc.Flush(context.Background())
+	events.mu.Lock()
+	events.events = nil
+	events.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 30; i++ {
+		svc.Observe(context.Background(), chat("gg"))
+	}
+	time.Sleep(flushInterval / 4)
+	if got := len(events.published()); got != 0 {
+		t.Fatalf("published %d events before the first tick", got)
+	}
+
+	// al cancelar se guarda lo pendiente en un único evento
+	cancel()
+	<-done
+	published := events.published()
+	if len(published) != 1 || len(published[0]) != 1 || published[0][0].Count != 30 {
+		t.Fatalf("published = %+v, want a single event with count 30", published)
+	}
+}
+
+func TestObserveAnnouncesMilestones(t *testing.T) {
+	out := &chatOut{}
+	every := 100
+	svc, _ := newTestTrackers(t, newMemoryTrackerRepo(), out, TrackerMutationDTO{Name: "gg", Term: "gg", MilestoneEvery: &every})
+
+	for i := 0; i < 99; i++ {
+		svc.Observe(context.Background(), chat("gg"))
+	}
+	if len(out.texts) != 0 {
+		t.Fatalf("announced before the milestone: %v", out.texts)
+	}
+	// un mensaje con varias apariciones cruza el hito una sola vez
+	svc.Observe(context.Background(), chat("gg gg gg"))
+	if len(out.texts) != 1 || out.texts[0] != "🎉 ¡gg ya va 100 veces en este stream!" {
+		t.Fatalf("announcements = %v", out.texts)
+	}
+}
+
+func TestCountsSurviveRestartWithinSession(t *testing.T) {
+	repo := newMemoryTrackerRepo()
+	svc, _ := newTestTrackers(t, repo, nil, TrackerMutationDTO{Name: "gg", Term: "gg"})
+	for i := 0; i < 7; i++ {
+		svc.Observe(context.Background(), chat("gg"))
+	}
+	svc.Flush(context.Background())
+
+	restarted, _ := newTestTrackers(t, repo, nil)
+	restarted.Observe(context.Background(), chat("gg"))
+	if got := restarted.List(); len(got) != 1 || got[0].Count != 8 {
+		t.Fatalf("after restart = %+v, want count 8", got)
+	}
+
+	// una sesión nueva empieza desde cero
+	restarted.SetSessionFunc(func(context.Context) string { return "stream-2" })
+	restarted.Observe(context.Background(), chat("gg"))
+	if got := restarted.List(); got[0].Count != 1 || got[0].SessionID != "stream-2" {
+		t.Fatalf("new session = %+v, want count 1", got)
+	}
+}
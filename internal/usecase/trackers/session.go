@@ -0,0 +1,72 @@
+package trackers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// StatusSnapshotter devuelve el estado de los streams (status.Resolver).
+type StatusSnapshotter interface {
+	Snapshot(ctx context.Context) []domain.StreamStatus
+}
+
+// StatusSession identifica la sesión de stream actual a partir de la hora de
+// inicio del directo. Consulta las APIs en segundo plano para no frenar el chat
+// y conserva la última sesión conocida mientras el stream está offline.
+type StatusSession struct {
+	status StatusSnapshotter
+	ttl    time.Duration
+
+	mu         sync.Mutex
+	current    string
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+func NewStatusSession(status StatusSnapshotter, ttl time.Duration) *StatusSession {
+	if ttl <= 0 {
+		ttl = 2 * time.Minute
+	}
+	return &StatusSession{status: status, ttl: ttl}
+}
+
+// ID devuelve "" mientras no se haya visto ningún directo.
+func (s *StatusSession) ID(ctx context.Context) string {
+	if s == nil || s.status == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.refreshing && time.Since(s.fetchedAt) >= s.ttl {
+		s.refreshing = true
+		go s.refresh(context.WithoutCancel(ctx))
+	}
+	return s.current
+}
+
+func (s *StatusSession) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	var started time.Time
+	for _, status := range s.status.Snapshot(ctx) {
+		if !status.IsLive || status.StartedAt.IsZero() {
+			continue
+		}
+		if started.IsZero() || status.StartedAt.Before(started) {
+			started = status.StartedAt
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshing = false
+	s.fetchedAt = time.Now()
+	if !started.IsZero() {
+		s.current = "live-" + strconv.FormatInt(started.UTC().Unix(), 10)
+	}
+}
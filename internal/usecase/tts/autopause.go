@@ -0,0 +1,148 @@
+package tts
+
+import (
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const autoPauseWindow = time.Minute
+
+// AutoPauseChange se emite al pausar/reanudar; platform/channel son del mensaje
+// que provocó el cambio (vacíos al reanudar por inactividad).
+type AutoPauseChange struct {
+	Paused    bool
+	Rate      int
+	Platform  domain.Platform
+	ChannelID string
+}
+
+// AutoPause mide mensajes/minuto y decide si pausar el TTS del chat con histéresis.
+type AutoPause struct {
+	mu         sync.Mutex
+	cfg        domain.TTSAutoPauseSettings
+	now        func() time.Time
+	hits       []time.Time
+	paused     bool
+	belowSince time.Time
+}
+
+func NewAutoPause(cfg domain.TTSAutoPauseSettings) *AutoPause {
+	return &AutoPause{
+		cfg: sanitizeAutoPause(cfg),
+		now: time.Now,
+	}
+}
+
+func (a *AutoPause) Settings() domain.TTSAutoPauseSettings {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.cfg
+}
+
+func (a *AutoPause) Configure(cfg domain.TTSAutoPauseSettings) *AutoPauseChange {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cfg = sanitizeAutoPause(cfg)
+	if !a.cfg.Enabled && a.paused {
+		a.paused = false
+		a.belowSince = time.Time{}
+		return &AutoPauseChange{Paused: false, Rate: a.rateLocked(a.now())}
+	}
+	return nil
+}
+
+func (a *AutoPause) Paused() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.paused
+}
+
+// Rate devuelve los mensajes del último minuto.
+func (a *AutoPause) Rate() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.rateLocked(a.now())
+}
+
+// Observe cuenta un mensaje de chat y devuelve el cambio de estado si lo hubo.
+func (a *AutoPause) Observe(platform domain.Platform, channelID string) *AutoPauseChange {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	a.hits = append(a.hits, now)
+	change := a.evaluateLocked(now)
+	if change != nil {
+		change.Platform = platform
+		change.ChannelID = channelID
+	}
+	return change
+}
+
+// Tick reevalúa el estado sin mensajes nuevos (para reanudar cuando el chat se calma).
+func (a *AutoPause) Tick() *AutoPauseChange {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.evaluateLocked(a.now())
+}
+
+func (a *AutoPause) evaluateLocked(now time.Time) *AutoPauseChange {
+	rate := a.rateLocked(now)
+	if !a.cfg.Enabled {
+		return nil
+	}
+
+	if !a.paused {
+		if rate >= a.cfg.PauseAbove {
+			a.paused = true
+			a.belowSince = time.Time{}
+			return &AutoPauseChange{Paused: true, Rate: rate}
+		}
+		return nil
+	}
+
+	if rate >= a.cfg.ResumeBelow {
+		a.belowSince = time.Time{}
+		return nil
+	}
+	if a.belowSince.IsZero() {
+		a.belowSince = now
+	}
+	if now.Sub(a.belowSince) >= time.Duration(a.cfg.ResumeAfterMinutes)*time.Minute {
+		a.paused = false
+		a.belowSince = time.Time{}
+		return &AutoPauseChange{Paused: false, Rate: rate}
+	}
+	return nil
+}
+
+func (a *AutoPause) rateLocked(now time.Time) int {
+	cutoff := now.Add(-autoPauseWindow)
+	idx := 0
+	for idx < len(a.hits) && !a.hits[idx].After(cutoff) {
+		idx++
+	}
+	if idx > 0 {
+		a.hits = append(a.hits[:0], a.hits[idx:]...)
+	}
+	return len(a.hits)
+}
+
+func sanitizeAutoPause(cfg domain.TTSAutoPauseSettings) domain.TTSAutoPauseSettings {
+	def := domain.DefaultTTSAutoPauseSettings()
+	if cfg.PauseAbove <= 0 {
+		cfg.PauseAbove = def.PauseAbove
+	}
+	if cfg.ResumeBelow <= 0 || cfg.ResumeBelow >= cfg.PauseAbove {
+		cfg.ResumeBelow = cfg.PauseAbove / 3
+		if cfg.ResumeBelow < 1 {
+			cfg.ResumeBelow = 1
+		}
+	}
+	if cfg.ResumeAfterMinutes < 0 {
+		cfg.ResumeAfterMinutes = def.ResumeAfterMinutes
+	}
+	return cfg
+}
@@ -0,0 +1,202 @@
+package tts
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func testAutoPauseSettings() domain.TTSAutoPauseSettings {
+	return domain.TTSAutoPauseSettings{
+		Enabled:            true,
+		PauseAbove:         10,
+		ResumeBelow:        3,
+		ResumeAfterMinutes: 2,
+	}
+}
+
+func newTestAutoPause(cfg domain.TTSAutoPauseSettings) (*AutoPause, *fakeClock) {
+	clock := newFakeClock()
+	a := NewAutoPause(cfg)
+	a.now = clock.Now
+	return a, clock
+}
+
+// burst manda n mensajes repartidos en el intervalo dado.
+func burst(a *AutoPause, clock *fakeClock, n int, every time.Duration) []*AutoPauseChange {
+	var changes []*AutoPauseChange
+	for i := 0; i < n; i++ {
+		if change := a.Observe(domain.PlatformTwitch, "canal"); change != nil {
+			changes = append(changes, change)
+		}
+		clock.advance(every)
+	}
+	return changes
+}
+
+func TestAutoPauseDisabledByDefault(t *testing.T) {
+	a, clock := newTestAutoPause(domain.DefaultTTSAutoPauseSettings())
+
+	if changes := burst(a, clock, 500, 10*time.Millisecond); len(changes) != 0 {
+		t.Fatalf("default settings paused TTS: %+v", changes)
+	}
+	if a.Paused() {
+		t.Fatal("default settings must not pause TTS")
+	}
+}
+
+func TestAutoPausePausesAboveThresholdOnce(t *testing.T) {
+	a, clock := newTestAutoPause(testAutoPauseSettings())
+
+	changes := burst(a, clock, 9, time.Second)
+	if len(changes) != 0 || a.Paused() {
+		t.Fatalf("paused below threshold: %+v", changes)
+	}
+
+	changes = burst(a, clock, 20, time.Second)
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want a single pause", len(changes))
+	}
+	if !changes[0].Paused || changes[0].Rate != 10 {
+		t.Fatalf("change = %+v, want paused at rate 10", changes[0])
+	}
+	if changes[0].Platform != domain.PlatformTwitch || changes[0].ChannelID != "canal" {
+		t.Fatalf("change should carry the triggering channel: %+v", changes[0])
+	}
+}
+
+func TestAutoPauseHysteresis(t *testing.T) {
+	a, clock := newTestAutoPause(testAutoPauseSettings())
+	burst(a, clock, 10, time.Second)
+	if !a.Paused() {
+		t.Fatal("expected pause after 10 msgs/min")
+	}
+
+	// el chat baja pero sigue por encima de resume_below: no reanuda nunca
+	clock.advance(time.Minute)
+	for i := 0; i < 5; i++ {
+		burst(a, clock, 4, 15*time.Second)
+		if change := a.Tick(); change != nil || !a.Paused() {
+			t.Fatalf("resumed while rate was %d (>= resume_below): %+v", a.Rate(), change)
+		}
+	}
+
+	// por debajo de resume_below empieza la cuenta de resume_after_minutes
+	clock.advance(time.Minute)
+	if change := a.Tick(); change != nil {
+		t.Fatalf("resumed immediately: %+v", change)
+	}
+	clock.advance(time.Minute)
+	if change := a.Tick(); change != nil {
+		t.Fatalf("resumed after 1 of 2 minutes: %+v", change)
+	}
+
+	// un repunte reinicia la cuenta
+	burst(a, clock, 3, time.Second)
+	clock.advance(90 * time.Second)
+	if change := a.Tick(); change != nil {
+		t.Fatalf("resumed although the quiet period restarted: %+v", change)
+	}
+
+	clock.advance(2 * time.Minute)
+	change := a.Tick()
+	if change == nil || change.Paused {
+		t.Fatalf("Tick() = %+v, want resume after 2 quiet minutes", change)
+	}
+	if a.Paused() {
+		t.Fatal("still paused after resuming")
+	}
+	if change := a.Tick(); change != nil {
+		t.Fatalf("resume announced twice: %+v", change)
+	}
+}
+
+func TestAutoPauseDisablingResumes(t *testing.T) {
+	a, clock := newTestAutoPause(testAutoPauseSettings())
+	burst(a, clock, 10, time.Second)
+
+	cfg := testAutoPauseSettings()
+	cfg.Enabled = false
+	change := a.Configure(cfg)
+	if change == nil || change.Paused || a.Paused() {
+		t.Fatalf("Configure(disabled) = %+v, want resume", change)
+	}
+}
+
+func TestSanitizeAutoPauseKeepsHysteresisGap(t *testing.T) {
+	got := sanitizeAutoPause(domain.TTSAutoPauseSettings{Enabled: true, PauseAbove: 30, ResumeBelow: 50, ResumeAfterMinutes: -1})
+	if got.ResumeBelow != 10 {
+		t.Fatalf("ResumeBelow = %d, want 10 (a third of pause_above)", got.ResumeBelow)
+	}
+	if got.ResumeAfterMinutes != domain.DefaultTTSAutoPauseSettings().ResumeAfterMinutes {
+		t.Fatalf("ResumeAfterMinutes = %d, want default", got.ResumeAfterMinutes)
+	}
+}
+
+type memoryQueue struct {
+	mu   sync.Mutex
+	reqs []Request
+}
+
+func (q *memoryQueue) Enqueue(_ context.Context, req Request) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.reqs = append(q.reqs, req)
+	return "id", nil
+}
+
+func TestServiceRejectsOnlyChatRequestsWhileAutoPaused(t *testing.T) {
+	svc := NewService(nil, "")
+	queue := &memoryQueue{}
+	svc.SetQueue(queue)
+
+	var changes []AutoPauseChange
+	svc.SetAutoPauseHandler(func(c AutoPauseChange) { changes = append(changes, c) })
+	if _, err := svc.SetAutoPauseSettings(context.Background(), testAutoPauseSettings()); err != nil {
+		t.Fatalf("SetAutoPauseSettings: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		svc.ObserveChatMessage(domain.Message{Platform: domain.PlatformKick, ChannelID: "99"})
+	}
+	if !svc.AutoPaused() || len(changes) != 1 || !changes[0].Paused {
+		t.Fatalf("AutoPaused = %v, changes = %+v", svc.AutoPaused(), changes)
+	}
+	if !svc.Snapshot(context.Background()).AutoPaused {
+		t.Fatal("status snapshot should report auto_paused")
+	}
+
+	if err := svc.RequestSpeech(context.Background(), "hola", "viewer", domain.PlatformKick, "99"); err == nil {
+		t.Fatal("chat request accepted while auto-paused")
+	}
+	if _, err := svc.Enqueue(context.Background(), Request{Text: "desde el escritorio"}); err != nil {
+		t.Fatalf("desktop request rejected while auto-paused: %v", err)
+	}
+	if len(queue.reqs) != 1 || queue.reqs[0].Text != "desde el escritorio" {
+		t.Fatalf("queue = %+v, want only the desktop request", queue.reqs)
+	}
+}
@@ -0,0 +1,180 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hegedustibor/htgo-tts/voices"
+)
+
+const (
+	googleTTSURL = "https://translate.google.com/translate_tts"
+	// chunkSize es el largo máximo (en runas) que acepta Google por pedido.
+	chunkSize = 200
+	// chunkParallelism es cuántos pedazos se descargan a la vez; más que eso
+	// y Google empieza a responder 429.
+	chunkParallelism = 3
+	// maxChunkAttempts es cuántas veces se pide cada pedazo ante 429/5xx.
+	maxChunkAttempts = 3
+	chunkBaseBackoff = 500 * time.Millisecond
+)
+
+// chunkStatusError es una respuesta de Google distinta de 200.
+type chunkStatusError struct {
+	status int
+	body   string
+}
+
+func (e *chunkStatusError) Error() string {
+	return fmt.Sprintf("tts: google tts status %d: %s", e.status, e.body)
+}
+
+// retryable indica si vale la pena volver a pedir el pedazo.
+func retryable(err error) bool {
+	var statusErr *chunkStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusTooManyRequests || statusErr.status >= http.StatusInternalServerError
+	}
+	// errores de red; los del contexto no se reintentan
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// chunkBackoff es la espera antes del reintento attempt (1, 2, ...):
+// exponencial con jitter para que los pedazos no reintenten juntos.
+func chunkBackoff(attempt int) time.Duration {
+	base := chunkBaseBackoff << (attempt - 1)
+	return base + rand.N(base)
+}
+
+// splitChunks corta text en pedazos de hasta size runas.
+func splitChunks(text string, size int) []string {
+	runes := []rune(text)
+	chunks := make([]string, 0, (len(runes)+size-1)/size)
+	for start := 0; start < len(runes); start += size {
+		end := min(start+size, len(runes))
+		chunks = append(chunks, string(runes[start:end]))
+	}
+	return chunks
+}
+
+// generateAudio descarga los pedazos de text en paralelo y los une en orden.
+// Corta apenas se cancela ctx (p. ej. al saltar la lectura), cuando un pedazo
+// falla sin remedio o cuando se pasa el tiempo máximo configurado.
+func (s *Service) generateAudio(ctx context.Context, text, voice string) ([]byte, error) {
+	voice = strings.TrimSpace(voice)
+	if voice == "" {
+		voice = voices.Spanish
+	}
+	limit := time.Duration(s.MaxFetchSeconds(ctx)) * time.Second
+	fetchCtx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+
+	chunks := splitChunks(text, chunkSize)
+	audio := make([][]byte, len(chunks))
+	jobs := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range min(chunkParallelism, len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				data, err := s.fetchChunkWithRetry(fetchCtx, chunks[i], voice)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				audio[i] = data
+			}
+		}()
+	}
+feed:
+	for i := range chunks {
+		select {
+		case jobs <- i:
+		case <-fetchCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case firstErr != nil && !errors.Is(firstErr, context.Canceled) && !errors.Is(firstErr, context.DeadlineExceeded):
+		return nil, firstErr
+	case fetchCtx.Err() != nil:
+		return nil, fmt.Errorf("tts: el audio tardó más de %s en descargarse: %w", limit, context.DeadlineExceeded)
+	}
+	return bytes.Join(audio, nil), nil
+}
+
+// fetchChunkWithRetry pide un pedazo y reintenta los 429/5xx y errores de red.
+func (s *Service) fetchChunkWithRetry(ctx context.Context, text, voice string) ([]byte, error) {
+	var err error
+	for attempt := 1; attempt <= maxChunkAttempts; attempt++ {
+		var audio []byte
+		audio, err = s.fetchChunk(ctx, text, voice)
+		if err == nil {
+			return audio, nil
+		}
+		if attempt == maxChunkAttempts || !retryable(err) {
+			break
+		}
+		timer := time.NewTimer(s.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil, err
+}
+
+func (s *Service) fetchChunk(ctx context.Context, text, voice string) ([]byte, error) {
+	params := url.Values{}
+	params.Set("ie", "UTF-8")
+	params.Set("client", "tw-ob")
+	params.Set("q", text)
+	params.Set("tl", voice)
+	params.Set("total", "1")
+	params.Set("idx", "0")
+	params.Set("textlen", fmt.Sprintf("%d", len([]rune(text))))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ttsURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := s.httpCli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &chunkStatusError{status: resp.StatusCode, body: string(body)}
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,218 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// googleStub hace de translate_tts: responde el texto pedido como "audio" y
+// cuenta los pedidos por pedazo.
+type googleStub struct {
+	mu     sync.Mutex
+	byText map[string]int
+	calls  atomic.Int32
+	// reply decide la respuesta al intento n (1, 2, ...) de un pedazo;
+	// 0 significa 200.
+	reply func(text string, attempt int) int
+	// block hace que cada pedido espere a que el cliente lo cancele.
+	block bool
+}
+
+func newFetchService(t *testing.T, stub *googleStub) *Service {
+	t.Helper()
+	stub.byText = make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.calls.Add(1)
+		text := r.URL.Query().Get("q")
+		stub.mu.Lock()
+		stub.byText[text]++
+		attempt := stub.byText[text]
+		stub.mu.Unlock()
+
+		if stub.block {
+			<-r.Context().Done()
+			return
+		}
+		if stub.reply != nil {
+			if status := stub.reply(text, attempt); status != 0 {
+				http.Error(w, "try later", status)
+				return
+			}
+		}
+		w.Write([]byte(text))
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := NewService(nil, "")
+	svc.ttsURL = srv.URL
+	svc.backoff = func(int) time.Duration { return time.Millisecond }
+	return svc
+}
+
+func TestSplitChunks(t *testing.T) {
+	text := strings.Repeat("ñ", 450)
+	chunks := splitChunks(text, chunkSize)
+	if len(chunks) != 3 || len([]rune(chunks[0])) != 200 || len([]rune(chunks[2])) != 50 {
+		t.Fatalf("pedazos = %d", len(chunks))
+	}
+	if strings.Join(chunks, "") != text {
+		t.Fatal("los pedazos no reconstruyen el texto")
+	}
+	if got := splitChunks("", chunkSize); len(got) != 0 {
+		t.Fatalf("texto vacío = %q", got)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&chunkStatusError{status: http.StatusTooManyRequests}, true},
+		{&chunkStatusError{status: http.StatusBadGateway}, true},
+		{&chunkStatusError{status: http.StatusBadRequest}, false},
+		{&chunkStatusError{status: http.StatusForbidden}, false},
+		{errors.New("connection reset"), true},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, esperaba %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestChunkBackoffGrows(t *testing.T) {
+	for attempt := 1; attempt <= maxChunkAttempts; attempt++ {
+		base := chunkBaseBackoff << (attempt - 1)
+		if got := chunkBackoff(attempt); got < base || got >= 2*base {
+			t.Errorf("intento %d: backoff = %s, esperaba [%s, %s)", attempt, got, base, 2*base)
+		}
+	}
+}
+
+func TestFetchRetries429AndKeepsOrder(t *testing.T) {
+	// cada pedazo recibe un 429 al primer intento
+	stub := &googleStub{reply: func(_ string, attempt int) int {
+		if attempt == 1 {
+			return http.StatusTooManyRequests
+		}
+		return 0
+	}}
+	svc := newFetchService(t, stub)
+
+	var b strings.Builder
+	for i := range 7 {
+		b.WriteString(strings.Repeat(string(rune('a'+i)), chunkSize))
+	}
+	text := b.String()
+
+	audio, err := svc.generateAudio(context.Background(), text, "es")
+	if err != nil {
+		t.Fatalf("generateAudio: %v", err)
+	}
+	if string(audio) != text {
+		t.Fatal("los pedazos se unieron fuera de orden")
+	}
+	if got := stub.calls.Load(); got != 14 {
+		t.Fatalf("pedidos = %d, esperaba dos por pedazo", got)
+	}
+}
+
+func TestFetchGivesUp(t *testing.T) {
+	stub := &googleStub{reply: func(string, int) int { return http.StatusServiceUnavailable }}
+	svc := newFetchService(t, stub)
+
+	_, err := svc.generateAudio(context.Background(), "hola", "es")
+	var statusErr *chunkStatusError
+	if !errors.As(err, &statusErr) || statusErr.status != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v", err)
+	}
+	if got := stub.calls.Load(); got != maxChunkAttempts {
+		t.Fatalf("pedidos = %d, esperaba %d", got, maxChunkAttempts)
+	}
+
+	// un 400 no se reintenta
+	stub = &googleStub{reply: func(string, int) int { return http.StatusBadRequest }}
+	svc = newFetchService(t, stub)
+	if _, err := svc.generateAudio(context.Background(), "hola", "es"); err == nil || stub.calls.Load() != 1 {
+		t.Fatalf("err = %v, pedidos = %d", err, stub.calls.Load())
+	}
+}
+
+func TestFetchStopsOnCancel(t *testing.T) {
+	stub := &googleStub{block: true}
+	svc := newFetchService(t, stub)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.generateAudio(ctx, strings.Repeat("x", 10*chunkSize), "es")
+		done <- err
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for stub.calls.Load() < chunkParallelism {
+		if time.Now().After(deadline) {
+			t.Fatalf("pedidos en vuelo = %d", stub.calls.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, esperaba context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("generateAudio no cortó al cancelar")
+	}
+	if got := stub.calls.Load(); got != chunkParallelism {
+		t.Fatalf("pedidos = %d; se siguieron pidiendo pedazos después de cancelar", got)
+	}
+}
+
+// maxFetchSettings agrega el tiempo máximo de descarga a memorySettings.
+type maxFetchSettings struct {
+	memorySettings
+	seconds int
+}
+
+func (m *maxFetchSettings) GetTTSMaxFetchSeconds(context.Context) (int, error) {
+	return m.seconds, nil
+}
+
+func (m *maxFetchSettings) SetTTSMaxFetchSeconds(_ context.Context, seconds int) error {
+	m.seconds = seconds
+	return nil
+}
+
+func TestFetchRespectsMaxDuration(t *testing.T) {
+	stub := &googleStub{block: true}
+	svc := newFetchService(t, stub)
+	repo := &maxFetchSettings{}
+	svc.repo = repo
+	if err := svc.SetMaxFetchSeconds(context.Background(), 1); err != nil {
+		t.Fatalf("SetMaxFetchSeconds: %v", err)
+	}
+
+	started := time.Now()
+	_, err := svc.generateAudio(context.Background(), "hola", "es")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "tardó más de 1s") {
+		t.Fatalf("err = %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("tardó %s con un tope de 1s", elapsed)
+	}
+	if err := svc.SetMaxFetchSeconds(context.Background(), 0); err == nil {
+		t.Fatal("aceptó un tope de 0 segundos")
+	}
+}
@@ -0,0 +1,222 @@
+package tts
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// historyBatchSize es cuántas lecturas se juntan antes de escribir.
+	historyBatchSize = 20
+	// historyFlushInterval es lo máximo que una lectura espera a guardarse.
+	historyFlushInterval = 2 * time.Second
+	historyPruneInterval = time.Hour
+	// historyMaxPending limita lo que se acumula si la base falla; al pasarlo
+	// se descarta lo más viejo.
+	historyMaxPending = 1000
+)
+
+// History guarda cada lectura procesada. Record no toca la base: las
+// lecturas se escriben por tandas en segundo plano para no demorar la
+// reproducción, y lo más viejo que la retención se borra solo.
+type History struct {
+	repo      domain.TTSHistoryRepository
+	retention time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	pending []domain.TTSHistoryEntry
+	// last es la última lectura que sonó completa.
+	last    *domain.TTSHistoryEntry
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+}
+
+// NewHistory crea el historial; retention <= 0 usa DefaultTTSHistoryRetention.
+func NewHistory(repo domain.TTSHistoryRepository, retention time.Duration) *History {
+	if retention <= 0 {
+		retention = domain.DefaultTTSHistoryRetention
+	}
+	return &History{
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start empieza a escribir en segundo plano y poda lo vencido.
+func (h *History) Start(ctx context.Context) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	if h.started {
+		h.mu.Unlock()
+		return
+	}
+	h.started = true
+	h.mu.Unlock()
+	go h.run(ctx)
+}
+
+// Record anota una lectura terminada. No bloquea.
+func (h *History) Record(entry domain.TTSHistoryEntry) {
+	if h == nil {
+		return
+	}
+	if entry.FinishedAt.IsZero() {
+		entry.FinishedAt = h.now()
+	}
+	h.mu.Lock()
+	h.pending = append(h.pending, entry)
+	if over := len(h.pending) - historyMaxPending; over > 0 {
+		log.Printf("tts history: se descartan %d lecturas sin guardar", over)
+		h.pending = append([]domain.TTSHistoryEntry(nil), h.pending[over:]...)
+	}
+	if entry.Outcome == domain.TTSOutcomeOK {
+		last := entry
+		h.last = &last
+	}
+	full := len(h.pending) >= historyBatchSize
+	h.mu.Unlock()
+	if full {
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// List devuelve las lecturas más recientes primero, incluidas las que
+// todavía no se habían escrito.
+func (h *History) List(ctx context.Context, filter domain.TTSHistoryFilter) ([]domain.TTSHistoryEntry, error) {
+	if h == nil || h.repo == nil {
+		return []domain.TTSHistoryEntry{}, nil
+	}
+	if err := h.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return h.repo.ListTTSHistory(ctx, filter)
+}
+
+// Last devuelve la última lectura que sonó completa.
+func (h *History) Last(ctx context.Context) (domain.TTSHistoryEntry, bool) {
+	if h == nil {
+		return domain.TTSHistoryEntry{}, false
+	}
+	h.mu.Lock()
+	last := h.last
+	h.mu.Unlock()
+	if last != nil {
+		return *last, true
+	}
+	if h.repo == nil {
+		return domain.TTSHistoryEntry{}, false
+	}
+	// recién arrancado: la última puede ser de la sesión anterior
+	entries, err := h.repo.ListTTSHistory(ctx, domain.TTSHistoryFilter{Limit: 1, Outcome: domain.TTSOutcomeOK})
+	if err != nil {
+		log.Printf("tts history: %v", err)
+		return domain.TTSHistoryEntry{}, false
+	}
+	if len(entries) == 0 {
+		return domain.TTSHistoryEntry{}, false
+	}
+	return entries[0], true
+}
+
+// Flush escribe ya lo pendiente. Si falla, lo pendiente se reintenta en la
+// próxima tanda.
+func (h *History) Flush(ctx context.Context) error {
+	if h == nil || h.repo == nil {
+		return nil
+	}
+	h.mu.Lock()
+	batch := h.pending
+	h.pending = nil
+	h.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := h.repo.SaveTTSHistory(ctx, batch); err != nil {
+		h.mu.Lock()
+		h.pending = append(batch, h.pending...)
+		if over := len(h.pending) - historyMaxPending; over > 0 {
+			h.pending = append([]domain.TTSHistoryEntry(nil), h.pending[over:]...)
+		}
+		h.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Prune borra lo terminado antes de la retención.
+func (h *History) Prune(ctx context.Context) (int64, error) {
+	if h == nil || h.repo == nil {
+		return 0, nil
+	}
+	return h.repo.PruneTTSHistory(ctx, h.now().Add(-h.retention))
+}
+
+// Close deja de escribir en segundo plano y guarda lo pendiente.
+func (h *History) Close(ctx context.Context) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	started := h.started
+	h.started = false
+	h.mu.Unlock()
+	if started {
+		close(h.stop)
+		<-h.done
+	}
+	return h.Flush(ctx)
+}
+
+func (h *History) run(ctx context.Context) {
+	defer close(h.done)
+	h.prune(ctx)
+
+	flush := time.NewTicker(historyFlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(historyPruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.stop:
+			return
+		case <-flush.C:
+		case <-h.wake:
+		case <-prune.C:
+			h.prune(ctx)
+			continue
+		}
+		if err := h.Flush(ctx); err != nil {
+			log.Printf("tts history: no pude guardar lecturas: %v", err)
+		}
+	}
+}
+
+func (h *History) prune(ctx context.Context) {
+	n, err := h.Prune(ctx)
+	if err != nil {
+		log.Printf("tts history: no pude borrar lecturas viejas: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("tts history: %d lecturas viejas borradas", n)
+	}
+}
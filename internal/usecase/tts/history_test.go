@@ -0,0 +1,156 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryHistory hace de la tabla tts_history.
+type memoryHistory struct {
+	entries []domain.TTSHistoryEntry
+	saves   int
+	failing bool
+}
+
+func (m *memoryHistory) SaveTTSHistory(_ context.Context, entries []domain.TTSHistoryEntry) error {
+	if m.failing {
+		return errors.New("base bloqueada")
+	}
+	m.saves++
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *memoryHistory) ListTTSHistory(_ context.Context, filter domain.TTSHistoryFilter) ([]domain.TTSHistoryEntry, error) {
+	out := []domain.TTSHistoryEntry{}
+	for _, entry := range m.entries {
+		if filter.User != "" && !strings.EqualFold(entry.RequestedBy, filter.User) {
+			continue
+		}
+		if filter.Outcome != "" && entry.Outcome != filter.Outcome {
+			continue
+		}
+		out = append(out, entry)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].FinishedAt.After(out[j].FinishedAt) })
+	if filter.Limit > 0 && len(out) > filter.Limit {
+		out = out[:filter.Limit]
+	}
+	return out, nil
+}
+
+func (m *memoryHistory) PruneTTSHistory(_ context.Context, before time.Time) (int64, error) {
+	kept := m.entries[:0]
+	var pruned int64
+	for _, entry := range m.entries {
+		if entry.FinishedAt.Before(before) {
+			pruned++
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	m.entries = kept
+	return pruned, nil
+}
+
+var historyStart = time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+
+func newTestHistory(repo *memoryHistory, retention time.Duration) *History {
+	h := NewHistory(repo, retention)
+	h.now = func() time.Time { return historyStart }
+	return h
+}
+
+func TestHistoryRecordDoesNotWriteUntilFlush(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryHistory{}
+	h := newTestHistory(repo, 0)
+
+	h.Record(domain.TTSHistoryEntry{ID: "r1", RequestedBy: "ana", Outcome: domain.TTSOutcomeOK})
+	h.Record(domain.TTSHistoryEntry{ID: "r2", RequestedBy: "beto", Outcome: domain.TTSOutcomeSkipped})
+	if repo.saves != 0 {
+		t.Fatal("Record escribió en la base")
+	}
+
+	// List incluye lo que todavía no se había escrito, en una sola tanda
+	entries, err := h.List(ctx, domain.TTSHistoryFilter{User: "ANA"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if repo.saves != 1 || len(repo.entries) != 2 {
+		t.Fatalf("saves = %d, entries = %+v", repo.saves, repo.entries)
+	}
+	if len(entries) != 1 || entries[0].ID != "r1" || !entries[0].FinishedAt.Equal(historyStart) {
+		t.Fatalf("List = %+v", entries)
+	}
+}
+
+func TestHistoryFlushRetriesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryHistory{failing: true}
+	h := newTestHistory(repo, 0)
+
+	h.Record(domain.TTSHistoryEntry{ID: "r1", Outcome: domain.TTSOutcomeOK})
+	if err := h.Flush(ctx); err == nil {
+		t.Fatal("Flush no devolvió el error de la base")
+	}
+	h.Record(domain.TTSHistoryEntry{ID: "r2", Outcome: domain.TTSOutcomeOK})
+
+	repo.failing = false
+	if err := h.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(repo.entries) != 2 || repo.entries[0].ID != "r1" || repo.entries[1].ID != "r2" {
+		t.Fatalf("entries = %+v, esperaba r1 y r2 en orden", repo.entries)
+	}
+}
+
+func TestHistoryPruneUsesRetention(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryHistory{entries: []domain.TTSHistoryEntry{
+		{ID: "vieja", FinishedAt: historyStart.Add(-8 * 24 * time.Hour)},
+		{ID: "borde", FinishedAt: historyStart.Add(-domain.DefaultTTSHistoryRetention)},
+		{ID: "ayer", FinishedAt: historyStart.Add(-24 * time.Hour)},
+	}}
+	h := newTestHistory(repo, 0)
+
+	n, err := h.Prune(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Prune = %d, %v; esperaba 1", n, err)
+	}
+	if len(repo.entries) != 2 || repo.entries[0].ID != "borde" {
+		t.Fatalf("entries = %+v", repo.entries)
+	}
+
+	// una retención más corta se lleva también lo de hace una semana
+	short := newTestHistory(repo, 2*24*time.Hour)
+	if n, _ := short.Prune(ctx); n != 1 || len(repo.entries) != 1 || repo.entries[0].ID != "ayer" {
+		t.Fatalf("Prune(2 días) = %d, entries = %+v", n, repo.entries)
+	}
+}
+
+func TestHistoryLastSkipsUnplayedItems(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryHistory{entries: []domain.TTSHistoryEntry{
+		{ID: "anterior", RequestedBy: "carla", Outcome: domain.TTSOutcomeOK, FinishedAt: historyStart.Add(-time.Hour)},
+	}}
+	h := newTestHistory(repo, 0)
+
+	// recién arrancado sale de la base
+	if last, ok := h.Last(ctx); !ok || last.RequestedBy != "carla" {
+		t.Fatalf("Last = %+v, %v", last, ok)
+	}
+
+	h.Record(domain.TTSHistoryEntry{ID: "r1", RequestedBy: "ana", Outcome: domain.TTSOutcomeOK})
+	h.Record(domain.TTSHistoryEntry{ID: "r2", RequestedBy: "beto", Outcome: domain.TTSOutcomeSkipped})
+	h.Record(domain.TTSHistoryEntry{ID: "r3", RequestedBy: "dani", Outcome: domain.TTSOutcomeFailed})
+	if last, ok := h.Last(ctx); !ok || last.ID != "r1" {
+		t.Fatalf("Last = %+v, %v; esperaba la última que sonó completa", last, ok)
+	}
+}
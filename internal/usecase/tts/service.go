@@ -1,12 +1,10 @@
 package tts
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
@@ -15,9 +13,16 @@ import (
 	"zhatBot/internal/domain"
 )
 
+// ErrUnsupportedVoice indica que el código de voz no está en la lista.
+var ErrUnsupportedVoice = errors.New("voz no soportada")
+
 type VoiceOption struct {
-	Code  string
-	Label string
+	Code   string
+	Label  string
+	Locale string
+	// Gender queda vacío cuando el motor no lo informa.
+	Gender string
+	Engine string
 }
 
 type Request struct {
@@ -37,33 +42,45 @@ type Queue interface {
 }
 
 type StatusSnapshot struct {
-	Enabled bool
-	Voice   VoiceOption
-	Voices  []VoiceOption
+	Enabled    bool
+	Voice      VoiceOption
+	Voices     []VoiceOption
+	AutoPaused bool
+	AutoPause  domain.TTSAutoPauseSettings
+	SkipVotes  int
+	// MaxFetchSeconds es el tiempo máximo para descargar el audio de una lectura.
+	MaxFetchSeconds int
 }
 
 type Service struct {
-	repo    domain.TTSSettingsRepository
-	queue   Queue
-	voices  []VoiceOption
-	httpCli *http.Client
+	repo      domain.TTSSettingsRepository
+	queue     Queue
+	voices    []VoiceOption
+	httpCli   *http.Client
+	ttsURL    string
+	backoff   func(attempt int) time.Duration
+	autoPause *AutoPause
+	onPause   func(AutoPauseChange)
 }
 
 func NewService(repo domain.TTSSettingsRepository, _ string) *Service {
 	return &Service{
 		repo: repo,
 		voices: []VoiceOption{
-			{Code: voices.Spanish, Label: "Español"},
-			{Code: "es-es", Label: "Español España"},
-			{Code: voices.English, Label: "Inglés US"},
-			{Code: voices.EnglishUK, Label: "Inglés UK"},
-			{Code: voices.Portuguese, Label: "Portugués"},
-			{Code: voices.French, Label: "Francés"},
-			{Code: voices.German, Label: "Alemán"},
+			{Code: voices.Spanish, Label: "Español", Locale: "es-419", Engine: EngineGoogleTranslate},
+			{Code: "es-es", Label: "Español España", Locale: "es-ES", Engine: EngineGoogleTranslate},
+			{Code: voices.English, Label: "Inglés US", Locale: "en-US", Engine: EngineGoogleTranslate},
+			{Code: voices.EnglishUK, Label: "Inglés UK", Locale: "en-GB", Engine: EngineGoogleTranslate},
+			{Code: voices.Portuguese, Label: "Portugués", Locale: "pt-BR", Engine: EngineGoogleTranslate},
+			{Code: voices.French, Label: "Francés", Locale: "fr-FR", Engine: EngineGoogleTranslate},
+			{Code: voices.German, Label: "Alemán", Locale: "de-DE", Engine: EngineGoogleTranslate},
 		},
 		httpCli: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		ttsURL:    googleTTSURL,
+		backoff:   chunkBackoff,
+		autoPause: NewAutoPause(domain.DefaultTTSAutoPauseSettings()),
 	}
 }
 
@@ -74,7 +91,7 @@ func (s *Service) ListVoices() []VoiceOption {
 func (s *Service) SetVoice(ctx context.Context, code string) (VoiceOption, error) {
 	option, ok := s.findVoice(code)
 	if !ok {
-		return VoiceOption{}, fmt.Errorf("voz no soportada")
+		return VoiceOption{}, ErrUnsupportedVoice
 	}
 	if s.repo != nil {
 		if err := s.repo.SetTTSVoice(ctx, option.Code); err != nil {
@@ -96,7 +113,11 @@ func (s *Service) CurrentVoice(ctx context.Context) VoiceOption {
 	return option
 }
 
+// RequestSpeech es la ruta del chat; se rechaza mientras el TTS está en pausa automática.
 func (s *Service) RequestSpeech(ctx context.Context, text, requestedBy string, platform domain.Platform, channelID string) error {
+	if s.AutoPaused() {
+		return fmt.Errorf("el TTS está en pausa por mucha actividad en el chat")
+	}
 	req := Request{
 		Text:        text,
 		RequestedBy: requestedBy,
@@ -125,60 +146,9 @@ func (s *Service) findVoice(code string) (VoiceOption, bool) {
 	return VoiceOption{}, false
 }
 
-func (s *Service) generateAudio(text, voice string) ([]byte, error) {
-	voice = strings.TrimSpace(voice)
-	if voice == "" {
-		voice = voices.Spanish
-	}
-
-	chunkSize := 200
-	runes := []rune(text)
-	buf := bytes.NewBuffer(nil)
-
-	for start := 0; start < len(runes); start += chunkSize {
-		end := start + chunkSize
-		if end > len(runes) {
-			end = len(runes)
-		}
-		chunk := string(runes[start:end])
-		audio, err := s.fetchChunk(chunk, voice)
-		if err != nil {
-			return nil, err
-		}
-		buf.Write(audio)
-	}
-
-	return buf.Bytes(), nil
-}
-
-func (s *Service) fetchChunk(text, voice string) ([]byte, error) {
-	params := url.Values{}
-	params.Set("ie", "UTF-8")
-	params.Set("client", "tw-ob")
-	params.Set("q", text)
-	params.Set("tl", voice)
-	params.Set("total", "1")
-	params.Set("idx", "0")
-	params.Set("textlen", fmt.Sprintf("%d", len([]rune(text))))
-
-	req, err := http.NewRequest(http.MethodGet, "https://translate.google.com/translate_tts?"+params.Encode(), nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	resp, err := s.httpCli.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		return nil, fmt.Errorf("tts: google tts status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return io.ReadAll(resp.Body)
+// Backend identifica el motor de síntesis (para logs y soporte).
+func (s *Service) Backend() string {
+	return EngineGoogleTranslate
 }
 
 func normalizeVoice(code string) string {
@@ -228,7 +198,7 @@ func (s *Service) Enqueue(ctx context.Context, req Request) (string, error) {
 		if option, ok := s.findVoice(req.VoiceCode); ok {
 			voice = option
 		} else {
-			return "", fmt.Errorf("voz no soportada")
+			return "", ErrUnsupportedVoice
 		}
 	}
 
@@ -252,10 +222,10 @@ func (s *Service) GenerateAudio(ctx context.Context, text, voiceCode string) ([]
 		if option, ok := s.findVoice(voiceCode); ok {
 			voice = option
 		} else {
-			return nil, VoiceOption{}, fmt.Errorf("voz no soportada")
+			return nil, VoiceOption{}, ErrUnsupportedVoice
 		}
 	}
-	audio, err := s.generateAudio(text, voice.Code)
+	audio, err := s.generateAudio(ctx, text, voice.Code)
 	if err != nil {
 		return nil, VoiceOption{}, err
 	}
@@ -264,8 +234,129 @@ func (s *Service) GenerateAudio(ctx context.Context, text, voiceCode string) ([]
 
 func (s *Service) Snapshot(ctx context.Context) StatusSnapshot {
 	return StatusSnapshot{
-		Enabled: s.Enabled(ctx),
-		Voice:   s.CurrentVoice(ctx),
-		Voices:  s.ListVoices(),
+		Enabled:         s.Enabled(ctx),
+		Voice:           s.CurrentVoice(ctx),
+		Voices:          s.ListVoices(),
+		AutoPaused:      s.AutoPaused(),
+		AutoPause:       s.AutoPauseSettings(),
+		SkipVotes:       s.SkipVotesRequired(ctx),
+		MaxFetchSeconds: s.MaxFetchSeconds(ctx),
+	}
+}
+
+// ----- Skip votes -----
+
+// SkipVotesRequired devuelve cuántos usuarios distintos deben votar !ttsskip.
+func (s *Service) SkipVotesRequired(ctx context.Context) int {
+	if repo, ok := s.repo.(domain.TTSSkipVotesRepository); ok {
+		if votes, err := repo.GetTTSSkipVotes(ctx); err == nil && votes > 0 {
+			return votes
+		}
+	}
+	return domain.DefaultTTSSkipVotes
+}
+
+func (s *Service) SetSkipVotesRequired(ctx context.Context, votes int) error {
+	if votes < 1 {
+		return fmt.Errorf("se necesita al menos 1 voto para saltar")
+	}
+	repo, ok := s.repo.(domain.TTSSkipVotesRepository)
+	if !ok {
+		return nil
+	}
+	if err := repo.SetTTSSkipVotes(ctx, votes); err != nil {
+		return fmt.Errorf("no pude guardar los votos para saltar: %w", err)
+	}
+	return nil
+}
+
+// ----- Max fetch -----
+
+// MaxFetchSeconds devuelve el tiempo máximo para descargar el audio de una lectura.
+func (s *Service) MaxFetchSeconds(ctx context.Context) int {
+	if repo, ok := s.repo.(domain.TTSMaxFetchRepository); ok {
+		if seconds, err := repo.GetTTSMaxFetchSeconds(ctx); err == nil && seconds > 0 {
+			return seconds
+		}
+	}
+	return domain.DefaultTTSMaxFetchSeconds
+}
+
+func (s *Service) SetMaxFetchSeconds(ctx context.Context, seconds int) error {
+	if seconds < 1 || seconds > domain.MaxTTSMaxFetchSeconds {
+		return fmt.Errorf("el tiempo máximo de descarga debe estar entre 1 y %d segundos", domain.MaxTTSMaxFetchSeconds)
+	}
+	repo, ok := s.repo.(domain.TTSMaxFetchRepository)
+	if !ok {
+		return nil
+	}
+	if err := repo.SetTTSMaxFetchSeconds(ctx, seconds); err != nil {
+		return fmt.Errorf("no pude guardar el tiempo máximo de descarga: %w", err)
+	}
+	return nil
+}
+
+// ----- Auto pause -----
+
+// LoadAutoPause carga los umbrales guardados, si el repositorio los soporta.
+func (s *Service) LoadAutoPause(ctx context.Context) error {
+	repo, ok := s.repo.(domain.TTSAutoPauseRepository)
+	if !ok {
+		return nil
+	}
+	stored, err := repo.GetTTSAutoPause(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		s.autoPause.Configure(*stored)
+	}
+	return nil
+}
+
+// Reload vuelve a leer la configuración guardada (voz y estado se leen en cada uso).
+func (s *Service) Reload(ctx context.Context) error {
+	return s.LoadAutoPause(ctx)
+}
+
+func (s *Service) AutoPauseSettings() domain.TTSAutoPauseSettings {
+	return s.autoPause.Settings()
+}
+
+func (s *Service) SetAutoPauseSettings(ctx context.Context, settings domain.TTSAutoPauseSettings) (domain.TTSAutoPauseSettings, error) {
+	change := s.autoPause.Configure(settings)
+	current := s.autoPause.Settings()
+	if repo, ok := s.repo.(domain.TTSAutoPauseRepository); ok {
+		if err := repo.SetTTSAutoPause(ctx, current); err != nil {
+			return current, fmt.Errorf("no pude guardar la pausa automática: %w", err)
+		}
+	}
+	s.notifyAutoPause(change)
+	return current, nil
+}
+
+// SetAutoPauseHandler registra el callback que se llama al pausar/reanudar.
+func (s *Service) SetAutoPauseHandler(fn func(AutoPauseChange)) {
+	s.onPause = fn
+}
+
+func (s *Service) AutoPaused() bool {
+	return s.autoPause.Paused()
+}
+
+// ObserveChatMessage cuenta un mensaje del chat para la pausa automática.
+func (s *Service) ObserveChatMessage(msg domain.Message) {
+	s.notifyAutoPause(s.autoPause.Observe(msg.Platform, msg.ChannelID))
+}
+
+// TickAutoPause reevalúa la pausa automática aunque no lleguen mensajes.
+func (s *Service) TickAutoPause() {
+	s.notifyAutoPause(s.autoPause.Tick())
+}
+
+func (s *Service) notifyAutoPause(change *AutoPauseChange) {
+	if change == nil || s.onPause == nil {
+		return
 	}
+	s.onPause(*change)
 }
@@ -0,0 +1,52 @@
+package tts
+
+import (
+	"strings"
+	"sync"
+)
+
+// SkipVotes cuenta los votos !ttsskip de la lectura actual. Los votos se
+// agrupan por ID de la petición y se reinician cuando cambia.
+type SkipVotes struct {
+	mu        sync.Mutex
+	requestID string
+	voters    map[string]struct{}
+}
+
+func NewSkipVotes() *SkipVotes {
+	return &SkipVotes{voters: make(map[string]struct{})}
+}
+
+// Vote registra el voto de un usuario para requestID y devuelve el total de
+// votos distintos. Votar dos veces no suma.
+func (v *SkipVotes) Vote(requestID, voter string) int {
+	voter = strings.ToLower(strings.TrimSpace(voter))
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if requestID != v.requestID {
+		v.requestID = requestID
+		v.voters = make(map[string]struct{})
+	}
+	if voter != "" {
+		v.voters[voter] = struct{}{}
+	}
+	return len(v.voters)
+}
+
+// Count devuelve los votos de requestID (0 si los votos son de otra lectura).
+func (v *SkipVotes) Count(requestID string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if requestID == "" || requestID != v.requestID {
+		return 0
+	}
+	return len(v.voters)
+}
+
+// Reset descarta los votos acumulados.
+func (v *SkipVotes) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.requestID = ""
+	v.voters = make(map[string]struct{})
+}
@@ -0,0 +1,69 @@
+package tts
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSkipVotesCountsUniqueVoters(t *testing.T) {
+	votes := NewSkipVotes()
+
+	if got := votes.Vote("req-1", "Ana"); got != 1 {
+		t.Fatalf("first vote = %d, want 1", got)
+	}
+	if got := votes.Vote("req-1", " ana "); got != 1 {
+		t.Fatalf("repeated vote = %d, want 1", got)
+	}
+	if got := votes.Vote("req-1", ""); got != 1 {
+		t.Fatalf("empty voter counted: %d", got)
+	}
+	if got := votes.Vote("req-1", "luis"); got != 2 {
+		t.Fatalf("second voter = %d, want 2", got)
+	}
+	if got := votes.Count("req-1"); got != 2 {
+		t.Fatalf("Count = %d, want 2", got)
+	}
+}
+
+func TestSkipVotesResetOnItemChange(t *testing.T) {
+	votes := NewSkipVotes()
+	votes.Vote("req-1", "ana")
+	votes.Vote("req-1", "luis")
+
+	if got := votes.Vote("req-2", "ana"); got != 1 {
+		t.Fatalf("vote on new item = %d, want 1", got)
+	}
+	if got := votes.Count("req-1"); got != 0 {
+		t.Fatalf("old item still has %d votes", got)
+	}
+
+	votes.Reset()
+	if got := votes.Count("req-2"); got != 0 {
+		t.Fatalf("Count after Reset = %d, want 0", got)
+	}
+	if got := votes.Count(""); got != 0 {
+		t.Fatalf("Count for empty id = %d, want 0", got)
+	}
+}
+
+func TestSkipVotesConcurrentVoters(t *testing.T) {
+	votes := NewSkipVotes()
+
+	const voters = 50
+	var wg sync.WaitGroup
+	for i := 0; i < voters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// cada usuario vota dos veces
+			votes.Vote("req-1", fmt.Sprintf("user%d", i))
+			votes.Vote("req-1", fmt.Sprintf("USER%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	if got := votes.Count("req-1"); got != voters {
+		t.Fatalf("Count = %d, want %d", got, voters)
+	}
+}
@@ -0,0 +1,76 @@
+package tts
+
+import (
+	"context"
+	"strings"
+)
+
+// EngineGoogleTranslate es el motor actual (la voz del traductor de Google).
+const EngineGoogleTranslate = "google-translate"
+
+// VoiceGroup agrupa las voces de un mismo idioma y motor para el panel de ajustes.
+type VoiceGroup struct {
+	Language string
+	Engine   string
+	Voices   []VoiceOption
+}
+
+var previewPhrases = map[string]string{
+	"es": "Hola, así suena esta voz en el stream.",
+	"en": "Hi, this is how this voice sounds on stream.",
+	"pt": "Olá, é assim que esta voz soa na live.",
+	"fr": "Bonjour, voici comment cette voix sonne en direct.",
+	"de": "Hallo, so klingt diese Stimme im Stream.",
+}
+
+// GroupVoices agrupa por idioma y motor respetando el orden de la lista.
+func GroupVoices(options []VoiceOption) []VoiceGroup {
+	var groups []VoiceGroup
+	index := make(map[string]int)
+	for _, option := range options {
+		language := voiceLanguage(option)
+		key := option.Engine + "|" + language
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, VoiceGroup{Language: language, Engine: option.Engine})
+		}
+		groups[i].Voices = append(groups[i].Voices, option)
+	}
+	return groups
+}
+
+// PreviewText devuelve la frase de muestra en el idioma de la voz.
+func PreviewText(option VoiceOption) string {
+	if phrase, ok := previewPhrases[voiceLanguage(option)]; ok {
+		return phrase
+	}
+	return previewPhrases["es"]
+}
+
+// PreviewVoice encola una frase de muestra con la voz indicada sin cambiar la
+// voz guardada.
+func (s *Service) PreviewVoice(ctx context.Context, code string) (string, error) {
+	option, ok := s.findVoice(code)
+	if !ok || strings.TrimSpace(code) == "" {
+		return "", ErrUnsupportedVoice
+	}
+	return s.Enqueue(ctx, Request{
+		Text:        PreviewText(option),
+		VoiceCode:   option.Code,
+		RequestedBy: "preview",
+		Platform:    "desktop",
+		ChannelID:   "desktop",
+		Metadata:    map[string]string{"preview": "true"},
+	})
+}
+
+func voiceLanguage(option VoiceOption) string {
+	tag := option.Locale
+	if tag == "" {
+		tag = option.Code
+	}
+	language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	return language
+}
@@ -0,0 +1,125 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memorySettings guarda la voz y el estado del TTS en memoria.
+type memorySettings struct {
+	voice    string
+	enabled  bool
+	setVoice int
+}
+
+func (m *memorySettings) SetTTSVoice(_ context.Context, voice string) error {
+	m.setVoice++
+	m.voice = voice
+	return nil
+}
+
+func (m *memorySettings) GetTTSVoice(context.Context) (string, error) { return m.voice, nil }
+
+func (m *memorySettings) SetTTSEnabled(_ context.Context, enabled bool) error {
+	m.enabled = enabled
+	return nil
+}
+
+func (m *memorySettings) GetTTSEnabled(context.Context) (bool, error) { return m.enabled, nil }
+
+func TestGroupVoicesByLanguageAndEngine(t *testing.T) {
+	groups := GroupVoices(NewService(nil, "").ListVoices())
+
+	var got []string
+	for _, g := range groups {
+		var codes []string
+		for _, v := range g.Voices {
+			codes = append(codes, v.Code)
+		}
+		got = append(got, g.Engine+"/"+g.Language+":"+strings.Join(codes, ","))
+	}
+	want := []string{
+		"google-translate/es:es,es-es",
+		"google-translate/en:en,en-UK",
+		"google-translate/pt:pt",
+		"google-translate/fr:fr",
+		"google-translate/de:de",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("groups = %v, want %v", got, want)
+	}
+}
+
+func TestGroupVoicesSplitsEngines(t *testing.T) {
+	groups := GroupVoices([]VoiceOption{
+		{Code: "es", Locale: "es-419", Engine: EngineGoogleTranslate},
+		{Code: "es-neural", Locale: "es-MX", Engine: "neural", Gender: "female"},
+		{Code: "en-neural", Engine: "neural"},
+		{Code: "es-es", Locale: "es-ES", Engine: EngineGoogleTranslate},
+	})
+	if len(groups) != 3 {
+		t.Fatalf("groups = %+v, want 3", groups)
+	}
+	if groups[0].Language != "es" || len(groups[0].Voices) != 2 || groups[0].Voices[1].Code != "es-es" {
+		t.Fatalf("first group = %+v", groups[0])
+	}
+	// sin Locale el idioma sale del código
+	if groups[2].Language != "en" || groups[2].Engine != "neural" {
+		t.Fatalf("third group = %+v", groups[2])
+	}
+	if GroupVoices(nil) != nil {
+		t.Fatal("no voices should give no groups")
+	}
+}
+
+func TestPreviewText(t *testing.T) {
+	if got := PreviewText(VoiceOption{Code: "en-UK", Locale: "en-GB"}); got != previewPhrases["en"] {
+		t.Fatalf("en preview = %q", got)
+	}
+	if got := PreviewText(VoiceOption{Code: "ja"}); got != previewPhrases["es"] {
+		t.Fatalf("unknown language preview = %q, want the Spanish phrase", got)
+	}
+}
+
+func TestPreviewVoiceDoesNotPersist(t *testing.T) {
+	ctx := context.Background()
+	settings := &memorySettings{voice: "es", enabled: true}
+	svc := NewService(settings, "")
+	queue := &memoryQueue{}
+	svc.SetQueue(queue)
+
+	if _, err := svc.PreviewVoice(ctx, "fr"); err != nil {
+		t.Fatalf("PreviewVoice: %v", err)
+	}
+	if settings.setVoice != 0 || settings.voice != "es" {
+		t.Fatalf("preview saved the voice: %+v", settings)
+	}
+	if got := svc.CurrentVoice(ctx).Code; got != "es" {
+		t.Fatalf("current voice = %q, want es", got)
+	}
+
+	if len(queue.reqs) != 1 {
+		t.Fatalf("queued %d requests, want 1", len(queue.reqs))
+	}
+	req := queue.reqs[0]
+	if req.VoiceCode != "fr" || req.Text != previewPhrases["fr"] || req.Metadata["preview"] != "true" {
+		t.Fatalf("preview request = %+v", req)
+	}
+}
+
+func TestPreviewVoiceRejectsUnknownVoice(t *testing.T) {
+	svc := NewService(&memorySettings{enabled: true}, "")
+	queue := &memoryQueue{}
+	svc.SetQueue(queue)
+
+	for _, code := range []string{"", "  ", "klingon"} {
+		if _, err := svc.PreviewVoice(context.Background(), code); !errors.Is(err, ErrUnsupportedVoice) {
+			t.Fatalf("PreviewVoice(%q) err = %v, want ErrUnsupportedVoice", code, err)
+		}
+	}
+	if len(queue.reqs) != 0 {
+		t.Fatalf("unknown voice queued %+v", queue.reqs)
+	}
+}
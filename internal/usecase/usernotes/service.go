@@ -0,0 +1,230 @@
+// Package usernotes guarda las notas de los mods sobre usuarios del chat.
+// Mantiene en memoria qué usuarios tienen notas para marcar sus mensajes sin
+// ir a la base en cada uno.
+package usernotes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"zhatBot/internal/domain"
+)
+
+const (
+	// MaxNoteLength es el largo máximo de una nota.
+	MaxNoteLength = 500
+	// maxKnownLogins limita el mapa de logins vistos en el chat; al llenarse
+	// se vacía (los usuarios con notas se conservan aparte).
+	maxKnownLogins = 5000
+)
+
+var (
+	ErrEmptyNote    = errors.New("la nota está vacía")
+	ErrNoteTooLong  = errors.New("la nota es demasiado larga")
+	ErrUnknownUser  = errors.New("usuario desconocido")
+	ErrNoteNotFound = errors.New("nota no encontrada")
+)
+
+type userKey struct {
+	platform domain.Platform
+	userID   string
+}
+
+type loginKey struct {
+	platform domain.Platform
+	login    string
+}
+
+type Service struct {
+	repo domain.UserNoteRepository
+	now  func() time.Time
+
+	mu          sync.RWMutex
+	noted       map[userKey]int
+	notedLogins map[loginKey]string
+	seenLogins  map[loginKey]string
+	onChange    func(platform domain.Platform, userID string, notes int)
+}
+
+func NewService(repo domain.UserNoteRepository) *Service {
+	return &Service{
+		repo:        repo,
+		now:         time.Now,
+		noted:       make(map[userKey]int),
+		notedLogins: make(map[loginKey]string),
+		seenLogins:  make(map[loginKey]string),
+	}
+}
+
+// Load arma el conjunto de usuarios con notas.
+func (s *Service) Load(ctx context.Context) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	users, err := s.repo.ListNotedUsers(ctx)
+	if err != nil {
+		return err
+	}
+	noted := make(map[userKey]int, len(users))
+	logins := make(map[loginKey]string, len(users))
+	for _, user := range users {
+		noted[userKey{platform: user.Platform, userID: user.UserID}] = user.Notes
+		if login := normalizeLogin(user.Login); login != "" {
+			logins[loginKey{platform: user.Platform, login: login}] = user.UserID
+		}
+	}
+	s.mu.Lock()
+	s.noted = noted
+	s.notedLogins = logins
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Service) Reload(ctx context.Context) error {
+	return s.Load(ctx)
+}
+
+// SetChangeHandler se llama al agregar o borrar una nota con la cantidad de
+// notas que le quedan al usuario.
+func (s *Service) SetChangeHandler(fn func(platform domain.Platform, userID string, notes int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// HasNotes indica si el usuario tiene notas. No consulta la base.
+func (s *Service) HasNotes(platform domain.Platform, userID string) bool {
+	if s == nil || userID == "" {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.noted[userKey{platform: platform, userID: userID}] > 0
+}
+
+// Observe recuerda el login de quien escribe para poder usar !note <usuario>.
+func (s *Service) Observe(msg domain.Message) {
+	if s == nil || msg.UserID == "" {
+		return
+	}
+	login := normalizeLogin(msg.LoginName())
+	if login == "" {
+		return
+	}
+	k := loginKey{platform: msg.Platform, login: login}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seenLogins[k] == msg.UserID {
+		return
+	}
+	if len(s.seenLogins) >= maxKnownLogins {
+		s.seenLogins = make(map[loginKey]string)
+	}
+	s.seenLogins[k] = msg.UserID
+}
+
+// ResolveUser busca el ID de un usuario por su login entre los que
+// escribieron en el chat y los que ya tienen notas.
+func (s *Service) ResolveUser(platform domain.Platform, name string) (string, bool) {
+	k := loginKey{platform: platform, login: normalizeLogin(name)}
+	if k.login == "" {
+		return "", false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if userID, ok := s.seenLogins[k]; ok {
+		return userID, true
+	}
+	userID, ok := s.notedLogins[k]
+	return userID, ok
+}
+
+func (s *Service) List(ctx context.Context, platform domain.Platform, userID string) ([]*domain.UserNote, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, ErrUnknownUser
+	}
+	return s.repo.ListUserNotes(ctx, platform, userID)
+}
+
+// Add guarda la nota y marca al usuario.
+func (s *Service) Add(ctx context.Context, note domain.UserNote) (*domain.UserNote, error) {
+	note.UserID = strings.TrimSpace(note.UserID)
+	note.Login = normalizeLogin(note.Login)
+	note.Note = strings.TrimSpace(note.Note)
+	note.Author = strings.TrimSpace(note.Author)
+	if note.UserID == "" {
+		return nil, ErrUnknownUser
+	}
+	if note.Note == "" {
+		return nil, ErrEmptyNote
+	}
+	if utf8.RuneCountInString(note.Note) > MaxNoteLength {
+		return nil, ErrNoteTooLong
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = s.now().UTC()
+	}
+
+	saved, err := s.repo.AddUserNote(ctx, &note)
+	if err != nil {
+		return nil, err
+	}
+
+	k := userKey{platform: saved.Platform, userID: saved.UserID}
+	s.mu.Lock()
+	s.noted[k]++
+	count := s.noted[k]
+	if saved.Login != "" {
+		s.notedLogins[loginKey{platform: saved.Platform, login: saved.Login}] = saved.UserID
+	}
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(saved.Platform, saved.UserID, count)
+	}
+	return saved, nil
+}
+
+// Delete borra una nota del usuario y lo desmarca si era la última.
+func (s *Service) Delete(ctx context.Context, platform domain.Platform, userID string, id int64) error {
+	userID = strings.TrimSpace(userID)
+	deleted, err := s.repo.DeleteUserNote(ctx, platform, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNoteNotFound
+	}
+
+	k := userKey{platform: platform, userID: userID}
+	s.mu.Lock()
+	count := s.noted[k] - 1
+	if count <= 0 {
+		count = 0
+		delete(s.noted, k)
+		for login, id := range s.notedLogins {
+			if login.platform == platform && id == userID {
+				delete(s.notedLogins, login)
+			}
+		}
+	} else {
+		s.noted[k] = count
+	}
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	if onChange != nil {
+		onChange(platform, userID, count)
+	}
+	return nil
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+}
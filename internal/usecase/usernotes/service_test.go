@@ -0,0 +1,219 @@
+package usernotes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"zhatBot/internal/domain"
+)
+
+// memoryNotes hace de la tabla user_notes.
+type memoryNotes struct {
+	mu     sync.Mutex
+	nextID int64
+	notes  []*domain.UserNote
+}
+
+func (m *memoryNotes) ListUserNotes(_ context.Context, platform domain.Platform, userID string) ([]*domain.UserNote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*domain.UserNote
+	for _, note := range m.notes {
+		if note.Platform == platform && note.UserID == userID {
+			copied := *note
+			out = append(out, &copied)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryNotes) ListNotedUsers(context.Context) ([]domain.NotedUser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	index := make(map[userKey]int)
+	var out []domain.NotedUser
+	for _, note := range m.notes {
+		k := userKey{platform: note.Platform, userID: note.UserID}
+		i, ok := index[k]
+		if !ok {
+			i = len(out)
+			index[k] = i
+			out = append(out, domain.NotedUser{Platform: note.Platform, UserID: note.UserID})
+		}
+		out[i].Notes++
+		if note.Login != "" {
+			out[i].Login = note.Login
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryNotes) AddUserNote(_ context.Context, note *domain.UserNote) (*domain.UserNote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	saved := *note
+	saved.ID = m.nextID
+	m.notes = append(m.notes, &saved)
+	copied := saved
+	return &copied, nil
+}
+
+func (m *memoryNotes) DeleteUserNote(_ context.Context, platform domain.Platform, userID string, id int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, note := range m.notes {
+		if note.ID == id && note.Platform == platform && note.UserID == userID {
+			m.notes = append(m.notes[:i], m.notes[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type change struct {
+	userID string
+	notes  int
+}
+
+func newTestService(t *testing.T, repo *memoryNotes) (*Service, *[]change) {
+	t.Helper()
+	svc := NewService(repo)
+	if err := svc.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	var changes []change
+	svc.SetChangeHandler(func(_ domain.Platform, userID string, notes int) {
+		changes = append(changes, change{userID: userID, notes: notes})
+	})
+	return svc, &changes
+}
+
+func note(userID, login, text string) domain.UserNote {
+	return domain.UserNote{Platform: domain.PlatformTwitch, UserID: userID, Login: login, Note: text, Author: "mod"}
+}
+
+func TestHasNotesFollowsAddAndDelete(t *testing.T) {
+	ctx := context.Background()
+	svc, changes := newTestService(t, &memoryNotes{})
+
+	if svc.HasNotes(domain.PlatformTwitch, "42") {
+		t.Fatal("el usuario tiene notas antes de agregarle una")
+	}
+	first, err := svc.Add(ctx, note("42", "@Ana", "avisada por spoilers"))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	second, err := svc.Add(ctx, note("42", "ana", "otra vez"))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if !svc.HasNotes(domain.PlatformTwitch, "42") {
+		t.Fatal("HasNotes = false después de agregar")
+	}
+	if svc.HasNotes(domain.PlatformKick, "42") {
+		t.Fatal("la marca se filtró a otra plataforma")
+	}
+	if first.Login != "ana" || first.CreatedAt.IsZero() {
+		t.Fatalf("nota guardada = %+v", first)
+	}
+
+	if err := svc.Delete(ctx, domain.PlatformTwitch, "42", first.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if !svc.HasNotes(domain.PlatformTwitch, "42") {
+		t.Fatal("se desmarcó con una nota todavía guardada")
+	}
+	if err := svc.Delete(ctx, domain.PlatformTwitch, "42", second.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if svc.HasNotes(domain.PlatformTwitch, "42") {
+		t.Fatal("sigue marcado sin notas")
+	}
+	if _, ok := svc.ResolveUser(domain.PlatformTwitch, "ana"); ok {
+		t.Fatal("el login sigue resolviendo después de borrar la última nota")
+	}
+
+	want := []change{{"42", 1}, {"42", 2}, {"42", 1}, {"42", 0}}
+	if len(*changes) != len(want) {
+		t.Fatalf("changes = %v, esperaba %v", *changes, want)
+	}
+	for i := range want {
+		if (*changes)[i] != want[i] {
+			t.Fatalf("changes = %v, esperaba %v", *changes, want)
+		}
+	}
+}
+
+func TestLoadWarmsNotedUsers(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryNotes{}
+	svc, _ := newTestService(t, repo)
+	if _, err := svc.Add(ctx, note("42", "ana", "avisada")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// "reinicio": un servicio nuevo arma el conjunto desde la base
+	restarted, _ := newTestService(t, repo)
+	if !restarted.HasNotes(domain.PlatformTwitch, "42") {
+		t.Fatal("Load no marcó al usuario con notas")
+	}
+	if userID, ok := restarted.ResolveUser(domain.PlatformTwitch, "@ANA"); !ok || userID != "42" {
+		t.Fatalf("ResolveUser = %q, %v", userID, ok)
+	}
+}
+
+func TestDeleteUnknownNote(t *testing.T) {
+	ctx := context.Background()
+	svc, changes := newTestService(t, &memoryNotes{})
+	saved, err := svc.Add(ctx, note("42", "ana", "avisada"))
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// la nota existe pero es de otro usuario
+	if err := svc.Delete(ctx, domain.PlatformTwitch, "7", saved.ID); !errors.Is(err, ErrNoteNotFound) {
+		t.Fatalf("err = %v, esperaba ErrNoteNotFound", err)
+	}
+	if !svc.HasNotes(domain.PlatformTwitch, "42") || len(*changes) != 1 {
+		t.Fatal("un borrado fallido cambió las marcas")
+	}
+}
+
+func TestAddValidates(t *testing.T) {
+	svc, changes := newTestService(t, &memoryNotes{})
+	tests := []struct {
+		note domain.UserNote
+		want error
+	}{
+		{note(" ", "ana", "hola"), ErrUnknownUser},
+		{note("42", "ana", "   "), ErrEmptyNote},
+		{note("42", "ana", strings.Repeat("é", MaxNoteLength+1)), ErrNoteTooLong},
+	}
+	for _, tt := range tests {
+		if _, err := svc.Add(context.Background(), tt.note); !errors.Is(err, tt.want) {
+			t.Errorf("Add(%q) = %v, esperaba %v", tt.note.Note, err, tt.want)
+		}
+	}
+	if _, err := svc.Add(context.Background(), note("42", "ana", strings.Repeat("é", MaxNoteLength))); err != nil {
+		t.Errorf("una nota de %d runas se rechazó: %v", MaxNoteLength, err)
+	}
+	if len(*changes) != 1 {
+		t.Fatalf("changes = %v", *changes)
+	}
+}
+
+func TestObserveResolvesChatters(t *testing.T) {
+	svc, _ := newTestService(t, &memoryNotes{})
+	svc.Observe(domain.Message{Platform: domain.PlatformKick, UserID: "9", Username: "Beto", Login: "beto"})
+
+	if userID, ok := svc.ResolveUser(domain.PlatformKick, "@Beto"); !ok || userID != "9" {
+		t.Fatalf("ResolveUser = %q, %v", userID, ok)
+	}
+	if _, ok := svc.ResolveUser(domain.PlatformTwitch, "beto"); ok {
+		t.Fatal("el login se resolvió en otra plataforma")
+	}
+}
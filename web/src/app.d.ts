@@ -11,6 +11,7 @@ declare global {
 
 	interface ImportMetaEnv {
 		readonly VITE_CHAT_WS_URL?: string;
+		readonly VITE_CHAT_WS_TOKEN?: string;
 		readonly VITE_API_BASE_URL?: string;
 	}
 }
@@ -2,6 +2,7 @@ declare global {
 	interface Window {
 		__CONFIG__?: {
 			WS_URL?: string;
+			WS_TOKEN?: string;
 			API_BASE_URL?: string;
 		};
 	}
@@ -11,4 +12,7 @@ const cfg = typeof window !== 'undefined' ? (window.__CONFIG__ ?? {}) : {};
 
 export const WS_URL = cfg.WS_URL ?? import.meta.env.VITE_CHAT_WS_URL;
 
+// WS_TOKEN es el CHAT_PAGE_TOKEN del bot: sin él /ws/chat solo deja leer.
+export const WS_TOKEN = cfg.WS_TOKEN ?? import.meta.env.VITE_CHAT_WS_TOKEN;
+
 export const API_BASE_URL = cfg.API_BASE_URL ?? import.meta.env.VITE_API_BASE_URL;
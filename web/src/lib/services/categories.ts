@@ -45,3 +45,37 @@ export const updateCategory = async (platform: Platform, name: string): Promise<
 		throw new Error(`Update failed ${response.status}`);
 	}
 };
+
+export type StreamSyncSettings = {
+	enabled: boolean;
+	title: boolean;
+	category: boolean;
+};
+
+export const getStreamSyncSettings = async (): Promise<StreamSyncSettings> => {
+	if (isWails()) {
+		return await callWailsBinding<StreamSyncSettings>('Category_GetSyncSettings');
+	}
+	const response = await fetch(`${baseUrl}/api/categories/sync`);
+	if (!response.ok) {
+		throw new Error(`Load failed ${response.status}`);
+	}
+	return (await response.json()) as StreamSyncSettings;
+};
+
+export const saveStreamSyncSettings = async (
+	settings: StreamSyncSettings
+): Promise<StreamSyncSettings> => {
+	if (isWails()) {
+		return await callWailsBinding<StreamSyncSettings>('Category_SetSyncSettings', settings);
+	}
+	const response = await fetch(`${baseUrl}/api/categories/sync`, {
+		method: 'PUT',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify(settings)
+	});
+	if (!response.ok) {
+		throw new Error(`Save failed ${response.status}`);
+	}
+	return (await response.json()) as StreamSyncSettings;
+};
@@ -1,9 +1,22 @@
 import { browser } from '$app/environment';
 import { readable, type Readable } from 'svelte/store';
-import type { ChatCommandPayload, ChatMessage, ChatStreamStatus } from '$lib/types/chat';
-import { WS_URL } from '$lib/config';
+import type {
+	ChatCommandPayload,
+	ChatDeleteEvent,
+	ChatMessage,
+	ChatStreamStatus,
+	LinkPreviewEvent
+} from '$lib/types/chat';
+import { WS_TOKEN, WS_URL } from '$lib/config';
 import { ttsQueue, type TTSEvent } from '$lib/stores/tts';
-import { isWails, onChatMessage, callWailsBinding } from '$lib/wails/adapter';
+import {
+	isWails,
+	onChatDelete,
+	onChatMessage,
+	onLinkPreview,
+	onUserNotes,
+	callWailsBinding
+} from '$lib/wails/adapter';
 
 interface ChatStreamOptions {
 	url?: string;
@@ -29,7 +42,7 @@ export const createChatStream = (options: ChatStreamOptions = {}): Readable<Chat
 		if (!browser) return;
 
 		const maxMessages = options.maxMessages ?? 200;
-		const url = normalizeWsUrl(options.url ?? WS_URL);
+		const url = withToken(normalizeWsUrl(options.url ?? WS_URL));
 		let status: ChatStreamStatus = 'connecting';
 		let messages: ChatMessage[] = [];
 		let socket: WebSocket | undefined;
@@ -45,6 +58,30 @@ export const createChatStream = (options: ChatStreamOptions = {}): Readable<Chat
 			messages = [enriched, ...messages].slice(-maxMessages);
 			update();
 		};
+		const attachPreview = (event: LinkPreviewEvent) => {
+			let changed = false;
+			messages = messages.map((message) => {
+				if (!message.id || message.id !== event.message_id) return message;
+				const previews = message.link_previews ?? [];
+				if (previews.some((preview) => preview.url === event.preview.url)) return message;
+				changed = true;
+				return { ...message, link_previews: [...previews, event.preview] };
+			});
+			if (changed) update();
+		};
+		const removeDeleted = (event: ChatDeleteEvent) => {
+			const before = messages.length;
+			messages = messages.filter((message) => !matchesDeletion(message, event));
+			if (messages.length !== before) update();
+		};
+		const markNotes = (platform: string, userId: string, hasNotes: boolean) => {
+			messages = messages.map((message) =>
+				message.platform === platform && message.user_id === userId
+					? { ...message, has_notes: hasNotes }
+					: message
+			);
+			update();
+		};
 
 		if (options.useMockFeed) {
 			const stopMock = startMockFeed(push);
@@ -59,6 +96,41 @@ export const createChatStream = (options: ChatStreamOptions = {}): Readable<Chat
 			status = 'connecting';
 			update();
 			let unsub: (() => void) | undefined;
+			let unsubPreview: (() => void) | undefined;
+			let unsubNotes: (() => void) | undefined;
+			let unsubDelete: (() => void) | undefined;
+			onChatDelete((payload) => {
+				const event = normalizeChatDeleteEvent(payload);
+				if (event) removeDeleted(event);
+			})
+				.then((off) => {
+					unsubDelete = off;
+				})
+				.catch((error) => {
+					console.error('[chat-stream] No se pudo suscribir a los borrados', error);
+				});
+			onUserNotes((payload) => {
+				if (!isPlainObject(payload)) return;
+				const platform = getStringField(payload, 'platform');
+				const userId = getStringField(payload, 'user_id');
+				if (platform && userId) markNotes(platform, userId, getBooleanField(payload, 'has_notes'));
+			})
+				.then((off) => {
+					unsubNotes = off;
+				})
+				.catch((error) => {
+					console.error('[chat-stream] No se pudo suscribir a las notas', error);
+				});
+			onLinkPreview((payload) => {
+				const event = normalizeLinkPreviewEvent(payload);
+				if (event) attachPreview(event);
+			})
+				.then((off) => {
+					unsubPreview = off;
+				})
+				.catch((error) => {
+					console.error('[chat-stream] No se pudo suscribir a las vistas previas', error);
+				});
 			onChatMessage((payload) => {
 				try {
 					const normalized = normalizeMessagePayload(payload);
@@ -80,6 +152,9 @@ export const createChatStream = (options: ChatStreamOptions = {}): Readable<Chat
 
 			return () => {
 				unsub?.();
+				unsubPreview?.();
+				unsubNotes?.();
+				unsubDelete?.();
 				status = 'disconnected';
 				update();
 			};
@@ -147,6 +222,20 @@ export const createChatStream = (options: ChatStreamOptions = {}): Readable<Chat
 			socket.addEventListener('message', (event) => {
 				try {
 					const parsed = JSON.parse(event.data) as unknown;
+					if (isPlainObject(parsed) && parsed.type === 'chat:link-preview') {
+						const preview = normalizeLinkPreviewEvent(parsed.data);
+						if (preview) attachPreview(preview);
+						return;
+					}
+					if (isPlainObject(parsed) && parsed.type === 'error') {
+						console.error('[chat-stream] El servidor rechazó el mensaje', parsed.data);
+						return;
+					}
+					if (isPlainObject(parsed) && parsed.type === 'chat:delete') {
+						const deletion = normalizeChatDeleteEvent(parsed.data);
+						if (deletion) removeDeleted(deletion);
+						return;
+					}
 					if (handleAppEvent(parsed)) {
 						return;
 					}
@@ -238,16 +327,22 @@ export const normalizeMessagePayload = (payload: unknown): ChatMessage => {
 		getStringField(source, 'received_at', 'ReceivedAt', 'receivedAt') || new Date().toISOString();
 
 	return {
+		id: getStringField(source, 'id', 'ID') || undefined,
+		platform_message_id: getStringField(source, 'platform_message_id', 'PlatformMessageID') || undefined,
+		login: getStringField(source, 'login', 'Login') || undefined,
 		platform,
 		channel_id: channel_id || '#unknown',
 		user_id: user_id || crypto.randomUUID(),
 		username,
 		text,
+		truncated: getBooleanField(source, 'truncated', 'Truncated') || undefined,
 		is_private: getBooleanField(source, 'is_private', 'IsPrivate'),
 		is_platform_owner: getBooleanField(source, 'is_platform_owner', 'IsPlatformOwner'),
 		is_platform_admin: getBooleanField(source, 'is_platform_admin', 'IsPlatformAdmin'),
 		is_platform_mod: getBooleanField(source, 'is_platform_mod', 'IsPlatformMod'),
 		is_platform_vip: getBooleanField(source, 'is_platform_vip', 'IsPlatformVip'),
+		bot_paused: getBooleanField(source, 'bot_paused', 'BotPaused'),
+		has_notes: getBooleanField(source, 'has_notes', 'HasNotes'),
 		received_at
 	};
 };
@@ -293,6 +388,18 @@ const normalizeWsUrl = (input?: string) => {
 	return input;
 };
 
+// withToken agrega el token del bot a la URL; sin él la conexión solo lee.
+const withToken = (input?: string) => {
+	if (!input || !WS_TOKEN) return input;
+	try {
+		const parsed = new URL(input);
+		parsed.searchParams.set('token', WS_TOKEN);
+		return parsed.toString();
+	} catch {
+		return input;
+	}
+};
+
 const handleAppEvent = (payload: unknown): boolean => {
 	if (!isPlainObject(payload)) {
 		return false;
@@ -310,6 +417,60 @@ const handleAppEvent = (payload: unknown): boolean => {
 	return false;
 };
 
+const normalizeLinkPreviewEvent = (data: unknown): LinkPreviewEvent | null => {
+	if (!isPlainObject(data) || !isPlainObject(data.preview)) return null;
+	const messageId = getStringField(data, 'message_id');
+	const preview = data.preview;
+	const url = getStringField(preview, 'url');
+	const title = getStringField(preview, 'title');
+	if (!messageId || !url || !title) return null;
+	return {
+		message_id: messageId,
+		platform: getStringField(data, 'platform'),
+		channel_id: getStringField(data, 'channel_id'),
+		preview: {
+			url,
+			domain: getStringField(preview, 'domain'),
+			title,
+			description: getStringField(preview, 'description') || undefined,
+			image_url: getStringField(preview, 'image_url') || undefined,
+			site_name: getStringField(preview, 'site_name') || undefined,
+			fetched_at: getStringField(preview, 'fetched_at')
+		}
+	};
+};
+
+const normalizeChatDeleteEvent = (data: unknown): ChatDeleteEvent | null => {
+	if (!isPlainObject(data)) return null;
+	const kind = getStringField(data, 'kind');
+	if (kind !== 'message' && kind !== 'user' && kind !== 'chat') return null;
+	return {
+		platform: getStringField(data, 'platform'),
+		channel_id: getStringField(data, 'channel_id'),
+		kind,
+		platform_message_id: getStringField(data, 'platform_message_id') || undefined,
+		user_id: getStringField(data, 'user_id') || undefined,
+		login: getStringField(data, 'login') || undefined
+	};
+};
+
+// matchesDeletion decide si un mensaje del historial cae dentro del borrado.
+const matchesDeletion = (message: ChatMessage, event: ChatDeleteEvent) => {
+	if (message.platform !== event.platform) return false;
+	switch (event.kind) {
+		case 'message':
+			return (
+				Boolean(event.platform_message_id) &&
+				message.platform_message_id === event.platform_message_id
+			);
+		case 'user':
+			if (event.user_id) return message.user_id === event.user_id;
+			return Boolean(event.login) && message.login?.toLowerCase() === event.login?.toLowerCase();
+		case 'chat':
+			return message.channel_id === event.channel_id;
+	}
+};
+
 const normalizeTTSEvent = (data: unknown): TTSEvent => {
 	if (!isPlainObject(data)) {
 		console.warn('[chat-stream] Evento TTS sin payload válido', data);
@@ -8,13 +8,19 @@ export type StreamStatusRecord = {
 	viewer_count?: number;
 	url?: string;
 	started_at?: string;
+	fetched_at?: string;
+	source?: string;
 };
 
-export const fetchStreamStatuses = async (): Promise<StreamStatusRecord[]> => {
+// refresh saltea la caché del backend (por defecto se reutiliza un estado de
+// hace unos segundos).
+export const fetchStreamStatuses = async (refresh = false): Promise<StreamStatusRecord[]> => {
 	if (isWails()) {
-		return await callWailsBinding<StreamStatusRecord[]>('StreamStatus_List');
+		return await callWailsBinding<StreamStatusRecord[]>(
+			refresh ? 'StreamStatus_Refresh' : 'StreamStatus_List'
+		);
 	}
-	const response = await fetch('/api/streams/status', {
+	const response = await fetch(refresh ? '/api/streams/status?refresh=1' : '/api/streams/status', {
 		headers: {
 			Accept: 'application/json'
 		}
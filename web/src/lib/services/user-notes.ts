@@ -0,0 +1,50 @@
+import { API_BASE_URL } from '$lib/config';
+import { isWails, callWailsBinding } from '$lib/wails/adapter';
+import type { UserNote } from '$lib/types/chat';
+
+const baseUrl = API_BASE_URL ?? 'http://localhost:8080';
+
+const notesUrl = (platform: string, userId: string) =>
+	`${baseUrl}/api/users/${encodeURIComponent(platform)}/${encodeURIComponent(userId)}/notes`;
+
+export const listUserNotes = async (platform: string, userId: string): Promise<UserNote[]> => {
+	if (isWails()) {
+		return (await callWailsBinding<UserNote[] | null>('Users_ListNotes', platform, userId)) ?? [];
+	}
+	const response = await fetch(notesUrl(platform, userId));
+	if (!response.ok) {
+		throw new Error(`Notes failed ${response.status}`);
+	}
+	return (await response.json()) as UserNote[];
+};
+
+export const addUserNote = async (
+	platform: string,
+	userId: string,
+	login: string,
+	note: string
+): Promise<UserNote> => {
+	if (isWails()) {
+		return await callWailsBinding<UserNote>('Users_AddNote', platform, userId, login, note);
+	}
+	const response = await fetch(notesUrl(platform, userId), {
+		method: 'POST',
+		headers: { 'Content-Type': 'application/json' },
+		body: JSON.stringify({ login, note })
+	});
+	if (!response.ok) {
+		throw new Error(`Add note failed ${response.status}`);
+	}
+	return (await response.json()) as UserNote;
+};
+
+export const deleteUserNote = async (platform: string, userId: string, id: number): Promise<void> => {
+	if (isWails()) {
+		await callWailsBinding('Users_DeleteNote', platform, userId, id);
+		return;
+	}
+	const response = await fetch(`${notesUrl(platform, userId)}/${id}`, { method: 'DELETE' });
+	if (!response.ok) {
+		throw new Error(`Delete note failed ${response.status}`);
+	}
+};
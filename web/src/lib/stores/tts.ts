@@ -10,6 +10,7 @@ export type TTSEvent = {
 	channel_id: string;
 	timestamp: string;
 	audio_base64?: string;
+	audio_id?: string;
 };
 
 const createQueue = () => {
@@ -1,17 +1,75 @@
 export interface ChatMessage {
+	id?: string;
+	platform_message_id?: string;
 	platform: string;
 	channel_id: string;
 	user_id: string;
 	username: string;
+	login?: string;
 	text: string;
+	// truncated: el texto llegó más largo que el máximo y se recortó.
+	truncated?: boolean;
 	is_private: boolean;
 	is_platform_owner: boolean;
 	is_platform_admin: boolean;
 	is_platform_mod: boolean;
 	is_platform_vip: boolean;
+	is_verified?: boolean;
+	avatar_url?: string;
+	bot_paused?: boolean;
+	has_notes?: boolean;
+	link_previews?: LinkPreview[];
 	received_at?: string;
 }
 
+export interface UserNote {
+	id: number;
+	platform: string;
+	user_id: string;
+	login?: string;
+	note: string;
+	author: string;
+	created_at: string;
+}
+
+export interface LinkPreview {
+	url: string;
+	domain: string;
+	title: string;
+	description?: string;
+	image_url?: string;
+	site_name?: string;
+	fetched_at: string;
+}
+
+// LinkPreviewEvent llega después del mensaje (chat:link-preview).
+export interface LinkPreviewEvent {
+	message_id: string;
+	platform: string;
+	channel_id: string;
+	preview: LinkPreview;
+}
+
+// ChatDeleteEvent llega cuando la moderación borra mensajes (chat:delete).
+export interface ChatDeleteEvent {
+	platform: string;
+	channel_id: string;
+	kind: 'message' | 'user' | 'chat';
+	platform_message_id?: string;
+	user_id?: string;
+	login?: string;
+}
+
+export interface UserProfile {
+	platform: string;
+	user_id: string;
+	login?: string;
+	display_name?: string;
+	avatar_url?: string;
+	verified: boolean;
+	fetched_at: string;
+}
+
 export type ChatStreamStatus = 'connecting' | 'connected' | 'disconnected';
 
 export interface ChatCommandPayload {
@@ -6,9 +6,15 @@ export type CommandAccessRole =
 	| 'vips'
 	| 'owner';
 
+export type WeightedResponse = {
+	text: string;
+	weight: number;
+};
+
 export type CommandRecord = {
 	name: string;
 	response: string;
+	responses?: WeightedResponse[];
 	aliases: string[];
 	platforms: string[];
 	permissions: CommandAccessRole[];
@@ -17,11 +23,14 @@ export type CommandRecord = {
 	editable?: boolean;
 	description?: string;
 	usage?: string;
+	status?: 'active' | 'inactive_platform_unavailable';
+	warnings?: string[];
 };
 
 export type CommandPayload = {
 	name: string;
 	response?: string;
+	responses?: WeightedResponse[];
 	aliases?: string[];
 	platforms?: string[];
 	permissions?: CommandAccessRole[];
@@ -3,6 +3,7 @@ export type NotificationType =
 	| 'donation'
 	| 'bits'
 	| 'giveaway_winner'
+	| 'follow'
 	| 'generic';
 
 export type NotificationRecord = {
@@ -55,6 +55,57 @@ export const onHeartbeat = (callback: (payload: unknown) => void) =>
 export const onChatMessage = (callback: (payload: unknown) => void) =>
 	subscribeToEvent('chat:message', callback);
 
+export const onUserEnriched = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('user:enriched', callback);
+
+export const onBusGap = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('bus:gap', callback);
+
+export const onTwitchRateLimited = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('twitch:ratelimited', callback);
+
+export const onStreamUnhealthy = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('stream:unhealthy', callback);
+
+export const onLinkPreview = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('chat:link-preview', callback);
+
+export const onUserNotes = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('user:notes', callback);
+
+export const onBotPaused = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('app:paused', callback);
+
+export const onCommandPrefix = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('app:prefix', callback);
+
+export const onTwitchAccount = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('twitch:account', callback);
+
+export const onBotAway = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('app:away', callback);
+
+export const onCountdownTick = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('countdown:tick', callback);
+
+export const onCounterUpdate = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('counter:update', callback);
+
+export const onAutoShoutout = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('shoutout:auto', callback);
+
+export const onReturningViewer = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('chat:returning-viewer', callback);
+
+export const onChatDelete = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('chat:delete', callback);
+
+export const onKickChatStatus = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('kick:chat:status', callback);
+
+export const onBotConflict = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('bots:conflict', callback);
+
 export const onCommandsChanged = (callback: (payload: unknown) => void) =>
 	subscribeToEvent('commands:changed', callback);
 
@@ -75,10 +126,15 @@ export const callWailsBinding = async <T>(method: string, ...args: unknown[]): P
 	return bridge[method](...args) as Promise<T>;
 };
 
+export const kickGetChatStatus = () => callWailsBinding('Kick_ChatStatus');
+
 export const ttsGetRunnerStatus = () => callWailsBinding('TTS_GetStatus');
 export const ttsEnqueue = (text: string, voice: string, lang: string, rate: number, volume: number) =>
 	callWailsBinding('TTS_Enqueue', text, voice, lang, rate, volume);
 export const ttsStopAll = () => callWailsBinding('TTS_StopAll');
+export const ttsGetAudio = (id: string) => callWailsBinding<string>('TTS_GetAudio', id);
+export const ttsHistory = (limit = 50, user = '') =>
+	callWailsBinding<Record<string, any>[]>('TTS_History', limit, user);
 export const ttsGetSettings = () => callWailsBinding('TTS_GetSettings');
 export const ttsUpdateSettings = (payload: { voice?: string; enabled?: boolean }) =>
 	callWailsBinding('TTS_UpdateSettings', payload);
@@ -88,8 +144,17 @@ export const oauthStart = (platform: string, role: string) =>
 export const oauthStatus = () => callWailsBinding<Record<string, any>>('OAuth_Status');
 export const oauthLogout = (platform: string, role: string) =>
 	callWailsBinding<void>('OAuth_Logout', platform, role);
+export const oauthRefresh = (platform: string, role: string) =>
+	callWailsBinding<unknown[]>('OAuth_Refresh', platform, role);
 export const configSetTwitchSecret = (secret: string) =>
 	callWailsBinding<void>('Config_SetTwitchSecret', secret);
+export const configFixRedirectURIs = () => callWailsBinding<string>('Config_FixRedirectURIs');
+
+export const onLegacyRedirect = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('config:legacy-redirect', callback);
+
+export const onOAuthReauthRequired = (callback: (payload: unknown) => void) =>
+	subscribeToEvent('oauth:reauth-required', callback);
 
 export const onOAuthComplete = (callback: (payload: unknown) => void) =>
 	subscribeToEvent('oauth:complete', callback);
@@ -186,3 +251,15 @@ export const useWailsAdapter = () => {
 		};
 	});
 };
+
+export type FileKind = 'sound' | 'backup' | 'commands';
+
+export interface PickedFile {
+	path: string;
+	name: string;
+	size: number;
+}
+
+export const dialogPickFile = (kind: FileKind) => callWailsBinding<PickedFile>('Dialog_PickFile', kind);
+export const dialogSaveFile = (kind: FileKind, defaultName = '') =>
+	callWailsBinding<string>('Dialog_SaveFile', kind, defaultName);